@@ -0,0 +1,30 @@
+package config
+
+import "time"
+
+type Config struct {
+	Server       Server                `yaml:"server"`
+	PostgreSQL   map[string]PostgreSQL `yaml:"postgresql"`
+	User         User                  `yaml:"user"`
+	Content      Content               `yaml:"content"`
+	Template     Template              `yaml:"template"`
+	Payment      Payment               `yaml:"payment"`
+	Media        Media                 `yaml:"media"`
+	School       School                `yaml:"school"`
+	SchoolConfig SchoolConfig          `yaml:"school_config"`
+}
+
+type Server struct {
+	Port int `yaml:"port"`
+}
+
+type PostgreSQL struct {
+	ConnectionString  string        `yaml:"connection_string"`
+	ConnectionTimeout time.Duration `yaml:"connection_timeout"`
+}
+
+// Followings are the known PostgreSQL key on config. Other
+// keys are the databases of schools.
+const (
+	PostgreSQLTenant string = "tenant"
+)
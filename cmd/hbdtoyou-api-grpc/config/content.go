@@ -0,0 +1,11 @@
+package config
+
+import configlib "hbdtoyou/pkg/config"
+
+type Content struct {
+	GRPC map[string]ContentGRPC `yaml:"grpc"`
+}
+
+type ContentGRPC struct {
+	Timeout configlib.Duration `yaml:"timeout"`
+}
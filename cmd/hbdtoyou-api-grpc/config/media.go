@@ -0,0 +1,59 @@
+package config
+
+import configlib "hbdtoyou/pkg/config"
+
+type Media struct {
+	Storage             MediaStorage       `yaml:"storage"`
+	MaxUploadSize       int64              `yaml:"max_upload_size"`
+	AllowedMIMETypes    []string           `yaml:"allowed_mime_types"`
+	SignedURLExpiration configlib.Duration `yaml:"signed_url_expiration"`
+	Image               MediaImage         `yaml:"image"`
+	Processing          MediaProcessing    `yaml:"processing"`
+}
+
+type MediaStorage struct {
+	Type      string                `yaml:"type"`
+	LocalFile MediaStorageLocalFile `yaml:"localfile"`
+	S3        MediaStorageS3        `yaml:"s3"`
+}
+
+type MediaStorageLocalFile struct {
+	RootDir    string `yaml:"root_dir"`
+	MountPath  string `yaml:"mount_path"`
+	BaseURL    string `yaml:"base_url"`
+	SigningKey string `yaml:"signing_key"`
+}
+
+type MediaStorageS3 struct {
+	Endpoint        string `yaml:"endpoint"`
+	Region          string `yaml:"region"`
+	Bucket          string `yaml:"bucket"`
+	AccessKeyID     string `yaml:"access_key_id"`
+	SecretAccessKey string `yaml:"secret_access_key"`
+	UseSSL          bool   `yaml:"use_ssl"`
+}
+
+// Followings are the known media storage types.
+const (
+	MediaStorageTypeLocalFile string = "localfile"
+	MediaStorageTypeS3        string = "s3"
+)
+
+type MediaImage struct {
+	Format    string              `yaml:"format"`
+	Quality   int                 `yaml:"quality"`
+	MaxPixels int                 `yaml:"max_pixels"`
+	Variants  []MediaImageVariant `yaml:"variants"`
+}
+
+type MediaImageVariant struct {
+	Name      string `yaml:"name"`
+	MaxWidth  int    `yaml:"max_width"`
+	MaxHeight int    `yaml:"max_height"`
+}
+
+type MediaProcessing struct {
+	Workers   int                `yaml:"workers"`
+	QueueSize int                `yaml:"queue_size"`
+	Timeout   configlib.Duration `yaml:"timeout"`
+}
@@ -0,0 +1,30 @@
+package config
+
+import configlib "hbdtoyou/pkg/config"
+
+type Payment struct {
+	Bundles      []PaymentBundle        `yaml:"bundles"`
+	Subscription PaymentSubscription    `yaml:"subscription"`
+	Review       PaymentReview          `yaml:"review"`
+	GRPC         map[string]PaymentGRPC `yaml:"grpc"`
+}
+
+type PaymentBundle struct {
+	ID       string `yaml:"id"`
+	Name     string `yaml:"name"`
+	Quota    int    `yaml:"quota"`
+	Price    int64  `yaml:"price"`
+	Currency string `yaml:"currency"`
+}
+
+type PaymentSubscription struct {
+	RenewalLead configlib.Duration `yaml:"renewal_lead"`
+}
+
+type PaymentReview struct {
+	ClaimDuration configlib.Duration `yaml:"claim_duration"`
+}
+
+type PaymentGRPC struct {
+	Timeout configlib.Duration `yaml:"timeout"`
+}
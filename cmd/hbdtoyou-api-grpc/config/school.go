@@ -0,0 +1,9 @@
+package config
+
+type School struct {
+	Tenant SchoolTenant `yaml:"tenant"`
+}
+
+type SchoolTenant struct {
+	Metadata string `yaml:"metadata"`
+}
@@ -0,0 +1,11 @@
+package config
+
+import configlib "hbdtoyou/pkg/config"
+
+type SchoolConfig struct {
+	Cache SchoolConfigCache `yaml:"cache"`
+}
+
+type SchoolConfigCache struct {
+	TTL configlib.Duration `yaml:"ttl"`
+}
@@ -0,0 +1,11 @@
+package config
+
+import configlib "hbdtoyou/pkg/config"
+
+type Template struct {
+	GRPC map[string]TemplateGRPC `yaml:"grpc"`
+}
+
+type TemplateGRPC struct {
+	Timeout configlib.Duration `yaml:"timeout"`
+}
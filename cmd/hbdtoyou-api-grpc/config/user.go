@@ -0,0 +1,15 @@
+package config
+
+import configlib "hbdtoyou/pkg/config"
+
+type User struct {
+	PasswordSalt    string              `yaml:"password_salt"`
+	TokenExpiration configlib.Duration  `yaml:"token_expiration"`
+	TokenSecretKey  string              `yaml:"token_secret_key"`
+	ClientID        string              `yaml:"client_id"`
+	GRPC            map[string]UserGRPC `yaml:"grpc"`
+}
+
+type UserGRPC struct {
+	Timeout configlib.Duration `yaml:"timeout"`
+}
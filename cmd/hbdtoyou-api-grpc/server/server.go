@@ -0,0 +1,522 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"hbdtoyou/cmd/hbdtoyou-api-grpc/config"
+	"hbdtoyou/internal/auth"
+	authgrpchandler "hbdtoyou/internal/auth/handler/grpc"
+	authservice "hbdtoyou/internal/auth/service"
+	authpgstore "hbdtoyou/internal/auth/store/postgresql"
+	"hbdtoyou/internal/content"
+	contentgrpchandler "hbdtoyou/internal/content/handler/grpc"
+	contentservice "hbdtoyou/internal/content/service"
+	contentpgstore "hbdtoyou/internal/content/store/postgresql"
+	"hbdtoyou/internal/entitlement"
+	entitlementservice "hbdtoyou/internal/entitlement/service"
+	entitlementpgstore "hbdtoyou/internal/entitlement/store/postgresql"
+	"hbdtoyou/internal/media"
+	mediaservice "hbdtoyou/internal/media/service"
+	mediapgstore "hbdtoyou/internal/media/store/postgresql"
+	"hbdtoyou/internal/payment"
+	paymentgrpchandler "hbdtoyou/internal/payment/handler/grpc"
+	paymentservice "hbdtoyou/internal/payment/service"
+	paymentpgstore "hbdtoyou/internal/payment/store/postgresql"
+	"hbdtoyou/internal/school"
+	schoolgrpchandler "hbdtoyou/internal/school/handler/grpc"
+	schoolservice "hbdtoyou/internal/school/service"
+	schoolpgstore "hbdtoyou/internal/school/store/postgresql"
+	"hbdtoyou/internal/schoolconfig"
+	schoolconfigservice "hbdtoyou/internal/schoolconfig/service"
+	schoolconfigpgstore "hbdtoyou/internal/schoolconfig/store/postgresql"
+	"hbdtoyou/internal/template"
+	templategrpchandler "hbdtoyou/internal/template/handler/grpc"
+	templateservice "hbdtoyou/internal/template/service"
+	templatepgstore "hbdtoyou/internal/template/store/postgresql"
+	configlib "hbdtoyou/pkg/config"
+	"hbdtoyou/pkg/graceful"
+	"hbdtoyou/pkg/imageproc"
+	"hbdtoyou/pkg/money"
+	pglib "hbdtoyou/pkg/postgresql"
+	secretlocalfile "hbdtoyou/pkg/secret/client/localfile"
+	"hbdtoyou/pkg/storage"
+	"io"
+	"log"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc"
+	"gopkg.in/yaml.v2"
+)
+
+// Following constants are the possible exit code returned
+// when running a server.
+const (
+	CodeSuccess = iota
+	CodeBadConfig
+	CodeFailServeGRPC
+)
+
+// Option contains available options to run the server.
+type Option struct {
+	SecretPath string
+	ConfigTest bool
+}
+
+// Run creates a server with the given Option and starts the
+// server.
+//
+// Run returns a status code suitable for os.Exit() argument.
+func Run(opt Option) int {
+	s, err := new(opt)
+	if err != nil {
+		return CodeBadConfig
+	}
+
+	// do not start server for config testing
+	if opt.ConfigTest {
+		return CodeSuccess
+	}
+
+	return s.start()
+}
+
+// server is the long-runnning application.
+//
+// It serves the same services as the HTTP server, background
+// jobs and event relays are left to the HTTP server.
+type server struct {
+	handlers     []handler
+	interceptors []grpc.UnaryServerInterceptor
+	config       config.Config
+
+	// closers are closed after the server is stopped, e.g.
+	// to wait for background jobs of a service.
+	closers []io.Closer
+}
+
+// handler provides mechanism to start gRPC handler. All gRPC
+// handlers must implements this interface.
+type handler interface {
+	Start(server *grpc.Server) error
+}
+
+// new creates and returns a new server.
+func new(opt Option) (*server, error) {
+	s := &server{}
+
+	// initialize secrets
+	var secrets map[string]string
+	{
+		client := secretlocalfile.New()
+		bytes, err := client.Fetch(context.Background(), opt.SecretPath)
+		if err != nil {
+			log.Printf("[memorify-api-grpc] failed to fetch secrets: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to fetch secrets: %s", err.Error())
+		}
+
+		err = yaml.Unmarshal(bytes, &secrets)
+		if err != nil {
+			log.Printf("[memorify-api-grpc] failed to unmarshal secrets: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to unmarshal secrets: %s", err.Error())
+		}
+	}
+
+	// initialize config
+	{
+		configPath, err := getConfigFilePath()
+		if err != nil {
+			log.Printf("[memorify-api-grpc] failed to get config filepath: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to get config filepath: %s", err.Error())
+		}
+
+		var cfg config.Config
+		err = configlib.ReadFile(configPath, &cfg, configlib.WithStrictParsing(), configlib.WithSecrets(secrets))
+		if err != nil {
+			log.Printf("[memorify-api-grpc] failed to read config: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to read config: %s", err.Error())
+		}
+		s.config = cfg
+	}
+
+	// end of config testing
+	if opt.ConfigTest {
+		return s, nil
+	}
+
+	// initilize postgresql client manager
+	var pgClientManager *pglib.ClientManager
+	{
+		// every configured database is a tenant a school can
+		// use, the default one is required
+		if _, ok := s.config.PostgreSQL[config.PostgreSQLTenant]; !ok {
+			log.Printf("[memorify-api-grpc] postgresql config not found for client name: %s\n", config.PostgreSQLTenant)
+			return nil, fmt.Errorf("postgresql config not found")
+		}
+
+		clientNames := make([]string, 0, len(s.config.PostgreSQL))
+		for clientName := range s.config.PostgreSQL {
+			clientNames = append(clientNames, clientName)
+		}
+		sort.Strings(clientNames)
+
+		var options []pglib.Option
+		for _, clientName := range clientNames {
+			cfg := s.config.PostgreSQL[clientName]
+			options = append(options, pglib.WithClientConfig(clientName, pglib.ClientConfig{
+				ConnectionString:  cfg.ConnectionString,
+				ConnectionTimeout: time.Duration(cfg.ConnectionTimeout),
+			}))
+		}
+
+		var err error
+		pgClientManager, err = pglib.NewClientManager(options...)
+		if err != nil {
+			log.Printf("[memorify-api-grpc] failed to initialize postgresql client manager: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize postgresql client manager: %s", err.Error())
+		}
+	}
+
+	// initialize postgresql router, the stores query the
+	// database of the school of a request
+	pgRouter, err := pglib.NewRouter(pgClientManager, config.PostgreSQLTenant)
+	if err != nil {
+		log.Printf("[memorify-api-grpc] failed to initialize postgresql router: %s\n", err.Error())
+		return nil, fmt.Errorf("failed to initialize postgresql router: %s", err.Error())
+	}
+
+	// initialize auth service
+	var authSvc auth.Service
+	{
+		pgStore, err := authpgstore.New(pgRouter)
+		if err != nil {
+			log.Printf("[auth-api-grpc] failed to initialize auth postgresql store: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize auth postgresql store: %s", err.Error())
+		}
+
+		svcOptions := []authservice.Option{}
+		svcOptions = append(svcOptions, authservice.WithConfig(authservice.Config{
+			PasswordSalt:    s.config.User.PasswordSalt,
+			TokenExpiration: time.Duration(s.config.User.TokenExpiration),
+			TokenSecretKey:  s.config.User.TokenSecretKey,
+			ClientID:        s.config.User.ClientID,
+		}))
+
+		authSvc, err = authservice.New(pgStore, svcOptions...)
+		if err != nil {
+			log.Printf("[auth-api-grpc] failed to initialize auth service: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize auth service: %s", err.Error())
+		}
+	}
+
+	// initialize school service
+	var schoolSvc school.Service
+	{
+		// schools are kept in the default database
+		pgDb, err := pgClientManager.GetDatabase(config.PostgreSQLTenant)
+		if err != nil {
+			log.Printf("[school-api-grpc] failed to get postgresql database: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to get postgresql database: %s", err.Error())
+		}
+
+		pgStore, err := schoolpgstore.New(pgDb)
+		if err != nil {
+			log.Printf("[school-api-grpc] failed to initialize school postgresql store: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize school postgresql store: %s", err.Error())
+		}
+
+		schoolSvc, err = schoolservice.New(pgStore, authSvc, schoolservice.WithConfig(schoolservice.Config{
+			DefaultTenant: pgRouter.GetDefaultTenant(),
+			Tenants:       pgRouter.GetTenants(),
+		}))
+		if err != nil {
+			log.Printf("[school-api-grpc] failed to initialize school service: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize school service: %s", err.Error())
+		}
+	}
+
+	// initialize school config service
+	var schoolConfigSvc schoolconfig.Service
+	{
+		// school configs are kept in the default database
+		pgDb, err := pgClientManager.GetDatabase(config.PostgreSQLTenant)
+		if err != nil {
+			log.Printf("[school-config-api-grpc] failed to get postgresql database: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to get postgresql database: %s", err.Error())
+		}
+
+		pgStore, err := schoolconfigpgstore.New(pgDb)
+		if err != nil {
+			log.Printf("[school-config-api-grpc] failed to initialize school config postgresql store: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize school config postgresql store: %s", err.Error())
+		}
+
+		schoolConfigSvc, err = schoolconfigservice.New(pgStore, authSvc, schoolSvc, schoolconfigservice.WithConfig(schoolconfigservice.Config{
+			SettingsCacheTTL: time.Duration(s.config.SchoolConfig.Cache.TTL),
+		}))
+		if err != nil {
+			log.Printf("[school-config-api-grpc] failed to initialize school config service: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize school config service: %s", err.Error())
+		}
+	}
+
+	// initialize storage client
+	var storageClient storage.Client
+	{
+		var err error
+		storageClient, err = newStorageClient(s.config.Media.Storage)
+		if err != nil {
+			log.Printf("[memorify-api-grpc] failed to initialize storage client: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize storage client: %s", err.Error())
+		}
+	}
+
+	// initialize media service
+	var mediaSvc media.Service
+	{
+		pgStore, err := mediapgstore.New(pgRouter)
+		if err != nil {
+			log.Printf("[media-api-grpc] failed to initialize media postgresql store: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize media postgresql store: %s", err.Error())
+		}
+
+		var variants []mediaservice.VariantConfig
+		for _, v := range s.config.Media.Image.Variants {
+			variants = append(variants, mediaservice.VariantConfig{
+				Name:      v.Name,
+				MaxWidth:  v.MaxWidth,
+				MaxHeight: v.MaxHeight,
+			})
+		}
+
+		svcOptions := []mediaservice.Option{}
+		svcOptions = append(svcOptions, mediaservice.WithConfig(mediaservice.Config{
+			MaxSize:             s.config.Media.MaxUploadSize,
+			AllowedMIMETypes:    s.config.Media.AllowedMIMETypes,
+			SignedURLExpiration: time.Duration(s.config.Media.SignedURLExpiration),
+			ImageFormat:         imageproc.Format(s.config.Media.Image.Format),
+			ImageQuality:        s.config.Media.Image.Quality,
+			MaxPixels:           s.config.Media.Image.MaxPixels,
+			Variants:            variants,
+			Workers:             s.config.Media.Processing.Workers,
+			QueueSize:           s.config.Media.Processing.QueueSize,
+			ProcessTimeout:      time.Duration(s.config.Media.Processing.Timeout),
+		}))
+
+		svc, err := mediaservice.New(pgStore, storageClient, svcOptions...)
+		if err != nil {
+			log.Printf("[media-api-grpc] failed to initialize media service: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize media service: %s", err.Error())
+		}
+		mediaSvc = svc
+		s.closers = append(s.closers, svc)
+	}
+
+	// initialize template service
+	var templateSvc template.Service
+	{
+		pgStore, err := templatepgstore.New(pgRouter)
+		if err != nil {
+			log.Printf("[template-api-grpc] failed to initialize template postgresql store: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize template postgresql store: %s", err.Error())
+		}
+
+		templateSvc, err = templateservice.New(pgStore, mediaSvc, authSvc)
+		if err != nil {
+			log.Printf("[template-api-grpc] failed to initialize template service: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize template service: %s", err.Error())
+		}
+	}
+
+	// initialize entitlement service
+	var entitlementSvc entitlement.Service
+	{
+		pgStore, err := entitlementpgstore.New(pgRouter)
+		if err != nil {
+			log.Printf("[entitlement-api-grpc] failed to initialize entitlement postgresql store: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize entitlement postgresql store: %s", err.Error())
+		}
+
+		entitlementSvc, err = entitlementservice.New(pgStore)
+		if err != nil {
+			log.Printf("[entitlement-api-grpc] failed to initialize entitlement service: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize entitlement service: %s", err.Error())
+		}
+	}
+
+	// initialize content service
+	var contentSvc content.Service
+	{
+		pgStore, err := contentpgstore.New(pgRouter)
+		if err != nil {
+			log.Printf("[content-api-grpc] failed to initialize content postgresql store: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize content postgresql store: %s", err.Error())
+		}
+
+		contentSvc, err = contentservice.New(pgStore, authSvc, templateSvc, mediaSvc, entitlementSvc, schoolConfigSvc)
+		if err != nil {
+			log.Printf("[content-api-grpc] failed to initialize content service: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize content service: %s", err.Error())
+		}
+	}
+
+	// initialize payment service
+	var paymentSvc payment.Service
+	{
+		pgStore, err := paymentpgstore.New(pgRouter)
+		if err != nil {
+			log.Printf("[payment-api-grpc] failed to initialize payment postgresql store: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize payment postgresql store: %s", err.Error())
+		}
+
+		var bundles []payment.Bundle
+		for _, b := range s.config.Payment.Bundles {
+			bundles = append(bundles, payment.Bundle{
+				ID:    b.ID,
+				Name:  b.Name,
+				Quota: b.Quota,
+				Price: money.Money{
+					Amount:   b.Price,
+					Currency: money.NormalizeCurrency(b.Currency),
+				},
+			})
+		}
+
+		svcOptions := []paymentservice.Option{}
+		svcOptions = append(svcOptions, paymentservice.WithConfig(paymentservice.Config{
+			Bundles:       bundles,
+			RenewalLead:   time.Duration(s.config.Payment.Subscription.RenewalLead),
+			ClaimDuration: time.Duration(s.config.Payment.Review.ClaimDuration),
+		}))
+
+		paymentSvc, err = paymentservice.New(pgStore, authSvc, contentSvc, templateSvc, mediaSvc, entitlementSvc, svcOptions...)
+		if err != nil {
+			log.Printf("[payment-api-grpc] failed to initialize payment service: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize payment service: %s", err.Error())
+		}
+	}
+
+	// initialize school gRPC handler, it resolves the school of
+	// every request, so its interceptor comes first
+	{
+		schoolGRPC, err := schoolgrpchandler.New(schoolSvc, authSvc, schoolgrpchandler.WithTenantSetting(schoolgrpchandler.TenantSetting{
+			Metadata: s.config.School.Tenant.Metadata,
+		}))
+		if err != nil {
+			log.Printf("[school-api-grpc] failed to initialize school grpc handlers: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize school grpc handlers: %s", err.Error())
+		}
+
+		s.interceptors = append(s.interceptors, schoolGRPC.UnaryServerInterceptor())
+	}
+
+	// initialize auth gRPC handler, it also authenticates every
+	// request
+	{
+		var options []authgrpchandler.Option
+		for scopeName, cfg := range s.config.User.GRPC {
+			options = append(options, authgrpchandler.WithScopeSetting(scopeName, authgrpchandler.ScopeSetting{
+				Timeout: time.Duration(cfg.Timeout),
+			}))
+		}
+
+		authGRPC, err := authgrpchandler.New(authSvc, options...)
+		if err != nil {
+			log.Printf("[auth-api-grpc] failed to initialize auth grpc handlers: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize auth grpc handlers: %s", err.Error())
+		}
+
+		s.handlers = append(s.handlers, authGRPC)
+		s.interceptors = append(s.interceptors, authGRPC.UnaryServerInterceptor())
+	}
+
+	// initialize content gRPC handler
+	{
+		var options []contentgrpchandler.Option
+		for scopeName, cfg := range s.config.Content.GRPC {
+			options = append(options, contentgrpchandler.WithScopeSetting(scopeName, contentgrpchandler.ScopeSetting{
+				Timeout: time.Duration(cfg.Timeout),
+			}))
+		}
+
+		contentGRPC, err := contentgrpchandler.New(contentSvc, options...)
+		if err != nil {
+			log.Printf("[content-api-grpc] failed to initialize content grpc handlers: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize content grpc handlers: %s", err.Error())
+		}
+
+		s.handlers = append(s.handlers, contentGRPC)
+	}
+
+	// initialize template gRPC handler
+	{
+		var options []templategrpchandler.Option
+		for scopeName, cfg := range s.config.Template.GRPC {
+			options = append(options, templategrpchandler.WithScopeSetting(scopeName, templategrpchandler.ScopeSetting{
+				Timeout: time.Duration(cfg.Timeout),
+			}))
+		}
+
+		templateGRPC, err := templategrpchandler.New(templateSvc, options...)
+		if err != nil {
+			log.Printf("[template-api-grpc] failed to initialize template grpc handlers: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize template grpc handlers: %s", err.Error())
+		}
+
+		s.handlers = append(s.handlers, templateGRPC)
+	}
+
+	// initialize payment gRPC handler
+	{
+		var options []paymentgrpchandler.Option
+		for scopeName, cfg := range s.config.Payment.GRPC {
+			options = append(options, paymentgrpchandler.WithScopeSetting(scopeName, paymentgrpchandler.ScopeSetting{
+				Timeout: time.Duration(cfg.Timeout),
+			}))
+		}
+
+		paymentGRPC, err := paymentgrpchandler.New(paymentSvc, options...)
+		if err != nil {
+			log.Printf("[payment-api-grpc] failed to initialize payment grpc handlers: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize payment grpc handlers: %s", err.Error())
+		}
+
+		s.handlers = append(s.handlers, paymentGRPC)
+	}
+
+	return s, nil
+}
+
+// start starts the given server.
+func (s *server) start() int {
+	log.Println("[memorify-api-grpc] starting server...")
+
+	// interceptors run in the order they are added
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(s.interceptors...))
+
+	// starts handlers
+	for _, h := range s.handlers {
+		if err := h.Start(srv); err != nil {
+			log.Printf("[memorify-api-grpc] failed to start handler: %s\n", err.Error())
+			return CodeFailServeGRPC
+		}
+	}
+
+	// serve using graceful mechanism
+	address := fmt.Sprintf(":%d", s.config.Server.Port)
+	err := graceful.ServeGRPC(srv, address, 0)
+
+	// close resources after no more request is served
+	for _, c := range s.closers {
+		if err := c.Close(); err != nil {
+			log.Printf("[memorify-api-grpc] failed to close resource: %s\n", err.Error())
+		}
+	}
+
+	if err != nil {
+		log.Printf("[memorify-api-grpc] failed to start server: %s\n", err.Error())
+		return CodeFailServeGRPC
+	}
+
+	return CodeSuccess
+}
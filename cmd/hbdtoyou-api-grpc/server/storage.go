@@ -0,0 +1,45 @@
+package server
+
+import (
+	"fmt"
+	"hbdtoyou/cmd/hbdtoyou-api-grpc/config"
+	"hbdtoyou/pkg/storage"
+	storagelocalfile "hbdtoyou/pkg/storage/client/localfile"
+	storages3 "hbdtoyou/pkg/storage/client/s3"
+)
+
+// newStorageClient creates a storage client based on the
+// given storage config.
+//
+// Objects of storage that is not reachable from outside are
+// served by the HTTP server, so the signed URLs point to it.
+func newStorageClient(cfg config.MediaStorage) (storage.Client, error) {
+	switch cfg.Type {
+	case config.MediaStorageTypeLocalFile:
+		client, err := storagelocalfile.New(storagelocalfile.Config{
+			RootDir:    cfg.LocalFile.RootDir,
+			BaseURL:    cfg.LocalFile.BaseURL,
+			SigningKey: cfg.LocalFile.SigningKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+
+	case config.MediaStorageTypeS3:
+		client, err := storages3.New(storages3.Config{
+			Endpoint:        cfg.S3.Endpoint,
+			Region:          cfg.S3.Region,
+			Bucket:          cfg.S3.Bucket,
+			AccessKeyID:     cfg.S3.AccessKeyID,
+			SecretAccessKey: cfg.S3.SecretAccessKey,
+			UseSSL:          cfg.S3.UseSSL,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+
+	return nil, fmt.Errorf("unknown storage type: %s", cfg.Type)
+}
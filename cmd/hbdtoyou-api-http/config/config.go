@@ -3,13 +3,21 @@ package config
 import "time"
 
 type Config struct {
-	Server     Server                `yaml:"server"`
-	PostgreSQL map[string]PostgreSQL `yaml:"postgresql"`
-	Encryption Encryption            `yaml:"encryption"`
-	User       User                  `yaml:"user"`
-	Content    Content               `yaml:"content"`
-	Template   Template              `yaml:"template"`
-	Payment    Payment               `yaml:"payment"`
+	Server       Server                `yaml:"server"`
+	PostgreSQL   map[string]PostgreSQL `yaml:"postgresql"`
+	Encryption   Encryption            `yaml:"encryption"`
+	User         User                  `yaml:"user"`
+	Content      Content               `yaml:"content"`
+	Template     Template              `yaml:"template"`
+	Payment      Payment               `yaml:"payment"`
+	Media        Media                 `yaml:"media"`
+	Notification Notification          `yaml:"notification"`
+	Event        Event                 `yaml:"event"`
+	Webhook      Webhook               `yaml:"webhook"`
+	School       School                `yaml:"school"`
+	SchoolConfig SchoolConfig          `yaml:"school_config"`
+	RateLimit    RateLimit             `yaml:"rate_limit"`
+	Idempotency  Idempotency           `yaml:"idempotency"`
 }
 
 type Server struct {
@@ -22,7 +30,8 @@ type PostgreSQL struct {
 	ConnectionTimeout time.Duration `yaml:"connection_timeout"`
 }
 
-// Followings are the known PostgreSQL key on config
+// Followings are the known PostgreSQL key on config. Other
+// keys are the databases of schools.
 const (
 	PostgreSQLTenant string = "tenant"
 )
@@ -3,9 +3,16 @@ package config
 import configlib "hbdtoyou/pkg/config"
 
 type Content struct {
-	HTTP map[string]ContentHTTP `yaml:"http"`
+	Trash ContentTrash           `yaml:"trash"`
+	HTTP  map[string]ContentHTTP `yaml:"http"`
+}
+
+type ContentTrash struct {
+	Retention     configlib.Duration `yaml:"retention"`
+	PurgeInterval configlib.Duration `yaml:"purge_interval"`
 }
 
 type ContentHTTP struct {
-	Timeout configlib.Duration `yaml:"timeout"`
+	Timeout   configlib.Duration `yaml:"timeout"`
+	RateLimit HTTPRateLimit      `yaml:"rate_limit"`
 }
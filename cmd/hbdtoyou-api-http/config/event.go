@@ -0,0 +1,15 @@
+package config
+
+import configlib "hbdtoyou/pkg/config"
+
+type Event struct {
+	Relay EventRelay `yaml:"relay"`
+}
+
+type EventRelay struct {
+	PollInterval configlib.Duration `yaml:"poll_interval"`
+	BatchSize    int                `yaml:"batch_size"`
+	MaxAttempts  int                `yaml:"max_attempts"`
+	BackoffBase  configlib.Duration `yaml:"backoff_base"`
+	BackoffMax   configlib.Duration `yaml:"backoff_max"`
+}
@@ -0,0 +1,15 @@
+package config
+
+import configlib "hbdtoyou/pkg/config"
+
+type Idempotency struct {
+	Store string             `yaml:"store"`
+	TTL   configlib.Duration `yaml:"ttl"`
+}
+
+// Followings are the known idempotency stores on config, the
+// records are kept in memory if it is empty.
+const (
+	IdempotencyStoreMemory     string = "memory"
+	IdempotencyStorePostgreSQL string = "postgresql"
+)
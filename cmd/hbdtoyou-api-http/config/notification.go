@@ -0,0 +1,56 @@
+package config
+
+import configlib "hbdtoyou/pkg/config"
+
+type Notification struct {
+	Channels []NotificationChannel       `yaml:"channels"`
+	Dispatch NotificationDispatch        `yaml:"dispatch"`
+	HTTP     map[string]NotificationHTTP `yaml:"http"`
+}
+
+type NotificationChannel struct {
+	Name      string                       `yaml:"name"`
+	Type      string                       `yaml:"type"`
+	SMTP      NotificationChannelSMTP      `yaml:"smtp"`
+	Webhook   NotificationChannelWebhook   `yaml:"webhook"`
+	LocalFile NotificationChannelLocalFile `yaml:"localfile"`
+}
+
+type NotificationChannelSMTP struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+}
+
+type NotificationChannelWebhook struct {
+	URL     string             `yaml:"url"`
+	Headers map[string]string  `yaml:"headers"`
+	Timeout configlib.Duration `yaml:"timeout"`
+}
+
+type NotificationChannelLocalFile struct {
+	Path string `yaml:"path"`
+}
+
+// Followings are the known notification channel types.
+const (
+	NotificationChannelTypeSMTP      string = "smtp"
+	NotificationChannelTypeWebhook   string = "webhook"
+	NotificationChannelTypeLocalFile string = "localfile"
+	NotificationChannelTypeLog       string = "log"
+)
+
+type NotificationDispatch struct {
+	Interval    configlib.Duration `yaml:"interval"`
+	MaxAttempts int                `yaml:"max_attempts"`
+	BackoffBase configlib.Duration `yaml:"backoff_base"`
+	BackoffMax  configlib.Duration `yaml:"backoff_max"`
+	BatchSize   int                `yaml:"batch_size"`
+}
+
+type NotificationHTTP struct {
+	Timeout   configlib.Duration `yaml:"timeout"`
+	RateLimit HTTPRateLimit      `yaml:"rate_limit"`
+}
@@ -3,9 +3,44 @@ package config
 import configlib "hbdtoyou/pkg/config"
 
 type Payment struct {
-	HTTP map[string]PaymentHTTP `yaml:"http"`
+	Bundles      []PaymentBundle        `yaml:"bundles"`
+	Subscription PaymentSubscription    `yaml:"subscription"`
+	Review       PaymentReview          `yaml:"review"`
+	Refund       PaymentRefund          `yaml:"refund"`
+	Gateways     []PaymentGateway       `yaml:"gateways"`
+	HTTP         map[string]PaymentHTTP `yaml:"http"`
 }
 
-type PaymentHTTP struct {
+type PaymentBundle struct {
+	ID       string `yaml:"id"`
+	Name     string `yaml:"name"`
+	Quota    int    `yaml:"quota"`
+	Price    int64  `yaml:"price"`
+	Currency string `yaml:"currency"`
+}
+
+type PaymentSubscription struct {
+	RenewalLead configlib.Duration `yaml:"renewal_lead"`
+	JobInterval configlib.Duration `yaml:"job_interval"`
+}
+
+type PaymentReview struct {
+	ClaimDuration configlib.Duration `yaml:"claim_duration"`
+}
+
+type PaymentRefund struct {
+	PendingTimeout configlib.Duration `yaml:"pending_timeout"`
+	JobInterval    configlib.Duration `yaml:"job_interval"`
+}
+
+type PaymentGateway struct {
+	Name    string             `yaml:"name"`
+	URL     string             `yaml:"url"`
+	Headers map[string]string  `yaml:"headers"`
 	Timeout configlib.Duration `yaml:"timeout"`
 }
+
+type PaymentHTTP struct {
+	Timeout   configlib.Duration `yaml:"timeout"`
+	RateLimit HTTPRateLimit      `yaml:"rate_limit"`
+}
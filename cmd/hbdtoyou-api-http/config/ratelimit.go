@@ -0,0 +1,23 @@
+package config
+
+import configlib "hbdtoyou/pkg/config"
+
+type RateLimit struct {
+	Store          string `yaml:"store"`
+	ClientIPHeader string `yaml:"client_ip_header"`
+}
+
+// Followings are the known rate limit stores on config, the
+// buckets are kept in memory if it is empty.
+const (
+	RateLimitStoreMemory     string = "memory"
+	RateLimitStorePostgreSQL string = "postgresql"
+)
+
+// HTTPRateLimit is the rate limit of an HTTP scope, it does
+// not limit if it is empty.
+type HTTPRateLimit struct {
+	Limit  int                `yaml:"limit"`
+	Period configlib.Duration `yaml:"period"`
+	Burst  int                `yaml:"burst"`
+}
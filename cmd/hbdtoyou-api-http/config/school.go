@@ -0,0 +1,18 @@
+package config
+
+import configlib "hbdtoyou/pkg/config"
+
+type School struct {
+	Tenant SchoolTenant          `yaml:"tenant"`
+	HTTP   map[string]SchoolHTTP `yaml:"http"`
+}
+
+type SchoolTenant struct {
+	Header     string `yaml:"header"`
+	BaseDomain string `yaml:"base_domain"`
+}
+
+type SchoolHTTP struct {
+	Timeout   configlib.Duration `yaml:"timeout"`
+	RateLimit HTTPRateLimit      `yaml:"rate_limit"`
+}
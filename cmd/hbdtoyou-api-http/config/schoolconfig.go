@@ -0,0 +1,17 @@
+package config
+
+import configlib "hbdtoyou/pkg/config"
+
+type SchoolConfig struct {
+	Cache SchoolConfigCache           `yaml:"cache"`
+	HTTP  map[string]SchoolConfigHTTP `yaml:"http"`
+}
+
+type SchoolConfigCache struct {
+	TTL configlib.Duration `yaml:"ttl"`
+}
+
+type SchoolConfigHTTP struct {
+	Timeout   configlib.Duration `yaml:"timeout"`
+	RateLimit HTTPRateLimit      `yaml:"rate_limit"`
+}
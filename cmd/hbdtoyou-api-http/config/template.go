@@ -3,9 +3,16 @@ package config
 import configlib "hbdtoyou/pkg/config"
 
 type Template struct {
-	HTTP map[string]TemplateHTTP `yaml:"http"`
+	Trash TemplateTrash           `yaml:"trash"`
+	HTTP  map[string]TemplateHTTP `yaml:"http"`
+}
+
+type TemplateTrash struct {
+	Retention     configlib.Duration `yaml:"retention"`
+	PurgeInterval configlib.Duration `yaml:"purge_interval"`
 }
 
 type TemplateHTTP struct {
-	Timeout configlib.Duration `yaml:"timeout"`
+	Timeout   configlib.Duration `yaml:"timeout"`
+	RateLimit HTTPRateLimit      `yaml:"rate_limit"`
 }
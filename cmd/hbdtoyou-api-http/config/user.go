@@ -11,5 +11,6 @@ type User struct {
 }
 
 type UserHTTP struct {
-	Timeout configlib.Duration `yaml:"timeout"`
+	Timeout   configlib.Duration `yaml:"timeout"`
+	RateLimit HTTPRateLimit      `yaml:"rate_limit"`
 }
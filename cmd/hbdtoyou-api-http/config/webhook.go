@@ -0,0 +1,25 @@
+package config
+
+import configlib "hbdtoyou/pkg/config"
+
+type Webhook struct {
+	Delivery WebhookDelivery        `yaml:"delivery"`
+	HTTP     map[string]WebhookHTTP `yaml:"http"`
+}
+
+type WebhookDelivery struct {
+	Interval         configlib.Duration `yaml:"interval"`
+	Timeout          configlib.Duration `yaml:"timeout"`
+	MaxAttempts      int                `yaml:"max_attempts"`
+	BackoffBase      configlib.Duration `yaml:"backoff_base"`
+	BackoffMax       configlib.Duration `yaml:"backoff_max"`
+	BatchSize        int                `yaml:"batch_size"`
+	ClaimTimeout     configlib.Duration `yaml:"claim_timeout"`
+	DisableThreshold int                `yaml:"disable_threshold"`
+	AllowInsecureURL bool               `yaml:"allow_insecure_url"`
+}
+
+type WebhookHTTP struct {
+	Timeout   configlib.Duration `yaml:"timeout"`
+	RateLimit HTTPRateLimit      `yaml:"rate_limit"`
+}
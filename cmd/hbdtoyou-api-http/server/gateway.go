@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hbdtoyou/cmd/hbdtoyou-api-http/config"
+	"hbdtoyou/internal/payment"
+	"hbdtoyou/pkg/gateway"
+	gatewaywebhook "hbdtoyou/pkg/gateway/client/webhook"
+	"hbdtoyou/pkg/money"
+	"time"
+)
+
+// paymentGateway implements payment.Gateway using a gateway
+// client.
+type paymentGateway struct {
+	client gateway.Client
+}
+
+// newPaymentGateway creates a payment gateway based on the
+// given gateway config.
+func newPaymentGateway(cfg config.PaymentGateway) (payment.Gateway, error) {
+	client, err := gatewaywebhook.New(gatewaywebhook.Config{
+		URL:     cfg.URL,
+		Headers: cfg.Headers,
+		Timeout: time.Duration(cfg.Timeout),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &paymentGateway{client: client}, nil
+}
+
+// Refund implements payment.Gateway.
+func (g *paymentGateway) Refund(ctx context.Context, reference string, refundID string, amount money.Money, reason string) (string, error) {
+	res, err := g.client.Refund(ctx, gateway.Refund{
+		ID:               refundID,
+		PaymentReference: reference,
+		Amount:           amount.Amount,
+		Currency:         amount.Currency,
+		Reason:           reason,
+	})
+	if errors.Is(err, gateway.ErrDeclined) {
+		return "", fmt.Errorf("%w: %s", payment.ErrRefundDeclined, err.Error())
+	}
+
+	return res, err
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"fmt"
+	"hbdtoyou/cmd/hbdtoyou-api-http/config"
+	"hbdtoyou/pkg/idempotency"
+	idempotencymemory "hbdtoyou/pkg/idempotency/store/memory"
+	idempotencypostgresql "hbdtoyou/pkg/idempotency/store/postgresql"
+	pglib "hbdtoyou/pkg/postgresql"
+	"io"
+)
+
+// idempotencyStore is an idempotency.Store closed after the
+// server is stopped.
+type idempotencyStore interface {
+	idempotency.Store
+	io.Closer
+}
+
+// newIdempotencyStore creates an idempotency store based on
+// the given idempotency config.
+//
+// The PostgreSQL store keeps the records in the default
+// database, so a retry is replayed by any replica.
+func newIdempotencyStore(cfg config.Idempotency, pgClientManager *pglib.ClientManager) (idempotencyStore, error) {
+	switch cfg.Store {
+	case "", config.IdempotencyStoreMemory:
+		return idempotencymemory.New(), nil
+
+	case config.IdempotencyStorePostgreSQL:
+		pgDb, err := pgClientManager.GetDatabase(config.PostgreSQLTenant)
+		if err != nil {
+			return nil, err
+		}
+		return idempotencypostgresql.New(pgDb)
+	}
+
+	return nil, fmt.Errorf("unknown idempotency store: %s", cfg.Store)
+}
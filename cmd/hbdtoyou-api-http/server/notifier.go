@@ -0,0 +1,44 @@
+package server
+
+import (
+	"fmt"
+	"hbdtoyou/cmd/hbdtoyou-api-http/config"
+	"hbdtoyou/pkg/notifier"
+	notifierlocalfile "hbdtoyou/pkg/notifier/client/localfile"
+	notifierlogger "hbdtoyou/pkg/notifier/client/logger"
+	notifiersmtp "hbdtoyou/pkg/notifier/client/smtp"
+	notifierwebhook "hbdtoyou/pkg/notifier/client/webhook"
+	"time"
+)
+
+// newNotifierClient creates a notifier client based on the
+// given notification channel config.
+func newNotifierClient(cfg config.NotificationChannel) (notifier.Client, error) {
+	switch cfg.Type {
+	case config.NotificationChannelTypeSMTP:
+		return notifiersmtp.New(notifiersmtp.Config{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
+		})
+
+	case config.NotificationChannelTypeWebhook:
+		return notifierwebhook.New(notifierwebhook.Config{
+			URL:     cfg.Webhook.URL,
+			Headers: cfg.Webhook.Headers,
+			Timeout: time.Duration(cfg.Webhook.Timeout),
+		})
+
+	case config.NotificationChannelTypeLocalFile:
+		return notifierlocalfile.New(notifierlocalfile.Config{
+			Path: cfg.LocalFile.Path,
+		})
+
+	case config.NotificationChannelTypeLog:
+		return notifierlogger.New(), nil
+	}
+
+	return nil, fmt.Errorf("unknown notification channel type: %s", cfg.Type)
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"hbdtoyou/pkg/openapi"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Followings are the paths serving the OpenAPI document and
+// its Swagger UI page.
+const (
+	openAPIPath   = "/openapi.json"
+	swaggerUIPath = "/docs"
+)
+
+// documentedHandler provides the OpenAPI routes of an HTTP
+// handler. HTTP handlers should implement this interface.
+type documentedHandler interface {
+	Routes() []openapi.Route
+}
+
+// startOpenAPI serves the OpenAPI document of the app routes
+// and its Swagger UI page.
+//
+// Every method served by the handlers is checked to be in the
+// document by TestOpenAPIDocumentsHandlers.
+func (s *server) startOpenAPI(rootMux *mux.Router, prefix string) error {
+	doc, err := newOpenAPIDocument(s.handlers, prefix)
+	if err != nil {
+		return err
+	}
+
+	docHandler, err := openapi.NewHandler(doc)
+	if err != nil {
+		return err
+	}
+
+	uiHandler, err := openapi.NewSwaggerUIHandler(doc.Info.Title, openAPIPath, swaggerUIPath)
+	if err != nil {
+		return err
+	}
+
+	rootMux.Handle(openAPIPath, docHandler).Methods(http.MethodGet, http.MethodHead)
+	rootMux.Handle(swaggerUIPath, uiHandler).Methods(http.MethodGet, http.MethodHead)
+	rootMux.PathPrefix(swaggerUIPath+"/").Handler(uiHandler).Methods(http.MethodGet, http.MethodHead)
+	return nil
+}
+
+// newOpenAPIDocument returns the OpenAPI document of the routes
+// of the given handlers, served under the given prefix.
+func newOpenAPIDocument(handlers []handler, prefix string) (*openapi.Document, error) {
+	var routes []openapi.Route
+	for _, h := range handlers {
+		if dh, ok := h.(documentedHandler); ok {
+			routes = append(routes, dh.Routes()...)
+		}
+	}
+
+	return openapi.New(openapi.Info{
+		Title:       "Memorify API",
+		Description: "The school of a request is given in its tenant header or subdomain, it is resolved from the access token otherwise.",
+		Version:     "v1",
+	}, prefix, routes)
+}
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	authhttphandler "hbdtoyou/internal/auth/handler/http"
+	contenthttphandler "hbdtoyou/internal/content/handler/http"
+	mediahttphandler "hbdtoyou/internal/media/handler/http"
+	notificationhttphandler "hbdtoyou/internal/notification/handler/http"
+	paymenthttphandler "hbdtoyou/internal/payment/handler/http"
+	schoolhttphandler "hbdtoyou/internal/school/handler/http"
+	schoolconfighttphandler "hbdtoyou/internal/schoolconfig/handler/http"
+	templatehttphandler "hbdtoyou/internal/template/handler/http"
+	webhookhttphandler "hbdtoyou/internal/webhook/handler/http"
+
+	"github.com/gorilla/mux"
+)
+
+// probedMethods are the methods requested on every handler to
+// find the methods it serves.
+var probedMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// pathVariablePattern matches the variables of a mux path
+// template.
+var pathVariablePattern = regexp.MustCompile(`{[^}]+}`)
+
+// servedRoute denotes a handler identity served by the server.
+type servedRoute struct {
+	name string
+	url  string
+}
+
+// newTestHandlers returns the HTTP handlers of every served
+// identity, without services, along with the served routes.
+func newTestHandlers(t *testing.T) ([]handler, []servedRoute) {
+	var handlers []handler
+	var routes []servedRoute
+	add := func(h handler, err error) {
+		if err != nil {
+			t.Fatalf("failed to initialize handler: %v", err)
+		}
+		handlers = append(handlers, h)
+	}
+
+	{
+		var options []schoolhttphandler.Option
+		for _, identity := range schoolHTTPIdentities {
+			options = append(options, schoolhttphandler.WithHandler(identity))
+			routes = append(routes, servedRoute{identity.Name, identity.URL})
+		}
+		add(schoolhttphandler.New(nil, nil, options...))
+	}
+	{
+		var options []schoolconfighttphandler.Option
+		for _, identity := range schoolConfigHTTPIdentities {
+			options = append(options, schoolconfighttphandler.WithHandler(identity))
+			routes = append(routes, servedRoute{identity.Name, identity.URL})
+		}
+		add(schoolconfighttphandler.New(nil, nil, options...))
+	}
+	{
+		var options []authhttphandler.Option
+		for _, identity := range authHTTPIdentities {
+			options = append(options, authhttphandler.WithHandler(identity))
+			routes = append(routes, servedRoute{identity.Name, identity.URL})
+		}
+		add(authhttphandler.New(nil, options...))
+	}
+	{
+		var options []contenthttphandler.Option
+		for _, identity := range contentHTTPIdentities {
+			options = append(options, contenthttphandler.WithHandler(identity))
+			routes = append(routes, servedRoute{identity.Name, identity.URL})
+		}
+		add(contenthttphandler.New(nil, nil, options...))
+	}
+	{
+		var options []templatehttphandler.Option
+		for _, identity := range templateHTTPIdentities {
+			options = append(options, templatehttphandler.WithHandler(identity))
+			routes = append(routes, servedRoute{identity.Name, identity.URL})
+		}
+		add(templatehttphandler.New(nil, nil, options...))
+	}
+	{
+		var options []paymenthttphandler.Option
+		for _, identity := range paymentHTTPIdentities {
+			options = append(options, paymenthttphandler.WithHandler(identity))
+			routes = append(routes, servedRoute{identity.Name, identity.URL})
+		}
+		add(paymenthttphandler.New(nil, nil, options...))
+	}
+	{
+		var options []mediahttphandler.Option
+		for _, identity := range mediaHTTPIdentities {
+			options = append(options, mediahttphandler.WithHandler(identity))
+			routes = append(routes, servedRoute{identity.Name, identity.URL})
+		}
+		add(mediahttphandler.New(nil, nil, options...))
+	}
+	{
+		var options []notificationhttphandler.Option
+		for _, identity := range notificationHTTPIdentities {
+			options = append(options, notificationhttphandler.WithHandler(identity))
+			routes = append(routes, servedRoute{identity.Name, identity.URL})
+		}
+		add(notificationhttphandler.New(nil, nil, options...))
+	}
+	{
+		var options []webhookhttphandler.Option
+		for _, identity := range webhookHTTPIdentities {
+			options = append(options, webhookhttphandler.WithHandler(identity))
+			routes = append(routes, servedRoute{identity.Name, identity.URL})
+		}
+		add(webhookhttphandler.New(nil, nil, options...))
+	}
+
+	return handlers, routes
+}
+
+// TestOpenAPIDocumentsHandlers checks the OpenAPI document has
+// an operation for every method served by every handler
+// identity, and only for those.
+func TestOpenAPIDocumentsHandlers(t *testing.T) {
+	handlers, routes := newTestHandlers(t)
+
+	doc, err := newOpenAPIDocument(handlers, appPathPrefix)
+	if err != nil {
+		t.Fatalf("newOpenAPIDocument() error = %v", err)
+	}
+
+	router := mux.NewRouter()
+	for _, h := range handlers {
+		if err := h.Start(router); err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+	}
+
+	for _, route := range routes {
+		// requests are sent without source, they are refused
+		// before reaching the services unless the method is
+		// not served
+		path := pathVariablePattern.ReplaceAllString(route.url, "test")
+		for _, method := range probedMethods {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+
+			served := rec.Code != http.StatusMethodNotAllowed
+			documented := doc.HasOperation(method, route.url)
+			switch {
+			case served && !documented:
+				t.Errorf("%s %s of handler %s is missing from the document", method, route.url, route.name)
+			case !served && documented:
+				t.Errorf("%s %s of handler %s is documented but not served", method, route.url, route.name)
+			}
+		}
+	}
+}
+
+// TestSwaggerUIServesEmbeddedAssets checks the Swagger UI page
+// and the assets it loads are served by the server itself.
+func TestSwaggerUIServesEmbeddedAssets(t *testing.T) {
+	handlers, _ := newTestHandlers(t)
+
+	s := &server{handlers: handlers}
+	router := mux.NewRouter()
+	if err := s.startOpenAPI(router, appPathPrefix); err != nil {
+		t.Fatalf("startOpenAPI() error = %v", err)
+	}
+
+	for _, path := range []string{openAPIPath, swaggerUIPath, swaggerUIPath + "/swagger-ui.css", swaggerUIPath + "/swagger-ui-bundle.js"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK || rec.Body.Len() == 0 {
+			t.Errorf("GET %s = %d with %d bytes, want 200 with a body", path, rec.Code, rec.Body.Len())
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, swaggerUIPath, nil))
+	if regexp.MustCompile(`(src|href)="https?://`).MatchString(rec.Body.String()) {
+		t.Errorf("Swagger UI page loads external assets:\n%s", rec.Body.String())
+	}
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"fmt"
+	"hbdtoyou/cmd/hbdtoyou-api-http/config"
+	pglib "hbdtoyou/pkg/postgresql"
+	"hbdtoyou/pkg/ratelimit"
+	ratelimitmemory "hbdtoyou/pkg/ratelimit/store/memory"
+	ratelimitpostgresql "hbdtoyou/pkg/ratelimit/store/postgresql"
+	"io"
+	"time"
+)
+
+// rateLimitStore is a ratelimit.Store closed after the server
+// is stopped.
+type rateLimitStore interface {
+	ratelimit.Store
+	io.Closer
+}
+
+// newRateLimitStore creates a rate limit store based on the
+// given rate limit config.
+//
+// The PostgreSQL store keeps the buckets in the default
+// database, so the limits are shared by all replicas.
+func newRateLimitStore(cfg config.RateLimit, pgClientManager *pglib.ClientManager) (rateLimitStore, error) {
+	switch cfg.Store {
+	case "", config.RateLimitStoreMemory:
+		return ratelimitmemory.New(), nil
+
+	case config.RateLimitStorePostgreSQL:
+		pgDb, err := pgClientManager.GetDatabase(config.PostgreSQLTenant)
+		if err != nil {
+			return nil, err
+		}
+		return ratelimitpostgresql.New(pgDb)
+	}
+
+	return nil, fmt.Errorf("unknown rate limit store: %s", cfg.Store)
+}
+
+// getRateLimit returns the rate limit of the given HTTP scope
+// config.
+func getRateLimit(cfg config.HTTPRateLimit) ratelimit.Limit {
+	return ratelimit.Limit{
+		Limit:  cfg.Limit,
+		Period: time.Duration(cfg.Period),
+		Burst:  cfg.Burst,
+	}
+}
@@ -10,22 +10,62 @@ import (
 	authpgstore "hbdtoyou/internal/auth/store/postgresql"
 	"hbdtoyou/internal/content"
 	contenthttphandler "hbdtoyou/internal/content/handler/http"
+	contentjobhandler "hbdtoyou/internal/content/handler/job"
 	contentservice "hbdtoyou/internal/content/service"
 	contentpgstore "hbdtoyou/internal/content/store/postgresql"
+	"hbdtoyou/internal/entitlement"
+	entitlementservice "hbdtoyou/internal/entitlement/service"
+	entitlementpgstore "hbdtoyou/internal/entitlement/store/postgresql"
+	"hbdtoyou/internal/media"
+	mediahttphandler "hbdtoyou/internal/media/handler/http"
+	mediaservice "hbdtoyou/internal/media/service"
+	mediapgstore "hbdtoyou/internal/media/store/postgresql"
+	"hbdtoyou/internal/notification"
+	notificationhttphandler "hbdtoyou/internal/notification/handler/http"
+	notificationjobhandler "hbdtoyou/internal/notification/handler/job"
+	notificationservice "hbdtoyou/internal/notification/service"
+	notificationpgstore "hbdtoyou/internal/notification/store/postgresql"
 	"hbdtoyou/internal/payment"
+	paymenteventhandler "hbdtoyou/internal/payment/handler/event"
 	paymenthttphandler "hbdtoyou/internal/payment/handler/http"
+	paymentjobhandler "hbdtoyou/internal/payment/handler/job"
 	paymentservice "hbdtoyou/internal/payment/service"
 	paymentpgstore "hbdtoyou/internal/payment/store/postgresql"
+	"hbdtoyou/internal/school"
+	schoolhttphandler "hbdtoyou/internal/school/handler/http"
+	schoolservice "hbdtoyou/internal/school/service"
+	schoolpgstore "hbdtoyou/internal/school/store/postgresql"
+	"hbdtoyou/internal/schoolconfig"
+	schoolconfighttphandler "hbdtoyou/internal/schoolconfig/handler/http"
+	schoolconfigservice "hbdtoyou/internal/schoolconfig/service"
+	schoolconfigpgstore "hbdtoyou/internal/schoolconfig/store/postgresql"
 	"hbdtoyou/internal/template"
 	templatehttphandler "hbdtoyou/internal/template/handler/http"
+	templatejobhandler "hbdtoyou/internal/template/handler/job"
 	templateservice "hbdtoyou/internal/template/service"
 	templatepgstore "hbdtoyou/internal/template/store/postgresql"
+	"hbdtoyou/internal/webhook"
+	webhookeventhandler "hbdtoyou/internal/webhook/handler/event"
+	webhookhttphandler "hbdtoyou/internal/webhook/handler/http"
+	webhookjobhandler "hbdtoyou/internal/webhook/handler/job"
+	webhookservice "hbdtoyou/internal/webhook/service"
+	webhookpgstore "hbdtoyou/internal/webhook/store/postgresql"
 	configlib "hbdtoyou/pkg/config"
+	eventlib "hbdtoyou/pkg/event"
+	eventoutbox "hbdtoyou/pkg/event/outbox/postgresql"
 	"hbdtoyou/pkg/graceful"
+	httplib "hbdtoyou/pkg/http"
+	"hbdtoyou/pkg/imageproc"
+	"hbdtoyou/pkg/money"
 	pglib "hbdtoyou/pkg/postgresql"
 	secretlocalfile "hbdtoyou/pkg/secret/client/localfile"
+	"hbdtoyou/pkg/storage"
+	webhooklib "hbdtoyou/pkg/webhook"
+	"io"
 	"log"
 	"net/http"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -40,6 +80,85 @@ const (
 	CodeFailServeHTTP
 )
 
+// appPathPrefix is the path prefix of all app routes.
+const appPathPrefix = "/tenant"
+
+// Followings are the identities of the served HTTP handlers
+// of each domain.
+var (
+	schoolHTTPIdentities = []schoolhttphandler.HandlerIdentity{
+		schoolhttphandler.HandlerSchools,
+		schoolhttphandler.HandlerSchool,
+	}
+
+	schoolConfigHTTPIdentities = []schoolconfighttphandler.HandlerIdentity{
+		schoolconfighttphandler.HandlerConfigs,
+		schoolconfighttphandler.HandlerConfig,
+	}
+
+	authHTTPIdentities = []authhttphandler.HandlerIdentity{
+		authhttphandler.HandlerLoginSocial,
+		authhttphandler.HandlerRefreshToken,
+		authhttphandler.HandlerUser,
+	}
+
+	contentHTTPIdentities = []contenthttphandler.HandlerIdentity{
+		contenthttphandler.HandlerContent,
+		contenthttphandler.HandlerContents,
+		contenthttphandler.HandlerContentRestore,
+		contenthttphandler.HandlerTrashContents,
+		contenthttphandler.HandlerContentRevisions,
+		contenthttphandler.HandlerContentRevisionRestore,
+		contenthttphandler.HandlerContentRevisionDiff,
+		contenthttphandler.HandlerContentMembers,
+		contenthttphandler.HandlerContentMember,
+		contenthttphandler.HandlerInvitations,
+		contenthttphandler.HandlerInvitationAccept,
+		contenthttphandler.HandlerInvitationDecline,
+	}
+
+	templateHTTPIdentities = []templatehttphandler.HandlerIdentity{
+		templatehttphandler.HandlerTemplate,
+		templatehttphandler.HandlerTemplates,
+		templatehttphandler.HandlerTemplateRestore,
+		templatehttphandler.HandlerTrashTemplates,
+	}
+
+	paymentHTTPIdentities = []paymenthttphandler.HandlerIdentity{
+		paymenthttphandler.HandlerPayment,
+		paymenthttphandler.HandlerPayments,
+		paymenthttphandler.HandlerBundles,
+		paymenthttphandler.HandlerPlan,
+		paymenthttphandler.HandlerPlans,
+		paymenthttphandler.HandlerSubscription,
+		paymenthttphandler.HandlerSubscriptions,
+		paymenthttphandler.HandlerSubscriptionCancel,
+		paymenthttphandler.HandlerVoucher,
+		paymenthttphandler.HandlerVouchers,
+		paymenthttphandler.HandlerPaymentInvoice,
+		paymenthttphandler.HandlerPaymentRefunds,
+		paymenthttphandler.HandlerPaymentClaim,
+		paymenthttphandler.HandlerPaymentReviews,
+	}
+
+	mediaHTTPIdentities = []mediahttphandler.HandlerIdentity{
+		mediahttphandler.HandlerMedia,
+		mediahttphandler.HandlerMedias,
+	}
+
+	notificationHTTPIdentities = []notificationhttphandler.HandlerIdentity{
+		notificationhttphandler.HandlerNotifications,
+		notificationhttphandler.HandlerNotificationRead,
+	}
+
+	webhookHTTPIdentities = []webhookhttphandler.HandlerIdentity{
+		webhookhttphandler.HandlerWebhooks,
+		webhookhttphandler.HandlerWebhook,
+		webhookhttphandler.HandlerWebhookDeliveries,
+		webhookhttphandler.HandlerWebhookRedeliver,
+	}
+)
+
 // Option contains available options to run the server.
 type Option struct {
 	SecretPath string
@@ -68,7 +187,22 @@ func Run(opt Option) int {
 type server struct {
 	srv      *http.Server
 	handlers []handler
+	workers  []worker
 	config   config.Config
+
+	// authenticate is the middleware storing the user of a
+	// request once its access token is validated, it is used
+	// by the rate limiter and the idempotency of the handlers.
+	authenticate func(http.Handler) http.Handler
+
+	// storageHandler serves objects of storage that is not
+	// reachable from outside, e.g. local file storage.
+	storageHandler   http.Handler
+	storageMountPath string
+
+	// closers are closed after the server is stopped, e.g.
+	// to wait for background jobs of a service.
+	closers []io.Closer
 }
 
 // handler provides mechanism to start HTTP handler. All HTTP
@@ -77,6 +211,13 @@ type handler interface {
 	Start(multiplexer *mux.Router) error
 }
 
+// worker provides mechanism to run background jobs. All job
+// handlers must implements this interface.
+type worker interface {
+	Start() error
+	Stop()
+}
+
 // new creates and returns a new server.
 func new(opt Option) (*server, error) {
 	s := &server{
@@ -128,18 +269,22 @@ func new(opt Option) (*server, error) {
 	// initilize postgresql client manager
 	var pgClientManager *pglib.ClientManager
 	{
-		clientNames := []string{
-			config.PostgreSQLTenant,
+		// every configured database is a tenant a school can
+		// use, the default one is required
+		if _, ok := s.config.PostgreSQL[config.PostgreSQLTenant]; !ok {
+			log.Printf("[memorify-api-http] postgresql config not found for client name: %s\n", config.PostgreSQLTenant)
+			return nil, fmt.Errorf("postgresql config not found")
 		}
 
+		clientNames := make([]string, 0, len(s.config.PostgreSQL))
+		for clientName := range s.config.PostgreSQL {
+			clientNames = append(clientNames, clientName)
+		}
+		sort.Strings(clientNames)
+
 		var options []pglib.Option
 		for _, clientName := range clientNames {
-			cfg, ok := s.config.PostgreSQL[clientName]
-			if !ok {
-				log.Printf("[memorify-api-http] postgresql config not found for client name: %s\n", clientName)
-				return nil, fmt.Errorf("postgresql config not found")
-			}
-
+			cfg := s.config.PostgreSQL[clientName]
 			options = append(options, pglib.WithClientConfig(clientName, pglib.ClientConfig{
 				ConnectionString:  cfg.ConnectionString,
 				ConnectionTimeout: time.Duration(cfg.ConnectionTimeout),
@@ -154,16 +299,44 @@ func new(opt Option) (*server, error) {
 		}
 	}
 
-	// initialize auth service
-	var authSvc auth.Service
+	// initialize postgresql router, the stores query the
+	// database of the school of a request
+	pgRouter, err := pglib.NewRouter(pgClientManager, config.PostgreSQLTenant)
+	if err != nil {
+		log.Printf("[memorify-api-http] failed to initialize postgresql router: %s\n", err.Error())
+		return nil, fmt.Errorf("failed to initialize postgresql router: %s", err.Error())
+	}
+
+	// initialize rate limiter of the HTTP handlers
+	var rateLimiter *httplib.RateLimiter
 	{
-		pgDb, err := pgClientManager.GetDatabase(config.PostgreSQLTenant)
+		store, err := newRateLimitStore(s.config.RateLimit, pgClientManager)
 		if err != nil {
-			log.Printf("[auth-api-http] failed to get postgresql database: %s\n", err.Error())
-			return nil, fmt.Errorf("failed to get postgresql database: %s", err.Error())
+			log.Printf("[memorify-api-http] failed to initialize rate limit store: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize rate limit store: %s", err.Error())
 		}
+		s.closers = append(s.closers, store)
 
-		pgStore, err := authpgstore.New(pgDb)
+		rateLimiter = httplib.NewRateLimiter(store, s.config.RateLimit.ClientIPHeader)
+	}
+
+	// initialize idempotency of the create HTTP handlers
+	var idempotency *httplib.Idempotency
+	{
+		store, err := newIdempotencyStore(s.config.Idempotency, pgClientManager)
+		if err != nil {
+			log.Printf("[memorify-api-http] failed to initialize idempotency store: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize idempotency store: %s", err.Error())
+		}
+		s.closers = append(s.closers, store)
+
+		idempotency = httplib.NewIdempotency(store, time.Duration(s.config.Idempotency.TTL))
+	}
+
+	// initialize auth service
+	var authSvc auth.Service
+	{
+		pgStore, err := authpgstore.New(pgRouter)
 		if err != nil {
 			log.Printf("[auth-api-http] failed to initialize auth postgresql store: %s\n", err.Error())
 			return nil, fmt.Errorf("failed to initialize auth postgresql store: %s", err.Error())
@@ -182,46 +355,159 @@ func new(opt Option) (*server, error) {
 			log.Printf("[auth-api-http] failed to initialize auth service: %s\n", err.Error())
 			return nil, fmt.Errorf("failed to initialize auth service: %s", err.Error())
 		}
+
+		s.authenticate = httplib.Authenticate(func(ctx context.Context, token string) (string, error) {
+			tokenData, err := authSvc.ValidateToken(ctx, token)
+			return tokenData.UserID, err
+		})
 	}
 
-	// initialize template service
-	var templateSvc template.Service
+	// initialize school service
+	var schoolSvc school.Service
+	{
+		// schools are kept in the default database
+		pgDb, err := pgClientManager.GetDatabase(config.PostgreSQLTenant)
+		if err != nil {
+			log.Printf("[school-api-http] failed to get postgresql database: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to get postgresql database: %s", err.Error())
+		}
+
+		pgStore, err := schoolpgstore.New(pgDb)
+		if err != nil {
+			log.Printf("[school-api-http] failed to initialize school postgresql store: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize school postgresql store: %s", err.Error())
+		}
+
+		schoolSvc, err = schoolservice.New(pgStore, authSvc, schoolservice.WithConfig(schoolservice.Config{
+			DefaultTenant: pgRouter.GetDefaultTenant(),
+			Tenants:       pgRouter.GetTenants(),
+		}))
+		if err != nil {
+			log.Printf("[school-api-http] failed to initialize school service: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize school service: %s", err.Error())
+		}
+	}
+
+	// initialize school config service
+	var schoolConfigSvc schoolconfig.Service
 	{
+		// school configs are kept in the default database
 		pgDb, err := pgClientManager.GetDatabase(config.PostgreSQLTenant)
 		if err != nil {
-			log.Printf("[template-api-http] failed to get postgresql database: %s\n", err.Error())
+			log.Printf("[school-config-api-http] failed to get postgresql database: %s\n", err.Error())
 			return nil, fmt.Errorf("failed to get postgresql database: %s", err.Error())
 		}
 
-		pgStore, err := templatepgstore.New(pgDb)
+		pgStore, err := schoolconfigpgstore.New(pgDb)
+		if err != nil {
+			log.Printf("[school-config-api-http] failed to initialize school config postgresql store: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize school config postgresql store: %s", err.Error())
+		}
+
+		schoolConfigSvc, err = schoolconfigservice.New(pgStore, authSvc, schoolSvc, schoolconfigservice.WithConfig(schoolconfigservice.Config{
+			SettingsCacheTTL: time.Duration(s.config.SchoolConfig.Cache.TTL),
+		}))
+		if err != nil {
+			log.Printf("[school-config-api-http] failed to initialize school config service: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize school config service: %s", err.Error())
+		}
+	}
+
+	// initialize storage client
+	var storageClient storage.Client
+	{
+		var err error
+		storageClient, s.storageHandler, err = newStorageClient(s.config.Media.Storage)
+		if err != nil {
+			log.Printf("[memorify-api-http] failed to initialize storage client: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize storage client: %s", err.Error())
+		}
+		s.storageMountPath = s.config.Media.Storage.LocalFile.MountPath
+	}
+
+	// initialize media service
+	var mediaSvc media.Service
+	{
+		pgStore, err := mediapgstore.New(pgRouter)
+		if err != nil {
+			log.Printf("[media-api-http] failed to initialize media postgresql store: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize media postgresql store: %s", err.Error())
+		}
+
+		var variants []mediaservice.VariantConfig
+		for _, v := range s.config.Media.Image.Variants {
+			variants = append(variants, mediaservice.VariantConfig{
+				Name:      v.Name,
+				MaxWidth:  v.MaxWidth,
+				MaxHeight: v.MaxHeight,
+			})
+		}
+
+		svcOptions := []mediaservice.Option{}
+		svcOptions = append(svcOptions, mediaservice.WithConfig(mediaservice.Config{
+			MaxSize:             s.config.Media.MaxUploadSize,
+			AllowedMIMETypes:    s.config.Media.AllowedMIMETypes,
+			SignedURLExpiration: time.Duration(s.config.Media.SignedURLExpiration),
+			ImageFormat:         imageproc.Format(s.config.Media.Image.Format),
+			ImageQuality:        s.config.Media.Image.Quality,
+			MaxPixels:           s.config.Media.Image.MaxPixels,
+			Variants:            variants,
+			Workers:             s.config.Media.Processing.Workers,
+			QueueSize:           s.config.Media.Processing.QueueSize,
+			ProcessTimeout:      time.Duration(s.config.Media.Processing.Timeout),
+		}))
+
+		svc, err := mediaservice.New(pgStore, storageClient, svcOptions...)
+		if err != nil {
+			log.Printf("[media-api-http] failed to initialize media service: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize media service: %s", err.Error())
+		}
+		mediaSvc = svc
+		s.closers = append(s.closers, svc)
+	}
+
+	// initialize template service
+	var templateSvc template.Service
+	{
+		pgStore, err := templatepgstore.New(pgRouter)
 		if err != nil {
 			log.Printf("[template-api-http] failed to initialize template postgresql store: %s\n", err.Error())
 			return nil, fmt.Errorf("failed to initialize template postgresql store: %s", err.Error())
 		}
 
-		templateSvc, err = templateservice.New(pgStore)
+		templateSvc, err = templateservice.New(pgStore, mediaSvc, authSvc)
 		if err != nil {
 			log.Printf("[template-api-http] failed to initialize template service: %s\n", err.Error())
 			return nil, fmt.Errorf("failed to initialize template service: %s", err.Error())
 		}
 	}
 
-	// initialize content service
-	var contentSvc content.Service
+	// initialize entitlement service
+	var entitlementSvc entitlement.Service
 	{
-		pgDb, err := pgClientManager.GetDatabase(config.PostgreSQLTenant)
+		pgStore, err := entitlementpgstore.New(pgRouter)
 		if err != nil {
-			log.Printf("[content-api-http] failed to get postgresql database: %s\n", err.Error())
-			return nil, fmt.Errorf("failed to get postgresql database: %s", err.Error())
+			log.Printf("[entitlement-api-http] failed to initialize entitlement postgresql store: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize entitlement postgresql store: %s", err.Error())
 		}
 
-		pgStore, err := contentpgstore.New(pgDb)
+		entitlementSvc, err = entitlementservice.New(pgStore)
+		if err != nil {
+			log.Printf("[entitlement-api-http] failed to initialize entitlement service: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize entitlement service: %s", err.Error())
+		}
+	}
+
+	// initialize content service
+	var contentSvc content.Service
+	{
+		pgStore, err := contentpgstore.New(pgRouter)
 		if err != nil {
 			log.Printf("[content-api-http] failed to initialize content postgresql store: %s\n", err.Error())
 			return nil, fmt.Errorf("failed to initialize content postgresql store: %s", err.Error())
 		}
 
-		contentSvc, err = contentservice.New(pgStore, authSvc, templateSvc)
+		contentSvc, err = contentservice.New(pgStore, authSvc, templateSvc, mediaSvc, entitlementSvc, schoolConfigSvc)
 		if err != nil {
 			log.Printf("[content-api-http] failed to initialize content service: %s\n", err.Error())
 			return nil, fmt.Errorf("failed to initialize content service: %s", err.Error())
@@ -231,40 +517,180 @@ func new(opt Option) (*server, error) {
 	// initialize payment service
 	var paymentSvc payment.Service
 	{
-		pgDb, err := pgClientManager.GetDatabase(config.PostgreSQLTenant)
-		if err != nil {
-			log.Printf("[payment-api-http] failed to get postgresql database: %s\n", err.Error())
-			return nil, fmt.Errorf("failed to get postgresql database: %s", err.Error())
-		}
-
-		pgStore, err := paymentpgstore.New(pgDb)
+		pgStore, err := paymentpgstore.New(pgRouter)
 		if err != nil {
 			log.Printf("[payment-api-http] failed to initialize payment postgresql store: %s\n", err.Error())
 			return nil, fmt.Errorf("failed to initialize payment postgresql store: %s", err.Error())
 		}
 
-		paymentSvc, err = paymentservice.New(pgStore, authSvc, contentSvc)
+		var bundles []payment.Bundle
+		for _, b := range s.config.Payment.Bundles {
+			bundles = append(bundles, payment.Bundle{
+				ID:    b.ID,
+				Name:  b.Name,
+				Quota: b.Quota,
+				Price: money.Money{
+					Amount:   b.Price,
+					Currency: money.NormalizeCurrency(b.Currency),
+				},
+			})
+		}
+
+		svcOptions := []paymentservice.Option{}
+		svcOptions = append(svcOptions, paymentservice.WithConfig(paymentservice.Config{
+			Bundles:              bundles,
+			RenewalLead:          time.Duration(s.config.Payment.Subscription.RenewalLead),
+			ClaimDuration:        time.Duration(s.config.Payment.Review.ClaimDuration),
+			PendingRefundTimeout: time.Duration(s.config.Payment.Refund.PendingTimeout),
+		}))
+
+		// payments processed by a gateway are refunded through
+		// it
+		for _, g := range s.config.Payment.Gateways {
+			gateway, err := newPaymentGateway(g)
+			if err != nil {
+				log.Printf("[payment-api-http] failed to initialize payment gateway %s: %s\n", g.Name, err.Error())
+				return nil, fmt.Errorf("failed to initialize payment gateway %s: %s", g.Name, err.Error())
+			}
+			svcOptions = append(svcOptions, paymentservice.WithGateway(g.Name, gateway))
+		}
+
+		paymentSvc, err = paymentservice.New(pgStore, authSvc, contentSvc, templateSvc, mediaSvc, entitlementSvc, svcOptions...)
 		if err != nil {
 			log.Printf("[payment-api-http] failed to initialize payment service: %s\n", err.Error())
 			return nil, fmt.Errorf("failed to initialize payment service: %s", err.Error())
 		}
 	}
 
+	// initialize notification service
+	var notificationSvc notification.Service
+	{
+		pgStore, err := notificationpgstore.New(pgRouter)
+		if err != nil {
+			log.Printf("[notification-api-http] failed to initialize notification postgresql store: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize notification postgresql store: %s", err.Error())
+		}
+
+		svcOptions := []notificationservice.Option{}
+		svcOptions = append(svcOptions, notificationservice.WithConfig(notificationservice.Config{
+			MaxAttempts: s.config.Notification.Dispatch.MaxAttempts,
+			BackoffBase: time.Duration(s.config.Notification.Dispatch.BackoffBase),
+			BackoffMax:  time.Duration(s.config.Notification.Dispatch.BackoffMax),
+			BatchSize:   s.config.Notification.Dispatch.BatchSize,
+		}))
+
+		for _, cfg := range s.config.Notification.Channels {
+			client, err := newNotifierClient(cfg)
+			if err != nil {
+				log.Printf("[notification-api-http] failed to initialize notification channel %s: %s\n", cfg.Name, err.Error())
+				return nil, fmt.Errorf("failed to initialize notification channel %s: %s", cfg.Name, err.Error())
+			}
+
+			svcOptions = append(svcOptions, notificationservice.WithChannel(cfg.Name, client))
+		}
+
+		notificationSvc, err = notificationservice.New(pgStore, authSvc, svcOptions...)
+		if err != nil {
+			log.Printf("[notification-api-http] failed to initialize notification service: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize notification service: %s", err.Error())
+		}
+	}
+
+	// initialize webhook service
+	var webhookSvc webhook.Service
+	{
+		pgStore, err := webhookpgstore.New(pgRouter)
+		if err != nil {
+			log.Printf("[webhook-api-http] failed to initialize webhook postgresql store: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize webhook postgresql store: %s", err.Error())
+		}
+
+		sender := webhooklib.NewClient(time.Duration(s.config.Webhook.Delivery.Timeout))
+
+		webhookSvc, err = webhookservice.New(pgStore, authSvc, sender, webhookservice.WithConfig(webhookservice.Config{
+			MaxAttempts:      s.config.Webhook.Delivery.MaxAttempts,
+			BackoffBase:      time.Duration(s.config.Webhook.Delivery.BackoffBase),
+			BackoffMax:       time.Duration(s.config.Webhook.Delivery.BackoffMax),
+			BatchSize:        s.config.Webhook.Delivery.BatchSize,
+			ClaimTimeout:     time.Duration(s.config.Webhook.Delivery.ClaimTimeout),
+			DisableThreshold: s.config.Webhook.Delivery.DisableThreshold,
+			AllowInsecureURL: s.config.Webhook.Delivery.AllowInsecureURL,
+		}))
+		if err != nil {
+			log.Printf("[webhook-api-http] failed to initialize webhook service: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize webhook service: %s", err.Error())
+		}
+	}
+
+	// initialize school HTTP handler, it also resolves the
+	// school of every request
+	{
+		var options []schoolhttphandler.Option
+		for scopeName, cfg := range s.config.School.HTTP {
+			options = append(options, schoolhttphandler.WithScopeSetting(scopeName, schoolhttphandler.ScopeSetting{
+				Timeout:   time.Duration(cfg.Timeout),
+				RateLimit: getRateLimit(cfg.RateLimit),
+			}))
+		}
+
+		options = append(options, schoolhttphandler.WithRateLimiter(rateLimiter))
+
+		options = append(options, schoolhttphandler.WithTenantSetting(schoolhttphandler.TenantSetting{
+			Header:     s.config.School.Tenant.Header,
+			BaseDomain: s.config.School.Tenant.BaseDomain,
+		}))
+
+		for _, identity := range schoolHTTPIdentities {
+			options = append(options, schoolhttphandler.WithHandler(identity))
+		}
+
+		schoolHTTP, err := schoolhttphandler.New(schoolSvc, authSvc, options...)
+		if err != nil {
+			log.Printf("[school-api-http] failed to initialize school http handlers: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize school http handlers: %s", err.Error())
+		}
+
+		s.handlers = append(s.handlers, schoolHTTP)
+	}
+
+	// initialize school config HTTP handler
+	{
+		var options []schoolconfighttphandler.Option
+		for scopeName, cfg := range s.config.SchoolConfig.HTTP {
+			options = append(options, schoolconfighttphandler.WithScopeSetting(scopeName, schoolconfighttphandler.ScopeSetting{
+				Timeout:   time.Duration(cfg.Timeout),
+				RateLimit: getRateLimit(cfg.RateLimit),
+			}))
+		}
+
+		options = append(options, schoolconfighttphandler.WithRateLimiter(rateLimiter))
+
+		for _, identity := range schoolConfigHTTPIdentities {
+			options = append(options, schoolconfighttphandler.WithHandler(identity))
+		}
+
+		schoolConfigHTTP, err := schoolconfighttphandler.New(schoolConfigSvc, authSvc, options...)
+		if err != nil {
+			log.Printf("[school-config-api-http] failed to initialize school config http handlers: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize school config http handlers: %s", err.Error())
+		}
+
+		s.handlers = append(s.handlers, schoolConfigHTTP)
+	}
+
 	// initialize auth HTTP handler
 	{
 		var options []authhttphandler.Option
 		for scopeName, cfg := range s.config.User.HTTP {
 			options = append(options, authhttphandler.WithScopeSetting(scopeName, authhttphandler.ScopeSetting{
-				Timeout: time.Duration(cfg.Timeout),
+				Timeout:   time.Duration(cfg.Timeout),
+				RateLimit: getRateLimit(cfg.RateLimit),
 			}))
 		}
 
-		identities := []authhttphandler.HandlerIdentity{
-			authhttphandler.HandlerLoginSocial,
-			authhttphandler.HandlerUser,
-		}
+		options = append(options, authhttphandler.WithRateLimiter(rateLimiter))
 
-		for _, identity := range identities {
+		for _, identity := range authHTTPIdentities {
 			options = append(options, authhttphandler.WithHandler(identity))
 		}
 
@@ -282,16 +708,15 @@ func new(opt Option) (*server, error) {
 		var options []contenthttphandler.Option
 		for scopeName, cfg := range s.config.Content.HTTP {
 			options = append(options, contenthttphandler.WithScopeSetting(scopeName, contenthttphandler.ScopeSetting{
-				Timeout: time.Duration(cfg.Timeout),
+				Timeout:   time.Duration(cfg.Timeout),
+				RateLimit: getRateLimit(cfg.RateLimit),
 			}))
 		}
 
-		identities := []contenthttphandler.HandlerIdentity{
-			contenthttphandler.HandlerContent,
-			contenthttphandler.HandlerContents,
-		}
+		options = append(options, contenthttphandler.WithRateLimiter(rateLimiter))
+		options = append(options, contenthttphandler.WithIdempotency(idempotency))
 
-		for _, identity := range identities {
+		for _, identity := range contentHTTPIdentities {
 			options = append(options, contenthttphandler.WithHandler(identity))
 		}
 
@@ -309,16 +734,15 @@ func new(opt Option) (*server, error) {
 		var options []templatehttphandler.Option
 		for scopeName, cfg := range s.config.Template.HTTP {
 			options = append(options, templatehttphandler.WithScopeSetting(scopeName, templatehttphandler.ScopeSetting{
-				Timeout: time.Duration(cfg.Timeout),
+				Timeout:   time.Duration(cfg.Timeout),
+				RateLimit: getRateLimit(cfg.RateLimit),
 			}))
 		}
 
-		identities := []templatehttphandler.HandlerIdentity{
-			templatehttphandler.HandlerTemplate,
-			templatehttphandler.HandlerTemplates,
-		}
+		options = append(options, templatehttphandler.WithRateLimiter(rateLimiter))
+		options = append(options, templatehttphandler.WithIdempotency(idempotency))
 
-		for _, identity := range identities {
+		for _, identity := range templateHTTPIdentities {
 			options = append(options, templatehttphandler.WithHandler(identity))
 		}
 
@@ -331,21 +755,138 @@ func new(opt Option) (*server, error) {
 		s.handlers = append(s.handlers, templateHTTP)
 	}
 
+	// initialize content job handler
+	{
+		contentJob, err := contentjobhandler.New(contentSvc, contentjobhandler.WithPurgeSetting(contentjobhandler.PurgeSetting{
+			Retention: time.Duration(s.config.Content.Trash.Retention),
+			Interval:  time.Duration(s.config.Content.Trash.PurgeInterval),
+		}), contentjobhandler.WithTenants(pgRouter.GetTenants()))
+		if err != nil {
+			log.Printf("[content-api-http] failed to initialize content job handlers: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize content job handlers: %s", err.Error())
+		}
+
+		s.workers = append(s.workers, contentJob)
+	}
+
+	// initialize template job handler
+	{
+		templateJob, err := templatejobhandler.New(templateSvc, templatejobhandler.WithPurgeSetting(templatejobhandler.PurgeSetting{
+			Retention: time.Duration(s.config.Template.Trash.Retention),
+			Interval:  time.Duration(s.config.Template.Trash.PurgeInterval),
+		}), templatejobhandler.WithTenants(pgRouter.GetTenants()))
+		if err != nil {
+			log.Printf("[template-api-http] failed to initialize template job handlers: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize template job handlers: %s", err.Error())
+		}
+
+		s.workers = append(s.workers, templateJob)
+	}
+
+	// initialize payment job handler
+	{
+		paymentJob, err := paymentjobhandler.New(paymentSvc, paymentjobhandler.WithSubscriptionSetting(paymentjobhandler.SubscriptionSetting{
+			Interval: time.Duration(s.config.Payment.Subscription.JobInterval),
+		}), paymentjobhandler.WithRefundSetting(paymentjobhandler.RefundSetting{
+			Interval: time.Duration(s.config.Payment.Refund.JobInterval),
+		}), paymentjobhandler.WithTenants(pgRouter.GetTenants()))
+		if err != nil {
+			log.Printf("[payment-api-http] failed to initialize payment job handlers: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize payment job handlers: %s", err.Error())
+		}
+
+		s.workers = append(s.workers, paymentJob)
+	}
+
+	// initialize event bus, side effects of a change are
+	// subscribed to its events instead of being called by the
+	// changing service
+	bus := eventlib.NewBus()
+
+	// initialize payment event handler
+	{
+		_, err := paymenteventhandler.New(paymentSvc, bus)
+		if err != nil {
+			log.Printf("[payment-api-http] failed to initialize payment event handlers: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize payment event handlers: %s", err.Error())
+		}
+	}
+
+	// initialize webhook event handler
+	{
+		_, err := webhookeventhandler.New(webhookSvc, bus)
+		if err != nil {
+			log.Printf("[webhook-api-http] failed to initialize webhook event handlers: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize webhook event handlers: %s", err.Error())
+		}
+	}
+
+	// initialize event outbox relays, one for each tenant as
+	// the events are written to the database of the school
+	for _, tenant := range pgRouter.GetTenants() {
+		pgDb, err := pgClientManager.GetDatabase(tenant)
+		if err != nil {
+			log.Printf("[memorify-api-http] failed to get postgresql database: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to get postgresql database: %s", err.Error())
+		}
+
+		relay, err := eventoutbox.NewRelay(pgDb, bus, eventoutbox.Config{
+			ConnectionString: s.config.PostgreSQL[tenant].ConnectionString,
+			PollInterval:     time.Duration(s.config.Event.Relay.PollInterval),
+			BatchSize:        s.config.Event.Relay.BatchSize,
+			MaxAttempts:      s.config.Event.Relay.MaxAttempts,
+			BackoffBase:      time.Duration(s.config.Event.Relay.BackoffBase),
+			BackoffMax:       time.Duration(s.config.Event.Relay.BackoffMax),
+			Tenant:           tenant,
+		})
+		if err != nil {
+			log.Printf("[memorify-api-http] failed to initialize event outbox relay: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize event outbox relay: %s", err.Error())
+		}
+
+		s.workers = append(s.workers, relay)
+	}
+
+	// initialize notification job handler
+	{
+		notificationJob, err := notificationjobhandler.New(notificationSvc, notificationjobhandler.WithDispatchSetting(notificationjobhandler.DispatchSetting{
+			Interval: time.Duration(s.config.Notification.Dispatch.Interval),
+		}), notificationjobhandler.WithTenants(pgRouter.GetTenants()))
+		if err != nil {
+			log.Printf("[notification-api-http] failed to initialize notification job handlers: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize notification job handlers: %s", err.Error())
+		}
+
+		s.workers = append(s.workers, notificationJob)
+	}
+
+	// initialize webhook job handler
+	{
+		webhookJob, err := webhookjobhandler.New(webhookSvc, webhookjobhandler.WithDeliverSetting(webhookjobhandler.DeliverSetting{
+			Interval: time.Duration(s.config.Webhook.Delivery.Interval),
+		}), webhookjobhandler.WithTenants(pgRouter.GetTenants()))
+		if err != nil {
+			log.Printf("[webhook-api-http] failed to initialize webhook job handlers: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize webhook job handlers: %s", err.Error())
+		}
+
+		s.workers = append(s.workers, webhookJob)
+	}
+
 	// initialize payment HTTP handler
 	{
 		var options []paymenthttphandler.Option
 		for scopeName, cfg := range s.config.Payment.HTTP {
 			options = append(options, paymenthttphandler.WithScopeSetting(scopeName, paymenthttphandler.ScopeSetting{
-				Timeout: time.Duration(cfg.Timeout),
+				Timeout:   time.Duration(cfg.Timeout),
+				RateLimit: getRateLimit(cfg.RateLimit),
 			}))
 		}
 
-		identities := []paymenthttphandler.HandlerIdentity{
-			paymenthttphandler.HandlerPayment,
-			paymenthttphandler.HandlerPayments,
-		}
+		options = append(options, paymenthttphandler.WithRateLimiter(rateLimiter))
+		options = append(options, paymenthttphandler.WithIdempotency(idempotency))
 
-		for _, identity := range identities {
+		for _, identity := range paymentHTTPIdentities {
 			options = append(options, paymenthttphandler.WithHandler(identity))
 		}
 
@@ -358,6 +899,85 @@ func new(opt Option) (*server, error) {
 		s.handlers = append(s.handlers, paymentHTTP)
 	}
 
+	// initialize media HTTP handler
+	{
+		var options []mediahttphandler.Option
+		for scopeName, cfg := range s.config.Media.HTTP {
+			options = append(options, mediahttphandler.WithScopeSetting(scopeName, mediahttphandler.ScopeSetting{
+				Timeout:   time.Duration(cfg.Timeout),
+				RateLimit: getRateLimit(cfg.RateLimit),
+			}))
+		}
+
+		options = append(options, mediahttphandler.WithRateLimiter(rateLimiter))
+
+		if s.config.Media.MaxUploadSize > 0 {
+			options = append(options, mediahttphandler.WithMaxUploadSize(s.config.Media.MaxUploadSize))
+		}
+
+		for _, identity := range mediaHTTPIdentities {
+			options = append(options, mediahttphandler.WithHandler(identity))
+		}
+
+		mediaHTTP, err := mediahttphandler.New(mediaSvc, authSvc, options...)
+		if err != nil {
+			log.Printf("[media-api-http] failed to initialize media http handlers: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize media http handlers: %s", err.Error())
+		}
+
+		s.handlers = append(s.handlers, mediaHTTP)
+	}
+
+	// initialize notification HTTP handler
+	{
+		var options []notificationhttphandler.Option
+		for scopeName, cfg := range s.config.Notification.HTTP {
+			options = append(options, notificationhttphandler.WithScopeSetting(scopeName, notificationhttphandler.ScopeSetting{
+				Timeout:   time.Duration(cfg.Timeout),
+				RateLimit: getRateLimit(cfg.RateLimit),
+			}))
+		}
+
+		options = append(options, notificationhttphandler.WithRateLimiter(rateLimiter))
+
+		for _, identity := range notificationHTTPIdentities {
+			options = append(options, notificationhttphandler.WithHandler(identity))
+		}
+
+		notificationHTTP, err := notificationhttphandler.New(notificationSvc, authSvc, options...)
+		if err != nil {
+			log.Printf("[notification-api-http] failed to initialize notification http handlers: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize notification http handlers: %s", err.Error())
+		}
+
+		s.handlers = append(s.handlers, notificationHTTP)
+	}
+
+	// initialize webhook HTTP handler
+	{
+		var options []webhookhttphandler.Option
+		for scopeName, cfg := range s.config.Webhook.HTTP {
+			options = append(options, webhookhttphandler.WithScopeSetting(scopeName, webhookhttphandler.ScopeSetting{
+				Timeout:   time.Duration(cfg.Timeout),
+				RateLimit: getRateLimit(cfg.RateLimit),
+			}))
+		}
+
+		options = append(options, webhookhttphandler.WithRateLimiter(rateLimiter))
+
+		for _, identity := range webhookHTTPIdentities {
+			options = append(options, webhookhttphandler.WithHandler(identity))
+		}
+
+		webhookHTTP, err := webhookhttphandler.New(webhookSvc, authSvc, options...)
+		if err != nil {
+			log.Printf("[webhook-api-http] failed to initialize webhook http handlers: %s\n", err.Error())
+			return nil, fmt.Errorf("failed to initialize webhook http handlers: %s", err.Error())
+		}
+
+		s.handlers = append(s.handlers, webhookHTTP)
+	}
+
 	return s, nil
 }
 
@@ -367,7 +987,7 @@ func (s *server) start() int {
 
 	// create multiplexer object
 	rootMux := mux.NewRouter()
-	appMux := rootMux.PathPrefix("/tenant").Subrouter()
+	appMux := rootMux.PathPrefix(appPathPrefix).Subrouter()
 
 	// use middlewares to app mux only
 	// appMux.Use(prometheuslib.GetHTTPHandlerMiddleware("memorify-api-http"))
@@ -380,6 +1000,30 @@ func (s *server) start() int {
 		}
 	}
 
+	// authenticate the requests after their tenant is resolved
+	// by the school handler, the token is valid for its school
+	appMux.Use(s.authenticate)
+
+	// serve the document of the started handlers
+	if err := s.startOpenAPI(rootMux, appPathPrefix); err != nil {
+		log.Printf("[memorify-api-http] failed to start openapi: %s\n", err.Error())
+		return CodeFailServeHTTP
+	}
+
+	// starts background jobs
+	for _, w := range s.workers {
+		if err := w.Start(); err != nil {
+			log.Printf("[memorify-api-http] failed to start worker: %s\n", err.Error())
+			return CodeFailServeHTTP
+		}
+	}
+
+	// serve storage objects through signed URLs, if needed
+	if s.storageHandler != nil && s.storageMountPath != "" {
+		prefix := strings.TrimRight(s.storageMountPath, "/")
+		rootMux.PathPrefix(prefix + "/").Handler(http.StripPrefix(prefix, s.storageHandler))
+	}
+
 	// handle prometheus pull endpoint
 	// rootMux.Handle("/metrics", promhttp.Handler())
 
@@ -389,6 +1033,19 @@ func (s *server) start() int {
 	// serve using graceful mechanism
 	address := fmt.Sprintf(":%d", s.config.Server.Port)
 	err := graceful.ServeHTTP(s.srv, address, 0)
+
+	// stop background jobs after no more request is served
+	for _, w := range s.workers {
+		w.Stop()
+	}
+
+	// close resources after no more request is served
+	for _, c := range s.closers {
+		if err := c.Close(); err != nil {
+			log.Printf("[memorify-api-http] failed to close resource: %s\n", err.Error())
+		}
+	}
+
 	if err != nil {
 		log.Printf("[memorify-api-http] failed to start server: %s\n", err.Error())
 		return CodeFailServeHTTP
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"fmt"
+	"hbdtoyou/cmd/hbdtoyou-api-http/config"
+	"hbdtoyou/pkg/storage"
+	storagelocalfile "hbdtoyou/pkg/storage/client/localfile"
+	storages3 "hbdtoyou/pkg/storage/client/s3"
+	"net/http"
+)
+
+// newStorageClient creates a storage client based on the
+// given storage config.
+//
+// For storage that is not reachable from outside, it also
+// returns an HTTP handler to serve the stored objects through
+// signed URLs. Otherwise, the returned handler is nil.
+func newStorageClient(cfg config.MediaStorage) (storage.Client, http.Handler, error) {
+	switch cfg.Type {
+	case config.MediaStorageTypeLocalFile:
+		client, err := storagelocalfile.New(storagelocalfile.Config{
+			RootDir:    cfg.LocalFile.RootDir,
+			BaseURL:    cfg.LocalFile.BaseURL,
+			SigningKey: cfg.LocalFile.SigningKey,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return client, client, nil
+
+	case config.MediaStorageTypeS3:
+		client, err := storages3.New(storages3.Config{
+			Endpoint:        cfg.S3.Endpoint,
+			Region:          cfg.S3.Region,
+			Bucket:          cfg.S3.Bucket,
+			AccessKeyID:     cfg.S3.AccessKeyID,
+			SecretAccessKey: cfg.S3.SecretAccessKey,
+			UseSSL:          cfg.S3.UseSSL,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return client, nil, nil
+	}
+
+	return nil, nil, fmt.Errorf("unknown storage type: %s", cfg.Type)
+}
@@ -0,0 +1,18 @@
+-- media stores files uploaded by users, e.g. card photos and
+-- proof of payment images. The file itself is stored in the
+-- configured object storage under storage_key.
+CREATE TABLE IF NOT EXISTS media (
+	id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	user_id     UUID NOT NULL REFERENCES user_info (id),
+	file_name   TEXT NOT NULL,
+	mime_type   TEXT NOT NULL,
+	size        BIGINT NOT NULL,
+	storage_key TEXT NOT NULL UNIQUE,
+	create_time TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS media_user_id_idx ON media (user_id);
+
+-- payments and contents reference uploaded media
+ALTER TABLE payment ADD COLUMN IF NOT EXISTS proof_payment_media_id UUID REFERENCES media (id) ON DELETE SET NULL;
+ALTER TABLE content ADD COLUMN IF NOT EXISTS media_ids UUID[] NOT NULL DEFAULT '{}';
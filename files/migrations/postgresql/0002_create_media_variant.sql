@@ -0,0 +1,20 @@
+-- uploaded images are processed in the background, status
+-- tracks the processing: 1 = processing, 2 = ready, 3 = failed.
+-- Media uploaded before this migration are already ready.
+ALTER TABLE media ADD COLUMN IF NOT EXISTS status SMALLINT NOT NULL DEFAULT 2;
+
+-- media_variant stores resized versions of an uploaded image,
+-- e.g. thumbnail, card and full.
+CREATE TABLE IF NOT EXISTS media_variant (
+	media_id    UUID NOT NULL REFERENCES media (id) ON DELETE CASCADE,
+	name        TEXT NOT NULL,
+	mime_type   TEXT NOT NULL,
+	width       INTEGER NOT NULL,
+	height      INTEGER NOT NULL,
+	size        BIGINT NOT NULL,
+	storage_key TEXT NOT NULL UNIQUE,
+	PRIMARY KEY (media_id, name)
+);
+
+-- templates may use an uploaded image as thumbnail
+ALTER TABLE template ADD COLUMN IF NOT EXISTS thumbnail_media_id UUID REFERENCES media (id) ON DELETE SET NULL;
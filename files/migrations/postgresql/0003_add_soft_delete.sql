@@ -0,0 +1,8 @@
+-- contents and templates are soft deleted, a deleted row stays
+-- in trash until it is purged after the configured retention.
+ALTER TABLE content ADD COLUMN IF NOT EXISTS delete_time TIMESTAMPTZ;
+ALTER TABLE template ADD COLUMN IF NOT EXISTS delete_time TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS content_delete_time_idx ON content (delete_time) WHERE delete_time IS NOT NULL;
+CREATE INDEX IF NOT EXISTS template_delete_time_idx ON template (delete_time) WHERE delete_time IS NOT NULL;
+CREATE INDEX IF NOT EXISTS content_template_id_idx ON content (template_id);
@@ -0,0 +1,6 @@
+-- version is incremented on every update and used as ETag, an
+-- update with an outdated version is refused.
+ALTER TABLE content ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE template ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE payment ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE user_info ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,12 @@
+-- content_revision stores previous states of a content, saved
+-- on every update so the changes can be undone.
+CREATE TABLE IF NOT EXISTS content_revision (
+	content_id               UUID NOT NULL REFERENCES content (id) ON DELETE CASCADE,
+	number                   INTEGER NOT NULL,
+	user_id                  UUID NOT NULL,
+	template_id              UUID NOT NULL,
+	detail_content_json_text TEXT NOT NULL,
+	status                   SMALLINT NOT NULL,
+	create_time              TIMESTAMPTZ NOT NULL,
+	PRIMARY KEY (content_id, number)
+);
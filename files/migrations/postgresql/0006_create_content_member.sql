@@ -0,0 +1,31 @@
+-- content_member stores users contributing on a content and
+-- invitations to do so. An invitation is sent by email and
+-- user_id is only set once it is accepted.
+-- role: 1 = viewer, 2 = editor, 3 = owner.
+-- status: 1 = pending, 2 = accepted, 3 = declined.
+CREATE TABLE IF NOT EXISTS content_member (
+	id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	content_id  UUID NOT NULL REFERENCES content (id) ON DELETE CASCADE,
+	user_id     UUID REFERENCES user_info (id) ON DELETE CASCADE,
+	email       TEXT NOT NULL,
+	role        SMALLINT NOT NULL,
+	status      SMALLINT NOT NULL,
+	invited_by  UUID REFERENCES user_info (id) ON DELETE SET NULL,
+	create_time TIMESTAMPTZ NOT NULL,
+	update_time TIMESTAMPTZ
+);
+
+-- an email can only be invited once per content, unless the
+-- previous invitation was declined
+CREATE UNIQUE INDEX IF NOT EXISTS content_member_content_id_email_idx ON content_member (content_id, email) WHERE status <> 3;
+CREATE INDEX IF NOT EXISTS content_member_user_id_idx ON content_member (user_id);
+CREATE INDEX IF NOT EXISTS content_member_email_idx ON content_member (email);
+
+-- existing content users are the owners of their contents
+INSERT INTO content_member (content_id, user_id, email, role, status, create_time)
+SELECT c.id, c.user_id, LOWER(TRIM(u.email)), 3, 2, c.create_time
+FROM content c
+JOIN user_info u ON u.id = c.user_id
+WHERE NOT EXISTS (
+	SELECT 1 FROM content_member cm WHERE cm.content_id = c.id AND cm.role = 3
+);
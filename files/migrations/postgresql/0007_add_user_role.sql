@@ -0,0 +1,4 @@
+-- role grants access to data of other users:
+-- 1 = user, 2 = admin. Admins are assigned directly in the
+-- database.
+ALTER TABLE user_info ADD COLUMN IF NOT EXISTS role SMALLINT NOT NULL DEFAULT 1;
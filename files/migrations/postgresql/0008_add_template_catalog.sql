@@ -0,0 +1,22 @@
+-- templates are browsed by category (occasion) and tags, and
+-- searched by name and description.
+-- category: 1 = birthday, 2 = anniversary, 3 = graduation,
+-- 4 = wedding, 5 = other. Existing templates are birthday
+-- templates.
+ALTER TABLE template ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+ALTER TABLE template ADD COLUMN IF NOT EXISTS category SMALLINT NOT NULL DEFAULT 1;
+ALTER TABLE template ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- featured templates are listed first ordered by
+-- featured_order, NULL means not featured.
+ALTER TABLE template ADD COLUMN IF NOT EXISTS featured_order INTEGER;
+
+ALTER TABLE template ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+	setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+	setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS template_category_idx ON template (category);
+CREATE INDEX IF NOT EXISTS template_tags_idx ON template USING GIN (tags);
+CREATE INDEX IF NOT EXISTS template_search_vector_idx ON template USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS template_featured_order_idx ON template (featured_order, create_time DESC);
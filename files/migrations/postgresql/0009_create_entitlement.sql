@@ -0,0 +1,51 @@
+-- templates are sold individually, price is in the minor unit
+-- of currency. A premium template without a price can only be
+-- used with a bundle quota.
+ALTER TABLE template ADD COLUMN IF NOT EXISTS price BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE template ADD COLUMN IF NOT EXISTS currency TEXT NOT NULL DEFAULT '';
+
+-- a payment purchases a product.
+-- product_type: 1 = template, 2 = content, 3 = bundle.
+-- product_id is the template ID for template and content
+-- products, or the configured bundle ID.
+ALTER TABLE payment ADD COLUMN IF NOT EXISTS product_type SMALLINT NOT NULL DEFAULT 2;
+ALTER TABLE payment ADD COLUMN IF NOT EXISTS product_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE payment ADD COLUMN IF NOT EXISTS currency TEXT NOT NULL DEFAULT '';
+ALTER TABLE payment ADD COLUMN IF NOT EXISTS quota INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE payment ALTER COLUMN content_id DROP NOT NULL;
+
+-- existing payments purchased the template of their content
+UPDATE payment p
+SET product_id = c.template_id::text
+FROM content c
+WHERE c.id = p.content_id AND p.product_id = '';
+
+-- entitlement allows a user to use paid templates. It is
+-- granted by an approved payment, and revoked when the payment
+-- is rejected afterwards.
+-- type: 1 = template, 2 = content, 3 = quota.
+CREATE TABLE IF NOT EXISTS entitlement (
+	id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	user_id     UUID NOT NULL REFERENCES user_info (id) ON DELETE CASCADE,
+	payment_id  UUID REFERENCES payment (id),
+	type        SMALLINT NOT NULL,
+	template_id UUID REFERENCES template (id) ON DELETE CASCADE,
+	content_id  UUID REFERENCES content (id) ON DELETE CASCADE,
+	quota       INTEGER NOT NULL DEFAULT 0,
+	used_quota  INTEGER NOT NULL DEFAULT 0,
+	create_time TIMESTAMPTZ NOT NULL,
+	revoke_time TIMESTAMPTZ
+);
+
+-- a payment grants at most one active entitlement
+CREATE UNIQUE INDEX IF NOT EXISTS entitlement_payment_id_idx ON entitlement (payment_id) WHERE revoke_time IS NULL;
+CREATE INDEX IF NOT EXISTS entitlement_user_id_idx ON entitlement (user_id, type) WHERE revoke_time IS NULL;
+
+-- premium users keep their remaining quota
+INSERT INTO entitlement (user_id, type, quota, create_time)
+SELECT u.id, 3, u.quota, NOW()
+FROM user_info u
+WHERE u.type = 2 AND u.quota > 0
+AND NOT EXISTS (
+	SELECT 1 FROM entitlement e WHERE e.user_id = u.id AND e.type = 3 AND e.payment_id IS NULL
+);
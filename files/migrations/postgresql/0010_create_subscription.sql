@@ -0,0 +1,45 @@
+-- plan is a recurring product, price is in the minor unit of
+-- currency. A paid period grants the quota until it ends.
+-- interval: 1 = monthly, 2 = yearly.
+CREATE TABLE IF NOT EXISTS plan (
+	id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	name        TEXT NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	price       INTEGER NOT NULL,
+	currency    TEXT NOT NULL,
+	interval    SMALLINT NOT NULL,
+	quota       INTEGER NOT NULL DEFAULT 0,
+	active      BOOLEAN NOT NULL DEFAULT TRUE,
+	version     BIGINT NOT NULL DEFAULT 1,
+	create_time TIMESTAMPTZ NOT NULL,
+	update_time TIMESTAMPTZ
+);
+
+-- subscription is a user subscribing to a plan. It becomes
+-- active once its first payment is approved, and is renewed by
+-- a pending payment created ahead of end_time.
+-- status: 1 = pending, 2 = active, 3 = lapsed, 4 = canceled.
+CREATE TABLE IF NOT EXISTS subscription (
+	id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	user_id     UUID NOT NULL REFERENCES user_info (id) ON DELETE CASCADE,
+	plan_id     UUID NOT NULL REFERENCES plan (id),
+	status      SMALLINT NOT NULL,
+	auto_renew  BOOLEAN NOT NULL DEFAULT TRUE,
+	start_time  TIMESTAMPTZ,
+	end_time    TIMESTAMPTZ,
+	renew_time  TIMESTAMPTZ,
+	version     BIGINT NOT NULL DEFAULT 1,
+	create_time TIMESTAMPTZ NOT NULL,
+	update_time TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS subscription_user_id_idx ON subscription (user_id, plan_id);
+CREATE INDEX IF NOT EXISTS subscription_renew_time_idx ON subscription (status, renew_time);
+CREATE INDEX IF NOT EXISTS subscription_end_time_idx ON subscription (status, end_time);
+
+-- a payment of plan product pays a period of the subscription
+ALTER TABLE payment ADD COLUMN IF NOT EXISTS subscription_id UUID REFERENCES subscription (id);
+
+-- entitlements granted by a subscription end with the paid
+-- period
+ALTER TABLE entitlement ADD COLUMN IF NOT EXISTS expire_time TIMESTAMPTZ;
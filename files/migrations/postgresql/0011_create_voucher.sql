@@ -0,0 +1,32 @@
+-- voucher is a promotion code giving a discount on payments.
+-- discount_type: 1 = percentage, 2 = fixed amount in the minor
+-- unit of currency.
+-- Zero limits are unlimited, and empty template_ids and
+-- plan_ids apply to any product.
+CREATE TABLE IF NOT EXISTS voucher (
+	id                       UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	code                     TEXT NOT NULL,
+	discount_type            SMALLINT NOT NULL,
+	discount_value           INTEGER NOT NULL,
+	currency                 TEXT NOT NULL DEFAULT '',
+	start_time               TIMESTAMPTZ,
+	end_time                 TIMESTAMPTZ,
+	max_redemptions          INTEGER NOT NULL DEFAULT 0,
+	max_redemptions_per_user INTEGER NOT NULL DEFAULT 0,
+	template_ids             TEXT[] NOT NULL DEFAULT '{}',
+	plan_ids                 TEXT[] NOT NULL DEFAULT '{}',
+	active                   BOOLEAN NOT NULL DEFAULT TRUE,
+	version                  BIGINT NOT NULL DEFAULT 1,
+	create_time              TIMESTAMPTZ NOT NULL,
+	update_time              TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS voucher_code_idx ON voucher (code);
+
+-- a payment redeeming a voucher, the amount is the price of
+-- the product less the discount. Payments that are not
+-- rejected count as redemptions.
+ALTER TABLE payment ADD COLUMN IF NOT EXISTS voucher_id UUID REFERENCES voucher (id);
+ALTER TABLE payment ADD COLUMN IF NOT EXISTS discount INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS payment_voucher_id_idx ON payment (voucher_id, user_id) WHERE voucher_id IS NOT NULL;
@@ -0,0 +1,11 @@
+-- amounts are stored in the minor unit of their currency as
+-- 64-bit integers, so large amounts in zero-decimal currencies
+-- do not overflow.
+ALTER TABLE payment ALTER COLUMN amount TYPE BIGINT;
+ALTER TABLE payment ALTER COLUMN discount TYPE BIGINT;
+ALTER TABLE plan ALTER COLUMN price TYPE BIGINT;
+
+-- payments created before currencies were recorded were paid
+-- in rupiah
+UPDATE payment SET currency = 'IDR' WHERE currency = '';
+UPDATE template SET currency = 'IDR' WHERE currency = '' AND price > 0;
@@ -0,0 +1,30 @@
+-- invoice_sequence holds the last issued invoice sequence of a
+-- year. The row is locked by the issuing transaction, so invoice
+-- numbers have no gaps.
+CREATE TABLE IF NOT EXISTS invoice_sequence (
+	year          INTEGER PRIMARY KEY,
+	last_sequence INTEGER NOT NULL
+);
+
+-- invoice is issued once a payment is approved. The user and
+-- items are a snapshot at issue time, amounts are in the minor
+-- unit of currency.
+CREATE TABLE IF NOT EXISTS invoice (
+	id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	payment_id  UUID NOT NULL UNIQUE REFERENCES payment (id),
+	user_id     UUID NOT NULL,
+	number      TEXT NOT NULL UNIQUE,
+	year        INTEGER NOT NULL,
+	sequence    INTEGER NOT NULL,
+	user_name   TEXT NOT NULL DEFAULT '',
+	user_email  TEXT NOT NULL DEFAULT '',
+	items       JSONB NOT NULL,
+	subtotal    BIGINT NOT NULL,
+	discount    BIGINT NOT NULL DEFAULT 0,
+	total       BIGINT NOT NULL,
+	currency    TEXT NOT NULL,
+	issue_time  TIMESTAMPTZ NOT NULL,
+	UNIQUE (year, sequence)
+);
+
+CREATE INDEX IF NOT EXISTS invoice_user_id_idx ON invoice (user_id);
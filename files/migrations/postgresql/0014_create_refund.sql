@@ -0,0 +1,23 @@
+-- payments processed by a payment gateway keep its reference
+-- there, and track the total amount refunded.
+-- status: 4 = refunded.
+ALTER TABLE payment ADD COLUMN IF NOT EXISTS refunded_amount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE payment ADD COLUMN IF NOT EXISTS gateway TEXT NOT NULL DEFAULT '';
+ALTER TABLE payment ADD COLUMN IF NOT EXISTS gateway_reference TEXT NOT NULL DEFAULT '';
+
+-- refund is a refund of a payment made by an administrator,
+-- amount is in the minor unit of currency.
+-- type: 1 = refund, 2 = chargeback.
+CREATE TABLE IF NOT EXISTS refund (
+	id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	payment_id        UUID NOT NULL REFERENCES payment (id),
+	type              SMALLINT NOT NULL,
+	amount            BIGINT NOT NULL,
+	currency          TEXT NOT NULL,
+	reason            TEXT NOT NULL,
+	actor_id          UUID NOT NULL,
+	gateway_reference TEXT NOT NULL DEFAULT '',
+	create_time       TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS refund_payment_id_idx ON refund (payment_id);
@@ -0,0 +1,8 @@
+-- reviewer_id is the administrator claiming a pending payment
+-- at claim_time, or the last one approving or rejecting it.
+ALTER TABLE payment ADD COLUMN IF NOT EXISTS reviewer_id UUID REFERENCES user_info (id);
+ALTER TABLE payment ADD COLUMN IF NOT EXISTS claim_time TIMESTAMPTZ;
+ALTER TABLE payment ADD COLUMN IF NOT EXISTS review_reason TEXT NOT NULL DEFAULT '';
+
+-- the review queue lists pending payments, the oldest first
+CREATE INDEX IF NOT EXISTS payment_status_date_idx ON payment (status, date);
@@ -0,0 +1,27 @@
+-- notification is the outbox of notifications to users, written
+-- in the same transaction as the notified change, and the in-app
+-- inbox of the users.
+-- event: 1 = payment approved, 2 = payment rejected,
+-- 3 = content published.
+-- status: 1 = pending, 2 = sent, 3 = failed.
+-- sent_channels are the channels the notification has been sent
+-- through, so a retry only sends through the failed ones.
+CREATE TABLE IF NOT EXISTS notification (
+	id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	user_id           UUID NOT NULL REFERENCES user_info (id) ON DELETE CASCADE,
+	event             SMALLINT NOT NULL,
+	subject           TEXT NOT NULL,
+	body              TEXT NOT NULL,
+	data              JSONB NOT NULL DEFAULT '{}',
+	status            SMALLINT NOT NULL,
+	sent_channels     TEXT[] NOT NULL DEFAULT '{}',
+	attempts          INT NOT NULL DEFAULT 0,
+	next_attempt_time TIMESTAMPTZ NOT NULL,
+	last_error        TEXT,
+	create_time       TIMESTAMPTZ NOT NULL,
+	send_time         TIMESTAMPTZ,
+	read_time         TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS notification_pending_idx ON notification (next_attempt_time) WHERE status = 1;
+CREATE INDEX IF NOT EXISTS notification_user_id_idx ON notification (user_id, create_time DESC);
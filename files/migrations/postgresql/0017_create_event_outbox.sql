@@ -0,0 +1,19 @@
+-- event_outbox holds domain events, written in the same
+-- transaction as the change they denote, e.g. payment.approved.
+-- The relay publishes them once committed, and is woken up
+-- through the event_outbox notification channel.
+-- payload is the JSON encoded detail of the change.
+CREATE TABLE IF NOT EXISTS event_outbox (
+	id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	type              TEXT NOT NULL,
+	aggregate_id      TEXT NOT NULL,
+	payload           JSONB NOT NULL,
+	attempts          INT NOT NULL DEFAULT 0,
+	next_attempt_time TIMESTAMPTZ NOT NULL,
+	last_error        TEXT,
+	create_time       TIMESTAMPTZ NOT NULL,
+	publish_time      TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS event_outbox_unpublished_idx ON event_outbox (next_attempt_time) WHERE publish_time IS NULL;
+CREATE INDEX IF NOT EXISTS event_outbox_aggregate_id_idx ON event_outbox (aggregate_id, create_time);
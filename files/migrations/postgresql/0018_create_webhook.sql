@@ -0,0 +1,45 @@
+-- webhook is an endpoint of an integrator subscribed to event
+-- types, e.g. payment.approved. Requests to the endpoint are
+-- signed with the secret.
+-- status: 1 = active, 2 = disabled.
+-- failure_count is the number of consecutive failed requests, the
+-- webhook is disabled once it reaches the configured threshold.
+CREATE TABLE IF NOT EXISTS webhook (
+	id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	url           TEXT NOT NULL,
+	secret        TEXT NOT NULL,
+	event_types   TEXT[] NOT NULL,
+	status        SMALLINT NOT NULL,
+	failure_count INT NOT NULL DEFAULT 0,
+	disable_time  TIMESTAMPTZ,
+	created_by    UUID NOT NULL REFERENCES user_info (id),
+	create_time   TIMESTAMPTZ NOT NULL,
+	update_time   TIMESTAMPTZ
+);
+
+-- webhook_delivery is a delivery of an event to a webhook, and
+-- the log of its last response.
+-- status: 1 = pending, 2 = succeeded, 3 = failed.
+-- payload is kept as text, so every attempt sends the same bytes.
+-- redelivery_of is the redelivered delivery of a manual
+-- redelivery, an event is otherwise delivered once per webhook.
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+	id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	webhook_id        UUID NOT NULL REFERENCES webhook (id) ON DELETE CASCADE,
+	event_id          TEXT NOT NULL,
+	event_type        TEXT NOT NULL,
+	payload           TEXT NOT NULL,
+	status            SMALLINT NOT NULL,
+	attempts          INT NOT NULL DEFAULT 0,
+	next_attempt_time TIMESTAMPTZ NOT NULL,
+	response_code     INT,
+	response_body     TEXT,
+	last_error        TEXT,
+	redelivery_of     UUID REFERENCES webhook_delivery (id) ON DELETE CASCADE,
+	create_time       TIMESTAMPTZ NOT NULL,
+	deliver_time      TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS webhook_delivery_event_idx ON webhook_delivery (webhook_id, event_id) WHERE redelivery_of IS NULL;
+CREATE INDEX IF NOT EXISTS webhook_delivery_pending_idx ON webhook_delivery (next_attempt_time) WHERE status = 1;
+CREATE INDEX IF NOT EXISTS webhook_delivery_webhook_id_idx ON webhook_delivery (webhook_id, create_time DESC);
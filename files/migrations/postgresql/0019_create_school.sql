@@ -0,0 +1,16 @@
+-- school is the registry of tenants, kept in the default
+-- database only. The data of a school is kept in its own
+-- database, tenant is the name of its PostgreSQL client in the
+-- config, and the other migrations are applied to it as well.
+-- status: 1 = active, 2 = inactive.
+CREATE TABLE IF NOT EXISTS school (
+	id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	code        TEXT NOT NULL,
+	name        TEXT NOT NULL,
+	tenant      TEXT NOT NULL,
+	status      SMALLINT NOT NULL,
+	create_time TIMESTAMPTZ NOT NULL,
+	update_time TIMESTAMPTZ,
+	CONSTRAINT school_code_key UNIQUE (code),
+	CONSTRAINT school_tenant_key UNIQUE (tenant)
+);
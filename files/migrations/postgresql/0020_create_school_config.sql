@@ -0,0 +1,13 @@
+-- school_config is a setting of a school, kept in the default
+-- database along with school. value is JSON matching the schema
+-- of its key.
+-- key: 1 = branding, 2 = allowed_templates, 3 = default_quota,
+-- 4 = payment_bank_account.
+CREATE TABLE IF NOT EXISTS school_config (
+	school_id   UUID NOT NULL REFERENCES school (id) ON DELETE CASCADE,
+	key         SMALLINT NOT NULL,
+	value       TEXT NOT NULL,
+	create_time TIMESTAMPTZ NOT NULL,
+	update_time TIMESTAMPTZ,
+	PRIMARY KEY (school_id, key)
+);
@@ -0,0 +1,13 @@
+-- rate_limit_bucket is a token bucket of the HTTP rate limits,
+-- kept in the default database and shared by all instances.
+-- key is the scope with the user ID or client IP, a bucket is
+-- deleted once expired as it is full again by then.
+CREATE TABLE IF NOT EXISTS rate_limit_bucket (
+	key         TEXT PRIMARY KEY,
+	tokens      DOUBLE PRECISION NOT NULL,
+	allowed     BOOLEAN NOT NULL,
+	update_time TIMESTAMPTZ NOT NULL,
+	expire_time TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS rate_limit_bucket_expire_time_idx ON rate_limit_bucket (expire_time);
@@ -0,0 +1,16 @@
+-- idempotency_record is the response of a request sent with an
+-- Idempotency-Key header, kept in the default database and
+-- replayed on retries by all instances. key is the scope with
+-- the user ID, path and the header value. status_code is NULL
+-- while the first request is being handled.
+CREATE TABLE IF NOT EXISTS idempotency_record (
+	key         TEXT PRIMARY KEY,
+	fingerprint TEXT NOT NULL,
+	status_code INTEGER,
+	header      TEXT,
+	body        BYTEA,
+	create_time TIMESTAMPTZ NOT NULL,
+	expire_time TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_record_expire_time_idx ON idempotency_record (expire_time);
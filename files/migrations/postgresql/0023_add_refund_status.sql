@@ -0,0 +1,5 @@
+-- a refund made by a gateway is saved as pending before the
+-- gateway is requested, and updated once the gateway replied.
+-- status: 1 = pending, 2 = completed, 3 = failed.
+ALTER TABLE refund ADD COLUMN IF NOT EXISTS status SMALLINT NOT NULL DEFAULT 2;
+ALTER TABLE refund ADD COLUMN IF NOT EXISTS update_time TIMESTAMPTZ;
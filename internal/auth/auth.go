@@ -21,6 +21,9 @@ type Service interface {
 	// except ID, and CreateTime. So, make sure to
 	// use current values in the given data if do not want to
 	// update some specific attributes.
+	//
+	// The given version must be the current version of the
+	// user, otherwise ErrVersionConflict is returned.
 	UpdateUser(ctx context.Context, reqUser User) error
 
 	// ValidateToken validates the given token and returns the
@@ -42,10 +45,17 @@ type User struct {
 	Email      string
 	Type       Type
 	Quota      int
+	Role       Role
+	Version    int64
 	CreateTime time.Time
 	UpdateTime time.Time
 }
 
+// IsAdmin returns whether the user is an administrator.
+func (u User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
 type TokenData struct {
 	UserID   string
 	Fullname string
@@ -53,6 +63,10 @@ type TokenData struct {
 	Email    string
 	Type     Type
 	Quota    int
+
+	// SchoolID is the school the token is issued for, empty
+	// for tokens issued without a school.
+	SchoolID string
 }
 
 type Type int
@@ -80,7 +94,48 @@ func (t Type) Value() int {
 	return int(t)
 }
 
+// Role denotes access role of a user. Role is not changed by
+// UpdateUser, administrators are assigned directly in the
+// database.
+type Role int
+
+const (
+	RoleUnknown Role = 0
+	RoleUser    Role = 1
+	RoleAdmin   Role = 2
+)
+
+var (
+	RoleName = map[Role]string{
+		RoleUser:  "user",
+		RoleAdmin: "admin",
+	}
+)
+
+func (r Role) String() string {
+	return RoleName[r]
+}
+
+func (r Role) Value() int {
+	return int(r)
+}
+
 type GetUserAuthFilter struct {
 	Email  string
 	UserID string
 }
+
+// Followings are the known user event types, written to the
+// event outbox along with the user change.
+const (
+	// EventUserRegistered is written when a user signs in
+	// for the first time.
+	EventUserRegistered = "user.registered"
+)
+
+// UserEvent is the payload of user events.
+type UserEvent struct {
+	UserID   string `json:"user_id"`
+	Fullname string `json:"fullname"`
+	Email    string `json:"email"`
+}
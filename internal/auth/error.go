@@ -15,4 +15,8 @@ var (
 
 	ErrInvalidToken = errors.New("invalid token")
 	ErrExpiredToken = errors.New("expired token")
+
+	// ErrVersionConflict is returned when updating a user
+	// with an outdated version.
+	ErrVersionConflict = errors.New("version conflict")
 )
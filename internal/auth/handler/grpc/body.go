@@ -0,0 +1,34 @@
+package grpc
+
+import (
+	"hbdtoyou/internal/auth"
+	authv1 "hbdtoyou/pkg/pb/auth/v1"
+)
+
+// userUpdatePaths are the paths of a user allowed in an
+// update mask. Email is verified on login and quota is given
+// by payments, neither is updated by the user itself.
+var userUpdatePaths = []string{"fullname"}
+
+func formatUser(u auth.User) *authv1.User {
+	return &authv1.User{
+		Id:       u.ID,
+		Fullname: u.Fullname,
+		Email:    u.Email,
+		Type:     authv1.UserType(u.Type),
+		Quota:    int32(u.Quota),
+		Role:     authv1.UserRole(u.Role),
+		Version:  u.Version,
+	}
+}
+
+// parseUser sets the fields of the given paths from the
+// request user to out.
+func parseUser(in *authv1.User, paths []string, out *auth.User) {
+	for _, path := range paths {
+		switch path {
+		case "fullname":
+			out.Fullname = in.GetFullname()
+		}
+	}
+}
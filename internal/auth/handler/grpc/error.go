@@ -0,0 +1,113 @@
+package grpc
+
+import (
+	"context"
+	"hbdtoyou/internal/auth"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Followings are the known errors from auth gRPC handlers.
+var (
+	// errBadRequest is returned when the given request is
+	// bad/invalid.
+	errBadRequest = status.Error(codes.InvalidArgument, "BAD_REQUEST")
+
+	// errExpiredToken is returned when the given token is
+	// expired.
+	errExpiredToken = status.Error(codes.Unauthenticated, "EXPIRED_TOKEN")
+
+	// errInternalServer is returned when there is an
+	// unexpected error encountered when processing a request.
+	errInternalServer = status.Error(codes.Internal, "INTERNAL_SERVER_ERROR")
+
+	// errUserAlreadyExist is returned when the given
+	// user already exist based on the predefined
+	// unique constraints.
+	errUserAlreadyExist = status.Error(codes.AlreadyExists, "USER_ALREADY_EXIST")
+
+	// errDataNotFound is returned when the desired data is
+	// not found.
+	errDataNotFound = status.Error(codes.NotFound, "DATA_NOT_FOUND")
+
+	// errInvalidToken is returned when the given token is
+	// invalid.
+	errInvalidToken = status.Error(codes.Unauthenticated, "INVALID_TOKEN")
+
+	// errInvalidUserID is returned when the given user ID is
+	// invalid.
+	errInvalidUserID = status.Error(codes.InvalidArgument, "INVALID_USER_ID")
+
+	// errInvalidEmail is returned when the given email is
+	// invalid.
+	errInvalidEmail = status.Error(codes.InvalidArgument, "INVALID_EMAIL")
+
+	// errInvalidTokenEmail is returned when the given token
+	// email is invalid.
+	errInvalidTokenEmail = status.Error(codes.Unauthenticated, "INVALID_TOKEN_EMAIL")
+
+	// errInvalidUpdateMask is returned when the given update
+	// mask is empty or has a path not allowed to be updated.
+	errInvalidUpdateMask = status.Error(codes.InvalidArgument, "INVALID_UPDATE_MASK")
+
+	// errPreconditionRequired is returned when updating
+	// without the current version.
+	errPreconditionRequired = status.Error(codes.FailedPrecondition, "PRECONDITION_REQUIRED")
+
+	// errVersionConflict is returned when the data has been
+	// changed since the given version.
+	errVersionConflict = status.Error(codes.Aborted, "VERSION_CONFLICT")
+
+	// errForbidden is returned when the caller is not allowed
+	// to access the user.
+	errForbidden = status.Error(codes.PermissionDenied, "FORBIDDEN")
+
+	// errRequestTimeout is returned when processing time has
+	// reached the timeout limit.
+	errRequestTimeout = status.Error(codes.DeadlineExceeded, "REQUEST_TIMEOUT")
+
+	// errSourceNotProvided is returned when there is no
+	// source provided in the request.
+	errSourceNotProvided = status.Error(codes.InvalidArgument, "SOURCE_NOT_PROVIDED")
+
+	// errUnauthorizedAccess is returned when the request
+	// is unaothorized.
+	errUnauthorizedAccess = status.Error(codes.Unauthenticated, "UNAUTHORIZED_ACCESS")
+)
+
+var (
+	// mapGRPCError maps service error into gRPC error with
+	// the status code matching the error.
+	//
+	// Internal server error-related should not be mapped here,
+	// and the handler should just return `errInternalServer`
+	// as the error instead
+	mapGRPCError = map[error]error{
+		auth.ErrVersionConflict:   errVersionConflict,
+		auth.ErrDataNotFound:      errDataNotFound,
+		auth.ErrInvalidUserID:     errInvalidUserID,
+		auth.ErrUserAlreadyExist:  errUserAlreadyExist,
+		auth.ErrInvalidToken:      errInvalidToken,
+		auth.ErrExpiredToken:      errExpiredToken,
+		auth.ErrInvalidEmail:      errInvalidEmail,
+		auth.ErrInvalidTokenEmail: errInvalidTokenEmail,
+	}
+)
+
+// parseError returns the gRPC error of the given error
+// returned by the auth service. Unknown errors are logged and
+// returned as `errInternalServer`.
+func parseError(ctx context.Context, err error, name string) error {
+	if v, ok := mapGRPCError[err]; ok {
+		return v
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return errRequestTimeout
+	}
+
+	log.Printf("[Auth gRPC][%s] Internal error. Err: %s\n", name, err.Error())
+	return errInternalServer
+}
@@ -0,0 +1,58 @@
+package grpc
+
+import (
+	"context"
+	contextlib "hbdtoyou/pkg/context"
+	grpclib "hbdtoyou/pkg/grpc"
+	authv1 "hbdtoyou/pkg/pb/auth/v1"
+	"log"
+
+	"google.golang.org/grpc"
+)
+
+// publicMethods are the gRPC methods served without an access
+// token, keyed by their full method name.
+var publicMethods = map[string]struct{}{
+	authv1.AuthService_LoginSocial_FullMethodName:  {},
+	authv1.AuthService_RefreshToken_FullMethodName: {},
+}
+
+// UnaryServerInterceptor returns a gRPC interceptor setting the
+// source and the user of the request in its context. Requests
+// to methods other than the public ones are refused without a
+// valid access token.
+//
+// The access token is validated against the school of the
+// request, so the interceptor must come after the tenant
+// interceptor.
+func (h *Handler) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		// get request source
+		source, err := grpclib.GetSourceFromMetadata(ctx)
+		if err != nil {
+			return nil, errSourceNotProvided
+		}
+		ctx = contextlib.SetSource(ctx, source)
+
+		if _, ok := publicMethods[info.FullMethod]; ok {
+			return handler(ctx, req)
+		}
+
+		// get token from metadata
+		token, err := grpclib.GetBearerTokenFromMetadata(ctx)
+		if err != nil {
+			return nil, errInvalidToken
+		}
+
+		// check access token, the caller is the owner of the
+		// token
+		tokenData, err := h.auth.ValidateToken(ctx, token)
+		if err != nil {
+			log.Printf("[Auth gRPC][%s] Unauthorized error from ValidateToken. Err: %s\n", info.FullMethod, err.Error())
+			return nil, errUnauthorizedAccess
+		}
+		ctx = contextlib.SetUserID(ctx, tokenData.UserID)
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,105 @@
+package grpc
+
+import (
+	"context"
+	contextlib "hbdtoyou/pkg/context"
+	grpclib "hbdtoyou/pkg/grpc"
+	authv1 "hbdtoyou/pkg/pb/auth/v1"
+)
+
+// LoginSocial implements authv1.AuthServiceServer.
+func (h *Handler) LoginSocial(ctx context.Context, req *authv1.LoginSocialRequest) (*authv1.LoginSocialResponse, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeLoginSocial].Timeout)
+	defer cancel()
+
+	token, tokenData, err := h.auth.LoginSocial(ctx, req.GetTokenEmail())
+	if err != nil {
+		return nil, parseError(ctx, err, "LoginSocial")
+	}
+
+	return &authv1.LoginSocialResponse{
+		Token:    token,
+		UserId:   tokenData.UserID,
+		Fullname: tokenData.Fullname,
+		Email:    tokenData.Email,
+	}, nil
+}
+
+// RefreshToken implements authv1.AuthServiceServer.
+func (h *Handler) RefreshToken(ctx context.Context, req *authv1.RefreshTokenRequest) (*authv1.RefreshTokenResponse, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeRefreshToken].Timeout)
+	defer cancel()
+
+	token, err := h.auth.RefreshToken(ctx, req.GetToken())
+	if err != nil {
+		return nil, parseError(ctx, err, "RefreshToken")
+	}
+
+	return &authv1.RefreshTokenResponse{
+		Token: token,
+	}, nil
+}
+
+// GetUser implements authv1.AuthServiceServer.
+func (h *Handler) GetUser(ctx context.Context, req *authv1.GetUserRequest) (*authv1.User, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeGetUser].Timeout)
+	defer cancel()
+
+	user, err := h.auth.GetUserByID(ctx, req.GetId())
+	if err != nil {
+		return nil, parseError(ctx, err, "GetUser")
+	}
+
+	return formatUser(user), nil
+}
+
+// UpdateUser implements authv1.AuthServiceServer.
+func (h *Handler) UpdateUser(ctx context.Context, req *authv1.UpdateUserRequest) (*authv1.User, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeUpdateUser].Timeout)
+	defer cancel()
+
+	if req.GetUser() == nil {
+		return nil, errBadRequest
+	}
+
+	paths, err := grpclib.GetUpdateMaskPaths(req.GetUpdateMask(), userUpdatePaths...)
+	if err != nil {
+		return nil, errInvalidUpdateMask
+	}
+
+	// updating without the version might overwrite changes
+	// made by others
+	if req.GetUser().GetVersion() <= 0 {
+		return nil, errPreconditionRequired
+	}
+
+	// a user can only update itself
+	if userID, _ := contextlib.GetUserID(ctx); userID != req.GetUser().GetId() {
+		return nil, errForbidden
+	}
+
+	current, err := h.auth.GetUserByID(ctx, req.GetUser().GetId())
+	if err != nil {
+		return nil, parseError(ctx, err, "UpdateUser")
+	}
+
+	// the data has been changed since the client read it
+	if current.Version != req.GetUser().GetVersion() {
+		return nil, errVersionConflict
+	}
+
+	parseUser(req.GetUser(), paths, &current)
+
+	err = h.auth.UpdateUser(ctx, current)
+	if err != nil {
+		return nil, parseError(ctx, err, "UpdateUser")
+	}
+
+	// the stored version is incremented on every update
+	current.Version++
+	return formatUser(current), nil
+}
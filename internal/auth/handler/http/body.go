@@ -7,6 +7,16 @@ type loginSocialRequestData struct {
 	TokenEmail string `json:"token_email"`
 }
 
+// refreshTokenRequestData is the data from user to perform
+// refreshToken.
+type refreshTokenRequestData struct {
+	Token string `json:"token"`
+}
+
+type refreshTokenResponseData struct {
+	Token string `json:"token"`
+}
+
 type loginResponseData struct {
 	UserID   string `json:"user_id"`
 	Fullname string `json:"fullname"`
@@ -20,10 +30,13 @@ type userHTTP struct {
 	Email    *string `json:"email"`
 	Type     *string `json:"type"`
 	Quota    *int    `json:"quota"`
+	Role     *string `json:"role"`
+	Version  *int64  `json:"version"`
 }
 
 func formatUser(u auth.User) userHTTP {
 	types := u.Type.String()
+	role := u.Role.String()
 
 	return userHTTP{
 		ID:       &u.ID,
@@ -31,25 +44,23 @@ func formatUser(u auth.User) userHTTP {
 		Email:    &u.Email,
 		Type:     &types,
 		Quota:    &u.Quota,
+		Role:     &role,
+		Version:  &u.Version,
 	}
 }
 
-func (u userHTTP) parseUser(out *auth.User) error {
-	if u.ID != nil {
-		out.ID = *u.ID
-	}
+// updateUserRequestData is the data from user to perform
+// updateUser. Email is verified on login and quota is given by
+// payments, neither is updated by the user itself.
+type updateUserRequestData struct {
+	Fullname *string `json:"fullname"`
+}
 
+// parseUser sets the updated fields from the request to out.
+func (u updateUserRequestData) parseUser(out *auth.User) error {
 	if u.Fullname != nil {
 		out.Fullname = *u.Fullname
 	}
 
-	if u.Email != nil {
-		out.Email = *u.Email
-	}
-
-	if u.Quota != nil {
-		out.Quota = *u.Quota
-	}
-
 	return nil
 }
@@ -48,6 +48,22 @@ var (
 	// is invalid.
 	errInvalidUsername = errors.New("INVALID_USERNAME")
 
+	// errInvalidIfMatch is returned when the given If-Match
+	// header is not a valid version ETag.
+	errInvalidIfMatch = errors.New("INVALID_IF_MATCH")
+
+	// errPreconditionRequired is returned when updating
+	// without If-Match header.
+	errPreconditionRequired = errors.New("PRECONDITION_REQUIRED")
+
+	// errVersionConflict is returned when the data has been
+	// changed since the given version.
+	errVersionConflict = errors.New("VERSION_CONFLICT")
+
+	// errForbidden is returned when the user is not allowed
+	// to access the requested data.
+	errForbidden = errors.New("FORBIDDEN")
+
 	// errMethodNotAllowed is returned when accessing not
 	// allowed HTTP method.
 	errMethodNotAllowed = errors.New("METHOD_NOT_ALLOWED")
@@ -77,6 +93,7 @@ var (
 	// and the handler should just return `errInternal` as the
 	// error instead
 	mapHTTPError = map[error]error{
+		auth.ErrVersionConflict:   errVersionConflict,
 		auth.ErrDataNotFound:      errDataNotFound,
 		auth.ErrInvalidUserID:     errInvalidUserID,
 		auth.ErrUserAlreadyExist:  errUserAlreadyExist,
@@ -0,0 +1,109 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	contextlib "hbdtoyou/pkg/context"
+	httplib "hbdtoyou/pkg/http"
+	"io/ioutil"
+	"log"
+	"net/http"
+)
+
+func (h *refreshTokenHandler) handleRefreshToken(w http.ResponseWriter, r *http.Request) {
+	// add timeout to context
+	timeout := h.scopeSettings[ScopeRefreshToken].Timeout
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	var (
+		err        error           // stores error in this handler
+		source     string          // stores request source
+		resBody    []byte          // stores response body to write
+		statusCode = http.StatusOK // stores response status code
+	)
+
+	// write response
+	defer func() {
+		// add status code to context for monitoring
+		ctx = contextlib.SetHTTPStatusCode(ctx, statusCode)
+		*r = *(r.WithContext(ctx))
+
+		// error
+		if err != nil {
+			log.Printf("[Auth HTTP][handleRefreshToken] Failed to refresh token. Source: %s, Err: %s\n", source, err.Error())
+			httplib.WriteErrorResponse(w, statusCode, []string{err.Error()})
+			return
+		}
+		// success
+		httplib.WriteResponse(w, resBody, statusCode, httplib.JSONContentTypeDecorator)
+	}()
+
+	// prepare channels for main go routine
+	resChan := make(chan refreshTokenResponseData, 1)
+	errChan := make(chan error, 1)
+
+	go func() {
+		// get request source
+		source, err = httplib.GetSourceFromHeader(r)
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errSourceNotProvided
+			return
+		}
+
+		// read request body
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errBadRequest
+			return
+		}
+
+		// unmarshall body
+		var data refreshTokenRequestData
+		err = json.Unmarshal(body, &data)
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errBadRequest
+			return
+		}
+
+		// refresh the token, it has to be valid
+		token, err := h.auth.RefreshToken(ctx, data.Token)
+		if err != nil {
+			// determine error and status code, by default its internal error
+			parsedErr := errInternalServer
+			statusCode = http.StatusInternalServerError
+			if v, ok := mapHTTPError[err]; ok {
+				parsedErr = v
+				statusCode = http.StatusBadRequest
+			}
+
+			// log the actual error if its internal error
+			if statusCode == http.StatusInternalServerError {
+				log.Printf("[Auth HTTP][handleRefreshToken] Internal error from RefreshToken. Err: %s\n", err.Error())
+			}
+
+			errChan <- parsedErr
+			return
+		}
+
+		resChan <- refreshTokenResponseData{
+			Token: token,
+		}
+	}()
+
+	// wait and handle main go routine
+	select {
+	case <-ctx.Done():
+		statusCode = http.StatusGatewayTimeout
+		err = errRequestTimeout
+	case err = <-errChan:
+	case resData := <-resChan:
+		res := httplib.ResponseEnvelope{
+			Data: resData,
+		}
+		resBody, err = json.Marshal(res)
+	}
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"hbdtoyou/internal/auth"
 	contextlib "hbdtoyou/pkg/context"
 	httplib "hbdtoyou/pkg/http"
 	"io/ioutil"
@@ -21,22 +22,23 @@ func (h *userHandler) handleUpdateUser(w http.ResponseWriter, r *http.Request, u
 		source     string          // stores request source
 		resBody    []byte          // stores response body to write
 		statusCode = http.StatusOK // stores response status code
+		resVersion int64           // stores response data version
 	)
 
 	// write response
 	defer func() {
 		// error
 		if err != nil {
-			log.Printf("[Auth HTTP][handleUpdateUser] Failed to update user by ID. user ID: %d, Source: %s, Err: %s\n", userID, source, err.Error())
+			log.Printf("[Auth HTTP][handleUpdateUser] Failed to update user by ID. user ID: %s, Source: %s, Err: %s\n", userID, source, err.Error())
 			httplib.WriteErrorResponse(w, statusCode, []string{err.Error()})
 			return
 		}
 		// success
-		httplib.WriteResponse(w, resBody, statusCode, httplib.JSONContentTypeDecorator)
+		httplib.WriteResponse(w, resBody, statusCode, httplib.JSONContentTypeDecorator, httplib.NewVersionETagDecorator(resVersion))
 	}()
 
 	// prepare channels for main go routine
-	resChan := make(chan string, 1)
+	resChan := make(chan auth.User, 1)
 	errChan := make(chan error, 1)
 
 	go func() {
@@ -66,6 +68,20 @@ func (h *userHandler) handleUpdateUser(w http.ResponseWriter, r *http.Request, u
 			return
 		}
 
+		// get expected version, updating without it might
+		// overwrite changes made by others
+		version, err := httplib.GetIfMatchVersionFromHeader(r)
+		if err == httplib.ErrIfMatchNotFound {
+			statusCode = http.StatusPreconditionRequired
+			errChan <- errPreconditionRequired
+			return
+		}
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errInvalidIfMatch
+			return
+		}
+
 		// read body
 		body, err := ioutil.ReadAll(r.Body)
 		if err != nil {
@@ -75,7 +91,7 @@ func (h *userHandler) handleUpdateUser(w http.ResponseWriter, r *http.Request, u
 		}
 
 		// unmarshall body
-		request := userHTTP{}
+		request := updateUserRequestData{}
 		err = json.Unmarshal(body, &request)
 		if err != nil {
 			statusCode = http.StatusBadRequest
@@ -91,7 +107,12 @@ func (h *userHandler) handleUpdateUser(w http.ResponseWriter, r *http.Request, u
 			return
 		}
 
-		// TODO: add authorization flow with roles
+		// a user can only update itself
+		if userID != reqUserID {
+			statusCode = http.StatusForbidden
+			errChan <- errForbidden
+			return
+		}
 
 		// get current bill data
 		current, err := h.auth.GetUserByID(ctx, userID)
@@ -106,14 +127,21 @@ func (h *userHandler) handleUpdateUser(w http.ResponseWriter, r *http.Request, u
 
 			// log the actual error if its internal error
 			if statusCode == http.StatusInternalServerError {
-				log.Printf("[Auth HTTP][handleUpdateUser] Internal error from GetUserByID, user ID: %d. Err: %s\n", userID, err.Error())
+				log.Printf("[Auth HTTP][handleUpdateUser] Internal error from GetUserByID, user ID: %s. Err: %s\n", userID, err.Error())
 			}
 
 			errChan <- parsedErr
 			return
 		}
 
-		// parse content from request body
+		// the data has been changed since the client read it
+		if current.Version != version {
+			statusCode = http.StatusPreconditionFailed
+			errChan <- errVersionConflict
+			return
+		}
+
+		// parse user from request body
 		err = request.parseUser(&current)
 		if err != nil {
 			statusCode = http.StatusBadRequest
@@ -130,6 +158,9 @@ func (h *userHandler) handleUpdateUser(w http.ResponseWriter, r *http.Request, u
 				parsedErr = v
 				statusCode = http.StatusBadRequest
 			}
+			if err == auth.ErrVersionConflict {
+				statusCode = http.StatusPreconditionFailed
+			}
 
 			// log the actual error if its internal error
 			if statusCode == http.StatusInternalServerError {
@@ -140,7 +171,9 @@ func (h *userHandler) handleUpdateUser(w http.ResponseWriter, r *http.Request, u
 			return
 		}
 
-		resChan <- userID
+		// the stored version is incremented on every update
+		current.Version++
+		resChan <- current
 	}()
 
 	// wait and handle main go routine
@@ -149,9 +182,10 @@ func (h *userHandler) handleUpdateUser(w http.ResponseWriter, r *http.Request, u
 		statusCode = http.StatusGatewayTimeout
 		err = errRequestTimeout
 	case err = <-errChan:
-	case userID := <-resChan:
+	case res := <-resChan:
+		resVersion = res.Version
 		resBody, err = json.Marshal(httplib.ResponseEnvelope{
-			Data: userID,
+			Data: res.ID,
 		})
 	}
 }
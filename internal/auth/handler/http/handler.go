@@ -22,6 +22,20 @@ func (h *authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+type refreshTokenHandler struct {
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *refreshTokenHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.handleRefreshToken(w, r)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
 type userHandler struct {
 	auth          auth.Service
 	scopeSettings map[Scope]ScopeSetting
@@ -3,6 +3,8 @@ package http
 import (
 	"errors"
 	"hbdtoyou/internal/auth"
+	httplib "hbdtoyou/pkg/http"
+	"hbdtoyou/pkg/ratelimit"
 	"net/http"
 	"time"
 
@@ -10,8 +12,9 @@ import (
 )
 
 var (
-	errUnknownScope  = errors.New("unknown scope name")
-	errUnknownConfig = errors.New("unknown config name")
+	errUnknownScope     = errors.New("unknown scope name")
+	errUnknownConfig    = errors.New("unknown config name")
+	errInvalidRateLimit = errors.New("invalid rate limit")
 )
 
 // Handler contains finance HTTP handlers.
@@ -19,6 +22,7 @@ type Handler struct {
 	handlers      map[string]*handler
 	auth          auth.Service
 	scopeSettings map[Scope]ScopeSetting
+	rateLimiter   *httplib.RateLimiter
 }
 
 // handler is the HTTP handler wrapper.
@@ -40,6 +44,11 @@ var (
 		URL:  "/v1/auth/social",
 	}
 
+	HandlerRefreshToken = HandlerIdentity{
+		Name: "auth-refresh",
+		URL:  "/v1/auth/refresh",
+	}
+
 	HandlerUser = HandlerIdentity{
 		Name: "user",
 		URL:  "/v1/users/{id}",
@@ -59,28 +68,36 @@ const (
 	ScopeLoginSocial
 	ScopeGetUserByID
 	ScopeUpdateUser
+	ScopeRefreshToken
 )
 
 var (
 	// ScopeName defines all the known scopes and their string
 	// representation.
 	ScopeName = map[Scope]string{
-		ScopeLoginSocial: "LoginSocial",
-		ScopeGetUserByID: "GetUserByID",
-		ScopeUpdateUser:  "UpdateUser",
+		ScopeLoginSocial:  "LoginSocial",
+		ScopeGetUserByID:  "GetUserByID",
+		ScopeUpdateUser:   "UpdateUser",
+		ScopeRefreshToken: "RefreshToken",
 	}
 
 	// ScopeValue is the reverse-mapping of ScopeName.
 	ScopeValue = map[string]Scope{
-		ScopeName[ScopeLoginSocial]: ScopeLoginSocial,
-		ScopeName[ScopeGetUserByID]: ScopeGetUserByID,
-		ScopeName[ScopeUpdateUser]:  ScopeUpdateUser,
+		ScopeName[ScopeLoginSocial]:  ScopeLoginSocial,
+		ScopeName[ScopeGetUserByID]:  ScopeGetUserByID,
+		ScopeName[ScopeUpdateUser]:   ScopeUpdateUser,
+		ScopeName[ScopeRefreshToken]: ScopeRefreshToken,
 	}
 )
 
 // ScopeSetting is the available configurations of a Scope.
 type ScopeSetting struct {
 	Timeout time.Duration
+
+	// RateLimit limits the requests of the scope per user,
+	// or per client IP if the request has no user. The zero
+	// value does not limit.
+	RateLimit ratelimit.Limit
 }
 
 // Followings are default values for ScopeSetting fields.
@@ -137,12 +154,25 @@ func WithScopeSetting(scopeName string, scopeSetting ScopeSetting) Option {
 		if scopeSetting.Timeout <= 0 {
 			scopeSetting.Timeout = defaultTimeout
 		}
+		if err := scopeSetting.RateLimit.Validate(); err != nil {
+			return errInvalidRateLimit
+		}
 
 		h.scopeSettings[scope] = scopeSetting
 		return nil
 	})
 }
 
+// WithRateLimiter returns Option to limit the rate of requests
+// using the given rate limiter, with the RateLimit of each
+// scope setting.
+func WithRateLimiter(rateLimiter *httplib.RateLimiter) Option {
+	return Option(func(h *Handler) error {
+		h.rateLimiter = rateLimiter
+		return nil
+	})
+}
+
 // New creates a new Handler.
 //
 // For the given Option, WithScopeSetting() should come first
@@ -175,6 +205,11 @@ func (h *Handler) createHTTPHandler(configName string) (http.Handler, error) {
 			auth:          h.auth,
 			scopeSettings: h.scopeSettings,
 		}
+	case HandlerRefreshToken.Name:
+		httpHandler = &refreshTokenHandler{
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
 	case HandlerUser.Name:
 		httpHandler = &userHandler{
 			auth:          h.auth,
@@ -190,7 +225,7 @@ func (h *Handler) createHTTPHandler(configName string) (http.Handler, error) {
 // Start starts all HTTP handlers.
 func (h *Handler) Start(multiplexer *mux.Router) error {
 	for _, handler := range h.handlers {
-		multiplexer.Handle(handler.identity.URL, handler.h)
+		multiplexer.Handle(handler.identity.URL, h.limitRate(handler.identity, handler.h))
 	}
 	return nil
 }
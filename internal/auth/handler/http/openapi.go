@@ -0,0 +1,35 @@
+package http
+
+import (
+	"hbdtoyou/pkg/openapi"
+	"net/http"
+)
+
+// routes defines the OpenAPI routes of each HTTP handler, it
+// must be updated along with the methods served by handler.go.
+var routes = map[string][]openapi.Route{
+	HandlerLoginSocial.Name: {
+		{Method: http.MethodPost, Summary: "Login with social account", Request: loginSocialRequestData{}, Response: loginResponseData{}, Public: true},
+	},
+	HandlerRefreshToken.Name: {
+		{Method: http.MethodPost, Summary: "Refresh token", Request: refreshTokenRequestData{}, Response: refreshTokenResponseData{}, Public: true},
+	},
+	HandlerUser.Name: {
+		{Method: http.MethodGet, Summary: "Get user by ID", Response: userHTTP{}, Versioned: true},
+		{Method: http.MethodPatch, Summary: "Update user", Request: updateUserRequestData{}, Response: "", IfMatch: true},
+	},
+}
+
+// Routes returns the OpenAPI routes of the registered HTTP
+// handlers.
+func (h *Handler) Routes() []openapi.Route {
+	var res []openapi.Route
+	for _, handler := range h.handlers {
+		for _, route := range routes[handler.identity.Name] {
+			route.Path = handler.identity.URL
+			route.Tag = "auth"
+			res = append(res, route)
+		}
+	}
+	return res
+}
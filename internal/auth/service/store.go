@@ -3,10 +3,11 @@ package service
 import (
 	"context"
 	"hbdtoyou/internal/auth"
+	"hbdtoyou/pkg/event"
 )
 
 type PGStore interface {
-	NewClient(useTx bool) (PGStoreClient, error)
+	NewClient(ctx context.Context, useTx bool) (PGStoreClient, error)
 }
 
 type PGStoreClient interface {
@@ -31,4 +32,8 @@ type PGStoreClient interface {
 	// use current values in the given data if do not want to
 	// update some specific attributes.
 	UpdateUser(ctx context.Context, reqUser auth.User) error
+
+	// CreateEvent writes a new event to the event outbox, to
+	// be published once the transaction is committed.
+	CreateEvent(ctx context.Context, e event.Event) error
 }
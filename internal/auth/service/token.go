@@ -4,6 +4,8 @@ import (
 	"context"
 	"hbdtoyou/internal/auth"
 
+	contextlib "hbdtoyou/pkg/context"
+
 	"github.com/golang-jwt/jwt/v4"
 )
 
@@ -14,6 +16,7 @@ type jwtClaims struct {
 	Email    string `json:"email"`
 	Fullname string `json:"fullname"`
 	Type     int    `json:"type"`
+	SchoolID string `json:"school_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -25,6 +28,7 @@ func (jwtc jwtClaims) parseTokenData() auth.TokenData {
 		Fullname: jwtc.Fullname,
 		Email:    jwtc.Email,
 		Type:     auth.Type(jwtc.Type),
+		SchoolID: jwtc.SchoolID,
 	}
 }
 
@@ -36,6 +40,7 @@ func formatTokenData(data auth.TokenData) jwtClaims {
 		Fullname: data.Fullname,
 		Email:    data.Email,
 		Type:     data.Type.Value(),
+		SchoolID: data.SchoolID,
 	}
 }
 
@@ -62,6 +67,12 @@ func (s *service) ValidateToken(ctx context.Context, token string) (auth.TokenDa
 		return auth.TokenData{}, auth.ErrInvalidToken
 	}
 
+	// a token is only valid for the school it is issued for,
+	// the same user ID may exist in another school
+	if schoolID, ok := contextlib.GetSchoolID(ctx); ok && schoolID != claims.SchoolID {
+		return auth.TokenData{}, auth.ErrInvalidToken
+	}
+
 	return claims.parseTokenData(), nil
 }
 
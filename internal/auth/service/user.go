@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"hbdtoyou/internal/auth"
+	"hbdtoyou/pkg/event"
+
+	contextlib "hbdtoyou/pkg/context"
 
 	"google.golang.org/api/idtoken"
 )
@@ -15,8 +18,12 @@ func (s *service) LoginSocial(ctx context.Context, tokenEmail string) (string, a
 		return "", auth.TokenData{}, auth.ErrInvalidEmail
 	}
 
+	// the token is only valid for the school the user is
+	// found in, if any
+	schoolID, _ := contextlib.GetSchoolID(ctx)
+
 	// get pg store client without using transaction
-	pgStoreClient, err := s.pgStore.NewClient(false)
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
 	if err != nil {
 		return "", auth.TokenData{}, err
 	}
@@ -35,6 +42,7 @@ func (s *service) LoginSocial(ctx context.Context, tokenEmail string) (string, a
 			Email:    bypassData.Email,
 			Quota:    bypassData.Quota,
 			Type:     bypassData.Type,
+			SchoolID: schoolID,
 		}
 		token, err := s.generateToken(tokenData)
 		if err != nil {
@@ -69,6 +77,7 @@ func (s *service) LoginSocial(ctx context.Context, tokenEmail string) (string, a
 			Email:    current.Email,
 			Quota:    current.Quota,
 			Type:     current.Type,
+			SchoolID: schoolID,
 		}
 		token, err := s.generateToken(tokenData)
 		if err != nil {
@@ -84,7 +93,7 @@ func (s *service) LoginSocial(ctx context.Context, tokenEmail string) (string, a
 	}
 
 	// if user empty will be create new user
-	id, err := pgStoreClient.CreateUser(ctx, reqUser)
+	id, err := s.registerUser(ctx, reqUser)
 	if err != nil {
 		return "", auth.TokenData{}, nil
 	}
@@ -96,6 +105,7 @@ func (s *service) LoginSocial(ctx context.Context, tokenEmail string) (string, a
 		Email:    reqUser.Email,
 		Quota:    0,
 		Type:     auth.TypeFree,
+		SchoolID: schoolID,
 	}
 
 	token, err := s.generateToken(tokenData)
@@ -106,6 +116,46 @@ func (s *service) LoginSocial(ctx context.Context, tokenEmail string) (string, a
 	return token, tokenData, nil
 }
 
+// registerUser creates the given new user along with its
+// registered event in a transaction, and returns the created
+// user ID.
+func (s *service) registerUser(ctx context.Context, reqUser auth.User) (string, error) {
+	// get pg store client using transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, true)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := pgStoreClient.CreateUser(ctx, reqUser)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return "", err
+	}
+
+	e, err := event.New(auth.EventUserRegistered, id, auth.UserEvent{
+		UserID:   id,
+		Fullname: reqUser.Fullname,
+		Email:    reqUser.Email,
+	}, s.timeNow())
+	if err != nil {
+		pgStoreClient.Rollback()
+		return "", err
+	}
+
+	err = pgStoreClient.CreateEvent(ctx, e)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return "", err
+	}
+
+	err = pgStoreClient.Commit()
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
 func (s *service) GetUserByID(ctx context.Context, userID string) (auth.User, error) {
 	// validate the given values
 	if userID == "" {
@@ -113,7 +163,7 @@ func (s *service) GetUserByID(ctx context.Context, userID string) (auth.User, er
 	}
 
 	// get pg store client without using transaction
-	pgStoreClient, err := s.pgStore.NewClient(false)
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
 	if err != nil {
 		return auth.User{}, err
 	}
@@ -139,7 +189,7 @@ func (s *service) UpdateUser(ctx context.Context, reqUser auth.User) error {
 	reqUser.UpdateTime = s.timeNow()
 
 	// get pg store client without using transaction
-	pgStoreClient, err := s.pgStore.NewClient(false)
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
 	if err != nil {
 		return err
 	}
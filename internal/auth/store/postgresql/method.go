@@ -7,6 +7,9 @@ import (
 	"hbdtoyou/internal/auth"
 	"strings"
 
+	"hbdtoyou/pkg/event"
+	outbox "hbdtoyou/pkg/event/outbox/postgresql"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 )
@@ -96,13 +99,14 @@ func (s *storeClient) GetUserAuth(ctx context.Context, filter auth.GetUserAuthFi
 
 func (sc *storeClient) UpdateUser(ctx context.Context, reqUser auth.User) error {
 	argsKV := map[string]interface{}{
-		"id":              reqUser.ID,
-		"fullname":        reqUser.Fullname,
-		"username":        reqUser.Username,
-		"email":           reqUser.Email,
-		"type":            reqUser.Type,
-		"quota":           reqUser.Quota,
-		"update_time":     reqUser.UpdateTime,
+		"id":          reqUser.ID,
+		"fullname":    reqUser.Fullname,
+		"username":    reqUser.Username,
+		"email":       reqUser.Email,
+		"type":        reqUser.Type,
+		"quota":       reqUser.Quota,
+		"version":     reqUser.Version,
+		"update_time": reqUser.UpdateTime,
 	}
 
 	query, args, err := sqlx.Named(queryUpdateUser, argsKV)
@@ -117,6 +121,23 @@ func (sc *storeClient) UpdateUser(ctx context.Context, reqUser auth.User) error
 
 	query = sc.q.Rebind(query)
 
-	_, err = sc.q.Exec(query, args...)
-	return err
-}
\ No newline at end of file
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	// nothing is updated when the version is outdated
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return auth.ErrVersionConflict
+	}
+
+	return nil
+}
+
+func (sc *storeClient) CreateEvent(ctx context.Context, e event.Event) error {
+	return outbox.Write(ctx, sc.q, e)
+}
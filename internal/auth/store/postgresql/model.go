@@ -14,6 +14,8 @@ type UserModel struct {
 	Email      string     `db:"email"`
 	Type       auth.Type  `db:"type"`
 	Quota      int        `db:"quota"`
+	Role       auth.Role  `db:"role"`
+	Version    int64      `db:"version"`
 	CreateTime time.Time  `db:"create_time"`
 	UpdateTime *time.Time `db:"update_time"`
 }
@@ -26,6 +28,8 @@ func (dbData *UserModel) format() auth.User {
 		Email:      dbData.Email,
 		Quota:      dbData.Quota,
 		Type:       dbData.Type,
+		Role:       dbData.Role,
+		Version:    dbData.Version,
 		CreateTime: dbData.CreateTime,
 	}
 
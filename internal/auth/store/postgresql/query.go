@@ -35,6 +35,8 @@ const (
 			email,
 			type,
 			quota,
+			role,
+			version,
 			create_time,
 			update_time
 		FROM
@@ -51,8 +53,11 @@ const (
 			email = :email,
 			type = :type,
 			quota = :quota,
+			version = version + 1,
 			update_time = :update_time
 		WHERE
 			id = :id
+		AND
+			version = :version
 	`
 )
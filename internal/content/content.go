@@ -2,6 +2,7 @@ package content
 
 import (
 	"context"
+	"hbdtoyou/pkg/jsondiff"
 	"time"
 )
 
@@ -16,6 +17,9 @@ type Service interface {
 	GetContentByID(ctx context.Context, contentID string) (Content, error)
 
 	// GetContents returns all contents.
+	//
+	// Users other than administrators only get their own
+	// contents, or contents shared with them.
 	GetContents(ctx context.Context, filter GetContentsFilter) ([]Content, error)
 
 	// UpdateContent updates existing content
@@ -25,11 +29,66 @@ type Service interface {
 	// except ID, and CreateTime. So, make sure to
 	// use current values in the given data if do not want to
 	// update some specific attributes.
+	//
+	// The given version must be the current version of the
+	// content, otherwise ErrVersionConflict is returned.
 	UpdateContent(ctx context.Context, reqContent Content) error
 
 	// DeleteContent delete a content
 	// with the given content id.
+	//
+	// The content is moved to trash and can be restored
+	// until it is purged.
 	DeleteContentByID(ctx context.Context, contentID string) error
+
+	// RestoreContentByID restores a deleted content with the
+	// given content id from trash.
+	RestoreContentByID(ctx context.Context, contentID string) error
+
+	// PurgeDeletedContents permanently deletes contents that
+	// were deleted before the given time and returns the
+	// number of purged contents.
+	PurgeDeletedContents(ctx context.Context, before time.Time) (int64, error)
+
+	// GetContentRevisions returns all revisions of a content
+	// with the given content ID, newest first.
+	GetContentRevisions(ctx context.Context, contentID string) ([]Revision, error)
+
+	// RestoreContentRevision restores a content with the given
+	// content ID to the state saved in the given revision.
+	//
+	// The given version must be the current version of the
+	// content, otherwise ErrVersionConflict is returned.
+	RestoreContentRevision(ctx context.Context, contentID string, number int, version int64) error
+
+	// GetContentRevisionDiff returns the changes between two
+	// revisions of a content with the given content ID.
+	//
+	// A zero revision number denotes the current state of
+	// the content.
+	GetContentRevisionDiff(ctx context.Context, contentID string, from, to int) (RevisionDiff, error)
+
+	// InviteContentMember invites a user with the given email
+	// to contribute on a content and returns the created
+	// invitation ID. Only the content owner can invite.
+	InviteContentMember(ctx context.Context, reqMember Member) (string, error)
+
+	// GetContentMembers returns all members and pending
+	// invitations of a content with the given content ID.
+	GetContentMembers(ctx context.Context, contentID string) ([]Member, error)
+
+	// RemoveContentMember removes a member with the given
+	// member ID from a content. The content owner can remove
+	// any other member, while a member can only leave.
+	RemoveContentMember(ctx context.Context, contentID, memberID string) error
+
+	// GetInvitations returns pending invitations sent to the
+	// email of the caller.
+	GetInvitations(ctx context.Context) ([]Member, error)
+
+	// RespondInvitation accepts or declines an invitation with
+	// the given member ID sent to the email of the caller.
+	RespondInvitation(ctx context.Context, memberID string, accept bool) error
 }
 
 // Content denotes the content.
@@ -38,9 +97,12 @@ type Content struct {
 	UserID                string
 	TemplateID            string
 	DetailContentJSONText string
+	MediaIDs              []string
 	Status                Status
+	Version               int64
 	CreateTime            time.Time
 	UpdateTime            time.Time
+	DeleteTime            time.Time
 
 	// derived
 	UserName      string
@@ -83,9 +145,159 @@ func (s Status) Value() int {
 	return int(s)
 }
 
+// Revision denotes a previous state of a content, saved
+// before the content is updated.
+type Revision struct {
+	ContentID             string
+	Number                int
+	UserID                string
+	TemplateID            string
+	DetailContentJSONText string
+	Status                Status
+	CreateTime            time.Time
+}
+
+// RevisionDiff denotes the changes between two revisions of
+// a content.
+//
+// Paths of the changes are relative to a document with
+// "template_id", "status" and "detail_content" fields.
+type RevisionDiff struct {
+	ContentID string
+	From      int
+	To        int
+	Changes   []jsondiff.Change
+}
+
+// Member denotes a user contributing on a content, or an
+// invitation to do so when it is not accepted yet.
+type Member struct {
+	ID         string
+	ContentID  string
+	UserID     string
+	Email      string
+	Role       MemberRole
+	Status     MemberStatus
+	InvitedBy  string
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+// MemberRole denotes role of a content member.
+type MemberRole int
+
+// Followings are the known content member roles, ordered by
+// their permissions.
+const (
+	MemberRoleUnknown MemberRole = 0
+	MemberRoleViewer  MemberRole = 1
+	MemberRoleEditor  MemberRole = 2
+	MemberRoleOwner   MemberRole = 3
+)
+
+var (
+	// MemberRoleList is a list of valid content member roles.
+	MemberRoleList = map[MemberRole]struct{}{
+		MemberRoleViewer: {},
+		MemberRoleEditor: {},
+		MemberRoleOwner:  {},
+	}
+
+	// MemberRoleName maps content member role to it's string
+	// representation.
+	MemberRoleName = map[MemberRole]string{
+		MemberRoleViewer: "viewer",
+		MemberRoleEditor: "editor",
+		MemberRoleOwner:  "owner",
+	}
+)
+
+// String returns string representaion of a content member
+// role.
+func (r MemberRole) String() string {
+	return MemberRoleName[r]
+}
+
+// Value returns int value of a content member role.
+func (r MemberRole) Value() int {
+	return int(r)
+}
+
+// AtLeast returns whether the role has at least the
+// permissions of the given role.
+func (r MemberRole) AtLeast(role MemberRole) bool {
+	return r >= role
+}
+
+// MemberStatus denotes status of a content member.
+type MemberStatus int
+
+// Followings are the known content member status.
+const (
+	MemberStatusUnknown  MemberStatus = 0
+	MemberStatusPending  MemberStatus = 1
+	MemberStatusAccepted MemberStatus = 2
+	MemberStatusDeclined MemberStatus = 3
+)
+
+var (
+	// MemberStatusList is a list of valid content member
+	// status.
+	MemberStatusList = map[MemberStatus]struct{}{
+		MemberStatusPending:  {},
+		MemberStatusAccepted: {},
+		MemberStatusDeclined: {},
+	}
+
+	// MemberStatusName maps content member status to it's
+	// string representation.
+	MemberStatusName = map[MemberStatus]string{
+		MemberStatusPending:  "pending",
+		MemberStatusAccepted: "accepted",
+		MemberStatusDeclined: "declined",
+	}
+)
+
+// String returns string representaion of a content member
+// status.
+func (s MemberStatus) String() string {
+	return MemberStatusName[s]
+}
+
+// Value returns int value of a content member status.
+func (s MemberStatus) Value() int {
+	return int(s)
+}
+
 type GetContentsFilter struct {
 	UserID        string
 	TemplateID    string
 	TemplateLabel string
 	Status        Status
+
+	// SharedWithUserID returns contents the given user is
+	// an accepted member of, excluding the owned ones.
+	SharedWithUserID string
+
+	// Deleted returns contents in trash instead.
+	Deleted bool
+}
+
+// Followings are the known content event types, written to the
+// event outbox along with the content change.
+const (
+	// EventCreated is written when a content is created.
+	EventCreated = "content.created"
+
+	// EventPublished is written when a content becomes
+	// active, on creation or on update.
+	EventPublished = "content.published"
+)
+
+// ContentEvent is the payload of content events.
+type ContentEvent struct {
+	ContentID  string `json:"content_id"`
+	UserID     string `json:"user_id"`
+	TemplateID string `json:"template_id"`
+	Status     string `json:"status"`
 }
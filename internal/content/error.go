@@ -35,7 +35,51 @@ var (
 	// status is invalid.
 	ErrInvalidContentStatus = errors.New("invalid content status")
 
+	// ErrInvalidMediaID is returned when the given media ID
+	// is invalid or not owned by the content user.
+	ErrInvalidMediaID = errors.New("invalid media id")
+
 	// ErrInvalidContentStatus is returned when the given condition
 	// content access is invalid.
 	ErrInvalidContentAccess = errors.New("invalid content access")
+
+	// ErrVersionConflict is returned when updating a content
+	// with an outdated version.
+	ErrVersionConflict = errors.New("version conflict")
+
+	// ErrInvalidRevisionNumber is returned when the given
+	// revision number is invalid.
+	ErrInvalidRevisionNumber = errors.New("invalid revision number")
+
+	// ErrForbidden is returned when the caller is not allowed
+	// to access a content.
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrInvalidMemberID is returned when the given member ID
+	// is invalid.
+	ErrInvalidMemberID = errors.New("invalid member id")
+
+	// ErrInvalidMemberEmail is returned when the given member
+	// email is invalid.
+	ErrInvalidMemberEmail = errors.New("invalid member email")
+
+	// ErrInvalidMemberRole is returned when the given member
+	// role is invalid.
+	ErrInvalidMemberRole = errors.New("invalid member role")
+
+	// ErrMemberAlreadyExist is returned when the invited user
+	// is already a member or has a pending invitation.
+	ErrMemberAlreadyExist = errors.New("member already exist")
+
+	// ErrInvitationAlreadyResponded is returned when responding
+	// an invitation which is not pending anymore.
+	ErrInvitationAlreadyResponded = errors.New("invitation already responded")
+
+	// ErrTemplateNotAllowed is returned when the school of the
+	// request does not allow the given template.
+	ErrTemplateNotAllowed = errors.New("template not allowed")
+
+	// ErrContentQuotaExceeded is returned when the user would
+	// have more contents than the school of the request allows.
+	ErrContentQuotaExceeded = errors.New("content quota exceeded")
 )
@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"hbdtoyou/internal/content"
+	contentv1 "hbdtoyou/pkg/pb/content/v1"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// contentUpdatePaths are the paths of a content allowed in an
+// update mask.
+var contentUpdatePaths = []string{"template_id", "detail_content_json_text", "media_ids", "status"}
+
+func formatContent(c content.Content) *contentv1.Content {
+	return &contentv1.Content{
+		Id:                    c.ID,
+		UserId:                c.UserID,
+		UserName:              c.UserName,
+		TemplateId:            c.TemplateID,
+		TemplateName:          c.TemplateName,
+		TemplateLabel:         c.TemplateLabel,
+		DetailContentJsonText: c.DetailContentJSONText,
+		MediaIds:              c.MediaIDs,
+		Status:                contentv1.ContentStatus(c.Status),
+		Version:               c.Version,
+		CreateTime:            formatTime(c.CreateTime),
+		UpdateTime:            formatTime(c.UpdateTime),
+		DeleteTime:            formatTime(c.DeleteTime),
+	}
+}
+
+// parseContent sets the fields of the given paths from the
+// request content to out.
+func parseContent(in *contentv1.Content, paths []string, out *content.Content) {
+	for _, path := range paths {
+		switch path {
+		case "template_id":
+			out.TemplateID = in.GetTemplateId()
+		case "detail_content_json_text":
+			out.DetailContentJSONText = in.GetDetailContentJsonText()
+		case "media_ids":
+			out.MediaIDs = in.GetMediaIds()
+		case "status":
+			out.Status = content.Status(in.GetStatus())
+		}
+	}
+}
+
+func formatRevision(rev content.Revision) *contentv1.Revision {
+	return &contentv1.Revision{
+		ContentId:             rev.ContentID,
+		Number:                int32(rev.Number),
+		UserId:                rev.UserID,
+		TemplateId:            rev.TemplateID,
+		DetailContentJsonText: rev.DetailContentJSONText,
+		Status:                contentv1.ContentStatus(rev.Status),
+		CreateTime:            formatTime(rev.CreateTime),
+	}
+}
+
+// formatTime returns the given time as a timestamp, or nil
+// for the zero time.
+func formatTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,131 @@
+package grpc
+
+import (
+	"context"
+	"hbdtoyou/internal/content"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Followings are the known errors from content gRPC handlers.
+var (
+	// errBadRequest is returned when the given request is
+	// bad/invalid.
+	errBadRequest = status.Error(codes.InvalidArgument, "BAD_REQUEST")
+
+	// errInternalServer is returned when there is an
+	// unexpected error encountered when processing a request.
+	errInternalServer = status.Error(codes.Internal, "INTERNAL_SERVER_ERROR")
+
+	// errDataNotFound is returned when the desired data is
+	// not found.
+	errDataNotFound = status.Error(codes.NotFound, "DATA_NOT_FOUND")
+
+	// errInvalidUserID is returned when the given user ID is
+	// invalid.
+	errInvalidUserID = status.Error(codes.InvalidArgument, "INVALID_USER_ID")
+
+	// errInvalidContentID is returned when the given content
+	// ID is invalid.
+	errInvalidContentID = status.Error(codes.InvalidArgument, "INVALID_CONTENT_ID")
+
+	// errInvalidContentName is returned when the given content
+	// name is invalid.
+	errInvalidContentName = status.Error(codes.InvalidArgument, "INVALID_CONTENT_NAME")
+
+	// errInvalidDetailContentJSONText is returned when the
+	// given detail content JSON text is invalid.
+	errInvalidDetailContentJSONText = status.Error(codes.InvalidArgument, "INVALID_DETAIL_CONTENT_JSON_TEXT")
+
+	// errInvalidTemplateID is returned when the given template
+	// ID is invalid.
+	errInvalidTemplateID = status.Error(codes.InvalidArgument, "INVALID_TEMPLATE_ID")
+
+	// errInvalidContentType is returned when the given content
+	// type is invalid.
+	errInvalidContentType = status.Error(codes.InvalidArgument, "INVALID_CONTENT_TYPE")
+
+	// errInvalidContentStatus is returned when the given
+	// content status is invalid.
+	errInvalidContentStatus = status.Error(codes.InvalidArgument, "INVALID_CONTENT_STATUS")
+
+	// errInvalidContentAccess is returned when the given
+	// content access is invalid.
+	errInvalidContentAccess = status.Error(codes.PermissionDenied, "INVALID_CONTENT_ACCESS")
+
+	// errInvalidMediaID is returned when the given media ID
+	// is invalid.
+	errInvalidMediaID = status.Error(codes.InvalidArgument, "INVALID_MEDIA_ID")
+
+	// errTemplateNotAllowed is returned when the school of
+	// the request does not allow the given template.
+	errTemplateNotAllowed = status.Error(codes.FailedPrecondition, "TEMPLATE_NOT_ALLOWED")
+
+	// errContentQuotaExceeded is returned when the user would
+	// have more contents than the school of the request
+	// allows.
+	errContentQuotaExceeded = status.Error(codes.ResourceExhausted, "CONTENT_QUOTA_EXCEEDED")
+
+	// errForbidden is returned when the caller is not allowed
+	// to access the content.
+	errForbidden = status.Error(codes.PermissionDenied, "FORBIDDEN")
+
+	// errInvalidUpdateMask is returned when the given update
+	// mask is empty or has a path not allowed to be updated.
+	errInvalidUpdateMask = status.Error(codes.InvalidArgument, "INVALID_UPDATE_MASK")
+
+	// errPreconditionRequired is returned when updating
+	// without the current version.
+	errPreconditionRequired = status.Error(codes.FailedPrecondition, "PRECONDITION_REQUIRED")
+
+	// errVersionConflict is returned when the data has been
+	// changed since the given version.
+	errVersionConflict = status.Error(codes.Aborted, "VERSION_CONFLICT")
+
+	// errRequestTimeout is returned when processing time has
+	// reached the timeout limit.
+	errRequestTimeout = status.Error(codes.DeadlineExceeded, "REQUEST_TIMEOUT")
+)
+
+var (
+	// mapGRPCError maps service error into gRPC error with
+	// the status code matching the error.
+	//
+	// Internal server error-related should not be mapped here,
+	// and the handler should just return `errInternalServer`
+	// as the error instead
+	mapGRPCError = map[error]error{
+		content.ErrVersionConflict:              errVersionConflict,
+		content.ErrInvalidContentID:             errInvalidContentID,
+		content.ErrInvalidTemplateID:            errInvalidTemplateID,
+		content.ErrInvalidContentType:           errInvalidContentType,
+		content.ErrInvalidContentStatus:         errInvalidContentStatus,
+		content.ErrInvalidContentName:           errInvalidContentName,
+		content.ErrInvalidDetailContentJSONText: errInvalidDetailContentJSONText,
+		content.ErrInvalidUserID:                errInvalidUserID,
+		content.ErrDataNotFound:                 errDataNotFound,
+		content.ErrInvalidContentAccess:         errInvalidContentAccess,
+		content.ErrInvalidMediaID:               errInvalidMediaID,
+		content.ErrForbidden:                    errForbidden,
+		content.ErrTemplateNotAllowed:           errTemplateNotAllowed,
+		content.ErrContentQuotaExceeded:         errContentQuotaExceeded,
+	}
+)
+
+// parseError returns the gRPC error of the given error
+// returned by the content service. Unknown errors are logged
+// and returned as `errInternalServer`.
+func parseError(ctx context.Context, err error, name string) error {
+	if v, ok := mapGRPCError[err]; ok {
+		return v
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return errRequestTimeout
+	}
+
+	log.Printf("[Content gRPC][%s] Internal error. Err: %s\n", name, err.Error())
+	return errInternalServer
+}
@@ -0,0 +1,135 @@
+package grpc
+
+import (
+	"errors"
+	"hbdtoyou/internal/content"
+	contentv1 "hbdtoyou/pkg/pb/content/v1"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+var (
+	errUnknownScope = errors.New("unknown scope name")
+)
+
+// Handler contains content gRPC handlers.
+type Handler struct {
+	contentv1.UnimplementedContentServiceServer
+
+	content       content.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+// Scope is a shared settings identifier.
+//
+// Registering a new Scope is done by adding a new Scope
+// value and new entry in ScopeName and ScopeValue.
+type Scope int
+
+// Followings are the known scopes in content gRPC handlers,
+// named after the methods of the service.
+const (
+	_ Scope = iota
+	ScopeCreateContent
+	ScopeGetContent
+	ScopeListContents
+	ScopeUpdateContent
+	ScopeDeleteContent
+	ScopeRestoreContent
+	ScopeListContentRevisions
+)
+
+var (
+	// ScopeName defines all the known scopes and their string
+	// representation.
+	ScopeName = map[Scope]string{
+		ScopeCreateContent:        "CreateContent",
+		ScopeGetContent:           "GetContent",
+		ScopeListContents:         "ListContents",
+		ScopeUpdateContent:        "UpdateContent",
+		ScopeDeleteContent:        "DeleteContent",
+		ScopeRestoreContent:       "RestoreContent",
+		ScopeListContentRevisions: "ListContentRevisions",
+	}
+
+	// ScopeValue is the reverse-mapping of ScopeName.
+	ScopeValue = map[string]Scope{
+		ScopeName[ScopeCreateContent]:        ScopeCreateContent,
+		ScopeName[ScopeGetContent]:           ScopeGetContent,
+		ScopeName[ScopeListContents]:         ScopeListContents,
+		ScopeName[ScopeUpdateContent]:        ScopeUpdateContent,
+		ScopeName[ScopeDeleteContent]:        ScopeDeleteContent,
+		ScopeName[ScopeRestoreContent]:       ScopeRestoreContent,
+		ScopeName[ScopeListContentRevisions]: ScopeListContentRevisions,
+	}
+)
+
+// ScopeSetting is the available configurations of a Scope.
+type ScopeSetting struct {
+	Timeout time.Duration
+}
+
+// Followings are default values for ScopeSetting fields.
+const (
+	defaultTimeout = 5000 * time.Millisecond
+)
+
+// getDefaultScopeSettings returns default scope settings
+// for all scopes.
+func getDefaultScopeSettings() map[Scope]ScopeSetting {
+	defaultSettings := make(map[Scope]ScopeSetting)
+	for _, scope := range ScopeValue {
+		defaultSettings[scope] = ScopeSetting{
+			Timeout: defaultTimeout,
+		}
+	}
+	return defaultSettings
+}
+
+// Option controls the behavior of Handler.
+type Option func(*Handler) error
+
+// WithScopeSetting returns Option to set scope setting for
+// a specific scope name.
+func WithScopeSetting(scopeName string, scopeSetting ScopeSetting) Option {
+	return Option(func(h *Handler) error {
+		scope, ok := ScopeValue[scopeName]
+		if !ok {
+			return errUnknownScope
+		}
+
+		// validate setting
+		if scopeSetting.Timeout <= 0 {
+			scopeSetting.Timeout = defaultTimeout
+		}
+
+		h.scopeSettings[scope] = scopeSetting
+		return nil
+	})
+}
+
+// New creates a new Handler.
+func New(content content.Service, options ...Option) (*Handler, error) {
+	h := &Handler{
+		content:       content,
+		scopeSettings: getDefaultScopeSettings(),
+	}
+
+	// apply options
+	for _, opt := range options {
+		err := opt(h)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return h, nil
+}
+
+// Start registers the content gRPC service to the given
+// server.
+func (h *Handler) Start(server *grpc.Server) error {
+	contentv1.RegisterContentServiceServer(server, h)
+	return nil
+}
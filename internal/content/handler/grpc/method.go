@@ -0,0 +1,181 @@
+package grpc
+
+import (
+	"context"
+	"hbdtoyou/internal/content"
+	contextlib "hbdtoyou/pkg/context"
+	grpclib "hbdtoyou/pkg/grpc"
+	contentv1 "hbdtoyou/pkg/pb/content/v1"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// CreateContent implements contentv1.ContentServiceServer.
+func (h *Handler) CreateContent(ctx context.Context, req *contentv1.CreateContentRequest) (*contentv1.CreateContentResponse, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeCreateContent].Timeout)
+	defer cancel()
+
+	// the content is owned by the caller
+	userID, _ := contextlib.GetUserID(ctx)
+
+	reqContent := content.Content{
+		UserID:                userID,
+		TemplateID:            req.GetTemplateId(),
+		DetailContentJSONText: req.GetDetailContentJsonText(),
+		MediaIDs:              req.GetMediaIds(),
+		Status:                content.Status(req.GetStatus()),
+	}
+	if reqContent.Status == content.StatusUnknown {
+		reqContent.Status = content.StatusActive
+	}
+
+	contentID, err := h.content.CreateContent(ctx, reqContent)
+	if err != nil {
+		return nil, parseError(ctx, err, "CreateContent")
+	}
+
+	return &contentv1.CreateContentResponse{
+		Id: contentID,
+	}, nil
+}
+
+// GetContent implements contentv1.ContentServiceServer.
+func (h *Handler) GetContent(ctx context.Context, req *contentv1.GetContentRequest) (*contentv1.Content, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeGetContent].Timeout)
+	defer cancel()
+
+	c, err := h.content.GetContentByID(ctx, req.GetId())
+	if err != nil {
+		return nil, parseError(ctx, err, "GetContent")
+	}
+
+	return formatContent(c), nil
+}
+
+// ListContents implements contentv1.ContentServiceServer.
+func (h *Handler) ListContents(ctx context.Context, req *contentv1.ListContentsRequest) (*contentv1.ListContentsResponse, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeListContents].Timeout)
+	defer cancel()
+
+	filter := content.GetContentsFilter{
+		UserID:        req.GetUserId(),
+		TemplateID:    req.GetTemplateId(),
+		TemplateLabel: req.GetTemplateLabel(),
+		Status:        content.Status(req.GetStatus()),
+	}
+
+	// list contents shared with the caller instead
+	if req.GetSharedWithMe() {
+		filter.SharedWithUserID, _ = contextlib.GetUserID(ctx)
+	}
+
+	contents, err := h.content.GetContents(ctx, filter)
+	if err != nil {
+		return nil, parseError(ctx, err, "ListContents")
+	}
+
+	res := &contentv1.ListContentsResponse{
+		Contents: make([]*contentv1.Content, 0, len(contents)),
+	}
+	for _, c := range contents {
+		res.Contents = append(res.Contents, formatContent(c))
+	}
+
+	return res, nil
+}
+
+// UpdateContent implements contentv1.ContentServiceServer.
+func (h *Handler) UpdateContent(ctx context.Context, req *contentv1.UpdateContentRequest) (*contentv1.Content, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeUpdateContent].Timeout)
+	defer cancel()
+
+	if req.GetContent() == nil {
+		return nil, errBadRequest
+	}
+
+	paths, err := grpclib.GetUpdateMaskPaths(req.GetUpdateMask(), contentUpdatePaths...)
+	if err != nil {
+		return nil, errInvalidUpdateMask
+	}
+
+	// updating without the version might overwrite changes
+	// made by others
+	if req.GetContent().GetVersion() <= 0 {
+		return nil, errPreconditionRequired
+	}
+
+	current, err := h.content.GetContentByID(ctx, req.GetContent().GetId())
+	if err != nil {
+		return nil, parseError(ctx, err, "UpdateContent")
+	}
+
+	// the data has been changed since the client read it
+	if current.Version != req.GetContent().GetVersion() {
+		return nil, errVersionConflict
+	}
+
+	parseContent(req.GetContent(), paths, &current)
+
+	err = h.content.UpdateContent(ctx, current)
+	if err != nil {
+		return nil, parseError(ctx, err, "UpdateContent")
+	}
+
+	// the stored version is incremented on every update
+	current.Version++
+	return formatContent(current), nil
+}
+
+// DeleteContent implements contentv1.ContentServiceServer.
+func (h *Handler) DeleteContent(ctx context.Context, req *contentv1.DeleteContentRequest) (*emptypb.Empty, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeDeleteContent].Timeout)
+	defer cancel()
+
+	err := h.content.DeleteContentByID(ctx, req.GetId())
+	if err != nil {
+		return nil, parseError(ctx, err, "DeleteContent")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// RestoreContent implements contentv1.ContentServiceServer.
+func (h *Handler) RestoreContent(ctx context.Context, req *contentv1.RestoreContentRequest) (*emptypb.Empty, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeRestoreContent].Timeout)
+	defer cancel()
+
+	err := h.content.RestoreContentByID(ctx, req.GetId())
+	if err != nil {
+		return nil, parseError(ctx, err, "RestoreContent")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListContentRevisions implements
+// contentv1.ContentServiceServer.
+func (h *Handler) ListContentRevisions(ctx context.Context, req *contentv1.ListContentRevisionsRequest) (*contentv1.ListContentRevisionsResponse, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeListContentRevisions].Timeout)
+	defer cancel()
+
+	revisions, err := h.content.GetContentRevisions(ctx, req.GetContentId())
+	if err != nil {
+		return nil, parseError(ctx, err, "ListContentRevisions")
+	}
+
+	res := &contentv1.ListContentRevisionsResponse{
+		Revisions: make([]*contentv1.Revision, 0, len(revisions)),
+	}
+	for _, rev := range revisions {
+		res.Revisions = append(res.Revisions, formatRevision(rev))
+	}
+
+	return res, nil
+}
@@ -2,24 +2,44 @@ package http
 
 import (
 	"hbdtoyou/internal/content"
+	"hbdtoyou/pkg/jsondiff"
 	"net/http"
+	"strconv"
 )
 
+// timeFormat denotes the standard time format used in
+// content HTTP handlers.
+var timeFormat = "02/01/2006 3:04 PM -07:00"
+
 type contentHTTP struct {
-	ID                    *string `json:"id"`
-	UserID                *string `json:"user_id"`
-	Username              *string `json:"user_name"`
-	TemplateID            *string `json:"template_id"`
-	TemplateName          *string `json:"template_name"`
-	TemplateLabel         *string `json:"template_label"`
-	DetailContentJSONText *string `json:"detail_content_json_text"`
-	Type                  *string `json:"type"`
-	Status                *string `json:"status"`
+	ID                    *string   `json:"id"`
+	UserID                *string   `json:"user_id"`
+	Username              *string   `json:"user_name"`
+	TemplateID            *string   `json:"template_id"`
+	TemplateName          *string   `json:"template_name"`
+	TemplateLabel         *string   `json:"template_label"`
+	DetailContentJSONText *string   `json:"detail_content_json_text"`
+	MediaIDs              *[]string `json:"media_ids"`
+	Type                  *string   `json:"type"`
+	Status                *string   `json:"status"`
+	Version               *int64    `json:"version"`
+	DeleteTime            *string   `json:"delete_time,omitempty"`
 }
 
 func formatContent(c content.Content) contentHTTP {
 	status := c.Status.String()
 
+	mediaIDs := c.MediaIDs
+	if mediaIDs == nil {
+		mediaIDs = []string{}
+	}
+
+	var deleteTime *string
+	if !c.DeleteTime.IsZero() {
+		formatted := c.DeleteTime.Format(timeFormat)
+		deleteTime = &formatted
+	}
+
 	return contentHTTP{
 		ID:                    &c.ID,
 		UserID:                &c.UserID,
@@ -29,6 +49,9 @@ func formatContent(c content.Content) contentHTTP {
 		TemplateLabel:         &c.TemplateLabel,
 		Status:                &status,
 		DetailContentJSONText: &c.DetailContentJSONText,
+		MediaIDs:              &mediaIDs,
+		Version:               &c.Version,
+		DeleteTime:            deleteTime,
 	}
 }
 
@@ -48,9 +71,129 @@ func (c contentHTTP) parseContent(out *content.Content) error {
 		out.DetailContentJSONText = *c.DetailContentJSONText
 	}
 
+	if c.MediaIDs != nil {
+		out.MediaIDs = *c.MediaIDs
+	}
+
 	return nil
 }
 
+type revisionHTTP struct {
+	Number                *int    `json:"number"`
+	UserID                *string `json:"user_id"`
+	TemplateID            *string `json:"template_id"`
+	DetailContentJSONText *string `json:"detail_content_json_text"`
+	Status                *string `json:"status"`
+	CreateTime            *string `json:"create_time"`
+}
+
+func formatRevision(rev content.Revision) revisionHTTP {
+	status := rev.Status.String()
+	createTime := rev.CreateTime.Format(timeFormat)
+
+	return revisionHTTP{
+		Number:                &rev.Number,
+		UserID:                &rev.UserID,
+		TemplateID:            &rev.TemplateID,
+		DetailContentJSONText: &rev.DetailContentJSONText,
+		Status:                &status,
+		CreateTime:            &createTime,
+	}
+}
+
+type revisionDiffHTTP struct {
+	ContentID *string            `json:"content_id"`
+	From      *int               `json:"from"`
+	To        *int               `json:"to"`
+	Changes   *[]jsondiff.Change `json:"changes"`
+}
+
+func formatRevisionDiff(diff content.RevisionDiff) revisionDiffHTTP {
+	changes := diff.Changes
+	if changes == nil {
+		changes = []jsondiff.Change{}
+	}
+
+	return revisionDiffHTTP{
+		ContentID: &diff.ContentID,
+		From:      &diff.From,
+		To:        &diff.To,
+		Changes:   &changes,
+	}
+}
+
+type memberHTTP struct {
+	ID         *string `json:"id"`
+	ContentID  *string `json:"content_id"`
+	UserID     *string `json:"user_id"`
+	Email      *string `json:"email"`
+	Role       *string `json:"role"`
+	Status     *string `json:"status"`
+	InvitedBy  *string `json:"invited_by"`
+	CreateTime *string `json:"create_time"`
+}
+
+func formatMember(m content.Member) memberHTTP {
+	role := m.Role.String()
+	status := m.Status.String()
+	createTime := m.CreateTime.Format(timeFormat)
+
+	return memberHTTP{
+		ID:         &m.ID,
+		ContentID:  &m.ContentID,
+		UserID:     &m.UserID,
+		Email:      &m.Email,
+		Role:       &role,
+		Status:     &status,
+		InvitedBy:  &m.InvitedBy,
+		CreateTime: &createTime,
+	}
+}
+
+func (m memberHTTP) parseMember(out *content.Member) error {
+	if m.Email != nil {
+		out.Email = *m.Email
+	}
+
+	if m.Role != nil {
+		role, err := parseMemberRole(*m.Role)
+		if err != nil {
+			return err
+		}
+		out.Role = role
+	}
+
+	return nil
+}
+
+func parseMemberRole(req string) (content.MemberRole, error) {
+	switch req {
+	case content.MemberRoleViewer.String():
+		return content.MemberRoleViewer, nil
+	case content.MemberRoleEditor.String():
+		return content.MemberRoleEditor, nil
+	case content.MemberRoleOwner.String():
+		return content.MemberRoleOwner, nil
+	}
+
+	return content.MemberRoleUnknown, errInvalidMemberRole
+}
+
+// parseRevisionNumber parses the given revision number. An
+// empty revision number denotes the current content.
+func parseRevisionNumber(req string) (int, error) {
+	if req == "" {
+		return 0, nil
+	}
+
+	number, err := strconv.Atoi(req)
+	if err != nil || number < 0 {
+		return 0, errInvalidRevisionNumber
+	}
+
+	return number, nil
+}
+
 func parseContentStatus(req string) (content.Status, error) {
 	switch req {
 	case content.StatusActive.String():
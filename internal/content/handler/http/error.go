@@ -68,10 +68,62 @@ var (
 	// invalid.
 	errInvalidContentAccess = errors.New("INVALID_CONTENT_ACCESS")
 
+	// errInvalidMediaID is returned when the given media ID is
+	// invalid.
+	errInvalidMediaID = errors.New("INVALID_MEDIA_ID")
+
+	// errInvalidRevisionNumber is returned when the given
+	// revision number is invalid.
+	errInvalidRevisionNumber = errors.New("INVALID_REVISION_NUMBER")
+
+	// errInvalidMemberID is returned when the given member ID
+	// is invalid.
+	errInvalidMemberID = errors.New("INVALID_MEMBER_ID")
+
+	// errInvalidMemberEmail is returned when the given member
+	// email is invalid.
+	errInvalidMemberEmail = errors.New("INVALID_MEMBER_EMAIL")
+
+	// errInvalidMemberRole is returned when the given member
+	// role is invalid.
+	errInvalidMemberRole = errors.New("INVALID_MEMBER_ROLE")
+
+	// errMemberAlreadyExist is returned when the invited user
+	// is already a member or has a pending invitation.
+	errMemberAlreadyExist = errors.New("MEMBER_ALREADY_EXIST")
+
+	// errInvitationAlreadyResponded is returned when responding
+	// an invitation which is not pending anymore.
+	errInvitationAlreadyResponded = errors.New("INVITATION_ALREADY_RESPONDED")
+
+	// errTemplateNotAllowed is returned when the school does
+	// not allow the given template.
+	errTemplateNotAllowed = errors.New("TEMPLATE_NOT_ALLOWED")
+
+	// errContentQuotaExceeded is returned when the user would
+	// have more contents than the school allows.
+	errContentQuotaExceeded = errors.New("CONTENT_QUOTA_EXCEEDED")
+
+	// errForbidden is returned when the user is not allowed
+	// to access the requested data.
+	errForbidden = errors.New("FORBIDDEN")
+
 	// errInvalidUsername is returned when the given username
 	// is invalid.
 	errInvalidUsername = errors.New("INVALID_USERNAME")
 
+	// errInvalidIfMatch is returned when the given If-Match
+	// header is not a valid version ETag.
+	errInvalidIfMatch = errors.New("INVALID_IF_MATCH")
+
+	// errPreconditionRequired is returned when updating
+	// without If-Match header.
+	errPreconditionRequired = errors.New("PRECONDITION_REQUIRED")
+
+	// errVersionConflict is returned when the data has been
+	// changed since the given version.
+	errVersionConflict = errors.New("VERSION_CONFLICT")
+
 	// errMethodNotAllowed is returned when accessing not
 	// allowed HTTP method.
 	errMethodNotAllowed = errors.New("METHOD_NOT_ALLOWED")
@@ -101,6 +153,7 @@ var (
 	// and the handler should just return `errInternal` as the
 	// error instead
 	mapHTTPError = map[error]error{
+		content.ErrVersionConflict:              errVersionConflict,
 		content.ErrInvalidContentID:             errInvalidContentID,
 		content.ErrInvalidTemplateID:            errInvalidTemplateID,
 		content.ErrInvalidContentType:           errInvalidContentType,
@@ -110,5 +163,15 @@ var (
 		content.ErrInvalidUserID:                errInvalidUserID,
 		content.ErrDataNotFound:                 errDataNotFound,
 		content.ErrInvalidContentAccess:         errInvalidContentAccess,
+		content.ErrInvalidMediaID:               errInvalidMediaID,
+		content.ErrInvalidRevisionNumber:        errInvalidRevisionNumber,
+		content.ErrForbidden:                    errForbidden,
+		content.ErrInvalidMemberID:              errInvalidMemberID,
+		content.ErrInvalidMemberEmail:           errInvalidMemberEmail,
+		content.ErrInvalidMemberRole:            errInvalidMemberRole,
+		content.ErrMemberAlreadyExist:           errMemberAlreadyExist,
+		content.ErrInvitationAlreadyResponded:   errInvitationAlreadyResponded,
+		content.ErrTemplateNotAllowed:           errTemplateNotAllowed,
+		content.ErrContentQuotaExceeded:         errContentQuotaExceeded,
 	}
 )
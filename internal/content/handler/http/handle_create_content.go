@@ -116,6 +116,9 @@ func (h *contentsHandler) handleCreateContent(w http.ResponseWriter, r *http.Req
 				parsedErr = v
 				statusCode = http.StatusBadRequest
 			}
+			if parsedErr == errForbidden {
+				statusCode = http.StatusForbidden
+			}
 
 			// log the actual error if its internal error
 			if statusCode == http.StatusInternalServerError {
@@ -134,6 +137,20 @@ func (h *contentsHandler) handleCreateContent(w http.ResponseWriter, r *http.Req
 	case <-ctx.Done():
 		statusCode = http.StatusGatewayTimeout
 		err = errRequestTimeout
+
+		// the content might still be created, its response is replayed to the
+		// retries of the request
+		httplib.SetLateResponse(r, func() (int, []byte, bool) {
+			select {
+			case <-errChan:
+				return 0, nil, false
+			case contentID := <-resChan:
+				body, err := json.Marshal(httplib.ResponseEnvelope{
+					Data: contentID,
+				})
+				return http.StatusOK, body, err == nil
+			}
+		})
 	case err = <-errChan:
 	case contentID := <-resChan:
 		resBody, err = json.Marshal(httplib.ResponseEnvelope{
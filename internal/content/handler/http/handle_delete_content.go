@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"hbdtoyou/internal/content"
 	contextlib "hbdtoyou/pkg/context"
 	httplib "hbdtoyou/pkg/http"
 	"log"
@@ -26,7 +27,7 @@ func (h *contentHandler) handleDeleteContentByID(w http.ResponseWriter, r *http.
 	defer func() {
 		// error
 		if err != nil {
-			log.Printf("[Content HTTP][handleDeleteContent] Failed to delete content by ID. content ID: %d, Source: %s, Err: %s\n", contentID, source, err.Error())
+			log.Printf("[Content HTTP][handleDeleteContent] Failed to delete content by ID. content ID: %s, Source: %s, Err: %s\n", contentID, source, err.Error())
 			httplib.WriteErrorResponse(w, statusCode, []string{err.Error()})
 			return
 		}
@@ -75,7 +76,6 @@ func (h *contentHandler) handleDeleteContentByID(w http.ResponseWriter, r *http.
 
 		// TODO: add authorization flow with roles
 
-		var contentID string
 		err = h.content.DeleteContentByID(ctx, contentID)
 		if err != nil {
 			// determine error and status code, by default its internal error
@@ -85,6 +85,9 @@ func (h *contentHandler) handleDeleteContentByID(w http.ResponseWriter, r *http.
 				parsedErr = v
 				statusCode = http.StatusBadRequest
 			}
+			if err == content.ErrForbidden {
+				statusCode = http.StatusForbidden
+			}
 
 			// log the actual error if its internal error
 			if statusCode == http.StatusInternalServerError {
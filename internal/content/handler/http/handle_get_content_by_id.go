@@ -21,18 +21,19 @@ func (h *contentHandler) handleGetContentByID(w http.ResponseWriter, r *http.Req
 		source     string          // stores request source
 		resBody    []byte          // stores response body to write
 		statusCode = http.StatusOK // stores response status code
+		resVersion int64           // stores response data version
 	)
 
 	// write response
 	defer func() {
 		// error
 		if err != nil {
-			log.Printf("[Content HTTP][handleGetContentByID] Failed to get content by ID. content ID: %d, Source: %s, Err: %s\n", contentID, source, err.Error())
+			log.Printf("[Content HTTP][handleGetContentByID] Failed to get content by ID. content ID: %s, Source: %s, Err: %s\n", contentID, source, err.Error())
 			httplib.WriteErrorResponse(w, statusCode, []string{err.Error()})
 			return
 		}
 		// success
-		httplib.WriteResponse(w, resBody, statusCode, httplib.JSONContentTypeDecorator)
+		httplib.WriteResponse(w, resBody, statusCode, httplib.JSONContentTypeDecorator, httplib.NewVersionETagDecorator(resVersion))
 	}()
 
 	// prepare channels for main go routine
@@ -74,8 +75,8 @@ func (h *contentHandler) handleGetContentByID(w http.ResponseWriter, r *http.Req
 			return
 		}
 
-		var content content.Content
-		content, err = h.content.GetContentByID(ctx, contentID)
+		var result content.Content
+		result, err = h.content.GetContentByID(ctx, contentID)
 		if err != nil {
 			// determine error and status code, by default its internal error
 			parsedErr := errInternalServer
@@ -84,6 +85,9 @@ func (h *contentHandler) handleGetContentByID(w http.ResponseWriter, r *http.Req
 				parsedErr = v
 				statusCode = http.StatusBadRequest
 			}
+			if err == content.ErrForbidden {
+				statusCode = http.StatusForbidden
+			}
 
 			// log the actual error if its internal error
 			if statusCode == http.StatusInternalServerError {
@@ -94,7 +98,7 @@ func (h *contentHandler) handleGetContentByID(w http.ResponseWriter, r *http.Req
 			return
 		}
 
-		resChan <- content
+		resChan <- result
 	}()
 
 	// wait and handle main go routine
@@ -104,6 +108,7 @@ func (h *contentHandler) handleGetContentByID(w http.ResponseWriter, r *http.Req
 		err = errRequestTimeout
 	case err = <-errChan:
 	case res := <-resChan:
+		resVersion = res.Version
 		resBody, err = json.Marshal(httplib.ResponseEnvelope{
 			Data: formatContent(res),
 		})
@@ -82,6 +82,11 @@ func (h *contentsHandler) handleGetContents(w http.ResponseWriter, r *http.Reque
 			return
 		}
 
+		// list contents shared with the caller instead
+		if r.URL.Query().Get("shared_with_me") == "true" {
+			filter.SharedWithUserID = reqUserID
+		}
+
 		var contents []content.Content
 		contents, err = h.content.GetContents(ctx, filter)
 		if err != nil {
@@ -92,6 +97,9 @@ func (h *contentsHandler) handleGetContents(w http.ResponseWriter, r *http.Reque
 				parsedErr = v
 				statusCode = http.StatusBadRequest
 			}
+			if err == content.ErrForbidden {
+				statusCode = http.StatusForbidden
+			}
 
 			// log the actual error if its internal error
 			if statusCode == http.StatusInternalServerError {
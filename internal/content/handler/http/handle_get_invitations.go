@@ -0,0 +1,119 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"hbdtoyou/internal/content"
+	contextlib "hbdtoyou/pkg/context"
+	httplib "hbdtoyou/pkg/http"
+	"log"
+	"net/http"
+)
+
+func (h *invitationsHandler) handleGetInvitations(w http.ResponseWriter, r *http.Request) {
+	// add timeout to context
+	timeout := h.scopeSettings[ScopeGetInvitations].Timeout
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	var (
+		err        error           // stores error in this handler
+		source     string          // stores request source
+		resBody    []byte          // stores response body to write
+		statusCode = http.StatusOK // stores response status code
+	)
+
+	// write response
+	defer func() {
+		// error
+		if err != nil {
+			log.Printf("[Content HTTP][handleGetInvitations] Failed to get invitations. Source: %s, Err: %s\n", source, err.Error())
+			httplib.WriteErrorResponse(w, statusCode, []string{err.Error()})
+			return
+		}
+		// success
+		httplib.WriteResponse(w, resBody, statusCode, httplib.JSONContentTypeDecorator)
+	}()
+
+	// prepare channels for main go routine
+	resChan := make(chan []content.Member, 1)
+	errChan := make(chan error, 1)
+
+	go func() {
+		// get request source
+		source, err = httplib.GetSourceFromHeader(r)
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errSourceNotProvided
+			return
+		}
+		ctx = contextlib.SetSource(ctx, source)
+
+		// get user ID
+		reqUserID, err := httplib.GetUserIDFromHeader(r)
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errInvalidUserID
+			return
+		}
+		ctx = contextlib.SetUserID(ctx, reqUserID)
+
+		// get token from header
+		token, err := httplib.GetBearerTokenFromHeader(r)
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errInvalidToken
+			return
+		}
+
+		// check access token
+		err = checkAccessToken(ctx, h.auth, token, reqUserID, "handleGetInvitations")
+		if err != nil {
+			statusCode = http.StatusUnauthorized
+			errChan <- err
+			return
+		}
+
+		res, err := h.content.GetInvitations(ctx)
+		if err != nil {
+			// determine error and status code, by default its internal error
+			parsedErr := errInternalServer
+			statusCode = http.StatusInternalServerError
+			if v, ok := mapHTTPError[err]; ok {
+				parsedErr = v
+				statusCode = http.StatusBadRequest
+			}
+			if err == content.ErrForbidden {
+				statusCode = http.StatusForbidden
+			}
+
+			// log the actual error if its internal error
+			if statusCode == http.StatusInternalServerError {
+				log.Printf("[Content HTTP][handleGetInvitations] Internal error from GetInvitations. Err: %s\n", err.Error())
+			}
+
+			errChan <- parsedErr
+			return
+		}
+
+		resChan <- res
+	}()
+
+	// wait and handle main go routine
+	select {
+	case <-ctx.Done():
+		statusCode = http.StatusGatewayTimeout
+		err = errRequestTimeout
+	case err = <-errChan:
+	case res := <-resChan:
+		// format each invitation
+		invitations := make([]memberHTTP, 0)
+		for _, m := range res {
+			invitations = append(invitations, formatMember(m))
+		}
+
+		resBody, err = json.Marshal(httplib.ResponseEnvelope{
+			Data: invitations,
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"hbdtoyou/internal/content"
+	contextlib "hbdtoyou/pkg/context"
+	httplib "hbdtoyou/pkg/http"
+	"log"
+	"net/http"
+)
+
+func (h *contentRevisionRestoreHandler) handleRestoreContentRevision(w http.ResponseWriter, r *http.Request, contentID, number string) {
+	// add timeout to context
+	timeout := h.scopeSettings[ScopeRestoreContentRevision].Timeout
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	var (
+		err        error           // stores error in this handler
+		source     string          // stores request source
+		resBody    []byte          // stores response body to write
+		statusCode = http.StatusOK // stores response status code
+		resVersion int64           // stores response data version
+	)
+
+	// write response
+	defer func() {
+		// error
+		if err != nil {
+			log.Printf("[Content HTTP][handleRestoreContentRevision] Failed to restore content revision. content ID: %s, Revision: %s, Source: %s, Err: %s\n", contentID, number, source, err.Error())
+			httplib.WriteErrorResponse(w, statusCode, []string{err.Error()})
+			return
+		}
+		// success
+		httplib.WriteResponse(w, resBody, statusCode, httplib.JSONContentTypeDecorator, httplib.NewVersionETagDecorator(resVersion))
+	}()
+
+	// prepare channels for main go routine
+	resChan := make(chan int64, 1)
+	errChan := make(chan error, 1)
+
+	go func() {
+		// get request source
+		source, err = httplib.GetSourceFromHeader(r)
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errSourceNotProvided
+			return
+		}
+		ctx = contextlib.SetSource(ctx, source)
+
+		// get user ID
+		reqUserID, err := httplib.GetUserIDFromHeader(r)
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errInvalidUserID
+			return
+		}
+		ctx = contextlib.SetUserID(ctx, reqUserID)
+
+		// get token from header
+		token, err := httplib.GetBearerTokenFromHeader(r)
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errInvalidToken
+			return
+		}
+
+		// get expected version, restoring without it might
+		// overwrite changes made by others
+		version, err := httplib.GetIfMatchVersionFromHeader(r)
+		if err == httplib.ErrIfMatchNotFound {
+			statusCode = http.StatusPreconditionRequired
+			errChan <- errPreconditionRequired
+			return
+		}
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errInvalidIfMatch
+			return
+		}
+
+		// parse revision number
+		revisionNumber, err := parseRevisionNumber(number)
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- err
+			return
+		}
+
+		// check access token
+		err = checkAccessToken(ctx, h.auth, token, reqUserID, "handleRestoreContentRevision")
+		if err != nil {
+			statusCode = http.StatusUnauthorized
+			errChan <- err
+			return
+		}
+
+		err = h.content.RestoreContentRevision(ctx, contentID, revisionNumber, version)
+		if err != nil {
+			// determine error and status code, by default its internal error
+			parsedErr := errInternalServer
+			statusCode = http.StatusInternalServerError
+			if v, ok := mapHTTPError[err]; ok {
+				parsedErr = v
+				statusCode = http.StatusBadRequest
+			}
+			if err == content.ErrForbidden {
+				statusCode = http.StatusForbidden
+			}
+			if err == content.ErrDataNotFound {
+				statusCode = http.StatusNotFound
+			}
+			if err == content.ErrVersionConflict {
+				statusCode = http.StatusPreconditionFailed
+			}
+
+			// log the actual error if its internal error
+			if statusCode == http.StatusInternalServerError {
+				log.Printf("[Content HTTP][handleRestoreContentRevision] Internal error from RestoreContentRevision. Err: %s\n", err.Error())
+			}
+
+			errChan <- parsedErr
+			return
+		}
+
+		// the stored version is incremented on restore
+		resChan <- version + 1
+	}()
+
+	// wait and handle main go routine
+	select {
+	case <-ctx.Done():
+		statusCode = http.StatusGatewayTimeout
+		err = errRequestTimeout
+	case err = <-errChan:
+	case res := <-resChan:
+		resVersion = res
+		resBody, err = json.Marshal(httplib.ResponseEnvelope{
+			Data: contentID,
+		})
+	}
+}
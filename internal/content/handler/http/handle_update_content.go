@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"hbdtoyou/internal/content"
 	contextlib "hbdtoyou/pkg/context"
 	httplib "hbdtoyou/pkg/http"
 	"io/ioutil"
@@ -21,22 +22,23 @@ func (h *contentHandler) handleUpdateContent(w http.ResponseWriter, r *http.Requ
 		source     string          // stores request source
 		resBody    []byte          // stores response body to write
 		statusCode = http.StatusOK // stores response status code
+		resVersion int64           // stores response data version
 	)
 
 	// write response
 	defer func() {
 		// error
 		if err != nil {
-			log.Printf("[Content HTTP][handleUpdateContent] Failed to update content by ID. content ID: %d, Source: %s, Err: %s\n", contentID, source, err.Error())
+			log.Printf("[Content HTTP][handleUpdateContent] Failed to update content by ID. content ID: %s, Source: %s, Err: %s\n", contentID, source, err.Error())
 			httplib.WriteErrorResponse(w, statusCode, []string{err.Error()})
 			return
 		}
 		// success
-		httplib.WriteResponse(w, resBody, statusCode, httplib.JSONContentTypeDecorator)
+		httplib.WriteResponse(w, resBody, statusCode, httplib.JSONContentTypeDecorator, httplib.NewVersionETagDecorator(resVersion))
 	}()
 
 	// prepare channels for main go routine
-	resChan := make(chan string, 1)
+	resChan := make(chan content.Content, 1)
 	errChan := make(chan error, 1)
 
 	go func() {
@@ -66,6 +68,20 @@ func (h *contentHandler) handleUpdateContent(w http.ResponseWriter, r *http.Requ
 			return
 		}
 
+		// get expected version, updating without it might
+		// overwrite changes made by others
+		version, err := httplib.GetIfMatchVersionFromHeader(r)
+		if err == httplib.ErrIfMatchNotFound {
+			statusCode = http.StatusPreconditionRequired
+			errChan <- errPreconditionRequired
+			return
+		}
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errInvalidIfMatch
+			return
+		}
+
 		// read body
 		body, err := ioutil.ReadAll(r.Body)
 		if err != nil {
@@ -103,16 +119,26 @@ func (h *contentHandler) handleUpdateContent(w http.ResponseWriter, r *http.Requ
 				parsedErr = v
 				statusCode = http.StatusBadRequest
 			}
+			if err == content.ErrForbidden {
+				statusCode = http.StatusForbidden
+			}
 
 			// log the actual error if its internal error
 			if statusCode == http.StatusInternalServerError {
-				log.Printf("[Content HTTP][handleUpdateContent] Internal error from GetContentByID, content ID: %d. Err: %s\n", contentID, err.Error())
+				log.Printf("[Content HTTP][handleUpdateContent] Internal error from GetContentByID, content ID: %s. Err: %s\n", contentID, err.Error())
 			}
 
 			errChan <- parsedErr
 			return
 		}
 
+		// the data has been changed since the client read it
+		if current.Version != version {
+			statusCode = http.StatusPreconditionFailed
+			errChan <- errVersionConflict
+			return
+		}
+
 		// parse content from request body
 		err = request.parseContent(&current)
 		if err != nil {
@@ -130,6 +156,15 @@ func (h *contentHandler) handleUpdateContent(w http.ResponseWriter, r *http.Requ
 				parsedErr = v
 				statusCode = http.StatusBadRequest
 			}
+			if err == content.ErrForbidden {
+				statusCode = http.StatusForbidden
+			}
+			if err == content.ErrDataNotFound {
+				statusCode = http.StatusNotFound
+			}
+			if err == content.ErrVersionConflict {
+				statusCode = http.StatusPreconditionFailed
+			}
 
 			// log the actual error if its internal error
 			if statusCode == http.StatusInternalServerError {
@@ -140,7 +175,9 @@ func (h *contentHandler) handleUpdateContent(w http.ResponseWriter, r *http.Requ
 			return
 		}
 
-		resChan <- contentID
+		// the stored version is incremented on every update
+		current.Version++
+		resChan <- current
 	}()
 
 	// wait and handle main go routine
@@ -149,9 +186,10 @@ func (h *contentHandler) handleUpdateContent(w http.ResponseWriter, r *http.Requ
 		statusCode = http.StatusGatewayTimeout
 		err = errRequestTimeout
 	case err = <-errChan:
-	case contentID := <-resChan:
+	case res := <-resChan:
+		resVersion = res.Version
 		resBody, err = json.Marshal(httplib.ResponseEnvelope{
-			Data: contentID,
+			Data: res.ID,
 		})
 	}
 }
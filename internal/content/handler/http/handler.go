@@ -49,3 +49,174 @@ func (h *contentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
 	}
 }
+
+type contentRestoreHandler struct {
+	content       content.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *contentRestoreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	contentID := vars["id"]
+
+	switch r.Method {
+	case http.MethodPost:
+		h.handleRestoreContentByID(w, r, contentID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type trashContentsHandler struct {
+	content       content.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *trashContentsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetTrashContents(w, r)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type contentRevisionsHandler struct {
+	content       content.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *contentRevisionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	contentID := vars["id"]
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetContentRevisions(w, r, contentID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type contentRevisionRestoreHandler struct {
+	content       content.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *contentRevisionRestoreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	contentID := vars["id"]
+	number := vars["number"]
+
+	switch r.Method {
+	case http.MethodPost:
+		h.handleRestoreContentRevision(w, r, contentID, number)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type contentRevisionDiffHandler struct {
+	content       content.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *contentRevisionDiffHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	contentID := vars["id"]
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetContentRevisionDiff(w, r, contentID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type contentMembersHandler struct {
+	content       content.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *contentMembersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	contentID := vars["id"]
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetContentMembers(w, r, contentID)
+	case http.MethodPost:
+		h.handleInviteContentMember(w, r, contentID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type contentMemberHandler struct {
+	content       content.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *contentMemberHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	contentID := vars["id"]
+	memberID := vars["member_id"]
+
+	switch r.Method {
+	case http.MethodDelete:
+		h.handleRemoveContentMember(w, r, contentID, memberID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type invitationsHandler struct {
+	content       content.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *invitationsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetInvitations(w, r)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+// invitationResponseHandler handles either accepting or
+// declining an invitation.
+type invitationResponseHandler struct {
+	content       content.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+	scope         Scope
+	accept        bool
+}
+
+func (h *invitationResponseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	memberID := vars["id"]
+
+	switch r.Method {
+	case http.MethodPost:
+		h.handleRespondInvitation(w, r, memberID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
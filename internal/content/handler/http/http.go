@@ -4,6 +4,8 @@ import (
 	"errors"
 	"hbdtoyou/internal/auth"
 	"hbdtoyou/internal/content"
+	httplib "hbdtoyou/pkg/http"
+	"hbdtoyou/pkg/ratelimit"
 	"net/http"
 	"time"
 
@@ -11,8 +13,9 @@ import (
 )
 
 var (
-	errUnknownScope  = errors.New("unknown scope name")
-	errUnknownConfig = errors.New("unknown config name")
+	errUnknownScope     = errors.New("unknown scope name")
+	errUnknownConfig    = errors.New("unknown config name")
+	errInvalidRateLimit = errors.New("invalid rate limit")
 )
 
 // Handler contains finance HTTP handlers.
@@ -21,6 +24,8 @@ type Handler struct {
 	content       content.Service
 	auth          auth.Service
 	scopeSettings map[Scope]ScopeSetting
+	rateLimiter   *httplib.RateLimiter
+	idempotency   *httplib.Idempotency
 }
 
 // handler is the HTTP handler wrapper.
@@ -45,6 +50,46 @@ var (
 		Name: "contents",
 		URL:  "/v1/contents",
 	}
+	HandlerContentRestore = HandlerIdentity{
+		Name: "content_restore",
+		URL:  "/v1/contents/{id}/restore",
+	}
+	HandlerTrashContents = HandlerIdentity{
+		Name: "trash_contents",
+		URL:  "/v1/trash/contents",
+	}
+	HandlerContentRevisions = HandlerIdentity{
+		Name: "content_revisions",
+		URL:  "/v1/contents/{id}/revisions",
+	}
+	HandlerContentRevisionRestore = HandlerIdentity{
+		Name: "content_revision_restore",
+		URL:  "/v1/contents/{id}/revisions/{number}/restore",
+	}
+	HandlerContentRevisionDiff = HandlerIdentity{
+		Name: "content_revision_diff",
+		URL:  "/v1/contents/{id}/revisions/diff",
+	}
+	HandlerContentMembers = HandlerIdentity{
+		Name: "content_members",
+		URL:  "/v1/contents/{id}/members",
+	}
+	HandlerContentMember = HandlerIdentity{
+		Name: "content_member",
+		URL:  "/v1/contents/{id}/members/{member_id}",
+	}
+	HandlerInvitations = HandlerIdentity{
+		Name: "invitations",
+		URL:  "/v1/invitations",
+	}
+	HandlerInvitationAccept = HandlerIdentity{
+		Name: "invitation_accept",
+		URL:  "/v1/invitations/{id}/accept",
+	}
+	HandlerInvitationDecline = HandlerIdentity{
+		Name: "invitation_decline",
+		URL:  "/v1/invitations/{id}/decline",
+	}
 )
 
 // Scope is a shared settings identifier.
@@ -62,6 +107,17 @@ const (
 	ScopeGetContentByID
 	ScopeUpdateContent
 	ScopeDeleteContent
+	ScopeRestoreContent
+	ScopeGetTrashContents
+	ScopeGetContentRevisions
+	ScopeRestoreContentRevision
+	ScopeGetContentRevisionDiff
+	ScopeGetContentMembers
+	ScopeInviteContentMember
+	ScopeRemoveContentMember
+	ScopeGetInvitations
+	ScopeAcceptInvitation
+	ScopeDeclineInvitation
 )
 
 var (
@@ -73,6 +129,20 @@ var (
 		ScopeGetContentByID: "GetContentByID",
 		ScopeUpdateContent:  "UpdateContent",
 		ScopeDeleteContent:  "DeleteContent",
+		ScopeRestoreContent: "RestoreContent",
+
+		ScopeGetTrashContents: "GetTrashContents",
+
+		ScopeGetContentRevisions:    "GetContentRevisions",
+		ScopeRestoreContentRevision: "RestoreContentRevision",
+		ScopeGetContentRevisionDiff: "GetContentRevisionDiff",
+
+		ScopeGetContentMembers:   "GetContentMembers",
+		ScopeInviteContentMember: "InviteContentMember",
+		ScopeRemoveContentMember: "RemoveContentMember",
+		ScopeGetInvitations:      "GetInvitations",
+		ScopeAcceptInvitation:    "AcceptInvitation",
+		ScopeDeclineInvitation:   "DeclineInvitation",
 	}
 
 	// ScopeValue is the reverse-mapping of ScopeName.
@@ -82,12 +152,31 @@ var (
 		ScopeName[ScopeGetContentByID]: ScopeGetContentByID,
 		ScopeName[ScopeUpdateContent]:  ScopeUpdateContent,
 		ScopeName[ScopeDeleteContent]:  ScopeDeleteContent,
+		ScopeName[ScopeRestoreContent]: ScopeRestoreContent,
+
+		ScopeName[ScopeGetTrashContents]: ScopeGetTrashContents,
+
+		ScopeName[ScopeGetContentRevisions]:    ScopeGetContentRevisions,
+		ScopeName[ScopeRestoreContentRevision]: ScopeRestoreContentRevision,
+		ScopeName[ScopeGetContentRevisionDiff]: ScopeGetContentRevisionDiff,
+
+		ScopeName[ScopeGetContentMembers]:   ScopeGetContentMembers,
+		ScopeName[ScopeInviteContentMember]: ScopeInviteContentMember,
+		ScopeName[ScopeRemoveContentMember]: ScopeRemoveContentMember,
+		ScopeName[ScopeGetInvitations]:      ScopeGetInvitations,
+		ScopeName[ScopeAcceptInvitation]:    ScopeAcceptInvitation,
+		ScopeName[ScopeDeclineInvitation]:   ScopeDeclineInvitation,
 	}
 )
 
 // ScopeSetting is the available configurations of a Scope.
 type ScopeSetting struct {
 	Timeout time.Duration
+
+	// RateLimit limits the requests of the scope per user,
+	// or per client IP if the request has no user. The zero
+	// value does not limit.
+	RateLimit ratelimit.Limit
 }
 
 // Followings are default values for ScopeSetting fields.
@@ -144,12 +233,35 @@ func WithScopeSetting(scopeName string, scopeSetting ScopeSetting) Option {
 		if scopeSetting.Timeout <= 0 {
 			scopeSetting.Timeout = defaultTimeout
 		}
+		if err := scopeSetting.RateLimit.Validate(); err != nil {
+			return errInvalidRateLimit
+		}
 
 		h.scopeSettings[scope] = scopeSetting
 		return nil
 	})
 }
 
+// WithRateLimiter returns Option to limit the rate of requests
+// using the given rate limiter, with the RateLimit of each
+// scope setting.
+func WithRateLimiter(rateLimiter *httplib.RateLimiter) Option {
+	return Option(func(h *Handler) error {
+		h.rateLimiter = rateLimiter
+		return nil
+	})
+}
+
+// WithIdempotency returns Option to replay the saved response
+// of the create requests sent again with the same
+// Idempotency-Key header.
+func WithIdempotency(idempotency *httplib.Idempotency) Option {
+	return Option(func(h *Handler) error {
+		h.idempotency = idempotency
+		return nil
+	})
+}
+
 // New creates a new Handler.
 //
 // For the given Option, WithScopeSetting() should come first
@@ -190,6 +302,70 @@ func (h *Handler) createHTTPHandler(configName string) (http.Handler, error) {
 			auth:          h.auth,
 			scopeSettings: h.scopeSettings,
 		}
+	case HandlerContentRestore.Name:
+		httpHandler = &contentRestoreHandler{
+			content:       h.content,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerTrashContents.Name:
+		httpHandler = &trashContentsHandler{
+			content:       h.content,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerContentRevisions.Name:
+		httpHandler = &contentRevisionsHandler{
+			content:       h.content,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerContentRevisionRestore.Name:
+		httpHandler = &contentRevisionRestoreHandler{
+			content:       h.content,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerContentRevisionDiff.Name:
+		httpHandler = &contentRevisionDiffHandler{
+			content:       h.content,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerContentMembers.Name:
+		httpHandler = &contentMembersHandler{
+			content:       h.content,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerContentMember.Name:
+		httpHandler = &contentMemberHandler{
+			content:       h.content,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerInvitations.Name:
+		httpHandler = &invitationsHandler{
+			content:       h.content,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerInvitationAccept.Name:
+		httpHandler = &invitationResponseHandler{
+			content:       h.content,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+			scope:         ScopeAcceptInvitation,
+			accept:        true,
+		}
+	case HandlerInvitationDecline.Name:
+		httpHandler = &invitationResponseHandler{
+			content:       h.content,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+			scope:         ScopeDeclineInvitation,
+			accept:        false,
+		}
 	default:
 		return httpHandler, errUnknownConfig
 	}
@@ -200,7 +376,7 @@ func (h *Handler) createHTTPHandler(configName string) (http.Handler, error) {
 // Start starts all HTTP handlers.
 func (h *Handler) Start(multiplexer *mux.Router) error {
 	for _, handler := range h.handlers {
-		multiplexer.Handle(handler.identity.URL, handler.h)
+		multiplexer.Handle(handler.identity.URL, h.limitRate(handler.identity, h.ensureIdempotency(handler.identity, handler.h)))
 	}
 	return nil
 }
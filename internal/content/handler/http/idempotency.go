@@ -0,0 +1,39 @@
+package http
+
+import (
+	httplib "hbdtoyou/pkg/http"
+	"net/http"
+)
+
+// idempotentScopes are the scopes of the requests whose
+// response is replayed when they are sent again with the same
+// Idempotency-Key header.
+var idempotentScopes = map[Scope]bool{
+	ScopeCreateContent: true,
+}
+
+// ensureIdempotency returns the given handler of the given
+// identity replaying the saved response of the requests of
+// idempotentScopes.
+func (h *Handler) ensureIdempotency(identity HandlerIdentity, next http.Handler) http.Handler {
+	if h.idempotency == nil {
+		return next
+	}
+
+	scopes := handlerScopes[identity.Name]
+	return h.idempotency.Handler(next, httplib.IdempotencyFunc(func(r *http.Request) (string, string, bool) {
+		scope, ok := scopes[r.Method]
+		if !ok || !idempotentScopes[scope] {
+			return "", "", false
+		}
+
+		// the saved responses are only replayed to their user,
+		// the request is handled as is if it is unauthorized
+		userID, ok := httplib.GetAuthenticatedUserID(r)
+		if !ok {
+			return "", "", false
+		}
+
+		return ScopeName[scope], userID, true
+	}))
+}
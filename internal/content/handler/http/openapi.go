@@ -0,0 +1,74 @@
+package http
+
+import (
+	"hbdtoyou/pkg/openapi"
+	"net/http"
+)
+
+// routes defines the OpenAPI routes of each HTTP handler, it
+// must be updated along with the methods served by handler.go.
+var routes = map[string][]openapi.Route{
+	HandlerContents.Name: {
+		{Method: http.MethodPost, Summary: "Create content", Request: contentHTTP{}, Response: "", Idempotent: true},
+		{Method: http.MethodGet, Summary: "Get contents", Response: []contentHTTP{}, Query: []*openapi.Parameter{
+			openapi.Query("user_id", "string", "Owner of the contents."),
+			openapi.Query("template_id", "string", "Template of the contents."),
+			openapi.Query("template_label", "string", "Label of the template of the contents."),
+			openapi.Query("status", "string", "Status of the contents."),
+			openapi.Query("shared_with_me", "boolean", "Lists the contents shared with the caller instead."),
+		}},
+	},
+	HandlerContent.Name: {
+		{Method: http.MethodGet, Summary: "Get content by ID", Response: contentHTTP{}, Versioned: true},
+		{Method: http.MethodPatch, Summary: "Update content", Request: contentHTTP{}, Response: "", IfMatch: true},
+		{Method: http.MethodDelete, Summary: "Delete content", Response: ""},
+	},
+	HandlerContentRestore.Name: {
+		{Method: http.MethodPost, Summary: "Restore content", Response: ""},
+	},
+	HandlerTrashContents.Name: {
+		{Method: http.MethodGet, Summary: "Get trash contents", Response: []contentHTTP{}},
+	},
+	HandlerContentRevisions.Name: {
+		{Method: http.MethodGet, Summary: "Get content revisions", Response: []revisionHTTP{}},
+	},
+	HandlerContentRevisionRestore.Name: {
+		{Method: http.MethodPost, Summary: "Restore content revision", Response: "", Versioned: true},
+	},
+	HandlerContentRevisionDiff.Name: {
+		{Method: http.MethodGet, Summary: "Get content revision diff", Response: revisionDiffHTTP{}, Query: []*openapi.Parameter{
+			openapi.Query("from", "integer", "Number of the older revision."),
+			openapi.Query("to", "integer", "Number of the newer revision."),
+		}},
+	},
+	HandlerContentMembers.Name: {
+		{Method: http.MethodGet, Summary: "Get content members", Response: []memberHTTP{}},
+		{Method: http.MethodPost, Summary: "Invite content member", Request: memberHTTP{}, Response: ""},
+	},
+	HandlerContentMember.Name: {
+		{Method: http.MethodDelete, Summary: "Remove content member", Response: ""},
+	},
+	HandlerInvitations.Name: {
+		{Method: http.MethodGet, Summary: "Get invitations", Response: []memberHTTP{}},
+	},
+	HandlerInvitationAccept.Name: {
+		{Method: http.MethodPost, Summary: "Accept invitation", Response: ""},
+	},
+	HandlerInvitationDecline.Name: {
+		{Method: http.MethodPost, Summary: "Decline invitation", Response: ""},
+	},
+}
+
+// Routes returns the OpenAPI routes of the registered HTTP
+// handlers.
+func (h *Handler) Routes() []openapi.Route {
+	var res []openapi.Route
+	for _, handler := range h.handlers {
+		for _, route := range routes[handler.identity.Name] {
+			route.Path = handler.identity.URL
+			route.Tag = "content"
+			res = append(res, route)
+		}
+	}
+	return res
+}
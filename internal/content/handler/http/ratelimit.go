@@ -0,0 +1,71 @@
+package http
+
+import (
+	httplib "hbdtoyou/pkg/http"
+	"hbdtoyou/pkg/ratelimit"
+	"net/http"
+)
+
+// handlerScopes maps the methods of each handler to the scope
+// of their requests, the rate limit of the scope is applied
+// before the request is handled.
+var handlerScopes = map[string]map[string]Scope{
+	HandlerContent.Name: {
+		http.MethodGet:    ScopeGetContentByID,
+		http.MethodPatch:  ScopeUpdateContent,
+		http.MethodDelete: ScopeDeleteContent,
+	},
+	HandlerContents.Name: {
+		http.MethodPost: ScopeCreateContent,
+		http.MethodGet:  ScopeGetContents,
+	},
+	HandlerContentRestore.Name: {
+		http.MethodPost: ScopeRestoreContent,
+	},
+	HandlerTrashContents.Name: {
+		http.MethodGet: ScopeGetTrashContents,
+	},
+	HandlerContentRevisions.Name: {
+		http.MethodGet: ScopeGetContentRevisions,
+	},
+	HandlerContentRevisionRestore.Name: {
+		http.MethodPost: ScopeRestoreContentRevision,
+	},
+	HandlerContentRevisionDiff.Name: {
+		http.MethodGet: ScopeGetContentRevisionDiff,
+	},
+	HandlerContentMembers.Name: {
+		http.MethodGet:  ScopeGetContentMembers,
+		http.MethodPost: ScopeInviteContentMember,
+	},
+	HandlerContentMember.Name: {
+		http.MethodDelete: ScopeRemoveContentMember,
+	},
+	HandlerInvitations.Name: {
+		http.MethodGet: ScopeGetInvitations,
+	},
+	HandlerInvitationAccept.Name: {
+		http.MethodPost: ScopeAcceptInvitation,
+	},
+	HandlerInvitationDecline.Name: {
+		http.MethodPost: ScopeDeclineInvitation,
+	},
+}
+
+// limitRate returns the given handler of the given identity
+// limited by the rate limit of the scope of each request.
+func (h *Handler) limitRate(identity HandlerIdentity, next http.Handler) http.Handler {
+	if h.rateLimiter == nil {
+		return next
+	}
+
+	scopes := handlerScopes[identity.Name]
+	return h.rateLimiter.Handler(next, httplib.LimitFunc(func(r *http.Request) (string, ratelimit.Limit, bool) {
+		scope, ok := scopes[r.Method]
+		if !ok {
+			return "", ratelimit.Limit{}, false
+		}
+
+		return ScopeName[scope], h.scopeSettings[scope].RateLimit, true
+	}))
+}
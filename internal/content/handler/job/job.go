@@ -0,0 +1,124 @@
+package job
+
+import (
+	"context"
+	"hbdtoyou/internal/content"
+	joblib "hbdtoyou/pkg/job"
+	"log"
+	"time"
+)
+
+// Followings are default values for PurgeSetting fields.
+const (
+	defaultPurgeRetention = 30 * 24 * time.Hour
+	defaultPurgeInterval  = 1 * time.Hour
+)
+
+// Handler contains content background jobs.
+type Handler struct {
+	content      content.Service
+	purgeSetting PurgeSetting
+	tenants      []string
+	runners      []*joblib.Runner
+	timeNow      func() time.Time
+}
+
+// PurgeSetting is the available configurations of the job
+// purging deleted contents.
+type PurgeSetting struct {
+	// Retention is how long a deleted content is kept in
+	// trash before it is purged.
+	Retention time.Duration
+
+	// Interval is how often the job runs.
+	Interval time.Duration
+}
+
+// Option controls the behavior of Handler.
+type Option func(*Handler) error
+
+// WithPurgeSetting returns Option to set the purge job
+// setting.
+func WithPurgeSetting(setting PurgeSetting) Option {
+	return Option(func(h *Handler) error {
+		if setting.Retention > 0 {
+			h.purgeSetting.Retention = setting.Retention
+		}
+		if setting.Interval > 0 {
+			h.purgeSetting.Interval = setting.Interval
+		}
+		return nil
+	})
+}
+
+// WithTenants returns Option to run every job once for each
+// of the given tenants.
+func WithTenants(tenants []string) Option {
+	return Option(func(h *Handler) error {
+		h.tenants = tenants
+		return nil
+	})
+}
+
+// New creates a new Handler.
+func New(content content.Service, options ...Option) (*Handler, error) {
+	h := &Handler{
+		content: content,
+		purgeSetting: PurgeSetting{
+			Retention: defaultPurgeRetention,
+			Interval:  defaultPurgeInterval,
+		},
+		timeNow: time.Now,
+	}
+
+	// apply options
+	for _, opt := range options {
+		err := opt(h)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	purgeRunner, err := joblib.NewRunner("purge_contents", h.purgeSetting.Interval, 0, joblib.ForEachTenant(h.tenants, h.purgeDeletedContents))
+	if err != nil {
+		return nil, err
+	}
+	h.runners = append(h.runners, purgeRunner)
+
+	return h, nil
+}
+
+// Start starts all jobs.
+func (h *Handler) Start() error {
+	for _, runner := range h.runners {
+		if err := runner.Start(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop stops all jobs and waits until the running ones are
+// finished.
+func (h *Handler) Stop() {
+	for _, runner := range h.runners {
+		runner.Stop()
+	}
+}
+
+// purgeDeletedContents permanently deletes contents that
+// have been in trash longer than the retention.
+func (h *Handler) purgeDeletedContents(ctx context.Context) error {
+	before := h.timeNow().Add(-h.purgeSetting.Retention)
+
+	purged, err := h.content.PurgeDeletedContents(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	if purged > 0 {
+		log.Printf("[Content Job][purgeDeletedContents] Purged %d deleted contents\n", purged)
+	}
+
+	return nil
+}
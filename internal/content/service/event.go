@@ -0,0 +1,43 @@
+package service
+
+import (
+	"hbdtoyou/internal/content"
+	"hbdtoyou/pkg/event"
+	"time"
+)
+
+// newContentEvent returns an event of the given type denoting
+// a change of the given content.
+func newContentEvent(eventType string, c content.Content, createTime time.Time) (event.Event, error) {
+	return event.New(eventType, c.ID, content.ContentEvent{
+		ContentID:  c.ID,
+		UserID:     c.UserID,
+		TemplateID: c.TemplateID,
+		Status:     c.Status.String(),
+	}, createTime)
+}
+
+// getContentEvents returns the events of changing the given
+// current content into the requested one. current is nil for a
+// created content.
+func getContentEvents(reqContent content.Content, current *content.Content, createTime time.Time) ([]event.Event, error) {
+	var eventTypes []string
+	if current == nil {
+		eventTypes = append(eventTypes, content.EventCreated)
+	}
+
+	if reqContent.Status == content.StatusActive && (current == nil || current.Status != content.StatusActive) {
+		eventTypes = append(eventTypes, content.EventPublished)
+	}
+
+	events := make([]event.Event, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		e, err := newContentEvent(eventType, reqContent, createTime)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	return events, nil
+}
@@ -2,15 +2,26 @@ package service
 
 import (
 	"context"
-	"hbdtoyou/internal/auth"
+	"encoding/json"
 	"hbdtoyou/internal/content"
+	"hbdtoyou/internal/entitlement"
+	"hbdtoyou/internal/media"
 	"hbdtoyou/internal/template"
+	"net/mail"
+	"strings"
+	"time"
+
+	contextlib "hbdtoyou/pkg/context"
+	"hbdtoyou/pkg/jsondiff"
 
 	"github.com/google/uuid"
 )
 
 // CreateContent creates a new content and returns
 // the created content ID.
+//
+// Users other than administrators only create their own
+// contents.
 func (s *service) CreateContent(ctx context.Context, reqContent content.Content) (string, error) {
 	// validate fields
 	err := validateContent(reqContent)
@@ -18,33 +29,115 @@ func (s *service) CreateContent(ctx context.Context, reqContent content.Content)
 		return "", err
 	}
 
-	currentTemplate, err := s.template.GetTemplateByID(ctx, reqContent.TemplateID)
-	if err != nil {
-		return "", err
+	userID, ok := contextlib.GetUserID(ctx)
+	if !ok {
+		return "", content.ErrInvalidUserID
 	}
 
-	if currentTemplate.Label == template.LabelPremium {
-		user, err := s.user.GetUserByID(ctx, reqContent.UserID)
+	if reqContent.UserID != userID {
+		caller, err := s.user.GetUserByID(ctx, userID)
 		if err != nil {
 			return "", err
 		}
 
-		if user.Type == auth.TypeFree || user.Quota == 0 {
-			return "", content.ErrInvalidContentAccess
+		if !caller.IsAdmin() {
+			return "", content.ErrForbidden
 		}
 	}
 
+	// validate referenced media
+	err = s.validateMediaIDs(ctx, reqContent)
+	if err != nil {
+		return "", err
+	}
+
+	currentTemplate, err := s.template.GetTemplateByID(ctx, reqContent.TemplateID)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.validateTemplateAllowed(ctx, reqContent.TemplateID)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := s.user.GetUserByID(ctx, reqContent.UserID)
+	if err != nil {
+		return "", err
+	}
+
 	// update fields
 	reqContent.CreateTime = s.timeNow()
 
 	// get pg store client using transaction
-	pgStoreClient, err := s.pgStore.NewClient(false)
+	pgStoreClient, err := s.pgStore.NewClient(ctx, true)
 	if err != nil {
 		return "", err
 	}
 
 	// inserts content in pgstore
 	contentID, err := pgStoreClient.CreateContent(ctx, reqContent)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return "", err
+	}
+
+	// the created content counts toward the quota
+	err = s.validateContentQuota(ctx, pgStoreClient, reqContent.UserID, contentID)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return "", err
+	}
+
+	// the content user is the owner member of the content
+	_, err = pgStoreClient.CreateContentMember(ctx, content.Member{
+		ContentID:  contentID,
+		UserID:     reqContent.UserID,
+		Email:      normalizeEmail(user.Email),
+		Role:       content.MemberRoleOwner,
+		Status:     content.MemberStatusAccepted,
+		CreateTime: reqContent.CreateTime,
+	})
+	if err != nil {
+		pgStoreClient.Rollback()
+		return "", err
+	}
+
+	// paid templates are used on behalf of the user
+	// entitlements, the content is not created if the user
+	// has none
+	err = s.useTemplate(ctx, pgStoreClient, reqContent.UserID, contentID, currentTemplate)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return "", err
+	}
+
+	// the created content is published
+	reqContent.ID = contentID
+	events, err := getContentEvents(reqContent, nil, reqContent.CreateTime)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return "", err
+	}
+
+	for _, e := range events {
+		err = pgStoreClient.CreateEvent(ctx, e)
+		if err != nil {
+			pgStoreClient.Rollback()
+			return "", err
+		}
+	}
+
+	// the content user is notified of a published content
+	if reqContent.Status == content.StatusActive {
+		_, err = pgStoreClient.CreateNotification(ctx, buildPublishedNotification(contentID, reqContent.UserID, reqContent.CreateTime))
+		if err != nil {
+			pgStoreClient.Rollback()
+			return "", err
+		}
+	}
+
+	err = pgStoreClient.Commit()
 	if err != nil {
 		return "", err
 	}
@@ -61,7 +154,7 @@ func (s *service) GetContentByID(ctx context.Context, contentID string) (content
 	}
 
 	// get pg store client without transaction
-	pgStoreClient, err := s.pgStore.NewClient(false)
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
 	if err != nil {
 		return content.Content{}, err
 	}
@@ -72,13 +165,45 @@ func (s *service) GetContentByID(ctx context.Context, contentID string) (content
 		return content.Content{}, err
 	}
 
+	// any member can read the content
+	err = s.checkMemberRole(ctx, pgStoreClient, contentID, content.MemberRoleViewer)
+	if err != nil {
+		return content.Content{}, err
+	}
+
 	return result, nil
 }
 
 // GetContents returns all contents.
+//
+// Users other than administrators only get their own
+// contents, or contents shared with them.
 func (s *service) GetContents(ctx context.Context, filter content.GetContentsFilter) ([]content.Content, error) {
+	userID, ok := contextlib.GetUserID(ctx)
+	if !ok {
+		return nil, content.ErrInvalidUserID
+	}
+
+	caller, err := s.user.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !caller.IsAdmin() {
+		switch {
+		case filter.SharedWithUserID != "":
+			if filter.SharedWithUserID != caller.ID {
+				return nil, content.ErrForbidden
+			}
+		case filter.UserID != "" && filter.UserID != caller.ID:
+			return nil, content.ErrForbidden
+		default:
+			filter.UserID = caller.ID
+		}
+	}
+
 	// get pg store client without transaction
-	pgStoreClient, err := s.pgStore.NewClient(false)
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
 	if err != nil {
 		return nil, err
 	}
@@ -111,18 +236,217 @@ func (s *service) UpdateContent(ctx context.Context, reqContent content.Content)
 		return err
 	}
 
+	// validate referenced media
+	err = s.validateMediaIDs(ctx, reqContent)
+	if err != nil {
+		return err
+	}
+
 	// update fields
 	reqContent.UpdateTime = s.timeNow()
 
 	// get pg store client using transaction
-	pgStoreClient, err := s.pgStore.NewClient(false)
+	pgStoreClient, err := s.pgStore.NewClient(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	// get current content from pgstore to be saved as revision
+	current, err := pgStoreClient.GetContentByID(ctx, reqContent.ID)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return err
+	}
+
+	// only editors can update the content
+	err = s.checkMemberRole(ctx, pgStoreClient, reqContent.ID, content.MemberRoleEditor)
 	if err != nil {
+		pgStoreClient.Rollback()
 		return err
 	}
 
-	// inserts task in pgstore
+	// the saved revision would not be the previous state of
+	// the content if it was updated in the meantime
+	if current.Version != reqContent.Version {
+		pgStoreClient.Rollback()
+		return content.ErrVersionConflict
+	}
+
+	// updates content in pgstore
 	err = pgStoreClient.UpdateContent(ctx, reqContent)
 	if err != nil {
+		pgStoreClient.Rollback()
+		return err
+	}
+
+	// switching to a paid template is charged to the content
+	// owner entitlements
+	if current.TemplateID != reqContent.TemplateID {
+		nextTemplate, err := s.template.GetTemplateByID(ctx, reqContent.TemplateID)
+		if err != nil {
+			pgStoreClient.Rollback()
+			return err
+		}
+
+		err = s.validateTemplateAllowed(ctx, reqContent.TemplateID)
+		if err != nil {
+			pgStoreClient.Rollback()
+			return err
+		}
+
+		err = s.useTemplate(ctx, pgStoreClient, current.UserID, current.ID, nextTemplate)
+		if err != nil {
+			pgStoreClient.Rollback()
+			return err
+		}
+	}
+
+	// only changes on the revisioned attributes are saved
+	if current.TemplateID != reqContent.TemplateID ||
+		current.DetailContentJSONText != reqContent.DetailContentJSONText ||
+		current.Status != reqContent.Status {
+		userID, ok := contextlib.GetUserID(ctx)
+		if !ok {
+			userID = current.UserID
+		}
+
+		_, err = pgStoreClient.CreateContentRevision(ctx, content.Revision{
+			ContentID:             current.ID,
+			UserID:                userID,
+			TemplateID:            current.TemplateID,
+			DetailContentJSONText: current.DetailContentJSONText,
+			Status:                current.Status,
+			CreateTime:            reqContent.UpdateTime,
+		})
+		if err != nil {
+			pgStoreClient.Rollback()
+			return err
+		}
+	}
+
+	// the change is published, the owner of the content is
+	// kept from the current content
+	reqContent.UserID = current.UserID
+	events, err := getContentEvents(reqContent, &current, reqContent.UpdateTime)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return err
+	}
+
+	for _, e := range events {
+		err = pgStoreClient.CreateEvent(ctx, e)
+		if err != nil {
+			pgStoreClient.Rollback()
+			return err
+		}
+	}
+
+	// the content user is notified once the content is
+	// published
+	if current.Status != content.StatusActive && reqContent.Status == content.StatusActive {
+		_, err = pgStoreClient.CreateNotification(ctx, buildPublishedNotification(current.ID, current.UserID, reqContent.UpdateTime))
+		if err != nil {
+			pgStoreClient.Rollback()
+			return err
+		}
+	}
+
+	return pgStoreClient.Commit()
+}
+
+// validateTemplateAllowed returns nil if the school of the
+// request allows the template with the given template ID.
+func (s *service) validateTemplateAllowed(ctx context.Context, templateID string) error {
+	schoolID, _ := contextlib.GetSchoolID(ctx)
+	settings, err := s.schoolConfig.GetSettings(ctx, schoolID)
+	if err != nil {
+		return err
+	}
+
+	if !settings.IsTemplateAllowed(templateID) {
+		return content.ErrTemplateNotAllowed
+	}
+
+	return nil
+}
+
+// validateContentQuota returns nil if the user with the given
+// user ID can have the content with the given content ID along
+// with their other contents. The contents are counted using the
+// given store client, so uncommitted contents are included.
+//
+// The quota of the user is the quota of their quota
+// entitlements, at least the default quota of the school of the
+// request.
+func (s *service) validateContentQuota(ctx context.Context, pgStoreClient PGStoreClient, userID, contentID string) error {
+	schoolID, _ := contextlib.GetSchoolID(ctx)
+	settings, err := s.schoolConfig.GetSettings(ctx, schoolID)
+	if err != nil {
+		return err
+	}
+
+	// no quota is set
+	if settings.DefaultQuota == 0 {
+		return nil
+	}
+
+	entitlements, err := s.entitlement.GetEntitlements(pgStoreClient.ShareTx(ctx), userID)
+	if err != nil {
+		return err
+	}
+
+	var quota int
+	for _, e := range entitlements {
+		if e.Type == entitlement.TypeQuota {
+			quota += e.Quota
+		}
+	}
+	if quota < settings.DefaultQuota {
+		quota = settings.DefaultQuota
+	}
+
+	contents, err := pgStoreClient.GetContents(ctx, content.GetContentsFilter{
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	// the given content is not counted, the quota is exceeded
+	// if the other contents fill it already
+	var count int
+	for _, c := range contents {
+		if c.ID != contentID {
+			count++
+		}
+	}
+
+	if count >= quota {
+		return content.ErrContentQuotaExceeded
+	}
+
+	return nil
+}
+
+// useTemplate authorizes the given user to use the given
+// template in the given content, using the user entitlements
+// if the template is paid. The entitlements are used within
+// the transaction of the given store client, so a consumed
+// quota is given back if the content is not saved.
+func (s *service) useTemplate(ctx context.Context, pgStoreClient PGStoreClient, userID string, contentID string, t template.Template) error {
+	if !t.IsPaid() {
+		return nil
+	}
+
+	err := s.entitlement.UseEntitlement(pgStoreClient.ShareTx(ctx), entitlement.Usage{
+		UserID:     userID,
+		TemplateID: t.ID,
+		ContentID:  contentID,
+	})
+	if err != nil {
+		if err == entitlement.ErrNoEntitlement {
+			return content.ErrInvalidContentAccess
+		}
 		return err
 	}
 
@@ -131,6 +455,9 @@ func (s *service) UpdateContent(ctx context.Context, reqContent content.Content)
 
 // DeleteContent delete a content
 // with the given content id.
+//
+// The content is moved to trash and can be restored
+// until it is purged.
 func (s *service) DeleteContentByID(ctx context.Context, contentID string) error {
 	// validate id
 	if contentID == "" {
@@ -138,20 +465,497 @@ func (s *service) DeleteContentByID(ctx context.Context, contentID string) error
 	}
 
 	// get pg store client using transaction
-	pgStoreClient, err := s.pgStore.NewClient(false)
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	// make sure the content exists
+	_, err = pgStoreClient.GetContentByID(ctx, contentID)
+	if err != nil {
+		return err
+	}
+
+	// only the owner can delete the content
+	err = s.checkMemberRole(ctx, pgStoreClient, contentID, content.MemberRoleOwner)
 	if err != nil {
 		return err
 	}
 
 	// delete content in pgstore
-	err = pgStoreClient.DeleteContentByID(ctx, contentID)
+	err = pgStoreClient.DeleteContentByID(ctx, contentID, s.timeNow())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RestoreContentByID restores a deleted content with the
+// given content id from trash.
+func (s *service) RestoreContentByID(ctx context.Context, contentID string) error {
+	// validate id
+	if contentID == "" {
+		return content.ErrInvalidContentID
+	}
+
+	// get pg store client using transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	// only the owner can restore the content
+	err = s.checkMemberRole(ctx, pgStoreClient, contentID, content.MemberRoleOwner)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return err
+	}
+
+	// restore content in pgstore
+	err = pgStoreClient.RestoreContentByID(ctx, contentID)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return err
+	}
+
+	// get restored content from pgstore
+	restored, err := pgStoreClient.GetContentByID(ctx, contentID)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return err
+	}
+
+	// a content can not be restored without its template
+	_, err = s.template.GetTemplateByID(ctx, restored.TemplateID)
+	if err != nil {
+		pgStoreClient.Rollback()
+		if err == template.ErrTemplateNotFound {
+			return content.ErrInvalidTemplateID
+		}
+		return err
+	}
+
+	// the restored content counts toward the quota again
+	err = s.validateContentQuota(ctx, pgStoreClient, restored.UserID, restored.ID)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return err
+	}
+
+	return pgStoreClient.Commit()
+}
+
+// PurgeDeletedContents permanently deletes contents that
+// were deleted before the given time and returns the
+// number of purged contents.
+func (s *service) PurgeDeletedContents(ctx context.Context, before time.Time) (int64, error) {
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return 0, err
+	}
+
+	// purge contents in pgstore
+	return pgStoreClient.PurgeDeletedContents(ctx, before)
+}
+
+// GetContentRevisions returns all revisions of a content
+// with the given content ID, newest first.
+func (s *service) GetContentRevisions(ctx context.Context, contentID string) ([]content.Revision, error) {
+	// make sure the content exists
+	_, err := s.GetContentByID(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// get revisions from pgstore
+	return pgStoreClient.GetContentRevisions(ctx, contentID)
+}
+
+// RestoreContentRevision restores a content with the given
+// content ID to the state saved in the given revision.
+//
+// The state before restoring is saved as a new revision, so
+// restoring can be undone as well.
+func (s *service) RestoreContentRevision(ctx context.Context, contentID string, number int, version int64) error {
+	// validate revision number
+	if number <= 0 {
+		return content.ErrInvalidRevisionNumber
+	}
+
+	// get current content
+	current, err := s.GetContentByID(ctx, contentID)
+	if err != nil {
+		return err
+	}
+
+	if current.Version != version {
+		return content.ErrVersionConflict
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	// get revision from pgstore
+	revision, err := pgStoreClient.GetContentRevision(ctx, contentID, number)
 	if err != nil {
 		return err
 	}
 
+	// a content can not be restored without its template
+	_, err = s.template.GetTemplateByID(ctx, revision.TemplateID)
+	if err != nil {
+		if err == template.ErrTemplateNotFound {
+			return content.ErrInvalidTemplateID
+		}
+		return err
+	}
+
+	if revision.TemplateID != current.TemplateID {
+		err = s.validateTemplateAllowed(ctx, revision.TemplateID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// update fields
+	current.TemplateID = revision.TemplateID
+	current.DetailContentJSONText = revision.DetailContentJSONText
+	current.Status = revision.Status
+
+	return s.UpdateContent(ctx, current)
+}
+
+// GetContentRevisionDiff returns the changes between two
+// revisions of a content with the given content ID.
+//
+// A zero revision number denotes the current state of
+// the content.
+func (s *service) GetContentRevisionDiff(ctx context.Context, contentID string, from, to int) (content.RevisionDiff, error) {
+	// validate revision numbers
+	if from < 0 || to < 0 {
+		return content.RevisionDiff{}, content.ErrInvalidRevisionNumber
+	}
+
+	// get current content
+	current, err := s.GetContentByID(ctx, contentID)
+	if err != nil {
+		return content.RevisionDiff{}, err
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return content.RevisionDiff{}, err
+	}
+
+	// get documents of both revisions
+	fromDoc, err := getRevisionDocument(ctx, pgStoreClient, current, from)
+	if err != nil {
+		return content.RevisionDiff{}, err
+	}
+
+	toDoc, err := getRevisionDocument(ctx, pgStoreClient, current, to)
+	if err != nil {
+		return content.RevisionDiff{}, err
+	}
+
+	changes, err := jsondiff.Diff(fromDoc, toDoc)
+	if err != nil {
+		return content.RevisionDiff{}, err
+	}
+
+	return content.RevisionDiff{
+		ContentID: contentID,
+		From:      from,
+		To:        to,
+		Changes:   changes,
+	}, nil
+}
+
+// getRevisionDocument returns JSON document of a content
+// revision with the given number to be compared. A zero
+// number returns the document of the given current content.
+func getRevisionDocument(ctx context.Context, pgStoreClient PGStoreClient, current content.Content, number int) ([]byte, error) {
+	revision := content.Revision{
+		TemplateID:            current.TemplateID,
+		DetailContentJSONText: current.DetailContentJSONText,
+		Status:                current.Status,
+	}
+
+	if number > 0 {
+		var err error
+		revision, err = pgStoreClient.GetContentRevision(ctx, current.ID, number)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// detail content which is not a valid JSON is compared
+	// as a plain string
+	var detailContent interface{} = revision.DetailContentJSONText
+	if json.Valid([]byte(revision.DetailContentJSONText)) {
+		detailContent = json.RawMessage(revision.DetailContentJSONText)
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"template_id":    revision.TemplateID,
+		"status":         revision.Status.String(),
+		"detail_content": detailContent,
+	})
+}
+
+// InviteContentMember invites a user with the given email
+// to contribute on a content and returns the created
+// invitation ID. Only the content owner can invite.
+func (s *service) InviteContentMember(ctx context.Context, reqMember content.Member) (string, error) {
+	// validate fields
+	if reqMember.ContentID == "" {
+		return "", content.ErrInvalidContentID
+	}
+
+	email, err := mail.ParseAddress(reqMember.Email)
+	if err != nil {
+		return "", content.ErrInvalidMemberEmail
+	}
+
+	// the owner role can not be given away
+	if reqMember.Role != content.MemberRoleEditor && reqMember.Role != content.MemberRoleViewer {
+		return "", content.ErrInvalidMemberRole
+	}
+
+	// get caller
+	userID, ok := contextlib.GetUserID(ctx)
+	if !ok {
+		return "", content.ErrInvalidUserID
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return "", err
+	}
+
+	// make sure the content exists
+	_, err = pgStoreClient.GetContentByID(ctx, reqMember.ContentID)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.checkMemberRole(ctx, pgStoreClient, reqMember.ContentID, content.MemberRoleOwner)
+	if err != nil {
+		return "", err
+	}
+
+	// update fields
+	reqMember.UserID = ""
+	reqMember.Email = normalizeEmail(email.Address)
+	reqMember.Status = content.MemberStatusPending
+	reqMember.InvitedBy = userID
+	reqMember.CreateTime = s.timeNow()
+
+	// inserts invitation in pgstore
+	return pgStoreClient.CreateContentMember(ctx, reqMember)
+}
+
+// GetContentMembers returns all members and pending
+// invitations of a content with the given content ID.
+func (s *service) GetContentMembers(ctx context.Context, contentID string) ([]content.Member, error) {
+	// make sure the content exists and readable by the caller
+	_, err := s.GetContentByID(ctx, contentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// get members from pgstore
+	return pgStoreClient.GetContentMembers(ctx, contentID)
+}
+
+// RemoveContentMember removes a member with the given
+// member ID from a content. The content owner can remove
+// any other member, while a member can only leave.
+func (s *service) RemoveContentMember(ctx context.Context, contentID, memberID string) error {
+	// validate ids
+	if contentID == "" {
+		return content.ErrInvalidContentID
+	}
+
+	if memberID == "" {
+		return content.ErrInvalidMemberID
+	}
+
+	// get caller
+	userID, ok := contextlib.GetUserID(ctx)
+	if !ok {
+		return content.ErrInvalidUserID
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	// get member from pgstore
+	member, err := pgStoreClient.GetContentMemberByID(ctx, memberID)
+	if err != nil {
+		return err
+	}
+
+	if member.ContentID != contentID {
+		return content.ErrDataNotFound
+	}
+
+	// a content can not be left without its owner
+	if member.Role == content.MemberRoleOwner {
+		return content.ErrForbidden
+	}
+
+	if member.UserID != userID {
+		err = s.checkMemberRole(ctx, pgStoreClient, contentID, content.MemberRoleOwner)
+		if err != nil {
+			return err
+		}
+	}
+
+	// delete member in pgstore
+	return pgStoreClient.DeleteContentMember(ctx, contentID, memberID)
+}
+
+// GetInvitations returns pending invitations sent to the
+// email of the caller.
+func (s *service) GetInvitations(ctx context.Context) ([]content.Member, error) {
+	// get caller
+	userID, ok := contextlib.GetUserID(ctx)
+	if !ok {
+		return nil, content.ErrInvalidUserID
+	}
+
+	user, err := s.user.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// a user without a verified email has no invitation
+	if user.Email == "" {
+		return nil, nil
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// get invitations from pgstore
+	return pgStoreClient.GetPendingInvitations(ctx, normalizeEmail(user.Email))
+}
+
+// RespondInvitation accepts or declines an invitation with
+// the given member ID sent to the email of the caller.
+func (s *service) RespondInvitation(ctx context.Context, memberID string, accept bool) error {
+	// validate id
+	if memberID == "" {
+		return content.ErrInvalidMemberID
+	}
+
+	// get caller
+	userID, ok := contextlib.GetUserID(ctx)
+	if !ok {
+		return content.ErrInvalidUserID
+	}
+
+	user, err := s.user.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	// get invitation from pgstore
+	member, err := pgStoreClient.GetContentMemberByID(ctx, memberID)
+	if err != nil {
+		return err
+	}
+
+	// invitations sent to other users are hidden, the email
+	// of a user is the one verified on its social login and
+	// can not be changed by the user
+	if user.Email == "" || member.Email != normalizeEmail(user.Email) {
+		return content.ErrDataNotFound
+	}
+
+	// update fields
+	member.Status = content.MemberStatusDeclined
+	if accept {
+		member.UserID = userID
+		member.Status = content.MemberStatusAccepted
+	}
+	member.UpdateTime = s.timeNow()
+
+	// update invitation in pgstore
+	return pgStoreClient.UpdateContentMemberStatus(ctx, member)
+}
+
+// checkMemberRole checks whether the caller from context has
+// at least the given role on a content with the given
+// content ID. Administrators have every role.
+func (s *service) checkMemberRole(ctx context.Context, pgStoreClient PGStoreClient, contentID string, role content.MemberRole) error {
+	userID, ok := contextlib.GetUserID(ctx)
+	if !ok {
+		return content.ErrInvalidUserID
+	}
+
+	caller, err := s.user.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if caller.IsAdmin() {
+		return nil
+	}
+
+	current, err := pgStoreClient.GetContentMemberRole(ctx, contentID, userID)
+	if err != nil {
+		if err == content.ErrDataNotFound {
+			return content.ErrForbidden
+		}
+		return err
+	}
+
+	if !current.AtLeast(role) {
+		return content.ErrForbidden
+	}
+
 	return nil
 }
 
+// normalizeEmail returns the given email in the form stored
+// for content members.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
 // validateContent validates fields of the given content
 // whether its comply the predetermined rules.
 func validateContent(reqContent content.Content) error {
@@ -174,3 +978,23 @@ func validateContent(reqContent content.Content) error {
 
 	return nil
 }
+
+// validateMediaIDs validates that every media referenced by
+// the given content exists and owned by the content user.
+func (s *service) validateMediaIDs(ctx context.Context, reqContent content.Content) error {
+	for _, mediaID := range reqContent.MediaIDs {
+		m, err := s.media.GetMediaByID(ctx, mediaID)
+		if err != nil {
+			if err == media.ErrDataNotFound || err == media.ErrInvalidMediaID {
+				return content.ErrInvalidMediaID
+			}
+			return err
+		}
+
+		if m.UserID != reqContent.UserID || m.Status == media.StatusFailed {
+			return content.ErrInvalidMediaID
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"hbdtoyou/internal/notification"
+	"time"
+)
+
+// buildPublishedNotification returns the notification of a
+// published content with the given ID to its user.
+func buildPublishedNotification(contentID, userID string, publishTime time.Time) notification.Notification {
+	return notification.Notification{
+		UserID:  userID,
+		Event:   notification.EventContentPublished,
+		Subject: "Your content is published",
+		Body:    "Your content has been published and is ready to be shared.",
+		Data: map[string]string{
+			"content_id": contentID,
+		},
+		Status:          notification.StatusPending,
+		NextAttemptTime: publishTime,
+		CreateTime:      publishTime,
+	}
+}
@@ -2,25 +2,37 @@ package service
 
 import (
 	"hbdtoyou/internal/auth"
+	"hbdtoyou/internal/entitlement"
+	"hbdtoyou/internal/media"
+	"hbdtoyou/internal/schoolconfig"
 	"hbdtoyou/internal/template"
 	"time"
 )
 
 // service implements subject.Service.
 type service struct {
-	pgStore  PGStore
-	user     auth.Service
-	template template.Service
-	timeNow  func() time.Time
+	pgStore     PGStore
+	user        auth.Service
+	template    template.Service
+	media       media.Service
+	entitlement entitlement.Service
+
+	// schoolConfig provides the limits of the school of a
+	// request.
+	schoolConfig schoolconfig.Service
+	timeNow      func() time.Time
 }
 
 // New creates a new service.
-func New(pgStore PGStore, user auth.Service, template template.Service) (*service, error) {
+func New(pgStore PGStore, user auth.Service, template template.Service, media media.Service, entitlement entitlement.Service, schoolConfig schoolconfig.Service) (*service, error) {
 	s := &service{
-		pgStore:  pgStore,
-		user:     user,
-		template: template,
-		timeNow:  time.Now,
+		pgStore:      pgStore,
+		user:         user,
+		template:     template,
+		media:        media,
+		entitlement:  entitlement,
+		schoolConfig: schoolConfig,
+		timeNow:      time.Now,
 	}
 
 	return s, nil
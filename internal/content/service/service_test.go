@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"hbdtoyou/internal/auth"
+	"hbdtoyou/internal/content"
+	"hbdtoyou/internal/entitlement"
+	"hbdtoyou/internal/schoolconfig"
+	"hbdtoyou/internal/template"
+	"sync"
+	"testing"
+	"time"
+
+	contextlib "hbdtoyou/pkg/context"
+)
+
+// memoryStore is an in-memory PGStore holding the data used by
+// the tests. Transactions are not isolated.
+type memoryStore struct {
+	mu       sync.Mutex
+	contents map[string]content.Content
+	roles    map[string]map[string]content.MemberRole
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		contents: make(map[string]content.Content),
+		roles:    make(map[string]map[string]content.MemberRole),
+	}
+}
+
+// addContent adds the given content owned by its user.
+func (s *memoryStore) addContent(c content.Content) {
+	s.contents[c.ID] = c
+	s.roles[c.ID] = map[string]content.MemberRole{
+		c.UserID: content.MemberRoleOwner,
+	}
+}
+
+func (s *memoryStore) NewClient(ctx context.Context, useTx bool) (PGStoreClient, error) {
+	return &memoryStoreClient{s: s}, nil
+}
+
+// memoryStoreClient implements the PGStoreClient methods used
+// by the tests, the others panic.
+type memoryStoreClient struct {
+	PGStoreClient
+
+	s *memoryStore
+}
+
+func (c *memoryStoreClient) Commit() error   { return nil }
+func (c *memoryStoreClient) Rollback() error { return nil }
+
+func (c *memoryStoreClient) ShareTx(ctx context.Context) context.Context { return ctx }
+
+func (c *memoryStoreClient) GetContentByID(ctx context.Context, contentID string) (content.Content, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	result, ok := c.s.contents[contentID]
+	if !ok || !result.DeleteTime.IsZero() {
+		return content.Content{}, content.ErrDataNotFound
+	}
+	return result, nil
+}
+
+func (c *memoryStoreClient) GetContents(ctx context.Context, filter content.GetContentsFilter) ([]content.Content, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	var result []content.Content
+	for _, v := range c.s.contents {
+		if v.UserID == filter.UserID && v.DeleteTime.IsZero() != filter.Deleted {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+func (c *memoryStoreClient) DeleteContentByID(ctx context.Context, contentID string, deleteTime time.Time) error {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	result, ok := c.s.contents[contentID]
+	if !ok || !result.DeleteTime.IsZero() {
+		return content.ErrDataNotFound
+	}
+	result.DeleteTime = deleteTime
+	c.s.contents[contentID] = result
+	return nil
+}
+
+func (c *memoryStoreClient) RestoreContentByID(ctx context.Context, contentID string) error {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	result, ok := c.s.contents[contentID]
+	if !ok || result.DeleteTime.IsZero() {
+		return content.ErrDataNotFound
+	}
+	result.DeleteTime = time.Time{}
+	c.s.contents[contentID] = result
+	return nil
+}
+
+func (c *memoryStoreClient) GetContentMemberRole(ctx context.Context, contentID, userID string) (content.MemberRole, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	role, ok := c.s.roles[contentID][userID]
+	if !ok {
+		return 0, content.ErrDataNotFound
+	}
+	return role, nil
+}
+
+// memoryUsers is an auth.Service returning the users it holds.
+type memoryUsers struct {
+	auth.Service
+
+	users map[string]auth.User
+}
+
+func (u memoryUsers) GetUserByID(ctx context.Context, userID string) (auth.User, error) {
+	user, ok := u.users[userID]
+	if !ok {
+		return auth.User{}, auth.ErrDataNotFound
+	}
+	return user, nil
+}
+
+// freeTemplates is a template.Service returning every template
+// as a free one.
+type freeTemplates struct {
+	template.Service
+}
+
+func (freeTemplates) GetTemplateByID(ctx context.Context, templateID string) (template.Template, error) {
+	return template.Template{ID: templateID, Label: template.LabelFree}, nil
+}
+
+// memoryEntitlements is an entitlement.Service returning the
+// entitlements it holds.
+type memoryEntitlements struct {
+	entitlement.Service
+
+	entitlements []entitlement.Entitlement
+}
+
+func (e memoryEntitlements) GetEntitlements(ctx context.Context, userID string) ([]entitlement.Entitlement, error) {
+	var result []entitlement.Entitlement
+	for _, v := range e.entitlements {
+		if v.UserID == userID {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+// fixedSettings is a schoolconfig.Service returning the same
+// settings for every school.
+type fixedSettings struct {
+	schoolconfig.Service
+
+	settings schoolconfig.Settings
+}
+
+func (f fixedSettings) GetSettings(ctx context.Context, schoolID string) (schoolconfig.Settings, error) {
+	return f.settings, nil
+}
+
+var testUsers = memoryUsers{users: map[string]auth.User{
+	"admin-1": {ID: "admin-1", Role: auth.RoleAdmin},
+	"user-1":  {ID: "user-1", Role: auth.RoleUser},
+	"user-2":  {ID: "user-2", Role: auth.RoleUser},
+}}
+
+// newTestService returns a service over the given store,
+// entitlements and settings at a controlled time.
+func newTestService(t *testing.T, store *memoryStore, entitlements memoryEntitlements, settings schoolconfig.Settings, now *time.Time) *service {
+	s, err := New(store, testUsers, freeTemplates{}, nil, entitlements, fixedSettings{settings: settings})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	s.timeNow = func() time.Time { return *now }
+	return s
+}
+
+func TestDeleteAndRestoreContentOfOtherUser(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID string
+		want   error
+	}{
+		{name: "administrator", userID: "admin-1", want: nil},
+		{name: "owner", userID: "user-1", want: nil},
+		{name: "other user", userID: "user-2", want: content.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemoryStore()
+			now := time.Now()
+			s := newTestService(t, store, memoryEntitlements{}, schoolconfig.Settings{}, &now)
+
+			store.addContent(content.Content{ID: "content-1", UserID: "user-1", TemplateID: "template-1"})
+
+			ctx := contextlib.SetUserID(context.Background(), tt.userID)
+
+			err := s.DeleteContentByID(ctx, "content-1")
+			if err != tt.want {
+				t.Fatalf("DeleteContentByID() error = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil {
+				// the content is deleted by its owner instead
+				err = s.DeleteContentByID(contextlib.SetUserID(context.Background(), "user-1"), "content-1")
+				if err != nil {
+					t.Fatalf("DeleteContentByID() error = %v", err)
+				}
+			}
+			if store.contents["content-1"].DeleteTime.IsZero() {
+				t.Fatalf("content is not deleted")
+			}
+
+			err = s.RestoreContentByID(ctx, "content-1")
+			if err != tt.want {
+				t.Fatalf("RestoreContentByID() error = %v, want %v", err, tt.want)
+			}
+			if restored := store.contents["content-1"].DeleteTime.IsZero(); restored != (tt.want == nil) {
+				t.Errorf("content restored = %v, want %v", restored, tt.want == nil)
+			}
+		})
+	}
+}
+
+func TestRestoreContentWithinQuota(t *testing.T) {
+	tests := []struct {
+		name         string
+		defaultQuota int
+		quota        int
+		contents     int
+		want         error
+	}{
+		{name: "below default quota", defaultQuota: 2, contents: 0, want: nil},
+		{name: "at default quota", defaultQuota: 2, contents: 1, want: nil},
+		{name: "over default quota", defaultQuota: 2, contents: 2, want: content.ErrContentQuotaExceeded},
+		{name: "at entitled quota", defaultQuota: 2, quota: 3, contents: 2, want: nil},
+		{name: "over entitled quota", defaultQuota: 2, quota: 3, contents: 3, want: content.ErrContentQuotaExceeded},
+		{name: "entitled quota below default quota", defaultQuota: 2, quota: 1, contents: 1, want: nil},
+		{name: "no quota", defaultQuota: 0, contents: 5, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemoryStore()
+			now := time.Now()
+
+			var entitlements memoryEntitlements
+			if tt.quota > 0 {
+				entitlements.entitlements = []entitlement.Entitlement{
+					{ID: "entitlement-1", UserID: "user-1", Type: entitlement.TypeQuota, Quota: tt.quota},
+				}
+			}
+			s := newTestService(t, store, entitlements, schoolconfig.Settings{DefaultQuota: tt.defaultQuota}, &now)
+
+			for i := 0; i < tt.contents; i++ {
+				store.addContent(content.Content{ID: fmt.Sprintf("content-%d", i), UserID: "user-1", TemplateID: "template-1"})
+			}
+			store.addContent(content.Content{ID: "deleted", UserID: "user-1", TemplateID: "template-1", DeleteTime: now})
+
+			ctx := contextlib.SetUserID(context.Background(), "user-1")
+
+			err := s.RestoreContentByID(ctx, "deleted")
+			if err != tt.want {
+				t.Fatalf("RestoreContentByID() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -3,10 +3,13 @@ package service
 import (
 	"context"
 	"hbdtoyou/internal/content"
+	"hbdtoyou/internal/notification"
+	"hbdtoyou/pkg/event"
+	"time"
 )
 
 type PGStore interface {
-	NewClient(useTx bool) (PGStoreClient, error)
+	NewClient(ctx context.Context, useTx bool) (PGStoreClient, error)
 }
 
 type PGStoreClient interface {
@@ -14,6 +17,10 @@ type PGStoreClient interface {
 	Commit() error
 	// Rollback aborts the transaction.
 	Rollback() error
+	// ShareTx returns a new Context carrying the transaction,
+	// so other services called with it apply their changes
+	// within the transaction.
+	ShareTx(ctx context.Context) context.Context
 
 	// CreateContent creates a new content and returns
 	// the created content ID.
@@ -36,6 +43,63 @@ type PGStoreClient interface {
 	UpdateContent(ctx context.Context, reqContent content.Content) error
 
 	// DeleteContent delete a content
-	// with the given content id.
-	DeleteContentByID(ctx context.Context, contentID string) error
+	// with the given content id by setting its delete time.
+	DeleteContentByID(ctx context.Context, contentID string, deleteTime time.Time) error
+
+	// RestoreContentByID restores a deleted content with the
+	// given content id.
+	RestoreContentByID(ctx context.Context, contentID string) error
+
+	// PurgeDeletedContents permanently deletes contents that
+	// were deleted before the given time and returns the
+	// number of purged contents.
+	PurgeDeletedContents(ctx context.Context, before time.Time) (int64, error)
+
+	// CreateContentRevision saves the given revision of a
+	// content and returns the assigned revision number.
+	CreateContentRevision(ctx context.Context, revision content.Revision) (int, error)
+
+	// GetContentRevisions returns all revisions of a content
+	// with the given content ID, newest first.
+	GetContentRevisions(ctx context.Context, contentID string) ([]content.Revision, error)
+
+	// GetContentRevision returns a revision of a content with
+	// the given content ID and revision number.
+	GetContentRevision(ctx context.Context, contentID string, number int) (content.Revision, error)
+
+	// CreateContentMember creates a new content member and
+	// returns the created member ID.
+	CreateContentMember(ctx context.Context, reqMember content.Member) (string, error)
+
+	// GetContentMembers returns all members of a content with
+	// the given content ID, excluding declined invitations.
+	GetContentMembers(ctx context.Context, contentID string) ([]content.Member, error)
+
+	// GetPendingInvitations returns pending invitations sent
+	// to the given email.
+	GetPendingInvitations(ctx context.Context, email string) ([]content.Member, error)
+
+	// GetContentMemberByID returns a content member with the
+	// given member ID.
+	GetContentMemberByID(ctx context.Context, memberID string) (content.Member, error)
+
+	// GetContentMemberRole returns role of an accepted member
+	// with the given user ID on a content.
+	GetContentMemberRole(ctx context.Context, contentID, userID string) (content.MemberRole, error)
+
+	// UpdateContentMemberStatus updates status of a pending
+	// invitation with the given member data.
+	UpdateContentMemberStatus(ctx context.Context, reqMember content.Member) error
+
+	// DeleteContentMember deletes a member with the given
+	// member ID from a content.
+	DeleteContentMember(ctx context.Context, contentID, memberID string) error
+
+	// CreateNotification writes a new notification to the
+	// outbox and returns the created notification ID.
+	CreateNotification(ctx context.Context, reqNotification notification.Notification) (string, error)
+
+	// CreateEvent writes a new event to the event outbox, to
+	// be published once the transaction is committed.
+	CreateEvent(ctx context.Context, e event.Event) error
 }
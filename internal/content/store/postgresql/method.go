@@ -3,14 +3,19 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"hbdtoyou/internal/content"
+	"hbdtoyou/internal/notification"
 	"strings"
+	"time"
 
-	contextlib "hbdtoyou/pkg/context"
+	"hbdtoyou/pkg/event"
+	outbox "hbdtoyou/pkg/event/outbox/postgresql"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 func (sc *storeClient) CreateContent(ctx context.Context, reqContent content.Content) (string, error) {
@@ -19,6 +24,7 @@ func (sc *storeClient) CreateContent(ctx context.Context, reqContent content.Con
 		"user_id":                  reqContent.UserID,
 		"template_id":              reqContent.TemplateID,
 		"detail_content_json_text": reqContent.DetailContentJSONText,
+		"media_ids":                pq.Array(reqContent.MediaIDs),
 		"status":                   reqContent.Status,
 		"create_time":              reqContent.CreateTime,
 	}
@@ -68,6 +74,29 @@ func (sc *storeClient) GetContents(ctx context.Context, filter content.GetConten
 		argKV["template_label"] = filter.TemplateLabel
 	}
 
+	if filter.SharedWithUserID != "" {
+		id, err := uuid.Parse(filter.SharedWithUserID)
+		if err != nil {
+			return nil, content.ErrInvalidUserID
+		}
+		conditions = append(conditions, `c.id IN (
+			SELECT cm.content_id FROM content_member cm
+			WHERE cm.user_id = :shared_with_user_id
+			AND cm.status = :member_status
+			AND cm.role <> :member_role
+		)`)
+		argKV["shared_with_user_id"] = id
+		argKV["member_status"] = content.MemberStatusAccepted
+		argKV["member_role"] = content.MemberRoleOwner
+	}
+
+	// deleted contents are only listed in trash
+	if filter.Deleted {
+		conditions = append(conditions, "c.delete_time IS NOT NULL")
+	} else {
+		conditions = append(conditions, "c.delete_time IS NULL")
+	}
+
 	// if filter.Status > 0 {
 	// 	conditions = append(conditions, "c.status = :status")
 	// 	argKV["status"] = filter.Status
@@ -124,7 +153,7 @@ func (sc *storeClient) GetContents(ctx context.Context, filter content.GetConten
 }
 
 func (sc *storeClient) GetContentByID(ctx context.Context, contentID string) (content.Content, error) {
-	query := fmt.Sprintf(queryGetContent, "WHERE c.id = $1")
+	query := fmt.Sprintf(queryGetContent, "WHERE c.id = $1 AND c.delete_time IS NULL")
 
 	// query single row
 	var model contentModel
@@ -145,7 +174,9 @@ func (sc *storeClient) UpdateContent(ctx context.Context, reqContent content.Con
 		"id":                       reqContent.ID,
 		"template_id":              reqContent.TemplateID,
 		"detail_content_json_text": reqContent.DetailContentJSONText,
+		"media_ids":                pq.Array(reqContent.MediaIDs),
 		"status":                   reqContent.Status,
+		"version":                  reqContent.Version,
 		"update_time":              reqContent.UpdateTime,
 	}
 
@@ -161,21 +192,37 @@ func (sc *storeClient) UpdateContent(ctx context.Context, reqContent content.Con
 	query = sc.q.Rebind(query)
 
 	// execute query
-	_, err = sc.q.Exec(query, args...)
-	return err
-}
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
 
-func (sc *storeClient) DeleteContentByID(ctx context.Context, contentID string) error {
-	// get user ID
-	userID, ok := contextlib.GetUserID(ctx)
-	if !ok {
-		return content.ErrInvalidUserID
+	// nothing is updated when the version is outdated, or the
+	// content is not found or deleted
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		var exists bool
+		err = sc.q.QueryRowx(queryCheckContentExists, reqContent.ID).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return content.ErrDataNotFound
+		}
+		return content.ErrVersionConflict
 	}
 
+	return nil
+}
+
+func (sc *storeClient) DeleteContentByID(ctx context.Context, contentID string, deleteTime time.Time) error {
 	// construct arguments filled with fields for the query
 	argsKV := map[string]interface{}{
-		"id":      contentID,
-		"user_id": userID,
+		"id":          contentID,
+		"delete_time": deleteTime,
 	}
 
 	// prepare query
@@ -190,10 +237,404 @@ func (sc *storeClient) DeleteContentByID(ctx context.Context, contentID string)
 	query = sc.q.Rebind(query)
 
 	// execute query
-	_, err = sc.q.Exec(query, args...)
+	res, err := sc.q.Exec(query, args...)
 	if err != nil {
 		return err
 	}
 
+	// nothing is deleted when the content does not exist, is
+	// not owned by the user or is already deleted
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return content.ErrDataNotFound
+	}
+
 	return nil
 }
+
+func (sc *storeClient) RestoreContentByID(ctx context.Context, contentID string) error {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"id": contentID,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryRestoreContent, argsKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	// nothing is restored when the content is not in trash
+	// or not owned by the user
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return content.ErrDataNotFound
+	}
+
+	return nil
+}
+
+func (sc *storeClient) PurgeDeletedContents(ctx context.Context, before time.Time) (int64, error) {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"before": before,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryPurgeContents, argsKV)
+	if err != nil {
+		return 0, err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (sc *storeClient) CreateContentRevision(ctx context.Context, revision content.Revision) (int, error) {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"content_id":               revision.ContentID,
+		"user_id":                  revision.UserID,
+		"template_id":              revision.TemplateID,
+		"detail_content_json_text": revision.DetailContentJSONText,
+		"status":                   revision.Status,
+		"create_time":              revision.CreateTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryCreateContentRevision, argsKV)
+	if err != nil {
+		return 0, err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	var number int
+	err = sc.q.QueryRowx(query, args...).Scan(&number)
+	if err != nil {
+		return 0, err
+	}
+
+	return number, nil
+}
+
+func (sc *storeClient) GetContentRevisions(ctx context.Context, contentID string) ([]content.Revision, error) {
+	query := fmt.Sprintf(queryGetContentRevision, "WHERE content_id = $1 ORDER BY number DESC")
+
+	// query to database
+	rows, err := sc.q.Queryx(query, contentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// read rows
+	result := make([]content.Revision, 0)
+	for rows.Next() {
+		var row revisionModel
+		err = rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, row.format())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (sc *storeClient) GetContentRevision(ctx context.Context, contentID string, number int) (content.Revision, error) {
+	query := fmt.Sprintf(queryGetContentRevision, "WHERE content_id = $1 AND number = $2")
+
+	// query single row
+	var model revisionModel
+	err := sc.q.QueryRowx(query, contentID, number).StructScan(&model)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return content.Revision{}, content.ErrDataNotFound
+		}
+		return content.Revision{}, err
+	}
+
+	return model.format(), nil
+}
+
+func (sc *storeClient) CreateContentMember(ctx context.Context, reqMember content.Member) (string, error) {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"content_id":  reqMember.ContentID,
+		"user_id":     nullString(reqMember.UserID),
+		"email":       reqMember.Email,
+		"role":        reqMember.Role,
+		"status":      reqMember.Status,
+		"invited_by":  nullString(reqMember.InvitedBy),
+		"create_time": reqMember.CreateTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryCreateContentMember, argsKV)
+	if err != nil {
+		return "", err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return "", err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	var id string
+	err = sc.q.QueryRowx(query, args...).Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr != nil {
+			if pqErr.Code.Name() == "unique_violation" {
+				return "", content.ErrMemberAlreadyExist
+			}
+		}
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (sc *storeClient) GetContentMembers(ctx context.Context, contentID string) ([]content.Member, error) {
+	query := fmt.Sprintf(queryGetContentMember, "WHERE cm.content_id = $1 AND cm.status <> $2 ORDER BY cm.role DESC, cm.create_time")
+
+	return sc.getContentMembers(query, contentID, content.MemberStatusDeclined)
+}
+
+func (sc *storeClient) GetPendingInvitations(ctx context.Context, email string) ([]content.Member, error) {
+	query := fmt.Sprintf(queryGetContentMember, "WHERE cm.email = $1 AND cm.status = $2 AND c.delete_time IS NULL ORDER BY cm.create_time DESC")
+
+	return sc.getContentMembers(query, email, content.MemberStatusPending)
+}
+
+// getContentMembers returns content members returned by the
+// given query.
+func (sc *storeClient) getContentMembers(query string, args ...interface{}) ([]content.Member, error) {
+	// query to database
+	rows, err := sc.q.Queryx(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// read rows
+	result := make([]content.Member, 0)
+	for rows.Next() {
+		var row memberModel
+		err = rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, row.format())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (sc *storeClient) GetContentMemberByID(ctx context.Context, memberID string) (content.Member, error) {
+	query := fmt.Sprintf(queryGetContentMember, "WHERE cm.id = $1 AND c.delete_time IS NULL")
+
+	// query single row
+	var model memberModel
+	err := sc.q.QueryRowx(query, memberID).StructScan(&model)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return content.Member{}, content.ErrDataNotFound
+		}
+		return content.Member{}, err
+	}
+
+	return model.format(), nil
+}
+
+func (sc *storeClient) GetContentMemberRole(ctx context.Context, contentID, userID string) (content.MemberRole, error) {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"content_id": contentID,
+		"user_id":    userID,
+		"status":     content.MemberStatusAccepted,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryGetContentMemberRole, argsKV)
+	if err != nil {
+		return content.MemberRoleUnknown, err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return content.MemberRoleUnknown, err
+	}
+	query = sc.q.Rebind(query)
+
+	// query single row
+	var role content.MemberRole
+	err = sc.q.QueryRowx(query, args...).Scan(&role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return content.MemberRoleUnknown, content.ErrDataNotFound
+		}
+		return content.MemberRoleUnknown, err
+	}
+
+	return role, nil
+}
+
+func (sc *storeClient) UpdateContentMemberStatus(ctx context.Context, reqMember content.Member) error {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"id":             reqMember.ID,
+		"user_id":        nullString(reqMember.UserID),
+		"status":         reqMember.Status,
+		"update_time":    reqMember.UpdateTime,
+		"pending_status": content.MemberStatusPending,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryUpdateContentMemberStatus, argsKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	// nothing is updated when the invitation is not pending
+	// anymore
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return content.ErrInvitationAlreadyResponded
+	}
+
+	return nil
+}
+
+func (sc *storeClient) DeleteContentMember(ctx context.Context, contentID, memberID string) error {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"id":         memberID,
+		"content_id": contentID,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryDeleteContentMember, argsKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return content.ErrDataNotFound
+	}
+
+	return nil
+}
+
+func (sc *storeClient) CreateNotification(ctx context.Context, reqNotification notification.Notification) (string, error) {
+	data, err := json.Marshal(reqNotification.Data)
+	if err != nil {
+		return "", err
+	}
+
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"user_id":           reqNotification.UserID,
+		"event":             reqNotification.Event,
+		"subject":           reqNotification.Subject,
+		"body":              reqNotification.Body,
+		"data":              string(data),
+		"status":            reqNotification.Status,
+		"next_attempt_time": reqNotification.NextAttemptTime,
+		"create_time":       reqNotification.CreateTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryCreateNotification, argKV)
+	if err != nil {
+		return "", err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return "", err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	var id string
+	err = sc.q.QueryRowx(query, args...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (sc *storeClient) CreateEvent(ctx context.Context, e event.Event) error {
+	return outbox.Write(ctx, sc.q, e)
+}
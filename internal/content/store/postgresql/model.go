@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type contentModel struct {
@@ -15,9 +16,12 @@ type contentModel struct {
 	TemplateName          string         `db:"template_name"`
 	TemplateLabel         string         `db:"template_label"`
 	DetailContentJSONText string         `db:"detail_content_json_text"`
+	MediaIDs              pq.StringArray `db:"media_ids"`
 	Status                content.Status `db:"status"`
+	Version               int64          `db:"version"`
 	CreateTime            time.Time      `db:"create_time"`
 	UpdateTime            *time.Time     `db:"update_time"`
+	DeleteTime            *time.Time     `db:"delete_time"`
 }
 
 // format formats database struct into domain struct.
@@ -30,7 +34,9 @@ func (dbData *contentModel) format() content.Content {
 		TemplateName:          dbData.TemplateName,
 		TemplateLabel:         dbData.TemplateLabel,
 		DetailContentJSONText: dbData.DetailContentJSONText,
+		MediaIDs:              dbData.MediaIDs,
 		Status:                dbData.Status,
+		Version:               dbData.Version,
 		CreateTime:            dbData.CreateTime,
 	}
 
@@ -38,5 +44,79 @@ func (dbData *contentModel) format() content.Content {
 		c.UpdateTime = *dbData.UpdateTime
 	}
 
+	if dbData.DeleteTime != nil {
+		c.DeleteTime = *dbData.DeleteTime
+	}
+
 	return c
 }
+
+type revisionModel struct {
+	ContentID             uuid.UUID      `db:"content_id"`
+	Number                int            `db:"number"`
+	UserID                string         `db:"user_id"`
+	TemplateID            uuid.UUID      `db:"template_id"`
+	DetailContentJSONText string         `db:"detail_content_json_text"`
+	Status                content.Status `db:"status"`
+	CreateTime            time.Time      `db:"create_time"`
+}
+
+// format formats database struct into domain struct.
+func (dbData *revisionModel) format() content.Revision {
+	return content.Revision{
+		ContentID:             dbData.ContentID.String(),
+		Number:                dbData.Number,
+		UserID:                dbData.UserID,
+		TemplateID:            dbData.TemplateID.String(),
+		DetailContentJSONText: dbData.DetailContentJSONText,
+		Status:                dbData.Status,
+		CreateTime:            dbData.CreateTime,
+	}
+}
+
+type memberModel struct {
+	ID         uuid.UUID            `db:"id"`
+	ContentID  uuid.UUID            `db:"content_id"`
+	UserID     *string              `db:"user_id"`
+	Email      string               `db:"email"`
+	Role       content.MemberRole   `db:"role"`
+	Status     content.MemberStatus `db:"status"`
+	InvitedBy  *string              `db:"invited_by"`
+	CreateTime time.Time            `db:"create_time"`
+	UpdateTime *time.Time           `db:"update_time"`
+}
+
+// format formats database struct into domain struct.
+func (dbData *memberModel) format() content.Member {
+	m := content.Member{
+		ID:         dbData.ID.String(),
+		ContentID:  dbData.ContentID.String(),
+		Email:      dbData.Email,
+		Role:       dbData.Role,
+		Status:     dbData.Status,
+		CreateTime: dbData.CreateTime,
+	}
+
+	if dbData.UserID != nil {
+		m.UserID = *dbData.UserID
+	}
+
+	if dbData.InvitedBy != nil {
+		m.InvitedBy = *dbData.InvitedBy
+	}
+
+	if dbData.UpdateTime != nil {
+		m.UpdateTime = *dbData.UpdateTime
+	}
+
+	return m
+}
+
+// nullString returns nil for an empty string, so it is stored
+// as NULL.
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
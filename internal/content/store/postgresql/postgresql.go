@@ -1,6 +1,7 @@
 package postgresql
 
 import (
+	"context"
 	"errors"
 	"hbdtoyou/internal/content/service"
 	pglib "hbdtoyou/pkg/postgresql"
@@ -15,7 +16,7 @@ var (
 
 // store implements teachingmaterial/service.PGStore
 type store struct {
-	db *sqlx.DB
+	router *pglib.Router
 }
 
 // storeClient implements teachingmaterial/service.PGStoreClient.
@@ -23,23 +24,29 @@ type storeClient struct {
 	q pglib.Querier
 }
 
-// New creates a new store.
-func New(db *sqlx.DB) (*store, error) {
+// New creates a new store. Every client is created on the
+// database of the tenant in the given context.
+func New(router *pglib.Router) (*store, error) {
 	s := &store{
-		db: db,
+		router: router,
 	}
 
 	return s, nil
 }
 
-func (s *store) NewClient(useTx bool) (service.PGStoreClient, error) {
+func (s *store) NewClient(ctx context.Context, useTx bool) (service.PGStoreClient, error) {
 	var q pglib.Querier
 
+	// route to the database of the tenant
+	db, err := s.router.GetDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// determine what object should be use as querier
-	q = s.db
+	q = db
 	if useTx {
-		var err error
-		q, err = s.db.Beginx()
+		q, err = db.Beginx()
 		if err != nil {
 			return nil, err
 		}
@@ -63,3 +70,10 @@ func (sc *storeClient) Rollback() error {
 	}
 	return errInvalidRollback
 }
+
+func (sc *storeClient) ShareTx(ctx context.Context) context.Context {
+	if tx, ok := sc.q.(*sqlx.Tx); ok {
+		return pglib.SetTx(ctx, tx)
+	}
+	return ctx
+}
@@ -8,6 +8,7 @@ const (
 				user_id,
 				template_id,
 				detail_content_json_text,
+				media_ids,
 				status,
 				create_time
 			)
@@ -16,6 +17,7 @@ const (
 				:user_id,
 				:template_id,
 				:detail_content_json_text,
+				:media_ids,
 				:status,
 				:create_time
 			)
@@ -32,9 +34,12 @@ const (
 			t.label as template_label,
 			t.name as template_name,
 			c.detail_content_json_text,
+			c.media_ids,
 			c.status,
+			c.version,
 			c.create_time,
-			c.update_time
+			c.update_time,
+			c.delete_time
 		FROM
 			content c
 		LEFT JOIN
@@ -54,18 +59,208 @@ const (
 		SET
 			template_id = :template_id,
 			detail_content_json_text = :detail_content_json_text,
+			media_ids = :media_ids,
 			status = :status,
+			version = version + 1,
 			update_time = :update_time
 		WHERE
-			id = :id 
+			id = :id
+		AND
+			version = :version
+		AND
+			delete_time IS NULL
+	`
+
+	queryCheckContentExists = `
+		SELECT EXISTS (
+			SELECT
+				1
+			FROM
+				content
+			WHERE
+				id = $1
+			AND
+				delete_time IS NULL
+		)
 	`
 
 	queryDeleteContent = `
-		DELETE FROM
+		UPDATE
+			content
+		SET
+			delete_time = :delete_time
+		WHERE
+			id = :id
+		AND
+			delete_time IS NULL
+	`
+
+	queryRestoreContent = `
+		UPDATE
 			content
+		SET
+			delete_time = NULL
 		WHERE
 			id = :id
-		AND 
+		AND
+			delete_time IS NOT NULL
+	`
+
+	queryPurgeContents = `
+		DELETE FROM
+			content
+		WHERE
+			delete_time < :before
+	`
+
+	queryCreateContentRevision = `
+		INSERT INTO
+			content_revision
+			(
+				content_id,
+				number,
+				user_id,
+				template_id,
+				detail_content_json_text,
+				status,
+				create_time
+			)
+		SELECT
+			:content_id,
+			COALESCE(MAX(number), 0) + 1,
+			:user_id,
+			:template_id,
+			:detail_content_json_text,
+			:status,
+			:create_time
+		FROM
+			content_revision
+		WHERE
+			content_id = :content_id
+		RETURNING
+			number
+	`
+
+	queryGetContentRevision = `
+		SELECT
+			content_id,
+			number,
+			user_id,
+			template_id,
+			detail_content_json_text,
+			status,
+			create_time
+		FROM
+			content_revision
+		%s
+	`
+
+	queryCreateContentMember = `
+		INSERT INTO
+			content_member
+			(
+				content_id,
+				user_id,
+				email,
+				role,
+				status,
+				invited_by,
+				create_time
+			)
+		VALUES
+			(
+				:content_id,
+				:user_id,
+				:email,
+				:role,
+				:status,
+				:invited_by,
+				:create_time
+			)
+		RETURNING
+			id
+	`
+
+	queryGetContentMember = `
+		SELECT
+			cm.id,
+			cm.content_id,
+			cm.user_id,
+			cm.email,
+			cm.role,
+			cm.status,
+			cm.invited_by,
+			cm.create_time,
+			cm.update_time
+		FROM
+			content_member cm
+		JOIN
+			content c
+		ON
+			c.id = cm.content_id
+		%s
+	`
+
+	queryGetContentMemberRole = `
+		SELECT
+			role
+		FROM
+			content_member
+		WHERE
+			content_id = :content_id
+		AND
 			user_id = :user_id
+		AND
+			status = :status
+	`
+
+	queryUpdateContentMemberStatus = `
+		UPDATE
+			content_member
+		SET
+			user_id = :user_id,
+			status = :status,
+			update_time = :update_time
+		WHERE
+			id = :id
+		AND
+			status = :pending_status
+	`
+
+	queryDeleteContentMember = `
+		DELETE FROM
+			content_member
+		WHERE
+			id = :id
+		AND
+			content_id = :content_id
+	`
+
+	queryCreateNotification = `
+		INSERT INTO
+			notification
+			(
+				user_id,
+				event,
+				subject,
+				body,
+				data,
+				status,
+				next_attempt_time,
+				create_time
+			)
+		VALUES
+			(
+				:user_id,
+				:event,
+				:subject,
+				:body,
+				:data,
+				:status,
+				:next_attempt_time,
+				:create_time
+			)
+		RETURNING
+			id
 	`
 )
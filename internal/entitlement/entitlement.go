@@ -0,0 +1,116 @@
+package entitlement
+
+import (
+	"context"
+	"time"
+)
+
+// Service is the interface for entitlement service.
+//
+// An entitlement allows a user to create contents using paid
+// templates. It is granted when a payment is approved.
+type Service interface {
+	// GrantEntitlement grants a new entitlement and returns
+	// the granted entitlement ID.
+	GrantEntitlement(ctx context.Context, reqEntitlement Entitlement) (string, error)
+
+	// RevokeEntitlementsByPaymentID revokes entitlements
+	// granted by the payment with the given payment ID.
+	RevokeEntitlementsByPaymentID(ctx context.Context, paymentID string) error
+
+	// LimitQuotaByPaymentID reduces the quota of quota
+	// entitlements granted by the payment with the given
+	// payment ID to the given quota, but never below the quota
+	// already used.
+	LimitQuotaByPaymentID(ctx context.Context, paymentID string, quota int) error
+
+	// GetEntitlements returns all active and unexpired
+	// entitlements of the user with the given user ID.
+	GetEntitlements(ctx context.Context, userID string) ([]Entitlement, error)
+
+	// UseEntitlement authorizes the given usage of a paid
+	// template.
+	//
+	// The usage is authorized by an entitlement of the
+	// template or the content. Otherwise, one quota of a quota
+	// entitlement is consumed. ErrNoEntitlement is returned
+	// when none of them is available.
+	UseEntitlement(ctx context.Context, usage Usage) error
+}
+
+// Entitlement denotes a right of a user to use paid templates.
+type Entitlement struct {
+	ID     string
+	UserID string
+
+	// PaymentID references the payment granting the
+	// entitlement, if any.
+	PaymentID string
+
+	Type Type
+
+	// TemplateID is set for a template entitlement.
+	TemplateID string
+
+	// ContentID is set for a content entitlement.
+	ContentID string
+
+	// Quota is the number of contents can be created using
+	// any paid template, set for a quota entitlement.
+	Quota     int
+	UsedQuota int
+
+	// ExpireTime is when the entitlement can no longer be
+	// used. Zero means the entitlement never expires.
+	ExpireTime time.Time
+
+	CreateTime time.Time
+	RevokeTime time.Time
+}
+
+// Usage denotes a usage of a paid template by a user.
+type Usage struct {
+	UserID     string
+	TemplateID string
+
+	// ContentID is the content using the template.
+	ContentID string
+}
+
+// Type denotes type of an entitlement.
+type Type int
+
+// Following constans are the known entitlement types.
+const (
+	TypeUnknown  Type = 0
+	TypeTemplate Type = 1
+	TypeContent  Type = 2
+	TypeQuota    Type = 3
+)
+
+var (
+	// TypeList is a list of valid entitlement type.
+	TypeList = map[Type]struct{}{
+		TypeTemplate: {},
+		TypeContent:  {},
+		TypeQuota:    {},
+	}
+
+	// TypeName maps entitlement type to it's string
+	// representation.
+	TypeName = map[Type]string{
+		TypeTemplate: "template",
+		TypeContent:  "content",
+		TypeQuota:    "quota",
+	}
+)
+
+// String implements the Stringer interface.
+func (t Type) String() string {
+	return TypeName[t]
+}
+
+// Value implements the Valuer interface.
+func (t Type) Value() int {
+	return int(t)
+}
@@ -0,0 +1,41 @@
+package entitlement
+
+import "errors"
+
+var (
+	// ErrDataNotFound is returned when the desired data is
+	// not found.
+	ErrDataNotFound = errors.New("data not found")
+
+	// ErrInvalidUserID is returned when the given user ID is
+	// invalid.
+	ErrInvalidUserID = errors.New("invalid user id")
+
+	// ErrInvalidPaymentID is returned when the given payment
+	// ID is invalid.
+	ErrInvalidPaymentID = errors.New("invalid payment id")
+
+	// ErrInvalidEntitlementType is returned when the given
+	// entitlement type is invalid.
+	ErrInvalidEntitlementType = errors.New("invalid entitlement type")
+
+	// ErrInvalidTemplateID is returned when the given
+	// template ID is invalid.
+	ErrInvalidTemplateID = errors.New("invalid template id")
+
+	// ErrInvalidContentID is returned when the given content
+	// ID is invalid.
+	ErrInvalidContentID = errors.New("invalid content id")
+
+	// ErrInvalidQuota is returned when the given quota is
+	// invalid.
+	ErrInvalidQuota = errors.New("invalid quota")
+
+	// ErrEntitlementAlreadyExist is returned when the payment
+	// has already granted an entitlement.
+	ErrEntitlementAlreadyExist = errors.New("entitlement already exist")
+
+	// ErrNoEntitlement is returned when the user has no
+	// entitlement to use a paid template.
+	ErrNoEntitlement = errors.New("no entitlement")
+)
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"hbdtoyou/internal/entitlement"
+)
+
+// GrantEntitlement grants a new entitlement and returns the
+// granted entitlement ID.
+func (s *service) GrantEntitlement(ctx context.Context, reqEntitlement entitlement.Entitlement) (string, error) {
+	// validate fields
+	err := validateEntitlement(reqEntitlement)
+	if err != nil {
+		return "", err
+	}
+
+	// update fields
+	reqEntitlement.UsedQuota = 0
+	reqEntitlement.CreateTime = s.timeNow()
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return "", err
+	}
+
+	// inserts entitlement in pgstore
+	entitlementID, err := pgStoreClient.CreateEntitlement(ctx, reqEntitlement)
+	if err != nil {
+		return "", err
+	}
+
+	return entitlementID, nil
+}
+
+// RevokeEntitlementsByPaymentID revokes entitlements granted
+// by the payment with the given payment ID.
+//
+// Quota already used by contents is not taken back.
+func (s *service) RevokeEntitlementsByPaymentID(ctx context.Context, paymentID string) error {
+	// validate id
+	if paymentID == "" {
+		return entitlement.ErrInvalidPaymentID
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	return pgStoreClient.RevokeEntitlementsByPaymentID(ctx, paymentID, s.timeNow())
+}
+
+// LimitQuotaByPaymentID reduces the quota of quota
+// entitlements granted by the payment with the given payment
+// ID to the given quota.
+//
+// Quota already used by contents is not taken back.
+func (s *service) LimitQuotaByPaymentID(ctx context.Context, paymentID string, quota int) error {
+	// validate fields
+	if paymentID == "" {
+		return entitlement.ErrInvalidPaymentID
+	}
+
+	if quota < 0 {
+		return entitlement.ErrInvalidQuota
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	return pgStoreClient.LimitQuotaByPaymentID(ctx, paymentID, quota)
+}
+
+// GetEntitlements returns all active entitlements of the user
+// with the given user ID.
+func (s *service) GetEntitlements(ctx context.Context, userID string) ([]entitlement.Entitlement, error) {
+	// validate id
+	if userID == "" {
+		return nil, entitlement.ErrInvalidUserID
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgStoreClient.GetEntitlements(ctx, userID, s.timeNow())
+}
+
+// UseEntitlement authorizes the given usage of a paid
+// template.
+func (s *service) UseEntitlement(ctx context.Context, usage entitlement.Usage) error {
+	// validate fields
+	if usage.UserID == "" {
+		return entitlement.ErrInvalidUserID
+	}
+
+	if usage.TemplateID == "" {
+		return entitlement.ErrInvalidTemplateID
+	}
+
+	now := s.timeNow()
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	// a purchased template can be used by any content
+	ok, err := pgStoreClient.HasTemplateEntitlement(ctx, usage.UserID, usage.TemplateID, now)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+
+	if usage.ContentID != "" {
+		ok, err = pgStoreClient.HasContentEntitlement(ctx, usage.UserID, usage.ContentID, usage.TemplateID, now)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+
+	// otherwise, the usage is paid by a quota
+	return pgStoreClient.ConsumeQuota(ctx, usage.UserID, now)
+}
+
+// validateEntitlement validates fields of the given
+// entitlement whether its comply the predetermined rules.
+func validateEntitlement(reqEntitlement entitlement.Entitlement) error {
+	if reqEntitlement.UserID == "" {
+		return entitlement.ErrInvalidUserID
+	}
+
+	switch reqEntitlement.Type {
+	case entitlement.TypeTemplate:
+		if reqEntitlement.TemplateID == "" {
+			return entitlement.ErrInvalidTemplateID
+		}
+	case entitlement.TypeContent:
+		if reqEntitlement.TemplateID == "" {
+			return entitlement.ErrInvalidTemplateID
+		}
+		if reqEntitlement.ContentID == "" {
+			return entitlement.ErrInvalidContentID
+		}
+	case entitlement.TypeQuota:
+		if reqEntitlement.Quota <= 0 {
+			return entitlement.ErrInvalidQuota
+		}
+	default:
+		return entitlement.ErrInvalidEntitlementType
+	}
+
+	return nil
+}
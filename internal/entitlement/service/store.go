@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"hbdtoyou/internal/entitlement"
+	"time"
+)
+
+type PGStore interface {
+	NewClient(ctx context.Context, useTx bool) (PGStoreClient, error)
+}
+
+type PGStoreClient interface {
+	// Commit commits the transaction.
+	Commit() error
+	// Rollback aborts the transaction.
+	Rollback() error
+
+	// CreateEntitlement creates a new entitlement and returns
+	// the created entitlement ID.
+	CreateEntitlement(ctx context.Context, reqEntitlement entitlement.Entitlement) (string, error)
+
+	// RevokeEntitlementsByPaymentID marks entitlements granted
+	// by the given payment as revoked at the given time.
+	RevokeEntitlementsByPaymentID(ctx context.Context, paymentID string, revokeTime time.Time) error
+
+	// LimitQuotaByPaymentID reduces the quota of active quota
+	// entitlements granted by the given payment to the given
+	// quota, but never below the used quota.
+	LimitQuotaByPaymentID(ctx context.Context, paymentID string, quota int) error
+
+	// GetEntitlements returns all active entitlements of the
+	// given user, unexpired at the given time.
+	GetEntitlements(ctx context.Context, userID string, now time.Time) ([]entitlement.Entitlement, error)
+
+	// HasTemplateEntitlement returns whether the given user
+	// has an active entitlement of the given template,
+	// unexpired at the given time.
+	HasTemplateEntitlement(ctx context.Context, userID string, templateID string, now time.Time) (bool, error)
+
+	// HasContentEntitlement returns whether the given user has
+	// an active entitlement to use the given template in the
+	// given content, unexpired at the given time.
+	HasContentEntitlement(ctx context.Context, userID string, contentID string, templateID string, now time.Time) (bool, error)
+
+	// ConsumeQuota consumes one quota of the active quota
+	// entitlement of the given user having remaining quota at
+	// the given time, the soonest to expire first.
+	// ErrNoEntitlement is returned if there is none.
+	ConsumeQuota(ctx context.Context, userID string, now time.Time) error
+}
@@ -0,0 +1,215 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hbdtoyou/internal/entitlement"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func (sc *storeClient) CreateEntitlement(ctx context.Context, reqEntitlement entitlement.Entitlement) (string, error) {
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"user_id":     reqEntitlement.UserID,
+		"payment_id":  nullString(reqEntitlement.PaymentID),
+		"type":        reqEntitlement.Type,
+		"template_id": nullString(reqEntitlement.TemplateID),
+		"content_id":  nullString(reqEntitlement.ContentID),
+		"quota":       reqEntitlement.Quota,
+		"used_quota":  reqEntitlement.UsedQuota,
+		"expire_time": nullTime(reqEntitlement.ExpireTime),
+		"create_time": reqEntitlement.CreateTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryCreateEntitlement, argKV)
+	if err != nil {
+		return "", err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return "", err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	var id string
+	err = sc.q.QueryRowx(query, args...).Scan(&id)
+	if err != nil {
+		// a payment grants at most one entitlement, nothing is
+		// inserted on conflict so a shared transaction can
+		// still be used
+		if err == sql.ErrNoRows {
+			return "", entitlement.ErrEntitlementAlreadyExist
+		}
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (sc *storeClient) RevokeEntitlementsByPaymentID(ctx context.Context, paymentID string, revokeTime time.Time) error {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"payment_id":  paymentID,
+		"revoke_time": revokeTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryRevokeEntitlementsByPaymentID, argsKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query, the payment might have granted nothing
+	_, err = sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (sc *storeClient) LimitQuotaByPaymentID(ctx context.Context, paymentID string, quota int) error {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"payment_id": paymentID,
+		"type":       entitlement.TypeQuota,
+		"quota":      quota,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryLimitQuotaByPaymentID, argsKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query, the payment might have granted no quota
+	_, err = sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (sc *storeClient) GetEntitlements(ctx context.Context, userID string, now time.Time) ([]entitlement.Entitlement, error) {
+	query := fmt.Sprintf(queryGetEntitlement, "WHERE e.user_id = $1 AND e.revoke_time IS NULL AND (e.expire_time IS NULL OR e.expire_time > $2) ORDER BY e.create_time")
+
+	// query to database
+	rows, err := sc.q.Queryx(query, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// read rows
+	result := make([]entitlement.Entitlement, 0)
+	for rows.Next() {
+		var row entitlementModel
+		err = rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, row.format())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (sc *storeClient) HasTemplateEntitlement(ctx context.Context, userID string, templateID string, now time.Time) (bool, error) {
+	query := fmt.Sprintf(queryHasEntitlement, "WHERE e.user_id = $1 AND e.type = $2 AND e.template_id = $3 AND e.revoke_time IS NULL AND (e.expire_time IS NULL OR e.expire_time > $4)")
+
+	var ok bool
+	err := sc.q.QueryRowx(query, userID, entitlement.TypeTemplate, templateID, now).Scan(&ok)
+	if err != nil {
+		return false, err
+	}
+
+	return ok, nil
+}
+
+func (sc *storeClient) HasContentEntitlement(ctx context.Context, userID string, contentID string, templateID string, now time.Time) (bool, error) {
+	query := fmt.Sprintf(queryHasEntitlement, "WHERE e.user_id = $1 AND e.type = $2 AND e.content_id = $3 AND e.template_id = $4 AND e.revoke_time IS NULL AND (e.expire_time IS NULL OR e.expire_time > $5)")
+
+	var ok bool
+	err := sc.q.QueryRowx(query, userID, entitlement.TypeContent, contentID, templateID, now).Scan(&ok)
+	if err != nil {
+		return false, err
+	}
+
+	return ok, nil
+}
+
+func (sc *storeClient) ConsumeQuota(ctx context.Context, userID string, now time.Time) error {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"user_id": userID,
+		"type":    entitlement.TypeQuota,
+		"now":     now,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryConsumeQuota, argsKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	// nothing is updated when there is no remaining quota
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return entitlement.ErrNoEntitlement
+	}
+
+	return nil
+}
+
+// nullString returns nil for an empty string, so it is stored
+// as NULL in the database.
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// nullTime returns nil for a zero time, so it is stored as
+// NULL in the database.
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
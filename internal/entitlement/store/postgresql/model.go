@@ -0,0 +1,54 @@
+package postgresql
+
+import (
+	"hbdtoyou/internal/entitlement"
+	"time"
+)
+
+type entitlementModel struct {
+	ID         string           `db:"id"`
+	UserID     string           `db:"user_id"`
+	PaymentID  *string          `db:"payment_id"`
+	Type       entitlement.Type `db:"type"`
+	TemplateID *string          `db:"template_id"`
+	ContentID  *string          `db:"content_id"`
+	Quota      int              `db:"quota"`
+	UsedQuota  int              `db:"used_quota"`
+	ExpireTime *time.Time       `db:"expire_time"`
+	CreateTime time.Time        `db:"create_time"`
+	RevokeTime *time.Time       `db:"revoke_time"`
+}
+
+// format formats database struct into domain struct.
+func (dbData *entitlementModel) format() entitlement.Entitlement {
+	e := entitlement.Entitlement{
+		ID:         dbData.ID,
+		UserID:     dbData.UserID,
+		Type:       dbData.Type,
+		Quota:      dbData.Quota,
+		UsedQuota:  dbData.UsedQuota,
+		CreateTime: dbData.CreateTime,
+	}
+
+	if dbData.PaymentID != nil {
+		e.PaymentID = *dbData.PaymentID
+	}
+
+	if dbData.TemplateID != nil {
+		e.TemplateID = *dbData.TemplateID
+	}
+
+	if dbData.ContentID != nil {
+		e.ContentID = *dbData.ContentID
+	}
+
+	if dbData.ExpireTime != nil {
+		e.ExpireTime = *dbData.ExpireTime
+	}
+
+	if dbData.RevokeTime != nil {
+		e.RevokeTime = *dbData.RevokeTime
+	}
+
+	return e
+}
@@ -0,0 +1,92 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"hbdtoyou/internal/entitlement/service"
+	pglib "hbdtoyou/pkg/postgresql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	errInvalidCommit   = errors.New("cannot do commit on non-transactional querier")
+	errInvalidRollback = errors.New("cannot do rollback on non-transactional querier")
+)
+
+// store implements entitlement/service.PGStore
+type store struct {
+	router *pglib.Router
+}
+
+// storeClient implements entitlement/service.PGStoreClient.
+type storeClient struct {
+	q pglib.Querier
+
+	// shared is true when the client queries within the
+	// transaction of another service, which is committed or
+	// rolled back by that service.
+	shared bool
+}
+
+// New creates a new store. Every client is created on the
+// database of the tenant in the given context.
+func New(router *pglib.Router) (*store, error) {
+	s := &store{
+		router: router,
+	}
+
+	return s, nil
+}
+
+func (s *store) NewClient(ctx context.Context, useTx bool) (service.PGStoreClient, error) {
+	var q pglib.Querier
+
+	// join the transaction of the caller, so the entitlement
+	// changes are applied along with the caller changes
+	if tx, ok := pglib.GetTx(ctx); ok {
+		return &storeClient{
+			q:      tx,
+			shared: true,
+		}, nil
+	}
+
+	// route to the database of the tenant
+	db, err := s.router.GetDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// determine what object should be use as querier
+	q = db
+	if useTx {
+		q, err = db.Beginx()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &storeClient{
+		q: q,
+	}, nil
+}
+
+func (sc *storeClient) Commit() error {
+	if sc.shared {
+		return nil
+	}
+	if tx, ok := sc.q.(*sqlx.Tx); ok {
+		return tx.Commit()
+	}
+	return errInvalidCommit
+}
+
+func (sc *storeClient) Rollback() error {
+	if sc.shared {
+		return nil
+	}
+	if tx, ok := sc.q.(*sqlx.Tx); ok {
+		return tx.Rollback()
+	}
+	return errInvalidRollback
+}
@@ -0,0 +1,123 @@
+package postgresql
+
+const (
+	queryCreateEntitlement = `
+		INSERT INTO
+			entitlement
+			(
+				user_id,
+				payment_id,
+				type,
+				template_id,
+				content_id,
+				quota,
+				used_quota,
+				expire_time,
+				create_time
+			)
+		VALUES
+			(
+				:user_id,
+				:payment_id,
+				:type,
+				:template_id,
+				:content_id,
+				:quota,
+				:used_quota,
+				:expire_time,
+				:create_time
+			)
+		ON CONFLICT
+			(payment_id) WHERE revoke_time IS NULL
+		DO NOTHING
+		RETURNING
+			id
+	`
+
+	queryGetEntitlement = `
+		SELECT
+			e.id,
+			e.user_id,
+			e.payment_id,
+			e.type,
+			e.template_id,
+			e.content_id,
+			e.quota,
+			e.used_quota,
+			e.expire_time,
+			e.create_time,
+			e.revoke_time
+		FROM
+			entitlement e
+		%s
+	`
+
+	queryRevokeEntitlementsByPaymentID = `
+		UPDATE
+			entitlement
+		SET
+			revoke_time = :revoke_time
+		WHERE
+			payment_id = :payment_id
+		AND
+			revoke_time IS NULL
+	`
+
+	queryLimitQuotaByPaymentID = `
+		UPDATE
+			entitlement
+		SET
+			quota = GREATEST(used_quota, :quota)
+		WHERE
+			payment_id = :payment_id
+		AND
+			type = :type
+		AND
+			revoke_time IS NULL
+		AND
+			quota > :quota
+	`
+
+	queryHasEntitlement = `
+		SELECT EXISTS (
+			SELECT
+				1
+			FROM
+				entitlement e
+			%s
+		)
+	`
+
+	// queryConsumeQuota locks the quota entitlement to consume
+	// so concurrent usages can not exceed the quota.
+	queryConsumeQuota = `
+		UPDATE
+			entitlement
+		SET
+			used_quota = used_quota + 1
+		WHERE
+			id = (
+				SELECT
+					e.id
+				FROM
+					entitlement e
+				WHERE
+					e.user_id = :user_id
+				AND
+					e.type = :type
+				AND
+					e.revoke_time IS NULL
+				AND
+					(e.expire_time IS NULL OR e.expire_time > :now)
+				AND
+					e.used_quota < e.quota
+				ORDER BY
+					e.expire_time NULLS LAST,
+					e.create_time
+				LIMIT 1
+				FOR UPDATE
+			)
+		AND
+			used_quota < quota
+	`
+)
@@ -0,0 +1,37 @@
+package media
+
+import "errors"
+
+var (
+	// ErrDataNotFound is returned when the wanted data is
+	// not found.
+	ErrDataNotFound = errors.New("data not found")
+
+	// ErrInvalidMediaID is returned when the given media ID
+	// is invalid.
+	ErrInvalidMediaID = errors.New("invalid media id")
+
+	// ErrInvalidUserID is returned when the given user ID
+	// is invalid.
+	ErrInvalidUserID = errors.New("invalid user id")
+
+	// ErrInvalidFileName is returned when the given file
+	// name is invalid.
+	ErrInvalidFileName = errors.New("invalid file name")
+
+	// ErrInvalidMIMEType is returned when the uploaded file
+	// type is not allowed.
+	ErrInvalidMIMEType = errors.New("invalid mime type")
+
+	// ErrInvalidImage is returned when the uploaded image
+	// cannot be decoded or its dimension is too large.
+	ErrInvalidImage = errors.New("invalid image")
+
+	// ErrFileTooLarge is returned when the uploaded file
+	// exceeds the maximum allowed size.
+	ErrFileTooLarge = errors.New("file too large")
+
+	// ErrInvalidMediaAccess is returned when the media is
+	// accessed by a user other than its owner.
+	ErrInvalidMediaAccess = errors.New("invalid media access")
+)
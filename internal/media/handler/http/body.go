@@ -0,0 +1,61 @@
+package http
+
+import "hbdtoyou/internal/media"
+
+// timeFormat denotes the standard time format used in
+// media HTTP handlers.
+var timeFormat = "02/01/2006 3:04 PM -07:00"
+
+type mediaHTTP struct {
+	ID         *string       `json:"id"`
+	UserID     *string       `json:"user_id"`
+	FileName   *string       `json:"file_name"`
+	MIMEType   *string       `json:"mime_type"`
+	Size       *int64        `json:"size"`
+	Status     *string       `json:"status"`
+	URL        *string       `json:"url"`
+	Variants   []variantHTTP `json:"variants"`
+	CreateTime *string       `json:"create_time"`
+}
+
+type variantHTTP struct {
+	Name     *string `json:"name"`
+	MIMEType *string `json:"mime_type"`
+	Width    *int    `json:"width"`
+	Height   *int    `json:"height"`
+	Size     *int64  `json:"size"`
+	URL      *string `json:"url"`
+}
+
+func formatMedia(m media.Media) mediaHTTP {
+	createTime := m.CreateTime.Format(timeFormat)
+	status := m.Status.String()
+
+	variants := make([]variantHTTP, 0, len(m.Variants))
+	for _, v := range m.Variants {
+		variants = append(variants, formatVariant(v))
+	}
+
+	return mediaHTTP{
+		ID:         &m.ID,
+		UserID:     &m.UserID,
+		FileName:   &m.FileName,
+		MIMEType:   &m.MIMEType,
+		Size:       &m.Size,
+		Status:     &status,
+		URL:        &m.URL,
+		Variants:   variants,
+		CreateTime: &createTime,
+	}
+}
+
+func formatVariant(v media.Variant) variantHTTP {
+	return variantHTTP{
+		Name:     &v.Name,
+		MIMEType: &v.MIMEType,
+		Width:    &v.Width,
+		Height:   &v.Height,
+		Size:     &v.Size,
+		URL:      &v.URL,
+	}
+}
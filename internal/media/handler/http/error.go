@@ -0,0 +1,92 @@
+package http
+
+import (
+	"errors"
+	"hbdtoyou/internal/media"
+)
+
+// Followings are the known errors from Media HTTP handlers.
+var (
+	// errBadRequest is returned when the given request is
+	// bad/invalid.
+	errBadRequest = errors.New("BAD_REQUEST")
+
+	// errInternalServer is returned when there is an
+	// unexpected error encountered when processing a request.
+	errInternalServer = errors.New("INTERNAL_SERVER_ERROR")
+
+	// errDataNotFound is returned when the desired data is
+	// not found.
+	errDataNotFound = errors.New("DATA_NOT_FOUND")
+
+	// errInvalidToken is returned when the given token is
+	// invalid.
+	errInvalidToken = errors.New("INVALID_TOKEN")
+
+	// errInvalidUserID is returned when the given user ID is
+	// invalid.
+	errInvalidUserID = errors.New("INVALID_USER_ID")
+
+	// errInvalidMediaID is returned when the given media ID is
+	// invalid.
+	errInvalidMediaID = errors.New("INVALID_MEDIA_ID")
+
+	// errInvalidFileName is returned when the given file name
+	// is invalid.
+	errInvalidFileName = errors.New("INVALID_FILE_NAME")
+
+	// errInvalidMIMEType is returned when the uploaded file
+	// type is not allowed.
+	errInvalidMIMEType = errors.New("INVALID_MIME_TYPE")
+
+	// errInvalidImage is returned when the uploaded image
+	// cannot be processed.
+	errInvalidImage = errors.New("INVALID_IMAGE")
+
+	// errInvalidMediaAccess is returned when the given media
+	// access is invalid.
+	errInvalidMediaAccess = errors.New("INVALID_MEDIA_ACCESS")
+
+	// errFileNotProvided is returned when there is no file
+	// provided in the multipart request.
+	errFileNotProvided = errors.New("FILE_NOT_PROVIDED")
+
+	// errFileTooLarge is returned when the uploaded file
+	// exceeds the maximum allowed size.
+	errFileTooLarge = errors.New("FILE_TOO_LARGE")
+
+	// errMethodNotAllowed is returned when accessing not
+	// allowed HTTP method.
+	errMethodNotAllowed = errors.New("METHOD_NOT_ALLOWED")
+
+	// errRequestTimeout is returned when processing time has
+	// reached the timeout limit.
+	errRequestTimeout = errors.New("REQUEST_TIMEOUT")
+
+	// errSourceNotProvided is returned when there is no
+	// source provided in the request.
+	errSourceNotProvided = errors.New("SOURCE_NOT_PROVIDED")
+
+	// errUnauthorizedAccess is returned when the request
+	// is unaothorized.
+	errUnauthorizedAccess = errors.New("UNAUTHORIZED_ACCESS")
+)
+
+var (
+	// mapHTTPError maps service error into HTTP error that
+	// categorize as bad request error.
+	//
+	// Internal server error-related should not be mapped here,
+	// and the handler should just return `errInternal` as the
+	// error instead
+	mapHTTPError = map[error]error{
+		media.ErrDataNotFound:       errDataNotFound,
+		media.ErrInvalidMediaID:     errInvalidMediaID,
+		media.ErrInvalidUserID:      errInvalidUserID,
+		media.ErrInvalidFileName:    errInvalidFileName,
+		media.ErrInvalidMIMEType:    errInvalidMIMEType,
+		media.ErrInvalidImage:       errInvalidImage,
+		media.ErrFileTooLarge:       errFileTooLarge,
+		media.ErrInvalidMediaAccess: errInvalidMediaAccess,
+	}
+)
@@ -0,0 +1,172 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"hbdtoyou/internal/media"
+	contextlib "hbdtoyou/pkg/context"
+	httplib "hbdtoyou/pkg/http"
+	"io"
+	"log"
+	"net/http"
+)
+
+// multipartOverhead is the additional request body size
+// allowed on top of the maximum upload size to accommodate
+// multipart boundaries and headers.
+const multipartOverhead int64 = 64 << 10 // 64 KiB
+
+// formFieldFile is the multipart form field name that
+// contains the uploaded file.
+const formFieldFile = "file"
+
+func (h *mediasHandler) handleUploadMedia(w http.ResponseWriter, r *http.Request) {
+	// add timeout to context
+	timeout := h.scopeSettings[ScopeUploadMedia].Timeout
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	var (
+		err        error           // stores error in this handler
+		source     string          // stores request source
+		resBody    []byte          // stores response body to write
+		statusCode = http.StatusOK // stores response status code
+	)
+
+	// write response
+	defer func() {
+		// error
+		if err != nil {
+			log.Printf("[Media HTTP][handleUploadMedia] Failed to upload media. Source: %s, Err: %s\n", source, err.Error())
+			httplib.WriteErrorResponse(w, statusCode, []string{err.Error()})
+			return
+		}
+		// success
+		httplib.WriteResponse(w, resBody, statusCode, httplib.JSONContentTypeDecorator)
+	}()
+
+	// prepare channels for main go routine
+	resChan := make(chan string, 1)
+	errChan := make(chan error, 1)
+
+	go func() {
+		// get request source
+		source, err = httplib.GetSourceFromHeader(r)
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errSourceNotProvided
+			return
+		}
+		ctx = contextlib.SetSource(ctx, source)
+
+		// get user ID
+		reqUserID, err := httplib.GetUserIDFromHeader(r)
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errInvalidUserID
+			return
+		}
+		ctx = contextlib.SetUserID(ctx, reqUserID)
+
+		// get token from header
+		token, err := httplib.GetBearerTokenFromHeader(r)
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errInvalidToken
+			return
+		}
+
+		// check access token
+		err = checkAccessToken(ctx, h.auth, token, reqUserID, "handleUploadMedia")
+		if err != nil {
+			statusCode = http.StatusUnauthorized
+			errChan <- err
+			return
+		}
+
+		// limit request body, the exact file size is checked
+		// by the service
+		r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadSize+multipartOverhead)
+
+		// find the file part in the multipart body
+		multipartReader, err := r.MultipartReader()
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errBadRequest
+			return
+		}
+
+		var filePart io.Reader
+		var fileName string
+		for {
+			part, err := multipartReader.NextPart()
+			if err != nil {
+				break
+			}
+
+			if part.FormName() == formFieldFile {
+				filePart = part
+				fileName = part.FileName()
+				break
+			}
+		}
+
+		if filePart == nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errFileNotProvided
+			return
+		}
+
+		// format HTTP request into service object
+		reqMedia := media.Media{
+			UserID:   reqUserID,
+			FileName: fileName,
+		}
+
+		var mediaID string
+		mediaID, err = h.media.UploadMedia(ctx, reqMedia, filePart)
+		if err != nil {
+			// request body exceeding the limit is reported by
+			// the body reader
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				err = media.ErrFileTooLarge
+			}
+
+			// determine error and status code, by default its internal error
+			parsedErr := errInternalServer
+			statusCode = http.StatusInternalServerError
+			if v, ok := mapHTTPError[err]; ok {
+				parsedErr = v
+				statusCode = http.StatusBadRequest
+			}
+
+			if parsedErr == errFileTooLarge {
+				statusCode = http.StatusRequestEntityTooLarge
+			}
+
+			// log the actual error if its internal error
+			if statusCode == http.StatusInternalServerError {
+				log.Printf("[Media HTTP][handleUploadMedia] Internal error from UploadMedia. Err: %s\n", err.Error())
+			}
+
+			errChan <- parsedErr
+			return
+		}
+
+		resChan <- mediaID
+	}()
+
+	// wait and handle main go routine
+	select {
+	case <-ctx.Done():
+		statusCode = http.StatusGatewayTimeout
+		err = errRequestTimeout
+	case err = <-errChan:
+	case mediaID := <-resChan:
+		resBody, err = json.Marshal(httplib.ResponseEnvelope{
+			Data: mediaID,
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"hbdtoyou/internal/auth"
+	"hbdtoyou/internal/media"
+	"net/http"
+
+	httplib "hbdtoyou/pkg/http"
+
+	"github.com/gorilla/mux"
+)
+
+type mediasHandler struct {
+	media         media.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+	maxUploadSize int64
+}
+
+func (h *mediasHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.handleUploadMedia(w, r)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type mediaHandler struct {
+	media         media.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *mediaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	mediaID := vars["id"]
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetMediaByID(w, r, mediaID)
+	case http.MethodDelete:
+		h.handleDeleteMediaByID(w, r, mediaID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
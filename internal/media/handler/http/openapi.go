@@ -0,0 +1,32 @@
+package http
+
+import (
+	"hbdtoyou/pkg/openapi"
+	"net/http"
+)
+
+// routes defines the OpenAPI routes of each HTTP handler, it
+// must be updated along with the methods served by handler.go.
+var routes = map[string][]openapi.Route{
+	HandlerMedias.Name: {
+		{Method: http.MethodPost, Summary: "Upload media", Upload: formFieldFile, Response: ""},
+	},
+	HandlerMedia.Name: {
+		{Method: http.MethodGet, Summary: "Get media by ID", Response: mediaHTTP{}},
+		{Method: http.MethodDelete, Summary: "Delete media", Response: ""},
+	},
+}
+
+// Routes returns the OpenAPI routes of the registered HTTP
+// handlers.
+func (h *Handler) Routes() []openapi.Route {
+	var res []openapi.Route
+	for _, handler := range h.handlers {
+		for _, route := range routes[handler.identity.Name] {
+			route.Path = handler.identity.URL
+			route.Tag = "media"
+			res = append(res, route)
+		}
+	}
+	return res
+}
@@ -0,0 +1,111 @@
+package media
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+)
+
+// Service is the interface for media service.
+type Service interface {
+	// UploadMedia stores the file read from r as a new media
+	// owned by the user in the given media and returns the
+	// created media ID.
+	UploadMedia(ctx context.Context, reqMedia Media, r io.Reader) (string, error)
+
+	// GetMediaByID returns a media with the given media ID.
+	//
+	// The returned media and variant URLs are signed URLs
+	// that expire after a configured duration.
+	GetMediaByID(ctx context.Context, mediaID string) (Media, error)
+
+	// DeleteMediaByID deletes a media with the given media ID
+	// including the stored file.
+	DeleteMediaByID(ctx context.Context, mediaID string) error
+}
+
+// Media denotes an uploaded file.
+//
+// Uploaded images are processed in the background. Until the
+// processing is done, the media has no URL and no variants.
+type Media struct {
+	ID         string
+	UserID     string
+	FileName   string
+	MIMEType   string
+	Size       int64
+	StorageKey string
+	Status     Status
+	Variants   []Variant
+	CreateTime time.Time
+
+	// derived
+	URL string
+}
+
+// Variant denotes a resized version of an uploaded image.
+type Variant struct {
+	Name       string
+	MIMEType   string
+	Width      int
+	Height     int
+	Size       int64
+	StorageKey string
+
+	// derived
+	URL string
+}
+
+// Followings are the known variant names.
+const (
+	VariantThumbnail = "thumbnail"
+	VariantCard      = "card"
+	VariantFull      = "full"
+)
+
+// GetVariant returns the variant with the given name, if any.
+func (m Media) GetVariant(name string) (Variant, bool) {
+	for _, v := range m.Variants {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return Variant{}, false
+}
+
+// IsImage returns whether the media is an image.
+func (m Media) IsImage() bool {
+	return strings.HasPrefix(m.MIMEType, "image/")
+}
+
+// Status denotes processing status of a media.
+type Status int
+
+// Followings are the known media status.
+const (
+	StatusUnknown    Status = 0
+	StatusProcessing Status = 1
+	StatusReady      Status = 2
+	StatusFailed     Status = 3
+)
+
+var (
+	// StatusName maps media status to it's string
+	// representation.
+	StatusName = map[Status]string{
+		StatusProcessing: "processing",
+		StatusReady:      "ready",
+		StatusFailed:     "failed",
+	}
+)
+
+// String returns string representaion of a media status.
+func (s Status) String() string {
+	return StatusName[s]
+}
+
+// Value returns int value of a media status.
+func (s Status) Value() int {
+	return int(s)
+}
@@ -0,0 +1,409 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hbdtoyou/internal/media"
+	contextlib "hbdtoyou/pkg/context"
+	"hbdtoyou/pkg/imageproc"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// UploadMedia stores the file read from r as a new media
+// owned by the user in the given media and returns the
+// created media ID.
+//
+// Uploaded images are not stored as they are. They are
+// queued to be auto-oriented, stripped from their metadata,
+// resized into variants and re-encoded in the background.
+func (s *service) UploadMedia(ctx context.Context, reqMedia media.Media, r io.Reader) (string, error) {
+	// validate fields
+	id, err := uuid.Parse(reqMedia.UserID)
+	if err != nil || id == uuid.Nil {
+		return "", media.ErrInvalidUserID
+	}
+
+	reqMedia.FileName = path.Base(strings.TrimSpace(reqMedia.FileName))
+	if reqMedia.FileName == "" || reqMedia.FileName == "." || reqMedia.FileName == "/" {
+		return "", media.ErrInvalidFileName
+	}
+
+	// read one more byte than allowed to detect oversized file
+	data, err := io.ReadAll(io.LimitReader(r, s.config.MaxSize+1))
+	if err != nil {
+		return "", err
+	}
+
+	if int64(len(data)) > s.config.MaxSize {
+		return "", media.ErrFileTooLarge
+	}
+
+	// detect type from the content instead of trusting the
+	// client provided content type
+	mimeType := http.DetectContentType(data)
+	if !s.isAllowedMIMEType(mimeType) {
+		return "", media.ErrInvalidMIMEType
+	}
+
+	// update fields
+	reqMedia.Size = int64(len(data))
+	reqMedia.CreateTime = s.timeNow()
+
+	if _, ok := imageproc.DecodableMIMEType[mimeType]; ok {
+		return s.uploadImage(ctx, reqMedia, data)
+	}
+
+	return s.uploadFile(ctx, reqMedia, mimeType, data)
+}
+
+// uploadFile stores the given data as it is and creates a
+// ready media.
+func (s *service) uploadFile(ctx context.Context, reqMedia media.Media, mimeType string, data []byte) (string, error) {
+	// update fields
+	reqMedia.MIMEType = mimeType
+	reqMedia.StorageKey = generateStorageKey(reqMedia.UserID) + getExtension(mimeType)
+	reqMedia.Status = media.StatusReady
+
+	// store the file first, so a media record never points to
+	// a missing file
+	err := s.storage.Put(ctx, reqMedia.StorageKey, bytes.NewReader(data), reqMedia.Size, mimeType)
+	if err != nil {
+		return "", err
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return "", err
+	}
+
+	// inserts media in pgstore
+	mediaID, err := pgStoreClient.CreateMedia(ctx, reqMedia)
+	if err != nil {
+		// best effort to clean up the stored file
+		s.storage.Delete(ctx, reqMedia.StorageKey)
+		return "", err
+	}
+
+	return mediaID, nil
+}
+
+// uploadImage creates a processing media and queues the
+// given image data to be processed.
+//
+// The original image is never stored, so its metadata can
+// not leak through the storage.
+func (s *service) uploadImage(ctx context.Context, reqMedia media.Media, data []byte) (string, error) {
+	// refuse undecodable or oversized image early instead of
+	// failing in the background
+	width, height, err := imageproc.DecodeConfig(bytes.NewReader(data))
+	if err != nil || width <= 0 || height <= 0 {
+		return "", media.ErrInvalidImage
+	}
+	if width*height > s.config.MaxPixels {
+		return "", media.ErrInvalidImage
+	}
+
+	// the full variant is the media main file
+	baseKey := generateStorageKey(reqMedia.UserID)
+	reqMedia.MIMEType = imageproc.FormatMIMEType[s.config.ImageFormat]
+	reqMedia.StorageKey = s.getVariantStorageKey(baseKey, media.VariantFull)
+	reqMedia.Status = media.StatusProcessing
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return "", err
+	}
+
+	// inserts media in pgstore
+	mediaID, err := pgStoreClient.CreateMedia(ctx, reqMedia)
+	if err != nil {
+		return "", err
+	}
+
+	// queue the image, this blocks when the queue is full
+	err = s.pool.Submit(ctx, func() {
+		s.processImage(ctx, mediaID, baseKey, data)
+	})
+	if err != nil {
+		// best effort to clean up the created media
+		pgStoreClient.DeleteMediaByID(ctx, mediaID)
+		return "", err
+	}
+
+	return mediaID, nil
+}
+
+// processImage generates and stores all variants of the
+// given image data, then marks the media as ready.
+//
+// The media is marked as failed if any of the steps fails.
+//
+// The processing outlives the request, only the values of the
+// given context, e.g. the tenant, are kept.
+func (s *service) processImage(reqCtx context.Context, mediaID, baseKey string, data []byte) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(reqCtx), s.config.ProcessTimeout)
+	defer cancel()
+
+	variants, err := s.storeVariants(ctx, baseKey, data)
+	if err == nil {
+		err = s.createVariants(ctx, mediaID, variants)
+		if err != nil {
+			// best effort to clean up the stored variants
+			s.deleteVariants(ctx, variants)
+		}
+	}
+
+	if err != nil {
+		log.Printf("[media-service] failed to process media %s: %s\n", mediaID, err.Error())
+
+		// the processing context might have been expired
+		ctx = context.WithoutCancel(reqCtx)
+
+		pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+		if err != nil {
+			return
+		}
+		pgStoreClient.UpdateMediaStatus(ctx, mediaID, media.StatusFailed)
+	}
+}
+
+// storeVariants decodes the given image data, encodes it into
+// every configured variant and puts them into the storage.
+func (s *service) storeVariants(ctx context.Context, baseKey string, data []byte) ([]media.Variant, error) {
+	img, err := imageproc.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	mimeType := imageproc.FormatMIMEType[s.config.ImageFormat]
+
+	variants := make([]media.Variant, 0, len(s.config.Variants))
+	for _, cfg := range s.config.Variants {
+		resized, err := imageproc.Fit(img, cfg.MaxWidth, cfg.MaxHeight)
+		if err != nil {
+			s.deleteVariants(ctx, variants)
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+		err = imageproc.Encode(&buf, resized, s.config.ImageFormat, s.config.ImageQuality)
+		if err != nil {
+			s.deleteVariants(ctx, variants)
+			return nil, err
+		}
+
+		variant := media.Variant{
+			Name:       cfg.Name,
+			MIMEType:   mimeType,
+			Width:      resized.Bounds().Dx(),
+			Height:     resized.Bounds().Dy(),
+			Size:       int64(buf.Len()),
+			StorageKey: s.getVariantStorageKey(baseKey, cfg.Name),
+		}
+
+		err = s.storage.Put(ctx, variant.StorageKey, &buf, variant.Size, mimeType)
+		if err != nil {
+			s.deleteVariants(ctx, variants)
+			return nil, err
+		}
+
+		variants = append(variants, variant)
+	}
+
+	return variants, nil
+}
+
+// createVariants records the given variants and marks the
+// media as ready in a single transaction.
+func (s *service) createVariants(ctx context.Context, mediaID string, variants []media.Variant) error {
+	// get pg store client using transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	for _, variant := range variants {
+		err = pgStoreClient.CreateMediaVariant(ctx, mediaID, variant)
+		if err != nil {
+			pgStoreClient.Rollback()
+			return err
+		}
+	}
+
+	err = pgStoreClient.UpdateMediaStatus(ctx, mediaID, media.StatusReady)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return err
+	}
+
+	return pgStoreClient.Commit()
+}
+
+// deleteVariants deletes the stored files of the given
+// variants, ignoring any error.
+func (s *service) deleteVariants(ctx context.Context, variants []media.Variant) {
+	for _, variant := range variants {
+		s.storage.Delete(ctx, variant.StorageKey)
+	}
+}
+
+// GetMediaByID returns a media with the given media ID.
+func (s *service) GetMediaByID(ctx context.Context, mediaID string) (media.Media, error) {
+	// validate id
+	if mediaID == "" {
+		return media.Media{}, media.ErrInvalidMediaID
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return media.Media{}, err
+	}
+
+	// get media from pgstore
+	result, err := pgStoreClient.GetMediaByID(ctx, mediaID)
+	if err != nil {
+		return media.Media{}, err
+	}
+
+	// files are not available until the media is ready
+	if result.Status != media.StatusReady {
+		return result, nil
+	}
+
+	// sign media and variant URLs
+	result.URL, err = s.storage.SignedURL(ctx, result.StorageKey, s.config.SignedURLExpiration)
+	if err != nil {
+		return media.Media{}, err
+	}
+
+	for i, variant := range result.Variants {
+		result.Variants[i].URL, err = s.storage.SignedURL(ctx, variant.StorageKey, s.config.SignedURLExpiration)
+		if err != nil {
+			return media.Media{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// DeleteMediaByID deletes a media with the given media ID
+// including the stored files.
+func (s *service) DeleteMediaByID(ctx context.Context, mediaID string) error {
+	// validate id
+	if mediaID == "" {
+		return media.ErrInvalidMediaID
+	}
+
+	// get user ID
+	userID, ok := contextlib.GetUserID(ctx)
+	if !ok {
+		return media.ErrInvalidUserID
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	// get media from pgstore
+	current, err := pgStoreClient.GetMediaByID(ctx, mediaID)
+	if err != nil {
+		return err
+	}
+
+	// only the owner can delete a media
+	if current.UserID != userID {
+		return media.ErrInvalidMediaAccess
+	}
+
+	// delete media in pgstore, its variants are deleted
+	// along with it
+	err = pgStoreClient.DeleteMediaByID(ctx, mediaID)
+	if err != nil {
+		return err
+	}
+
+	// delete the stored files, the full variant shares the
+	// media storage key
+	keys := map[string]struct{}{
+		current.StorageKey: {},
+	}
+	for _, variant := range current.Variants {
+		keys[variant.StorageKey] = struct{}{}
+	}
+
+	for key := range keys {
+		err = s.storage.Delete(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isAllowedMIMEType checks whether the given MIME type is
+// allowed to be uploaded.
+func (s *service) isAllowedMIMEType(mimeType string) bool {
+	// remove parameters, e.g. "text/plain; charset=utf-8"
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+
+	for _, allowed := range s.config.AllowedMIMETypes {
+		if strings.EqualFold(allowed, mimeType) {
+			return true
+		}
+	}
+	return false
+}
+
+// getVariantStorageKey returns storage key of a variant with
+// the given name. The full variant has no suffix as it is the
+// media main file.
+func (s *service) getVariantStorageKey(baseKey, name string) string {
+	ext := imageproc.FormatExtension[s.config.ImageFormat]
+	if name == media.VariantFull {
+		return baseKey + ext
+	}
+	return fmt.Sprintf("%s_%s%s", baseKey, name, ext)
+}
+
+// mimeTypeExtension maps common MIME types to their preferred
+// file extension, since mime.ExtensionsByType() returns them
+// in alphabetical order.
+var mimeTypeExtension = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+	"image/gif":  ".gif",
+}
+
+// getExtension returns file extension of the given MIME
+// type, if any.
+func getExtension(mimeType string) string {
+	if ext, ok := mimeTypeExtension[mimeType]; ok {
+		return ext
+	}
+
+	if exts, err := mime.ExtensionsByType(mimeType); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+// generateStorageKey returns a new unique storage key,
+// without extension, for a file owned by the given user.
+func generateStorageKey(userID string) string {
+	return fmt.Sprintf("media/%s/%s", userID, uuid.New().String())
+}
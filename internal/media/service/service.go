@@ -0,0 +1,239 @@
+package service
+
+import (
+	"errors"
+	"hbdtoyou/internal/media"
+	"hbdtoyou/pkg/imageproc"
+	"hbdtoyou/pkg/storage"
+	"hbdtoyou/pkg/workerpool"
+	"time"
+)
+
+// Following constans are config default values.
+const (
+	defaultMaxSize             int64 = 5 << 20 // 5 MiB
+	defaultSignedURLExpiration       = 1 * time.Hour
+	defaultImageFormat               = imageproc.FormatJPEG
+	defaultImageQuality              = 85
+	defaultMaxPixels                 = 40_000_000
+	defaultWorkers                   = 4
+	defaultQueueSize                 = 64
+	defaultProcessTimeout            = 1 * time.Minute
+)
+
+// defaultAllowedMIMETypes is the default list of allowed
+// media MIME types.
+var defaultAllowedMIMETypes = []string{
+	"image/jpeg",
+	"image/png",
+	"image/webp",
+}
+
+// defaultVariants is the default list of image variants
+// generated for an uploaded image.
+var defaultVariants = []VariantConfig{
+	{Name: media.VariantThumbnail, MaxWidth: 320, MaxHeight: 320},
+	{Name: media.VariantCard, MaxWidth: 1080, MaxHeight: 1080},
+	{Name: media.VariantFull, MaxWidth: 2048, MaxHeight: 2048},
+}
+
+// Followings are the known error returned from service.
+var (
+	errMissingStorage     = errors.New("missing storage client")
+	errInvalidImageFormat = errors.New("invalid image format")
+	errMissingFullVariant = errors.New("missing full image variant")
+	errInvalidVariant     = errors.New("invalid image variant")
+)
+
+// service implements media.Service.
+type service struct {
+	pgStore PGStore
+	storage storage.Client
+	pool    *workerpool.Pool
+	config  Config
+	timeNow func() time.Time
+}
+
+// Config denotes service configuration
+//
+// Adding a new field should also add the corresponding default
+// value in getDefaultConfig().
+type Config struct {
+	// MaxSize is the maximum size of an uploaded file in
+	// bytes.
+	MaxSize int64
+
+	// AllowedMIMETypes is the list of MIME types allowed to
+	// be uploaded. The type is detected from the file content
+	// instead of trusting the client.
+	AllowedMIMETypes []string
+
+	// SignedURLExpiration is how long a media URL is valid.
+	SignedURLExpiration time.Duration
+
+	// ImageFormat is the format uploaded images are
+	// re-encoded into.
+	ImageFormat imageproc.Format
+
+	// ImageQuality is the quality of lossy image formats,
+	// ranging from 1 to 100.
+	ImageQuality int
+
+	// MaxPixels is the maximum number of pixels of an
+	// uploaded image, to refuse images that are small in
+	// size but huge once decoded.
+	MaxPixels int
+
+	// Variants is the list of variants generated for an
+	// uploaded image. It must contain the full variant,
+	// which is served as the media URL.
+	Variants []VariantConfig
+
+	// Workers is the number of images processed
+	// concurrently.
+	Workers int
+
+	// QueueSize is the number of images waiting to be
+	// processed before an upload is blocked.
+	QueueSize int
+
+	// ProcessTimeout is the maximum duration to process an
+	// image.
+	ProcessTimeout time.Duration
+}
+
+// VariantConfig denotes configuration of an image variant.
+type VariantConfig struct {
+	Name      string
+	MaxWidth  int
+	MaxHeight int
+}
+
+// getDefaultConfig returns service configuration with the
+// predefined default values.
+func getDefaultConfig() Config {
+	return Config{
+		MaxSize:             defaultMaxSize,
+		AllowedMIMETypes:    defaultAllowedMIMETypes,
+		SignedURLExpiration: defaultSignedURLExpiration,
+		ImageFormat:         defaultImageFormat,
+		ImageQuality:        defaultImageQuality,
+		MaxPixels:           defaultMaxPixels,
+		Variants:            defaultVariants,
+		Workers:             defaultWorkers,
+		QueueSize:           defaultQueueSize,
+		ProcessTimeout:      defaultProcessTimeout,
+	}
+}
+
+// New creates a new service.
+//
+// The returned service runs a pool of image processing
+// workers, Close() should be called to stop them.
+func New(pgStore PGStore, storage storage.Client, options ...Option) (*service, error) {
+	if storage == nil {
+		return nil, errMissingStorage
+	}
+
+	s := &service{
+		pgStore: pgStore,
+		storage: storage,
+		config:  getDefaultConfig(),
+		timeNow: time.Now,
+	}
+
+	// apply options
+	for _, opt := range options {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
+
+	// start image processing workers
+	pool, err := workerpool.New(s.config.Workers, s.config.QueueSize)
+	if err != nil {
+		return nil, err
+	}
+	s.pool = pool
+
+	return s, nil
+}
+
+// Close waits until all queued images are processed and
+// stops the image processing workers.
+func (s *service) Close() error {
+	s.pool.Close()
+	return nil
+}
+
+// Option controls the behavior of service.
+type Option func(*service) error
+
+// WithConfig returns Option to set service configuration.
+func WithConfig(config Config) Option {
+	return func(s *service) error {
+		if config.MaxSize > 0 {
+			s.config.MaxSize = config.MaxSize
+		}
+		if len(config.AllowedMIMETypes) > 0 {
+			s.config.AllowedMIMETypes = config.AllowedMIMETypes
+		}
+		if config.SignedURLExpiration > 0 {
+			s.config.SignedURLExpiration = config.SignedURLExpiration
+		}
+		if config.ImageFormat != "" {
+			if _, ok := imageproc.FormatList[config.ImageFormat]; !ok {
+				return errInvalidImageFormat
+			}
+			s.config.ImageFormat = config.ImageFormat
+		}
+		if config.ImageQuality > 0 {
+			s.config.ImageQuality = config.ImageQuality
+		}
+		if config.MaxPixels > 0 {
+			s.config.MaxPixels = config.MaxPixels
+		}
+		if len(config.Variants) > 0 {
+			if err := validateVariants(config.Variants); err != nil {
+				return err
+			}
+			s.config.Variants = config.Variants
+		}
+		if config.Workers > 0 {
+			s.config.Workers = config.Workers
+		}
+		if config.QueueSize > 0 {
+			s.config.QueueSize = config.QueueSize
+		}
+		if config.ProcessTimeout > 0 {
+			s.config.ProcessTimeout = config.ProcessTimeout
+		}
+		return nil
+	}
+}
+
+// validateVariants validates the given variant
+// configurations.
+func validateVariants(variants []VariantConfig) error {
+	var hasFull bool
+	names := make(map[string]struct{}, len(variants))
+	for _, v := range variants {
+		if v.Name == "" || v.MaxWidth <= 0 || v.MaxHeight <= 0 {
+			return errInvalidVariant
+		}
+		if _, ok := names[v.Name]; ok {
+			return errInvalidVariant
+		}
+		names[v.Name] = struct{}{}
+
+		if v.Name == media.VariantFull {
+			hasFull = true
+		}
+	}
+
+	if !hasFull {
+		return errMissingFullVariant
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"hbdtoyou/internal/media"
+)
+
+type PGStore interface {
+	NewClient(ctx context.Context, useTx bool) (PGStoreClient, error)
+}
+
+type PGStoreClient interface {
+	// Commit commits the transaction.
+	Commit() error
+	// Rollback aborts the transaction.
+	Rollback() error
+
+	// CreateMedia creates a new media and returns the
+	// created media ID.
+	CreateMedia(ctx context.Context, reqMedia media.Media) (string, error)
+
+	// GetMediaByID returns a media with the given media ID.
+	GetMediaByID(ctx context.Context, mediaID string) (media.Media, error)
+
+	// UpdateMediaStatus updates processing status of a media
+	// with the given media ID.
+	UpdateMediaStatus(ctx context.Context, mediaID string, status media.Status) error
+
+	// CreateMediaVariant creates a new variant of a media with
+	// the given media ID.
+	CreateMediaVariant(ctx context.Context, mediaID string, variant media.Variant) error
+
+	// DeleteMediaByID deletes a media with the given media
+	// ID.
+	DeleteMediaByID(ctx context.Context, mediaID string) error
+}
@@ -0,0 +1,156 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hbdtoyou/internal/media"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+func (sc *storeClient) CreateMedia(ctx context.Context, reqMedia media.Media) (string, error) {
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"user_id":     reqMedia.UserID,
+		"file_name":   reqMedia.FileName,
+		"mime_type":   reqMedia.MIMEType,
+		"size":        reqMedia.Size,
+		"storage_key": reqMedia.StorageKey,
+		"status":      reqMedia.Status,
+		"create_time": reqMedia.CreateTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryCreateMedia, argKV)
+	if err != nil {
+		return "", err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return "", err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	var id string
+	err = sc.q.QueryRowx(query, args...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (sc *storeClient) GetMediaByID(ctx context.Context, mediaID string) (media.Media, error) {
+	// validate id to avoid invalid uuid syntax error
+	if _, err := uuid.Parse(mediaID); err != nil {
+		return media.Media{}, media.ErrInvalidMediaID
+	}
+
+	query := fmt.Sprintf(queryGetMedia, "WHERE m.id = $1")
+
+	// query single row
+	var model mediaModel
+	err := sc.q.QueryRowx(query, mediaID).StructScan(&model)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return media.Media{}, media.ErrDataNotFound
+		}
+		return media.Media{}, err
+	}
+	result := model.format()
+
+	// query variants
+	rows, err := sc.q.Queryx(queryGetMediaVariants, mediaID)
+	if err != nil {
+		return media.Media{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var variant variantModel
+		err = rows.StructScan(&variant)
+		if err != nil {
+			return media.Media{}, err
+		}
+
+		result.Variants = append(result.Variants, variant.format())
+	}
+
+	return result, nil
+}
+
+func (sc *storeClient) UpdateMediaStatus(ctx context.Context, mediaID string, status media.Status) error {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"id":     mediaID,
+		"status": status,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryUpdateMediaStatus, argsKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	_, err = sc.q.Exec(query, args...)
+	return err
+}
+
+func (sc *storeClient) CreateMediaVariant(ctx context.Context, mediaID string, variant media.Variant) error {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"media_id":    mediaID,
+		"name":        variant.Name,
+		"mime_type":   variant.MIMEType,
+		"width":       variant.Width,
+		"height":      variant.Height,
+		"size":        variant.Size,
+		"storage_key": variant.StorageKey,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryCreateMediaVariant, argsKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	_, err = sc.q.Exec(query, args...)
+	return err
+}
+
+func (sc *storeClient) DeleteMediaByID(ctx context.Context, mediaID string) error {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"id": mediaID,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryDeleteMedia, argsKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	_, err = sc.q.Exec(query, args...)
+	return err
+}
@@ -0,0 +1,54 @@
+package postgresql
+
+import (
+	"hbdtoyou/internal/media"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mediaModel struct {
+	ID         uuid.UUID `db:"id"`
+	UserID     string    `db:"user_id"`
+	FileName   string    `db:"file_name"`
+	MIMEType   string    `db:"mime_type"`
+	Size       int64     `db:"size"`
+	StorageKey string    `db:"storage_key"`
+	Status     int       `db:"status"`
+	CreateTime time.Time `db:"create_time"`
+}
+
+// format formats database struct into domain struct.
+func (dbData *mediaModel) format() media.Media {
+	return media.Media{
+		ID:         dbData.ID.String(),
+		UserID:     dbData.UserID,
+		FileName:   dbData.FileName,
+		MIMEType:   dbData.MIMEType,
+		Size:       dbData.Size,
+		StorageKey: dbData.StorageKey,
+		Status:     media.Status(dbData.Status),
+		CreateTime: dbData.CreateTime,
+	}
+}
+
+type variantModel struct {
+	Name       string `db:"name"`
+	MIMEType   string `db:"mime_type"`
+	Width      int    `db:"width"`
+	Height     int    `db:"height"`
+	Size       int64  `db:"size"`
+	StorageKey string `db:"storage_key"`
+}
+
+// format formats database struct into domain struct.
+func (dbData *variantModel) format() media.Variant {
+	return media.Variant{
+		Name:       dbData.Name,
+		MIMEType:   dbData.MIMEType,
+		Width:      dbData.Width,
+		Height:     dbData.Height,
+		Size:       dbData.Size,
+		StorageKey: dbData.StorageKey,
+	}
+}
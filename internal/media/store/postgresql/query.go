@@ -0,0 +1,100 @@
+package postgresql
+
+const (
+	queryCreateMedia = `
+		INSERT INTO
+			media
+			(
+				user_id,
+				file_name,
+				mime_type,
+				size,
+				storage_key,
+				status,
+				create_time
+			)
+		VALUES
+			(
+				:user_id,
+				:file_name,
+				:mime_type,
+				:size,
+				:storage_key,
+				:status,
+				:create_time
+			)
+		RETURNING
+			id
+	`
+
+	queryGetMedia = `
+		SELECT
+			m.id,
+			m.user_id,
+			m.file_name,
+			m.mime_type,
+			m.size,
+			m.storage_key,
+			m.status,
+			m.create_time
+		FROM
+			media m
+		%s
+	`
+
+	queryUpdateMediaStatus = `
+		UPDATE
+			media
+		SET
+			status = :status
+		WHERE
+			id = :id
+	`
+
+	queryCreateMediaVariant = `
+		INSERT INTO
+			media_variant
+			(
+				media_id,
+				name,
+				mime_type,
+				width,
+				height,
+				size,
+				storage_key
+			)
+		VALUES
+			(
+				:media_id,
+				:name,
+				:mime_type,
+				:width,
+				:height,
+				:size,
+				:storage_key
+			)
+	`
+
+	queryGetMediaVariants = `
+		SELECT
+			mv.name,
+			mv.mime_type,
+			mv.width,
+			mv.height,
+			mv.size,
+			mv.storage_key
+		FROM
+			media_variant mv
+		WHERE
+			mv.media_id = $1
+		ORDER BY
+			mv.width ASC
+	`
+
+	queryDeleteMedia = `
+		DELETE FROM
+			media
+		WHERE
+			id = :id
+	`
+)
@@ -0,0 +1,17 @@
+package notification
+
+import "errors"
+
+var (
+	// ErrDataNotFound is returned when the desired data is
+	// not found.
+	ErrDataNotFound = errors.New("data not found")
+
+	// ErrInvalidUserID is returned when the given user ID is
+	// invalid.
+	ErrInvalidUserID = errors.New("invalid user id")
+
+	// ErrInvalidNotificationID is returned when the given
+	// notification ID is invalid.
+	ErrInvalidNotificationID = errors.New("invalid notification id")
+)
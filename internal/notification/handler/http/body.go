@@ -0,0 +1,63 @@
+package http
+
+import (
+	"hbdtoyou/internal/notification"
+	"net/http"
+	"time"
+
+	httplib "hbdtoyou/pkg/http"
+)
+
+// timeFormat denotes the standard time format used in
+// notification HTTP handlers.
+var timeFormat = "02/01/2006 3:04 PM -07:00"
+
+type notificationHTTP struct {
+	ID         *string           `json:"id"`
+	Event      *string           `json:"event"`
+	Subject    *string           `json:"subject"`
+	Body       *string           `json:"body"`
+	Data       map[string]string `json:"data"`
+	CreateTime *string           `json:"create_time"`
+	ReadTime   *string           `json:"read_time"`
+}
+
+func formatNotification(n notification.Notification) notificationHTTP {
+	event := n.Event.String()
+
+	return notificationHTTP{
+		ID:         &n.ID,
+		Event:      &event,
+		Subject:    &n.Subject,
+		Body:       &n.Body,
+		Data:       n.Data,
+		CreateTime: formatTime(n.CreateTime),
+		ReadTime:   formatTime(n.ReadTime),
+	}
+}
+
+// formatTime returns the given time formatted in timeFormat,
+// or nil for a zero time.
+func formatTime(t time.Time) *string {
+	if t.IsZero() {
+		return nil
+	}
+
+	formatted := t.Format(timeFormat)
+	return &formatted
+}
+
+func parseGetNotificationsQuery(r *http.Request) (notification.GetNotificationsFilter, error) {
+	res := notification.GetNotificationsFilter{
+		Unread: r.URL.Query().Get("unread") == "true",
+	}
+
+	page, limit, err := httplib.GetPaginationFromQuery(r)
+	if err != nil {
+		return res, errInvalidPagination
+	}
+	res.Page = page
+	res.Limit = limit
+
+	return res, nil
+}
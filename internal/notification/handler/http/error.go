@@ -0,0 +1,64 @@
+package http
+
+import (
+	"errors"
+	"hbdtoyou/internal/notification"
+)
+
+// Followings are the known errors from Notification HTTP
+// handlers.
+var (
+	// errInternalServer is returned when there is an
+	// unexpected error encountered when processing a request.
+	errInternalServer = errors.New("INTERNAL_SERVER_ERROR")
+
+	// errDataNotFound is returned when the desired data is
+	// not found.
+	errDataNotFound = errors.New("DATA_NOT_FOUND")
+
+	// errInvalidToken is returned when the given token is
+	// invalid.
+	errInvalidToken = errors.New("INVALID_TOKEN")
+
+	// errInvalidUserID is returned when the given user ID is
+	// invalid.
+	errInvalidUserID = errors.New("INVALID_USER_ID")
+
+	// errInvalidNotificationID is returned when the given
+	// notification ID is invalid.
+	errInvalidNotificationID = errors.New("INVALID_NOTIFICATION_ID")
+
+	// errInvalidPagination is returned when the given page or
+	// limit is invalid.
+	errInvalidPagination = errors.New("INVALID_PAGINATION")
+
+	// errMethodNotAllowed is returned when accessing not
+	// allowed HTTP method.
+	errMethodNotAllowed = errors.New("METHOD_NOT_ALLOWED")
+
+	// errRequestTimeout is returned when processing time has
+	// reached the timeout limit.
+	errRequestTimeout = errors.New("REQUEST_TIMEOUT")
+
+	// errSourceNotProvided is returned when there is no
+	// source provided in the request.
+	errSourceNotProvided = errors.New("SOURCE_NOT_PROVIDED")
+
+	// errUnauthorizedAccess is returned when the request
+	// is unaothorized.
+	errUnauthorizedAccess = errors.New("UNAUTHORIZED_ACCESS")
+)
+
+var (
+	// mapHTTPError maps service error into HTTP error that
+	// categorize as bad request error.
+	//
+	// Internal server error-related should not be mapped here,
+	// and the handler should just return `errInternal` as the
+	// error instead
+	mapHTTPError = map[error]error{
+		notification.ErrDataNotFound:          errDataNotFound,
+		notification.ErrInvalidUserID:         errInvalidUserID,
+		notification.ErrInvalidNotificationID: errInvalidNotificationID,
+	}
+)
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"hbdtoyou/internal/auth"
+	"hbdtoyou/internal/notification"
+	"net/http"
+
+	httplib "hbdtoyou/pkg/http"
+
+	"github.com/gorilla/mux"
+)
+
+type notificationsHandler struct {
+	notification  notification.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *notificationsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetNotifications(w, r)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type notificationReadHandler struct {
+	notification  notification.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *notificationReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	notificationID := vars["id"]
+
+	switch r.Method {
+	case http.MethodPost:
+		h.handleReadNotification(w, r, notificationID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
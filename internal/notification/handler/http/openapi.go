@@ -0,0 +1,35 @@
+package http
+
+import (
+	"hbdtoyou/pkg/openapi"
+	"net/http"
+)
+
+// routes defines the OpenAPI routes of each HTTP handler, it
+// must be updated along with the methods served by handler.go.
+var routes = map[string][]openapi.Route{
+	HandlerNotifications.Name: {
+		{Method: http.MethodGet, Summary: "Get notifications", Response: []notificationHTTP{}, Query: []*openapi.Parameter{
+			openapi.Query("unread", "boolean", "Lists unread notifications only."),
+			openapi.Query("page", "integer", "Page number, starting from 1."),
+			openapi.Query("limit", "integer", "Number of notifications per page."),
+		}},
+	},
+	HandlerNotificationRead.Name: {
+		{Method: http.MethodPost, Summary: "Read notification", Response: ""},
+	},
+}
+
+// Routes returns the OpenAPI routes of the registered HTTP
+// handlers.
+func (h *Handler) Routes() []openapi.Route {
+	var res []openapi.Route
+	for _, handler := range h.handlers {
+		for _, route := range routes[handler.identity.Name] {
+			route.Path = handler.identity.URL
+			route.Tag = "notification"
+			res = append(res, route)
+		}
+	}
+	return res
+}
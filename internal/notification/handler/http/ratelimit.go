@@ -0,0 +1,37 @@
+package http
+
+import (
+	httplib "hbdtoyou/pkg/http"
+	"hbdtoyou/pkg/ratelimit"
+	"net/http"
+)
+
+// handlerScopes maps the methods of each handler to the scope
+// of their requests, the rate limit of the scope is applied
+// before the request is handled.
+var handlerScopes = map[string]map[string]Scope{
+	HandlerNotifications.Name: {
+		http.MethodGet: ScopeGetNotifications,
+	},
+	HandlerNotificationRead.Name: {
+		http.MethodPost: ScopeReadNotification,
+	},
+}
+
+// limitRate returns the given handler of the given identity
+// limited by the rate limit of the scope of each request.
+func (h *Handler) limitRate(identity HandlerIdentity, next http.Handler) http.Handler {
+	if h.rateLimiter == nil {
+		return next
+	}
+
+	scopes := handlerScopes[identity.Name]
+	return h.rateLimiter.Handler(next, httplib.LimitFunc(func(r *http.Request) (string, ratelimit.Limit, bool) {
+		scope, ok := scopes[r.Method]
+		if !ok {
+			return "", ratelimit.Limit{}, false
+		}
+
+		return ScopeName[scope], h.scopeSettings[scope].RateLimit, true
+	}))
+}
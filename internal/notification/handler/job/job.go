@@ -0,0 +1,111 @@
+package job
+
+import (
+	"context"
+	"hbdtoyou/internal/notification"
+	joblib "hbdtoyou/pkg/job"
+	"log"
+	"time"
+)
+
+// Followings are default values for DispatchSetting fields.
+const (
+	defaultDispatchInterval = 10 * time.Second
+)
+
+// Handler contains notification background jobs.
+type Handler struct {
+	notification    notification.Service
+	dispatchSetting DispatchSetting
+	tenants         []string
+	runners         []*joblib.Runner
+}
+
+// DispatchSetting is the available configurations of the job
+// dispatching pending notifications.
+type DispatchSetting struct {
+	// Interval is how often the job runs.
+	Interval time.Duration
+}
+
+// Option controls the behavior of Handler.
+type Option func(*Handler) error
+
+// WithDispatchSetting returns Option to set the dispatch job
+// setting.
+func WithDispatchSetting(setting DispatchSetting) Option {
+	return Option(func(h *Handler) error {
+		if setting.Interval > 0 {
+			h.dispatchSetting.Interval = setting.Interval
+		}
+		return nil
+	})
+}
+
+// WithTenants returns Option to run every job once for each
+// of the given tenants.
+func WithTenants(tenants []string) Option {
+	return Option(func(h *Handler) error {
+		h.tenants = tenants
+		return nil
+	})
+}
+
+// New creates a new Handler.
+func New(notification notification.Service, options ...Option) (*Handler, error) {
+	h := &Handler{
+		notification: notification,
+		dispatchSetting: DispatchSetting{
+			Interval: defaultDispatchInterval,
+		},
+	}
+
+	// apply options
+	for _, opt := range options {
+		err := opt(h)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dispatchRunner, err := joblib.NewRunner("dispatch_notifications", h.dispatchSetting.Interval, 0, joblib.ForEachTenant(h.tenants, h.dispatchNotifications))
+	if err != nil {
+		return nil, err
+	}
+	h.runners = append(h.runners, dispatchRunner)
+
+	return h, nil
+}
+
+// Start starts all jobs.
+func (h *Handler) Start() error {
+	for _, runner := range h.runners {
+		if err := runner.Start(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop stops all jobs and waits until the running ones are
+// finished.
+func (h *Handler) Stop() {
+	for _, runner := range h.runners {
+		runner.Stop()
+	}
+}
+
+// dispatchNotifications sends pending notifications through
+// the configured channels.
+func (h *Handler) dispatchNotifications(ctx context.Context) error {
+	sent, err := h.notification.DispatchNotifications(ctx)
+	if err != nil {
+		return err
+	}
+
+	if sent > 0 {
+		log.Printf("[Notification Job][dispatchNotifications] Sent %d notifications\n", sent)
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package notification
+
+import (
+	"context"
+	"time"
+)
+
+// Service is the interface for notification service.
+//
+// A notification is written to the outbox in the same
+// transaction as the change it notifies, by the service making
+// the change. It is then dispatched to the configured channels
+// in the background, and kept as the in-app inbox of the user.
+type Service interface {
+	// GetNotifications returns the notifications of the
+	// caller based on the given filter, newest first.
+	GetNotifications(ctx context.Context, filter GetNotificationsFilter) ([]Notification, error)
+
+	// ReadNotification marks a notification of the caller
+	// with the given notification ID as read.
+	ReadNotification(ctx context.Context, notificationID string) error
+
+	// DispatchNotifications sends pending notifications due
+	// at the moment through the configured channels, and
+	// returns the number of sent notifications.
+	//
+	// A notification failing on any channel is retried later
+	// with an increasing backoff, only on the failing
+	// channels, until it reaches the maximum attempts.
+	DispatchNotifications(ctx context.Context) (int, error)
+}
+
+// Notification denotes a notification to a user.
+type Notification struct {
+	ID      string
+	UserID  string
+	Event   Event
+	Subject string
+	Body    string
+
+	// Data holds references of the notified change, e.g. the
+	// ID of an approved payment.
+	Data map[string]string
+
+	Status Status
+
+	// SentChannels are the channels the notification has been
+	// sent through.
+	SentChannels []string
+
+	// Attempts is the number of failed dispatches.
+	// NextAttemptTime is when the notification is dispatched
+	// next, and LastError is the error of the last attempt.
+	Attempts        int
+	NextAttemptTime time.Time
+	LastError       string
+
+	CreateTime time.Time
+	SendTime   time.Time
+	ReadTime   time.Time
+}
+
+// Event denotes the change notified by a notification.
+type Event int
+
+// Following constans are the known notification events.
+const (
+	EventUnknown          Event = 0
+	EventPaymentApproved  Event = 1
+	EventPaymentRejected  Event = 2
+	EventContentPublished Event = 3
+)
+
+var (
+	// EventList is a list of valid notification event.
+	EventList = map[Event]struct{}{
+		EventPaymentApproved:  {},
+		EventPaymentRejected:  {},
+		EventContentPublished: {},
+	}
+
+	// EventName maps notification event to it's string
+	// representation.
+	EventName = map[Event]string{
+		EventPaymentApproved:  "payment_approved",
+		EventPaymentRejected:  "payment_rejected",
+		EventContentPublished: "content_published",
+	}
+)
+
+// String implements the Stringer interface.
+func (e Event) String() string {
+	return EventName[e]
+}
+
+// Value implements the Valuer interface.
+func (e Event) Value() int {
+	return int(e)
+}
+
+// Status denotes dispatch status of a notification.
+type Status int
+
+// Following constans are the known notification status.
+const (
+	StatusUnknown Status = 0
+	StatusPending Status = 1
+	StatusSent    Status = 2
+
+	// StatusFailed is a notification that has reached the
+	// maximum attempts. It is still in the in-app inbox.
+	StatusFailed Status = 3
+)
+
+var (
+	// StatusList is a list of valid notification status.
+	StatusList = map[Status]struct{}{
+		StatusPending: {},
+		StatusSent:    {},
+		StatusFailed:  {},
+	}
+
+	// StatusName maps notification status to it's string
+	// representation.
+	StatusName = map[Status]string{
+		StatusPending: "pending",
+		StatusSent:    "sent",
+		StatusFailed:  "failed",
+	}
+)
+
+// String implements the Stringer interface.
+func (s Status) String() string {
+	return StatusName[s]
+}
+
+// Value implements the Valuer interface.
+func (s Status) Value() int {
+	return int(s)
+}
+
+type GetNotificationsFilter struct {
+	// UserID is filled with the caller.
+	UserID string
+
+	// Unread only returns notifications not read yet.
+	Unread bool
+
+	// Page starts from 1. Zero values of Page and Limit use
+	// the defaults.
+	Page  int
+	Limit int
+}
+
+// Followings are the page size limits of GetNotifications.
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// PageLimit returns the requested page and limit of the
+// filter, with defaults applied and the limit capped to
+// MaxLimit.
+func (f GetNotificationsFilter) PageLimit() (int, int) {
+	page, limit := f.Page, f.Limit
+	if page <= 0 {
+		page = 1
+	}
+
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	return page, limit
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"hbdtoyou/internal/notification"
+	"log"
+	"strings"
+	"time"
+
+	contextlib "hbdtoyou/pkg/context"
+	"hbdtoyou/pkg/notifier"
+)
+
+// GetNotifications returns the notifications of the caller
+// based on the given filter, newest first.
+func (s *service) GetNotifications(ctx context.Context, filter notification.GetNotificationsFilter) ([]notification.Notification, error) {
+	// users only get their own notifications
+	userID, ok := contextlib.GetUserID(ctx)
+	if !ok {
+		return nil, notification.ErrInvalidUserID
+	}
+	filter.UserID = userID
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgStoreClient.GetNotifications(ctx, filter)
+}
+
+// ReadNotification marks a notification of the caller with the
+// given notification ID as read.
+func (s *service) ReadNotification(ctx context.Context, notificationID string) error {
+	// validate id
+	if notificationID == "" {
+		return notification.ErrInvalidNotificationID
+	}
+
+	// users only read their own notifications
+	userID, ok := contextlib.GetUserID(ctx)
+	if !ok {
+		return notification.ErrInvalidUserID
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	return pgStoreClient.ReadNotification(ctx, notificationID, userID, s.timeNow())
+}
+
+// DispatchNotifications sends pending notifications due at the
+// moment through the configured channels, and returns the
+// number of sent notifications.
+func (s *service) DispatchNotifications(ctx context.Context) (int, error) {
+	now := s.timeNow()
+
+	// get pg store client using transaction, the due
+	// notifications are locked so concurrent dispatchers do
+	// not send them twice
+	pgStoreClient, err := s.pgStore.NewClient(ctx, true)
+	if err != nil {
+		return 0, err
+	}
+
+	due, err := pgStoreClient.GetDueNotificationsForUpdate(ctx, now, s.config.BatchSize)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return 0, err
+	}
+
+	var sent int
+	for i := range due {
+		s.dispatchNotification(ctx, &due[i], now)
+
+		err = pgStoreClient.UpdateNotificationDelivery(ctx, due[i])
+		if err != nil {
+			pgStoreClient.Rollback()
+			return 0, err
+		}
+
+		if due[i].Status == notification.StatusSent {
+			sent++
+		}
+	}
+
+	err = pgStoreClient.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return sent, nil
+}
+
+// dispatchNotification sends the given notification through
+// the channels it has not been sent through, and updates its
+// dispatch status by the result.
+func (s *service) dispatchNotification(ctx context.Context, n *notification.Notification, now time.Time) {
+	user, err := s.user.GetUserByID(ctx, n.UserID)
+	if err != nil {
+		s.retryNotification(n, now, err.Error())
+		return
+	}
+
+	msg := notifier.Message{
+		ID:    n.ID,
+		Event: n.Event.String(),
+		Recipient: notifier.Recipient{
+			UserID: user.ID,
+			Name:   user.Fullname,
+			Email:  user.Email,
+		},
+		Subject:    n.Subject,
+		Body:       n.Body,
+		Data:       n.Data,
+		CreateTime: n.CreateTime,
+	}
+
+	var errs []string
+	for _, ch := range s.channels {
+		if isChannelSent(*n, ch.name) {
+			continue
+		}
+
+		err = ch.client.Send(ctx, msg)
+		if err == notifier.ErrInvalidRecipient {
+			// retrying would not reach the user either
+			log.Printf("[Notification Service][dispatchNotification] Skipped channel %s of notification %s. Err: %s\n", ch.name, n.ID, err.Error())
+		} else if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", ch.name, err.Error()))
+			continue
+		}
+
+		n.SentChannels = append(n.SentChannels, ch.name)
+	}
+
+	if len(errs) > 0 {
+		s.retryNotification(n, now, strings.Join(errs, "; "))
+		return
+	}
+
+	n.Status = notification.StatusSent
+	n.SendTime = now
+	n.LastError = ""
+}
+
+// retryNotification schedules the next dispatch of the given
+// notification failing by the given error, or fails it when it
+// has reached the maximum attempts.
+func (s *service) retryNotification(n *notification.Notification, now time.Time, lastError string) {
+	n.Attempts++
+	n.LastError = lastError
+
+	if n.Attempts >= s.config.MaxAttempts {
+		n.Status = notification.StatusFailed
+		log.Printf("[Notification Service][retryNotification] Notification %s failed after %d attempts. Err: %s\n", n.ID, n.Attempts, lastError)
+		return
+	}
+
+	n.NextAttemptTime = now.Add(s.getBackoff(n.Attempts))
+}
+
+// getBackoff returns the delay before retrying a notification
+// failed the given number of attempts.
+func (s *service) getBackoff(attempts int) time.Duration {
+	backoff := s.config.BackoffBase
+	for i := 1; i < attempts && backoff < s.config.BackoffMax; i++ {
+		backoff *= 2
+	}
+
+	if backoff > s.config.BackoffMax {
+		return s.config.BackoffMax
+	}
+
+	return backoff
+}
+
+// isChannelSent returns whether the given notification has been
+// sent through the channel with the given name.
+func isChannelSent(n notification.Notification, name string) bool {
+	for _, sent := range n.SentChannels {
+		if sent == name {
+			return true
+		}
+	}
+
+	return false
+}
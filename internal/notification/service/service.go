@@ -0,0 +1,134 @@
+package service
+
+import (
+	"errors"
+	"hbdtoyou/internal/auth"
+	"hbdtoyou/pkg/notifier"
+	"time"
+)
+
+// Following constans are config default values.
+const (
+	defaultMaxAttempts = 5
+	defaultBackoffBase = 1 * time.Minute
+	defaultBackoffMax  = 1 * time.Hour
+	defaultBatchSize   = 50
+)
+
+// Followings are the known error returned from service.
+var (
+	errInvalidChannel   = errors.New("invalid channel")
+	errChannelDuplicate = errors.New("channel already registered")
+)
+
+// service implements notification.Service.
+type service struct {
+	pgStore  PGStore
+	user     auth.Service
+	channels []channel
+	config   Config
+	timeNow  func() time.Time
+}
+
+// channel is a registered channel notifications are sent
+// through.
+type channel struct {
+	name   string
+	client notifier.Client
+}
+
+// Config denotes service configuration
+//
+// Adding a new field should also add the corresponding default
+// value in getDefaultConfig().
+type Config struct {
+	// MaxAttempts is the number of failed dispatches after
+	// which a notification is no longer retried.
+	MaxAttempts int
+
+	// BackoffBase is the delay before the first retry, it is
+	// doubled on every next retry up to BackoffMax.
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+
+	// BatchSize is the maximum number of notifications
+	// dispatched at once.
+	BatchSize int
+}
+
+// getDefaultConfig returns service configuration with the
+// predefined default values.
+func getDefaultConfig() Config {
+	return Config{
+		MaxAttempts: defaultMaxAttempts,
+		BackoffBase: defaultBackoffBase,
+		BackoffMax:  defaultBackoffMax,
+		BatchSize:   defaultBatchSize,
+	}
+}
+
+// New creates a new service.
+func New(pgStore PGStore, user auth.Service, options ...Option) (*service, error) {
+	s := &service{
+		pgStore: pgStore,
+		user:    user,
+		config:  getDefaultConfig(),
+		timeNow: time.Now,
+	}
+
+	// apply options
+	for _, opt := range options {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Option controls the behavior of service.
+type Option func(*service) error
+
+// WithConfig returns Option to set service configuration.
+func WithConfig(config Config) Option {
+	return func(s *service) error {
+		if config.MaxAttempts > 0 {
+			s.config.MaxAttempts = config.MaxAttempts
+		}
+		if config.BackoffBase > 0 {
+			s.config.BackoffBase = config.BackoffBase
+		}
+		if config.BackoffMax > 0 {
+			s.config.BackoffMax = config.BackoffMax
+		}
+		if config.BatchSize > 0 {
+			s.config.BatchSize = config.BatchSize
+		}
+		return nil
+	}
+}
+
+// WithChannel returns Option to register a channel with the
+// given name notifications are sent through. The in-app inbox
+// needs no channel.
+func WithChannel(name string, client notifier.Client) Option {
+	return func(s *service) error {
+		if name == "" || client == nil {
+			return errInvalidChannel
+		}
+
+		// the name identifies the channel a notification has
+		// been sent through
+		for _, ch := range s.channels {
+			if ch.name == name {
+				return errChannelDuplicate
+			}
+		}
+
+		s.channels = append(s.channels, channel{
+			name:   name,
+			client: client,
+		})
+		return nil
+	}
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"hbdtoyou/internal/notification"
+	"time"
+)
+
+type PGStore interface {
+	NewClient(ctx context.Context, useTx bool) (PGStoreClient, error)
+}
+
+type PGStoreClient interface {
+	// Commit commits the transaction.
+	Commit() error
+	// Rollback aborts the transaction.
+	Rollback() error
+
+	// GetNotifications returns all notifications based on
+	// the given filter, newest first.
+	GetNotifications(ctx context.Context, filter notification.GetNotificationsFilter) ([]notification.Notification, error)
+
+	// ReadNotification marks a notification of the given user
+	// as read at the given time. ErrDataNotFound is returned
+	// if the user has no such notification.
+	ReadNotification(ctx context.Context, notificationID, userID string, readTime time.Time) error
+
+	// GetDueNotificationsForUpdate returns at most the given
+	// limit of pending notifications due at the given time,
+	// oldest first, and locks them until the transaction ends.
+	// Notifications locked by another transaction are
+	// skipped.
+	GetDueNotificationsForUpdate(ctx context.Context, now time.Time, limit int) ([]notification.Notification, error)
+
+	// UpdateNotificationDelivery updates the dispatch status,
+	// sent channels and attempts of the given notification.
+	UpdateNotificationDelivery(ctx context.Context, reqNotification notification.Notification) error
+}
@@ -0,0 +1,177 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"hbdtoyou/internal/notification"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+func (sc *storeClient) GetNotifications(ctx context.Context, filter notification.GetNotificationsFilter) ([]notification.Notification, error) {
+	// define variables to custom query
+	argKV := make(map[string]interface{})
+	conditions := make([]string, 0)
+
+	id, err := uuid.Parse(filter.UserID)
+	if err != nil {
+		return nil, notification.ErrInvalidUserID
+	}
+	conditions = append(conditions, "user_id = :user_id")
+	argKV["user_id"] = id
+
+	if filter.Unread {
+		conditions = append(conditions, "read_time IS NULL")
+	}
+
+	page, limit := filter.PageLimit()
+	argKV["limit"] = limit
+	argKV["offset"] = (page - 1) * limit
+
+	// construct query, ties are broken by ID so the pages are
+	// stable
+	condition := fmt.Sprintf("WHERE %s ORDER BY create_time DESC, id LIMIT :limit OFFSET :offset", strings.Join(conditions, " AND "))
+	query := fmt.Sprintf(queryGetNotification, condition)
+
+	// prepare query
+	query, args, err := sqlx.Named(query, argKV)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	query = sc.q.Rebind(query)
+
+	return sc.queryNotifications(query, args...)
+}
+
+func (sc *storeClient) ReadNotification(ctx context.Context, notificationID, userID string, readTime time.Time) error {
+	// invalid IDs cannot match any notification
+	if _, err := uuid.Parse(notificationID); err != nil {
+		return notification.ErrDataNotFound
+	}
+
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"id":        notificationID,
+		"user_id":   userID,
+		"read_time": readTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryReadNotification, argsKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	// nothing is updated when the notification does not exist
+	// or is not owned by the user
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return notification.ErrDataNotFound
+	}
+
+	return nil
+}
+
+func (sc *storeClient) GetDueNotificationsForUpdate(ctx context.Context, now time.Time, limit int) ([]notification.Notification, error) {
+	query := fmt.Sprintf(queryGetNotification, "WHERE status = $1 AND next_attempt_time <= $2 ORDER BY create_time LIMIT $3 FOR UPDATE SKIP LOCKED")
+
+	return sc.queryNotifications(query, notification.StatusPending, now, limit)
+}
+
+func (sc *storeClient) UpdateNotificationDelivery(ctx context.Context, reqNotification notification.Notification) error {
+	// a nil array is stored as NULL
+	sentChannels := reqNotification.SentChannels
+	if sentChannels == nil {
+		sentChannels = []string{}
+	}
+
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"id":                reqNotification.ID,
+		"status":            reqNotification.Status,
+		"sent_channels":     pq.Array(sentChannels),
+		"attempts":          reqNotification.Attempts,
+		"next_attempt_time": reqNotification.NextAttemptTime,
+		"last_error":        nullString(reqNotification.LastError),
+		"send_time":         nullTime(reqNotification.SendTime),
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryUpdateNotificationDelivery, argsKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return notification.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// queryNotifications runs the given notification query and
+// returns the read rows.
+func (sc *storeClient) queryNotifications(query string, args ...interface{}) ([]notification.Notification, error) {
+	// query to database
+	rows, err := sc.q.Queryx(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// read rows
+	result := make([]notification.Notification, 0)
+	for rows.Next() {
+		var row notificationModel
+		err = rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, row.format())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,101 @@
+package postgresql
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"hbdtoyou/internal/notification"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type notificationModel struct {
+	ID              uuid.UUID             `db:"id"`
+	UserID          string                `db:"user_id"`
+	Event           notification.Event    `db:"event"`
+	Subject         string                `db:"subject"`
+	Body            string                `db:"body"`
+	Data            notificationDataModel `db:"data"`
+	Status          notification.Status   `db:"status"`
+	SentChannels    pq.StringArray        `db:"sent_channels"`
+	Attempts        int                   `db:"attempts"`
+	NextAttemptTime time.Time             `db:"next_attempt_time"`
+	LastError       *string               `db:"last_error"`
+	CreateTime      time.Time             `db:"create_time"`
+	SendTime        *time.Time            `db:"send_time"`
+	ReadTime        *time.Time            `db:"read_time"`
+}
+
+// format formats database struct into domain struct.
+func (dbData *notificationModel) format() notification.Notification {
+	n := notification.Notification{
+		ID:              dbData.ID.String(),
+		UserID:          dbData.UserID,
+		Event:           dbData.Event,
+		Subject:         dbData.Subject,
+		Body:            dbData.Body,
+		Data:            dbData.Data,
+		Status:          dbData.Status,
+		SentChannels:    dbData.SentChannels,
+		Attempts:        dbData.Attempts,
+		NextAttemptTime: dbData.NextAttemptTime,
+		CreateTime:      dbData.CreateTime,
+	}
+
+	if dbData.LastError != nil {
+		n.LastError = *dbData.LastError
+	}
+
+	if dbData.SendTime != nil {
+		n.SendTime = *dbData.SendTime
+	}
+
+	if dbData.ReadTime != nil {
+		n.ReadTime = *dbData.ReadTime
+	}
+
+	return n
+}
+
+// notificationDataModel implements sql.Scanner and
+// driver.Valuer to read and write the data JSON column.
+type notificationDataModel map[string]string
+
+// Scan implements the sql.Scanner interface.
+func (m *notificationDataModel) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, m)
+	case string:
+		return json.Unmarshal([]byte(v), m)
+	case nil:
+		*m = nil
+		return nil
+	}
+	return fmt.Errorf("unsupported notification data type %T", src)
+}
+
+// Value implements the driver.Valuer interface.
+func (m notificationDataModel) Value() (driver.Value, error) {
+	return json.Marshal(m)
+}
+
+// nullString returns nil for an empty string, so it is stored
+// as NULL.
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// nullTime returns nil for a zero time, so it is stored as
+// NULL.
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
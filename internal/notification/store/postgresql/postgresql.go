@@ -0,0 +1,72 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"hbdtoyou/internal/notification/service"
+	pglib "hbdtoyou/pkg/postgresql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	errInvalidCommit   = errors.New("cannot do commit on non-transactional querier")
+	errInvalidRollback = errors.New("cannot do rollback on non-transactional querier")
+)
+
+// store implements notification/service.PGStore
+type store struct {
+	router *pglib.Router
+}
+
+// storeClient implements notification/service.PGStoreClient.
+type storeClient struct {
+	q pglib.Querier
+}
+
+// New creates a new store. Every client is created on the
+// database of the tenant in the given context.
+func New(router *pglib.Router) (*store, error) {
+	s := &store{
+		router: router,
+	}
+
+	return s, nil
+}
+
+func (s *store) NewClient(ctx context.Context, useTx bool) (service.PGStoreClient, error) {
+	var q pglib.Querier
+
+	// route to the database of the tenant
+	db, err := s.router.GetDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// determine what object should be use as querier
+	q = db
+	if useTx {
+		q, err = db.Beginx()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &storeClient{
+		q: q,
+	}, nil
+}
+
+func (sc *storeClient) Commit() error {
+	if tx, ok := sc.q.(*sqlx.Tx); ok {
+		return tx.Commit()
+	}
+	return errInvalidCommit
+}
+
+func (sc *storeClient) Rollback() error {
+	if tx, ok := sc.q.(*sqlx.Tx); ok {
+		return tx.Rollback()
+	}
+	return errInvalidRollback
+}
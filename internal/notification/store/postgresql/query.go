@@ -0,0 +1,49 @@
+package postgresql
+
+const (
+	queryGetNotification = `
+		SELECT
+			id,
+			user_id,
+			event,
+			subject,
+			body,
+			data,
+			status,
+			sent_channels,
+			attempts,
+			next_attempt_time,
+			last_error,
+			create_time,
+			send_time,
+			read_time
+		FROM
+			notification
+		%s
+	`
+
+	queryReadNotification = `
+		UPDATE
+			notification
+		SET
+			read_time = COALESCE(read_time, :read_time)
+		WHERE
+			id = :id
+		AND
+			user_id = :user_id
+	`
+
+	queryUpdateNotificationDelivery = `
+		UPDATE
+			notification
+		SET
+			status = :status,
+			sent_channels = :sent_channels,
+			attempts = :attempts,
+			next_attempt_time = :next_attempt_time,
+			last_error = :last_error,
+			send_time = :send_time
+		WHERE
+			id = :id
+	`
+)
@@ -31,7 +31,152 @@ var (
 	// invalid.
 	ErrInvalidProofPaymentURL = errors.New("invalid proof payment url")
 
+	// ErrInvalidProofPaymentMediaID is returned when the given
+	// proof payment media ID is invalid or not owned by the
+	// payment user.
+	ErrInvalidProofPaymentMediaID = errors.New("invalid proof payment media id")
+
 	// ErrInvalidPaymentDate is returned when the given payment date is
 	// invalid.
 	ErrInvalidPaymentDate = errors.New("invalid payment date")
+
+	// ErrVersionConflict is returned when updating a payment
+	// with an outdated version.
+	ErrVersionConflict = errors.New("version conflict")
+
+	// ErrInvalidProductType is returned when the given product
+	// type is invalid.
+	ErrInvalidProductType = errors.New("invalid product type")
+
+	// ErrInvalidProductID is returned when the given product ID
+	// is invalid, or the product can not be purchased.
+	ErrInvalidProductID = errors.New("invalid product id")
+
+	// ErrInvalidContentID is returned when the given content ID
+	// is invalid or not owned by the payment user.
+	ErrInvalidContentID = errors.New("invalid content id")
+
+	// ErrAmountMismatch is returned when the given payment
+	// amount does not match the price of the product.
+	ErrAmountMismatch = errors.New("amount mismatch")
+
+	// ErrInvalidPlanID is returned when the given plan ID is
+	// invalid.
+	ErrInvalidPlanID = errors.New("invalid plan id")
+
+	// ErrInvalidPlanName is returned when the given plan name
+	// is invalid.
+	ErrInvalidPlanName = errors.New("invalid plan name")
+
+	// ErrInvalidPlanPrice is returned when the given plan price
+	// or currency is invalid.
+	ErrInvalidPlanPrice = errors.New("invalid plan price")
+
+	// ErrInvalidPlanInterval is returned when the given plan
+	// interval is invalid.
+	ErrInvalidPlanInterval = errors.New("invalid plan interval")
+
+	// ErrInvalidPlanQuota is returned when the given plan quota
+	// is invalid.
+	ErrInvalidPlanQuota = errors.New("invalid plan quota")
+
+	// ErrInvalidSubscriptionID is returned when the given
+	// subscription ID is invalid.
+	ErrInvalidSubscriptionID = errors.New("invalid subscription id")
+
+	// ErrSubscriptionEnded is returned when canceling a
+	// subscription that has already ended.
+	ErrSubscriptionEnded = errors.New("subscription ended")
+
+	// ErrInvalidVoucherID is returned when the given voucher
+	// ID is invalid.
+	ErrInvalidVoucherID = errors.New("invalid voucher id")
+
+	// ErrInvalidVoucherCode is returned when the given voucher
+	// code is invalid, or there is no voucher with the code.
+	ErrInvalidVoucherCode = errors.New("invalid voucher code")
+
+	// ErrInvalidVoucherDiscount is returned when the given
+	// voucher discount type, value or currency is invalid.
+	ErrInvalidVoucherDiscount = errors.New("invalid voucher discount")
+
+	// ErrInvalidVoucherPeriod is returned when the given
+	// voucher validity period is invalid.
+	ErrInvalidVoucherPeriod = errors.New("invalid voucher period")
+
+	// ErrInvalidVoucherLimit is returned when the given
+	// voucher redemption limit is invalid.
+	ErrInvalidVoucherLimit = errors.New("invalid voucher limit")
+
+	// ErrVoucherAlreadyExist is returned when there is another
+	// voucher with the given code.
+	ErrVoucherAlreadyExist = errors.New("voucher already exist")
+
+	// ErrVoucherNotApplicable is returned when the voucher is
+	// not valid at the moment, or does not apply to the
+	// purchased product.
+	ErrVoucherNotApplicable = errors.New("voucher not applicable")
+
+	// ErrVoucherExhausted is returned when the voucher has
+	// reached its redemption limit, in total or for the user.
+	ErrVoucherExhausted = errors.New("voucher exhausted")
+
+	// ErrInvoiceNotAvailable is returned when getting the
+	// invoice of a payment that is not approved.
+	ErrInvoiceNotAvailable = errors.New("invoice not available")
+
+	// ErrInvoiceAlreadyExist is returned when issuing an
+	// invoice for a payment that already has one.
+	ErrInvoiceAlreadyExist = errors.New("invoice already exist")
+
+	// ErrInvalidRefundType is returned when the given refund
+	// type is invalid.
+	ErrInvalidRefundType = errors.New("invalid refund type")
+
+	// ErrInvalidRefundAmount is returned when the given refund
+	// amount is not positive, is not in the payment currency,
+	// or exceeds the amount left to refund.
+	ErrInvalidRefundAmount = errors.New("invalid refund amount")
+
+	// ErrInvalidRefundReason is returned when the given refund
+	// reason is empty.
+	ErrInvalidRefundReason = errors.New("invalid refund reason")
+
+	// ErrRefundDeclined is returned by a gateway declining a
+	// refund, the refund is failed.
+	ErrRefundDeclined = errors.New("refund declined")
+
+	// ErrPaymentNotRefundable is returned when refunding a
+	// payment that is not approved, or when changing the
+	// status of a refunded payment.
+	ErrPaymentNotRefundable = errors.New("payment not refundable")
+
+	// ErrPaymentAlreadyApproved is returned when changing the
+	// status of an approved payment, it is refunded instead.
+	ErrPaymentAlreadyApproved = errors.New("payment already approved")
+
+	// ErrPaymentNotPending is returned when claiming or
+	// reviewing a payment that is not pending.
+	ErrPaymentNotPending = errors.New("payment not pending")
+
+	// ErrPaymentAlreadyClaimed is returned when the payment is
+	// claimed by another administrator.
+	ErrPaymentAlreadyClaimed = errors.New("payment already claimed")
+
+	// ErrPaymentNotClaimed is returned when releasing a
+	// payment that is not claimed by the caller.
+	ErrPaymentNotClaimed = errors.New("payment not claimed")
+
+	// ErrInvalidReview is returned when the given review has
+	// no payments, too many payments, or a status other than
+	// done and rejected.
+	ErrInvalidReview = errors.New("invalid review")
+
+	// ErrInvalidReviewReason is returned when rejecting
+	// payments without a reason.
+	ErrInvalidReviewReason = errors.New("invalid review reason")
+
+	// ErrForbidden is returned when the caller is neither the
+	// payment user nor an administrator.
+	ErrForbidden = errors.New("forbidden")
 )
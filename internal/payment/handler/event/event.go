@@ -0,0 +1,54 @@
+package event
+
+import (
+	"context"
+	"hbdtoyou/internal/payment"
+	eventlib "hbdtoyou/pkg/event"
+)
+
+// Handler contains payment event handlers.
+type Handler struct {
+	payment payment.Service
+}
+
+// New creates a new Handler and subscribes its handlers to
+// the given subscriber.
+func New(paymentSvc payment.Service, subscriber eventlib.Subscriber) (*Handler, error) {
+	h := &Handler{
+		payment: paymentSvc,
+	}
+
+	// the type of a user follows the subscriptions affected
+	// by their payments
+	eventTypes := []string{
+		payment.EventApproved,
+		payment.EventUnapproved,
+		payment.EventRejected,
+		payment.EventRefunded,
+	}
+
+	for _, eventType := range eventTypes {
+		err := subscriber.Subscribe(eventType, "payment.sync_user_type", h.syncUserType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return h, nil
+}
+
+// syncUserType updates the type of the user of a payment for
+// a plan by their subscriptions.
+func (h *Handler) syncUserType(ctx context.Context, e eventlib.Event) error {
+	var p payment.PaymentEvent
+	err := e.Decode(&p)
+	if err != nil {
+		return err
+	}
+
+	if p.ProductType != payment.ProductTypePlan.String() {
+		return nil
+	}
+
+	return h.payment.SyncUserType(ctx, p.UserID)
+}
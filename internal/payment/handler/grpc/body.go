@@ -0,0 +1,97 @@
+package grpc
+
+import (
+	"hbdtoyou/internal/payment"
+	"hbdtoyou/pkg/money"
+	paymentv1 "hbdtoyou/pkg/pb/payment/v1"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func formatPayment(p payment.Payment) *paymentv1.Payment {
+	return &paymentv1.Payment{
+		Id:                  p.ID,
+		UserId:              p.UserID,
+		ProductType:         paymentv1.PaymentProductType(p.ProductType),
+		ProductId:           p.ProductID,
+		ContentId:           p.ContentID,
+		Amount:              formatMoney(p.Amount),
+		Quota:               int32(p.Quota),
+		SubscriptionId:      p.SubscriptionID,
+		VoucherCode:         p.VoucherCode,
+		Discount:            formatMoney(p.Discount),
+		RefundedAmount:      formatMoney(p.RefundedAmount),
+		ReviewReason:        p.ReviewReason,
+		ProofPaymentUrl:     p.GetProofPaymentURL(),
+		ProofPaymentMediaId: p.ProofPaymentMediaID,
+		Date:                formatTime(p.Date),
+		Status:              paymentv1.PaymentStatus(p.Status),
+		Version:             p.Version,
+		CreateTime:          formatTime(p.CreateTime),
+		UpdateTime:          formatTime(p.UpdateTime),
+	}
+}
+
+func parseCreatePaymentRequest(req *paymentv1.CreatePaymentRequest, out *payment.Payment) {
+	out.ProductType = payment.ProductType(req.GetProductType())
+	out.ProductID = req.GetProductId()
+	out.ContentID = req.GetContentId()
+
+	// the amount is charged by the product price, the given
+	// one is checked against it
+	out.Amount = parseMoney(req.GetAmount())
+
+	out.VoucherCode = req.GetVoucherCode()
+	out.ProofPaymentURL = req.GetProofPaymentUrl()
+	out.ProofPaymentMediaID = req.GetProofPaymentMediaId()
+
+	if req.GetDate() != nil {
+		out.Date = req.GetDate().AsTime()
+	}
+}
+
+func formatBundle(b payment.Bundle) *paymentv1.Bundle {
+	return &paymentv1.Bundle{
+		Id:    b.ID,
+		Name:  b.Name,
+		Quota: int32(b.Quota),
+		Price: formatMoney(b.Price),
+	}
+}
+
+func formatPlan(p payment.Plan) *paymentv1.Plan {
+	return &paymentv1.Plan{
+		Id:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       formatMoney(p.Price),
+		Interval:    paymentv1.PlanInterval(p.Interval),
+		Quota:       int32(p.Quota),
+		Active:      p.Active,
+		Version:     p.Version,
+	}
+}
+
+func formatMoney(m money.Money) *paymentv1.Money {
+	return &paymentv1.Money{
+		Amount:   m.Amount,
+		Currency: m.Currency,
+	}
+}
+
+func parseMoney(m *paymentv1.Money) money.Money {
+	return money.Money{
+		Amount:   m.GetAmount(),
+		Currency: m.GetCurrency(),
+	}
+}
+
+// formatTime returns the given time as a timestamp, or nil
+// for the zero time.
+func formatTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,134 @@
+package grpc
+
+import (
+	"context"
+	"hbdtoyou/internal/payment"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Followings are the known errors from payment gRPC handlers.
+var (
+	// errInternalServer is returned when there is an
+	// unexpected error encountered when processing a request.
+	errInternalServer = status.Error(codes.Internal, "INTERNAL_SERVER_ERROR")
+
+	// errDataNotFound is returned when the desired data is
+	// not found.
+	errDataNotFound = status.Error(codes.NotFound, "DATA_NOT_FOUND")
+
+	// errForbidden is returned when the caller is not allowed
+	// to access the payment.
+	errForbidden = status.Error(codes.PermissionDenied, "FORBIDDEN")
+
+	// errInvalidPaymentID is returned when the given payment
+	// ID is invalid.
+	errInvalidPaymentID = status.Error(codes.InvalidArgument, "INVALID_PAYMENT_ID")
+
+	// errInvalidUserID is returned when the given user ID is
+	// invalid.
+	errInvalidUserID = status.Error(codes.InvalidArgument, "INVALID_USER_ID")
+
+	// errInvalidPaymentDate is returned when the given payment
+	// date is invalid.
+	errInvalidPaymentDate = status.Error(codes.InvalidArgument, "INVALID_PAYMENT_DATE")
+
+	// errInvalidProofPaymentURL is returned when the given
+	// proof payment URL is invalid.
+	errInvalidProofPaymentURL = status.Error(codes.InvalidArgument, "INVALID_PROOF_PAYMENT_URL")
+
+	// errInvalidProofPaymentMediaID is returned when the given
+	// proof payment media ID is invalid.
+	errInvalidProofPaymentMediaID = status.Error(codes.InvalidArgument, "INVALID_PROOF_PAYMENT_MEDIA_ID")
+
+	// errInvalidPaymentStatus is returned when the given
+	// payment status is invalid.
+	errInvalidPaymentStatus = status.Error(codes.InvalidArgument, "INVALID_PAYMENT_STATUS")
+
+	// errInvalidProductType is returned when the given product
+	// type is invalid.
+	errInvalidProductType = status.Error(codes.InvalidArgument, "INVALID_PRODUCT_TYPE")
+
+	// errInvalidProductID is returned when the given product
+	// ID is invalid.
+	errInvalidProductID = status.Error(codes.InvalidArgument, "INVALID_PRODUCT_ID")
+
+	// errInvalidContentID is returned when the given content
+	// ID is invalid.
+	errInvalidContentID = status.Error(codes.InvalidArgument, "INVALID_CONTENT_ID")
+
+	// errInvalidAmount is returned when the given amount is
+	// invalid.
+	errInvalidAmount = status.Error(codes.InvalidArgument, "INVALID_AMOUNT")
+
+	// errAmountMismatch is returned when the given amount does
+	// not match the price of the product.
+	errAmountMismatch = status.Error(codes.FailedPrecondition, "AMOUNT_MISMATCH")
+
+	// errInvalidPlanID is returned when the given plan ID is
+	// invalid.
+	errInvalidPlanID = status.Error(codes.InvalidArgument, "INVALID_PLAN_ID")
+
+	// errInvalidVoucherCode is returned when the given voucher
+	// code is invalid.
+	errInvalidVoucherCode = status.Error(codes.InvalidArgument, "INVALID_VOUCHER_CODE")
+
+	// errVoucherNotApplicable is returned when the voucher can
+	// not be applied to the payment.
+	errVoucherNotApplicable = status.Error(codes.FailedPrecondition, "VOUCHER_NOT_APPLICABLE")
+
+	// errVoucherExhausted is returned when the voucher has no
+	// redemption left.
+	errVoucherExhausted = status.Error(codes.ResourceExhausted, "VOUCHER_EXHAUSTED")
+
+	// errRequestTimeout is returned when processing time has
+	// reached the timeout limit.
+	errRequestTimeout = status.Error(codes.DeadlineExceeded, "REQUEST_TIMEOUT")
+)
+
+var (
+	// mapGRPCError maps service error into gRPC error with
+	// the status code matching the error.
+	//
+	// Internal server error-related should not be mapped here,
+	// and the handler should just return `errInternalServer`
+	// as the error instead
+	mapGRPCError = map[error]error{
+		payment.ErrForbidden:                  errForbidden,
+		payment.ErrInvalidPaymentID:           errInvalidPaymentID,
+		payment.ErrInvalidUserID:              errInvalidUserID,
+		payment.ErrDataNotFound:               errDataNotFound,
+		payment.ErrInvalidPaymentDate:         errInvalidPaymentDate,
+		payment.ErrInvalidProofPaymentURL:     errInvalidProofPaymentURL,
+		payment.ErrInvalidProofPaymentMediaID: errInvalidProofPaymentMediaID,
+		payment.ErrInvalidPaymentStatus:       errInvalidPaymentStatus,
+		payment.ErrInvalidProductType:         errInvalidProductType,
+		payment.ErrInvalidProductID:           errInvalidProductID,
+		payment.ErrInvalidContentID:           errInvalidContentID,
+		payment.ErrInvalidAmount:              errInvalidAmount,
+		payment.ErrAmountMismatch:             errAmountMismatch,
+		payment.ErrInvalidPlanID:              errInvalidPlanID,
+
+		payment.ErrInvalidVoucherCode:   errInvalidVoucherCode,
+		payment.ErrVoucherNotApplicable: errVoucherNotApplicable,
+		payment.ErrVoucherExhausted:     errVoucherExhausted,
+	}
+)
+
+// parseError returns the gRPC error of the given error
+// returned by the payment service. Unknown errors are logged
+// and returned as `errInternalServer`.
+func parseError(ctx context.Context, err error, name string) error {
+	if v, ok := mapGRPCError[err]; ok {
+		return v
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return errRequestTimeout
+	}
+
+	log.Printf("[Payment gRPC][%s] Internal error. Err: %s\n", name, err.Error())
+	return errInternalServer
+}
@@ -0,0 +1,113 @@
+package grpc
+
+import (
+	"context"
+	"hbdtoyou/internal/payment"
+	contextlib "hbdtoyou/pkg/context"
+	paymentv1 "hbdtoyou/pkg/pb/payment/v1"
+)
+
+// CreatePayment implements paymentv1.PaymentServiceServer.
+func (h *Handler) CreatePayment(ctx context.Context, req *paymentv1.CreatePaymentRequest) (*paymentv1.CreatePaymentResponse, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeCreatePayment].Timeout)
+	defer cancel()
+
+	// the payment is made by the caller
+	userID, _ := contextlib.GetUserID(ctx)
+
+	reqPayment := payment.Payment{
+		UserID: userID,
+		Status: payment.StatusPending,
+	}
+	parseCreatePaymentRequest(req, &reqPayment)
+
+	paymentID, err := h.payment.CreatePayment(ctx, reqPayment)
+	if err != nil {
+		return nil, parseError(ctx, err, "CreatePayment")
+	}
+
+	return &paymentv1.CreatePaymentResponse{
+		Id: paymentID,
+	}, nil
+}
+
+// GetPayment implements paymentv1.PaymentServiceServer.
+func (h *Handler) GetPayment(ctx context.Context, req *paymentv1.GetPaymentRequest) (*paymentv1.Payment, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeGetPayment].Timeout)
+	defer cancel()
+
+	p, err := h.payment.GetPaymentByID(ctx, req.GetId())
+	if err != nil {
+		return nil, parseError(ctx, err, "GetPayment")
+	}
+
+	return formatPayment(p), nil
+}
+
+// ListPayments implements paymentv1.PaymentServiceServer.
+func (h *Handler) ListPayments(ctx context.Context, req *paymentv1.ListPaymentsRequest) (*paymentv1.ListPaymentsResponse, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeListPayments].Timeout)
+	defer cancel()
+
+	payments, err := h.payment.GetPayments(ctx, payment.GetPaymentsFilter{
+		UserID: req.GetUserId(),
+		Status: payment.Status(req.GetStatus()),
+	})
+	if err != nil {
+		return nil, parseError(ctx, err, "ListPayments")
+	}
+
+	res := &paymentv1.ListPaymentsResponse{
+		Payments: make([]*paymentv1.Payment, 0, len(payments)),
+	}
+	for _, p := range payments {
+		res.Payments = append(res.Payments, formatPayment(p))
+	}
+
+	return res, nil
+}
+
+// ListBundles implements paymentv1.PaymentServiceServer.
+func (h *Handler) ListBundles(ctx context.Context, req *paymentv1.ListBundlesRequest) (*paymentv1.ListBundlesResponse, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeListBundles].Timeout)
+	defer cancel()
+
+	bundles, err := h.payment.GetBundles(ctx)
+	if err != nil {
+		return nil, parseError(ctx, err, "ListBundles")
+	}
+
+	res := &paymentv1.ListBundlesResponse{
+		Bundles: make([]*paymentv1.Bundle, 0, len(bundles)),
+	}
+	for _, b := range bundles {
+		res.Bundles = append(res.Bundles, formatBundle(b))
+	}
+
+	return res, nil
+}
+
+// ListPlans implements paymentv1.PaymentServiceServer.
+func (h *Handler) ListPlans(ctx context.Context, req *paymentv1.ListPlansRequest) (*paymentv1.ListPlansResponse, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeListPlans].Timeout)
+	defer cancel()
+
+	plans, err := h.payment.GetPlans(ctx)
+	if err != nil {
+		return nil, parseError(ctx, err, "ListPlans")
+	}
+
+	res := &paymentv1.ListPlansResponse{
+		Plans: make([]*paymentv1.Plan, 0, len(plans)),
+	}
+	for _, p := range plans {
+		res.Plans = append(res.Plans, formatPlan(p))
+	}
+
+	return res, nil
+}
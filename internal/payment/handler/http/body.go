@@ -2,6 +2,7 @@ package http
 
 import (
 	"hbdtoyou/internal/payment"
+	"net/http"
 	"time"
 )
 
@@ -10,42 +11,79 @@ import (
 var timeFormat = "02/01/2006 3:04 PM -07:00"
 
 type paymentHTTP struct {
-	ID              *string `json:"id"`
-	UserID          *string `json:"user_id"`
-	UserName        *string `json:"user_name"`
-	UserType        *string `json:"user_type"`
-	UserQuota       *int    `json:"user_quota"`
-	TemplateID      *string `json:"template_id"`
-	TemplateName    *string `json:"template_name"`
-	TemplateLabel   *string `json:"template_label"`
-	ContentID       *string `json:"content_id"`
-	Amount          *int    `json:"amount"`
-	ProofPaymentURL *string `json:"proof_payment_url"`
-	Date            *string `json:"date"`
-	Status          *string `json:"status"`
+	ID                  *string `json:"id"`
+	UserID              *string `json:"user_id"`
+	UserName            *string `json:"user_name"`
+	UserType            *string `json:"user_type"`
+	UserQuota           *int    `json:"user_quota"`
+	TemplateID          *string `json:"template_id"`
+	TemplateName        *string `json:"template_name"`
+	TemplateLabel       *string `json:"template_label"`
+	ProductType         *string `json:"product_type"`
+	ProductID           *string `json:"product_id"`
+	ContentID           *string `json:"content_id"`
+	Amount              *int64  `json:"amount"`
+	Currency            *string `json:"currency"`
+	AmountDisplay       *string `json:"amount_display"`
+	Quota               *int    `json:"quota"`
+	SubscriptionID      *string `json:"subscription_id"`
+	VoucherCode         *string `json:"voucher_code"`
+	Discount            *int64  `json:"discount"`
+	DiscountDisplay     *string `json:"discount_display"`
+	RefundedAmount      *int64  `json:"refunded_amount"`
+	RefundedDisplay     *string `json:"refunded_amount_display"`
+	ReviewerID          *string `json:"reviewer_id"`
+	ClaimTime           *string `json:"claim_time"`
+	ReviewReason        *string `json:"review_reason"`
+	ProofPaymentURL     *string `json:"proof_payment_url"`
+	ProofPaymentMediaID *string `json:"proof_payment_media_id"`
+	Date                *string `json:"date"`
+	Status              *string `json:"status"`
+	Version             *int64  `json:"version"`
 }
 
 func formatPayment(p payment.Payment) paymentHTTP {
 	status := p.Status.String()
 	userType := p.UserType.String()
 	templateLabel := p.TemplateLabel.String()
+	productType := p.ProductType.String()
+	proofPaymentURL := p.GetProofPaymentURL()
 
 	date := p.Date.Format(timeFormat)
+	amountDisplay := p.Amount.String()
+	discountDisplay := p.Discount.String()
+	refundedDisplay := p.RefundedAmount.String()
 
 	res := paymentHTTP{
-		ID:              &p.ID,
-		UserID:          &p.UserID,
-		UserName:        &p.UserName,
-		UserType:        &userType,
-		UserQuota:       &p.UserQuota,
-		TemplateID:      &p.TemplateID,
-		TemplateName:    &p.TemplateName,
-		TemplateLabel:   &templateLabel,
-		ContentID:       &p.ContentID,
-		Amount:          &p.Amount,
-		ProofPaymentURL: &p.ProofPaymentURL,
-		Date:            &date,
-		Status:          &status,
+		ID:                  &p.ID,
+		UserID:              &p.UserID,
+		UserName:            &p.UserName,
+		UserType:            &userType,
+		UserQuota:           &p.UserQuota,
+		TemplateID:          &p.TemplateID,
+		TemplateName:        &p.TemplateName,
+		TemplateLabel:       &templateLabel,
+		ProductType:         &productType,
+		ProductID:           &p.ProductID,
+		ContentID:           &p.ContentID,
+		Amount:              &p.Amount.Amount,
+		Currency:            &p.Amount.Currency,
+		AmountDisplay:       &amountDisplay,
+		Quota:               &p.Quota,
+		SubscriptionID:      &p.SubscriptionID,
+		VoucherCode:         &p.VoucherCode,
+		Discount:            &p.Discount.Amount,
+		DiscountDisplay:     &discountDisplay,
+		RefundedAmount:      &p.RefundedAmount.Amount,
+		RefundedDisplay:     &refundedDisplay,
+		ReviewerID:          &p.ReviewerID,
+		ClaimTime:           formatTime(p.ClaimTime),
+		ReviewReason:        &p.ReviewReason,
+		ProofPaymentURL:     &proofPaymentURL,
+		ProofPaymentMediaID: &p.ProofPaymentMediaID,
+		Date:                &date,
+		Status:              &status,
+		Version:             &p.Version,
 	}
 
 	return res
@@ -60,18 +98,48 @@ func (p paymentHTTP) parsePayment(out *payment.Payment) error {
 		out.UserID = *p.UserID
 	}
 
+	if p.ProductType != nil {
+		productType, err := parseProductType(*p.ProductType)
+		if err != nil {
+			return err
+		}
+		out.ProductType = productType
+	}
+
+	if p.ProductID != nil {
+		out.ProductID = *p.ProductID
+	}
+
 	if p.ContentID != nil {
 		out.ContentID = *p.ContentID
 	}
 
+	// the amount is charged by the product price, the given
+	// one is checked against it
 	if p.Amount != nil {
-		out.Amount = *p.Amount
+		out.Amount.Amount = *p.Amount
+	}
+
+	if p.Currency != nil {
+		out.Amount.Currency = *p.Currency
+	}
+
+	if p.VoucherCode != nil {
+		out.VoucherCode = *p.VoucherCode
 	}
 
 	if p.ProofPaymentURL != nil {
 		out.ProofPaymentURL = *p.ProofPaymentURL
 	}
 
+	if p.ReviewReason != nil {
+		out.ReviewReason = *p.ReviewReason
+	}
+
+	if p.ProofPaymentMediaID != nil {
+		out.ProofPaymentMediaID = *p.ProofPaymentMediaID
+	}
+
 	if p.Date != nil && *p.Date != "" {
 		endTime, err := time.Parse(timeFormat, *p.Date)
 		if err != nil {
@@ -99,7 +167,520 @@ func parsePaymentStatus(req string) (payment.Status, error) {
 		return payment.StatusPending, nil
 	case payment.StatusRejected.String():
 		return payment.StatusRejected, nil
+	case payment.StatusRefunded.String():
+		return payment.StatusRefunded, nil
 	}
 
 	return payment.StatusUnknown, errInvalidPaymentStatus
 }
+
+func parseProductType(req string) (payment.ProductType, error) {
+	for productType, name := range payment.ProductTypeName {
+		if req == name {
+			return productType, nil
+		}
+	}
+
+	return payment.ProductTypeUnknown, errInvalidProductType
+}
+
+type bundleHTTP struct {
+	ID           *string `json:"id"`
+	Name         *string `json:"name"`
+	Quota        *int    `json:"quota"`
+	Price        *int64  `json:"price"`
+	Currency     *string `json:"currency"`
+	PriceDisplay *string `json:"price_display"`
+}
+
+func formatBundle(b payment.Bundle) bundleHTTP {
+	priceDisplay := b.Price.String()
+
+	return bundleHTTP{
+		ID:           &b.ID,
+		Name:         &b.Name,
+		Quota:        &b.Quota,
+		Price:        &b.Price.Amount,
+		Currency:     &b.Price.Currency,
+		PriceDisplay: &priceDisplay,
+	}
+}
+
+func parseGetPaymentsQuery(r *http.Request) (payment.GetPaymentsFilter, error) {
+	query := r.URL.Query()
+
+	res := payment.GetPaymentsFilter{
+		UserID: query.Get("user_id"),
+	}
+
+	statusParams := query.Get("status")
+	if statusParams != "" {
+		paymentStatus, err := parsePaymentStatus(statusParams)
+		if err != nil {
+			return res, err
+		}
+
+		res.Status = paymentStatus
+	}
+	return res, nil
+}
+
+type planHTTP struct {
+	ID           *string `json:"id"`
+	Name         *string `json:"name"`
+	Description  *string `json:"description"`
+	Price        *int64  `json:"price"`
+	Currency     *string `json:"currency"`
+	PriceDisplay *string `json:"price_display"`
+	Interval     *string `json:"interval"`
+	Quota        *int    `json:"quota"`
+	Active       *bool   `json:"active"`
+	Version      *int64  `json:"version"`
+}
+
+func formatPlan(p payment.Plan) planHTTP {
+	interval := p.Interval.String()
+	priceDisplay := p.Price.String()
+
+	return planHTTP{
+		ID:           &p.ID,
+		Name:         &p.Name,
+		Description:  &p.Description,
+		Price:        &p.Price.Amount,
+		Currency:     &p.Price.Currency,
+		PriceDisplay: &priceDisplay,
+		Interval:     &interval,
+		Quota:        &p.Quota,
+		Active:       &p.Active,
+		Version:      &p.Version,
+	}
+}
+
+func (p planHTTP) parsePlan(out *payment.Plan) error {
+	if p.Name != nil {
+		out.Name = *p.Name
+	}
+
+	if p.Description != nil {
+		out.Description = *p.Description
+	}
+
+	if p.Price != nil {
+		out.Price.Amount = *p.Price
+	}
+
+	if p.Currency != nil {
+		out.Price.Currency = *p.Currency
+	}
+
+	if p.Interval != nil {
+		interval, err := parseInterval(*p.Interval)
+		if err != nil {
+			return err
+		}
+		out.Interval = interval
+	}
+
+	if p.Quota != nil {
+		out.Quota = *p.Quota
+	}
+
+	if p.Active != nil {
+		out.Active = *p.Active
+	}
+
+	return nil
+}
+
+func parseInterval(req string) (payment.Interval, error) {
+	for interval, name := range payment.IntervalName {
+		if req == name {
+			return interval, nil
+		}
+	}
+
+	return payment.IntervalUnknown, errInvalidPlanInterval
+}
+
+type subscriptionHTTP struct {
+	ID           *string `json:"id"`
+	UserID       *string `json:"user_id"`
+	PlanID       *string `json:"plan_id"`
+	PlanName     *string `json:"plan_name"`
+	PlanInterval *string `json:"plan_interval"`
+	Status       *string `json:"status"`
+	AutoRenew    *bool   `json:"auto_renew"`
+	StartTime    *string `json:"start_time"`
+	EndTime      *string `json:"end_time"`
+	RenewTime    *string `json:"renew_time"`
+	Version      *int64  `json:"version"`
+}
+
+func formatSubscription(s payment.Subscription) subscriptionHTTP {
+	planInterval := s.PlanInterval.String()
+	status := s.Status.String()
+
+	return subscriptionHTTP{
+		ID:           &s.ID,
+		UserID:       &s.UserID,
+		PlanID:       &s.PlanID,
+		PlanName:     &s.PlanName,
+		PlanInterval: &planInterval,
+		Status:       &status,
+		AutoRenew:    &s.AutoRenew,
+		StartTime:    formatTime(s.StartTime),
+		EndTime:      formatTime(s.EndTime),
+		RenewTime:    formatTime(s.RenewTime),
+		Version:      &s.Version,
+	}
+}
+
+// formatTime returns the given time formatted in timeFormat,
+// or nil for a zero time.
+func formatTime(t time.Time) *string {
+	if t.IsZero() {
+		return nil
+	}
+
+	formatted := t.Format(timeFormat)
+	return &formatted
+}
+
+func parseSubscriptionStatus(req string) (payment.SubscriptionStatus, error) {
+	for status, name := range payment.SubscriptionStatusName {
+		if req == name {
+			return status, nil
+		}
+	}
+
+	return payment.SubscriptionStatusUnknown, errInvalidSubscriptionStatus
+}
+
+func parseGetSubscriptionsQuery(r *http.Request) (payment.GetSubscriptionsFilter, error) {
+	query := r.URL.Query()
+
+	res := payment.GetSubscriptionsFilter{
+		UserID: query.Get("user_id"),
+		PlanID: query.Get("plan_id"),
+	}
+
+	statusParams := query.Get("status")
+	if statusParams != "" {
+		status, err := parseSubscriptionStatus(statusParams)
+		if err != nil {
+			return res, err
+		}
+
+		res.Statuses = []payment.SubscriptionStatus{status}
+	}
+
+	return res, nil
+}
+
+type voucherHTTP struct {
+	ID                    *string  `json:"id"`
+	Code                  *string  `json:"code"`
+	DiscountType          *string  `json:"discount_type"`
+	DiscountValue         *int     `json:"discount_value"`
+	Currency              *string  `json:"currency"`
+	StartTime             *string  `json:"start_time"`
+	EndTime               *string  `json:"end_time"`
+	MaxRedemptions        *int     `json:"max_redemptions"`
+	MaxRedemptionsPerUser *int     `json:"max_redemptions_per_user"`
+	TemplateIDs           []string `json:"template_ids"`
+	PlanIDs               []string `json:"plan_ids"`
+	Active                *bool    `json:"active"`
+	Redemptions           *int     `json:"redemptions"`
+	Version               *int64   `json:"version"`
+}
+
+func formatVoucher(v payment.Voucher) voucherHTTP {
+	discountType := v.DiscountType.String()
+
+	res := voucherHTTP{
+		ID:                    &v.ID,
+		Code:                  &v.Code,
+		DiscountType:          &discountType,
+		DiscountValue:         &v.DiscountValue,
+		Currency:              &v.Currency,
+		StartTime:             formatTime(v.StartTime),
+		EndTime:               formatTime(v.EndTime),
+		MaxRedemptions:        &v.MaxRedemptions,
+		MaxRedemptionsPerUser: &v.MaxRedemptionsPerUser,
+		TemplateIDs:           v.TemplateIDs,
+		PlanIDs:               v.PlanIDs,
+		Active:                &v.Active,
+		Redemptions:           &v.Redemptions,
+		Version:               &v.Version,
+	}
+
+	if res.TemplateIDs == nil {
+		res.TemplateIDs = []string{}
+	}
+
+	if res.PlanIDs == nil {
+		res.PlanIDs = []string{}
+	}
+
+	return res
+}
+
+func (v voucherHTTP) parseVoucher(out *payment.Voucher) error {
+	if v.Code != nil {
+		out.Code = *v.Code
+	}
+
+	if v.DiscountType != nil {
+		discountType, err := parseDiscountType(*v.DiscountType)
+		if err != nil {
+			return err
+		}
+		out.DiscountType = discountType
+	}
+
+	if v.DiscountValue != nil {
+		out.DiscountValue = *v.DiscountValue
+	}
+
+	if v.Currency != nil {
+		out.Currency = *v.Currency
+	}
+
+	// an empty time removes the bound of the period
+	if v.StartTime != nil {
+		startTime, err := parseTime(*v.StartTime)
+		if err != nil {
+			return errInvalidVoucherPeriod
+		}
+		out.StartTime = startTime
+	}
+
+	if v.EndTime != nil {
+		endTime, err := parseTime(*v.EndTime)
+		if err != nil {
+			return errInvalidVoucherPeriod
+		}
+		out.EndTime = endTime
+	}
+
+	if v.MaxRedemptions != nil {
+		out.MaxRedemptions = *v.MaxRedemptions
+	}
+
+	if v.MaxRedemptionsPerUser != nil {
+		out.MaxRedemptionsPerUser = *v.MaxRedemptionsPerUser
+	}
+
+	if v.TemplateIDs != nil {
+		out.TemplateIDs = v.TemplateIDs
+	}
+
+	if v.PlanIDs != nil {
+		out.PlanIDs = v.PlanIDs
+	}
+
+	if v.Active != nil {
+		out.Active = *v.Active
+	}
+
+	return nil
+}
+
+// parseTime parses the given time formatted in timeFormat, an
+// empty string is parsed as a zero time.
+func parseTime(req string) (time.Time, error) {
+	if req == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(timeFormat, req)
+}
+
+func parseDiscountType(req string) (payment.DiscountType, error) {
+	for discountType, name := range payment.DiscountTypeName {
+		if req == name {
+			return discountType, nil
+		}
+	}
+
+	return payment.DiscountTypeUnknown, errInvalidVoucherDiscount
+}
+
+type invoiceHTTP struct {
+	ID        *string           `json:"id"`
+	PaymentID *string           `json:"payment_id"`
+	UserID    *string           `json:"user_id"`
+	Number    *string           `json:"number"`
+	UserName  *string           `json:"user_name"`
+	UserEmail *string           `json:"user_email"`
+	Items     []invoiceItemHTTP `json:"items"`
+	Currency  *string           `json:"currency"`
+	Subtotal  *int64            `json:"subtotal"`
+	Discount  *int64            `json:"discount"`
+	Total     *int64            `json:"total"`
+
+	TotalDisplay *string `json:"total_display"`
+	IssueTime    *string `json:"issue_time"`
+}
+
+type invoiceItemHTTP struct {
+	Description *string `json:"description"`
+	Quantity    *int    `json:"quantity"`
+	UnitPrice   *int64  `json:"unit_price"`
+	Amount      *int64  `json:"amount"`
+}
+
+func formatInvoice(inv payment.Invoice) invoiceHTTP {
+	totalDisplay := inv.Total.String()
+
+	items := make([]invoiceItemHTTP, 0, len(inv.Items))
+	for i := range inv.Items {
+		item := inv.Items[i]
+		items = append(items, invoiceItemHTTP{
+			Description: &item.Description,
+			Quantity:    &item.Quantity,
+			UnitPrice:   &item.UnitPrice.Amount,
+			Amount:      &item.Amount.Amount,
+		})
+	}
+
+	return invoiceHTTP{
+		ID:           &inv.ID,
+		PaymentID:    &inv.PaymentID,
+		UserID:       &inv.UserID,
+		Number:       &inv.Number,
+		UserName:     &inv.UserName,
+		UserEmail:    &inv.UserEmail,
+		Items:        items,
+		Currency:     &inv.Total.Currency,
+		Subtotal:     &inv.Subtotal.Amount,
+		Discount:     &inv.Discount.Amount,
+		Total:        &inv.Total.Amount,
+		TotalDisplay: &totalDisplay,
+		IssueTime:    formatTime(inv.IssueTime),
+	}
+}
+
+type refundHTTP struct {
+	ID               *string `json:"id"`
+	PaymentID        *string `json:"payment_id"`
+	Type             *string `json:"type"`
+	Amount           *int64  `json:"amount"`
+	Currency         *string `json:"currency"`
+	AmountDisplay    *string `json:"amount_display"`
+	Reason           *string `json:"reason"`
+	ActorID          *string `json:"actor_id"`
+	Status           *string `json:"status"`
+	GatewayReference *string `json:"gateway_reference"`
+	CreateTime       *string `json:"create_time"`
+	UpdateTime       *string `json:"update_time"`
+}
+
+func formatRefund(r payment.Refund) refundHTTP {
+	refundType := r.Type.String()
+	refundStatus := r.Status.String()
+	amountDisplay := r.Amount.String()
+
+	return refundHTTP{
+		ID:               &r.ID,
+		PaymentID:        &r.PaymentID,
+		Type:             &refundType,
+		Amount:           &r.Amount.Amount,
+		Currency:         &r.Amount.Currency,
+		AmountDisplay:    &amountDisplay,
+		Reason:           &r.Reason,
+		ActorID:          &r.ActorID,
+		Status:           &refundStatus,
+		GatewayReference: &r.GatewayReference,
+		CreateTime:       formatTime(r.CreateTime),
+		UpdateTime:       formatTime(r.UpdateTime),
+	}
+}
+
+func (r refundHTTP) parseRefund(out *payment.Refund) error {
+	if r.Type != nil {
+		refundType, err := parseRefundType(*r.Type)
+		if err != nil {
+			return err
+		}
+		out.Type = refundType
+	}
+
+	if r.Amount != nil {
+		out.Amount.Amount = *r.Amount
+	}
+
+	if r.Currency != nil {
+		out.Amount.Currency = *r.Currency
+	}
+
+	if r.Reason != nil {
+		out.Reason = *r.Reason
+	}
+
+	return nil
+}
+
+func parseRefundType(req string) (payment.RefundType, error) {
+	for refundType, name := range payment.RefundTypeName {
+		if req == name {
+			return refundType, nil
+		}
+	}
+
+	return payment.RefundTypeUnknown, errInvalidRefundType
+}
+
+type reviewHTTP struct {
+	PaymentIDs []string `json:"payment_ids"`
+	Status     *string  `json:"status"`
+	Reason     *string  `json:"reason"`
+}
+
+func (r reviewHTTP) parseReview(out *payment.Review) error {
+	out.PaymentIDs = r.PaymentIDs
+
+	if r.Status != nil {
+		status, err := parsePaymentStatus(*r.Status)
+		if err != nil {
+			return err
+		}
+		out.Status = status
+	}
+
+	if r.Reason != nil {
+		out.Reason = *r.Reason
+	}
+
+	return nil
+}
+
+type reviewResultHTTP struct {
+	PaymentID *string `json:"payment_id"`
+	Success   *bool   `json:"success"`
+	Error     *string `json:"error,omitempty"`
+}
+
+// formatReviewResult formats the given review result, its
+// error is formatted as the HTTP error of the payment.
+func formatReviewResult(r payment.ReviewResult, parsedErr error) reviewResultHTTP {
+	success := parsedErr == nil
+
+	res := reviewResultHTTP{
+		PaymentID: &r.PaymentID,
+		Success:   &success,
+	}
+
+	if parsedErr != nil {
+		errMsg := parsedErr.Error()
+		res.Error = &errMsg
+	}
+
+	return res
+}
+
+func parseGetReviewQueueQuery(r *http.Request) payment.ReviewQueueFilter {
+	return payment.ReviewQueueFilter{
+		Claimable: r.URL.Query().Get("claimable") == "true",
+	}
+}
@@ -28,7 +28,6 @@ var (
 	// not found.
 	errDataNotFound = errors.New("DATA_NOT_FOUND")
 
-
 	// errInvalidToken is returned when the given token is
 	// invalid.
 	errInvalidToken = errors.New("INVALID_TOKEN")
@@ -49,14 +48,167 @@ var (
 	// invalid.
 	errInvalidProofPaymentURL = errors.New("INVALID_PROOF_PAYMENT_URL")
 
+	// errInvalidProofPaymentMediaID is returned when the given
+	// proof payment media ID is invalid.
+	errInvalidProofPaymentMediaID = errors.New("INVALID_PROOF_PAYMENT_MEDIA_ID")
+
 	// errInvalidPaymentStatus is returned when the given payment status is
 	// invalid.
 	errInvalidPaymentStatus = errors.New("INVALID_PAYMENT_STATUS")
 
+	// errInvalidProductType is returned when the given product
+	// type is invalid.
+	errInvalidProductType = errors.New("INVALID_PRODUCT_TYPE")
+
+	// errInvalidProductID is returned when the given product ID
+	// is invalid or the product can not be purchased.
+	errInvalidProductID = errors.New("INVALID_PRODUCT_ID")
+
+	// errInvalidContentID is returned when the given content ID
+	// is invalid.
+	errInvalidContentID = errors.New("INVALID_CONTENT_ID")
+
+	// errInvalidAmount is returned when the given amount is
+	// invalid.
+	errInvalidAmount = errors.New("INVALID_AMOUNT")
+
+	// errAmountMismatch is returned when the given amount does
+	// not match the price of the product.
+	errAmountMismatch = errors.New("AMOUNT_MISMATCH")
+
+	// errInvalidPlanID is returned when the given plan ID is
+	// invalid.
+	errInvalidPlanID = errors.New("INVALID_PLAN_ID")
+
+	// errInvalidPlanName is returned when the given plan name
+	// is invalid.
+	errInvalidPlanName = errors.New("INVALID_PLAN_NAME")
+
+	// errInvalidPlanPrice is returned when the given plan price
+	// or currency is invalid.
+	errInvalidPlanPrice = errors.New("INVALID_PLAN_PRICE")
+
+	// errInvalidPlanInterval is returned when the given plan
+	// interval is invalid.
+	errInvalidPlanInterval = errors.New("INVALID_PLAN_INTERVAL")
+
+	// errInvalidPlanQuota is returned when the given plan quota
+	// is invalid.
+	errInvalidPlanQuota = errors.New("INVALID_PLAN_QUOTA")
+
+	// errInvalidSubscriptionID is returned when the given
+	// subscription ID is invalid.
+	errInvalidSubscriptionID = errors.New("INVALID_SUBSCRIPTION_ID")
+
+	// errInvalidSubscriptionStatus is returned when the given
+	// subscription status is invalid.
+	errInvalidSubscriptionStatus = errors.New("INVALID_SUBSCRIPTION_STATUS")
+
+	// errSubscriptionEnded is returned when canceling a
+	// subscription that has already ended.
+	errSubscriptionEnded = errors.New("SUBSCRIPTION_ENDED")
+
+	// errInvalidVoucherID is returned when the given voucher ID
+	// is invalid.
+	errInvalidVoucherID = errors.New("INVALID_VOUCHER_ID")
+
+	// errInvalidVoucherCode is returned when the given voucher
+	// code is invalid.
+	errInvalidVoucherCode = errors.New("INVALID_VOUCHER_CODE")
+
+	// errInvalidVoucherDiscount is returned when the given
+	// voucher discount is invalid.
+	errInvalidVoucherDiscount = errors.New("INVALID_VOUCHER_DISCOUNT")
+
+	// errInvalidVoucherPeriod is returned when the given
+	// voucher validity period is invalid.
+	errInvalidVoucherPeriod = errors.New("INVALID_VOUCHER_PERIOD")
+
+	// errInvalidVoucherLimit is returned when the given voucher
+	// redemption limit is invalid.
+	errInvalidVoucherLimit = errors.New("INVALID_VOUCHER_LIMIT")
+
+	// errVoucherAlreadyExist is returned when there is another
+	// voucher with the given code.
+	errVoucherAlreadyExist = errors.New("VOUCHER_ALREADY_EXIST")
+
+	// errVoucherNotApplicable is returned when the voucher can
+	// not be redeemed for the payment.
+	errVoucherNotApplicable = errors.New("VOUCHER_NOT_APPLICABLE")
+
+	// errVoucherExhausted is returned when the voucher has
+	// reached its redemption limit.
+	errVoucherExhausted = errors.New("VOUCHER_EXHAUSTED")
+
+	// errInvalidRefundType is returned when the given refund
+	// type is invalid.
+	errInvalidRefundType = errors.New("INVALID_REFUND_TYPE")
+
+	// errInvalidRefundAmount is returned when the given refund
+	// amount is invalid or exceeds the amount left to refund.
+	errInvalidRefundAmount = errors.New("INVALID_REFUND_AMOUNT")
+
+	// errInvalidRefundReason is returned when the given refund
+	// reason is empty.
+	errInvalidRefundReason = errors.New("INVALID_REFUND_REASON")
+
+	// errPaymentNotRefundable is returned when refunding a
+	// payment that is not approved, or when changing the
+	// status of a refunded payment.
+	errPaymentNotRefundable = errors.New("PAYMENT_NOT_REFUNDABLE")
+
+	// errPaymentAlreadyApproved is returned when changing the
+	// status of an approved payment, it is refunded instead.
+	errPaymentAlreadyApproved = errors.New("PAYMENT_ALREADY_APPROVED")
+
+	// errRefundDeclined is returned when the gateway of the
+	// payment declined the refund.
+	errRefundDeclined = errors.New("REFUND_DECLINED")
+
+	// errPaymentNotPending is returned when claiming or
+	// reviewing a payment that is not pending.
+	errPaymentNotPending = errors.New("PAYMENT_NOT_PENDING")
+
+	// errPaymentAlreadyClaimed is returned when the payment is
+	// claimed by another administrator.
+	errPaymentAlreadyClaimed = errors.New("PAYMENT_ALREADY_CLAIMED")
+
+	// errPaymentNotClaimed is returned when releasing a
+	// payment that is not claimed by the caller.
+	errPaymentNotClaimed = errors.New("PAYMENT_NOT_CLAIMED")
+
+	// errInvalidReview is returned when the given review is
+	// invalid.
+	errInvalidReview = errors.New("INVALID_REVIEW")
+
+	// errInvalidReviewReason is returned when rejecting
+	// payments without a reason.
+	errInvalidReviewReason = errors.New("INVALID_REVIEW_REASON")
+
+	// errInvoiceNotAvailable is returned when getting the
+	// invoice of a payment that is not approved.
+	errInvoiceNotAvailable = errors.New("INVOICE_NOT_AVAILABLE")
+
 	// errInvalidUsername is returned when the given username
 	// is invalid.
 	errInvalidUsername = errors.New("INVALID_USERNAME")
 
+	// errInvalidIfMatch is returned when the given If-Match
+	// header is not a valid version ETag.
+	errInvalidIfMatch = errors.New("INVALID_IF_MATCH")
+
+	// errPreconditionRequired is returned when updating
+	// without If-Match header.
+	errPreconditionRequired = errors.New("PRECONDITION_REQUIRED")
+
+	// errVersionConflict is returned when the data has been
+	// changed since the given version.
+	errVersionConflict = errors.New("VERSION_CONFLICT")
+
+	// errForbidden is returned when the user is not allowed
+	// to access the requested data.
+	errForbidden = errors.New("FORBIDDEN")
+
 	// errMethodNotAllowed is returned when accessing not
 	// allowed HTTP method.
 	errMethodNotAllowed = errors.New("METHOD_NOT_ALLOWED")
@@ -86,11 +238,51 @@ var (
 	// and the handler should just return `errInternal` as the
 	// error instead
 	mapHTTPError = map[error]error{
-		payment.ErrInvalidPaymentID:       errInvalidPaymentID,
-		payment.ErrInvalidUserID:          errInvalidUserID,
-		payment.ErrDataNotFound:           errDataNotFound,
-		payment.ErrInvalidPaymentDate:     errInvalidPaymentDate,
-		payment.ErrInvalidProofPaymentURL: errInvalidProofPaymentURL,
-		payment.ErrInvalidPaymentStatus:   errInvalidPaymentStatus,
+		payment.ErrVersionConflict:            errVersionConflict,
+		payment.ErrForbidden:                  errForbidden,
+		payment.ErrInvalidPaymentID:           errInvalidPaymentID,
+		payment.ErrInvalidUserID:              errInvalidUserID,
+		payment.ErrDataNotFound:               errDataNotFound,
+		payment.ErrInvalidPaymentDate:         errInvalidPaymentDate,
+		payment.ErrInvalidProofPaymentURL:     errInvalidProofPaymentURL,
+		payment.ErrInvalidProofPaymentMediaID: errInvalidProofPaymentMediaID,
+		payment.ErrInvalidPaymentStatus:       errInvalidPaymentStatus,
+		payment.ErrInvalidProductType:         errInvalidProductType,
+		payment.ErrInvalidProductID:           errInvalidProductID,
+		payment.ErrInvalidContentID:           errInvalidContentID,
+		payment.ErrInvalidAmount:              errInvalidAmount,
+		payment.ErrAmountMismatch:             errAmountMismatch,
+
+		payment.ErrInvalidPlanID:         errInvalidPlanID,
+		payment.ErrInvalidPlanName:       errInvalidPlanName,
+		payment.ErrInvalidPlanPrice:      errInvalidPlanPrice,
+		payment.ErrInvalidPlanInterval:   errInvalidPlanInterval,
+		payment.ErrInvalidPlanQuota:      errInvalidPlanQuota,
+		payment.ErrInvalidSubscriptionID: errInvalidSubscriptionID,
+		payment.ErrSubscriptionEnded:     errSubscriptionEnded,
+
+		payment.ErrInvalidVoucherID:       errInvalidVoucherID,
+		payment.ErrInvalidVoucherCode:     errInvalidVoucherCode,
+		payment.ErrInvalidVoucherDiscount: errInvalidVoucherDiscount,
+		payment.ErrInvalidVoucherPeriod:   errInvalidVoucherPeriod,
+		payment.ErrInvalidVoucherLimit:    errInvalidVoucherLimit,
+		payment.ErrVoucherAlreadyExist:    errVoucherAlreadyExist,
+		payment.ErrVoucherNotApplicable:   errVoucherNotApplicable,
+		payment.ErrVoucherExhausted:       errVoucherExhausted,
+
+		payment.ErrInvoiceNotAvailable: errInvoiceNotAvailable,
+
+		payment.ErrInvalidRefundType:      errInvalidRefundType,
+		payment.ErrInvalidRefundAmount:    errInvalidRefundAmount,
+		payment.ErrInvalidRefundReason:    errInvalidRefundReason,
+		payment.ErrPaymentNotRefundable:   errPaymentNotRefundable,
+		payment.ErrPaymentAlreadyApproved: errPaymentAlreadyApproved,
+		payment.ErrRefundDeclined:         errRefundDeclined,
+
+		payment.ErrPaymentNotPending:     errPaymentNotPending,
+		payment.ErrPaymentAlreadyClaimed: errPaymentAlreadyClaimed,
+		payment.ErrPaymentNotClaimed:     errPaymentNotClaimed,
+		payment.ErrInvalidReview:         errInvalidReview,
+		payment.ErrInvalidReviewReason:   errInvalidReviewReason,
 	}
 )
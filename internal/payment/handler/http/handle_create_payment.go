@@ -95,11 +95,11 @@ func (h *paymentsHandler) handleCreatePayment(w http.ResponseWriter, r *http.Req
 		// TODO: add authorization flow with roles
 
 		// format HTTP request into service object
-		payment := payment.Payment{
+		reqPayment := payment.Payment{
 			UserID: reqUserID,
 			Status: payment.StatusPending,
 		}
-		err = request.parsePayment(&payment)
+		err = request.parsePayment(&reqPayment)
 		if err != nil {
 			statusCode = http.StatusUnauthorized
 			errChan <- err
@@ -107,7 +107,7 @@ func (h *paymentsHandler) handleCreatePayment(w http.ResponseWriter, r *http.Req
 		}
 
 		var paymentID string
-		paymentID, err = h.payment.CreatePayment(ctx, payment)
+		paymentID, err = h.payment.CreatePayment(ctx, reqPayment)
 		if err != nil {
 			// determine error and status code, by default its internal error
 			parsedErr := errInternalServer
@@ -116,6 +116,9 @@ func (h *paymentsHandler) handleCreatePayment(w http.ResponseWriter, r *http.Req
 				parsedErr = v
 				statusCode = http.StatusBadRequest
 			}
+			if err == payment.ErrForbidden {
+				statusCode = http.StatusForbidden
+			}
 
 			// log the actual error if its internal error
 			if statusCode == http.StatusInternalServerError {
@@ -134,6 +137,20 @@ func (h *paymentsHandler) handleCreatePayment(w http.ResponseWriter, r *http.Req
 	case <-ctx.Done():
 		statusCode = http.StatusGatewayTimeout
 		err = errRequestTimeout
+
+		// the payment might still be created, its response is replayed to the
+		// retries of the request
+		httplib.SetLateResponse(r, func() (int, []byte, bool) {
+			select {
+			case <-errChan:
+				return 0, nil, false
+			case paymentID := <-resChan:
+				body, err := json.Marshal(httplib.ResponseEnvelope{
+					Data: paymentID,
+				})
+				return http.StatusOK, body, err == nil
+			}
+		})
 	case err = <-errChan:
 	case paymentID := <-resChan:
 		resBody, err = json.Marshal(httplib.ResponseEnvelope{
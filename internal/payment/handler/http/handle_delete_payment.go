@@ -26,7 +26,7 @@ package http
 // 	defer func() {
 // 		// error
 // 		if err != nil {
-// 			log.Printf("[Payment HTTP][handleDeletePayment] Failed to delete payment by ID. payment ID: %d, Source: %s, Err: %s\n", paymentID, source, err.Error())
+// 			log.Printf("[Payment HTTP][handleDeletePayment] Failed to delete payment by ID. payment ID: %s, Source: %s, Err: %s\n", paymentID, source, err.Error())
 // 			httplib.WriteErrorResponse(w, statusCode, []string{err.Error()})
 // 			return
 // 		}
@@ -0,0 +1,129 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"hbdtoyou/internal/payment"
+	contextlib "hbdtoyou/pkg/context"
+	httplib "hbdtoyou/pkg/http"
+	"log"
+	"net/http"
+	"strings"
+)
+
+func (h *paymentInvoiceHandler) handleGetInvoice(w http.ResponseWriter, r *http.Request, paymentID string) {
+	// add timeout to context
+	timeout := h.scopeSettings[ScopeGetInvoice].Timeout
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	var (
+		err        error           // stores error in this handler
+		source     string          // stores request source
+		resBody    []byte          // stores response body to write
+		statusCode = http.StatusOK // stores response status code
+
+		// stores response decorators, the invoice is written
+		// as PDF unless JSON is accepted
+		decorators = []httplib.ResponseDecorator{httplib.JSONContentTypeDecorator}
+	)
+
+	// write response
+	defer func() {
+		// error
+		if err != nil {
+			log.Printf("[Payment HTTP][handleGetInvoice] Failed to get invoice by payment ID. payment ID: %s, Source: %s, Err: %s\n", paymentID, source, err.Error())
+			httplib.WriteErrorResponse(w, statusCode, []string{err.Error()})
+			return
+		}
+		// success
+		httplib.WriteResponse(w, resBody, statusCode, decorators...)
+	}()
+
+	// prepare channels for main go routine
+	resChan := make(chan payment.Invoice, 1)
+	errChan := make(chan error, 1)
+
+	go func() {
+		// get request source
+		source, err = httplib.GetSourceFromHeader(r)
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errSourceNotProvided
+			return
+		}
+		ctx = contextlib.SetSource(ctx, source)
+
+		// get user ID
+		reqUserID, err := httplib.GetUserIDFromHeader(r)
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errInvalidUserID
+			return
+		}
+		ctx = contextlib.SetUserID(ctx, reqUserID)
+
+		// get token from header
+		token, err := httplib.GetBearerTokenFromHeader(r)
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errInvalidToken
+			return
+		}
+
+		// check access token
+		err = checkAccessToken(ctx, h.auth, token, reqUserID, "handleGetInvoice")
+		if err != nil {
+			statusCode = http.StatusUnauthorized
+			errChan <- err
+			return
+		}
+
+		var result payment.Invoice
+		result, err = h.payment.GetInvoiceByPaymentID(ctx, paymentID)
+		if err != nil {
+			// determine error and status code, by default its internal error
+			parsedErr := errInternalServer
+			statusCode = http.StatusInternalServerError
+			if v, ok := mapHTTPError[err]; ok {
+				parsedErr = v
+				statusCode = http.StatusBadRequest
+			}
+			if err == payment.ErrForbidden {
+				statusCode = http.StatusForbidden
+			}
+
+			// log the actual error if its internal error
+			if statusCode == http.StatusInternalServerError {
+				log.Printf("[Payment HTTP][handleGetInvoice] Internal error from GetInvoiceByPaymentID. Err: %s\n", err.Error())
+			}
+
+			errChan <- parsedErr
+			return
+		}
+
+		resChan <- result
+	}()
+
+	// wait and handle main go routine
+	select {
+	case <-ctx.Done():
+		statusCode = http.StatusGatewayTimeout
+		err = errRequestTimeout
+	case err = <-errChan:
+	case res := <-resChan:
+		// the admin UI reads the invoice as JSON
+		if strings.Contains(r.Header.Get("Accept"), "application/json") {
+			resBody, err = json.Marshal(httplib.ResponseEnvelope{
+				Data: formatInvoice(res),
+			})
+			return
+		}
+
+		resBody, err = renderInvoicePDF(res)
+		decorators = []httplib.ResponseDecorator{
+			httplib.NewContentTypeDecorator("application/pdf"),
+			httplib.NewContentDispositionDecorator("inline", res.Number+".pdf"),
+		}
+	}
+}
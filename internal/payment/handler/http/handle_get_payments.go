@@ -74,8 +74,16 @@ func (h *paymentsHandler) handleGetPayments(w http.ResponseWriter, r *http.Reque
 			return
 		}
 
+		var filter payment.GetPaymentsFilter
+		filter, err = parseGetPaymentsQuery(r)
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- err
+			return
+		}
+
 		var payments []payment.Payment
-		payments, err = h.payment.GetPayments(ctx)
+		payments, err = h.payment.GetPayments(ctx, filter)
 		if err != nil {
 			// determine error and status code, by default its internal error
 			parsedErr := errInternalServer
@@ -84,6 +92,9 @@ func (h *paymentsHandler) handleGetPayments(w http.ResponseWriter, r *http.Reque
 				parsedErr = v
 				statusCode = http.StatusBadRequest
 			}
+			if err == payment.ErrForbidden {
+				statusCode = http.StatusForbidden
+			}
 
 			// log the actual error if its internal error
 			if statusCode == http.StatusInternalServerError {
@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"hbdtoyou/internal/payment"
 	contextlib "hbdtoyou/pkg/context"
 	httplib "hbdtoyou/pkg/http"
 	"io/ioutil"
@@ -21,22 +22,23 @@ func (h *paymentHandler) handleUpdatePayment(w http.ResponseWriter, r *http.Requ
 		source     string          // stores request source
 		resBody    []byte          // stores response body to write
 		statusCode = http.StatusOK // stores response status code
+		resVersion int64           // stores response data version
 	)
 
 	// write response
 	defer func() {
 		// error
 		if err != nil {
-			log.Printf("[Payment HTTP][handleUpdatePayment] Failed to update payment by ID. payment ID: %d, Source: %s, Err: %s\n", paymentID, source, err.Error())
+			log.Printf("[Payment HTTP][handleUpdatePayment] Failed to update payment by ID. payment ID: %s, Source: %s, Err: %s\n", paymentID, source, err.Error())
 			httplib.WriteErrorResponse(w, statusCode, []string{err.Error()})
 			return
 		}
 		// success
-		httplib.WriteResponse(w, resBody, statusCode, httplib.JSONContentTypeDecorator)
+		httplib.WriteResponse(w, resBody, statusCode, httplib.JSONContentTypeDecorator, httplib.NewVersionETagDecorator(resVersion))
 	}()
 
 	// prepare channels for main go routine
-	resChan := make(chan string, 1)
+	resChan := make(chan payment.Payment, 1)
 	errChan := make(chan error, 1)
 
 	go func() {
@@ -66,6 +68,20 @@ func (h *paymentHandler) handleUpdatePayment(w http.ResponseWriter, r *http.Requ
 			return
 		}
 
+		// get expected version, updating without it might
+		// overwrite changes made by others
+		version, err := httplib.GetIfMatchVersionFromHeader(r)
+		if err == httplib.ErrIfMatchNotFound {
+			statusCode = http.StatusPreconditionRequired
+			errChan <- errPreconditionRequired
+			return
+		}
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errInvalidIfMatch
+			return
+		}
+
 		// read body
 		body, err := ioutil.ReadAll(r.Body)
 		if err != nil {
@@ -103,17 +119,27 @@ func (h *paymentHandler) handleUpdatePayment(w http.ResponseWriter, r *http.Requ
 				parsedErr = v
 				statusCode = http.StatusBadRequest
 			}
+			if err == payment.ErrForbidden {
+				statusCode = http.StatusForbidden
+			}
 
 			// log the actual error if its internal error
 			if statusCode == http.StatusInternalServerError {
-				log.Printf("[Payment HTTP][handleUpdatePayment] Internal error from GetPaymentByID, payment ID: %d. Err: %s\n", paymentID, err.Error())
+				log.Printf("[Payment HTTP][handleUpdatePayment] Internal error from GetPaymentByID, payment ID: %s. Err: %s\n", paymentID, err.Error())
 			}
 
 			errChan <- parsedErr
 			return
 		}
 
-		// parse content from request body
+		// the data has been changed since the client read it
+		if current.Version != version {
+			statusCode = http.StatusPreconditionFailed
+			errChan <- errVersionConflict
+			return
+		}
+
+		// parse payment from request body
 		err = request.parsePayment(&current)
 		if err != nil {
 			statusCode = http.StatusBadRequest
@@ -130,6 +156,12 @@ func (h *paymentHandler) handleUpdatePayment(w http.ResponseWriter, r *http.Requ
 				parsedErr = v
 				statusCode = http.StatusBadRequest
 			}
+			if err == payment.ErrForbidden {
+				statusCode = http.StatusForbidden
+			}
+			if err == payment.ErrVersionConflict {
+				statusCode = http.StatusPreconditionFailed
+			}
 
 			// log the actual error if its internal error
 			if statusCode == http.StatusInternalServerError {
@@ -140,7 +172,9 @@ func (h *paymentHandler) handleUpdatePayment(w http.ResponseWriter, r *http.Requ
 			return
 		}
 
-		resChan <- paymentID
+		// the stored version is incremented on every update
+		current.Version++
+		resChan <- current
 	}()
 
 	// wait and handle main go routine
@@ -149,9 +183,10 @@ func (h *paymentHandler) handleUpdatePayment(w http.ResponseWriter, r *http.Requ
 		statusCode = http.StatusGatewayTimeout
 		err = errRequestTimeout
 	case err = <-errChan:
-	case paymentID := <-resChan:
+	case res := <-resChan:
+		resVersion = res.Version
 		resBody, err = json.Marshal(httplib.ResponseEnvelope{
-			Data: paymentID,
+			Data: res.ID,
 		})
 	}
 }
@@ -49,3 +49,225 @@ func (h *paymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
 	}
 }
+
+type bundlesHandler struct {
+	payment       payment.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *bundlesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetBundles(w, r)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type plansHandler struct {
+	payment       payment.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *plansHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.handleCreatePlan(w, r)
+	case http.MethodGet:
+		h.handleGetPlans(w, r)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type planHandler struct {
+	payment       payment.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *planHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	planID := vars["id"]
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetPlanByID(w, r, planID)
+	case http.MethodPatch:
+		h.handleUpdatePlan(w, r, planID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type subscriptionsHandler struct {
+	payment       payment.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *subscriptionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetSubscriptions(w, r)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type subscriptionHandler struct {
+	payment       payment.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *subscriptionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	subscriptionID := vars["id"]
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetSubscriptionByID(w, r, subscriptionID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type subscriptionCancelHandler struct {
+	payment       payment.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *subscriptionCancelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	subscriptionID := vars["id"]
+
+	switch r.Method {
+	case http.MethodPost:
+		h.handleCancelSubscription(w, r, subscriptionID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type vouchersHandler struct {
+	payment       payment.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *vouchersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.handleCreateVoucher(w, r)
+	case http.MethodGet:
+		h.handleGetVouchers(w, r)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type voucherHandler struct {
+	payment       payment.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *voucherHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	voucherID := vars["id"]
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetVoucherByID(w, r, voucherID)
+	case http.MethodPatch:
+		h.handleUpdateVoucher(w, r, voucherID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type paymentInvoiceHandler struct {
+	payment       payment.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *paymentInvoiceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	paymentID := vars["id"]
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetInvoice(w, r, paymentID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type paymentRefundsHandler struct {
+	payment       payment.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *paymentRefundsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	paymentID := vars["id"]
+
+	switch r.Method {
+	case http.MethodPost:
+		h.handleRefundPayment(w, r, paymentID)
+	case http.MethodGet:
+		h.handleGetRefunds(w, r, paymentID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type paymentClaimHandler struct {
+	payment       payment.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *paymentClaimHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	paymentID := vars["id"]
+
+	switch r.Method {
+	case http.MethodPost:
+		h.handleClaimPayment(w, r, paymentID)
+	case http.MethodDelete:
+		h.handleReleasePayment(w, r, paymentID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type paymentReviewsHandler struct {
+	payment       payment.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *paymentReviewsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetReviewQueue(w, r)
+	case http.MethodPost:
+		h.handleReviewPayments(w, r)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"hbdtoyou/internal/auth"
 	"hbdtoyou/internal/payment"
+	httplib "hbdtoyou/pkg/http"
+	"hbdtoyou/pkg/ratelimit"
 	"net/http"
 	"time"
 
@@ -11,8 +13,9 @@ import (
 )
 
 var (
-	errUnknownScope  = errors.New("unknown scope name")
-	errUnknownConfig = errors.New("unknown config name")
+	errUnknownScope     = errors.New("unknown scope name")
+	errUnknownConfig    = errors.New("unknown config name")
+	errInvalidRateLimit = errors.New("invalid rate limit")
 )
 
 // Handler contains finance HTTP handlers.
@@ -21,6 +24,8 @@ type Handler struct {
 	payment       payment.Service
 	auth          auth.Service
 	scopeSettings map[Scope]ScopeSetting
+	rateLimiter   *httplib.RateLimiter
+	idempotency   *httplib.Idempotency
 }
 
 // handler is the HTTP handler wrapper.
@@ -45,6 +50,54 @@ var (
 		Name: "payments",
 		URL:  "/v1/payments",
 	}
+	HandlerBundles = HandlerIdentity{
+		Name: "bundles",
+		URL:  "/v1/bundles",
+	}
+	HandlerPlan = HandlerIdentity{
+		Name: "plan",
+		URL:  "/v1/plans/{id}",
+	}
+	HandlerPlans = HandlerIdentity{
+		Name: "plans",
+		URL:  "/v1/plans",
+	}
+	HandlerSubscription = HandlerIdentity{
+		Name: "subscription",
+		URL:  "/v1/subscriptions/{id}",
+	}
+	HandlerSubscriptions = HandlerIdentity{
+		Name: "subscriptions",
+		URL:  "/v1/subscriptions",
+	}
+	HandlerSubscriptionCancel = HandlerIdentity{
+		Name: "subscription_cancel",
+		URL:  "/v1/subscriptions/{id}/cancel",
+	}
+	HandlerPaymentInvoice = HandlerIdentity{
+		Name: "payment_invoice",
+		URL:  "/v1/payments/{id}/invoice",
+	}
+	HandlerPaymentRefunds = HandlerIdentity{
+		Name: "payment_refunds",
+		URL:  "/v1/payments/{id}/refunds",
+	}
+	HandlerPaymentClaim = HandlerIdentity{
+		Name: "payment_claim",
+		URL:  "/v1/payments/{id}/claim",
+	}
+	HandlerPaymentReviews = HandlerIdentity{
+		Name: "payment_reviews",
+		URL:  "/v1/payment-reviews",
+	}
+	HandlerVoucher = HandlerIdentity{
+		Name: "voucher",
+		URL:  "/v1/vouchers/{id}",
+	}
+	HandlerVouchers = HandlerIdentity{
+		Name: "vouchers",
+		URL:  "/v1/vouchers",
+	}
 )
 
 // Scope is a shared settings identifier.
@@ -62,6 +115,25 @@ const (
 	ScopeGetPaymentByID
 	ScopeUpdatePayment
 	ScopeDeletePayment
+	ScopeGetBundles
+	ScopeCreatePlan
+	ScopeGetPlans
+	ScopeGetPlanByID
+	ScopeUpdatePlan
+	ScopeGetSubscriptions
+	ScopeGetSubscriptionByID
+	ScopeCancelSubscription
+	ScopeCreateVoucher
+	ScopeGetVouchers
+	ScopeGetVoucherByID
+	ScopeUpdateVoucher
+	ScopeGetInvoice
+	ScopeRefundPayment
+	ScopeGetRefunds
+	ScopeGetReviewQueue
+	ScopeClaimPayment
+	ScopeReleasePayment
+	ScopeReviewPayments
 )
 
 var (
@@ -73,6 +145,29 @@ var (
 		ScopeGetPaymentByID: "GetPaymentByID",
 		ScopeUpdatePayment:  "UpdatePayment",
 		ScopeDeletePayment:  "DeletePayment",
+		ScopeGetBundles:     "GetBundles",
+
+		ScopeCreatePlan:          "CreatePlan",
+		ScopeGetPlans:            "GetPlans",
+		ScopeGetPlanByID:         "GetPlanByID",
+		ScopeUpdatePlan:          "UpdatePlan",
+		ScopeGetSubscriptions:    "GetSubscriptions",
+		ScopeGetSubscriptionByID: "GetSubscriptionByID",
+		ScopeCancelSubscription:  "CancelSubscription",
+
+		ScopeCreateVoucher:  "CreateVoucher",
+		ScopeGetVouchers:    "GetVouchers",
+		ScopeGetVoucherByID: "GetVoucherByID",
+		ScopeUpdateVoucher:  "UpdateVoucher",
+
+		ScopeGetInvoice:    "GetInvoice",
+		ScopeRefundPayment: "RefundPayment",
+		ScopeGetRefunds:    "GetRefunds",
+
+		ScopeGetReviewQueue: "GetReviewQueue",
+		ScopeClaimPayment:   "ClaimPayment",
+		ScopeReleasePayment: "ReleasePayment",
+		ScopeReviewPayments: "ReviewPayments",
 	}
 
 	// ScopeValue is the reverse-mapping of ScopeName.
@@ -82,12 +177,40 @@ var (
 		ScopeName[ScopeGetPaymentByID]: ScopeGetPaymentByID,
 		ScopeName[ScopeUpdatePayment]:  ScopeUpdatePayment,
 		ScopeName[ScopeDeletePayment]:  ScopeDeletePayment,
+		ScopeName[ScopeGetBundles]:     ScopeGetBundles,
+
+		ScopeName[ScopeCreatePlan]:          ScopeCreatePlan,
+		ScopeName[ScopeGetPlans]:            ScopeGetPlans,
+		ScopeName[ScopeGetPlanByID]:         ScopeGetPlanByID,
+		ScopeName[ScopeUpdatePlan]:          ScopeUpdatePlan,
+		ScopeName[ScopeGetSubscriptions]:    ScopeGetSubscriptions,
+		ScopeName[ScopeGetSubscriptionByID]: ScopeGetSubscriptionByID,
+		ScopeName[ScopeCancelSubscription]:  ScopeCancelSubscription,
+
+		ScopeName[ScopeCreateVoucher]:  ScopeCreateVoucher,
+		ScopeName[ScopeGetVouchers]:    ScopeGetVouchers,
+		ScopeName[ScopeGetVoucherByID]: ScopeGetVoucherByID,
+		ScopeName[ScopeUpdateVoucher]:  ScopeUpdateVoucher,
+
+		ScopeName[ScopeGetInvoice]:    ScopeGetInvoice,
+		ScopeName[ScopeRefundPayment]: ScopeRefundPayment,
+		ScopeName[ScopeGetRefunds]:    ScopeGetRefunds,
+
+		ScopeName[ScopeGetReviewQueue]: ScopeGetReviewQueue,
+		ScopeName[ScopeClaimPayment]:   ScopeClaimPayment,
+		ScopeName[ScopeReleasePayment]: ScopeReleasePayment,
+		ScopeName[ScopeReviewPayments]: ScopeReviewPayments,
 	}
 )
 
 // ScopeSetting is the available configurations of a Scope.
 type ScopeSetting struct {
 	Timeout time.Duration
+
+	// RateLimit limits the requests of the scope per user,
+	// or per client IP if the request has no user. The zero
+	// value does not limit.
+	RateLimit ratelimit.Limit
 }
 
 // Followings are default values for ScopeSetting fields.
@@ -144,12 +267,35 @@ func WithScopeSetting(scopeName string, scopeSetting ScopeSetting) Option {
 		if scopeSetting.Timeout <= 0 {
 			scopeSetting.Timeout = defaultTimeout
 		}
+		if err := scopeSetting.RateLimit.Validate(); err != nil {
+			return errInvalidRateLimit
+		}
 
 		h.scopeSettings[scope] = scopeSetting
 		return nil
 	})
 }
 
+// WithRateLimiter returns Option to limit the rate of requests
+// using the given rate limiter, with the RateLimit of each
+// scope setting.
+func WithRateLimiter(rateLimiter *httplib.RateLimiter) Option {
+	return Option(func(h *Handler) error {
+		h.rateLimiter = rateLimiter
+		return nil
+	})
+}
+
+// WithIdempotency returns Option to replay the saved response
+// of the create requests sent again with the same
+// Idempotency-Key header.
+func WithIdempotency(idempotency *httplib.Idempotency) Option {
+	return Option(func(h *Handler) error {
+		h.idempotency = idempotency
+		return nil
+	})
+}
+
 // New creates a new Handler.
 //
 // For the given Option, WithScopeSetting() should come first
@@ -180,14 +326,86 @@ func (h *Handler) createHTTPHandler(configName string) (http.Handler, error) {
 	switch configName {
 	case HandlerPayment.Name:
 		httpHandler = &paymentHandler{
-			payment:      h.payment,
-			auth:         h.auth,
+			payment:       h.payment,
+			auth:          h.auth,
 			scopeSettings: h.scopeSettings,
 		}
 	case HandlerPayments.Name:
 		httpHandler = &paymentsHandler{
-			payment:      h.payment,
-			auth:         h.auth,
+			payment:       h.payment,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerBundles.Name:
+		httpHandler = &bundlesHandler{
+			payment:       h.payment,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerPlan.Name:
+		httpHandler = &planHandler{
+			payment:       h.payment,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerPlans.Name:
+		httpHandler = &plansHandler{
+			payment:       h.payment,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerSubscription.Name:
+		httpHandler = &subscriptionHandler{
+			payment:       h.payment,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerSubscriptions.Name:
+		httpHandler = &subscriptionsHandler{
+			payment:       h.payment,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerSubscriptionCancel.Name:
+		httpHandler = &subscriptionCancelHandler{
+			payment:       h.payment,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerPaymentInvoice.Name:
+		httpHandler = &paymentInvoiceHandler{
+			payment:       h.payment,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerPaymentRefunds.Name:
+		httpHandler = &paymentRefundsHandler{
+			payment:       h.payment,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerPaymentClaim.Name:
+		httpHandler = &paymentClaimHandler{
+			payment:       h.payment,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerPaymentReviews.Name:
+		httpHandler = &paymentReviewsHandler{
+			payment:       h.payment,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerVoucher.Name:
+		httpHandler = &voucherHandler{
+			payment:       h.payment,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerVouchers.Name:
+		httpHandler = &vouchersHandler{
+			payment:       h.payment,
+			auth:          h.auth,
 			scopeSettings: h.scopeSettings,
 		}
 	default:
@@ -200,7 +418,7 @@ func (h *Handler) createHTTPHandler(configName string) (http.Handler, error) {
 // Start starts all HTTP handlers.
 func (h *Handler) Start(multiplexer *mux.Router) error {
 	for _, handler := range h.handlers {
-		multiplexer.Handle(handler.identity.URL, handler.h)
+		multiplexer.Handle(handler.identity.URL, h.limitRate(handler.identity, h.ensureIdempotency(handler.identity, handler.h)))
 	}
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"hbdtoyou/pkg/openapi"
+	"net/http"
+)
+
+// routes defines the OpenAPI routes of each HTTP handler, it
+// must be updated along with the methods served by handler.go.
+var routes = map[string][]openapi.Route{
+	HandlerPayments.Name: {
+		{Method: http.MethodPost, Summary: "Create payment", Request: paymentHTTP{}, Response: "", Idempotent: true},
+		{Method: http.MethodGet, Summary: "Get payments", Response: []paymentHTTP{}, Query: []*openapi.Parameter{
+			openapi.Query("user_id", "string", "Payer of the payments."),
+			openapi.Query("status", "string", "Status of the payments."),
+		}},
+	},
+	HandlerPayment.Name: {
+		{Method: http.MethodGet, Summary: "Get payment by ID", Response: paymentHTTP{}, Versioned: true},
+		{Method: http.MethodPatch, Summary: "Update payment", Request: paymentHTTP{}, Response: "", IfMatch: true},
+	},
+	HandlerPaymentInvoice.Name: {
+		{Method: http.MethodGet, Summary: "Get payment invoice", Response: invoiceHTTP{}, Files: []string{"application/pdf"}},
+	},
+	HandlerPaymentRefunds.Name: {
+		{Method: http.MethodPost, Summary: "Refund payment", Request: refundHTTP{}, Response: "", Idempotent: true},
+		{Method: http.MethodGet, Summary: "Get payment refunds", Response: []refundHTTP{}},
+	},
+	HandlerPaymentClaim.Name: {
+		{Method: http.MethodPost, Summary: "Claim payment", Response: paymentHTTP{}, Versioned: true},
+		{Method: http.MethodDelete, Summary: "Release payment", Response: ""},
+	},
+	HandlerPaymentReviews.Name: {
+		{Method: http.MethodGet, Summary: "Get payment review queue", Response: []paymentHTTP{}, Query: []*openapi.Parameter{
+			openapi.Query("claimable", "boolean", "Lists payments claimable by the caller only."),
+		}},
+		{Method: http.MethodPost, Summary: "Review payments", Request: reviewHTTP{}, Response: []reviewResultHTTP{}},
+	},
+	HandlerBundles.Name: {
+		{Method: http.MethodGet, Summary: "Get bundles", Response: []bundleHTTP{}, Public: true},
+	},
+	HandlerPlans.Name: {
+		{Method: http.MethodPost, Summary: "Create plan", Request: planHTTP{}, Response: "", Idempotent: true},
+		{Method: http.MethodGet, Summary: "Get plans", Response: []planHTTP{}},
+	},
+	HandlerPlan.Name: {
+		{Method: http.MethodGet, Summary: "Get plan by ID", Response: planHTTP{}, Versioned: true},
+		{Method: http.MethodPatch, Summary: "Update plan", Request: planHTTP{}, Response: "", IfMatch: true},
+	},
+	HandlerSubscriptions.Name: {
+		{Method: http.MethodGet, Summary: "Get subscriptions", Response: []subscriptionHTTP{}, Query: []*openapi.Parameter{
+			openapi.Query("user_id", "string", "Subscriber of the subscriptions."),
+			openapi.Query("plan_id", "string", "Plan of the subscriptions."),
+			openapi.Query("status", "string", "Status of the subscriptions."),
+		}},
+	},
+	HandlerSubscription.Name: {
+		{Method: http.MethodGet, Summary: "Get subscription by ID", Response: subscriptionHTTP{}, Versioned: true},
+	},
+	HandlerSubscriptionCancel.Name: {
+		{Method: http.MethodPost, Summary: "Cancel subscription", Response: ""},
+	},
+	HandlerVouchers.Name: {
+		{Method: http.MethodPost, Summary: "Create voucher", Request: voucherHTTP{}, Response: "", Idempotent: true},
+		{Method: http.MethodGet, Summary: "Get vouchers", Response: []voucherHTTP{}},
+	},
+	HandlerVoucher.Name: {
+		{Method: http.MethodGet, Summary: "Get voucher by ID", Response: voucherHTTP{}, Versioned: true},
+		{Method: http.MethodPatch, Summary: "Update voucher", Request: voucherHTTP{}, Response: "", IfMatch: true},
+	},
+}
+
+// Routes returns the OpenAPI routes of the registered HTTP
+// handlers.
+func (h *Handler) Routes() []openapi.Route {
+	var res []openapi.Route
+	for _, handler := range h.handlers {
+		for _, route := range routes[handler.identity.Name] {
+			route.Path = handler.identity.URL
+			route.Tag = "payment"
+			res = append(res, route)
+		}
+	}
+	return res
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"bytes"
+	"hbdtoyou/internal/payment"
+	"strconv"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// invoiceDateFormat denotes the date format printed in invoice
+// documents.
+var invoiceDateFormat = "02 January 2006"
+
+// Followings are the widths of invoice item table columns in
+// millimeters, they add up to the printable width of A4.
+const (
+	invoiceColDescription = 95.0
+	invoiceColQuantity    = 15.0
+	invoiceColUnitPrice   = 40.0
+	invoiceColAmount      = 40.0
+)
+
+// renderInvoicePDF renders the given invoice as a printable PDF
+// document.
+func renderInvoicePDF(inv payment.Invoice) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(inv.Number, true)
+	pdf.AddPage()
+
+	// core fonts are encoded in cp1252, which covers the
+	// supported currency symbols
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	// header
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.CellFormat(0, 12, "INVOICE", "", 1, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, tr("Number: "+inv.Number), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, tr("Date: "+inv.IssueTime.Format(invoiceDateFormat)), "", 1, "L", false, 0, "")
+	pdf.Ln(6)
+
+	// billed user
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(0, 6, "Billed to", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, tr(inv.UserName), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, tr(inv.UserEmail), "", 1, "L", false, 0, "")
+	pdf.Ln(6)
+
+	// items
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetFillColor(235, 235, 235)
+	pdf.CellFormat(invoiceColDescription, 8, "Description", "1", 0, "L", true, 0, "")
+	pdf.CellFormat(invoiceColQuantity, 8, "Qty", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(invoiceColUnitPrice, 8, "Unit Price", "1", 0, "R", true, 0, "")
+	pdf.CellFormat(invoiceColAmount, 8, "Amount", "1", 1, "R", true, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	for _, item := range inv.Items {
+		pdf.CellFormat(invoiceColDescription, 8, tr(item.Description), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(invoiceColQuantity, 8, strconv.Itoa(item.Quantity), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(invoiceColUnitPrice, 8, tr(item.UnitPrice.String()), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(invoiceColAmount, 8, tr(item.Amount.String()), "1", 1, "R", false, 0, "")
+	}
+
+	// totals, aligned with the amount column
+	labelWidth := invoiceColDescription + invoiceColQuantity + invoiceColUnitPrice
+	pdf.CellFormat(labelWidth, 8, "Subtotal", "", 0, "R", false, 0, "")
+	pdf.CellFormat(invoiceColAmount, 8, tr(inv.Subtotal.String()), "", 1, "R", false, 0, "")
+
+	if !inv.Discount.IsZero() {
+		pdf.CellFormat(labelWidth, 8, "Discount", "", 0, "R", false, 0, "")
+		pdf.CellFormat(invoiceColAmount, 8, tr("-"+inv.Discount.String()), "", 1, "R", false, 0, "")
+	}
+
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(labelWidth, 8, "Total", "", 0, "R", false, 0, "")
+	pdf.CellFormat(invoiceColAmount, 8, tr(inv.Total.String()), "", 1, "R", false, 0, "")
+
+	pdf.Ln(10)
+	pdf.SetFont("Helvetica", "I", 9)
+	pdf.CellFormat(0, 6, "This invoice is a receipt of a completed payment.", "", 1, "L", false, 0, "")
+
+	var buf bytes.Buffer
+	err := pdf.Output(&buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
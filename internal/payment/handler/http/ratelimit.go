@@ -0,0 +1,95 @@
+package http
+
+import (
+	httplib "hbdtoyou/pkg/http"
+	"hbdtoyou/pkg/ratelimit"
+	"net/http"
+)
+
+// handlerScopes maps the methods of each handler to the scope
+// of their requests, the rate limit of the scope is applied
+// before the request is handled.
+var handlerScopes = map[string]map[string]Scope{
+	HandlerPayment.Name: {
+		http.MethodGet:   ScopeGetPaymentByID,
+		http.MethodPatch: ScopeUpdatePayment,
+	},
+	HandlerPayments.Name: {
+		http.MethodPost: ScopeCreatePayment,
+		http.MethodGet:  ScopeGetPayments,
+	},
+	HandlerBundles.Name: {
+		http.MethodGet: ScopeGetBundles,
+	},
+	HandlerPlan.Name: {
+		http.MethodGet:   ScopeGetPlanByID,
+		http.MethodPatch: ScopeUpdatePlan,
+	},
+	HandlerPlans.Name: {
+		http.MethodPost: ScopeCreatePlan,
+		http.MethodGet:  ScopeGetPlans,
+	},
+	HandlerSubscription.Name: {
+		http.MethodGet: ScopeGetSubscriptionByID,
+	},
+	HandlerSubscriptions.Name: {
+		http.MethodGet: ScopeGetSubscriptions,
+	},
+	HandlerSubscriptionCancel.Name: {
+		http.MethodPost: ScopeCancelSubscription,
+	},
+	HandlerPaymentInvoice.Name: {
+		http.MethodGet: ScopeGetInvoice,
+	},
+	HandlerPaymentRefunds.Name: {
+		http.MethodPost: ScopeRefundPayment,
+		http.MethodGet:  ScopeGetRefunds,
+	},
+	HandlerPaymentClaim.Name: {
+		http.MethodPost:   ScopeClaimPayment,
+		http.MethodDelete: ScopeReleasePayment,
+	},
+	HandlerPaymentReviews.Name: {
+		http.MethodGet:  ScopeGetReviewQueue,
+		http.MethodPost: ScopeReviewPayments,
+	},
+	HandlerVoucher.Name: {
+		http.MethodGet:   ScopeGetVoucherByID,
+		http.MethodPatch: ScopeUpdateVoucher,
+	},
+	HandlerVouchers.Name: {
+		http.MethodPost: ScopeCreateVoucher,
+		http.MethodGet:  ScopeGetVouchers,
+	},
+}
+
+// publicHandlers are the handlers requested without a user,
+// their requests are limited per client IP even if an access
+// token is given.
+var publicHandlers = map[string]bool{
+	HandlerBundles.Name: true,
+}
+
+// limitRate returns the given handler of the given identity
+// limited by the rate limit of the scope of each request.
+func (h *Handler) limitRate(identity HandlerIdentity, next http.Handler) http.Handler {
+	if h.rateLimiter == nil {
+		return next
+	}
+
+	scopes := handlerScopes[identity.Name]
+	limitFunc := httplib.LimitFunc(func(r *http.Request) (string, ratelimit.Limit, bool) {
+		scope, ok := scopes[r.Method]
+		if !ok {
+			return "", ratelimit.Limit{}, false
+		}
+
+		return ScopeName[scope], h.scopeSettings[scope].RateLimit, true
+	})
+
+	if publicHandlers[identity.Name] {
+		return h.rateLimiter.PublicHandler(next, limitFunc)
+	}
+
+	return h.rateLimiter.Handler(next, limitFunc)
+}
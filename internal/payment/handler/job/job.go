@@ -0,0 +1,181 @@
+package job
+
+import (
+	"context"
+	"hbdtoyou/internal/payment"
+	joblib "hbdtoyou/pkg/job"
+	"log"
+	"time"
+)
+
+// Followings are default values for SubscriptionSetting
+// fields.
+const (
+	defaultSubscriptionInterval = 1 * time.Hour
+)
+
+// Followings are default values for RefundSetting fields.
+const (
+	defaultRefundInterval = 5 * time.Minute
+)
+
+// Handler contains payment background jobs.
+type Handler struct {
+	payment             payment.Service
+	subscriptionSetting SubscriptionSetting
+	refundSetting       RefundSetting
+	tenants             []string
+	runners             []*joblib.Runner
+}
+
+// SubscriptionSetting is the available configurations of the
+// jobs renewing and expiring subscriptions.
+type SubscriptionSetting struct {
+	// Interval is how often the jobs run.
+	Interval time.Duration
+}
+
+// RefundSetting is the available configurations of the job
+// resuming pending refunds.
+type RefundSetting struct {
+	// Interval is how often the job runs.
+	Interval time.Duration
+}
+
+// Option controls the behavior of Handler.
+type Option func(*Handler) error
+
+// WithSubscriptionSetting returns Option to set the
+// subscription jobs setting.
+func WithSubscriptionSetting(setting SubscriptionSetting) Option {
+	return Option(func(h *Handler) error {
+		if setting.Interval > 0 {
+			h.subscriptionSetting.Interval = setting.Interval
+		}
+		return nil
+	})
+}
+
+// WithRefundSetting returns Option to set the refund job
+// setting.
+func WithRefundSetting(setting RefundSetting) Option {
+	return Option(func(h *Handler) error {
+		if setting.Interval > 0 {
+			h.refundSetting.Interval = setting.Interval
+		}
+		return nil
+	})
+}
+
+// WithTenants returns Option to run every job once for each
+// of the given tenants.
+func WithTenants(tenants []string) Option {
+	return Option(func(h *Handler) error {
+		h.tenants = tenants
+		return nil
+	})
+}
+
+// New creates a new Handler.
+func New(payment payment.Service, options ...Option) (*Handler, error) {
+	h := &Handler{
+		payment: payment,
+		subscriptionSetting: SubscriptionSetting{
+			Interval: defaultSubscriptionInterval,
+		},
+		refundSetting: RefundSetting{
+			Interval: defaultRefundInterval,
+		},
+	}
+
+	// apply options
+	for _, opt := range options {
+		err := opt(h)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	renewRunner, err := joblib.NewRunner("renew_subscriptions", h.subscriptionSetting.Interval, 0, joblib.ForEachTenant(h.tenants, h.renewSubscriptions))
+	if err != nil {
+		return nil, err
+	}
+	h.runners = append(h.runners, renewRunner)
+
+	expireRunner, err := joblib.NewRunner("expire_subscriptions", h.subscriptionSetting.Interval, 0, joblib.ForEachTenant(h.tenants, h.expireSubscriptions))
+	if err != nil {
+		return nil, err
+	}
+	h.runners = append(h.runners, expireRunner)
+
+	refundRunner, err := joblib.NewRunner("resume_refunds", h.refundSetting.Interval, 0, joblib.ForEachTenant(h.tenants, h.resumePendingRefunds))
+	if err != nil {
+		return nil, err
+	}
+	h.runners = append(h.runners, refundRunner)
+
+	return h, nil
+}
+
+// Start starts all jobs.
+func (h *Handler) Start() error {
+	for _, runner := range h.runners {
+		if err := runner.Start(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop stops all jobs and waits until the running ones are
+// finished.
+func (h *Handler) Stop() {
+	for _, runner := range h.runners {
+		runner.Stop()
+	}
+}
+
+// renewSubscriptions creates pending payments for the next
+// period of subscriptions due to renew.
+func (h *Handler) renewSubscriptions(ctx context.Context) error {
+	renewed, err := h.payment.RenewSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	if renewed > 0 {
+		log.Printf("[Payment Job][renewSubscriptions] Created %d renewal payments\n", renewed)
+	}
+
+	return nil
+}
+
+// expireSubscriptions lapses subscriptions whose period has
+// ended.
+func (h *Handler) expireSubscriptions(ctx context.Context) error {
+	lapsed, err := h.payment.ExpireSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	if lapsed > 0 {
+		log.Printf("[Payment Job][expireSubscriptions] Lapsed %d subscriptions\n", lapsed)
+	}
+
+	return nil
+}
+
+// resumePendingRefunds makes the refunds left pending through
+// their gateway again.
+func (h *Handler) resumePendingRefunds(ctx context.Context) error {
+	resumed, err := h.payment.ResumePendingRefunds(ctx)
+	if err != nil {
+		return err
+	}
+
+	if resumed > 0 {
+		log.Printf("[Payment Job][resumePendingRefunds] Resumed %d pending refunds\n", resumed)
+	}
+
+	return nil
+}
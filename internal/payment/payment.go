@@ -4,6 +4,7 @@ import (
 	"context"
 	"hbdtoyou/internal/auth"
 	"hbdtoyou/internal/template"
+	"hbdtoyou/pkg/money"
 	"time"
 )
 
@@ -11,14 +12,21 @@ import (
 type Service interface {
 	// CreatePayment creates a new payment and returns
 	// the created payment ID.
+	//
+	// The amount is the price of the purchased product, less
+	// the discount of the voucher with the given voucher code
+	// if any. A given amount must match it, otherwise
+	// ErrAmountMismatch is returned.
 	CreatePayment(ctx context.Context, reqPayment Payment) (string, error)
 
 	// GetPaymentByID returns a payment with the given
 	// payment ID.
 	GetPaymentByID(ctx context.Context, paymentID string) (Payment, error)
 
-	// GetPayments returns all payments.
-	GetPayments(ctx context.Context) ([]Payment, error)
+	// GetPayments returns all payments based on the given
+	// filter. Users other than administrators only get their
+	// own payments.
+	GetPayments(ctx context.Context, filter GetPaymentsFilter) ([]Payment, error)
 
 	// UpdatePayment updates existing payment
 	// with the given payment data.
@@ -27,20 +35,231 @@ type Service interface {
 	// except ID, and CreateTime. So, make sure to
 	// use current values in the given data if do not want to
 	// update some specific attributes.
+	//
+	// The given version must be the current version of the
+	// payment, otherwise ErrVersionConflict is returned.
+	//
+	// Only administrators can change the payment status, the
+	// payment user can only update a pending payment. The
+	// purchased product and its price can not be changed.
+	//
+	// Approving a payment grants the purchased product to the
+	// payment user and issues its invoice. The status of an
+	// approved payment can not be changed, it is refunded by
+	// RefundPayment instead.
 	UpdatePayment(ctx context.Context, reqPayment Payment) error
+
+	// GetBundles returns all bundles can be purchased.
+	GetBundles(ctx context.Context) ([]Bundle, error)
+
+	// CreatePlan creates a new plan and returns the created
+	// plan ID. Only administrators can create plans.
+	CreatePlan(ctx context.Context, reqPlan Plan) (string, error)
+
+	// GetPlanByID returns a plan with the given plan ID.
+	GetPlanByID(ctx context.Context, planID string) (Plan, error)
+
+	// GetPlans returns all plans. Inactive plans are only
+	// returned to administrators.
+	GetPlans(ctx context.Context) ([]Plan, error)
+
+	// UpdatePlan updates existing plan with the given plan
+	// data. Only administrators can update plans.
+	//
+	// The given version must be the current version of the
+	// plan, otherwise ErrVersionConflict is returned.
+	UpdatePlan(ctx context.Context, reqPlan Plan) error
+
+	// GetSubscriptionByID returns a subscription with the
+	// given subscription ID.
+	GetSubscriptionByID(ctx context.Context, subscriptionID string) (Subscription, error)
+
+	// GetSubscriptions returns all subscriptions based on the
+	// given filter. Users other than administrators only get
+	// their own subscriptions.
+	GetSubscriptions(ctx context.Context, filter GetSubscriptionsFilter) ([]Subscription, error)
+
+	// CancelSubscription stops the renewal of a subscription
+	// with the given subscription ID. An active subscription
+	// stays active until the end of its current period.
+	CancelSubscription(ctx context.Context, subscriptionID string) error
+
+	// RenewSubscriptions creates pending payments for the
+	// next period of auto-renewing subscriptions that are due
+	// to renew, and returns the number of created payments.
+	RenewSubscriptions(ctx context.Context) (int, error)
+
+	// ExpireSubscriptions lapses active subscriptions whose
+	// period has ended, and returns the number of lapsed
+	// subscriptions. Users left without an active subscription
+	// are downgraded to free users.
+	ExpireSubscriptions(ctx context.Context) (int, error)
+
+	// SyncUserType updates the type of the user with the
+	// given user ID by whether they have an active
+	// subscription. It is called on payment events affecting
+	// subscriptions.
+	SyncUserType(ctx context.Context, userID string) error
+
+	// CreateVoucher creates a new voucher and returns the
+	// created voucher ID. Only administrators can create
+	// vouchers.
+	CreateVoucher(ctx context.Context, reqVoucher Voucher) (string, error)
+
+	// GetVoucherByID returns a voucher with the given voucher
+	// ID. Only administrators can read vouchers.
+	GetVoucherByID(ctx context.Context, voucherID string) (Voucher, error)
+
+	// GetVouchers returns all vouchers. Only administrators
+	// can read vouchers.
+	GetVouchers(ctx context.Context) ([]Voucher, error)
+
+	// UpdateVoucher updates existing voucher with the given
+	// voucher data. Only administrators can update vouchers.
+	//
+	// The given version must be the current version of the
+	// voucher, otherwise ErrVersionConflict is returned.
+	UpdateVoucher(ctx context.Context, reqVoucher Voucher) error
+
+	// GetInvoiceByPaymentID returns the invoice of a payment
+	// with the given payment ID. Only the payment user and
+	// administrators can read it.
+	//
+	// An invoice is issued once the payment is approved,
+	// otherwise ErrInvoiceNotAvailable is returned.
+	GetInvoiceByPaymentID(ctx context.Context, paymentID string) (Invoice, error)
+
+	// RefundPayment refunds the given amount of an approved
+	// payment and returns the created refund ID. Only
+	// administrators can refund payments.
+	//
+	// A payment paid through a gateway is refunded by the
+	// gateway, the refund is pending until the gateway made
+	// it and is resumed if the gateway can not be reached.
+	// ErrRefundDeclined is returned if the gateway declined
+	// it. The unused quota granted by the payment is reduced
+	// in proportion to the refunded amount, the quota already
+	// used is kept. Refunding the whole amount marks the
+	// payment as refunded and revokes its product.
+	RefundPayment(ctx context.Context, reqRefund Refund) (string, error)
+
+	// ResumePendingRefunds makes the refunds left pending
+	// through their gateway again, and returns the number of
+	// resumed refunds.
+	ResumePendingRefunds(ctx context.Context) (int, error)
+
+	// GetRefundsByPaymentID returns all refunds of a payment
+	// with the given payment ID. Only the payment user and
+	// administrators can read them.
+	GetRefundsByPaymentID(ctx context.Context, paymentID string) ([]Refund, error)
+
+	// GetReviewQueue returns pending payments to be reviewed,
+	// the oldest first. Only administrators can read the
+	// queue.
+	GetReviewQueue(ctx context.Context, filter ReviewQueueFilter) ([]Payment, error)
+
+	// ClaimPayment claims a pending payment with the given
+	// payment ID for the caller to review, and returns the
+	// claimed payment. Only administrators can claim payments.
+	//
+	// Other administrators can not approve or reject the
+	// payment until the claim expires or is released.
+	// ErrPaymentAlreadyClaimed is returned if the payment is
+	// claimed by another administrator.
+	ClaimPayment(ctx context.Context, paymentID string) (Payment, error)
+
+	// ReleasePayment releases the claim of the caller on a
+	// payment with the given payment ID.
+	ReleasePayment(ctx context.Context, paymentID string) error
+
+	// ReviewPayments approves or rejects the pending payments
+	// in the given review, and returns the result of each
+	// payment. Only administrators can review payments.
+	//
+	// Each payment is updated by the rules of UpdatePayment,
+	// a failing payment does not stop the others.
+	ReviewPayments(ctx context.Context, reqReview Review) ([]ReviewResult, error)
+}
+
+// Gateway is the interface for a payment gateway processing
+// payments outside of the service.
+type Gateway interface {
+	// Refund refunds the given amount of a payment with the
+	// given gateway reference, and returns the gateway
+	// reference of the refund.
+	//
+	// The refund ID is the idempotency key of the refund, so
+	// a retried refund is made once.
+	Refund(ctx context.Context, reference string, refundID string, amount money.Money, reason string) (string, error)
 }
 
 // Payment denotes the payment.
 type Payment struct {
-	ID              string
-	UserID          string
-	ContentID       string
-	Amount          int
+	ID     string
+	UserID string
+
+	// ProductType and ProductID denote the purchased product.
+	// ProductID is the template ID for template and content
+	// products, or the bundle ID for bundle products.
+	ProductType ProductType
+	ProductID   string
+
+	// ContentID is the content the template is purchased
+	// for, set for content products.
+	ContentID string
+
+	// Amount is the price of the product, determined when
+	// the payment is created.
+	Amount money.Money
+
+	// Quota is the quota of the purchased bundle, or the
+	// quota per period of the purchased plan.
+	Quota int
+
+	// SubscriptionID is the subscription the plan is
+	// purchased for, set for plan products.
+	SubscriptionID string
+
+	// VoucherCode is the code of the voucher redeemed by the
+	// payment. Discount is deducted from the price of the
+	// product, Amount is the price after the discount.
+	VoucherCode string
+	VoucherID   string
+	Discount    money.Money
+
+	// RefundedAmount is the total amount of the refunds of
+	// the payment.
+	RefundedAmount money.Money
+
+	// Gateway and GatewayReference denote the payment gateway
+	// processing the payment and its reference there. They are
+	// empty for payments proven by a proof of payment.
+	Gateway          string
+	GatewayReference string
+
+	// ReviewerID is the administrator claiming the payment
+	// at ClaimTime, or the last one approving or rejecting
+	// it. ReviewReason is given by the reviewer.
+	ReviewerID   string
+	ClaimTime    time.Time
+	ReviewReason string
+
+	// ProofPaymentURL is the stored URL of the proof of
+	// payment given by the user, it is empty when
+	// ProofPaymentMediaID is set.
 	ProofPaymentURL string
-	Date            time.Time
-	Status          Status
-	CreateTime      time.Time
-	UpdateTime      time.Time
+	// ProofPaymentMediaID references an uploaded media used as
+	// proof of payment.
+	ProofPaymentMediaID string
+	// ProofPaymentMediaURL is the signed URL of the proof
+	// payment media, resolved on read. It expires, so it is
+	// never stored.
+	ProofPaymentMediaURL string
+	Date                 time.Time
+	Status               Status
+	Version              int64
+	CreateTime           time.Time
+	UpdateTime           time.Time
 
 	// derived attributes
 	UserName      string
@@ -51,6 +270,16 @@ type Payment struct {
 	TemplateLabel template.Label
 }
 
+// GetProofPaymentURL returns the URL to present the proof of
+// the payment, the signed URL of the proof payment media if
+// any.
+func (p Payment) GetProofPaymentURL() string {
+	if p.ProofPaymentMediaID != "" {
+		return p.ProofPaymentMediaURL
+	}
+	return p.ProofPaymentURL
+}
+
 // Status denotes status of a payment.
 type Status int
 
@@ -60,6 +289,10 @@ const (
 	StatusDone     Status = 1
 	StatusPending  Status = 2
 	StatusRejected Status = 3
+
+	// StatusRefunded is a done payment whose whole amount
+	// has been refunded.
+	StatusRefunded Status = 4
 )
 
 var (
@@ -68,6 +301,7 @@ var (
 		StatusDone:     {},
 		StatusPending:  {},
 		StatusRejected: {},
+		StatusRefunded: {},
 	}
 
 	// StatusName maps payment status to it's string
@@ -76,6 +310,7 @@ var (
 		StatusDone:     "done",
 		StatusPending:  "pending",
 		StatusRejected: "rejected",
+		StatusRefunded: "refunded",
 	}
 )
 
@@ -88,3 +323,562 @@ func (s Status) String() string {
 func (s Status) Value() int {
 	return int(s)
 }
+
+// ProductType denotes type of a purchased product.
+type ProductType int
+
+// Following constans are the known product types.
+const (
+	ProductTypeUnknown ProductType = 0
+
+	// ProductTypeTemplate purchases a template to be used by
+	// any content of the user.
+	ProductTypeTemplate ProductType = 1
+
+	// ProductTypeContent purchases a template to be used by a
+	// specific content.
+	ProductTypeContent ProductType = 2
+
+	// ProductTypeBundle purchases a quota of contents using
+	// any paid template.
+	ProductTypeBundle ProductType = 3
+
+	// ProductTypePlan purchases a period of a plan
+	// subscription. The user is subscribed to the plan, or
+	// the existing subscription is renewed.
+	ProductTypePlan ProductType = 4
+)
+
+var (
+	// ProductTypeList is a list of valid product type.
+	ProductTypeList = map[ProductType]struct{}{
+		ProductTypeTemplate: {},
+		ProductTypeContent:  {},
+		ProductTypeBundle:   {},
+		ProductTypePlan:     {},
+	}
+
+	// ProductTypeName maps product type to it's string
+	// representation.
+	ProductTypeName = map[ProductType]string{
+		ProductTypeTemplate: "template",
+		ProductTypeContent:  "content",
+		ProductTypeBundle:   "bundle",
+		ProductTypePlan:     "plan",
+	}
+)
+
+// String implements the Stringer interface.
+func (t ProductType) String() string {
+	return ProductTypeName[t]
+}
+
+// Value implements the Valuer interface.
+func (t ProductType) Value() int {
+	return int(t)
+}
+
+// Bundle denotes a configured product granting a quota of
+// contents using any paid template.
+type Bundle struct {
+	ID    string
+	Name  string
+	Quota int
+	Price money.Money
+}
+
+type GetPaymentsFilter struct {
+	UserID string
+	Status Status
+}
+
+// Plan denotes a premium plan subscribed periodically.
+type Plan struct {
+	ID          string
+	Name        string
+	Description string
+
+	// Price is the price of a period.
+	Price money.Money
+
+	Interval Interval
+
+	// Quota is the number of contents can be created using
+	// any paid template in a period.
+	Quota int
+
+	// Active denotes whether the plan can be subscribed.
+	// Existing subscriptions of an inactive plan are not
+	// renewed.
+	Active bool
+
+	Version    int64
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+// Interval denotes the billing period of a plan.
+type Interval int
+
+// Following constans are the known plan intervals.
+const (
+	IntervalUnknown Interval = 0
+	IntervalMonthly Interval = 1
+	IntervalYearly  Interval = 2
+)
+
+var (
+	// IntervalList is a list of valid plan interval.
+	IntervalList = map[Interval]struct{}{
+		IntervalMonthly: {},
+		IntervalYearly:  {},
+	}
+
+	// IntervalName maps plan interval to it's string
+	// representation.
+	IntervalName = map[Interval]string{
+		IntervalMonthly: "monthly",
+		IntervalYearly:  "yearly",
+	}
+)
+
+// String implements the Stringer interface.
+func (i Interval) String() string {
+	return IntervalName[i]
+}
+
+// Value implements the Valuer interface.
+func (i Interval) Value() int {
+	return int(i)
+}
+
+// Next returns the end of a period starting at the given
+// time.
+func (i Interval) Next(t time.Time) time.Time {
+	switch i {
+	case IntervalYearly:
+		return t.AddDate(1, 0, 0)
+	default:
+		return t.AddDate(0, 1, 0)
+	}
+}
+
+// Subscription denotes a subscription of a user to a plan.
+type Subscription struct {
+	ID     string
+	UserID string
+	PlanID string
+	Status SubscriptionStatus
+
+	// AutoRenew denotes whether a payment for the next period
+	// is created before the current period ends.
+	AutoRenew bool
+
+	// StartTime and EndTime are the period the subscription
+	// is active, set once the first payment is approved.
+	StartTime time.Time
+	EndTime   time.Time
+
+	// RenewTime is when the payment for the next period is
+	// created. Zero means the subscription is not going to be
+	// renewed, or its renewal payment has been created.
+	RenewTime time.Time
+
+	Version    int64
+	CreateTime time.Time
+	UpdateTime time.Time
+
+	// derived attributes
+	PlanName     string
+	PlanInterval Interval
+}
+
+// SubscriptionStatus denotes status of a subscription.
+type SubscriptionStatus int
+
+// Following constans are the known subscription status.
+const (
+	SubscriptionStatusUnknown SubscriptionStatus = 0
+
+	// SubscriptionStatusPending is waiting for the first
+	// payment to be approved.
+	SubscriptionStatusPending  SubscriptionStatus = 1
+	SubscriptionStatusActive   SubscriptionStatus = 2
+	SubscriptionStatusLapsed   SubscriptionStatus = 3
+	SubscriptionStatusCanceled SubscriptionStatus = 4
+)
+
+var (
+	// SubscriptionStatusList is a list of valid subscription
+	// status.
+	SubscriptionStatusList = map[SubscriptionStatus]struct{}{
+		SubscriptionStatusPending:  {},
+		SubscriptionStatusActive:   {},
+		SubscriptionStatusLapsed:   {},
+		SubscriptionStatusCanceled: {},
+	}
+
+	// SubscriptionStatusName maps subscription status to it's
+	// string representation.
+	SubscriptionStatusName = map[SubscriptionStatus]string{
+		SubscriptionStatusPending:  "pending",
+		SubscriptionStatusActive:   "active",
+		SubscriptionStatusLapsed:   "lapsed",
+		SubscriptionStatusCanceled: "canceled",
+	}
+)
+
+// String implements the Stringer interface.
+func (s SubscriptionStatus) String() string {
+	return SubscriptionStatusName[s]
+}
+
+// Value implements the Valuer interface.
+func (s SubscriptionStatus) Value() int {
+	return int(s)
+}
+
+type GetSubscriptionsFilter struct {
+	UserID   string
+	PlanID   string
+	Statuses []SubscriptionStatus
+}
+
+// Voucher denotes a promotion code giving a discount on
+// payments.
+type Voucher struct {
+	ID   string
+	Code string
+
+	// DiscountValue is a percentage for percentage vouchers,
+	// or an amount in the minor unit of Currency for fixed
+	// vouchers.
+	DiscountType  DiscountType
+	DiscountValue int
+	Currency      string
+
+	// StartTime and EndTime are the period the voucher can be
+	// redeemed. Zero means the period is unbounded.
+	StartTime time.Time
+	EndTime   time.Time
+
+	// MaxRedemptions and MaxRedemptionsPerUser limit the
+	// number of payments redeeming the voucher, in total and
+	// per user. Zero means unlimited.
+	MaxRedemptions        int
+	MaxRedemptionsPerUser int
+
+	// TemplateIDs and PlanIDs restrict the products the
+	// voucher applies to. The voucher applies to any product
+	// when both are empty.
+	TemplateIDs []string
+	PlanIDs     []string
+
+	// Active denotes whether the voucher can be redeemed.
+	Active bool
+
+	Version    int64
+	CreateTime time.Time
+	UpdateTime time.Time
+
+	// derived attributes
+	Redemptions int
+}
+
+// IsValidAt returns whether the voucher can be redeemed at
+// the given time.
+func (v Voucher) IsValidAt(t time.Time) bool {
+	if !v.Active {
+		return false
+	}
+
+	if !v.StartTime.IsZero() && t.Before(v.StartTime) {
+		return false
+	}
+
+	if !v.EndTime.IsZero() && !t.Before(v.EndTime) {
+		return false
+	}
+
+	return true
+}
+
+// AppliesTo returns whether the voucher can be redeemed by
+// the given payment.
+func (v Voucher) AppliesTo(p Payment) bool {
+	// a fixed discount is only meaningful in its currency
+	if v.DiscountType == DiscountTypeFixed && v.Currency != p.Amount.Currency {
+		return false
+	}
+
+	if len(v.TemplateIDs) == 0 && len(v.PlanIDs) == 0 {
+		return true
+	}
+
+	var productIDs []string
+	switch p.ProductType {
+	case ProductTypeTemplate, ProductTypeContent:
+		productIDs = v.TemplateIDs
+	case ProductTypePlan:
+		productIDs = v.PlanIDs
+	}
+
+	for _, id := range productIDs {
+		if id == p.ProductID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DiscountOf returns the discount of the voucher on the given
+// amount. The discount never exceeds the amount.
+func (v Voucher) DiscountOf(amount money.Money) money.Money {
+	discount := money.Money{
+		Currency: amount.Currency,
+	}
+
+	switch v.DiscountType {
+	case DiscountTypePercentage:
+		discount = amount.Percent(v.DiscountValue)
+	case DiscountTypeFixed:
+		discount.Amount = int64(v.DiscountValue)
+	}
+
+	if discount.Amount > amount.Amount {
+		return amount
+	}
+
+	return discount
+}
+
+// DiscountType denotes how a voucher discount is calculated.
+type DiscountType int
+
+// Following constans are the known discount types.
+const (
+	DiscountTypeUnknown    DiscountType = 0
+	DiscountTypePercentage DiscountType = 1
+	DiscountTypeFixed      DiscountType = 2
+)
+
+var (
+	// DiscountTypeList is a list of valid discount type.
+	DiscountTypeList = map[DiscountType]struct{}{
+		DiscountTypePercentage: {},
+		DiscountTypeFixed:      {},
+	}
+
+	// DiscountTypeName maps discount type to it's string
+	// representation.
+	DiscountTypeName = map[DiscountType]string{
+		DiscountTypePercentage: "percentage",
+		DiscountTypeFixed:      "fixed",
+	}
+)
+
+// String implements the Stringer interface.
+func (t DiscountType) String() string {
+	return DiscountTypeName[t]
+}
+
+// Value implements the Valuer interface.
+func (t DiscountType) Value() int {
+	return int(t)
+}
+
+// Invoice denotes the receipt of an approved payment.
+//
+// The invoice keeps the user and items as they were when it
+// was issued.
+type Invoice struct {
+	ID        string
+	PaymentID string
+	UserID    string
+
+	// Number is unique and sequential within the year the
+	// invoice is issued, e.g. INV-2026-000001.
+	Number   string
+	Year     int
+	Sequence int
+
+	UserName  string
+	UserEmail string
+	Items     []InvoiceItem
+
+	// Total is the amount paid, Subtotal less Discount.
+	Subtotal money.Money
+	Discount money.Money
+	Total    money.Money
+
+	IssueTime time.Time
+}
+
+// InvoiceItem denotes a purchased product in an invoice.
+type InvoiceItem struct {
+	Description string
+	Quantity    int
+	UnitPrice   money.Money
+	Amount      money.Money
+}
+
+// Refund denotes a refund of a payment.
+type Refund struct {
+	ID        string
+	PaymentID string
+	Type      RefundType
+	Amount    money.Money
+	Reason    string
+
+	// ActorID is the administrator refunding the payment.
+	ActorID string
+
+	// Status is pending while the refund is being made by
+	// the gateway processing the payment.
+	Status RefundStatus
+
+	// GatewayReference is the reference of the refund in the
+	// gateway processing the payment, if any.
+	GatewayReference string
+
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+// RefundType denotes the cause of a refund.
+type RefundType int
+
+// Following constans are the known refund types.
+const (
+	RefundTypeUnknown RefundType = 0
+
+	// RefundTypeRefund is a refund granted to the user.
+	RefundTypeRefund RefundType = 1
+
+	// RefundTypeChargeback is a payment disputed by the user
+	// and reversed by the bank.
+	RefundTypeChargeback RefundType = 2
+)
+
+var (
+	// RefundTypeList is a list of valid refund type.
+	RefundTypeList = map[RefundType]struct{}{
+		RefundTypeRefund:     {},
+		RefundTypeChargeback: {},
+	}
+
+	// RefundTypeName maps refund type to it's string
+	// representation.
+	RefundTypeName = map[RefundType]string{
+		RefundTypeRefund:     "refund",
+		RefundTypeChargeback: "chargeback",
+	}
+)
+
+// String implements the Stringer interface.
+func (t RefundType) String() string {
+	return RefundTypeName[t]
+}
+
+// Value implements the Valuer interface.
+func (t RefundType) Value() int {
+	return int(t)
+}
+
+// RefundStatus denotes status of a refund.
+type RefundStatus int
+
+// Following constans are the known refund statuses.
+const (
+	RefundStatusUnknown RefundStatus = 0
+
+	// RefundStatusPending is a refund being made by the
+	// gateway, its amount is already counted as refunded.
+	RefundStatusPending RefundStatus = 1
+
+	// RefundStatusCompleted is a refund made.
+	RefundStatusCompleted RefundStatus = 2
+
+	// RefundStatusFailed is a refund refused by the gateway,
+	// its amount is no longer counted as refunded.
+	RefundStatusFailed RefundStatus = 3
+)
+
+var (
+	// RefundStatusName maps refund status to it's string
+	// representation.
+	RefundStatusName = map[RefundStatus]string{
+		RefundStatusPending:   "pending",
+		RefundStatusCompleted: "completed",
+		RefundStatusFailed:    "failed",
+	}
+)
+
+// String implements the Stringer interface.
+func (s RefundStatus) String() string {
+	return RefundStatusName[s]
+}
+
+// Value implements the Valuer interface.
+func (s RefundStatus) Value() int {
+	return int(s)
+}
+
+type ReviewQueueFilter struct {
+	// Claimable only returns payments not claimed by other
+	// administrators.
+	Claimable bool
+}
+
+// Review denotes approving or rejecting payments at once.
+type Review struct {
+	PaymentIDs []string
+
+	// Status is either StatusDone or StatusRejected.
+	Status Status
+
+	// Reason is required to reject payments.
+	Reason string
+}
+
+// ReviewResult denotes the result of reviewing a payment.
+type ReviewResult struct {
+	PaymentID string
+
+	// Err is nil if the payment is reviewed.
+	Err error
+}
+
+// Followings are the known payment event types, written to the
+// event outbox along with the payment change.
+const (
+	// EventApproved is written when a payment is approved.
+	EventApproved = "payment.approved"
+
+	// EventUnapproved is written when an approved payment is
+	// moved back to another status by an administrator.
+	EventUnapproved = "payment.unapproved"
+
+	// EventRejected is written when a payment is rejected.
+	EventRejected = "payment.rejected"
+
+	// EventRefunded is written when a payment is refunded,
+	// fully or partially.
+	EventRefunded = "payment.refunded"
+)
+
+// PaymentEvent is the payload of payment events.
+type PaymentEvent struct {
+	PaymentID   string `json:"payment_id"`
+	UserID      string `json:"user_id"`
+	ProductType string `json:"product_type"`
+	ProductID   string `json:"product_id"`
+	Status      string `json:"status"`
+
+	// Amount and RefundedAmount are in the minor unit of
+	// the currency.
+	Amount         int64  `json:"amount"`
+	RefundedAmount int64  `json:"refunded_amount"`
+	Currency       string `json:"currency"`
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"hbdtoyou/internal/payment"
+	"hbdtoyou/pkg/event"
+	"time"
+)
+
+// newPaymentEvent returns an event of the given type denoting
+// a change of the given payment.
+func newPaymentEvent(eventType string, p payment.Payment, createTime time.Time) (event.Event, error) {
+	return event.New(eventType, p.ID, payment.PaymentEvent{
+		PaymentID:      p.ID,
+		UserID:         p.UserID,
+		ProductType:    p.ProductType.String(),
+		ProductID:      p.ProductID,
+		Status:         p.Status.String(),
+		Amount:         p.Amount.Amount,
+		RefundedAmount: p.RefundedAmount.Amount,
+		Currency:       p.Amount.Currency,
+	}, createTime)
+}
+
+// getStatusEvents returns the events of changing the status of
+// the given current payment into the status of the requested
+// one.
+func getStatusEvents(reqPayment payment.Payment, current payment.Payment) ([]event.Event, error) {
+	if reqPayment.Status == current.Status {
+		return nil, nil
+	}
+
+	var eventTypes []string
+	if current.Status == payment.StatusDone {
+		eventTypes = append(eventTypes, payment.EventUnapproved)
+	}
+
+	switch reqPayment.Status {
+	case payment.StatusDone:
+		eventTypes = append(eventTypes, payment.EventApproved)
+	case payment.StatusRejected:
+		eventTypes = append(eventTypes, payment.EventRejected)
+	}
+
+	// the product and its price are kept from the current
+	// payment
+	p := current
+	p.Status = reqPayment.Status
+
+	events := make([]event.Event, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		e, err := newPaymentEvent(eventType, p, reqPayment.UpdateTime)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"hbdtoyou/internal/payment"
+)
+
+// GetInvoiceByPaymentID returns the invoice of a payment with
+// the given payment ID.
+func (s *service) GetInvoiceByPaymentID(ctx context.Context, paymentID string) (payment.Invoice, error) {
+	// validate id
+	if paymentID == "" {
+		return payment.Invoice{}, payment.ErrInvalidPaymentID
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return payment.Invoice{}, err
+	}
+
+	p, err := pgStoreClient.GetPaymentByID(ctx, paymentID)
+	if err != nil {
+		return payment.Invoice{}, err
+	}
+
+	// only the payment user and administrators can read it
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return payment.Invoice{}, err
+	}
+
+	if !caller.IsAdmin() && p.UserID != caller.ID {
+		return payment.Invoice{}, payment.ErrForbidden
+	}
+
+	// a refunded payment keeps the invoice of its approval
+	if p.Status != payment.StatusDone && p.Status != payment.StatusRefunded {
+		return payment.Invoice{}, payment.ErrInvoiceNotAvailable
+	}
+
+	result, err := pgStoreClient.GetInvoiceByPaymentID(ctx, paymentID)
+	if err != payment.ErrDataNotFound {
+		return result, err
+	}
+
+	// payments approved before invoices were introduced are
+	// issued one on the first read
+	result, err = s.issueInvoice(ctx, p)
+	if err == payment.ErrInvoiceAlreadyExist {
+		return pgStoreClient.GetInvoiceByPaymentID(ctx, paymentID)
+	}
+
+	return result, err
+}
+
+// issueInvoice creates the invoice of the given payment in its
+// own transaction, and returns it.
+func (s *service) issueInvoice(ctx context.Context, p payment.Payment) (payment.Invoice, error) {
+	inv, err := s.buildInvoice(ctx, p)
+	if err != nil {
+		return payment.Invoice{}, err
+	}
+
+	// get pg store client using transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, true)
+	if err != nil {
+		return payment.Invoice{}, err
+	}
+
+	err = s.createInvoice(ctx, pgStoreClient, &inv)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return payment.Invoice{}, err
+	}
+
+	err = pgStoreClient.Commit()
+	if err != nil {
+		return payment.Invoice{}, err
+	}
+
+	return inv, nil
+}
+
+// savepointCreateInvoice is the name of the savepoint set
+// before an invoice is created.
+const savepointCreateInvoice = "create_invoice"
+
+// createInvoice numbers and creates the given invoice.
+//
+// The given pg store client must use a transaction, the
+// invoice sequence is locked until it ends.
+func (s *service) createInvoice(ctx context.Context, pgStoreClient PGStoreClient, inv *payment.Invoice) error {
+	// a payment approved again keeps its invoice
+	_, err := pgStoreClient.GetInvoiceByPaymentID(ctx, inv.PaymentID)
+	if err == nil {
+		return payment.ErrInvoiceAlreadyExist
+	}
+	if err != payment.ErrDataNotFound {
+		return err
+	}
+
+	// the invoice is created after a savepoint, so a violated
+	// constraint does not abort the transaction
+	err = pgStoreClient.Savepoint(savepointCreateInvoice)
+	if err != nil {
+		return err
+	}
+
+	inv.Year = inv.IssueTime.UTC().Year()
+	inv.Sequence, err = pgStoreClient.NextInvoiceSequence(ctx, inv.Year)
+	if err != nil {
+		return err
+	}
+	inv.Number = fmt.Sprintf("INV-%d-%06d", inv.Year, inv.Sequence)
+
+	inv.ID, err = pgStoreClient.CreateInvoice(ctx, *inv)
+	if err == payment.ErrInvoiceAlreadyExist {
+		// the invoice was issued concurrently, the sequence is
+		// restored and the transaction can still be committed
+		rbErr := pgStoreClient.RollbackToSavepoint(savepointCreateInvoice)
+		if rbErr != nil {
+			return rbErr
+		}
+	}
+	return err
+}
+
+// buildInvoice returns the invoice of the given payment to be
+// issued now, with the current user and product details.
+func (s *service) buildInvoice(ctx context.Context, p payment.Payment) (payment.Invoice, error) {
+	user, err := s.user.GetUserByID(ctx, p.UserID)
+	if err != nil {
+		return payment.Invoice{}, err
+	}
+
+	description, err := s.getProductDescription(ctx, p)
+	if err != nil {
+		return payment.Invoice{}, err
+	}
+
+	// the discount was deducted from the product price
+	subtotal, err := p.Amount.Add(p.Discount)
+	if err != nil {
+		return payment.Invoice{}, err
+	}
+
+	return payment.Invoice{
+		PaymentID: p.ID,
+		UserID:    p.UserID,
+		UserName:  user.Fullname,
+		UserEmail: user.Email,
+		Items: []payment.InvoiceItem{
+			{
+				Description: description,
+				Quantity:    1,
+				UnitPrice:   subtotal,
+				Amount:      subtotal,
+			},
+		},
+		Subtotal:  subtotal,
+		Discount:  p.Discount,
+		Total:     p.Amount,
+		IssueTime: s.timeNow(),
+	}, nil
+}
+
+// getProductDescription returns the description of the
+// product purchased by the given payment.
+func (s *service) getProductDescription(ctx context.Context, p payment.Payment) (string, error) {
+	switch p.ProductType {
+	case payment.ProductTypeTemplate:
+		return fmt.Sprintf("Template %s", p.TemplateName), nil
+
+	case payment.ProductTypeContent:
+		return fmt.Sprintf("Template %s for a content", p.TemplateName), nil
+
+	case payment.ProductTypeBundle:
+		name := p.ProductID
+		for _, b := range s.config.Bundles {
+			if b.ID == p.ProductID {
+				name = b.Name
+			}
+		}
+		return fmt.Sprintf("Bundle %s, %d contents", name, p.Quota), nil
+
+	case payment.ProductTypePlan:
+		// get pg store client without transaction
+		pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+		if err != nil {
+			return "", err
+		}
+
+		plan, err := pgStoreClient.GetPlanByID(ctx, p.ProductID)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Plan %s, %s subscription", plan.Name, plan.Interval), nil
+	}
+
+	return p.ProductType.String(), nil
+}
@@ -3,16 +3,54 @@ package service
 import (
 	"context"
 	"hbdtoyou/internal/auth"
+	"hbdtoyou/internal/content"
+	"hbdtoyou/internal/entitlement"
+	"hbdtoyou/internal/media"
+	"hbdtoyou/internal/notification"
 	"hbdtoyou/internal/payment"
+	"hbdtoyou/internal/template"
+	"time"
+
+	contextlib "hbdtoyou/pkg/context"
+	"hbdtoyou/pkg/event"
+	"hbdtoyou/pkg/money"
 )
 
 func (s *service) CreatePayment(ctx context.Context, reqPayment payment.Payment) (string, error) {
+	// users can only pay for themselves
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if !caller.IsAdmin() && reqPayment.UserID != caller.ID {
+		return "", payment.ErrForbidden
+	}
+
 	// validate fields
-	if reqPayment.ProofPaymentURL == "" {
+	if reqPayment.ProofPaymentURL == "" && reqPayment.ProofPaymentMediaID == "" {
 		return "", payment.ErrInvalidProofPaymentURL
 	}
 
-	if reqPayment.Amount <= 0 {
+	if reqPayment.ProofPaymentMediaID != "" {
+		err = s.validateProofPaymentMedia(ctx, reqPayment)
+		if err != nil {
+			return "", err
+		}
+
+		// the URL is resolved from the media on read
+		reqPayment.ProofPaymentURL = ""
+	}
+
+	// the price is determined by the purchased product, the
+	// amount given by the caller is only checked against it
+	requested := reqPayment.Amount
+	err = s.resolveProduct(ctx, &reqPayment)
+	if err != nil {
+		return "", err
+	}
+
+	if reqPayment.Amount.Amount <= 0 {
 		return "", payment.ErrInvalidAmount
 	}
 
@@ -21,26 +59,43 @@ func (s *service) CreatePayment(ctx context.Context, reqPayment payment.Payment)
 	reqPayment.Status = payment.StatusPending
 
 	// get pg store client using transaction
-	pgStoreClient, err := s.pgStore.NewClient(false)
+	pgStoreClient, err := s.pgStore.NewClient(ctx, true)
 	if err != nil {
 		return "", err
 	}
 
-	// inserts content in pgstore
-	paymentID, err := pgStoreClient.CreatePayment(ctx, reqPayment)
-	if err != nil {
-		return "", err
+	// the voucher is redeemed in the same transaction, so
+	// concurrent payments can not exceed its limits
+	if reqPayment.VoucherCode != "" {
+		err = s.redeemVoucher(ctx, pgStoreClient, &reqPayment)
+		if err != nil {
+			pgStoreClient.Rollback()
+			return "", err
+		}
+	}
+
+	if !isAmountMatched(requested, reqPayment.Amount) {
+		pgStoreClient.Rollback()
+		return "", payment.ErrAmountMismatch
+	}
+
+	// a plan is purchased for the user subscription
+	if reqPayment.ProductType == payment.ProductTypePlan {
+		err = s.resolveSubscription(ctx, pgStoreClient, &reqPayment)
+		if err != nil {
+			pgStoreClient.Rollback()
+			return "", err
+		}
 	}
 
-	user, err := s.user.GetUserByID(ctx, reqPayment.UserID)
+	// inserts payment in pgstore
+	paymentID, err := pgStoreClient.CreatePayment(ctx, reqPayment)
 	if err != nil {
+		pgStoreClient.Rollback()
 		return "", err
 	}
 
-	user.Quota = 1
-	user.Type = auth.TypePending
-
-	err = s.user.UpdateUser(ctx, user)
+	err = pgStoreClient.Commit()
 	if err != nil {
 		return "", err
 	}
@@ -55,7 +110,7 @@ func (s *service) GetPaymentByID(ctx context.Context, paymentID string) (payment
 	}
 
 	// get pg store client without transaction
-	pgStoreClient, err := s.pgStore.NewClient(false)
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
 	if err != nil {
 		return payment.Payment{}, err
 	}
@@ -66,22 +121,60 @@ func (s *service) GetPaymentByID(ctx context.Context, paymentID string) (payment
 		return payment.Payment{}, err
 	}
 
+	// only the payment user and administrators can read it
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return payment.Payment{}, err
+	}
+
+	if !caller.IsAdmin() && result.UserID != caller.ID {
+		return payment.Payment{}, payment.ErrForbidden
+	}
+
+	// resolve proof payment URL from media
+	err = s.resolveProofPaymentURL(ctx, &result)
+	if err != nil {
+		return payment.Payment{}, err
+	}
+
 	return result, nil
 }
 
-func (s *service) GetPayments(ctx context.Context) ([]payment.Payment, error) {
+func (s *service) GetPayments(ctx context.Context, filter payment.GetPaymentsFilter) ([]payment.Payment, error) {
+	// users other than administrators only get their own
+	// payments
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !caller.IsAdmin() {
+		if filter.UserID != "" && filter.UserID != caller.ID {
+			return nil, payment.ErrForbidden
+		}
+		filter.UserID = caller.ID
+	}
+
 	// get pg store client without transaction
-	pgStoreClient, err := s.pgStore.NewClient(false)
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
 	if err != nil {
 		return nil, err
 	}
 
 	// get payments from pgstore
-	result, err := pgStoreClient.GetPayments(ctx)
+	result, err := pgStoreClient.GetPayments(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
+	// resolve proof payment URL from media
+	for i := range result {
+		err = s.resolveProofPaymentURL(ctx, &result[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return result, nil
 }
 
@@ -95,34 +188,448 @@ func (s *service) UpdatePayment(ctx context.Context, reqPayment payment.Payment)
 	reqPayment.UpdateTime = s.timeNow()
 
 	// get pg store client using transaction
-	pgStoreClient, err := s.pgStore.NewClient(false)
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	// get current payment from pgstore
+	current, err := pgStoreClient.GetPaymentByID(ctx, reqPayment.ID)
+	if err != nil {
+		return err
+	}
+
+	// a payment can not be moved to another user
+	if reqPayment.UserID != current.UserID {
+		return payment.ErrInvalidUserID
+	}
+
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the payment user can only update a pending payment
+	// without changing its status
+	if !caller.IsAdmin() {
+		if current.UserID != caller.ID {
+			return payment.ErrForbidden
+		}
+
+		if current.Status != payment.StatusPending || reqPayment.Status != current.Status {
+			return payment.ErrForbidden
+		}
+	}
+
+	// the new proof payment media is validated as on creation
+	if reqPayment.ProofPaymentMediaID != current.ProofPaymentMediaID {
+		if reqPayment.ProofPaymentMediaID == "" && reqPayment.ProofPaymentURL == "" {
+			return payment.ErrInvalidProofPaymentURL
+		}
+
+		if reqPayment.ProofPaymentMediaID != "" {
+			err = s.validateProofPaymentMedia(ctx, reqPayment)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// the purchased product and its price are kept
+	reqPayment.ProductType = current.ProductType
+	reqPayment.ProductID = current.ProductID
+	reqPayment.ContentID = current.ContentID
+	reqPayment.Amount = current.Amount
+	reqPayment.Quota = current.Quota
+	reqPayment.SubscriptionID = current.SubscriptionID
+	reqPayment.VoucherID = current.VoucherID
+	reqPayment.Discount = current.Discount
+	reqPayment.RefundedAmount = current.RefundedAmount
+
+	// the review is kept, only administrators review payments
+	reqPayment.ReviewerID = current.ReviewerID
+	reqPayment.ClaimTime = current.ClaimTime
+	if !caller.IsAdmin() {
+		reqPayment.ReviewReason = current.ReviewReason
+	}
+
+	// a payment claimed by another administrator is reviewed
+	// by them, the claim ends with the review
+	if reqPayment.Status != current.Status {
+		if s.isClaimedByOther(current, caller.ID) {
+			return payment.ErrPaymentAlreadyClaimed
+		}
+
+		reqPayment.ReviewerID = caller.ID
+		reqPayment.ClaimTime = time.Time{}
+	}
+
+	// a payment is only refunded by RefundPayment, and stays
+	// refunded
+	if (current.Status == payment.StatusRefunded || reqPayment.Status == payment.StatusRefunded) && reqPayment.Status != current.Status {
+		return payment.ErrPaymentNotRefundable
+	}
+
+	// an approved payment is refunded by RefundPayment instead,
+	// so its product is adjusted to the refunded amount
+	if current.Status == payment.StatusDone && reqPayment.Status != current.Status {
+		return payment.ErrPaymentAlreadyApproved
+	}
+
+	approved := current.Status != payment.StatusDone && reqPayment.Status == payment.StatusDone
+
+	grant := getPaymentEntitlement(current)
+
+	// the subscription period follows its payments, the
+	// quota of a plan is only valid within the paid period
+	var sub payment.Subscription
+	updateSubscription := current.ProductType == payment.ProductTypePlan && approved
+	if updateSubscription {
+		sub, err = pgStoreClient.GetSubscriptionByID(ctx, current.SubscriptionID)
+		if err != nil {
+			return err
+		}
+
+		s.extendSubscription(&sub, reqPayment.UpdateTime)
+		grant.ExpireTime = sub.EndTime
+	}
+
+	// the invoice is issued along with the approval
+	var inv *payment.Invoice
+	if approved {
+		issued, err := s.buildInvoice(ctx, current)
+		if err != nil {
+			return err
+		}
+		inv = &issued
+	}
+
+	// the product is granted along with the approval
+	var reqGrant *entitlement.Entitlement
+	if approved {
+		reqGrant = &grant
+	}
+
+	// the payment user is notified of the review
+	notif := buildPaymentNotification(reqPayment, current)
+
+	// the status change is published, e.g. to update the type
+	// of the user by their subscriptions
+	events, err := getStatusEvents(reqPayment, current)
 	if err != nil {
 		return err
 	}
 
-	// inserts task in pgstore
+	// updates payment, its subscription, entitlements and
+	// invoice in pgstore
+	return s.updatePaymentSubscription(ctx, reqPayment, sub, updateSubscription, reqGrant, inv, notif, events)
+}
+
+// updatePaymentSubscription updates the given payment, and
+// the given subscription if updateSubscription is true, in a
+// transaction. The given entitlement is granted, the given
+// invoice is issued, and the given notification and events
+// are written to their outboxes as well if any.
+func (s *service) updatePaymentSubscription(ctx context.Context, reqPayment payment.Payment, sub payment.Subscription, updateSubscription bool, grant *entitlement.Entitlement, inv *payment.Invoice, notif *notification.Notification, events []event.Event) error {
+	// get pg store client using transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	// the payment is locked by the update, so it is not
+	// reviewed concurrently
 	err = pgStoreClient.UpdatePayment(ctx, reqPayment)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return err
+	}
+
+	if grant != nil {
+		// the entitlement might be left granted by an approval
+		// of the payment not completed before
+		_, err = s.entitlement.GrantEntitlement(pgStoreClient.ShareTx(ctx), *grant)
+		if err != nil && err != entitlement.ErrEntitlementAlreadyExist {
+			pgStoreClient.Rollback()
+			return err
+		}
+	}
+
+	if updateSubscription {
+		err = pgStoreClient.UpdateSubscription(ctx, sub)
+		if err != nil {
+			pgStoreClient.Rollback()
+			return err
+		}
+	}
+
+	if inv != nil {
+		err = s.createInvoice(ctx, pgStoreClient, inv)
+		if err != nil && err != payment.ErrInvoiceAlreadyExist {
+			pgStoreClient.Rollback()
+			return err
+		}
+	}
+
+	if notif != nil {
+		_, err = pgStoreClient.CreateNotification(ctx, *notif)
+		if err != nil {
+			pgStoreClient.Rollback()
+			return err
+		}
+	}
+
+	for _, e := range events {
+		err = pgStoreClient.CreateEvent(ctx, e)
+		if err != nil {
+			pgStoreClient.Rollback()
+			return err
+		}
+	}
+
+	return pgStoreClient.Commit()
+}
+
+// GetBundles returns all bundles can be purchased.
+func (s *service) GetBundles(ctx context.Context) ([]payment.Bundle, error) {
+	return s.config.Bundles, nil
+}
+
+// resolveProduct validates the product purchased by the given
+// payment, and fills the payment price based on the product.
+//
+// A payment for a content without product type purchases the
+// current template of the content.
+func (s *service) resolveProduct(ctx context.Context, reqPayment *payment.Payment) error {
+	if reqPayment.ProductType == payment.ProductTypeUnknown && reqPayment.ContentID != "" {
+		reqPayment.ProductType = payment.ProductTypeContent
+	}
+
+	switch reqPayment.ProductType {
+	case payment.ProductTypeTemplate:
+		reqPayment.ContentID = ""
+		return s.resolveTemplatePrice(ctx, reqPayment)
+
+	case payment.ProductTypeContent:
+		if reqPayment.ContentID == "" {
+			return payment.ErrInvalidContentID
+		}
+
+		// the template can only be purchased for own content
+		result, err := s.content.GetContentByID(ctx, reqPayment.ContentID)
+		if err != nil {
+			if err == content.ErrDataNotFound || err == content.ErrForbidden {
+				return payment.ErrInvalidContentID
+			}
+			return err
+		}
+
+		if result.UserID != reqPayment.UserID {
+			return payment.ErrInvalidContentID
+		}
+
+		if reqPayment.ProductID == "" {
+			reqPayment.ProductID = result.TemplateID
+		}
+
+		return s.resolveTemplatePrice(ctx, reqPayment)
+
+	case payment.ProductTypeBundle:
+		reqPayment.ContentID = ""
+		for _, b := range s.config.Bundles {
+			if b.ID == reqPayment.ProductID {
+				reqPayment.Amount = b.Price
+				reqPayment.Quota = b.Quota
+				return nil
+			}
+		}
+		return payment.ErrInvalidProductID
+
+	case payment.ProductTypePlan:
+		reqPayment.ContentID = ""
+		if reqPayment.ProductID == "" {
+			return payment.ErrInvalidProductID
+		}
+
+		// get pg store client without transaction
+		pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+		if err != nil {
+			return err
+		}
+
+		plan, err := pgStoreClient.GetPlanByID(ctx, reqPayment.ProductID)
+		if err != nil {
+			if err == payment.ErrDataNotFound {
+				return payment.ErrInvalidProductID
+			}
+			return err
+		}
+
+		if !plan.Active {
+			return payment.ErrInvalidProductID
+		}
+
+		reqPayment.Amount = plan.Price
+		reqPayment.Quota = plan.Quota
+		return nil
+	}
+
+	return payment.ErrInvalidProductType
+}
+
+// resolveSubscription fills the subscription of the given
+// plan payment. The payment renews the current subscription
+// of the user to the plan, otherwise the user is subscribed to
+// the plan once the payment is approved.
+func (s *service) resolveSubscription(ctx context.Context, pgStoreClient PGStoreClient, reqPayment *payment.Payment) error {
+	subs, err := pgStoreClient.GetSubscriptions(ctx, payment.GetSubscriptionsFilter{
+		UserID: reqPayment.UserID,
+		PlanID: reqPayment.ProductID,
+		Statuses: []payment.SubscriptionStatus{
+			payment.SubscriptionStatusPending,
+			payment.SubscriptionStatusActive,
+		},
+	})
 	if err != nil {
 		return err
 	}
 
-	user, err := s.user.GetUserByID(ctx, reqPayment.UserID)
+	if len(subs) > 0 {
+		reqPayment.SubscriptionID = subs[0].ID
+		return nil
+	}
+
+	subscriptionID, err := pgStoreClient.CreateSubscription(ctx, payment.Subscription{
+		UserID:     reqPayment.UserID,
+		PlanID:     reqPayment.ProductID,
+		Status:     payment.SubscriptionStatusPending,
+		AutoRenew:  true,
+		CreateTime: reqPayment.CreateTime,
+	})
 	if err != nil {
 		return err
 	}
 
-	if reqPayment.Status == payment.StatusDone {
-		user.Quota = 3
-		user.Type = auth.TypePemium
-	} else if reqPayment.Status == payment.StatusRejected {
-		user.Quota = 0
-		user.Type = auth.TypeFree
+	reqPayment.SubscriptionID = subscriptionID
+	return nil
+}
+
+// resolveTemplatePrice fills the given payment price with the
+// price of the purchased template.
+func (s *service) resolveTemplatePrice(ctx context.Context, reqPayment *payment.Payment) error {
+	if reqPayment.ProductID == "" {
+		return payment.ErrInvalidProductID
+	}
+
+	t, err := s.template.GetTemplateByID(ctx, reqPayment.ProductID)
+	if err != nil {
+		if err == template.ErrTemplateNotFound || err == template.ErrInvalidTemplateID {
+			return payment.ErrInvalidProductID
+		}
+		return err
+	}
+
+	// only templates with a price are sold
+	if t.Price <= 0 {
+		return payment.ErrInvalidProductID
+	}
+
+	price, err := money.New(t.Price, t.Currency)
+	if err != nil {
+		return payment.ErrInvalidProductID
+	}
+
+	reqPayment.Amount = price
+	reqPayment.Quota = 0
+	return nil
+}
+
+// isAmountMatched returns whether the amount given by the
+// caller matches the actual amount of a payment. A zero amount
+// is not given, and the currency can be omitted.
+func isAmountMatched(requested, actual money.Money) bool {
+	if requested.IsZero() && requested.Currency == "" {
+		return true
+	}
+
+	if requested.Amount != actual.Amount {
+		return false
+	}
+
+	return requested.Currency == "" || money.NormalizeCurrency(requested.Currency) == actual.Currency
+}
+
+// getPaymentEntitlement returns the entitlement granted by the
+// given payment.
+func getPaymentEntitlement(p payment.Payment) entitlement.Entitlement {
+	e := entitlement.Entitlement{
+		UserID:    p.UserID,
+		PaymentID: p.ID,
+	}
+
+	switch p.ProductType {
+	case payment.ProductTypeTemplate:
+		e.Type = entitlement.TypeTemplate
+		e.TemplateID = p.ProductID
+	case payment.ProductTypeContent:
+		e.Type = entitlement.TypeContent
+		e.TemplateID = p.ProductID
+		e.ContentID = p.ContentID
+	case payment.ProductTypeBundle, payment.ProductTypePlan:
+		e.Type = entitlement.TypeQuota
+		e.Quota = p.Quota
+	}
+
+	return e
+}
+
+// getCaller returns the user calling the service, based on
+// the user ID in the given context.
+func (s *service) getCaller(ctx context.Context) (auth.User, error) {
+	userID, ok := contextlib.GetUserID(ctx)
+	if !ok {
+		return auth.User{}, payment.ErrInvalidUserID
+	}
+
+	return s.user.GetUserByID(ctx, userID)
+}
+
+// validateProofPaymentMedia validates that the media used as
+// proof of the given payment exists, is an image and owned by
+// the payment user.
+func (s *service) validateProofPaymentMedia(ctx context.Context, reqPayment payment.Payment) error {
+	m, err := s.media.GetMediaByID(ctx, reqPayment.ProofPaymentMediaID)
+	if err != nil {
+		if err == media.ErrDataNotFound || err == media.ErrInvalidMediaID {
+			return payment.ErrInvalidProofPaymentMediaID
+		}
+		return err
+	}
+
+	if m.UserID != reqPayment.UserID || !m.IsImage() || m.Status == media.StatusFailed {
+		return payment.ErrInvalidProofPaymentMediaID
+	}
+
+	return nil
+}
+
+// resolveProofPaymentURL fills proof payment media URL of the
+// given payment with a signed URL of the proof payment media,
+// if any.
+func (s *service) resolveProofPaymentURL(ctx context.Context, p *payment.Payment) error {
+	if p.ProofPaymentMediaID == "" {
+		return nil
 	}
 
-	err = s.user.UpdateUser(ctx, user)
+	m, err := s.media.GetMediaByID(ctx, p.ProofPaymentMediaID)
 	if err != nil {
+		// the media might have been deleted by its owner
+		if err == media.ErrDataNotFound {
+			return nil
+		}
 		return err
 	}
 
+	p.ProofPaymentMediaURL = m.URL
 	return nil
 }
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"fmt"
+	"hbdtoyou/internal/notification"
+	"hbdtoyou/internal/payment"
+)
+
+// buildPaymentNotification returns the notification of the
+// given payment review, or nil if the status change is not
+// notified to the payment user.
+func buildPaymentNotification(reqPayment payment.Payment, current payment.Payment) *notification.Notification {
+	if reqPayment.Status == current.Status {
+		return nil
+	}
+
+	n := notification.Notification{
+		UserID: current.UserID,
+		Data: map[string]string{
+			"payment_id":   current.ID,
+			"product_type": current.ProductType.String(),
+			"amount":       current.Amount.String(),
+		},
+		Status:          notification.StatusPending,
+		NextAttemptTime: reqPayment.UpdateTime,
+		CreateTime:      reqPayment.UpdateTime,
+	}
+
+	switch reqPayment.Status {
+	case payment.StatusDone:
+		n.Event = notification.EventPaymentApproved
+		n.Subject = "Your payment is approved"
+		n.Body = fmt.Sprintf("Your payment of %s for %s has been approved.", current.Amount.String(), current.ProductType.String())
+	case payment.StatusRejected:
+		n.Event = notification.EventPaymentRejected
+		n.Subject = "Your payment is rejected"
+		n.Body = fmt.Sprintf("Your payment of %s for %s has been rejected.", current.Amount.String(), current.ProductType.String())
+		if reqPayment.ReviewReason != "" {
+			n.Body = fmt.Sprintf("%s Reason: %s", n.Body, reqPayment.ReviewReason)
+			n.Data["reason"] = reqPayment.ReviewReason
+		}
+	default:
+		return nil
+	}
+
+	return &n
+}
@@ -0,0 +1,418 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"hbdtoyou/internal/entitlement"
+	"hbdtoyou/internal/payment"
+	"hbdtoyou/pkg/money"
+	"log"
+	"strings"
+	"time"
+)
+
+// RefundPayment refunds the given amount of an approved
+// payment and returns the created refund ID.
+func (s *service) RefundPayment(ctx context.Context, reqRefund payment.Refund) (string, error) {
+	// validate id
+	if reqRefund.PaymentID == "" {
+		return "", payment.ErrInvalidPaymentID
+	}
+
+	// a refund is granted unless stated otherwise
+	if reqRefund.Type == payment.RefundTypeUnknown {
+		reqRefund.Type = payment.RefundTypeRefund
+	}
+
+	// validate fields
+	err := validateRefund(&reqRefund)
+	if err != nil {
+		return "", err
+	}
+
+	// only administrators can refund payments
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if !caller.IsAdmin() {
+		return "", payment.ErrForbidden
+	}
+
+	// update fields
+	reqRefund.ActorID = caller.ID
+	reqRefund.CreateTime = s.timeNow()
+
+	// get pg store client using transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, true)
+	if err != nil {
+		return "", err
+	}
+
+	// the payment is locked so concurrent refunds can not
+	// exceed its amount
+	current, err := pgStoreClient.GetPaymentByIDForUpdate(ctx, reqRefund.PaymentID)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return "", err
+	}
+
+	if current.Status != payment.StatusDone {
+		pgStoreClient.Rollback()
+		return "", payment.ErrPaymentNotRefundable
+	}
+
+	// the amount is in the payment currency if omitted
+	if reqRefund.Amount.Currency == "" {
+		reqRefund.Amount.Currency = current.Amount.Currency
+	}
+
+	refunded, err := current.RefundedAmount.Add(reqRefund.Amount)
+	if err != nil || refunded.Amount > current.Amount.Amount {
+		pgStoreClient.Rollback()
+		return "", payment.ErrInvalidRefundAmount
+	}
+
+	// a chargeback is already reversed by the bank, only a
+	// refund is made through the gateway
+	var gateway payment.Gateway
+	if current.Gateway != "" && reqRefund.Type == payment.RefundTypeRefund {
+		var ok bool
+		gateway, ok = s.gateways[current.Gateway]
+		if !ok {
+			pgStoreClient.Rollback()
+			return "", errGatewayNotFound
+		}
+	}
+
+	// the refund is counted as refunded, so concurrent
+	// refunds can not exceed the payment amount
+	reqPayment := current
+	reqPayment.RefundedAmount = refunded
+
+	// a refund made without gateway is completed at once,
+	// otherwise it is saved as pending before requesting the
+	// gateway
+	reqRefund.Status = payment.RefundStatusCompleted
+	if gateway != nil {
+		reqRefund.Status = payment.RefundStatusPending
+	}
+
+	reqRefund.ID, err = pgStoreClient.CreateRefund(ctx, reqRefund)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return "", err
+	}
+
+	err = s.applyRefund(ctx, pgStoreClient, reqPayment, reqRefund.Status == payment.RefundStatusCompleted)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return "", err
+	}
+
+	err = pgStoreClient.Commit()
+	if err != nil {
+		return "", err
+	}
+
+	// a declined refund is failed, otherwise the refund is
+	// left pending to be resumed
+	if gateway != nil {
+		err = s.makeGatewayRefund(ctx, gateway, current, reqRefund)
+		if err == payment.ErrRefundDeclined {
+			return "", err
+		}
+		if err != nil {
+			log.Printf("[Payment Service][RefundPayment] Refund %s is left pending. Err: %s\n", reqRefund.ID, err.Error())
+		}
+	}
+
+	return reqRefund.ID, nil
+}
+
+// ResumePendingRefunds makes the refunds pending for longer
+// than the pending timeout through their gateway again, and
+// returns the number of resumed refunds.
+//
+// A refund is left pending when the service stops or fails
+// after the refund is saved, the gateway makes a refund once
+// per refund ID.
+func (s *service) ResumePendingRefunds(ctx context.Context) (int, error) {
+	before := s.timeNow().Add(-s.config.PendingRefundTimeout)
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return 0, err
+	}
+
+	refunds, err := pgStoreClient.GetPendingRefunds(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+
+	var resumed int
+	for _, r := range refunds {
+		p, err := pgStoreClient.GetPaymentByID(ctx, r.PaymentID)
+		if err != nil {
+			log.Printf("[Payment Service][ResumePendingRefunds] Failed to get payment of refund %s. Err: %s\n", r.ID, err.Error())
+			continue
+		}
+
+		gateway, ok := s.gateways[p.Gateway]
+		if !ok {
+			log.Printf("[Payment Service][ResumePendingRefunds] Gateway %q of refund %s not found\n", p.Gateway, r.ID)
+			continue
+		}
+
+		err = s.makeGatewayRefund(ctx, gateway, p, r)
+		if err != nil {
+			log.Printf("[Payment Service][ResumePendingRefunds] Failed to resume refund %s. Err: %s\n", r.ID, err.Error())
+			continue
+		}
+
+		resumed++
+	}
+
+	return resumed, nil
+}
+
+// makeGatewayRefund requests the given gateway to make the
+// given pending refund of the given payment, then completes
+// the refund, or fails it if the gateway declined it.
+//
+// The refund is left pending if the gateway can not be
+// reached or the refund can not be updated, so it is resumed
+// later.
+func (s *service) makeGatewayRefund(ctx context.Context, gateway payment.Gateway, p payment.Payment, reqRefund payment.Refund) error {
+	reference, errGateway := gateway.Refund(ctx, p.GatewayReference, reqRefund.ID, reqRefund.Amount, reqRefund.Reason)
+	if errGateway != nil {
+		if !errors.Is(errGateway, payment.ErrRefundDeclined) {
+			return errGateway
+		}
+		errGateway = payment.ErrRefundDeclined
+	}
+
+	// the reply of the gateway is saved even if the request
+	// is canceled meanwhile
+	ctx = context.WithoutCancel(ctx)
+
+	// get pg store client using transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	current, err := pgStoreClient.GetPaymentByIDForUpdate(ctx, p.ID)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return err
+	}
+
+	// the refund might have been updated concurrently
+	pending, err := pgStoreClient.GetRefundByIDForUpdate(ctx, reqRefund.ID)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return err
+	}
+
+	if pending.Status != payment.RefundStatusPending {
+		pgStoreClient.Rollback()
+		return errGateway
+	}
+
+	pending.UpdateTime = s.timeNow()
+	pending.Status = payment.RefundStatusCompleted
+	pending.GatewayReference = reference
+	if errGateway != nil {
+		pending.Status = payment.RefundStatusFailed
+	}
+
+	err = pgStoreClient.UpdateRefund(ctx, pending)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return err
+	}
+
+	// the amount of a declined refund is no longer counted
+	// as refunded
+	if errGateway != nil {
+		current.RefundedAmount.Amount -= pending.Amount.Amount
+		current.UpdateTime = pending.UpdateTime
+		err = pgStoreClient.UpdatePayment(ctx, current)
+		if err != nil {
+			pgStoreClient.Rollback()
+			return err
+		}
+
+		err = pgStoreClient.Commit()
+		if err != nil {
+			return err
+		}
+
+		return errGateway
+	}
+
+	err = s.applyRefund(ctx, pgStoreClient, current, true)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return err
+	}
+
+	return pgStoreClient.Commit()
+}
+
+// applyRefund updates the given payment with its refunded
+// amount using the given store client. Once the refund is
+// completed, a fully refunded payment is marked as refunded
+// and the product of the payment is adjusted.
+//
+// The entitlements of the payment are adjusted within the
+// transaction of the given store client.
+func (s *service) applyRefund(ctx context.Context, pgStoreClient PGStoreClient, reqPayment payment.Payment, completed bool) error {
+	reqPayment.UpdateTime = s.timeNow()
+
+	fullyRefunded := completed && reqPayment.RefundedAmount.Amount == reqPayment.Amount.Amount
+	if fullyRefunded {
+		reqPayment.Status = payment.StatusRefunded
+	}
+
+	err := pgStoreClient.UpdatePayment(ctx, reqPayment)
+	if err != nil {
+		return err
+	}
+
+	if !completed {
+		return nil
+	}
+
+	// the paid period of a refunded plan is no longer valid
+	if fullyRefunded && reqPayment.ProductType == payment.ProductTypePlan {
+		sub, err := pgStoreClient.GetSubscriptionByID(ctx, reqPayment.SubscriptionID)
+		if err != nil {
+			return err
+		}
+
+		sub.Status = payment.SubscriptionStatusLapsed
+		sub.EndTime = reqPayment.UpdateTime
+		sub.RenewTime = time.Time{}
+		sub.UpdateTime = reqPayment.UpdateTime
+
+		err = pgStoreClient.UpdateSubscription(ctx, sub)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the product is revoked or its unused quota reduced
+	// along with the refund
+	txCtx := pgStoreClient.ShareTx(ctx)
+	if fullyRefunded {
+		err = s.entitlement.RevokeEntitlementsByPaymentID(txCtx, reqPayment.ID)
+		if err != nil {
+			return err
+		}
+	} else if reqPayment.Quota > 0 {
+		entitlements, err := s.entitlement.GetEntitlements(txCtx, reqPayment.UserID)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range entitlements {
+			if e.PaymentID != reqPayment.ID || e.Type != entitlement.TypeQuota {
+				continue
+			}
+
+			err = s.entitlement.LimitQuotaByPaymentID(txCtx, reqPayment.ID, getRemainingQuota(reqPayment, e, reqPayment.RefundedAmount))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// the refund is published, e.g. to update the type of
+	// the user by their subscriptions
+	refundEvent, err := newPaymentEvent(payment.EventRefunded, reqPayment, reqPayment.UpdateTime)
+	if err != nil {
+		return err
+	}
+
+	return pgStoreClient.CreateEvent(ctx, refundEvent)
+}
+
+// GetRefundsByPaymentID returns all refunds of a payment with
+// the given payment ID.
+func (s *service) GetRefundsByPaymentID(ctx context.Context, paymentID string) ([]payment.Refund, error) {
+	// validate id
+	if paymentID == "" {
+		return nil, payment.ErrInvalidPaymentID
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := pgStoreClient.GetPaymentByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the payment user and administrators can read them
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !caller.IsAdmin() && p.UserID != caller.ID {
+		return nil, payment.ErrForbidden
+	}
+
+	return pgStoreClient.GetRefundsByPaymentID(ctx, paymentID)
+}
+
+// getRemainingQuota returns the quota of the given quota
+// entitlement granted by the given payment kept after the
+// given amount is refunded. The quota already used is kept,
+// the unused quota is reduced in proportion to the amount
+// refunded.
+func getRemainingQuota(p payment.Payment, e entitlement.Entitlement, refunded money.Money) int {
+	if p.Amount.Amount <= 0 {
+		return e.Quota
+	}
+
+	unused := int64(p.Quota - e.UsedQuota)
+	if unused <= 0 {
+		return e.UsedQuota
+	}
+
+	return e.UsedQuota + int(unused*(p.Amount.Amount-refunded.Amount)/p.Amount.Amount)
+}
+
+// validateRefund validates fields of the given refund whether
+// its comply the predetermined rules.
+func validateRefund(reqRefund *payment.Refund) error {
+	if _, ok := payment.RefundTypeList[reqRefund.Type]; !ok {
+		return payment.ErrInvalidRefundType
+	}
+
+	if reqRefund.Amount.Amount <= 0 {
+		return payment.ErrInvalidRefundAmount
+	}
+
+	if reqRefund.Amount.Currency != "" {
+		if !money.IsCurrency(reqRefund.Amount.Currency) {
+			return payment.ErrInvalidRefundAmount
+		}
+		reqRefund.Amount.Currency = money.NormalizeCurrency(reqRefund.Amount.Currency)
+	}
+
+	reqRefund.Reason = strings.TrimSpace(reqRefund.Reason)
+	if reqRefund.Reason == "" {
+		return payment.ErrInvalidRefundReason
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"hbdtoyou/internal/payment"
+	"strings"
+)
+
+// maxReviewPayments is the maximum number of payments can be
+// reviewed at once.
+const maxReviewPayments = 100
+
+// GetReviewQueue returns pending payments to be reviewed, the
+// oldest first.
+func (s *service) GetReviewQueue(ctx context.Context, filter payment.ReviewQueueFilter) ([]payment.Payment, error) {
+	// only administrators can read the queue
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !caller.IsAdmin() {
+		return nil, payment.ErrForbidden
+	}
+
+	var reviewerID string
+	if filter.Claimable {
+		reviewerID = caller.ID
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// get payments from pgstore
+	result, err := pgStoreClient.GetPendingPayments(ctx, reviewerID, s.timeNow().Add(-s.config.ClaimDuration))
+	if err != nil {
+		return nil, err
+	}
+
+	// resolve proof payment URL from media
+	for i := range result {
+		err = s.resolveProofPaymentURL(ctx, &result[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// ClaimPayment claims a pending payment with the given payment
+// ID for the caller to review, and returns the claimed
+// payment.
+func (s *service) ClaimPayment(ctx context.Context, paymentID string) (payment.Payment, error) {
+	// validate id
+	if paymentID == "" {
+		return payment.Payment{}, payment.ErrInvalidPaymentID
+	}
+
+	// only administrators can claim payments
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return payment.Payment{}, err
+	}
+
+	if !caller.IsAdmin() {
+		return payment.Payment{}, payment.ErrForbidden
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return payment.Payment{}, err
+	}
+
+	current, err := pgStoreClient.GetPaymentByID(ctx, paymentID)
+	if err != nil {
+		return payment.Payment{}, err
+	}
+
+	if current.Status != payment.StatusPending {
+		return payment.Payment{}, payment.ErrPaymentNotPending
+	}
+
+	// the claim is checked again when it is made, another
+	// administrator might claim the payment in between
+	now := s.timeNow()
+	err = pgStoreClient.ClaimPayment(ctx, paymentID, caller.ID, now, now.Add(-s.config.ClaimDuration))
+	if err != nil {
+		return payment.Payment{}, err
+	}
+
+	return s.GetPaymentByID(ctx, paymentID)
+}
+
+// ReleasePayment releases the claim of the caller on a payment
+// with the given payment ID.
+func (s *service) ReleasePayment(ctx context.Context, paymentID string) error {
+	// validate id
+	if paymentID == "" {
+		return payment.ErrInvalidPaymentID
+	}
+
+	// only administrators claim payments
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !caller.IsAdmin() {
+		return payment.ErrForbidden
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	return pgStoreClient.ReleasePayment(ctx, paymentID, caller.ID)
+}
+
+// ReviewPayments approves or rejects the pending payments in
+// the given review, and returns the result of each payment.
+func (s *service) ReviewPayments(ctx context.Context, reqReview payment.Review) ([]payment.ReviewResult, error) {
+	// validate fields
+	err := validateReview(&reqReview)
+	if err != nil {
+		return nil, err
+	}
+
+	// only administrators can review payments
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !caller.IsAdmin() {
+		return nil, payment.ErrForbidden
+	}
+
+	result := make([]payment.ReviewResult, 0, len(reqReview.PaymentIDs))
+	for _, paymentID := range reqReview.PaymentIDs {
+		result = append(result, payment.ReviewResult{
+			PaymentID: paymentID,
+			Err:       s.reviewPayment(ctx, paymentID, reqReview),
+		})
+	}
+
+	return result, nil
+}
+
+// reviewPayment approves or rejects a pending payment with the
+// given payment ID by the given review.
+func (s *service) reviewPayment(ctx context.Context, paymentID string, reqReview payment.Review) error {
+	current, err := s.GetPaymentByID(ctx, paymentID)
+	if err != nil {
+		return err
+	}
+
+	// an approved payment is not rejected by a bulk review
+	if current.Status != payment.StatusPending {
+		return payment.ErrPaymentNotPending
+	}
+
+	current.Status = reqReview.Status
+	current.ReviewReason = reqReview.Reason
+
+	return s.UpdatePayment(ctx, current)
+}
+
+// isClaimedByOther returns whether the given payment is claimed
+// by an administrator other than the given one.
+func (s *service) isClaimedByOther(p payment.Payment, reviewerID string) bool {
+	if p.Status != payment.StatusPending || p.ClaimTime.IsZero() || p.ReviewerID == reviewerID {
+		return false
+	}
+
+	return p.ClaimTime.After(s.timeNow().Add(-s.config.ClaimDuration))
+}
+
+// validateReview validates fields of the given review whether
+// its comply the predetermined rules.
+func validateReview(reqReview *payment.Review) error {
+	if reqReview.Status != payment.StatusDone && reqReview.Status != payment.StatusRejected {
+		return payment.ErrInvalidReview
+	}
+
+	// each payment is reviewed once
+	paymentIDs := make([]string, 0, len(reqReview.PaymentIDs))
+	seen := make(map[string]struct{}, len(reqReview.PaymentIDs))
+	for _, id := range reqReview.PaymentIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		paymentIDs = append(paymentIDs, id)
+	}
+	reqReview.PaymentIDs = paymentIDs
+
+	if len(reqReview.PaymentIDs) == 0 || len(reqReview.PaymentIDs) > maxReviewPayments {
+		return payment.ErrInvalidReview
+	}
+
+	reqReview.Reason = strings.TrimSpace(reqReview.Reason)
+	if reqReview.Status == payment.StatusRejected && reqReview.Reason == "" {
+		return payment.ErrInvalidReviewReason
+	}
+
+	return nil
+}
@@ -1,27 +1,152 @@
 package service
 
 import (
+	"errors"
 	"hbdtoyou/internal/auth"
 	"hbdtoyou/internal/content"
+	"hbdtoyou/internal/entitlement"
+	"hbdtoyou/internal/media"
+	"hbdtoyou/internal/payment"
+	"hbdtoyou/internal/template"
+	"hbdtoyou/pkg/money"
 	"time"
 )
 
+// Following constans are config default values.
+const (
+	defaultRenewalLead   = 3 * 24 * time.Hour
+	defaultClaimDuration = 15 * time.Minute
+
+	defaultPendingRefundTimeout = 10 * time.Minute
+)
+
+// Followings are the known error returned from service.
+var (
+	errInvalidBundle = errors.New("invalid bundle")
+
+	errInvalidGateway  = errors.New("invalid gateway")
+	errGatewayNotFound = errors.New("gateway not found")
+)
+
 // service implements subject.Service.
 type service struct {
-	pgStore PGStore
-	user    auth.Service
-	content content.Service
-	timeNow func() time.Time
+	pgStore     PGStore
+	user        auth.Service
+	content     content.Service
+	template    template.Service
+	media       media.Service
+	entitlement entitlement.Service
+	gateways    map[string]payment.Gateway
+	config      Config
+	timeNow     func() time.Time
+}
+
+// Config denotes service configuration
+//
+// Adding a new field should also add the corresponding default
+// value in getDefaultConfig().
+type Config struct {
+	// Bundles is the list of bundles can be purchased.
+	Bundles []payment.Bundle
+
+	// RenewalLead is how long before a subscription period
+	// ends the payment for the next period is created.
+	RenewalLead time.Duration
+
+	// ClaimDuration is how long a claim of an administrator
+	// on a payment to review lasts.
+	ClaimDuration time.Duration
+
+	// PendingRefundTimeout is how long a refund is pending
+	// before it is resumed by ResumePendingRefunds.
+	PendingRefundTimeout time.Duration
+}
+
+// getDefaultConfig returns service configuration with the
+// predefined default values.
+func getDefaultConfig() Config {
+	return Config{
+		Bundles:              []payment.Bundle{},
+		RenewalLead:          defaultRenewalLead,
+		ClaimDuration:        defaultClaimDuration,
+		PendingRefundTimeout: defaultPendingRefundTimeout,
+	}
 }
 
 // New creates a new service.
-func New(pgStore PGStore, user auth.Service, content content.Service) (*service, error) {
+func New(pgStore PGStore, user auth.Service, content content.Service, template template.Service, media media.Service, entitlement entitlement.Service, options ...Option) (*service, error) {
 	s := &service{
-		pgStore: pgStore,
-		user:    user,
-		content: content,
-		timeNow: time.Now,
+		pgStore:     pgStore,
+		user:        user,
+		content:     content,
+		template:    template,
+		media:       media,
+		entitlement: entitlement,
+		gateways:    make(map[string]payment.Gateway),
+		config:      getDefaultConfig(),
+		timeNow:     time.Now,
+	}
+
+	// apply options
+	for _, opt := range options {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
 	}
 
 	return s, nil
 }
+
+// Option controls the behavior of service.
+type Option func(*service) error
+
+// WithConfig returns Option to set service configuration.
+func WithConfig(config Config) Option {
+	return func(s *service) error {
+		if len(config.Bundles) > 0 {
+			if err := validateBundles(config.Bundles); err != nil {
+				return err
+			}
+			s.config.Bundles = config.Bundles
+		}
+		if config.RenewalLead > 0 {
+			s.config.RenewalLead = config.RenewalLead
+		}
+		if config.ClaimDuration > 0 {
+			s.config.ClaimDuration = config.ClaimDuration
+		}
+		if config.PendingRefundTimeout > 0 {
+			s.config.PendingRefundTimeout = config.PendingRefundTimeout
+		}
+		return nil
+	}
+}
+
+// WithGateway returns Option to register a payment gateway
+// with the given name. Payments processed by the gateway are
+// refunded through it.
+func WithGateway(name string, gateway payment.Gateway) Option {
+	return func(s *service) error {
+		if name == "" || gateway == nil {
+			return errInvalidGateway
+		}
+		s.gateways[name] = gateway
+		return nil
+	}
+}
+
+// validateBundles validates the given bundle configurations.
+func validateBundles(bundles []payment.Bundle) error {
+	ids := make(map[string]struct{}, len(bundles))
+	for _, b := range bundles {
+		if b.ID == "" || b.Quota <= 0 || b.Price.Amount <= 0 || !money.IsCurrency(b.Price.Currency) {
+			return errInvalidBundle
+		}
+		if _, ok := ids[b.ID]; ok {
+			return errInvalidBundle
+		}
+		ids[b.ID] = struct{}{}
+	}
+
+	return nil
+}
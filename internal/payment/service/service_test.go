@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"hbdtoyou/internal/auth"
+	"hbdtoyou/internal/media"
+	"hbdtoyou/internal/payment"
+	"hbdtoyou/pkg/money"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	contextlib "hbdtoyou/pkg/context"
+)
+
+// memoryStore is an in-memory PGStore holding the data used by
+// the tests. Transactions are not isolated.
+type memoryStore struct {
+	mu            sync.Mutex
+	payments      map[string]payment.Payment
+	plans         map[string]payment.Plan
+	subscriptions map[string]payment.Subscription
+	nextID        int
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		payments:      make(map[string]payment.Payment),
+		plans:         make(map[string]payment.Plan),
+		subscriptions: make(map[string]payment.Subscription),
+	}
+}
+
+func (s *memoryStore) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s-%d", prefix, s.nextID)
+}
+
+func (s *memoryStore) NewClient(ctx context.Context, useTx bool) (PGStoreClient, error) {
+	return &memoryStoreClient{s: s}, nil
+}
+
+// memoryStoreClient implements the PGStoreClient methods used
+// by the tests, the others panic.
+type memoryStoreClient struct {
+	PGStoreClient
+
+	s *memoryStore
+}
+
+func (c *memoryStoreClient) Commit() error   { return nil }
+func (c *memoryStoreClient) Rollback() error { return nil }
+
+func (c *memoryStoreClient) ShareTx(ctx context.Context) context.Context { return ctx }
+
+func (c *memoryStoreClient) CreatePayment(ctx context.Context, reqPayment payment.Payment) (string, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	reqPayment.ID = c.s.newID("payment")
+	c.s.payments[reqPayment.ID] = reqPayment
+	return reqPayment.ID, nil
+}
+
+func (c *memoryStoreClient) GetPaymentByID(ctx context.Context, paymentID string) (payment.Payment, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	p, ok := c.s.payments[paymentID]
+	if !ok {
+		return payment.Payment{}, payment.ErrDataNotFound
+	}
+	return p, nil
+}
+
+func (c *memoryStoreClient) UpdatePayment(ctx context.Context, reqPayment payment.Payment) error {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	c.s.payments[reqPayment.ID] = reqPayment
+	return nil
+}
+
+func (c *memoryStoreClient) GetPlanByID(ctx context.Context, planID string) (payment.Plan, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	p, ok := c.s.plans[planID]
+	if !ok {
+		return payment.Plan{}, payment.ErrDataNotFound
+	}
+	return p, nil
+}
+
+func (c *memoryStoreClient) UpdateSubscription(ctx context.Context, reqSubscription payment.Subscription) error {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	c.s.subscriptions[reqSubscription.ID] = reqSubscription
+	return nil
+}
+
+func (c *memoryStoreClient) GetRenewableSubscriptions(ctx context.Context, now time.Time) ([]payment.Subscription, error) {
+	return c.getSubscriptions(func(sub payment.Subscription) bool {
+		return sub.Status == payment.SubscriptionStatusActive && sub.AutoRenew && !sub.RenewTime.IsZero() && !sub.RenewTime.After(now)
+	}), nil
+}
+
+func (c *memoryStoreClient) GetEndedSubscriptions(ctx context.Context, now time.Time) ([]payment.Subscription, error) {
+	return c.getSubscriptions(func(sub payment.Subscription) bool {
+		return sub.Status == payment.SubscriptionStatusActive && !sub.EndTime.After(now)
+	}), nil
+}
+
+func (c *memoryStoreClient) HasActiveSubscription(ctx context.Context, userID string, now time.Time) (bool, error) {
+	subs := c.getSubscriptions(func(sub payment.Subscription) bool {
+		return sub.UserID == userID && sub.Status == payment.SubscriptionStatusActive && sub.EndTime.After(now)
+	})
+	return len(subs) > 0, nil
+}
+
+func (c *memoryStoreClient) getSubscriptions(match func(payment.Subscription) bool) []payment.Subscription {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	var result []payment.Subscription
+	for _, sub := range c.s.subscriptions {
+		if match(sub) {
+			result = append(result, sub)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// memoryUsers is an auth.Service returning the users it holds.
+type memoryUsers struct {
+	auth.Service
+
+	users map[string]auth.User
+}
+
+func (u memoryUsers) GetUserByID(ctx context.Context, userID string) (auth.User, error) {
+	user, ok := u.users[userID]
+	if !ok {
+		return auth.User{}, auth.ErrDataNotFound
+	}
+	return user, nil
+}
+
+func (u memoryUsers) UpdateUser(ctx context.Context, reqUser auth.User) error {
+	u.users[reqUser.ID] = reqUser
+	return nil
+}
+
+// memoryMedia is a media.Service returning the media it holds.
+type memoryMedia struct {
+	media.Service
+
+	media map[string]media.Media
+}
+
+func (m memoryMedia) GetMediaByID(ctx context.Context, mediaID string) (media.Media, error) {
+	result, ok := m.media[mediaID]
+	if !ok {
+		return media.Media{}, media.ErrDataNotFound
+	}
+	return result, nil
+}
+
+// newTestService returns a service over the given store, users
+// and media at a controlled time.
+func newTestService(t *testing.T, store *memoryStore, users memoryUsers, m memoryMedia, now *time.Time) *service {
+	s, err := New(store, users, nil, nil, m, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	s.timeNow = func() time.Time { return *now }
+	return s
+}
+
+func TestUpdatePaymentRejectsMediaOfOtherUser(t *testing.T) {
+	store := newMemoryStore()
+	now := time.Now()
+
+	users := memoryUsers{users: map[string]auth.User{
+		"user-1": {ID: "user-1", Role: auth.RoleUser},
+		"user-2": {ID: "user-2", Role: auth.RoleUser},
+	}}
+	m := memoryMedia{media: map[string]media.Media{
+		"media-1": {ID: "media-1", UserID: "user-1", MIMEType: "image/jpeg", Status: media.StatusReady},
+		"media-2": {ID: "media-2", UserID: "user-2", MIMEType: "image/jpeg", Status: media.StatusReady},
+		"media-3": {ID: "media-3", UserID: "user-1", MIMEType: "application/pdf", Status: media.StatusReady},
+	}}
+	s := newTestService(t, store, users, m, &now)
+
+	current := payment.Payment{
+		ID:                  "payment-1",
+		UserID:              "user-1",
+		ProofPaymentMediaID: "media-1",
+		Status:              payment.StatusPending,
+	}
+	store.payments[current.ID] = current
+
+	ctx := contextlib.SetUserID(context.Background(), "user-1")
+
+	tests := []struct {
+		name    string
+		mediaID string
+		want    error
+	}{
+		{name: "media of other user", mediaID: "media-2", want: payment.ErrInvalidProofPaymentMediaID},
+		{name: "media not image", mediaID: "media-3", want: payment.ErrInvalidProofPaymentMediaID},
+		{name: "unknown media", mediaID: "media-4", want: payment.ErrInvalidProofPaymentMediaID},
+		{name: "own media", mediaID: "media-1", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqPayment := current
+			reqPayment.ProofPaymentMediaID = tt.mediaID
+
+			err := s.UpdatePayment(ctx, reqPayment)
+			if err != tt.want {
+				t.Fatalf("UpdatePayment() error = %v, want %v", err, tt.want)
+			}
+
+			if tt.want != nil && store.payments[current.ID].ProofPaymentMediaID != current.ProofPaymentMediaID {
+				t.Errorf("proof payment media ID = %s, want %s", store.payments[current.ID].ProofPaymentMediaID, current.ProofPaymentMediaID)
+			}
+		})
+	}
+}
+
+func TestUpdatePaymentRefusesUnapproval(t *testing.T) {
+	store := newMemoryStore()
+	now := time.Now()
+
+	users := memoryUsers{users: map[string]auth.User{
+		"admin-1": {ID: "admin-1", Role: auth.RoleAdmin},
+	}}
+	s := newTestService(t, store, users, memoryMedia{}, &now)
+
+	current := payment.Payment{
+		ID:              "payment-1",
+		UserID:          "user-1",
+		ProofPaymentURL: "https://example.com/proof.jpg",
+		Status:          payment.StatusDone,
+	}
+	store.payments[current.ID] = current
+
+	ctx := contextlib.SetUserID(context.Background(), "admin-1")
+
+	for _, status := range []payment.Status{payment.StatusRejected, payment.StatusPending} {
+		reqPayment := current
+		reqPayment.Status = status
+
+		err := s.UpdatePayment(ctx, reqPayment)
+		if err != payment.ErrPaymentAlreadyApproved {
+			t.Errorf("UpdatePayment() to %s error = %v, want %v", status, err, payment.ErrPaymentAlreadyApproved)
+		}
+	}
+
+	if store.payments[current.ID].Status != payment.StatusDone {
+		t.Errorf("status = %s, want %s", store.payments[current.ID].Status, payment.StatusDone)
+	}
+}
+
+func TestRenewAndExpireSubscriptions(t *testing.T) {
+	store := newMemoryStore()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	users := memoryUsers{users: map[string]auth.User{
+		"user-1": {ID: "user-1", Type: auth.TypePemium},
+		"user-2": {ID: "user-2", Type: auth.TypePemium},
+	}}
+	s := newTestService(t, store, users, memoryMedia{}, &now)
+
+	store.plans["plan-1"] = payment.Plan{ID: "plan-1", Price: money.Money{Amount: 50000, Currency: "IDR"}, Interval: payment.IntervalMonthly, Quota: 10, Active: true}
+	store.plans["plan-2"] = payment.Plan{ID: "plan-2", Price: money.Money{Amount: 50000, Currency: "IDR"}, Interval: payment.IntervalMonthly, Quota: 10}
+
+	subs := []payment.Subscription{
+		// due to renew
+		{ID: "sub-due", UserID: "user-1", PlanID: "plan-1", AutoRenew: true, RenewTime: now.Add(-time.Hour), EndTime: now.Add(48 * time.Hour)},
+		// renews later
+		{ID: "sub-later", UserID: "user-1", PlanID: "plan-1", AutoRenew: true, RenewTime: now.Add(time.Hour), EndTime: now.Add(72 * time.Hour)},
+		// due, but its plan is inactive
+		{ID: "sub-inactive", UserID: "user-1", PlanID: "plan-2", AutoRenew: true, RenewTime: now.Add(-time.Hour), EndTime: now.Add(48 * time.Hour)},
+		// not renewing, its period has ended
+		{ID: "sub-ended", UserID: "user-2", PlanID: "plan-1", EndTime: now.Add(-time.Minute)},
+	}
+	for _, sub := range subs {
+		sub.Status = payment.SubscriptionStatusActive
+		store.subscriptions[sub.ID] = sub
+	}
+
+	ctx := context.Background()
+
+	renewed, err := s.RenewSubscriptions(ctx)
+	if err != nil {
+		t.Fatalf("RenewSubscriptions() error = %v", err)
+	}
+	if renewed != 1 {
+		t.Errorf("RenewSubscriptions() = %d, want 1", renewed)
+	}
+
+	var renewals []string
+	for _, p := range store.payments {
+		if p.Status != payment.StatusPending || !p.Date.Equal(now) || p.Amount != store.plans[p.ProductID].Price {
+			t.Errorf("renewal payment = %+v, want pending at %s for the plan price", p, now)
+		}
+		renewals = append(renewals, p.SubscriptionID)
+	}
+	if len(renewals) != 1 || renewals[0] != "sub-due" {
+		t.Errorf("renewed subscriptions = %v, want [sub-due]", renewals)
+	}
+
+	// the renewal payment is created only once per period
+	if !store.subscriptions["sub-due"].RenewTime.IsZero() {
+		t.Errorf("sub-due renew time = %s, want zero", store.subscriptions["sub-due"].RenewTime)
+	}
+	if store.subscriptions["sub-inactive"].AutoRenew {
+		t.Errorf("sub-inactive is still auto-renewing")
+	}
+	if !store.subscriptions["sub-later"].RenewTime.Equal(now.Add(time.Hour)) {
+		t.Errorf("sub-later renew time = %s, want %s", store.subscriptions["sub-later"].RenewTime, now.Add(time.Hour))
+	}
+
+	lapsed, err := s.ExpireSubscriptions(ctx)
+	if err != nil {
+		t.Fatalf("ExpireSubscriptions() error = %v", err)
+	}
+	if lapsed != 1 {
+		t.Errorf("ExpireSubscriptions() = %d, want 1", lapsed)
+	}
+
+	for id, want := range map[string]payment.SubscriptionStatus{
+		"sub-due":      payment.SubscriptionStatusActive,
+		"sub-later":    payment.SubscriptionStatusActive,
+		"sub-inactive": payment.SubscriptionStatusActive,
+		"sub-ended":    payment.SubscriptionStatusLapsed,
+	} {
+		if got := store.subscriptions[id].Status; got != want {
+			t.Errorf("%s status = %s, want %s", id, got, want)
+		}
+	}
+	if users.users["user-1"].Type != auth.TypePemium || users.users["user-2"].Type != auth.TypeFree {
+		t.Errorf("user types = %s and %s, want premium and free", users.users["user-1"].Type, users.users["user-2"].Type)
+	}
+
+	// later runs follow the time of the service
+	now = now.Add(2 * time.Hour)
+
+	renewed, err = s.RenewSubscriptions(ctx)
+	if err != nil {
+		t.Fatalf("RenewSubscriptions() error = %v", err)
+	}
+	if renewed != 1 || len(store.payments) != 2 {
+		t.Errorf("RenewSubscriptions() = %d with %d payments, want 1 with 2", renewed, len(store.payments))
+	}
+	if !store.subscriptions["sub-later"].RenewTime.IsZero() {
+		t.Errorf("sub-later renew time = %s, want zero", store.subscriptions["sub-later"].RenewTime)
+	}
+}
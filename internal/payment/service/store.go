@@ -2,11 +2,14 @@ package service
 
 import (
 	"context"
+	"hbdtoyou/internal/notification"
 	"hbdtoyou/internal/payment"
+	"hbdtoyou/pkg/event"
+	"time"
 )
 
 type PGStore interface {
-	NewClient(useTx bool) (PGStoreClient, error)
+	NewClient(ctx context.Context, useTx bool) (PGStoreClient, error)
 }
 
 type PGStoreClient interface {
@@ -14,6 +17,17 @@ type PGStoreClient interface {
 	Commit() error
 	// Rollback aborts the transaction.
 	Rollback() error
+	// Savepoint sets a savepoint with the given name in the
+	// transaction.
+	Savepoint(name string) error
+	// RollbackToSavepoint aborts the changes made in the
+	// transaction after the savepoint with the given name,
+	// the transaction can be used again.
+	RollbackToSavepoint(name string) error
+	// ShareTx returns a new Context carrying the transaction,
+	// so other services called with it apply their changes
+	// within the transaction.
+	ShareTx(ctx context.Context) context.Context
 
 	// CreatePayment creates a new payment and returns
 	// the created payment ID.
@@ -23,8 +37,14 @@ type PGStoreClient interface {
 	// payment ID.
 	GetPaymentByID(ctx context.Context, paymentID string) (payment.Payment, error)
 
-	// GetPayments returns all payments.
-	GetPayments(ctx context.Context) ([]payment.Payment, error)
+	// GetPaymentByIDForUpdate returns a payment with the
+	// given payment ID, and locks it until the transaction
+	// ends.
+	GetPaymentByIDForUpdate(ctx context.Context, paymentID string) (payment.Payment, error)
+
+	// GetPayments returns all payments based on the given
+	// filter.
+	GetPayments(ctx context.Context, filter payment.GetPaymentsFilter) ([]payment.Payment, error)
 
 	// UpdatePayment updates existing payment
 	// with the given payment data.
@@ -34,4 +54,130 @@ type PGStoreClient interface {
 	// use current values in the given data if do not want to
 	// update some specific attributes.
 	UpdatePayment(ctx context.Context, reqPayment payment.Payment) error
+
+	// GetPendingPayments returns pending payments, the oldest
+	// first. If the given reviewer ID is not empty, payments
+	// claimed by other reviewers after the given expire time
+	// are left out.
+	GetPendingPayments(ctx context.Context, reviewerID string, expireTime time.Time) ([]payment.Payment, error)
+
+	// ClaimPayment claims a pending payment for the given
+	// reviewer at the given claim time. ErrPaymentAlreadyClaimed
+	// is returned if another reviewer has claimed it after the
+	// given expire time.
+	ClaimPayment(ctx context.Context, paymentID, reviewerID string, claimTime, expireTime time.Time) error
+
+	// ReleasePayment releases the claim of the given reviewer
+	// on a pending payment. ErrPaymentNotClaimed is returned if
+	// the reviewer holds no claim on it.
+	ReleasePayment(ctx context.Context, paymentID, reviewerID string) error
+
+	// CreatePlan creates a new plan and returns the created
+	// plan ID.
+	CreatePlan(ctx context.Context, reqPlan payment.Plan) (string, error)
+
+	// GetPlanByID returns a plan with the given plan ID.
+	GetPlanByID(ctx context.Context, planID string) (payment.Plan, error)
+
+	// GetPlans returns all plans, or only the active ones.
+	GetPlans(ctx context.Context, activeOnly bool) ([]payment.Plan, error)
+
+	// UpdatePlan updates existing plan with the given plan
+	// data.
+	UpdatePlan(ctx context.Context, reqPlan payment.Plan) error
+
+	// CreateSubscription creates a new subscription and
+	// returns the created subscription ID.
+	CreateSubscription(ctx context.Context, reqSubscription payment.Subscription) (string, error)
+
+	// GetSubscriptionByID returns a subscription with the
+	// given subscription ID.
+	GetSubscriptionByID(ctx context.Context, subscriptionID string) (payment.Subscription, error)
+
+	// GetSubscriptions returns all subscriptions based on the
+	// given filter.
+	GetSubscriptions(ctx context.Context, filter payment.GetSubscriptionsFilter) ([]payment.Subscription, error)
+
+	// UpdateSubscription updates existing subscription with
+	// the given subscription data.
+	UpdateSubscription(ctx context.Context, reqSubscription payment.Subscription) error
+
+	// GetRenewableSubscriptions returns active auto-renewing
+	// subscriptions due to renew at the given time.
+	GetRenewableSubscriptions(ctx context.Context, now time.Time) ([]payment.Subscription, error)
+
+	// GetEndedSubscriptions returns active subscriptions whose
+	// period has ended at the given time.
+	GetEndedSubscriptions(ctx context.Context, now time.Time) ([]payment.Subscription, error)
+
+	// HasActiveSubscription returns whether the given user has
+	// an active subscription at the given time.
+	HasActiveSubscription(ctx context.Context, userID string, now time.Time) (bool, error)
+
+	// CreateVoucher creates a new voucher and returns the
+	// created voucher ID.
+	CreateVoucher(ctx context.Context, reqVoucher payment.Voucher) (string, error)
+
+	// GetVoucherByID returns a voucher with the given voucher
+	// ID.
+	GetVoucherByID(ctx context.Context, voucherID string) (payment.Voucher, error)
+
+	// GetVoucherByCodeForUpdate returns a voucher with the
+	// given code, and locks it until the transaction ends so
+	// its redemptions are counted consistently.
+	GetVoucherByCodeForUpdate(ctx context.Context, code string) (payment.Voucher, error)
+
+	// GetVouchers returns all vouchers.
+	GetVouchers(ctx context.Context) ([]payment.Voucher, error)
+
+	// UpdateVoucher updates existing voucher with the given
+	// voucher data.
+	UpdateVoucher(ctx context.Context, reqVoucher payment.Voucher) error
+
+	// CountVoucherRedemptions returns the number of payments
+	// redeeming the given voucher that are not rejected, in
+	// total and by the given user.
+	CountVoucherRedemptions(ctx context.Context, voucherID, userID string) (int, int, error)
+
+	// NextInvoiceSequence increments and returns the invoice
+	// sequence of the given year. The sequence is locked
+	// until the transaction ends, so the invoice numbers have
+	// no gaps.
+	NextInvoiceSequence(ctx context.Context, year int) (int, error)
+
+	// CreateInvoice creates a new invoice and returns the
+	// created invoice ID.
+	CreateInvoice(ctx context.Context, reqInvoice payment.Invoice) (string, error)
+
+	// GetInvoiceByPaymentID returns the invoice of a payment
+	// with the given payment ID.
+	GetInvoiceByPaymentID(ctx context.Context, paymentID string) (payment.Invoice, error)
+
+	// CreateRefund creates a new refund and returns the
+	// created refund ID.
+	CreateRefund(ctx context.Context, reqRefund payment.Refund) (string, error)
+
+	// GetRefundByIDForUpdate returns a refund with the given
+	// refund ID, locked until the transaction ends.
+	GetRefundByIDForUpdate(ctx context.Context, refundID string) (payment.Refund, error)
+
+	// GetPendingRefunds returns refunds pending since before
+	// the given time.
+	GetPendingRefunds(ctx context.Context, before time.Time) ([]payment.Refund, error)
+
+	// UpdateRefund updates the status, gateway reference and
+	// update time of the given refund.
+	UpdateRefund(ctx context.Context, reqRefund payment.Refund) error
+
+	// GetRefundsByPaymentID returns all refunds of a payment
+	// with the given payment ID.
+	GetRefundsByPaymentID(ctx context.Context, paymentID string) ([]payment.Refund, error)
+
+	// CreateNotification writes a new notification to the
+	// outbox and returns the created notification ID.
+	CreateNotification(ctx context.Context, reqNotification notification.Notification) (string, error)
+
+	// CreateEvent writes a new event to the event outbox, to
+	// be published once the transaction is committed.
+	CreateEvent(ctx context.Context, e event.Event) error
 }
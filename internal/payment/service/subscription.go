@@ -0,0 +1,427 @@
+package service
+
+import (
+	"context"
+	"hbdtoyou/internal/auth"
+	"hbdtoyou/internal/payment"
+	"hbdtoyou/pkg/money"
+	"log"
+	"time"
+)
+
+// CreatePlan creates a new plan and returns the created plan
+// ID.
+func (s *service) CreatePlan(ctx context.Context, reqPlan payment.Plan) (string, error) {
+	// only administrators manage plans
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if !caller.IsAdmin() {
+		return "", payment.ErrForbidden
+	}
+
+	// validate fields
+	reqPlan.Price.Currency = money.NormalizeCurrency(reqPlan.Price.Currency)
+	err = validatePlan(reqPlan)
+	if err != nil {
+		return "", err
+	}
+
+	// update fields
+	reqPlan.CreateTime = s.timeNow()
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return "", err
+	}
+
+	// inserts plan in pgstore
+	return pgStoreClient.CreatePlan(ctx, reqPlan)
+}
+
+// GetPlanByID returns a plan with the given plan ID.
+func (s *service) GetPlanByID(ctx context.Context, planID string) (payment.Plan, error) {
+	// validate id
+	if planID == "" {
+		return payment.Plan{}, payment.ErrInvalidPlanID
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return payment.Plan{}, err
+	}
+
+	return pgStoreClient.GetPlanByID(ctx, planID)
+}
+
+// GetPlans returns all plans. Inactive plans are only returned
+// to administrators.
+func (s *service) GetPlans(ctx context.Context) ([]payment.Plan, error) {
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgStoreClient.GetPlans(ctx, !caller.IsAdmin())
+}
+
+// UpdatePlan updates existing plan with the given plan data.
+//
+// The new price and quota only apply to the periods paid
+// afterwards.
+func (s *service) UpdatePlan(ctx context.Context, reqPlan payment.Plan) error {
+	// validate id
+	if reqPlan.ID == "" {
+		return payment.ErrInvalidPlanID
+	}
+
+	// only administrators manage plans
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !caller.IsAdmin() {
+		return payment.ErrForbidden
+	}
+
+	// validate fields
+	reqPlan.Price.Currency = money.NormalizeCurrency(reqPlan.Price.Currency)
+	err = validatePlan(reqPlan)
+	if err != nil {
+		return err
+	}
+
+	// update fields
+	reqPlan.UpdateTime = s.timeNow()
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	return pgStoreClient.UpdatePlan(ctx, reqPlan)
+}
+
+// GetSubscriptionByID returns a subscription with the given
+// subscription ID.
+func (s *service) GetSubscriptionByID(ctx context.Context, subscriptionID string) (payment.Subscription, error) {
+	// validate id
+	if subscriptionID == "" {
+		return payment.Subscription{}, payment.ErrInvalidSubscriptionID
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return payment.Subscription{}, err
+	}
+
+	result, err := pgStoreClient.GetSubscriptionByID(ctx, subscriptionID)
+	if err != nil {
+		return payment.Subscription{}, err
+	}
+
+	// only the subscribed user and administrators can read it
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return payment.Subscription{}, err
+	}
+
+	if !caller.IsAdmin() && result.UserID != caller.ID {
+		return payment.Subscription{}, payment.ErrForbidden
+	}
+
+	return result, nil
+}
+
+// GetSubscriptions returns all subscriptions based on the
+// given filter.
+func (s *service) GetSubscriptions(ctx context.Context, filter payment.GetSubscriptionsFilter) ([]payment.Subscription, error) {
+	// users other than administrators only get their own
+	// subscriptions
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !caller.IsAdmin() {
+		if filter.UserID != "" && filter.UserID != caller.ID {
+			return nil, payment.ErrForbidden
+		}
+		filter.UserID = caller.ID
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgStoreClient.GetSubscriptions(ctx, filter)
+}
+
+// CancelSubscription stops the renewal of a subscription with
+// the given subscription ID.
+//
+// A pending subscription is canceled right away.
+func (s *service) CancelSubscription(ctx context.Context, subscriptionID string) error {
+	// get subscription, the caller must be allowed to read it
+	sub, err := s.GetSubscriptionByID(ctx, subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	switch sub.Status {
+	case payment.SubscriptionStatusPending:
+		sub.Status = payment.SubscriptionStatusCanceled
+	case payment.SubscriptionStatusActive:
+		// nothing to do if it is already canceled
+		if !sub.AutoRenew {
+			return nil
+		}
+	default:
+		return payment.ErrSubscriptionEnded
+	}
+
+	// update fields
+	sub.AutoRenew = false
+	sub.RenewTime = time.Time{}
+	sub.UpdateTime = s.timeNow()
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	return pgStoreClient.UpdateSubscription(ctx, sub)
+}
+
+// RenewSubscriptions creates pending payments for the next
+// period of auto-renewing subscriptions that are due to renew.
+//
+// A subscription of an inactive plan is not renewed.
+func (s *service) RenewSubscriptions(ctx context.Context) (int, error) {
+	now := s.timeNow()
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return 0, err
+	}
+
+	subs, err := pgStoreClient.GetRenewableSubscriptions(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	var renewed int
+	for _, sub := range subs {
+		// a failing subscription should not block the others
+		ok, err := s.renewSubscription(ctx, sub, now)
+		if err != nil {
+			log.Printf("[Payment Service][RenewSubscriptions] Failed to renew subscription %s. Err: %s\n", sub.ID, err.Error())
+			continue
+		}
+
+		if ok {
+			renewed++
+		}
+	}
+
+	return renewed, nil
+}
+
+// renewSubscription creates a pending payment for the next
+// period of the given subscription, and returns whether the
+// payment is created.
+func (s *service) renewSubscription(ctx context.Context, sub payment.Subscription, now time.Time) (bool, error) {
+	// get pg store client using transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, true)
+	if err != nil {
+		return false, err
+	}
+
+	plan, err := pgStoreClient.GetPlanByID(ctx, sub.PlanID)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return false, err
+	}
+
+	// the payment is created only once per period
+	sub.RenewTime = time.Time{}
+	sub.UpdateTime = now
+
+	if !plan.Active {
+		sub.AutoRenew = false
+	} else {
+		_, err = pgStoreClient.CreatePayment(ctx, payment.Payment{
+			UserID:         sub.UserID,
+			ProductType:    payment.ProductTypePlan,
+			ProductID:      plan.ID,
+			Amount:         plan.Price,
+			Quota:          plan.Quota,
+			SubscriptionID: sub.ID,
+			Date:           now,
+			Status:         payment.StatusPending,
+			CreateTime:     now,
+		})
+		if err != nil {
+			pgStoreClient.Rollback()
+			return false, err
+		}
+	}
+
+	err = pgStoreClient.UpdateSubscription(ctx, sub)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return false, err
+	}
+
+	err = pgStoreClient.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return plan.Active, nil
+}
+
+// ExpireSubscriptions lapses active subscriptions whose period
+// has ended.
+func (s *service) ExpireSubscriptions(ctx context.Context) (int, error) {
+	now := s.timeNow()
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return 0, err
+	}
+
+	subs, err := pgStoreClient.GetEndedSubscriptions(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	var lapsed int
+	for _, sub := range subs {
+		// downgrade the user first, so it is retried on the
+		// next run if failing
+		err = s.syncUserType(ctx, pgStoreClient, sub.UserID)
+		if err != nil {
+			log.Printf("[Payment Service][ExpireSubscriptions] Failed to update type of user %s. Err: %s\n", sub.UserID, err.Error())
+			continue
+		}
+
+		sub.Status = payment.SubscriptionStatusLapsed
+		sub.RenewTime = time.Time{}
+		sub.UpdateTime = now
+
+		err = pgStoreClient.UpdateSubscription(ctx, sub)
+		if err != nil {
+			log.Printf("[Payment Service][ExpireSubscriptions] Failed to lapse subscription %s. Err: %s\n", sub.ID, err.Error())
+			continue
+		}
+
+		lapsed++
+	}
+
+	return lapsed, nil
+}
+
+// extendSubscription activates the given subscription for a
+// period of its plan. The period starts when the current one
+// ends, or at the given time if the subscription is not
+// active.
+func (s *service) extendSubscription(sub *payment.Subscription, now time.Time) {
+	start := now
+	if sub.Status == payment.SubscriptionStatusActive && sub.EndTime.After(now) {
+		start = sub.EndTime
+	} else {
+		sub.StartTime = now
+	}
+
+	sub.Status = payment.SubscriptionStatusActive
+	sub.EndTime = sub.PlanInterval.Next(start)
+	sub.RenewTime = time.Time{}
+	if sub.AutoRenew {
+		sub.RenewTime = sub.EndTime.Add(-s.config.RenewalLead)
+	}
+	sub.UpdateTime = now
+}
+
+// SyncUserType updates the type of the user with the given
+// user ID by whether they have an active subscription.
+func (s *service) SyncUserType(ctx context.Context, userID string) error {
+	// validate id
+	if userID == "" {
+		return payment.ErrInvalidUserID
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	return s.syncUserType(ctx, pgStoreClient, userID)
+}
+
+// syncUserType sets the type of the given user to premium if
+// the user has an active subscription, otherwise to free.
+func (s *service) syncUserType(ctx context.Context, pgStoreClient PGStoreClient, userID string) error {
+	active, err := pgStoreClient.HasActiveSubscription(ctx, userID, s.timeNow())
+	if err != nil {
+		return err
+	}
+
+	userType := auth.TypeFree
+	if active {
+		userType = auth.TypePemium
+	}
+
+	user, err := s.user.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Type == userType {
+		return nil
+	}
+
+	user.Type = userType
+	return s.user.UpdateUser(ctx, user)
+}
+
+// validatePlan validates fields of the given plan whether its
+// comply the predetermined rules.
+func validatePlan(reqPlan payment.Plan) error {
+	if reqPlan.Name == "" {
+		return payment.ErrInvalidPlanName
+	}
+
+	if reqPlan.Price.Amount <= 0 || !money.IsCurrency(reqPlan.Price.Currency) {
+		return payment.ErrInvalidPlanPrice
+	}
+
+	if _, valid := payment.IntervalList[reqPlan.Interval]; !valid {
+		return payment.ErrInvalidPlanInterval
+	}
+
+	if reqPlan.Quota <= 0 {
+		return payment.ErrInvalidPlanQuota
+	}
+
+	return nil
+}
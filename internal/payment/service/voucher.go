@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"hbdtoyou/internal/payment"
+	"hbdtoyou/pkg/money"
+	"regexp"
+	"strings"
+)
+
+// voucherCodeRegex is the pattern of a valid voucher code.
+var voucherCodeRegex = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+// CreateVoucher creates a new voucher and returns the created
+// voucher ID.
+func (s *service) CreateVoucher(ctx context.Context, reqVoucher payment.Voucher) (string, error) {
+	// only administrators manage vouchers
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if !caller.IsAdmin() {
+		return "", payment.ErrForbidden
+	}
+
+	// validate fields
+	err = validateVoucher(&reqVoucher)
+	if err != nil {
+		return "", err
+	}
+
+	// update fields
+	reqVoucher.CreateTime = s.timeNow()
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return "", err
+	}
+
+	// inserts voucher in pgstore
+	return pgStoreClient.CreateVoucher(ctx, reqVoucher)
+}
+
+// GetVoucherByID returns a voucher with the given voucher ID.
+func (s *service) GetVoucherByID(ctx context.Context, voucherID string) (payment.Voucher, error) {
+	// validate id
+	if voucherID == "" {
+		return payment.Voucher{}, payment.ErrInvalidVoucherID
+	}
+
+	// only administrators manage vouchers
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return payment.Voucher{}, err
+	}
+
+	if !caller.IsAdmin() {
+		return payment.Voucher{}, payment.ErrForbidden
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return payment.Voucher{}, err
+	}
+
+	return pgStoreClient.GetVoucherByID(ctx, voucherID)
+}
+
+// GetVouchers returns all vouchers.
+func (s *service) GetVouchers(ctx context.Context) ([]payment.Voucher, error) {
+	// only administrators manage vouchers
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !caller.IsAdmin() {
+		return nil, payment.ErrForbidden
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgStoreClient.GetVouchers(ctx)
+}
+
+// UpdateVoucher updates existing voucher with the given
+// voucher data.
+//
+// The changes only apply to the payments created afterwards.
+func (s *service) UpdateVoucher(ctx context.Context, reqVoucher payment.Voucher) error {
+	// validate id
+	if reqVoucher.ID == "" {
+		return payment.ErrInvalidVoucherID
+	}
+
+	// only administrators manage vouchers
+	caller, err := s.getCaller(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !caller.IsAdmin() {
+		return payment.ErrForbidden
+	}
+
+	// validate fields
+	err = validateVoucher(&reqVoucher)
+	if err != nil {
+		return err
+	}
+
+	// update fields
+	reqVoucher.UpdateTime = s.timeNow()
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	return pgStoreClient.UpdateVoucher(ctx, reqVoucher)
+}
+
+// redeemVoucher applies the voucher with the code of the given
+// payment to it, deducting the discount from its amount.
+//
+// The given pg store client must use a transaction. The voucher
+// is locked until the transaction ends, so the redemption
+// limits hold for concurrent payments.
+func (s *service) redeemVoucher(ctx context.Context, pgStoreClient PGStoreClient, reqPayment *payment.Payment) error {
+	code := normalizeVoucherCode(reqPayment.VoucherCode)
+	if !voucherCodeRegex.MatchString(code) {
+		return payment.ErrInvalidVoucherCode
+	}
+
+	v, err := pgStoreClient.GetVoucherByCodeForUpdate(ctx, code)
+	if err != nil {
+		if err == payment.ErrDataNotFound {
+			return payment.ErrInvalidVoucherCode
+		}
+		return err
+	}
+
+	if !v.IsValidAt(reqPayment.CreateTime) || !v.AppliesTo(*reqPayment) {
+		return payment.ErrVoucherNotApplicable
+	}
+
+	// check the limits
+	if v.MaxRedemptions > 0 || v.MaxRedemptionsPerUser > 0 {
+		total, byUser, err := pgStoreClient.CountVoucherRedemptions(ctx, v.ID, reqPayment.UserID)
+		if err != nil {
+			return err
+		}
+
+		if v.MaxRedemptions > 0 && total >= v.MaxRedemptions {
+			return payment.ErrVoucherExhausted
+		}
+
+		if v.MaxRedemptionsPerUser > 0 && byUser >= v.MaxRedemptionsPerUser {
+			return payment.ErrVoucherExhausted
+		}
+	}
+
+	reqPayment.VoucherCode = v.Code
+	reqPayment.VoucherID = v.ID
+	reqPayment.Discount = v.DiscountOf(reqPayment.Amount)
+	reqPayment.Amount, err = reqPayment.Amount.Sub(reqPayment.Discount)
+	return err
+}
+
+// normalizeVoucherCode returns the given voucher code in its
+// stored form, codes are case insensitive.
+func normalizeVoucherCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// validateVoucher validates fields of the given voucher whether
+// its comply the predetermined rules, and normalizes its code.
+func validateVoucher(reqVoucher *payment.Voucher) error {
+	reqVoucher.Code = normalizeVoucherCode(reqVoucher.Code)
+	if !voucherCodeRegex.MatchString(reqVoucher.Code) {
+		return payment.ErrInvalidVoucherCode
+	}
+
+	switch reqVoucher.DiscountType {
+	case payment.DiscountTypePercentage:
+		if reqVoucher.DiscountValue <= 0 || reqVoucher.DiscountValue > 100 {
+			return payment.ErrInvalidVoucherDiscount
+		}
+		reqVoucher.Currency = ""
+	case payment.DiscountTypeFixed:
+		reqVoucher.Currency = money.NormalizeCurrency(reqVoucher.Currency)
+		if reqVoucher.DiscountValue <= 0 || !money.IsCurrency(reqVoucher.Currency) {
+			return payment.ErrInvalidVoucherDiscount
+		}
+	default:
+		return payment.ErrInvalidVoucherDiscount
+	}
+
+	if !reqVoucher.StartTime.IsZero() && !reqVoucher.EndTime.IsZero() && !reqVoucher.EndTime.After(reqVoucher.StartTime) {
+		return payment.ErrInvalidVoucherPeriod
+	}
+
+	if reqVoucher.MaxRedemptions < 0 || reqVoucher.MaxRedemptionsPerUser < 0 {
+		return payment.ErrInvalidVoucherLimit
+	}
+
+	return nil
+}
@@ -3,22 +3,41 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"hbdtoyou/internal/notification"
 	"hbdtoyou/internal/payment"
+	"strings"
+	"time"
 
+	"hbdtoyou/pkg/event"
+	outbox "hbdtoyou/pkg/event/outbox/postgresql"
+
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 func (sc *storeClient) CreatePayment(ctx context.Context, reqPayment payment.Payment) (string, error) {
 	// construct arguments filled with fields for the query
 	argKV := map[string]interface{}{
-		"user_id":           reqPayment.UserID,
-		"content_id":        reqPayment.ContentID,
-		"amount":            reqPayment.Amount,
-		"proof_payment_url": reqPayment.ProofPaymentURL,
-		"date":              reqPayment.Date,
-		"status":            reqPayment.Status,
-		"create_time":       reqPayment.CreateTime,
+		"user_id":                reqPayment.UserID,
+		"product_type":           reqPayment.ProductType,
+		"product_id":             reqPayment.ProductID,
+		"content_id":             nullString(reqPayment.ContentID),
+		"amount":                 reqPayment.Amount.Amount,
+		"currency":               reqPayment.Amount.Currency,
+		"quota":                  reqPayment.Quota,
+		"subscription_id":        nullString(reqPayment.SubscriptionID),
+		"voucher_id":             nullString(reqPayment.VoucherID),
+		"discount":               reqPayment.Discount.Amount,
+		"gateway":                reqPayment.Gateway,
+		"gateway_reference":      reqPayment.GatewayReference,
+		"proof_payment_url":      reqPayment.ProofPaymentURL,
+		"proof_payment_media_id": nullString(reqPayment.ProofPaymentMediaID),
+		"date":                   reqPayment.Date,
+		"status":                 reqPayment.Status,
+		"create_time":            reqPayment.CreateTime,
 	}
 
 	// prepare query
@@ -58,44 +77,55 @@ func (sc *storeClient) GetPaymentByID(ctx context.Context, paymentID string) (pa
 	return model.format(), nil
 }
 
-func (sc *storeClient) GetPayments(ctx context.Context) ([]payment.Payment, error) {
+func (sc *storeClient) GetPaymentByIDForUpdate(ctx context.Context, paymentID string) (payment.Payment, error) {
+	query := fmt.Sprintf(queryGetPayment, "WHERE p.id = $1 FOR UPDATE OF p")
+
+	// query single row
+	var model paymentModel
+	err := sc.q.QueryRowx(query, paymentID).StructScan(&model)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return payment.Payment{}, payment.ErrDataNotFound
+		}
+		return payment.Payment{}, err
+	}
+
+	return model.format(), nil
+}
+
+func (sc *storeClient) GetPayments(ctx context.Context, filter payment.GetPaymentsFilter) ([]payment.Payment, error) {
 	// define variables to custom query
-	// argKV := make(map[string]interface{})
-	// conditions := make([]string, 0)
-
-	// if filter.UserID != "" {
-	// 	id, err := uuid.Parse(filter.UserID)
-	// 	if err != nil {
-	// 		return nil, payment.ErrInvalidUserID
-	// 	}
-	// 	conditions = append(conditions, "p.user_id = :user_id")
-	// 	argKV["user_id"] = id
-	// }
-
-	// if filter.Status > 0 {
-	// 	conditions = append(conditions, "p.status = :status")
-	// 	argKV["status"] = filter.Status
-	// }
-
-	// if filter.Status > 0 {
-	// 	conditions = append(conditions, "p.status = :status")
-	// 	argKV["status"] = filter.Status
-	// }
+	argKV := make(map[string]interface{})
+	conditions := make([]string, 0)
+
+	if filter.UserID != "" {
+		id, err := uuid.Parse(filter.UserID)
+		if err != nil {
+			return nil, payment.ErrInvalidUserID
+		}
+		conditions = append(conditions, "p.user_id = :user_id")
+		argKV["user_id"] = id
+	}
+
+	if filter.Status > 0 {
+		conditions = append(conditions, "p.status = :status")
+		argKV["status"] = filter.Status
+	}
 
 	// construct strings to custom query
-	// condition := strings.Join(conditions, " AND ")
+	condition := strings.Join(conditions, " AND ")
 
-	// // since the query does not contains "WHERE" yet, need
-	// // to add it if needed
-	// if len(conditions) > 0 {
-	// 	condition = fmt.Sprintf("WHERE %s", condition)
-	// }
+	// since the query does not contains "WHERE" yet, need
+	// to add it if needed
+	if len(conditions) > 0 {
+		condition = fmt.Sprintf("WHERE %s", condition)
+	}
 
 	// construct query
-	query := fmt.Sprintf(queryGetPayment, "ORDER BY p.date DESC")
+	query := fmt.Sprintf(queryGetPayment, condition+" ORDER BY p.date DESC")
 
 	// prepare query
-	query, args, err := sqlx.Named(query, map[string]interface{}{})
+	query, args, err := sqlx.Named(query, argKV)
 	if err != nil {
 		return nil, err
 	}
@@ -133,16 +163,29 @@ func (sc *storeClient) GetPayments(ctx context.Context) ([]payment.Payment, erro
 }
 
 func (sc *storeClient) UpdatePayment(ctx context.Context, reqPayment payment.Payment) error {
+	// the URL of a proof payment media is resolved on read,
+	// the stored URL is kept
+	var proofPaymentURL interface{} = reqPayment.ProofPaymentURL
+	if reqPayment.ProofPaymentMediaID != "" {
+		proofPaymentURL = nil
+	}
+
 	// construct arguments filled with fields for the query
 	argsKV := map[string]interface{}{
-		"id":                reqPayment.ID,
-		"user_id":           reqPayment.UserID,
-		"content_id":        reqPayment.ContentID,
-		"amount":            reqPayment.Amount,
-		"proof_payment_url": reqPayment.ProofPaymentURL,
-		"date":              reqPayment.Date,
-		"status":            reqPayment.Status,
-		"update_time":       reqPayment.UpdateTime,
+		"id":                     reqPayment.ID,
+		"user_id":                reqPayment.UserID,
+		"content_id":             nullString(reqPayment.ContentID),
+		"amount":                 reqPayment.Amount.Amount,
+		"refunded_amount":        reqPayment.RefundedAmount.Amount,
+		"reviewer_id":            nullString(reqPayment.ReviewerID),
+		"claim_time":             nullTime(reqPayment.ClaimTime),
+		"review_reason":          reqPayment.ReviewReason,
+		"proof_payment_url":      proofPaymentURL,
+		"proof_payment_media_id": nullString(reqPayment.ProofPaymentMediaID),
+		"date":                   reqPayment.Date,
+		"status":                 reqPayment.Status,
+		"version":                reqPayment.Version,
+		"update_time":            reqPayment.UpdateTime,
 	}
 
 	// prepare query
@@ -157,6 +200,925 @@ func (sc *storeClient) UpdatePayment(ctx context.Context, reqPayment payment.Pay
 	query = sc.q.Rebind(query)
 
 	// execute query
-	_, err = sc.q.Exec(query, args...)
-	return err
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	// nothing is updated when the version is outdated
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return payment.ErrVersionConflict
+	}
+
+	return nil
+}
+
+func (sc *storeClient) GetPendingPayments(ctx context.Context, reviewerID string, expireTime time.Time) ([]payment.Payment, error) {
+	// define variables to custom query
+	argKV := map[string]interface{}{
+		"status": payment.StatusPending,
+	}
+	conditions := []string{"p.status = :status"}
+
+	// leave out payments claimed by other reviewers
+	if reviewerID != "" {
+		conditions = append(conditions, "(p.reviewer_id IS NULL OR p.reviewer_id = :reviewer_id OR p.claim_time IS NULL OR p.claim_time <= :expire_time)")
+		argKV["reviewer_id"] = reviewerID
+		argKV["expire_time"] = expireTime
+	}
+
+	// construct query, the oldest payments are reviewed first
+	query := fmt.Sprintf(queryGetPayment, fmt.Sprintf("WHERE %s ORDER BY p.date, p.create_time", strings.Join(conditions, " AND ")))
+
+	// prepare query
+	query, args, err := sqlx.Named(query, argKV)
+	if err != nil {
+		return nil, err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	query = sc.q.Rebind(query)
+
+	// query to database
+	rows, err := sc.q.Queryx(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// read rows
+	result := make([]payment.Payment, 0)
+	for rows.Next() {
+		var row paymentModel
+		err = rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, row.format())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (sc *storeClient) ClaimPayment(ctx context.Context, paymentID, reviewerID string, claimTime, expireTime time.Time) error {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"id":          paymentID,
+		"status":      payment.StatusPending,
+		"reviewer_id": reviewerID,
+		"claim_time":  claimTime,
+		"expire_time": expireTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryClaimPayment, argsKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	// nothing is claimed when another reviewer holds the claim
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return payment.ErrPaymentAlreadyClaimed
+	}
+
+	return nil
+}
+
+func (sc *storeClient) ReleasePayment(ctx context.Context, paymentID, reviewerID string) error {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"id":          paymentID,
+		"status":      payment.StatusPending,
+		"reviewer_id": reviewerID,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryReleasePayment, argsKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	// nothing is released when the reviewer holds no claim
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return payment.ErrPaymentNotClaimed
+	}
+
+	return nil
+}
+
+func (sc *storeClient) CreatePlan(ctx context.Context, reqPlan payment.Plan) (string, error) {
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"name":        reqPlan.Name,
+		"description": reqPlan.Description,
+		"price":       reqPlan.Price.Amount,
+		"currency":    reqPlan.Price.Currency,
+		"interval":    reqPlan.Interval,
+		"quota":       reqPlan.Quota,
+		"active":      reqPlan.Active,
+		"create_time": reqPlan.CreateTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryCreatePlan, argKV)
+	if err != nil {
+		return "", err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return "", err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	var id string
+	err = sc.q.QueryRowx(query, args...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (sc *storeClient) GetPlanByID(ctx context.Context, planID string) (payment.Plan, error) {
+	query := fmt.Sprintf(queryGetPlan, "WHERE pl.id = $1")
+
+	// query single row
+	var model planModel
+	err := sc.q.QueryRowx(query, planID).StructScan(&model)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return payment.Plan{}, payment.ErrDataNotFound
+		}
+		return payment.Plan{}, err
+	}
+
+	return model.format(), nil
+}
+
+func (sc *storeClient) GetPlans(ctx context.Context, activeOnly bool) ([]payment.Plan, error) {
+	condition := ""
+	if activeOnly {
+		condition = "WHERE pl.active"
+	}
+
+	// construct query
+	query := fmt.Sprintf(queryGetPlan, condition+" ORDER BY pl.price, pl.create_time")
+
+	// query to database
+	rows, err := sc.q.Queryx(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// read rows
+	result := make([]payment.Plan, 0)
+	for rows.Next() {
+		var row planModel
+		err = rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, row.format())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (sc *storeClient) UpdatePlan(ctx context.Context, reqPlan payment.Plan) error {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"id":          reqPlan.ID,
+		"name":        reqPlan.Name,
+		"description": reqPlan.Description,
+		"price":       reqPlan.Price.Amount,
+		"currency":    reqPlan.Price.Currency,
+		"interval":    reqPlan.Interval,
+		"quota":       reqPlan.Quota,
+		"active":      reqPlan.Active,
+		"version":     reqPlan.Version,
+		"update_time": reqPlan.UpdateTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryUpdatePlan, argsKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	// nothing is updated when the version is outdated
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return payment.ErrVersionConflict
+	}
+
+	return nil
+}
+
+func (sc *storeClient) CreateSubscription(ctx context.Context, reqSubscription payment.Subscription) (string, error) {
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"user_id":     reqSubscription.UserID,
+		"plan_id":     reqSubscription.PlanID,
+		"status":      reqSubscription.Status,
+		"auto_renew":  reqSubscription.AutoRenew,
+		"create_time": reqSubscription.CreateTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryCreateSubscription, argKV)
+	if err != nil {
+		return "", err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return "", err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	var id string
+	err = sc.q.QueryRowx(query, args...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (sc *storeClient) GetSubscriptionByID(ctx context.Context, subscriptionID string) (payment.Subscription, error) {
+	query := fmt.Sprintf(queryGetSubscription, "WHERE s.id = $1")
+
+	// query single row
+	var model subscriptionModel
+	err := sc.q.QueryRowx(query, subscriptionID).StructScan(&model)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return payment.Subscription{}, payment.ErrDataNotFound
+		}
+		return payment.Subscription{}, err
+	}
+
+	return model.format(), nil
+}
+
+func (sc *storeClient) GetSubscriptions(ctx context.Context, filter payment.GetSubscriptionsFilter) ([]payment.Subscription, error) {
+	// define variables to custom query
+	argKV := make(map[string]interface{})
+	conditions := make([]string, 0)
+
+	if filter.UserID != "" {
+		id, err := uuid.Parse(filter.UserID)
+		if err != nil {
+			return nil, payment.ErrInvalidUserID
+		}
+		conditions = append(conditions, "s.user_id = :user_id")
+		argKV["user_id"] = id
+	}
+
+	if filter.PlanID != "" {
+		id, err := uuid.Parse(filter.PlanID)
+		if err != nil {
+			return nil, payment.ErrInvalidPlanID
+		}
+		conditions = append(conditions, "s.plan_id = :plan_id")
+		argKV["plan_id"] = id
+	}
+
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, "s.status IN (:statuses)")
+		argKV["statuses"] = filter.Statuses
+	}
+
+	// construct strings to custom query
+	condition := strings.Join(conditions, " AND ")
+
+	// since the query does not contains "WHERE" yet, need
+	// to add it if needed
+	if len(conditions) > 0 {
+		condition = fmt.Sprintf("WHERE %s", condition)
+	}
+
+	return sc.getSubscriptions(ctx, condition+" ORDER BY s.create_time DESC", argKV)
+}
+
+func (sc *storeClient) GetRenewableSubscriptions(ctx context.Context, now time.Time) ([]payment.Subscription, error) {
+	argKV := map[string]interface{}{
+		"status": payment.SubscriptionStatusActive,
+		"now":    now,
+	}
+
+	return sc.getSubscriptions(ctx, "WHERE s.status = :status AND s.auto_renew AND s.renew_time <= :now ORDER BY s.renew_time", argKV)
+}
+
+func (sc *storeClient) GetEndedSubscriptions(ctx context.Context, now time.Time) ([]payment.Subscription, error) {
+	argKV := map[string]interface{}{
+		"status": payment.SubscriptionStatusActive,
+		"now":    now,
+	}
+
+	return sc.getSubscriptions(ctx, "WHERE s.status = :status AND s.end_time <= :now ORDER BY s.end_time", argKV)
+}
+
+// getSubscriptions returns subscriptions matching the given
+// condition.
+func (sc *storeClient) getSubscriptions(ctx context.Context, condition string, argKV map[string]interface{}) ([]payment.Subscription, error) {
+	// construct query
+	query := fmt.Sprintf(queryGetSubscription, condition)
+
+	// prepare query
+	query, args, err := sqlx.Named(query, argKV)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	query = sc.q.Rebind(query)
+
+	// query to database
+	rows, err := sc.q.Queryx(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// read rows
+	result := make([]payment.Subscription, 0)
+	for rows.Next() {
+		var row subscriptionModel
+		err = rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, row.format())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (sc *storeClient) UpdateSubscription(ctx context.Context, reqSubscription payment.Subscription) error {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"id":          reqSubscription.ID,
+		"status":      reqSubscription.Status,
+		"auto_renew":  reqSubscription.AutoRenew,
+		"start_time":  nullTime(reqSubscription.StartTime),
+		"end_time":    nullTime(reqSubscription.EndTime),
+		"renew_time":  nullTime(reqSubscription.RenewTime),
+		"version":     reqSubscription.Version,
+		"update_time": reqSubscription.UpdateTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryUpdateSubscription, argsKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	// nothing is updated when the version is outdated
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return payment.ErrVersionConflict
+	}
+
+	return nil
+}
+
+func (sc *storeClient) HasActiveSubscription(ctx context.Context, userID string, now time.Time) (bool, error) {
+	var ok bool
+	err := sc.q.QueryRowx(queryHasActiveSubscription, userID, payment.SubscriptionStatusActive, now).Scan(&ok)
+	if err != nil {
+		return false, err
+	}
+
+	return ok, nil
+}
+
+func (sc *storeClient) CreateVoucher(ctx context.Context, reqVoucher payment.Voucher) (string, error) {
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"code":                     reqVoucher.Code,
+		"discount_type":            reqVoucher.DiscountType,
+		"discount_value":           reqVoucher.DiscountValue,
+		"currency":                 reqVoucher.Currency,
+		"start_time":               nullTime(reqVoucher.StartTime),
+		"end_time":                 nullTime(reqVoucher.EndTime),
+		"max_redemptions":          reqVoucher.MaxRedemptions,
+		"max_redemptions_per_user": reqVoucher.MaxRedemptionsPerUser,
+		"template_ids":             pq.Array(reqVoucher.TemplateIDs),
+		"plan_ids":                 pq.Array(reqVoucher.PlanIDs),
+		"active":                   reqVoucher.Active,
+		"create_time":              reqVoucher.CreateTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryCreateVoucher, argKV)
+	if err != nil {
+		return "", err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return "", err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	var id string
+	err = sc.q.QueryRowx(query, args...).Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr != nil {
+			if pqErr.Code.Name() == "unique_violation" {
+				return "", payment.ErrVoucherAlreadyExist
+			}
+		}
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (sc *storeClient) GetVoucherByID(ctx context.Context, voucherID string) (payment.Voucher, error) {
+	query := fmt.Sprintf(queryGetVoucher, "WHERE v.id = $1")
+
+	// query single row
+	var model voucherModel
+	err := sc.q.QueryRowx(query, voucherID).StructScan(&model)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return payment.Voucher{}, payment.ErrDataNotFound
+		}
+		return payment.Voucher{}, err
+	}
+
+	return model.format(), nil
+}
+
+func (sc *storeClient) GetVoucherByCodeForUpdate(ctx context.Context, code string) (payment.Voucher, error) {
+	// the redemptions are not counted here, a count in the
+	// same statement might miss the ones committed while
+	// waiting for the lock
+	var model voucherModel
+	err := sc.q.QueryRowx(queryLockVoucher, code).StructScan(&model)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return payment.Voucher{}, payment.ErrDataNotFound
+		}
+		return payment.Voucher{}, err
+	}
+
+	return model.format(), nil
+}
+
+func (sc *storeClient) GetVouchers(ctx context.Context) ([]payment.Voucher, error) {
+	// construct query
+	query := fmt.Sprintf(queryGetVoucher, "ORDER BY v.create_time DESC")
+
+	// query to database
+	rows, err := sc.q.Queryx(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// read rows
+	result := make([]payment.Voucher, 0)
+	for rows.Next() {
+		var row voucherModel
+		err = rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, row.format())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (sc *storeClient) UpdateVoucher(ctx context.Context, reqVoucher payment.Voucher) error {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"id":                       reqVoucher.ID,
+		"code":                     reqVoucher.Code,
+		"discount_type":            reqVoucher.DiscountType,
+		"discount_value":           reqVoucher.DiscountValue,
+		"currency":                 reqVoucher.Currency,
+		"start_time":               nullTime(reqVoucher.StartTime),
+		"end_time":                 nullTime(reqVoucher.EndTime),
+		"max_redemptions":          reqVoucher.MaxRedemptions,
+		"max_redemptions_per_user": reqVoucher.MaxRedemptionsPerUser,
+		"template_ids":             pq.Array(reqVoucher.TemplateIDs),
+		"plan_ids":                 pq.Array(reqVoucher.PlanIDs),
+		"active":                   reqVoucher.Active,
+		"version":                  reqVoucher.Version,
+		"update_time":              reqVoucher.UpdateTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryUpdateVoucher, argsKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr != nil {
+			if pqErr.Code.Name() == "unique_violation" {
+				return payment.ErrVoucherAlreadyExist
+			}
+		}
+		return err
+	}
+
+	// nothing is updated when the version is outdated
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return payment.ErrVersionConflict
+	}
+
+	return nil
+}
+
+func (sc *storeClient) CountVoucherRedemptions(ctx context.Context, voucherID, userID string) (int, int, error) {
+	var total, byUser int
+	err := sc.q.QueryRowx(queryCountVoucherRedemptions, voucherID, userID).Scan(&total, &byUser)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return total, byUser, nil
+}
+
+func (sc *storeClient) NextInvoiceSequence(ctx context.Context, year int) (int, error) {
+	var sequence int
+	err := sc.q.QueryRowx(queryNextInvoiceSequence, year).Scan(&sequence)
+	if err != nil {
+		return 0, err
+	}
+
+	return sequence, nil
+}
+
+func (sc *storeClient) CreateInvoice(ctx context.Context, reqInvoice payment.Invoice) (string, error) {
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"payment_id": reqInvoice.PaymentID,
+		"user_id":    reqInvoice.UserID,
+		"number":     reqInvoice.Number,
+		"year":       reqInvoice.Year,
+		"sequence":   reqInvoice.Sequence,
+		"user_name":  reqInvoice.UserName,
+		"user_email": reqInvoice.UserEmail,
+		"items":      newInvoiceItemsModel(reqInvoice.Items),
+		"subtotal":   reqInvoice.Subtotal.Amount,
+		"discount":   reqInvoice.Discount.Amount,
+		"total":      reqInvoice.Total.Amount,
+		"currency":   reqInvoice.Total.Currency,
+		"issue_time": reqInvoice.IssueTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryCreateInvoice, argKV)
+	if err != nil {
+		return "", err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return "", err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	var id string
+	err = sc.q.QueryRowx(query, args...).Scan(&id)
+	if err != nil {
+		// a payment has at most one invoice
+		if pqErr, ok := err.(*pq.Error); ok && pqErr != nil {
+			if pqErr.Code.Name() == "unique_violation" {
+				return "", payment.ErrInvoiceAlreadyExist
+			}
+		}
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (sc *storeClient) GetInvoiceByPaymentID(ctx context.Context, paymentID string) (payment.Invoice, error) {
+	query := fmt.Sprintf(queryGetInvoice, "WHERE i.payment_id = $1")
+
+	// query single row
+	var model invoiceModel
+	err := sc.q.QueryRowx(query, paymentID).StructScan(&model)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return payment.Invoice{}, payment.ErrDataNotFound
+		}
+		return payment.Invoice{}, err
+	}
+
+	return model.format(), nil
+}
+
+func (sc *storeClient) CreateRefund(ctx context.Context, reqRefund payment.Refund) (string, error) {
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"payment_id":        reqRefund.PaymentID,
+		"type":              reqRefund.Type,
+		"amount":            reqRefund.Amount.Amount,
+		"currency":          reqRefund.Amount.Currency,
+		"reason":            reqRefund.Reason,
+		"actor_id":          reqRefund.ActorID,
+		"status":            reqRefund.Status,
+		"gateway_reference": reqRefund.GatewayReference,
+		"create_time":       reqRefund.CreateTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryCreateRefund, argKV)
+	if err != nil {
+		return "", err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return "", err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	var id string
+	err = sc.q.QueryRowx(query, args...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (sc *storeClient) GetRefundByIDForUpdate(ctx context.Context, refundID string) (payment.Refund, error) {
+	query := fmt.Sprintf(queryGetRefund, "WHERE r.id = $1 FOR UPDATE")
+
+	// query single row
+	var model refundModel
+	err := sc.q.QueryRowx(query, refundID).StructScan(&model)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return payment.Refund{}, payment.ErrDataNotFound
+		}
+		return payment.Refund{}, err
+	}
+
+	return model.format(), nil
+}
+
+func (sc *storeClient) GetPendingRefunds(ctx context.Context, before time.Time) ([]payment.Refund, error) {
+	query := fmt.Sprintf(queryGetRefund, "WHERE r.status = $1 AND r.create_time < $2 ORDER BY r.create_time")
+
+	// query to database
+	rows, err := sc.q.Queryx(query, payment.RefundStatusPending, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// read rows
+	result := make([]payment.Refund, 0)
+	for rows.Next() {
+		var row refundModel
+		err = rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, row.format())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (sc *storeClient) UpdateRefund(ctx context.Context, reqRefund payment.Refund) error {
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"id":                reqRefund.ID,
+		"status":            reqRefund.Status,
+		"gateway_reference": reqRefund.GatewayReference,
+		"update_time":       reqRefund.UpdateTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryUpdateRefund, argKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return payment.ErrDataNotFound
+	}
+
+	return nil
+}
+
+func (sc *storeClient) GetRefundsByPaymentID(ctx context.Context, paymentID string) ([]payment.Refund, error) {
+	query := fmt.Sprintf(queryGetRefund, "WHERE r.payment_id = $1 ORDER BY r.create_time")
+
+	// query to database
+	rows, err := sc.q.Queryx(query, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// read rows
+	result := make([]payment.Refund, 0)
+	for rows.Next() {
+		var row refundModel
+		err = rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, row.format())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// nullString returns nil for an empty string, so it is stored
+// as NULL in the database.
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// nullTime returns nil for a zero time, so it is stored as
+// NULL in the database.
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func (sc *storeClient) CreateNotification(ctx context.Context, reqNotification notification.Notification) (string, error) {
+	data, err := json.Marshal(reqNotification.Data)
+	if err != nil {
+		return "", err
+	}
+
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"user_id":           reqNotification.UserID,
+		"event":             reqNotification.Event,
+		"subject":           reqNotification.Subject,
+		"body":              reqNotification.Body,
+		"data":              string(data),
+		"status":            reqNotification.Status,
+		"next_attempt_time": reqNotification.NextAttemptTime,
+		"create_time":       reqNotification.CreateTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryCreateNotification, argKV)
+	if err != nil {
+		return "", err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return "", err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	var id string
+	err = sc.q.QueryRowx(query, args...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (sc *storeClient) CreateEvent(ctx context.Context, e event.Event) error {
+	return outbox.Write(ctx, sc.q, e)
 }
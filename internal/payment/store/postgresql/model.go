@@ -1,47 +1,115 @@
 package postgresql
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"hbdtoyou/internal/auth"
 	"hbdtoyou/internal/payment"
 	"hbdtoyou/internal/template"
+	"hbdtoyou/pkg/money"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type paymentModel struct {
-	ID              string         `db:"id"`
-	UserID          string         `db:"user_id"`
-	ContentID       string         `db:"content_id"`
-	Amount          int            `db:"amount"`
-	ProofPaymentURL string         `db:"proof_payment_url"`
-	Date            time.Time      `db:"date"`
-	UserName        string         `db:"user_name"`
-	UserType        auth.Type      `db:"user_type"`
-	UserQuota       int            `db:"user_quota"`
-	TemplateID      string         `db:"template_id"`
-	TemplateName    string         `db:"template_name"`
-	TemplateLabel   template.Label `db:"template_label"`
-	Status          payment.Status `db:"status"`
-	CreateTime      time.Time      `db:"create_time"`
-	UpdateTime      *time.Time     `db:"update_time"`
+	ID                  string              `db:"id"`
+	UserID              string              `db:"user_id"`
+	ProductType         payment.ProductType `db:"product_type"`
+	ProductID           string              `db:"product_id"`
+	ContentID           *string             `db:"content_id"`
+	Amount              int64               `db:"amount"`
+	Currency            string              `db:"currency"`
+	Quota               int                 `db:"quota"`
+	SubscriptionID      *string             `db:"subscription_id"`
+	VoucherID           *string             `db:"voucher_id"`
+	VoucherCode         *string             `db:"voucher_code"`
+	Discount            int64               `db:"discount"`
+	RefundedAmount      int64               `db:"refunded_amount"`
+	Gateway             string              `db:"gateway"`
+	GatewayReference    string              `db:"gateway_reference"`
+	ReviewerID          *string             `db:"reviewer_id"`
+	ClaimTime           *time.Time          `db:"claim_time"`
+	ReviewReason        string              `db:"review_reason"`
+	ProofPaymentURL     string              `db:"proof_payment_url"`
+	ProofPaymentMediaID *string             `db:"proof_payment_media_id"`
+	Date                time.Time           `db:"date"`
+	UserName            string              `db:"user_name"`
+	UserType            auth.Type           `db:"user_type"`
+	UserQuota           int                 `db:"user_quota"`
+	TemplateID          *string             `db:"template_id"`
+	TemplateName        *string             `db:"template_name"`
+	TemplateLabel       *template.Label     `db:"template_label"`
+	Status              payment.Status      `db:"status"`
+	Version             int64               `db:"version"`
+	CreateTime          time.Time           `db:"create_time"`
+	UpdateTime          *time.Time          `db:"update_time"`
 }
 
 // format formats database struct into domain struct.
 func (dbData *paymentModel) format() payment.Payment {
 	p := payment.Payment{
-		ID:              dbData.ID,
-		UserID:          dbData.UserID,
-		UserName:        dbData.UserName,
-		UserType:        dbData.UserType,
-		UserQuota:       dbData.UserQuota,
-		TemplateID:      dbData.TemplateID,
-		TemplateName:    dbData.TemplateName,
-		TemplateLabel:   dbData.TemplateLabel,
-		ContentID:       dbData.ContentID,
-		Amount:          dbData.Amount,
-		ProofPaymentURL: dbData.ProofPaymentURL,
-		Date:            dbData.Date,
-		Status:          dbData.Status,
-		CreateTime:      dbData.CreateTime,
+		ID:               dbData.ID,
+		UserID:           dbData.UserID,
+		UserName:         dbData.UserName,
+		UserType:         dbData.UserType,
+		UserQuota:        dbData.UserQuota,
+		ProductType:      dbData.ProductType,
+		ProductID:        dbData.ProductID,
+		Amount:           money.Money{Amount: dbData.Amount, Currency: dbData.Currency},
+		Quota:            dbData.Quota,
+		Discount:         money.Money{Amount: dbData.Discount, Currency: dbData.Currency},
+		RefundedAmount:   money.Money{Amount: dbData.RefundedAmount, Currency: dbData.Currency},
+		Gateway:          dbData.Gateway,
+		GatewayReference: dbData.GatewayReference,
+		ReviewReason:     dbData.ReviewReason,
+		ProofPaymentURL:  dbData.ProofPaymentURL,
+		Date:             dbData.Date,
+		Status:           dbData.Status,
+		Version:          dbData.Version,
+		CreateTime:       dbData.CreateTime,
+	}
+
+	if dbData.ContentID != nil {
+		p.ContentID = *dbData.ContentID
+	}
+
+	if dbData.SubscriptionID != nil {
+		p.SubscriptionID = *dbData.SubscriptionID
+	}
+
+	if dbData.VoucherID != nil {
+		p.VoucherID = *dbData.VoucherID
+	}
+
+	if dbData.ReviewerID != nil {
+		p.ReviewerID = *dbData.ReviewerID
+	}
+
+	if dbData.ClaimTime != nil {
+		p.ClaimTime = *dbData.ClaimTime
+	}
+
+	if dbData.VoucherCode != nil {
+		p.VoucherCode = *dbData.VoucherCode
+	}
+
+	// only template and content products have a template
+	if dbData.TemplateID != nil {
+		p.TemplateID = *dbData.TemplateID
+	}
+
+	if dbData.TemplateName != nil {
+		p.TemplateName = *dbData.TemplateName
+	}
+
+	if dbData.TemplateLabel != nil {
+		p.TemplateLabel = *dbData.TemplateLabel
+	}
+
+	if dbData.ProofPaymentMediaID != nil {
+		p.ProofPaymentMediaID = *dbData.ProofPaymentMediaID
 	}
 
 	if dbData.UpdateTime != nil {
@@ -50,3 +118,268 @@ func (dbData *paymentModel) format() payment.Payment {
 
 	return p
 }
+
+type planModel struct {
+	ID          string           `db:"id"`
+	Name        string           `db:"name"`
+	Description string           `db:"description"`
+	Price       int64            `db:"price"`
+	Currency    string           `db:"currency"`
+	Interval    payment.Interval `db:"interval"`
+	Quota       int              `db:"quota"`
+	Active      bool             `db:"active"`
+	Version     int64            `db:"version"`
+	CreateTime  time.Time        `db:"create_time"`
+	UpdateTime  *time.Time       `db:"update_time"`
+}
+
+// format formats database struct into domain struct.
+func (dbData *planModel) format() payment.Plan {
+	p := payment.Plan{
+		ID:          dbData.ID,
+		Name:        dbData.Name,
+		Description: dbData.Description,
+		Price:       money.Money{Amount: dbData.Price, Currency: dbData.Currency},
+		Interval:    dbData.Interval,
+		Quota:       dbData.Quota,
+		Active:      dbData.Active,
+		Version:     dbData.Version,
+		CreateTime:  dbData.CreateTime,
+	}
+
+	if dbData.UpdateTime != nil {
+		p.UpdateTime = *dbData.UpdateTime
+	}
+
+	return p
+}
+
+type subscriptionModel struct {
+	ID           string                     `db:"id"`
+	UserID       string                     `db:"user_id"`
+	PlanID       string                     `db:"plan_id"`
+	PlanName     string                     `db:"plan_name"`
+	PlanInterval payment.Interval           `db:"plan_interval"`
+	Status       payment.SubscriptionStatus `db:"status"`
+	AutoRenew    bool                       `db:"auto_renew"`
+	StartTime    *time.Time                 `db:"start_time"`
+	EndTime      *time.Time                 `db:"end_time"`
+	RenewTime    *time.Time                 `db:"renew_time"`
+	Version      int64                      `db:"version"`
+	CreateTime   time.Time                  `db:"create_time"`
+	UpdateTime   *time.Time                 `db:"update_time"`
+}
+
+// format formats database struct into domain struct.
+func (dbData *subscriptionModel) format() payment.Subscription {
+	s := payment.Subscription{
+		ID:           dbData.ID,
+		UserID:       dbData.UserID,
+		PlanID:       dbData.PlanID,
+		PlanName:     dbData.PlanName,
+		PlanInterval: dbData.PlanInterval,
+		Status:       dbData.Status,
+		AutoRenew:    dbData.AutoRenew,
+		Version:      dbData.Version,
+		CreateTime:   dbData.CreateTime,
+	}
+
+	if dbData.StartTime != nil {
+		s.StartTime = *dbData.StartTime
+	}
+
+	if dbData.EndTime != nil {
+		s.EndTime = *dbData.EndTime
+	}
+
+	if dbData.RenewTime != nil {
+		s.RenewTime = *dbData.RenewTime
+	}
+
+	if dbData.UpdateTime != nil {
+		s.UpdateTime = *dbData.UpdateTime
+	}
+
+	return s
+}
+
+type voucherModel struct {
+	ID                    string               `db:"id"`
+	Code                  string               `db:"code"`
+	DiscountType          payment.DiscountType `db:"discount_type"`
+	DiscountValue         int                  `db:"discount_value"`
+	Currency              string               `db:"currency"`
+	StartTime             *time.Time           `db:"start_time"`
+	EndTime               *time.Time           `db:"end_time"`
+	MaxRedemptions        int                  `db:"max_redemptions"`
+	MaxRedemptionsPerUser int                  `db:"max_redemptions_per_user"`
+	TemplateIDs           pq.StringArray       `db:"template_ids"`
+	PlanIDs               pq.StringArray       `db:"plan_ids"`
+	Active                bool                 `db:"active"`
+	Redemptions           int                  `db:"redemptions"`
+	Version               int64                `db:"version"`
+	CreateTime            time.Time            `db:"create_time"`
+	UpdateTime            *time.Time           `db:"update_time"`
+}
+
+// format formats database struct into domain struct.
+func (dbData *voucherModel) format() payment.Voucher {
+	v := payment.Voucher{
+		ID:                    dbData.ID,
+		Code:                  dbData.Code,
+		DiscountType:          dbData.DiscountType,
+		DiscountValue:         dbData.DiscountValue,
+		Currency:              dbData.Currency,
+		MaxRedemptions:        dbData.MaxRedemptions,
+		MaxRedemptionsPerUser: dbData.MaxRedemptionsPerUser,
+		TemplateIDs:           dbData.TemplateIDs,
+		PlanIDs:               dbData.PlanIDs,
+		Active:                dbData.Active,
+		Redemptions:           dbData.Redemptions,
+		Version:               dbData.Version,
+		CreateTime:            dbData.CreateTime,
+	}
+
+	if dbData.StartTime != nil {
+		v.StartTime = *dbData.StartTime
+	}
+
+	if dbData.EndTime != nil {
+		v.EndTime = *dbData.EndTime
+	}
+
+	if dbData.UpdateTime != nil {
+		v.UpdateTime = *dbData.UpdateTime
+	}
+
+	return v
+}
+
+type invoiceModel struct {
+	ID        string            `db:"id"`
+	PaymentID string            `db:"payment_id"`
+	UserID    string            `db:"user_id"`
+	Number    string            `db:"number"`
+	Year      int               `db:"year"`
+	Sequence  int               `db:"sequence"`
+	UserName  string            `db:"user_name"`
+	UserEmail string            `db:"user_email"`
+	Items     invoiceItemsModel `db:"items"`
+	Subtotal  int64             `db:"subtotal"`
+	Discount  int64             `db:"discount"`
+	Total     int64             `db:"total"`
+	Currency  string            `db:"currency"`
+	IssueTime time.Time         `db:"issue_time"`
+}
+
+// format formats database struct into domain struct.
+func (dbData *invoiceModel) format() payment.Invoice {
+	inv := payment.Invoice{
+		ID:        dbData.ID,
+		PaymentID: dbData.PaymentID,
+		UserID:    dbData.UserID,
+		Number:    dbData.Number,
+		Year:      dbData.Year,
+		Sequence:  dbData.Sequence,
+		UserName:  dbData.UserName,
+		UserEmail: dbData.UserEmail,
+		Items:     make([]payment.InvoiceItem, 0, len(dbData.Items)),
+		Subtotal:  money.Money{Amount: dbData.Subtotal, Currency: dbData.Currency},
+		Discount:  money.Money{Amount: dbData.Discount, Currency: dbData.Currency},
+		Total:     money.Money{Amount: dbData.Total, Currency: dbData.Currency},
+		IssueTime: dbData.IssueTime,
+	}
+
+	for _, item := range dbData.Items {
+		inv.Items = append(inv.Items, payment.InvoiceItem{
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   money.Money{Amount: item.UnitPrice, Currency: dbData.Currency},
+			Amount:      money.Money{Amount: item.Amount, Currency: dbData.Currency},
+		})
+	}
+
+	return inv
+}
+
+// invoiceItemModel is an invoice item stored in the items
+// JSON column, in the currency of the invoice.
+type invoiceItemModel struct {
+	Description string `json:"description"`
+	Quantity    int    `json:"quantity"`
+	UnitPrice   int64  `json:"unit_price"`
+	Amount      int64  `json:"amount"`
+}
+
+// invoiceItemsModel implements sql.Scanner and driver.Valuer
+// to read and write the items JSON column.
+type invoiceItemsModel []invoiceItemModel
+
+// newInvoiceItemsModel converts the given invoice items into
+// their database form.
+func newInvoiceItemsModel(items []payment.InvoiceItem) invoiceItemsModel {
+	res := make(invoiceItemsModel, 0, len(items))
+	for _, item := range items {
+		res = append(res, invoiceItemModel{
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice.Amount,
+			Amount:      item.Amount.Amount,
+		})
+	}
+	return res
+}
+
+// Scan implements the sql.Scanner interface.
+func (m *invoiceItemsModel) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, m)
+	case string:
+		return json.Unmarshal([]byte(v), m)
+	case nil:
+		*m = nil
+		return nil
+	}
+	return fmt.Errorf("unsupported invoice items type %T", src)
+}
+
+// Value implements the driver.Valuer interface.
+func (m invoiceItemsModel) Value() (driver.Value, error) {
+	return json.Marshal(m)
+}
+
+type refundModel struct {
+	ID               string               `db:"id"`
+	PaymentID        string               `db:"payment_id"`
+	Type             payment.RefundType   `db:"type"`
+	Amount           int64                `db:"amount"`
+	Currency         string               `db:"currency"`
+	Reason           string               `db:"reason"`
+	ActorID          string               `db:"actor_id"`
+	Status           payment.RefundStatus `db:"status"`
+	GatewayReference string               `db:"gateway_reference"`
+	CreateTime       time.Time            `db:"create_time"`
+	UpdateTime       *time.Time           `db:"update_time"`
+}
+
+// format formats database struct into domain struct.
+func (dbData *refundModel) format() payment.Refund {
+	r := payment.Refund{
+		ID:               dbData.ID,
+		PaymentID:        dbData.PaymentID,
+		Type:             dbData.Type,
+		Amount:           money.Money{Amount: dbData.Amount, Currency: dbData.Currency},
+		Reason:           dbData.Reason,
+		ActorID:          dbData.ActorID,
+		Status:           dbData.Status,
+		GatewayReference: dbData.GatewayReference,
+		CreateTime:       dbData.CreateTime,
+	}
+
+	if dbData.UpdateTime != nil {
+		r.UpdateTime = *dbData.UpdateTime
+	}
+
+	return r
+}
@@ -1,6 +1,7 @@
 package postgresql
 
 import (
+	"context"
 	"errors"
 	"hbdtoyou/internal/payment/service"
 	pglib "hbdtoyou/pkg/postgresql"
@@ -11,11 +12,13 @@ import (
 var (
 	errInvalidCommit   = errors.New("cannot do commit on non-transactional querier")
 	errInvalidRollback = errors.New("cannot do rollback on non-transactional querier")
+
+	errInvalidSavepoint = errors.New("cannot do savepoint on non-transactional querier")
 )
 
 // store implements teachingmaterial/service.PGStore
 type store struct {
-	db *sqlx.DB
+	router *pglib.Router
 }
 
 // storeClient implements teachingmaterial/service.PGStoreClient.
@@ -23,23 +26,29 @@ type storeClient struct {
 	q pglib.Querier
 }
 
-// New creates a new store.
-func New(db *sqlx.DB) (*store, error) {
+// New creates a new store. Every client is created on the
+// database of the tenant in the given context.
+func New(router *pglib.Router) (*store, error) {
 	s := &store{
-		db: db,
+		router: router,
 	}
 
 	return s, nil
 }
 
-func (s *store) NewClient(useTx bool) (service.PGStoreClient, error) {
+func (s *store) NewClient(ctx context.Context, useTx bool) (service.PGStoreClient, error) {
 	var q pglib.Querier
 
+	// route to the database of the tenant
+	db, err := s.router.GetDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// determine what object should be use as querier
-	q = s.db
+	q = db
 	if useTx {
-		var err error
-		q, err = s.db.Beginx()
+		q, err = db.Beginx()
 		if err != nil {
 			return nil, err
 		}
@@ -63,3 +72,26 @@ func (sc *storeClient) Rollback() error {
 	}
 	return errInvalidRollback
 }
+
+func (sc *storeClient) Savepoint(name string) error {
+	if tx, ok := sc.q.(*sqlx.Tx); ok {
+		_, err := tx.Exec("SAVEPOINT " + name)
+		return err
+	}
+	return errInvalidSavepoint
+}
+
+func (sc *storeClient) RollbackToSavepoint(name string) error {
+	if tx, ok := sc.q.(*sqlx.Tx); ok {
+		_, err := tx.Exec("ROLLBACK TO SAVEPOINT " + name)
+		return err
+	}
+	return errInvalidSavepoint
+}
+
+func (sc *storeClient) ShareTx(ctx context.Context) context.Context {
+	if tx, ok := sc.q.(*sqlx.Tx); ok {
+		return pglib.SetTx(ctx, tx)
+	}
+	return ctx
+}
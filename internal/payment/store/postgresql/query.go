@@ -6,9 +6,19 @@ const (
 			payment
 			(
 				user_id,
+				product_type,
+				product_id,
 				content_id,
 				amount,
+				currency,
+				quota,
+				subscription_id,
+				voucher_id,
+				discount,
+				gateway,
+				gateway_reference,
 				proof_payment_url,
+				proof_payment_media_id,
 				date,
 				status,
 				create_time
@@ -16,9 +26,19 @@ const (
 		VALUES
 			(
 				:user_id,
+				:product_type,
+				:product_id,
 				:content_id,
 				:amount,
+				:currency,
+				:quota,
+				:subscription_id,
+				:voucher_id,
+				:discount,
+				:gateway,
+				:gateway_reference,
 				:proof_payment_url,
+				:proof_payment_media_id,
 				:date,
 				:status,
 				:create_time
@@ -34,13 +54,30 @@ const (
 			u.fullname as user_name,
 			u.type as user_type,
 			u.quota as user_quota,
+			p.product_type,
+			p.product_id,
 			p.content_id,
+			t.id as template_id,
 			t.label as template_label,
 			t.name as template_name,
 			p.amount,
+			p.currency,
+			p.quota,
+			p.subscription_id,
+			p.voucher_id,
+			v.code as voucher_code,
+			p.discount,
+			p.refunded_amount,
+			p.gateway,
+			p.gateway_reference,
+			p.reviewer_id,
+			p.claim_time,
+			p.review_reason,
 			p.proof_payment_url,
+			p.proof_payment_media_id,
 			p.date,
 			p.status,
+			p.version,
 			p.create_time,
 			p.update_time
 		FROM
@@ -50,13 +87,15 @@ const (
 		ON
 			u.id = p.user_id
 		LEFT JOIN
-			content c
+			template t
 		ON
-			c.id = p.content_id
+			p.product_type IN (1, 2)
+		AND
+			t.id::text = p.product_id
 		LEFT JOIN
-			template t
+			voucher v
 		ON
-			t.id = c.template_id
+			v.id = p.voucher_id
 		%s
 	`
 
@@ -67,11 +106,494 @@ const (
 			user_id = :user_id,
 			content_id = :content_id,
 			amount = :amount,
-			proof_payment_url = :proof_payment_url,
+			refunded_amount = :refunded_amount,
+			reviewer_id = :reviewer_id,
+			claim_time = :claim_time,
+			review_reason = :review_reason,
+			proof_payment_url = COALESCE(:proof_payment_url, proof_payment_url),
+			proof_payment_media_id = :proof_payment_media_id,
 			date = :date,
 			status = :status,
+			version = version + 1,
+			update_time = :update_time
+		WHERE
+			id = :id
+		AND
+			version = :version
+	`
+
+	// queryClaimPayment claims a pending payment unless it is
+	// claimed by another reviewer since the expire time.
+	queryClaimPayment = `
+		UPDATE
+			payment
+		SET
+			reviewer_id = :reviewer_id,
+			claim_time = :claim_time
+		WHERE
+			id = :id
+		AND
+			status = :status
+		AND
+			(
+				reviewer_id IS NULL
+			OR
+				reviewer_id = :reviewer_id
+			OR
+				claim_time IS NULL
+			OR
+				claim_time <= :expire_time
+			)
+	`
+
+	queryReleasePayment = `
+		UPDATE
+			payment
+		SET
+			claim_time = NULL
+		WHERE
+			id = :id
+		AND
+			status = :status
+		AND
+			reviewer_id = :reviewer_id
+		AND
+			claim_time IS NOT NULL
+	`
+
+	queryCreatePlan = `
+		INSERT INTO
+			plan
+			(
+				name,
+				description,
+				price,
+				currency,
+				interval,
+				quota,
+				active,
+				create_time
+			)
+		VALUES
+			(
+				:name,
+				:description,
+				:price,
+				:currency,
+				:interval,
+				:quota,
+				:active,
+				:create_time
+			)
+		RETURNING
+			id
+	`
+
+	queryGetPlan = `
+		SELECT
+			pl.id,
+			pl.name,
+			pl.description,
+			pl.price,
+			pl.currency,
+			pl.interval,
+			pl.quota,
+			pl.active,
+			pl.version,
+			pl.create_time,
+			pl.update_time
+		FROM
+			plan pl
+		%s
+	`
+
+	queryUpdatePlan = `
+		UPDATE
+			plan
+		SET
+			name = :name,
+			description = :description,
+			price = :price,
+			currency = :currency,
+			interval = :interval,
+			quota = :quota,
+			active = :active,
+			version = version + 1,
 			update_time = :update_time
 		WHERE
 			id = :id
+		AND
+			version = :version
+	`
+
+	queryCreateSubscription = `
+		INSERT INTO
+			subscription
+			(
+				user_id,
+				plan_id,
+				status,
+				auto_renew,
+				create_time
+			)
+		VALUES
+			(
+				:user_id,
+				:plan_id,
+				:status,
+				:auto_renew,
+				:create_time
+			)
+		RETURNING
+			id
+	`
+
+	queryGetSubscription = `
+		SELECT
+			s.id,
+			s.user_id,
+			s.plan_id,
+			pl.name as plan_name,
+			pl.interval as plan_interval,
+			s.status,
+			s.auto_renew,
+			s.start_time,
+			s.end_time,
+			s.renew_time,
+			s.version,
+			s.create_time,
+			s.update_time
+		FROM
+			subscription s
+		JOIN
+			plan pl
+		ON
+			pl.id = s.plan_id
+		%s
+	`
+
+	queryUpdateSubscription = `
+		UPDATE
+			subscription
+		SET
+			status = :status,
+			auto_renew = :auto_renew,
+			start_time = :start_time,
+			end_time = :end_time,
+			renew_time = :renew_time,
+			version = version + 1,
+			update_time = :update_time
+		WHERE
+			id = :id
+		AND
+			version = :version
+	`
+
+	queryHasActiveSubscription = `
+		SELECT EXISTS (
+			SELECT
+				1
+			FROM
+				subscription s
+			WHERE
+				s.user_id = $1
+			AND
+				s.status = $2
+			AND
+				s.end_time > $3
+		)
+	`
+
+	queryCreateVoucher = `
+		INSERT INTO
+			voucher
+			(
+				code,
+				discount_type,
+				discount_value,
+				currency,
+				start_time,
+				end_time,
+				max_redemptions,
+				max_redemptions_per_user,
+				template_ids,
+				plan_ids,
+				active,
+				create_time
+			)
+		VALUES
+			(
+				:code,
+				:discount_type,
+				:discount_value,
+				:currency,
+				:start_time,
+				:end_time,
+				:max_redemptions,
+				:max_redemptions_per_user,
+				:template_ids,
+				:plan_ids,
+				:active,
+				:create_time
+			)
+		RETURNING
+			id
+	`
+
+	queryGetVoucher = `
+		SELECT
+			v.id,
+			v.code,
+			v.discount_type,
+			v.discount_value,
+			v.currency,
+			v.start_time,
+			v.end_time,
+			v.max_redemptions,
+			v.max_redemptions_per_user,
+			v.template_ids,
+			v.plan_ids,
+			v.active,
+			(
+				SELECT
+					COUNT(*)
+				FROM
+					payment p
+				WHERE
+					p.voucher_id = v.id
+				AND
+					p.status != 3
+			) as redemptions,
+			v.version,
+			v.create_time,
+			v.update_time
+		FROM
+			voucher v
+		%s
+	`
+
+	queryLockVoucher = `
+		SELECT
+			v.id,
+			v.code,
+			v.discount_type,
+			v.discount_value,
+			v.currency,
+			v.start_time,
+			v.end_time,
+			v.max_redemptions,
+			v.max_redemptions_per_user,
+			v.template_ids,
+			v.plan_ids,
+			v.active,
+			v.version,
+			v.create_time,
+			v.update_time
+		FROM
+			voucher v
+		WHERE
+			v.code = $1
+		FOR UPDATE
+	`
+
+	queryUpdateVoucher = `
+		UPDATE
+			voucher
+		SET
+			code = :code,
+			discount_type = :discount_type,
+			discount_value = :discount_value,
+			currency = :currency,
+			start_time = :start_time,
+			end_time = :end_time,
+			max_redemptions = :max_redemptions,
+			max_redemptions_per_user = :max_redemptions_per_user,
+			template_ids = :template_ids,
+			plan_ids = :plan_ids,
+			active = :active,
+			version = version + 1,
+			update_time = :update_time
+		WHERE
+			id = :id
+		AND
+			version = :version
+	`
+
+	queryCountVoucherRedemptions = `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE p.user_id = $2)
+		FROM
+			payment p
+		WHERE
+			p.voucher_id = $1
+		AND
+			p.status != 3
+	`
+
+	queryNextInvoiceSequence = `
+		INSERT INTO
+			invoice_sequence
+			(
+				year,
+				last_sequence
+			)
+		VALUES
+			(
+				$1,
+				1
+			)
+		ON CONFLICT
+			(year)
+		DO UPDATE SET
+			last_sequence = invoice_sequence.last_sequence + 1
+		RETURNING
+			last_sequence
+	`
+
+	queryCreateInvoice = `
+		INSERT INTO
+			invoice
+			(
+				payment_id,
+				user_id,
+				number,
+				year,
+				sequence,
+				user_name,
+				user_email,
+				items,
+				subtotal,
+				discount,
+				total,
+				currency,
+				issue_time
+			)
+		VALUES
+			(
+				:payment_id,
+				:user_id,
+				:number,
+				:year,
+				:sequence,
+				:user_name,
+				:user_email,
+				:items,
+				:subtotal,
+				:discount,
+				:total,
+				:currency,
+				:issue_time
+			)
+		RETURNING
+			id
+	`
+
+	queryGetInvoice = `
+		SELECT
+			i.id,
+			i.payment_id,
+			i.user_id,
+			i.number,
+			i.year,
+			i.sequence,
+			i.user_name,
+			i.user_email,
+			i.items,
+			i.subtotal,
+			i.discount,
+			i.total,
+			i.currency,
+			i.issue_time
+		FROM
+			invoice i
+		%s
+	`
+
+	queryCreateRefund = `
+		INSERT INTO
+			refund
+			(
+				payment_id,
+				type,
+				amount,
+				currency,
+				reason,
+				actor_id,
+				status,
+				gateway_reference,
+				create_time
+			)
+		VALUES
+			(
+				:payment_id,
+				:type,
+				:amount,
+				:currency,
+				:reason,
+				:actor_id,
+				:status,
+				:gateway_reference,
+				:create_time
+			)
+		RETURNING
+			id
+	`
+
+	queryUpdateRefund = `
+		UPDATE
+			refund
+		SET
+			status = :status,
+			gateway_reference = :gateway_reference,
+			update_time = :update_time
+		WHERE
+			id = :id
+	`
+
+	queryGetRefund = `
+		SELECT
+			r.id,
+			r.payment_id,
+			r.type,
+			r.amount,
+			r.currency,
+			r.reason,
+			r.actor_id,
+			r.status,
+			r.gateway_reference,
+			r.create_time,
+			r.update_time
+		FROM
+			refund r
+		%s
+	`
+
+	queryCreateNotification = `
+		INSERT INTO
+			notification
+			(
+				user_id,
+				event,
+				subject,
+				body,
+				data,
+				status,
+				next_attempt_time,
+				create_time
+			)
+		VALUES
+			(
+				:user_id,
+				:event,
+				:subject,
+				:body,
+				:data,
+				:status,
+				:next_attempt_time,
+				:create_time
+			)
+		RETURNING
+			id
 	`
 )
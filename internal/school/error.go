@@ -0,0 +1,49 @@
+package school
+
+import "errors"
+
+var (
+	// ErrDataNotFound is returned when the desired data is
+	// not found.
+	ErrDataNotFound = errors.New("data not found")
+
+	// ErrForbidden is returned when the user is not allowed
+	// to access the requested data.
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrInvalidUserID is returned when the given user ID is
+	// invalid.
+	ErrInvalidUserID = errors.New("invalid user id")
+
+	// ErrInvalidSchoolID is returned when the given school ID
+	// is invalid.
+	ErrInvalidSchoolID = errors.New("invalid school id")
+
+	// ErrInvalidSchoolCode is returned when the given school
+	// code is invalid.
+	ErrInvalidSchoolCode = errors.New("invalid school code")
+
+	// ErrInvalidSchoolName is returned when the given school
+	// name is empty.
+	ErrInvalidSchoolName = errors.New("invalid school name")
+
+	// ErrInvalidTenant is returned when the given tenant is
+	// not a configured database, or is the default one.
+	ErrInvalidTenant = errors.New("invalid tenant")
+
+	// ErrInvalidSchoolStatus is returned when the given
+	// school status is invalid.
+	ErrInvalidSchoolStatus = errors.New("invalid school status")
+
+	// ErrSchoolAlreadyExist is returned when there is another
+	// school with the given code.
+	ErrSchoolAlreadyExist = errors.New("school already exist")
+
+	// ErrTenantAlreadyUsed is returned when the given tenant
+	// belongs to another school.
+	ErrTenantAlreadyUsed = errors.New("tenant already used")
+
+	// ErrSchoolInactive is returned when resolving an
+	// inactive school.
+	ErrSchoolInactive = errors.New("school inactive")
+)
@@ -0,0 +1,25 @@
+package grpc
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Followings are the known errors from school gRPC handlers.
+var (
+	// errInternalServer is returned when there is an
+	// unexpected error encountered when processing a request.
+	errInternalServer = status.Error(codes.Internal, "INTERNAL_SERVER_ERROR")
+
+	// errSchoolNotFound is returned when the school of a
+	// request is not found.
+	errSchoolNotFound = status.Error(codes.NotFound, "SCHOOL_NOT_FOUND")
+
+	// errSchoolInactive is returned when the school of a
+	// request is not active.
+	errSchoolInactive = status.Error(codes.PermissionDenied, "SCHOOL_INACTIVE")
+
+	// errSchoolMismatch is returned when the request refers
+	// to different schools.
+	errSchoolMismatch = status.Error(codes.InvalidArgument, "SCHOOL_MISMATCH")
+)
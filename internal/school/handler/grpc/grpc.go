@@ -0,0 +1,50 @@
+package grpc
+
+import (
+	"hbdtoyou/internal/auth"
+	"hbdtoyou/internal/school"
+)
+
+// Handler contains school gRPC handlers. There is no school
+// gRPC service yet, the handler only resolves the school of
+// requests to other services.
+type Handler struct {
+	school        school.Service
+	auth          auth.Service
+	tenantSetting TenantSetting
+}
+
+// Option controls the behavior of Handler.
+type Option func(*Handler) error
+
+// WithTenantSetting returns Option to set the tenant
+// resolution setting.
+func WithTenantSetting(setting TenantSetting) Option {
+	return Option(func(h *Handler) error {
+		if setting.Metadata != "" {
+			h.tenantSetting.Metadata = setting.Metadata
+		}
+		return nil
+	})
+}
+
+// New creates a new Handler.
+func New(schoolSvc school.Service, auth auth.Service, options ...Option) (*Handler, error) {
+	h := &Handler{
+		school: schoolSvc,
+		auth:   auth,
+		tenantSetting: TenantSetting{
+			Metadata: defaultTenantMetadata,
+		},
+	}
+
+	// apply options
+	for _, opt := range options {
+		err := opt(h)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return h, nil
+}
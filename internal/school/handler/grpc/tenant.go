@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"hbdtoyou/internal/school"
+	"log"
+	"strings"
+
+	contextlib "hbdtoyou/pkg/context"
+	grpclib "hbdtoyou/pkg/grpc"
+
+	"google.golang.org/grpc"
+)
+
+// Followings are default values for TenantSetting fields.
+const (
+	defaultTenantMetadata = "x-school"
+)
+
+// TenantSetting is the available configurations of the tenant
+// resolution.
+//
+// The school of a request is resolved, in order, from:
+//   - the metadata, holding the school ID or code
+//   - the school claim of the bearer token
+//
+// A request without a school is served from the default
+// database.
+type TenantSetting struct {
+	Metadata string
+}
+
+// UnaryServerInterceptor returns a gRPC interceptor setting the
+// school and the tenant of the request in its context.
+func (h *Handler) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		refs := h.getSchoolRefs(ctx)
+		if len(refs) == 0 {
+			return handler(ctx, req)
+		}
+
+		s, err := h.school.ResolveSchool(ctx, refs[0])
+		if err != nil {
+			switch err {
+			case school.ErrDataNotFound, school.ErrInvalidSchoolID:
+				return nil, errSchoolNotFound
+			case school.ErrSchoolInactive:
+				return nil, errSchoolInactive
+			}
+
+			log.Printf("[School gRPC][%s] Internal error from ResolveSchool. Err: %s\n", info.FullMethod, err.Error())
+			return nil, errInternalServer
+		}
+
+		// every given reference has to point to the same school
+		for _, ref := range refs[1:] {
+			if !strings.EqualFold(ref, s.ID) && !strings.EqualFold(ref, s.Code) {
+				return nil, errSchoolMismatch
+			}
+		}
+
+		ctx = contextlib.SetSchoolID(ctx, s.ID)
+		ctx = contextlib.SetTenant(ctx, s.Tenant)
+		return handler(ctx, req)
+	}
+}
+
+// getSchoolRefs returns the school IDs or codes given in the
+// request, in the order of precedence.
+func (h *Handler) getSchoolRefs(ctx context.Context) []string {
+	var refs []string
+
+	if ref := grpclib.GetValueFromMetadata(ctx, h.tenantSetting.Metadata); ref != "" {
+		refs = append(refs, ref)
+	}
+
+	// an invalid token is left to be refused by the auth
+	// interceptor
+	if token, err := grpclib.GetBearerTokenFromMetadata(ctx); err == nil {
+		tokenData, err := h.auth.ValidateToken(ctx, token)
+		if err == nil && tokenData.SchoolID != "" {
+			refs = append(refs, tokenData.SchoolID)
+		}
+	}
+
+	return refs
+}
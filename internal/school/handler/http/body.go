@@ -0,0 +1,80 @@
+package http
+
+import (
+	"hbdtoyou/internal/school"
+	"time"
+)
+
+// timeFormat denotes the standard time format used in school
+// HTTP handlers.
+var timeFormat = "02/01/2006 3:04 PM -07:00"
+
+type schoolHTTP struct {
+	ID         *string `json:"id"`
+	Code       *string `json:"code"`
+	Name       *string `json:"name"`
+	Tenant     *string `json:"tenant"`
+	Status     *string `json:"status"`
+	CreateTime *string `json:"create_time"`
+	UpdateTime *string `json:"update_time"`
+}
+
+func formatSchool(s school.School) schoolHTTP {
+	status := s.Status.String()
+
+	return schoolHTTP{
+		ID:         &s.ID,
+		Code:       &s.Code,
+		Name:       &s.Name,
+		Tenant:     &s.Tenant,
+		Status:     &status,
+		CreateTime: formatTime(s.CreateTime),
+		UpdateTime: formatTime(s.UpdateTime),
+	}
+}
+
+func (s schoolHTTP) parseSchool(out *school.School) error {
+	if s.Code != nil {
+		out.Code = *s.Code
+	}
+
+	if s.Name != nil {
+		out.Name = *s.Name
+	}
+
+	if s.Tenant != nil {
+		out.Tenant = *s.Tenant
+	}
+
+	if s.Status != nil {
+		status, err := parseSchoolStatus(*s.Status)
+		if err != nil {
+			return err
+		}
+		out.Status = status
+	}
+
+	return nil
+}
+
+func parseSchoolStatus(req string) (school.Status, error) {
+	switch req {
+	case school.StatusActive.String():
+		return school.StatusActive, nil
+	case school.StatusInactive.String():
+		return school.StatusInactive, nil
+	}
+
+	return school.StatusUnknown, errInvalidSchoolStatus
+}
+
+// formatTime returns the given time formatted in timeFormat,
+// or nil for a zero time.
+func formatTime(t time.Time) *string {
+	if t.IsZero() {
+		return nil
+	}
+
+	formatted := t.Format(timeFormat)
+	return &formatted
+}
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"errors"
+	"hbdtoyou/internal/school"
+)
+
+// Followings are the known errors from School HTTP handlers.
+var (
+	// errBadRequest is returned when the given request is
+	// bad/invalid.
+	errBadRequest = errors.New("BAD_REQUEST")
+
+	// errInternalServer is returned when there is an
+	// unexpected error encountered when processing a request.
+	errInternalServer = errors.New("INTERNAL_SERVER_ERROR")
+
+	// errDataNotFound is returned when the desired data is
+	// not found.
+	errDataNotFound = errors.New("DATA_NOT_FOUND")
+
+	// errForbidden is returned when the user is not allowed
+	// to access the requested data.
+	errForbidden = errors.New("FORBIDDEN")
+
+	// errInvalidToken is returned when the given token is
+	// invalid.
+	errInvalidToken = errors.New("INVALID_TOKEN")
+
+	// errInvalidUserID is returned when the given user ID is
+	// invalid.
+	errInvalidUserID = errors.New("INVALID_USER_ID")
+
+	// errInvalidSchoolID is returned when the given school ID
+	// is invalid.
+	errInvalidSchoolID = errors.New("INVALID_SCHOOL_ID")
+
+	// errInvalidSchoolCode is returned when the given school
+	// code is invalid.
+	errInvalidSchoolCode = errors.New("INVALID_SCHOOL_CODE")
+
+	// errInvalidSchoolName is returned when the given school
+	// name is invalid.
+	errInvalidSchoolName = errors.New("INVALID_SCHOOL_NAME")
+
+	// errInvalidTenant is returned when the given tenant is
+	// invalid.
+	errInvalidTenant = errors.New("INVALID_TENANT")
+
+	// errInvalidSchoolStatus is returned when the given
+	// school status is invalid.
+	errInvalidSchoolStatus = errors.New("INVALID_SCHOOL_STATUS")
+
+	// errSchoolAlreadyExist is returned when there is another
+	// school with the given code.
+	errSchoolAlreadyExist = errors.New("SCHOOL_ALREADY_EXIST")
+
+	// errTenantAlreadyUsed is returned when the given tenant
+	// belongs to another school.
+	errTenantAlreadyUsed = errors.New("TENANT_ALREADY_USED")
+
+	// errSchoolNotFound is returned when the school of a
+	// request is unknown.
+	errSchoolNotFound = errors.New("SCHOOL_NOT_FOUND")
+
+	// errSchoolInactive is returned when the school of a
+	// request is inactive.
+	errSchoolInactive = errors.New("SCHOOL_INACTIVE")
+
+	// errSchoolMismatch is returned when the request refers
+	// to different schools.
+	errSchoolMismatch = errors.New("SCHOOL_MISMATCH")
+
+	// errMethodNotAllowed is returned when accessing not
+	// allowed HTTP method.
+	errMethodNotAllowed = errors.New("METHOD_NOT_ALLOWED")
+
+	// errRequestTimeout is returned when processing time has
+	// reached the timeout limit.
+	errRequestTimeout = errors.New("REQUEST_TIMEOUT")
+
+	// errSourceNotProvided is returned when there is no
+	// source provided in the request.
+	errSourceNotProvided = errors.New("SOURCE_NOT_PROVIDED")
+
+	// errUnauthorizedAccess is returned when the request
+	// is unaothorized.
+	errUnauthorizedAccess = errors.New("UNAUTHORIZED_ACCESS")
+)
+
+var (
+	// mapHTTPError maps service error into HTTP error that
+	// categorize as bad request error.
+	//
+	// Internal server error-related should not be mapped here,
+	// and the handler should just return `errInternal` as the
+	// error instead
+	mapHTTPError = map[error]error{
+		school.ErrDataNotFound:        errDataNotFound,
+		school.ErrForbidden:           errForbidden,
+		school.ErrInvalidUserID:       errInvalidUserID,
+		school.ErrInvalidSchoolID:     errInvalidSchoolID,
+		school.ErrInvalidSchoolCode:   errInvalidSchoolCode,
+		school.ErrInvalidSchoolName:   errInvalidSchoolName,
+		school.ErrInvalidTenant:       errInvalidTenant,
+		school.ErrInvalidSchoolStatus: errInvalidSchoolStatus,
+		school.ErrSchoolAlreadyExist:  errSchoolAlreadyExist,
+		school.ErrTenantAlreadyUsed:   errTenantAlreadyUsed,
+	}
+)
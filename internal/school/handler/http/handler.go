@@ -0,0 +1,49 @@
+package http
+
+import (
+	"hbdtoyou/internal/auth"
+	"hbdtoyou/internal/school"
+	"net/http"
+
+	httplib "hbdtoyou/pkg/http"
+
+	"github.com/gorilla/mux"
+)
+
+type schoolsHandler struct {
+	school        school.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *schoolsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetSchools(w, r)
+	case http.MethodPost:
+		h.handleCreateSchool(w, r)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type schoolHandler struct {
+	school        school.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *schoolHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	schoolID := vars["id"]
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetSchoolByID(w, r, schoolID)
+	case http.MethodPatch:
+		h.handleUpdateSchool(w, r, schoolID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
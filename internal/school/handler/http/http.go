@@ -0,0 +1,243 @@
+package http
+
+import (
+	"errors"
+	"hbdtoyou/internal/auth"
+	"hbdtoyou/internal/school"
+	httplib "hbdtoyou/pkg/http"
+	"hbdtoyou/pkg/ratelimit"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+var (
+	errUnknownScope     = errors.New("unknown scope name")
+	errUnknownConfig    = errors.New("unknown config name")
+	errInvalidRateLimit = errors.New("invalid rate limit")
+)
+
+// Handler contains school HTTP handlers.
+type Handler struct {
+	handlers      map[string]*handler
+	school        school.Service
+	tenantSetting TenantSetting
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+	rateLimiter   *httplib.RateLimiter
+}
+
+// handler is the HTTP handler wrapper.
+type handler struct {
+	h        http.Handler
+	identity HandlerIdentity
+}
+
+// HandlerIdentity denotes the identity of an HTTP hanlder.
+type HandlerIdentity struct {
+	Name string
+	URL  string
+}
+
+// Followings are the known HTTP handler identities
+var (
+	HandlerSchools = HandlerIdentity{
+		Name: "schools",
+		URL:  "/v1/schools",
+	}
+	HandlerSchool = HandlerIdentity{
+		Name: "school",
+		URL:  "/v1/schools/{id}",
+	}
+)
+
+// Scope is a shared settings identifier.
+//
+// Registering a new Scope is done by adding a new Scope
+// value and new entry in ScopeName and ScopeValue.
+type Scope int
+
+// Followings are the known scopes in school HTTP handlers.
+const (
+	_ Scope = iota
+	ScopeCreateSchool
+	ScopeGetSchools
+	ScopeGetSchoolByID
+	ScopeUpdateSchool
+)
+
+var (
+	// ScopeName defines all the known scopes and their string
+	// representation.
+	ScopeName = map[Scope]string{
+		ScopeCreateSchool:  "CreateSchool",
+		ScopeGetSchools:    "GetSchools",
+		ScopeGetSchoolByID: "GetSchoolByID",
+		ScopeUpdateSchool:  "UpdateSchool",
+	}
+
+	// ScopeValue is the reverse-mapping of ScopeName.
+	ScopeValue = map[string]Scope{
+		ScopeName[ScopeCreateSchool]:  ScopeCreateSchool,
+		ScopeName[ScopeGetSchools]:    ScopeGetSchools,
+		ScopeName[ScopeGetSchoolByID]: ScopeGetSchoolByID,
+		ScopeName[ScopeUpdateSchool]:  ScopeUpdateSchool,
+	}
+)
+
+// ScopeSetting is the available configurations of a Scope.
+type ScopeSetting struct {
+	Timeout time.Duration
+
+	// RateLimit limits the requests of the scope per user,
+	// or per client IP if the request has no user. The zero
+	// value does not limit.
+	RateLimit ratelimit.Limit
+}
+
+// Followings are default values for ScopeSetting fields.
+const (
+	defaultTimeout = 5000 * time.Millisecond
+)
+
+// getDefaultScopeSettings returns default scope settings
+// for all scopes.
+func getDefaultScopeSettings() map[Scope]ScopeSetting {
+	defaultSettings := make(map[Scope]ScopeSetting)
+	for _, scope := range ScopeValue {
+		defaultSettings[scope] = ScopeSetting{
+			Timeout: defaultTimeout,
+		}
+	}
+	return defaultSettings
+}
+
+// Option controls the behavior of Handler.
+type Option func(*Handler) error
+
+// WithHandler returns Option to add HTTP handler.
+func WithHandler(identity HandlerIdentity) Option {
+	return Option(func(h *Handler) error {
+		if h.handlers == nil {
+			h.handlers = map[string]*handler{}
+		}
+
+		h.handlers[identity.Name] = &handler{
+			identity: identity,
+		}
+
+		handler, err := h.createHTTPHandler(identity.Name)
+		if err != nil {
+			return err
+		}
+
+		h.handlers[identity.Name].h = handler
+		return nil
+	})
+}
+
+// WithScopeSetting returns Option to set scope setting for
+// a specific scope name.
+func WithScopeSetting(scopeName string, scopeSetting ScopeSetting) Option {
+	return Option(func(h *Handler) error {
+		scope, ok := ScopeValue[scopeName]
+		if !ok {
+			return errUnknownScope
+		}
+
+		// validate setting
+		if scopeSetting.Timeout <= 0 {
+			scopeSetting.Timeout = defaultTimeout
+		}
+		if err := scopeSetting.RateLimit.Validate(); err != nil {
+			return errInvalidRateLimit
+		}
+
+		h.scopeSettings[scope] = scopeSetting
+		return nil
+	})
+}
+
+// WithTenantSetting returns Option to set how the tenant of a
+// request is resolved.
+func WithTenantSetting(setting TenantSetting) Option {
+	return Option(func(h *Handler) error {
+		if setting.Header != "" {
+			h.tenantSetting.Header = setting.Header
+		}
+		h.tenantSetting.BaseDomain = setting.BaseDomain
+		return nil
+	})
+}
+
+// WithRateLimiter returns Option to limit the rate of requests
+// using the given rate limiter, with the RateLimit of each
+// scope setting.
+func WithRateLimiter(rateLimiter *httplib.RateLimiter) Option {
+	return Option(func(h *Handler) error {
+		h.rateLimiter = rateLimiter
+		return nil
+	})
+}
+
+// New creates a new Handler.
+//
+// For the given Option, WithScopeSetting() should come first
+// before WithHandler()
+func New(schoolSvc school.Service, auth auth.Service, options ...Option) (*Handler, error) {
+	h := &Handler{
+		handlers:      make(map[string]*handler),
+		school:        schoolSvc,
+		auth:          auth,
+		scopeSettings: getDefaultScopeSettings(),
+		tenantSetting: TenantSetting{
+			Header: defaultTenantHeader,
+		},
+	}
+
+	// apply options
+	for _, opt := range options {
+		err := opt(h)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return h, nil
+}
+
+// createHTTPHandler creates a new HTTP handler that
+// implements http.Handler.
+func (h *Handler) createHTTPHandler(configName string) (http.Handler, error) {
+	var httpHandler http.Handler
+	switch configName {
+	case HandlerSchools.Name:
+		httpHandler = &schoolsHandler{
+			school:        h.school,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerSchool.Name:
+		httpHandler = &schoolHandler{
+			school:        h.school,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	default:
+		return httpHandler, errUnknownConfig
+	}
+
+	return httpHandler, nil
+}
+
+// Start starts all HTTP handlers, and resolves the tenant of
+// every request served by the given multiplexer.
+func (h *Handler) Start(multiplexer *mux.Router) error {
+	for _, handler := range h.handlers {
+		multiplexer.Handle(handler.identity.URL, h.limitRate(handler.identity, handler.h))
+	}
+
+	multiplexer.Use(h.resolveTenant)
+	return nil
+}
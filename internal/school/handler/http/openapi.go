@@ -0,0 +1,33 @@
+package http
+
+import (
+	"hbdtoyou/pkg/openapi"
+	"net/http"
+)
+
+// routes defines the OpenAPI routes of each HTTP handler, it
+// must be updated along with the methods served by handler.go.
+var routes = map[string][]openapi.Route{
+	HandlerSchools.Name: {
+		{Method: http.MethodGet, Summary: "Get schools", Response: []schoolHTTP{}},
+		{Method: http.MethodPost, Summary: "Create school", Request: schoolHTTP{}, Response: ""},
+	},
+	HandlerSchool.Name: {
+		{Method: http.MethodGet, Summary: "Get school by ID", Response: schoolHTTP{}},
+		{Method: http.MethodPatch, Summary: "Update school", Request: schoolHTTP{}, Response: ""},
+	},
+}
+
+// Routes returns the OpenAPI routes of the registered HTTP
+// handlers.
+func (h *Handler) Routes() []openapi.Route {
+	var res []openapi.Route
+	for _, handler := range h.handlers {
+		for _, route := range routes[handler.identity.Name] {
+			route.Path = handler.identity.URL
+			route.Tag = "school"
+			res = append(res, route)
+		}
+	}
+	return res
+}
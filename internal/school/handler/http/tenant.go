@@ -0,0 +1,127 @@
+package http
+
+import (
+	"hbdtoyou/internal/school"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	contextlib "hbdtoyou/pkg/context"
+	httplib "hbdtoyou/pkg/http"
+)
+
+// Followings are default values for TenantSetting fields.
+const (
+	defaultTenantHeader = "X-School"
+)
+
+// TenantSetting is the available configurations of the tenant
+// resolution.
+//
+// The school of a request is resolved, in order, from:
+//   - the header, holding the school ID or code
+//   - the subdomain of the base domain, holding the school code
+//   - the school claim of the bearer token
+//
+// A request without a school is served from the default
+// database.
+type TenantSetting struct {
+	Header string
+
+	// BaseDomain is the domain the schools are subdomains of,
+	// e.g. "hbdtoyou.com" for "school-a.hbdtoyou.com". The
+	// subdomain is not used if it is empty.
+	BaseDomain string
+}
+
+// resolveTenant is a middleware setting the school and the
+// tenant of the request in its context.
+func (h *Handler) resolveTenant(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		refs := h.getSchoolRefs(r)
+		if len(refs) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		s, err := h.school.ResolveSchool(r.Context(), refs[0])
+		if err != nil {
+			statusCode, parsedErr := http.StatusBadRequest, errSchoolNotFound
+			switch err {
+			case school.ErrDataNotFound, school.ErrInvalidSchoolID:
+			case school.ErrSchoolInactive:
+				statusCode, parsedErr = http.StatusForbidden, errSchoolInactive
+			default:
+				log.Printf("[School HTTP][resolveTenant] Internal error from ResolveSchool. Err: %s\n", err.Error())
+				statusCode, parsedErr = http.StatusInternalServerError, errInternalServer
+			}
+
+			httplib.WriteErrorResponse(w, statusCode, []string{parsedErr.Error()})
+			return
+		}
+
+		// every given reference has to point to the same school
+		for _, ref := range refs[1:] {
+			if !strings.EqualFold(ref, s.ID) && !strings.EqualFold(ref, s.Code) {
+				httplib.WriteErrorResponse(w, http.StatusBadRequest, []string{errSchoolMismatch.Error()})
+				return
+			}
+		}
+
+		ctx := contextlib.SetSchoolID(r.Context(), s.ID)
+		ctx = contextlib.SetTenant(ctx, s.Tenant)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// getSchoolRefs returns the school IDs or codes given in the
+// request, in the order of precedence.
+func (h *Handler) getSchoolRefs(r *http.Request) []string {
+	var refs []string
+
+	if ref := strings.TrimSpace(r.Header.Get(h.tenantSetting.Header)); ref != "" {
+		refs = append(refs, ref)
+	}
+
+	if ref := h.getSubdomain(r); ref != "" {
+		refs = append(refs, ref)
+	}
+
+	// an invalid token is left to be refused by the handler
+	if token, err := httplib.GetBearerTokenFromHeader(r); err == nil {
+		tokenData, err := h.auth.ValidateToken(r.Context(), token)
+		if err == nil && tokenData.SchoolID != "" {
+			refs = append(refs, tokenData.SchoolID)
+		}
+	}
+
+	return refs
+}
+
+// getSubdomain returns the subdomain of the base domain the
+// request is made to, if any.
+func (h *Handler) getSubdomain(r *http.Request) string {
+	if h.tenantSetting.BaseDomain == "" {
+		return ""
+	}
+
+	host := r.Host
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+
+	suffix := "." + strings.ToLower(h.tenantSetting.BaseDomain)
+	host = strings.ToLower(host)
+	if !strings.HasSuffix(host, suffix) {
+		return ""
+	}
+
+	// only a direct subdomain is a school code
+	subdomain := strings.TrimSuffix(host, suffix)
+	if subdomain == "" || strings.Contains(subdomain, ".") {
+		return ""
+	}
+
+	return subdomain
+}
@@ -0,0 +1,92 @@
+package school
+
+import (
+	"context"
+	"time"
+)
+
+// Service is the interface for school service.
+//
+// A school is a tenant of the service. Each school has its own
+// database, every request made for a school is served from it.
+// Requests made without a school are served from the default
+// database, which also keeps the schools.
+//
+// Only administrators of the default database manage schools.
+type Service interface {
+	// CreateSchool creates a new school and returns the
+	// created school ID.
+	CreateSchool(ctx context.Context, reqSchool School) (string, error)
+
+	// GetSchools returns all schools.
+	GetSchools(ctx context.Context) ([]School, error)
+
+	// GetSchoolByID returns a school with the given school ID.
+	GetSchoolByID(ctx context.Context, schoolID string) (School, error)
+
+	// UpdateSchool updates existing school with the given
+	// school data.
+	UpdateSchool(ctx context.Context, reqSchool School) error
+
+	// ResolveSchool returns an active school with the given
+	// school ID or code. It is used to resolve the tenant of
+	// a request, so the caller is not authorized.
+	ResolveSchool(ctx context.Context, ref string) (School, error)
+}
+
+// School denotes a tenant of the service.
+type School struct {
+	ID string
+
+	// Code identifies the school in requests, e.g. as the
+	// subdomain. It consists of lowercase letters, digits
+	// and hyphens.
+	Code string
+	Name string
+
+	// Tenant is the name of the PostgreSQL client holding the
+	// data of the school. A tenant belongs to one school only.
+	Tenant string
+
+	Status     Status
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+// Status denotes status of a school.
+type Status int
+
+// Following constans are the known school status.
+const (
+	StatusUnknown Status = 0
+	StatusActive  Status = 1
+
+	// StatusInactive is a school whose requests are refused,
+	// its data is kept.
+	StatusInactive Status = 2
+)
+
+var (
+	// StatusList is a list of valid school status.
+	StatusList = map[Status]struct{}{
+		StatusActive:   {},
+		StatusInactive: {},
+	}
+
+	// StatusName maps school status to it's string
+	// representation.
+	StatusName = map[Status]string{
+		StatusActive:   "active",
+		StatusInactive: "inactive",
+	}
+)
+
+// String implements the Stringer interface.
+func (s Status) String() string {
+	return StatusName[s]
+}
+
+// Value implements the Valuer interface.
+func (s Status) Value() int {
+	return int(s)
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"hbdtoyou/internal/school"
+	"regexp"
+	"strings"
+
+	contextlib "hbdtoyou/pkg/context"
+
+	"github.com/google/uuid"
+)
+
+// codePattern is the valid school code pattern, it has to be a
+// valid subdomain label.
+var codePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// CreateSchool creates a new school and returns the created
+// school ID.
+func (s *service) CreateSchool(ctx context.Context, reqSchool school.School) (string, error) {
+	err := s.authorize(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	reqSchool.Code = strings.ToLower(strings.TrimSpace(reqSchool.Code))
+	reqSchool.Name = strings.TrimSpace(reqSchool.Name)
+	if reqSchool.Status == school.StatusUnknown {
+		reqSchool.Status = school.StatusActive
+	}
+
+	// validate fields
+	err = s.validateSchool(reqSchool)
+	if err != nil {
+		return "", err
+	}
+
+	// update fields
+	reqSchool.CreateTime = s.timeNow()
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return "", err
+	}
+
+	return pgStoreClient.CreateSchool(ctx, reqSchool)
+}
+
+// GetSchools returns all schools.
+func (s *service) GetSchools(ctx context.Context) ([]school.School, error) {
+	err := s.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgStoreClient.GetSchools(ctx)
+}
+
+// GetSchoolByID returns a school with the given school ID.
+func (s *service) GetSchoolByID(ctx context.Context, schoolID string) (school.School, error) {
+	// validate id
+	if schoolID == "" {
+		return school.School{}, school.ErrInvalidSchoolID
+	}
+
+	err := s.authorize(ctx)
+	if err != nil {
+		return school.School{}, err
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return school.School{}, err
+	}
+
+	return pgStoreClient.GetSchoolByID(ctx, schoolID)
+}
+
+// UpdateSchool updates existing school with the given school
+// data.
+func (s *service) UpdateSchool(ctx context.Context, reqSchool school.School) error {
+	// validate id
+	if reqSchool.ID == "" {
+		return school.ErrInvalidSchoolID
+	}
+
+	err := s.authorize(ctx)
+	if err != nil {
+		return err
+	}
+
+	reqSchool.Code = strings.ToLower(strings.TrimSpace(reqSchool.Code))
+	reqSchool.Name = strings.TrimSpace(reqSchool.Name)
+
+	// validate fields
+	err = s.validateSchool(reqSchool)
+	if err != nil {
+		return err
+	}
+
+	// update fields
+	reqSchool.UpdateTime = s.timeNow()
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	return pgStoreClient.UpdateSchool(ctx, reqSchool)
+}
+
+// ResolveSchool returns an active school with the given school
+// ID or code.
+func (s *service) ResolveSchool(ctx context.Context, ref string) (school.School, error) {
+	ref = strings.ToLower(strings.TrimSpace(ref))
+	if ref == "" {
+		return school.School{}, school.ErrInvalidSchoolID
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return school.School{}, err
+	}
+
+	// codes can not be mistaken for IDs, as codes looking
+	// like an ID are refused
+	var result school.School
+	if _, err := uuid.Parse(ref); err == nil {
+		result, err = pgStoreClient.GetSchoolByID(ctx, ref)
+		if err != nil {
+			return school.School{}, err
+		}
+	} else {
+		result, err = pgStoreClient.GetSchoolByCode(ctx, ref)
+		if err != nil {
+			return school.School{}, err
+		}
+	}
+
+	if result.Status != school.StatusActive {
+		return school.School{}, school.ErrSchoolInactive
+	}
+
+	return result, nil
+}
+
+// authorize returns nil if the caller is an administrator of
+// the default database, i.e. the request is made without a
+// school.
+func (s *service) authorize(ctx context.Context) error {
+	// administrators of a school only manage their school
+	if _, ok := contextlib.GetSchoolID(ctx); ok {
+		return school.ErrForbidden
+	}
+
+	userID, ok := contextlib.GetUserID(ctx)
+	if !ok {
+		return school.ErrInvalidUserID
+	}
+
+	caller, err := s.user.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !caller.IsAdmin() {
+		return school.ErrForbidden
+	}
+
+	return nil
+}
+
+// validateSchool validates fields of the given school.
+func (s *service) validateSchool(reqSchool school.School) error {
+	if !codePattern.MatchString(reqSchool.Code) {
+		return school.ErrInvalidSchoolCode
+	}
+
+	// codes are resolved as IDs if they look like one
+	if _, err := uuid.Parse(reqSchool.Code); err == nil {
+		return school.ErrInvalidSchoolCode
+	}
+
+	if reqSchool.Name == "" {
+		return school.ErrInvalidSchoolName
+	}
+
+	// the default database is shared by requests without a
+	// school, a school would see their data
+	if reqSchool.Tenant == "" || reqSchool.Tenant == s.config.DefaultTenant {
+		return school.ErrInvalidTenant
+	}
+
+	var configured bool
+	for _, tenant := range s.config.Tenants {
+		if tenant == reqSchool.Tenant {
+			configured = true
+			break
+		}
+	}
+	if !configured {
+		return school.ErrInvalidTenant
+	}
+
+	if _, valid := school.StatusList[reqSchool.Status]; !valid {
+		return school.ErrInvalidSchoolStatus
+	}
+
+	return nil
+}
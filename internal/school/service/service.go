@@ -0,0 +1,64 @@
+package service
+
+import (
+	"hbdtoyou/internal/auth"
+	"time"
+)
+
+// service implements school.Service.
+type service struct {
+	pgStore PGStore
+	user    auth.Service
+	config  Config
+	timeNow func() time.Time
+}
+
+// Config denotes service configuration
+//
+// Adding a new field should also add the corresponding default
+// value in getDefaultConfig().
+type Config struct {
+	// DefaultTenant is the database serving requests made
+	// without a school, it can not be used by a school.
+	DefaultTenant string
+
+	// Tenants are the configured databases schools can use.
+	Tenants []string
+}
+
+// getDefaultConfig returns service configuration with the
+// predefined default values.
+func getDefaultConfig() Config {
+	return Config{}
+}
+
+// New creates a new service.
+func New(pgStore PGStore, user auth.Service, options ...Option) (*service, error) {
+	s := &service{
+		pgStore: pgStore,
+		user:    user,
+		config:  getDefaultConfig(),
+		timeNow: time.Now,
+	}
+
+	// apply options
+	for _, opt := range options {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Option controls the behavior of service.
+type Option func(*service) error
+
+// WithConfig returns Option to set service configuration.
+func WithConfig(config Config) Option {
+	return func(s *service) error {
+		s.config.DefaultTenant = config.DefaultTenant
+		s.config.Tenants = config.Tenants
+		return nil
+	}
+}
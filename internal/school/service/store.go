@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"hbdtoyou/internal/school"
+)
+
+type PGStore interface {
+	NewClient(ctx context.Context, useTx bool) (PGStoreClient, error)
+}
+
+type PGStoreClient interface {
+	// Commit commits the transaction.
+	Commit() error
+	// Rollback aborts the transaction.
+	Rollback() error
+
+	// CreateSchool creates a new school and returns the
+	// created school ID.
+	CreateSchool(ctx context.Context, reqSchool school.School) (string, error)
+
+	// GetSchools returns all schools, ordered by code.
+	GetSchools(ctx context.Context) ([]school.School, error)
+
+	// GetSchoolByID returns a school with the given school ID.
+	GetSchoolByID(ctx context.Context, schoolID string) (school.School, error)
+
+	// GetSchoolByCode returns a school with the given code.
+	GetSchoolByCode(ctx context.Context, code string) (school.School, error)
+
+	// UpdateSchool updates existing school with the given
+	// school data.
+	UpdateSchool(ctx context.Context, reqSchool school.School) error
+}
@@ -0,0 +1,170 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"hbdtoyou/internal/school"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+func (sc *storeClient) CreateSchool(ctx context.Context, reqSchool school.School) (string, error) {
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"code":        reqSchool.Code,
+		"name":        reqSchool.Name,
+		"tenant":      reqSchool.Tenant,
+		"status":      reqSchool.Status,
+		"create_time": reqSchool.CreateTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryCreateSchool, argKV)
+	if err != nil {
+		return "", err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return "", err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	var id string
+	err = sc.q.QueryRowx(query, args...).Scan(&id)
+	if err != nil {
+		return "", parseUniqueViolation(err)
+	}
+
+	return id, nil
+}
+
+func (sc *storeClient) GetSchools(ctx context.Context) ([]school.School, error) {
+	query := fmt.Sprintf(queryGetSchool, "ORDER BY code")
+
+	return sc.querySchools(query)
+}
+
+func (sc *storeClient) GetSchoolByID(ctx context.Context, schoolID string) (school.School, error) {
+	// invalid IDs cannot match any school
+	if _, err := uuid.Parse(schoolID); err != nil {
+		return school.School{}, school.ErrDataNotFound
+	}
+
+	query := fmt.Sprintf(queryGetSchool, "WHERE id = $1")
+
+	return sc.getSchool(query, schoolID)
+}
+
+func (sc *storeClient) GetSchoolByCode(ctx context.Context, code string) (school.School, error) {
+	query := fmt.Sprintf(queryGetSchool, "WHERE code = $1")
+
+	return sc.getSchool(query, code)
+}
+
+func (sc *storeClient) UpdateSchool(ctx context.Context, reqSchool school.School) error {
+	// invalid IDs cannot match any school
+	if _, err := uuid.Parse(reqSchool.ID); err != nil {
+		return school.ErrDataNotFound
+	}
+
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"id":          reqSchool.ID,
+		"code":        reqSchool.Code,
+		"name":        reqSchool.Name,
+		"tenant":      reqSchool.Tenant,
+		"status":      reqSchool.Status,
+		"update_time": reqSchool.UpdateTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryUpdateSchool, argKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return parseUniqueViolation(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return school.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// getSchool runs the given school query and returns the only
+// read row.
+func (sc *storeClient) getSchool(query string, args ...interface{}) (school.School, error) {
+	result, err := sc.querySchools(query, args...)
+	if err != nil {
+		return school.School{}, err
+	}
+
+	if len(result) == 0 {
+		return school.School{}, school.ErrDataNotFound
+	}
+
+	return result[0], nil
+}
+
+// querySchools runs the given school query and returns the read
+// rows.
+func (sc *storeClient) querySchools(query string, args ...interface{}) ([]school.School, error) {
+	// query to database
+	rows, err := sc.q.Queryx(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// read rows
+	result := make([]school.School, 0)
+	for rows.Next() {
+		var row schoolModel
+		err = rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, row.format())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// parseUniqueViolation returns the school error of the given
+// error if it violates a unique constraint of school.
+func parseUniqueViolation(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr != nil {
+		if pqErr.Code.Name() == "unique_violation" {
+			switch pqErr.Constraint {
+			case constraintSchoolCode:
+				return school.ErrSchoolAlreadyExist
+			case constraintSchoolTenant:
+				return school.ErrTenantAlreadyUsed
+			}
+		}
+	}
+
+	return err
+}
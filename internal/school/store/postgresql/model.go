@@ -0,0 +1,36 @@
+package postgresql
+
+import (
+	"hbdtoyou/internal/school"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type schoolModel struct {
+	ID         uuid.UUID     `db:"id"`
+	Code       string        `db:"code"`
+	Name       string        `db:"name"`
+	Tenant     string        `db:"tenant"`
+	Status     school.Status `db:"status"`
+	CreateTime time.Time     `db:"create_time"`
+	UpdateTime *time.Time    `db:"update_time"`
+}
+
+// format formats database struct into domain struct.
+func (dbData *schoolModel) format() school.School {
+	s := school.School{
+		ID:         dbData.ID.String(),
+		Code:       dbData.Code,
+		Name:       dbData.Name,
+		Tenant:     dbData.Tenant,
+		Status:     dbData.Status,
+		CreateTime: dbData.CreateTime,
+	}
+
+	if dbData.UpdateTime != nil {
+		s.UpdateTime = *dbData.UpdateTime
+	}
+
+	return s
+}
@@ -0,0 +1,68 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"hbdtoyou/internal/school/service"
+	pglib "hbdtoyou/pkg/postgresql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	errInvalidCommit   = errors.New("cannot do commit on non-transactional querier")
+	errInvalidRollback = errors.New("cannot do rollback on non-transactional querier")
+)
+
+// store implements school/service.PGStore
+type store struct {
+	db *sqlx.DB
+}
+
+// storeClient implements school/service.PGStoreClient.
+type storeClient struct {
+	q pglib.Querier
+}
+
+// New creates a new store. Schools are kept in the given
+// database regardless of the tenant of a context, as the tenant
+// is resolved from them.
+func New(db *sqlx.DB) (*store, error) {
+	s := &store{
+		db: db,
+	}
+
+	return s, nil
+}
+
+func (s *store) NewClient(ctx context.Context, useTx bool) (service.PGStoreClient, error) {
+	var q pglib.Querier
+
+	// determine what object should be use as querier
+	q = s.db
+	if useTx {
+		var err error
+		q, err = s.db.Beginx()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &storeClient{
+		q: q,
+	}, nil
+}
+
+func (sc *storeClient) Commit() error {
+	if tx, ok := sc.q.(*sqlx.Tx); ok {
+		return tx.Commit()
+	}
+	return errInvalidCommit
+}
+
+func (sc *storeClient) Rollback() error {
+	if tx, ok := sc.q.(*sqlx.Tx); ok {
+		return tx.Rollback()
+	}
+	return errInvalidRollback
+}
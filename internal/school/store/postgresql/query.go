@@ -0,0 +1,58 @@
+package postgresql
+
+const (
+	queryCreateSchool = `
+		INSERT INTO
+			school
+			(
+				code,
+				name,
+				tenant,
+				status,
+				create_time
+			)
+		VALUES
+			(
+				:code,
+				:name,
+				:tenant,
+				:status,
+				:create_time
+			)
+		RETURNING
+			id
+	`
+
+	queryGetSchool = `
+		SELECT
+			id,
+			code,
+			name,
+			tenant,
+			status,
+			create_time,
+			update_time
+		FROM
+			school
+		%s
+	`
+
+	queryUpdateSchool = `
+		UPDATE
+			school
+		SET
+			code = :code,
+			name = :name,
+			tenant = :tenant,
+			status = :status,
+			update_time = :update_time
+		WHERE
+			id = :id
+	`
+)
+
+// Followings are the unique constraints of school.
+const (
+	constraintSchoolCode   = "school_code_key"
+	constraintSchoolTenant = "school_tenant_key"
+)
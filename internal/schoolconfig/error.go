@@ -0,0 +1,29 @@
+package schoolconfig
+
+import "errors"
+
+var (
+	// ErrDataNotFound is returned when the desired data is
+	// not found.
+	ErrDataNotFound = errors.New("data not found")
+
+	// ErrForbidden is returned when the user is not allowed
+	// to access the requested data.
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrInvalidUserID is returned when the given user ID is
+	// invalid.
+	ErrInvalidUserID = errors.New("invalid user id")
+
+	// ErrInvalidSchoolID is returned when the given school ID
+	// is invalid or the school does not exist.
+	ErrInvalidSchoolID = errors.New("invalid school id")
+
+	// ErrInvalidConfigKey is returned when the given config
+	// key is invalid.
+	ErrInvalidConfigKey = errors.New("invalid config key")
+
+	// ErrInvalidConfigValue is returned when the given config
+	// value does not match the schema of its key.
+	ErrInvalidConfigValue = errors.New("invalid config value")
+)
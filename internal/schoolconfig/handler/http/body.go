@@ -0,0 +1,61 @@
+package http
+
+import (
+	"encoding/json"
+	"hbdtoyou/internal/schoolconfig"
+	"time"
+)
+
+// timeFormat denotes the standard time format used in school
+// config HTTP handlers.
+var timeFormat = "02/01/2006 3:04 PM -07:00"
+
+type configHTTP struct {
+	SchoolID   *string         `json:"school_id"`
+	Key        *string         `json:"key"`
+	Value      json.RawMessage `json:"value"`
+	CreateTime *string         `json:"create_time"`
+	UpdateTime *string         `json:"update_time"`
+}
+
+func formatConfig(c schoolconfig.Config) configHTTP {
+	key := c.Key.String()
+
+	return configHTTP{
+		SchoolID:   &c.SchoolID,
+		Key:        &key,
+		Value:      json.RawMessage(c.ValueJSONText),
+		CreateTime: formatTime(c.CreateTime),
+		UpdateTime: formatTime(c.UpdateTime),
+	}
+}
+
+func (c configHTTP) parseConfig(out *schoolconfig.Config) error {
+	if len(c.Value) == 0 {
+		return errInvalidConfigValue
+	}
+	out.ValueJSONText = string(c.Value)
+
+	return nil
+}
+
+func parseConfigKey(req string) (schoolconfig.Key, error) {
+	for key, name := range schoolconfig.KeyName {
+		if name == req {
+			return key, nil
+		}
+	}
+
+	return schoolconfig.KeyUnknown, errInvalidConfigKey
+}
+
+// formatTime returns the given time formatted in timeFormat,
+// or nil for a zero time.
+func formatTime(t time.Time) *string {
+	if t.IsZero() {
+		return nil
+	}
+
+	formatted := t.Format(timeFormat)
+	return &formatted
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"errors"
+	"hbdtoyou/internal/schoolconfig"
+)
+
+// Followings are the known errors from School Config HTTP
+// handlers.
+var (
+	// errBadRequest is returned when the given request is
+	// bad/invalid.
+	errBadRequest = errors.New("BAD_REQUEST")
+
+	// errInternalServer is returned when there is an
+	// unexpected error encountered when processing a request.
+	errInternalServer = errors.New("INTERNAL_SERVER_ERROR")
+
+	// errDataNotFound is returned when the desired data is
+	// not found.
+	errDataNotFound = errors.New("DATA_NOT_FOUND")
+
+	// errForbidden is returned when the user is not allowed
+	// to access the requested data.
+	errForbidden = errors.New("FORBIDDEN")
+
+	// errInvalidToken is returned when the given token is
+	// invalid.
+	errInvalidToken = errors.New("INVALID_TOKEN")
+
+	// errInvalidUserID is returned when the given user ID is
+	// invalid.
+	errInvalidUserID = errors.New("INVALID_USER_ID")
+
+	// errInvalidSchoolID is returned when the given school ID
+	// is invalid.
+	errInvalidSchoolID = errors.New("INVALID_SCHOOL_ID")
+
+	// errInvalidConfigKey is returned when the given config
+	// key is invalid.
+	errInvalidConfigKey = errors.New("INVALID_CONFIG_KEY")
+
+	// errInvalidConfigValue is returned when the given config
+	// value does not match the schema of its key.
+	errInvalidConfigValue = errors.New("INVALID_CONFIG_VALUE")
+
+	// errMethodNotAllowed is returned when accessing not
+	// allowed HTTP method.
+	errMethodNotAllowed = errors.New("METHOD_NOT_ALLOWED")
+
+	// errRequestTimeout is returned when processing time has
+	// reached the timeout limit.
+	errRequestTimeout = errors.New("REQUEST_TIMEOUT")
+
+	// errSourceNotProvided is returned when there is no
+	// source provided in the request.
+	errSourceNotProvided = errors.New("SOURCE_NOT_PROVIDED")
+
+	// errUnauthorizedAccess is returned when the request
+	// is unaothorized.
+	errUnauthorizedAccess = errors.New("UNAUTHORIZED_ACCESS")
+)
+
+var (
+	// mapHTTPError maps service error into HTTP error that
+	// categorize as bad request error.
+	//
+	// Internal server error-related should not be mapped here,
+	// and the handler should just return `errInternal` as the
+	// error instead
+	mapHTTPError = map[error]error{
+		schoolconfig.ErrDataNotFound:       errDataNotFound,
+		schoolconfig.ErrForbidden:          errForbidden,
+		schoolconfig.ErrInvalidUserID:      errInvalidUserID,
+		schoolconfig.ErrInvalidSchoolID:    errInvalidSchoolID,
+		schoolconfig.ErrInvalidConfigKey:   errInvalidConfigKey,
+		schoolconfig.ErrInvalidConfigValue: errInvalidConfigValue,
+	}
+)
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"hbdtoyou/internal/auth"
+	"hbdtoyou/internal/schoolconfig"
+	"net/http"
+
+	httplib "hbdtoyou/pkg/http"
+
+	"github.com/gorilla/mux"
+)
+
+type configsHandler struct {
+	schoolConfig  schoolconfig.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *configsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	schoolID := vars["id"]
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetSchoolConfigs(w, r, schoolID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type configHandler struct {
+	schoolConfig  schoolconfig.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *configHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	schoolID := vars["id"]
+	key := vars["key"]
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetSchoolConfig(w, r, schoolID, key)
+	case http.MethodPut:
+		h.handleSetSchoolConfig(w, r, schoolID, key)
+	case http.MethodDelete:
+		h.handleDeleteSchoolConfig(w, r, schoolID, key)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
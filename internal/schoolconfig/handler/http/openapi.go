@@ -0,0 +1,33 @@
+package http
+
+import (
+	"hbdtoyou/pkg/openapi"
+	"net/http"
+)
+
+// routes defines the OpenAPI routes of each HTTP handler, it
+// must be updated along with the methods served by handler.go.
+var routes = map[string][]openapi.Route{
+	HandlerConfigs.Name: {
+		{Method: http.MethodGet, Summary: "Get school configs", Response: []configHTTP{}},
+	},
+	HandlerConfig.Name: {
+		{Method: http.MethodGet, Summary: "Get school config", Response: configHTTP{}},
+		{Method: http.MethodPut, Summary: "Set school config", Request: configHTTP{}, Response: ""},
+		{Method: http.MethodDelete, Summary: "Delete school config", Response: ""},
+	},
+}
+
+// Routes returns the OpenAPI routes of the registered HTTP
+// handlers.
+func (h *Handler) Routes() []openapi.Route {
+	var res []openapi.Route
+	for _, handler := range h.handlers {
+		for _, route := range routes[handler.identity.Name] {
+			route.Path = handler.identity.URL
+			route.Tag = "schoolconfig"
+			res = append(res, route)
+		}
+	}
+	return res
+}
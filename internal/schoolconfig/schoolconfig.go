@@ -0,0 +1,153 @@
+package schoolconfig
+
+import (
+	"context"
+	"time"
+)
+
+// Service is the interface for school config service.
+//
+// A school config is a setting of a school, stored as a JSON
+// value under a known key. The value of each key is validated
+// against the JSON Schema of the key.
+//
+// Only administrators of the default database manage school
+// configs, other services read them as Settings.
+type Service interface {
+	// SetConfig creates or replaces the config of a school
+	// with the given key.
+	SetConfig(ctx context.Context, reqConfig Config) error
+
+	// GetConfigs returns all configs of a school with the
+	// given school ID.
+	GetConfigs(ctx context.Context, schoolID string) ([]Config, error)
+
+	// GetConfig returns the config of a school with the given
+	// school ID and key.
+	GetConfig(ctx context.Context, schoolID string, key Key) (Config, error)
+
+	// DeleteConfig deletes the config of a school with the
+	// given school ID and key, the setting is unset afterward.
+	DeleteConfig(ctx context.Context, schoolID string, key Key) error
+
+	// GetSettings returns the settings of a school with the
+	// given school ID. Settings without config are left zero,
+	// an empty school ID returns zero settings.
+	//
+	// It is used by other services, so the caller is not
+	// authorized. The settings are cached, a changed config
+	// might be seen late by other instances.
+	GetSettings(ctx context.Context, schoolID string) (Settings, error)
+}
+
+// Config denotes a setting of a school.
+type Config struct {
+	SchoolID string
+	Key      Key
+
+	// ValueJSONText is the value of the setting in JSON, its
+	// format depends on the key.
+	ValueJSONText string
+
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+// Settings denotes all the settings of a school.
+type Settings struct {
+	Branding Branding
+
+	// AllowedTemplateIDs are the templates the users of the
+	// school can use, all templates are allowed if it is
+	// empty.
+	AllowedTemplateIDs []string
+
+	// DefaultQuota is the number of contents a user of the
+	// school can have, it is unlimited if it is zero.
+	DefaultQuota int
+
+	PaymentBankAccount BankAccount
+}
+
+// IsTemplateAllowed returns true if the template with the
+// given template ID can be used by the users of the school.
+func (s Settings) IsTemplateAllowed(templateID string) bool {
+	if len(s.AllowedTemplateIDs) == 0 {
+		return true
+	}
+
+	for _, id := range s.AllowedTemplateIDs {
+		if id == templateID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Branding denotes the look of a school, the value of
+// KeyBranding.
+type Branding struct {
+	// PrimaryColor and SecondaryColor are hex colors, e.g.
+	// "#1a2b3c".
+	PrimaryColor   string `json:"primary_color"`
+	SecondaryColor string `json:"secondary_color"`
+	LogoURL        string `json:"logo_url"`
+}
+
+// BankAccount denotes the bank account the payments of a school
+// are made to, the value of KeyPaymentBankAccount.
+type BankAccount struct {
+	BankName      string `json:"bank_name"`
+	AccountNumber string `json:"account_number"`
+	AccountName   string `json:"account_name"`
+}
+
+// Key denotes key of a school config.
+type Key int
+
+// Following constans are the known school config keys.
+const (
+	KeyUnknown Key = 0
+
+	// KeyBranding holds a Branding object.
+	KeyBranding Key = 1
+
+	// KeyAllowedTemplates holds an array of template IDs.
+	KeyAllowedTemplates Key = 2
+
+	// KeyDefaultQuota holds a non-negative integer.
+	KeyDefaultQuota Key = 3
+
+	// KeyPaymentBankAccount holds a BankAccount object.
+	KeyPaymentBankAccount Key = 4
+)
+
+var (
+	// KeyList is a list of valid school config keys.
+	KeyList = map[Key]struct{}{
+		KeyBranding:           {},
+		KeyAllowedTemplates:   {},
+		KeyDefaultQuota:       {},
+		KeyPaymentBankAccount: {},
+	}
+
+	// KeyName maps school config key to it's string
+	// representation.
+	KeyName = map[Key]string{
+		KeyBranding:           "branding",
+		KeyAllowedTemplates:   "allowed_templates",
+		KeyDefaultQuota:       "default_quota",
+		KeyPaymentBankAccount: "payment_bank_account",
+	}
+)
+
+// String implements the Stringer interface.
+func (k Key) String() string {
+	return KeyName[k]
+}
+
+// Value implements the Valuer interface.
+func (k Key) Value() int {
+	return int(k)
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"hbdtoyou/internal/school"
+	"hbdtoyou/internal/schoolconfig"
+
+	contextlib "hbdtoyou/pkg/context"
+)
+
+// SetConfig creates or replaces the config of a school with the
+// given key.
+func (s *service) SetConfig(ctx context.Context, reqConfig schoolconfig.Config) error {
+	// validate id
+	if reqConfig.SchoolID == "" {
+		return schoolconfig.ErrInvalidSchoolID
+	}
+
+	err := s.authorize(ctx)
+	if err != nil {
+		return err
+	}
+
+	// validate fields
+	value, err := validateConfig(reqConfig)
+	if err != nil {
+		return err
+	}
+	reqConfig.ValueJSONText = value
+
+	// configs of unknown schools would never be read
+	err = s.validateSchool(ctx, reqConfig.SchoolID)
+	if err != nil {
+		return err
+	}
+
+	// update fields
+	reqConfig.CreateTime = s.timeNow()
+	reqConfig.UpdateTime = reqConfig.CreateTime
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	err = pgStoreClient.SetConfig(ctx, reqConfig)
+	if err != nil {
+		return err
+	}
+
+	s.settings.Invalidate(reqConfig.SchoolID)
+
+	return nil
+}
+
+// GetConfigs returns all configs of a school with the given
+// school ID.
+func (s *service) GetConfigs(ctx context.Context, schoolID string) ([]schoolconfig.Config, error) {
+	// validate id
+	if schoolID == "" {
+		return nil, schoolconfig.ErrInvalidSchoolID
+	}
+
+	err := s.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgStoreClient.GetConfigs(ctx, schoolID)
+}
+
+// GetConfig returns the config of a school with the given
+// school ID and key.
+func (s *service) GetConfig(ctx context.Context, schoolID string, key schoolconfig.Key) (schoolconfig.Config, error) {
+	// validate id
+	if schoolID == "" {
+		return schoolconfig.Config{}, schoolconfig.ErrInvalidSchoolID
+	}
+
+	if _, valid := schoolconfig.KeyList[key]; !valid {
+		return schoolconfig.Config{}, schoolconfig.ErrInvalidConfigKey
+	}
+
+	err := s.authorize(ctx)
+	if err != nil {
+		return schoolconfig.Config{}, err
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return schoolconfig.Config{}, err
+	}
+
+	return pgStoreClient.GetConfig(ctx, schoolID, key)
+}
+
+// DeleteConfig deletes the config of a school with the given
+// school ID and key.
+func (s *service) DeleteConfig(ctx context.Context, schoolID string, key schoolconfig.Key) error {
+	// validate id
+	if schoolID == "" {
+		return schoolconfig.ErrInvalidSchoolID
+	}
+
+	if _, valid := schoolconfig.KeyList[key]; !valid {
+		return schoolconfig.ErrInvalidConfigKey
+	}
+
+	err := s.authorize(ctx)
+	if err != nil {
+		return err
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	err = pgStoreClient.DeleteConfig(ctx, schoolID, key)
+	if err != nil {
+		return err
+	}
+
+	s.settings.Invalidate(schoolID)
+
+	return nil
+}
+
+// GetSettings returns the settings of a school with the given
+// school ID.
+func (s *service) GetSettings(ctx context.Context, schoolID string) (schoolconfig.Settings, error) {
+	// requests without a school have no settings
+	if schoolID == "" {
+		return schoolconfig.Settings{}, nil
+	}
+
+	return s.settings.Get(ctx, schoolID)
+}
+
+// loadSettings reads the settings of a school with the given
+// school ID from its configs.
+func (s *service) loadSettings(ctx context.Context, schoolID string) (schoolconfig.Settings, error) {
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return schoolconfig.Settings{}, err
+	}
+
+	configs, err := pgStoreClient.GetConfigs(ctx, schoolID)
+	if err != nil {
+		return schoolconfig.Settings{}, err
+	}
+
+	result := schoolconfig.Settings{}
+	for _, c := range configs {
+		err = decodeConfig(c, &result)
+		if err != nil {
+			return schoolconfig.Settings{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// authorize returns nil if the caller is an administrator of
+// the default database, i.e. the request is made without a
+// school.
+func (s *service) authorize(ctx context.Context) error {
+	// administrators of a school do not manage their settings
+	if _, ok := contextlib.GetSchoolID(ctx); ok {
+		return schoolconfig.ErrForbidden
+	}
+
+	userID, ok := contextlib.GetUserID(ctx)
+	if !ok {
+		return schoolconfig.ErrInvalidUserID
+	}
+
+	caller, err := s.user.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !caller.IsAdmin() {
+		return schoolconfig.ErrForbidden
+	}
+
+	return nil
+}
+
+// validateSchool returns nil if the school with the given
+// school ID exists.
+func (s *service) validateSchool(ctx context.Context, schoolID string) error {
+	_, err := s.school.GetSchoolByID(ctx, schoolID)
+	switch err {
+	case nil:
+		return nil
+	case school.ErrDataNotFound, school.ErrInvalidSchoolID:
+		return schoolconfig.ErrInvalidSchoolID
+	case school.ErrForbidden:
+		return schoolconfig.ErrForbidden
+	}
+
+	return err
+}
+
+// validateConfig validates fields of the given config and
+// returns its compacted value.
+func validateConfig(reqConfig schoolconfig.Config) (string, error) {
+	schema, ok := schemas[reqConfig.Key]
+	if !ok {
+		return "", schoolconfig.ErrInvalidConfigKey
+	}
+
+	err := schema.Validate([]byte(reqConfig.ValueJSONText))
+	if err != nil {
+		return "", schoolconfig.ErrInvalidConfigValue
+	}
+
+	var value bytes.Buffer
+	err = json.Compact(&value, []byte(reqConfig.ValueJSONText))
+	if err != nil {
+		return "", schoolconfig.ErrInvalidConfigValue
+	}
+
+	// a value matching the schema might still not fit its
+	// setting, e.g. 1.0 is an integer but not an int
+	err = decodeConfig(reqConfig, &schoolconfig.Settings{})
+	if err != nil {
+		return "", schoolconfig.ErrInvalidConfigValue
+	}
+
+	return value.String(), nil
+}
+
+// decodeConfig decodes the value of the given config into its
+// setting in out. Configs of unknown keys are ignored.
+func decodeConfig(c schoolconfig.Config, out *schoolconfig.Settings) error {
+	var target interface{}
+	switch c.Key {
+	case schoolconfig.KeyBranding:
+		target = &out.Branding
+	case schoolconfig.KeyAllowedTemplates:
+		target = &out.AllowedTemplateIDs
+	case schoolconfig.KeyDefaultQuota:
+		target = &out.DefaultQuota
+	case schoolconfig.KeyPaymentBankAccount:
+		target = &out.PaymentBankAccount
+	default:
+		// configs of removed keys are left unread
+		return nil
+	}
+
+	return json.Unmarshal([]byte(c.ValueJSONText), target)
+}
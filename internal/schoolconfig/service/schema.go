@@ -0,0 +1,40 @@
+package service
+
+import (
+	"hbdtoyou/internal/schoolconfig"
+	"hbdtoyou/pkg/jsonschema"
+)
+
+// schemas are the JSON Schemas of the known school config
+// keys. The values are decoded into schoolconfig.Settings, so
+// a changed schema should still match its field.
+var schemas = map[schoolconfig.Key]*jsonschema.Schema{
+	schoolconfig.KeyBranding: jsonschema.MustParse(`{
+		"type": "object",
+		"properties": {
+			"primary_color": {"type": "string", "pattern": "^#[0-9a-fA-F]{6}$"},
+			"secondary_color": {"type": "string", "pattern": "^#[0-9a-fA-F]{6}$"},
+			"logo_url": {"type": "string", "pattern": "^https://", "maxLength": 2048}
+		},
+		"additionalProperties": false
+	}`),
+	schoolconfig.KeyAllowedTemplates: jsonschema.MustParse(`{
+		"type": "array",
+		"items": {"type": "string", "minLength": 1},
+		"uniqueItems": true
+	}`),
+	schoolconfig.KeyDefaultQuota: jsonschema.MustParse(`{
+		"type": "integer",
+		"minimum": 0
+	}`),
+	schoolconfig.KeyPaymentBankAccount: jsonschema.MustParse(`{
+		"type": "object",
+		"properties": {
+			"bank_name": {"type": "string", "minLength": 1, "maxLength": 100},
+			"account_number": {"type": "string", "pattern": "^[0-9]{5,34}$"},
+			"account_name": {"type": "string", "minLength": 1, "maxLength": 100}
+		},
+		"required": ["bank_name", "account_number", "account_name"],
+		"additionalProperties": false
+	}`),
+}
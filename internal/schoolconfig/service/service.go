@@ -0,0 +1,80 @@
+package service
+
+import (
+	"hbdtoyou/internal/auth"
+	"hbdtoyou/internal/school"
+	"hbdtoyou/internal/schoolconfig"
+	cachelib "hbdtoyou/pkg/cache"
+	"time"
+)
+
+// Following constans are config default values.
+const (
+	defaultSettingsCacheTTL = 1 * time.Minute
+)
+
+// service implements schoolconfig.Service.
+type service struct {
+	pgStore PGStore
+	user    auth.Service
+	school  school.Service
+	config  Config
+	timeNow func() time.Time
+
+	// settings caches settings by school ID.
+	settings *cachelib.Cache[string, schoolconfig.Settings]
+}
+
+// Config denotes service configuration
+//
+// Adding a new field should also add the corresponding default
+// value in getDefaultConfig().
+type Config struct {
+	// SettingsCacheTTL is how long the settings of a school
+	// are cached. A changed config is seen at once by this
+	// instance, but only after the TTL by the others.
+	SettingsCacheTTL time.Duration
+}
+
+// getDefaultConfig returns service configuration with the
+// predefined default values.
+func getDefaultConfig() Config {
+	return Config{
+		SettingsCacheTTL: defaultSettingsCacheTTL,
+	}
+}
+
+// New creates a new service.
+func New(pgStore PGStore, user auth.Service, school school.Service, options ...Option) (*service, error) {
+	s := &service{
+		pgStore: pgStore,
+		user:    user,
+		school:  school,
+		config:  getDefaultConfig(),
+		timeNow: time.Now,
+	}
+
+	// apply options
+	for _, opt := range options {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
+
+	s.settings = cachelib.New(s.config.SettingsCacheTTL, s.loadSettings)
+
+	return s, nil
+}
+
+// Option controls the behavior of service.
+type Option func(*service) error
+
+// WithConfig returns Option to set service configuration.
+func WithConfig(config Config) Option {
+	return func(s *service) error {
+		if config.SettingsCacheTTL > 0 {
+			s.config.SettingsCacheTTL = config.SettingsCacheTTL
+		}
+		return nil
+	}
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"hbdtoyou/internal/schoolconfig"
+)
+
+type PGStore interface {
+	NewClient(ctx context.Context, useTx bool) (PGStoreClient, error)
+}
+
+type PGStoreClient interface {
+	// Commit commits the transaction.
+	Commit() error
+	// Rollback aborts the transaction.
+	Rollback() error
+
+	// SetConfig creates or replaces the config of a school
+	// with the given key.
+	SetConfig(ctx context.Context, reqConfig schoolconfig.Config) error
+
+	// GetConfigs returns all configs of a school with the
+	// given school ID, ordered by key.
+	GetConfigs(ctx context.Context, schoolID string) ([]schoolconfig.Config, error)
+
+	// GetConfig returns the config of a school with the given
+	// school ID and key.
+	GetConfig(ctx context.Context, schoolID string, key schoolconfig.Key) (schoolconfig.Config, error)
+
+	// DeleteConfig deletes the config of a school with the
+	// given school ID and key.
+	DeleteConfig(ctx context.Context, schoolID string, key schoolconfig.Key) error
+}
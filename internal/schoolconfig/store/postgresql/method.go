@@ -0,0 +1,150 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"hbdtoyou/internal/schoolconfig"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+func (sc *storeClient) SetConfig(ctx context.Context, reqConfig schoolconfig.Config) error {
+	// invalid IDs cannot match any school
+	if _, err := uuid.Parse(reqConfig.SchoolID); err != nil {
+		return schoolconfig.ErrInvalidSchoolID
+	}
+
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"school_id":   reqConfig.SchoolID,
+		"key":         reqConfig.Key,
+		"value":       reqConfig.ValueJSONText,
+		"create_time": reqConfig.CreateTime,
+		"update_time": reqConfig.UpdateTime,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(querySetConfig, argKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	_, err = sc.q.Exec(query, args...)
+	if err != nil {
+		// the school is deleted meanwhile
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			return schoolconfig.ErrInvalidSchoolID
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (sc *storeClient) GetConfigs(ctx context.Context, schoolID string) ([]schoolconfig.Config, error) {
+	// invalid IDs cannot match any school
+	if _, err := uuid.Parse(schoolID); err != nil {
+		return []schoolconfig.Config{}, nil
+	}
+
+	query := fmt.Sprintf(queryGetConfig, "WHERE school_id = $1 ORDER BY key")
+
+	return sc.queryConfigs(query, schoolID)
+}
+
+func (sc *storeClient) GetConfig(ctx context.Context, schoolID string, key schoolconfig.Key) (schoolconfig.Config, error) {
+	// invalid IDs cannot match any school
+	if _, err := uuid.Parse(schoolID); err != nil {
+		return schoolconfig.Config{}, schoolconfig.ErrDataNotFound
+	}
+
+	query := fmt.Sprintf(queryGetConfig, "WHERE school_id = $1 AND key = $2")
+
+	result, err := sc.queryConfigs(query, schoolID, key)
+	if err != nil {
+		return schoolconfig.Config{}, err
+	}
+
+	if len(result) == 0 {
+		return schoolconfig.Config{}, schoolconfig.ErrDataNotFound
+	}
+
+	return result[0], nil
+}
+
+func (sc *storeClient) DeleteConfig(ctx context.Context, schoolID string, key schoolconfig.Key) error {
+	// invalid IDs cannot match any school
+	if _, err := uuid.Parse(schoolID); err != nil {
+		return schoolconfig.ErrDataNotFound
+	}
+
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"school_id": schoolID,
+		"key":       key,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryDeleteConfig, argKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return schoolconfig.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// queryConfigs runs the given config query and returns the read
+// rows.
+func (sc *storeClient) queryConfigs(query string, args ...interface{}) ([]schoolconfig.Config, error) {
+	// query to database
+	rows, err := sc.q.Queryx(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// read rows
+	result := make([]schoolconfig.Config, 0)
+	for rows.Next() {
+		var row configModel
+		err = rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, row.format())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
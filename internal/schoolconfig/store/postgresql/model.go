@@ -0,0 +1,32 @@
+package postgresql
+
+import (
+	"hbdtoyou/internal/schoolconfig"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type configModel struct {
+	SchoolID   uuid.UUID        `db:"school_id"`
+	Key        schoolconfig.Key `db:"key"`
+	Value      string           `db:"value"`
+	CreateTime time.Time        `db:"create_time"`
+	UpdateTime *time.Time       `db:"update_time"`
+}
+
+// format formats database struct into domain struct.
+func (dbData *configModel) format() schoolconfig.Config {
+	c := schoolconfig.Config{
+		SchoolID:      dbData.SchoolID.String(),
+		Key:           dbData.Key,
+		ValueJSONText: dbData.Value,
+		CreateTime:    dbData.CreateTime,
+	}
+
+	if dbData.UpdateTime != nil {
+		c.UpdateTime = *dbData.UpdateTime
+	}
+
+	return c
+}
@@ -0,0 +1,47 @@
+package postgresql
+
+const (
+	querySetConfig = `
+		INSERT INTO
+			school_config
+			(
+				school_id,
+				key,
+				value,
+				create_time
+			)
+		VALUES
+			(
+				:school_id,
+				:key,
+				:value,
+				:create_time
+			)
+		ON CONFLICT
+			(school_id, key)
+		DO UPDATE SET
+			value = EXCLUDED.value,
+			update_time = :update_time
+	`
+
+	queryGetConfig = `
+		SELECT
+			school_id,
+			key,
+			value,
+			create_time,
+			update_time
+		FROM
+			school_config
+		%s
+	`
+
+	queryDeleteConfig = `
+		DELETE FROM
+			school_config
+		WHERE
+			school_id = :school_id
+		AND
+			key = :key
+	`
+)
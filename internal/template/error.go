@@ -19,4 +19,41 @@ var (
 
 	// ErrInvalidTemplateThumbnailURI is returned when template thumbnail uri is invalid.
 	ErrInvalidTemplateThumbnailURI = errors.New("invalid template thumbnail uri")
+
+	// ErrTemplateInUse is returned when deleting a template that is still used by a content.
+	ErrTemplateInUse = errors.New("template in use")
+
+	// ErrInvalidTemplateThumbnailMediaID is returned when template thumbnail media id is invalid.
+	ErrInvalidTemplateThumbnailMediaID = errors.New("invalid template thumbnail media id")
+
+	// ErrVersionConflict is returned when updating a template
+	// with an outdated version.
+	ErrVersionConflict = errors.New("version conflict")
+
+	// ErrInvalidTemplateCategory is returned when template category is invalid.
+	ErrInvalidTemplateCategory = errors.New("invalid template category")
+
+	// ErrInvalidTemplateTag is returned when a template tag is invalid.
+	ErrInvalidTemplateTag = errors.New("invalid template tag")
+
+	// ErrInvalidTemplateFeaturedOrder is returned when template featured order is invalid.
+	ErrInvalidTemplateFeaturedOrder = errors.New("invalid template featured order")
+
+	// ErrInvalidTemplatePrice is returned when template price is invalid.
+	ErrInvalidTemplatePrice = errors.New("invalid template price")
+
+	// ErrInvalidTemplateCurrency is returned when template currency is invalid.
+	ErrInvalidTemplateCurrency = errors.New("invalid template currency")
+
+	// ErrInvalidPagination is returned when the requested page or limit is invalid.
+	ErrInvalidPagination = errors.New("invalid pagination")
+
+	// ErrInvalidSort is returned when the requested sort order is not applicable.
+	ErrInvalidSort = errors.New("invalid sort")
+
+	// ErrInvalidUserID is returned when the user of the request is invalid.
+	ErrInvalidUserID = errors.New("invalid user id")
+
+	// ErrForbidden is returned when the user is not allowed to manage templates.
+	ErrForbidden = errors.New("forbidden")
 )
@@ -0,0 +1,124 @@
+package grpc
+
+import (
+	"hbdtoyou/internal/template"
+	templatev1 "hbdtoyou/pkg/pb/template/v1"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// templateUpdatePaths are the paths of a template allowed in
+// an update mask.
+var templateUpdatePaths = []string{
+	"name",
+	"description",
+	"label",
+	"category",
+	"tags",
+	"thumbnail_uri",
+	"thumbnail_media_id",
+	"featured_order",
+	"price",
+	"currency",
+}
+
+func formatTemplate(t template.Template) *templatev1.Template {
+	return &templatev1.Template{
+		Id:               t.ID,
+		Name:             t.Name,
+		Description:      t.Description,
+		Label:            templatev1.TemplateLabel(t.Label),
+		Category:         templatev1.TemplateCategory(t.Category),
+		Tags:             t.Tags,
+		ThumbnailUri:     t.GetThumbnailURI(),
+		ThumbnailMediaId: t.ThumbnailMediaID,
+		FeaturedOrder:    int32(t.FeaturedOrder),
+		Price:            t.Price,
+		Currency:         t.Currency,
+		Version:          t.Version,
+		CreateTime:       formatTime(t.CreateTime),
+		UpdateTime:       formatTime(t.UpdateTime),
+		DeleteTime:       formatTime(t.DeleteTime),
+	}
+}
+
+// parseTemplate sets the fields of the given paths from the
+// request template to out.
+func parseTemplate(in *templatev1.Template, paths []string, out *template.Template) {
+	for _, path := range paths {
+		switch path {
+		case "name":
+			out.Name = in.GetName()
+		case "description":
+			out.Description = in.GetDescription()
+		case "label":
+			out.Label = template.Label(in.GetLabel())
+		case "category":
+			out.Category = template.Category(in.GetCategory())
+		case "tags":
+			out.Tags = in.GetTags()
+		case "thumbnail_uri":
+			out.ThumbnailURI = in.GetThumbnailUri()
+		case "thumbnail_media_id":
+			out.ThumbnailMediaID = in.GetThumbnailMediaId()
+		case "featured_order":
+			out.FeaturedOrder = int(in.GetFeaturedOrder())
+		case "price":
+			out.Price = in.GetPrice()
+		case "currency":
+			out.Currency = in.GetCurrency()
+		}
+	}
+}
+
+func parseSort(req templatev1.TemplateSort, query string) (template.Sort, error) {
+	switch req {
+	case templatev1.TemplateSort_TEMPLATE_SORT_UNSPECIFIED:
+		// search results are ordered by relevance by default
+		if query != "" {
+			return template.SortRelevance, nil
+		}
+		return template.SortFeatured, nil
+	case templatev1.TemplateSort_TEMPLATE_SORT_FEATURED:
+		return template.SortFeatured, nil
+	case templatev1.TemplateSort_TEMPLATE_SORT_NEWEST:
+		return template.SortNewest, nil
+	case templatev1.TemplateSort_TEMPLATE_SORT_RELEVANCE:
+		return template.SortRelevance, nil
+	}
+
+	return template.SortFeatured, errInvalidSort
+}
+
+func parseListTemplatesRequest(req *templatev1.ListTemplatesRequest) (template.GetTemplatesFilter, error) {
+	res := template.GetTemplatesFilter{
+		Label:    template.Label(req.GetLabel()),
+		Category: template.Category(req.GetCategory()),
+		Tag:      req.GetTag(),
+		Query:    req.GetQuery(),
+		Page:     int(req.GetPage()),
+		Limit:    int(req.GetLimit()),
+	}
+
+	if res.Page < 0 || res.Limit < 0 {
+		return res, errInvalidPagination
+	}
+
+	sort, err := parseSort(req.GetSort(), res.Query)
+	if err != nil {
+		return res, err
+	}
+	res.Sort = sort
+
+	return res, nil
+}
+
+// formatTime returns the given time as a timestamp, or nil
+// for the zero time.
+func formatTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,147 @@
+package grpc
+
+import (
+	"context"
+	"hbdtoyou/internal/template"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Followings are the known errors from template gRPC handlers.
+var (
+	// errBadRequest is returned when the given request is
+	// bad/invalid.
+	errBadRequest = status.Error(codes.InvalidArgument, "BAD_REQUEST")
+
+	// errInternalServer is returned when there is an
+	// unexpected error encountered when processing a request.
+	errInternalServer = status.Error(codes.Internal, "INTERNAL_SERVER_ERROR")
+
+	// errDataNotFound is returned when the desired data is
+	// not found.
+	errDataNotFound = status.Error(codes.NotFound, "DATA_NOT_FOUND")
+
+	// errForbidden is returned when the user is not allowed
+	// to manage templates.
+	errForbidden = status.Error(codes.PermissionDenied, "FORBIDDEN")
+
+	// errInvalidUserID is returned when the user of the
+	// request is invalid.
+	errInvalidUserID = status.Error(codes.InvalidArgument, "INVALID_USER_ID")
+
+	// errInvalidTemplateID is returned when the given template
+	// ID is invalid.
+	errInvalidTemplateID = status.Error(codes.InvalidArgument, "INVALID_TEMPLATE_ID")
+
+	// errInvalidTemplateName is returned when the given
+	// template name is invalid.
+	errInvalidTemplateName = status.Error(codes.InvalidArgument, "INVALID_TEMPLATE_NAME")
+
+	// errInvalidTemplateLabel is returned when the given
+	// template label is invalid.
+	errInvalidTemplateLabel = status.Error(codes.InvalidArgument, "INVALID_TEMPLATE_LABEL")
+
+	// errInvalidTemplateCategory is returned when the given
+	// template category is invalid.
+	errInvalidTemplateCategory = status.Error(codes.InvalidArgument, "INVALID_TEMPLATE_CATEGORY")
+
+	// errInvalidTemplateTag is returned when a given template
+	// tag is invalid.
+	errInvalidTemplateTag = status.Error(codes.InvalidArgument, "INVALID_TEMPLATE_TAG")
+
+	// errInvalidTemplateThumbnailURI is returned when the
+	// given template thumbnail URI is invalid.
+	errInvalidTemplateThumbnailURI = status.Error(codes.InvalidArgument, "INVALID_TEMPLATE_THUMBNAIL_URI")
+
+	// errInvalidTemplateThumbnailMediaID is returned when the
+	// given template thumbnail media ID is invalid.
+	errInvalidTemplateThumbnailMediaID = status.Error(codes.InvalidArgument, "INVALID_TEMPLATE_THUMBNAIL_MEDIA_ID")
+
+	// errInvalidTemplateFeaturedOrder is returned when the
+	// given template featured order is invalid.
+	errInvalidTemplateFeaturedOrder = status.Error(codes.InvalidArgument, "INVALID_TEMPLATE_FEATURED_ORDER")
+
+	// errInvalidTemplatePrice is returned when the given
+	// template price is invalid.
+	errInvalidTemplatePrice = status.Error(codes.InvalidArgument, "INVALID_TEMPLATE_PRICE")
+
+	// errInvalidTemplateCurrency is returned when the given
+	// template currency is invalid.
+	errInvalidTemplateCurrency = status.Error(codes.InvalidArgument, "INVALID_TEMPLATE_CURRENCY")
+
+	// errTemplateInUse is returned when deleting a template
+	// that is still used by a content.
+	errTemplateInUse = status.Error(codes.FailedPrecondition, "TEMPLATE_IN_USE")
+
+	// errInvalidPagination is returned when the requested page
+	// or limit is invalid.
+	errInvalidPagination = status.Error(codes.InvalidArgument, "INVALID_PAGINATION")
+
+	// errInvalidSort is returned when the requested sort order
+	// is invalid.
+	errInvalidSort = status.Error(codes.InvalidArgument, "INVALID_SORT")
+
+	// errInvalidUpdateMask is returned when the given update
+	// mask is empty or has a path not allowed to be updated.
+	errInvalidUpdateMask = status.Error(codes.InvalidArgument, "INVALID_UPDATE_MASK")
+
+	// errPreconditionRequired is returned when updating
+	// without the current version.
+	errPreconditionRequired = status.Error(codes.FailedPrecondition, "PRECONDITION_REQUIRED")
+
+	// errVersionConflict is returned when the data has been
+	// changed since the given version.
+	errVersionConflict = status.Error(codes.Aborted, "VERSION_CONFLICT")
+
+	// errRequestTimeout is returned when processing time has
+	// reached the timeout limit.
+	errRequestTimeout = status.Error(codes.DeadlineExceeded, "REQUEST_TIMEOUT")
+)
+
+var (
+	// mapGRPCError maps service error into gRPC error with
+	// the status code matching the error.
+	//
+	// Internal server error-related should not be mapped here,
+	// and the handler should just return `errInternalServer`
+	// as the error instead
+	mapGRPCError = map[error]error{
+		template.ErrVersionConflict:      errVersionConflict,
+		template.ErrInvalidTemplateID:    errInvalidTemplateID,
+		template.ErrTemplateNotFound:     errDataNotFound,
+		template.ErrInvalidTemplateName:  errInvalidTemplateName,
+		template.ErrInvalidTemplateLabel: errInvalidTemplateLabel,
+		template.ErrTemplateInUse:        errTemplateInUse,
+
+		template.ErrInvalidTemplateThumbnailURI:     errInvalidTemplateThumbnailURI,
+		template.ErrInvalidTemplateThumbnailMediaID: errInvalidTemplateThumbnailMediaID,
+
+		template.ErrInvalidTemplateCategory:      errInvalidTemplateCategory,
+		template.ErrInvalidTemplateTag:           errInvalidTemplateTag,
+		template.ErrInvalidTemplateFeaturedOrder: errInvalidTemplateFeaturedOrder,
+		template.ErrInvalidTemplatePrice:         errInvalidTemplatePrice,
+		template.ErrInvalidTemplateCurrency:      errInvalidTemplateCurrency,
+		template.ErrInvalidPagination:            errInvalidPagination,
+		template.ErrInvalidSort:                  errInvalidSort,
+		template.ErrInvalidUserID:                errInvalidUserID,
+		template.ErrForbidden:                    errForbidden,
+	}
+)
+
+// parseError returns the gRPC error of the given error
+// returned by the template service. Unknown errors are logged
+// and returned as `errInternalServer`.
+func parseError(ctx context.Context, err error, name string) error {
+	if v, ok := mapGRPCError[err]; ok {
+		return v
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return errRequestTimeout
+	}
+
+	log.Printf("[Template gRPC][%s] Internal error. Err: %s\n", name, err.Error())
+	return errInternalServer
+}
@@ -0,0 +1,137 @@
+package grpc
+
+import (
+	"context"
+	"hbdtoyou/internal/template"
+	grpclib "hbdtoyou/pkg/grpc"
+	templatev1 "hbdtoyou/pkg/pb/template/v1"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// CreateTemplate implements templatev1.TemplateServiceServer.
+func (h *Handler) CreateTemplate(ctx context.Context, req *templatev1.CreateTemplateRequest) (*templatev1.CreateTemplateResponse, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeCreateTemplate].Timeout)
+	defer cancel()
+
+	if req.GetTemplate() == nil {
+		return nil, errBadRequest
+	}
+
+	reqTemplate := template.Template{}
+	parseTemplate(req.GetTemplate(), templateUpdatePaths, &reqTemplate)
+	if reqTemplate.Category == template.CategoryUnknown {
+		reqTemplate.Category = template.CategoryBirthday
+	}
+
+	templateID, err := h.template.CreateTemplate(ctx, reqTemplate)
+	if err != nil {
+		return nil, parseError(ctx, err, "CreateTemplate")
+	}
+
+	return &templatev1.CreateTemplateResponse{
+		Id: templateID,
+	}, nil
+}
+
+// GetTemplate implements templatev1.TemplateServiceServer.
+func (h *Handler) GetTemplate(ctx context.Context, req *templatev1.GetTemplateRequest) (*templatev1.Template, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeGetTemplate].Timeout)
+	defer cancel()
+
+	t, err := h.template.GetTemplateByID(ctx, req.GetId())
+	if err != nil {
+		return nil, parseError(ctx, err, "GetTemplate")
+	}
+
+	return formatTemplate(t), nil
+}
+
+// ListTemplates implements templatev1.TemplateServiceServer.
+func (h *Handler) ListTemplates(ctx context.Context, req *templatev1.ListTemplatesRequest) (*templatev1.ListTemplatesResponse, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeListTemplates].Timeout)
+	defer cancel()
+
+	filter, err := parseListTemplatesRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, total, err := h.template.GetTemplates(ctx, filter)
+	if err != nil {
+		return nil, parseError(ctx, err, "ListTemplates")
+	}
+
+	page, limit := filter.PageLimit()
+	res := &templatev1.ListTemplatesResponse{
+		Templates: make([]*templatev1.Template, 0, len(templates)),
+		Page:      int32(page),
+		Limit:     int32(limit),
+		Total:     int32(total),
+	}
+	for _, t := range templates {
+		res.Templates = append(res.Templates, formatTemplate(t))
+	}
+
+	return res, nil
+}
+
+// UpdateTemplate implements templatev1.TemplateServiceServer.
+func (h *Handler) UpdateTemplate(ctx context.Context, req *templatev1.UpdateTemplateRequest) (*templatev1.Template, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeUpdateTemplate].Timeout)
+	defer cancel()
+
+	if req.GetTemplate() == nil {
+		return nil, errBadRequest
+	}
+
+	paths, err := grpclib.GetUpdateMaskPaths(req.GetUpdateMask(), templateUpdatePaths...)
+	if err != nil {
+		return nil, errInvalidUpdateMask
+	}
+
+	// updating without the version might overwrite changes
+	// made by others
+	if req.GetTemplate().GetVersion() <= 0 {
+		return nil, errPreconditionRequired
+	}
+
+	current, err := h.template.GetTemplateByID(ctx, req.GetTemplate().GetId())
+	if err != nil {
+		return nil, parseError(ctx, err, "UpdateTemplate")
+	}
+
+	// the data has been changed since the client read it
+	if current.Version != req.GetTemplate().GetVersion() {
+		return nil, errVersionConflict
+	}
+
+	parseTemplate(req.GetTemplate(), paths, &current)
+
+	err = h.template.UpdateTemplate(ctx, current)
+	if err != nil {
+		return nil, parseError(ctx, err, "UpdateTemplate")
+	}
+
+	// the stored version is incremented on every update
+	current.Version++
+	return formatTemplate(current), nil
+}
+
+// DeleteTemplate implements templatev1.TemplateServiceServer.
+func (h *Handler) DeleteTemplate(ctx context.Context, req *templatev1.DeleteTemplateRequest) (*emptypb.Empty, error) {
+	// add timeout to context
+	ctx, cancel := context.WithTimeout(ctx, h.scopeSettings[ScopeDeleteTemplate].Timeout)
+	defer cancel()
+
+	err := h.template.DeleteTemplateByID(ctx, req.GetId())
+	if err != nil {
+		return nil, parseError(ctx, err, "DeleteTemplate")
+	}
+
+	return &emptypb.Empty{}, nil
+}
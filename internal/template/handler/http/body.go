@@ -3,23 +3,70 @@ package http
 import (
 	"hbdtoyou/internal/template"
 	"net/http"
+
+	httplib "hbdtoyou/pkg/http"
+	"hbdtoyou/pkg/money"
 )
 
+// timeFormat denotes the standard time format used in
+// template HTTP handlers.
+var timeFormat = "02/01/2006 3:04 PM -07:00"
+
 type templateHTTP struct {
-	ID           *string `json:"id"`
-	Name         *string `json:"name"`
-	Label        *string `json:"label"`
-	ThumbnailURI *string `json:"thumbnail_uri"`
+	ID               *string   `json:"id"`
+	Name             *string   `json:"name"`
+	Description      *string   `json:"description"`
+	Label            *string   `json:"label"`
+	Category         *string   `json:"category"`
+	Tags             *[]string `json:"tags"`
+	ThumbnailURI     *string   `json:"thumbnail_uri"`
+	ThumbnailMediaID *string   `json:"thumbnail_media_id"`
+	FeaturedOrder    *int      `json:"featured_order"`
+	Price            *int64    `json:"price"`
+	Currency         *string   `json:"currency"`
+	PriceDisplay     *string   `json:"price_display,omitempty"`
+	Version          *int64    `json:"version"`
+	DeleteTime       *string   `json:"delete_time,omitempty"`
 }
 
 func formatTemplate(t template.Template) templateHTTP {
 	label := t.Label.String()
+	category := t.Category.String()
+	thumbnailURI := t.GetThumbnailURI()
+
+	tags := t.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	var deleteTime *string
+	if !t.DeleteTime.IsZero() {
+		formatted := t.DeleteTime.Format(timeFormat)
+		deleteTime = &formatted
+	}
+
+	// only templates on sale have a price to display
+	var priceDisplay *string
+	if t.Price > 0 {
+		formatted := money.Money{Amount: t.Price, Currency: t.Currency}.String()
+		priceDisplay = &formatted
+	}
 
 	return templateHTTP{
-		ID:           &t.ID,
-		Name:         &t.Name,
-		Label:        &label,
-		ThumbnailURI: &t.ThumbnailURI,
+		ID:               &t.ID,
+		Name:             &t.Name,
+		Description:      &t.Description,
+		Label:            &label,
+		Category:         &category,
+		Tags:             &tags,
+		ThumbnailURI:     &thumbnailURI,
+		ThumbnailMediaID: &t.ThumbnailMediaID,
+		FeaturedOrder:    &t.FeaturedOrder,
+		Price:            &t.Price,
+		Currency:         &t.Currency,
+		PriceDisplay:     priceDisplay,
+		Version:          &t.Version,
+		DeleteTime:       deleteTime,
 	}
 }
 
@@ -32,6 +79,10 @@ func (t templateHTTP) parseTemplate(out *template.Template) error {
 		out.Name = *t.Name
 	}
 
+	if t.Description != nil {
+		out.Description = *t.Description
+	}
+
 	if t.Label != nil {
 		label, err := parseLabel(*t.Label)
 		if err != nil {
@@ -41,10 +92,39 @@ func (t templateHTTP) parseTemplate(out *template.Template) error {
 		out.Label = label
 	}
 
+	if t.Category != nil {
+		category, err := parseCategory(*t.Category)
+		if err != nil {
+			return err
+		}
+
+		out.Category = category
+	}
+
+	if t.Tags != nil {
+		out.Tags = *t.Tags
+	}
+
+	if t.FeaturedOrder != nil {
+		out.FeaturedOrder = *t.FeaturedOrder
+	}
+
+	if t.Price != nil {
+		out.Price = *t.Price
+	}
+
+	if t.Currency != nil {
+		out.Currency = *t.Currency
+	}
+
 	if t.ThumbnailURI != nil {
 		out.ThumbnailURI = *t.ThumbnailURI
 	}
 
+	if t.ThumbnailMediaID != nil {
+		out.ThumbnailMediaID = *t.ThumbnailMediaID
+	}
+
 	return nil
 }
 
@@ -59,10 +139,63 @@ func parseLabel(req string) (template.Label, error) {
 	return template.LabelUnknown, errInvalidTemplateLabel
 }
 
-func (h *templatesHandler) parseHandleGetTemplatesQuery(r *http.Request) (template.GetTemplatesFilter, error) {
+func parseCategory(req string) (template.Category, error) {
+	for category, name := range template.CategoryName {
+		if req == name {
+			return category, nil
+		}
+	}
+
+	return template.CategoryUnknown, errInvalidTemplateCategory
+}
+
+func parseSort(req string) (template.Sort, error) {
+	for sort, name := range template.SortName {
+		if req == name {
+			return sort, nil
+		}
+	}
+
+	return template.SortFeatured, errInvalidSort
+}
+
+func parseGetTemplatesQuery(r *http.Request) (template.GetTemplatesFilter, error) {
 	query := r.URL.Query()
 
-	res := template.GetTemplatesFilter{}
+	res := template.GetTemplatesFilter{
+		Tag:   query.Get("tag"),
+		Query: query.Get("q"),
+	}
+
+	page, limit, err := httplib.GetPaginationFromQuery(r)
+	if err != nil {
+		return res, errInvalidPagination
+	}
+	res.Page = page
+	res.Limit = limit
+
+	categoryParams := query.Get("category")
+	if categoryParams != "" {
+		category, err := parseCategory(categoryParams)
+		if err != nil {
+			return res, err
+		}
+
+		res.Category = category
+	}
+
+	sortParams := query.Get("sort")
+	if sortParams != "" {
+		sort, err := parseSort(sortParams)
+		if err != nil {
+			return res, err
+		}
+
+		res.Sort = sort
+	} else if res.Query != "" {
+		// search results are ordered by relevance by default
+		res.Sort = template.SortRelevance
+	}
 
 	labelParams := query.Get("label")
 	if labelParams != "" {
@@ -76,3 +209,11 @@ func (h *templatesHandler) parseHandleGetTemplatesQuery(r *http.Request) (templa
 
 	return res, nil
 }
+
+// templatesPage denotes a page of listed templates.
+type templatesPage struct {
+	templates []template.Template
+	page      int
+	limit     int
+	total     int
+}
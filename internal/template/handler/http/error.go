@@ -72,6 +72,22 @@ var (
 	// is invalid.
 	errInvalidUsername = errors.New("INVALID_USERNAME")
 
+	// errInvalidIfMatch is returned when the given If-Match
+	// header is not a valid version ETag.
+	errInvalidIfMatch = errors.New("INVALID_IF_MATCH")
+
+	// errPreconditionRequired is returned when updating
+	// without If-Match header.
+	errPreconditionRequired = errors.New("PRECONDITION_REQUIRED")
+
+	// errVersionConflict is returned when the data has been
+	// changed since the given version.
+	errVersionConflict = errors.New("VERSION_CONFLICT")
+
+	// errForbidden is returned when the user is not allowed
+	// to manage templates.
+	errForbidden = errors.New("FORBIDDEN")
+
 	// errMethodNotAllowed is returned when accessing not
 	// allowed HTTP method.
 	errMethodNotAllowed = errors.New("METHOD_NOT_ALLOWED")
@@ -84,6 +100,41 @@ var (
 	// invalid.
 	errInvalidTemplateLabel = errors.New("INVALID_TEMPLATE_LABEL")
 
+	// errInvalidTemplateCategory is returned when the given template
+	// category is invalid.
+	errInvalidTemplateCategory = errors.New("INVALID_TEMPLATE_CATEGORY")
+
+	// errInvalidTemplateTag is returned when the given template tag is
+	// invalid.
+	errInvalidTemplateTag = errors.New("INVALID_TEMPLATE_TAG")
+
+	// errInvalidTemplateFeaturedOrder is returned when the given
+	// template featured order is invalid.
+	errInvalidTemplateFeaturedOrder = errors.New("INVALID_TEMPLATE_FEATURED_ORDER")
+
+	// errInvalidTemplatePrice is returned when the given template price is
+	// invalid.
+	errInvalidTemplatePrice = errors.New("INVALID_TEMPLATE_PRICE")
+
+	// errInvalidTemplateCurrency is returned when the given template
+	// currency is invalid.
+	errInvalidTemplateCurrency = errors.New("INVALID_TEMPLATE_CURRENCY")
+
+	// errInvalidPagination is returned when the given page or limit is
+	// invalid.
+	errInvalidPagination = errors.New("INVALID_PAGINATION")
+
+	// errInvalidSort is returned when the given sort order is invalid.
+	errInvalidSort = errors.New("INVALID_SORT")
+
+	// errInvalidTemplateThumbnailMediaID is returned when the given
+	// template thumbnail media id is invalid.
+	errInvalidTemplateThumbnailMediaID = errors.New("INVALID_TEMPLATE_THUMBNAIL_MEDIA_ID")
+
+	// errTemplateInUse is returned when deleting a template
+	// that is still used by a content.
+	errTemplateInUse = errors.New("TEMPLATE_IN_USE")
+
 	// errSourceNotProvided is returned when there is no
 	// source provided in the request.
 	errSourceNotProvided = errors.New("SOURCE_NOT_PROVIDED")
@@ -105,8 +156,22 @@ var (
 	// and the handler should just return `errInternal` as the
 	// error instead
 	mapHTTPError = map[error]error{
+		template.ErrVersionConflict:      errVersionConflict,
 		template.ErrInvalidTemplateID:    errInvalidTemplateID,
 		template.ErrTemplateNotFound:     errDataNotFound,
 		template.ErrInvalidTemplateLabel: errInvalidTemplateLabel,
+		template.ErrTemplateInUse:        errTemplateInUse,
+
+		template.ErrInvalidTemplateThumbnailMediaID: errInvalidTemplateThumbnailMediaID,
+
+		template.ErrInvalidTemplateCategory:      errInvalidTemplateCategory,
+		template.ErrInvalidTemplateTag:           errInvalidTemplateTag,
+		template.ErrInvalidTemplateFeaturedOrder: errInvalidTemplateFeaturedOrder,
+		template.ErrInvalidTemplatePrice:         errInvalidTemplatePrice,
+		template.ErrInvalidTemplateCurrency:      errInvalidTemplateCurrency,
+		template.ErrInvalidPagination:            errInvalidPagination,
+		template.ErrInvalidSort:                  errInvalidSort,
+		template.ErrInvalidUserID:                errInvalidUserID,
+		template.ErrForbidden:                    errForbidden,
 	}
 )
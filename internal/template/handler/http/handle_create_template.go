@@ -92,10 +92,11 @@ func (h *templatesHandler) handleCreateTemplate(w http.ResponseWriter, r *http.R
 			return
 		}
 
-		// TODO: add authorization flow with roles
-
 		// format HTTP request into service object
-		template := template.Template{}
+		// templates are for birthdays unless stated otherwise
+		template := template.Template{
+			Category: template.CategoryBirthday,
+		}
 		err = request.parseTemplate(&template)
 		if err != nil {
 			statusCode = http.StatusUnauthorized
@@ -113,6 +114,9 @@ func (h *templatesHandler) handleCreateTemplate(w http.ResponseWriter, r *http.R
 				parsedErr = v
 				statusCode = http.StatusBadRequest
 			}
+			if parsedErr == errForbidden {
+				statusCode = http.StatusForbidden
+			}
 
 			// log the actual error if its internal error
 			if statusCode == http.StatusInternalServerError {
@@ -131,6 +135,20 @@ func (h *templatesHandler) handleCreateTemplate(w http.ResponseWriter, r *http.R
 	case <-ctx.Done():
 		statusCode = http.StatusGatewayTimeout
 		err = errRequestTimeout
+
+		// the template might still be created, its response is replayed to the
+		// retries of the request
+		httplib.SetLateResponse(r, func() (int, []byte, bool) {
+			select {
+			case <-errChan:
+				return 0, nil, false
+			case templateID := <-resChan:
+				body, err := json.Marshal(httplib.ResponseEnvelope{
+					Data: templateID,
+				})
+				return http.StatusOK, body, err == nil
+			}
+		})
 	case err = <-errChan:
 	case templateID := <-resChan:
 		resBody, err = json.Marshal(httplib.ResponseEnvelope{
@@ -26,7 +26,7 @@ func (h *templateHandler) handleDeleteTemplateByID(w http.ResponseWriter, r *htt
 	defer func() {
 		// error
 		if err != nil {
-			log.Printf("[Template HTTP][handleDeleteTemplate] Failed to delete template by ID. template ID: %d, Source: %s, Err: %s\n", templateID, source, err.Error())
+			log.Printf("[Template HTTP][handleDeleteTemplate] Failed to delete template by ID. template ID: %s, Source: %s, Err: %s\n", templateID, source, err.Error())
 			httplib.WriteErrorResponse(w, statusCode, []string{err.Error()})
 			return
 		}
@@ -73,9 +73,6 @@ func (h *templateHandler) handleDeleteTemplateByID(w http.ResponseWriter, r *htt
 			return
 		}
 
-		// TODO: add authorization flow with roles
-
-		var templateID string
 		err = h.template.DeleteTemplateByID(ctx, templateID)
 		if err != nil {
 			// determine error and status code, by default its internal error
@@ -85,6 +82,9 @@ func (h *templateHandler) handleDeleteTemplateByID(w http.ResponseWriter, r *htt
 				parsedErr = v
 				statusCode = http.StatusBadRequest
 			}
+			if parsedErr == errForbidden {
+				statusCode = http.StatusForbidden
+			}
 
 			// log the actual error if its internal error
 			if statusCode == http.StatusInternalServerError {
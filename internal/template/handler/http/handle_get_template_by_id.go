@@ -21,18 +21,19 @@ func (h *templateHandler) handleGetTemplateByID(w http.ResponseWriter, r *http.R
 		source     string          // stores request source
 		resBody    []byte          // stores response body to write
 		statusCode = http.StatusOK // stores response status code
+		resVersion int64           // stores response data version
 	)
 
 	// write response
 	defer func() {
 		// error
 		if err != nil {
-			log.Printf("[Template HTTP][handleGetTemplateByID] Failed to get template by ID. template ID: %d, Source: %s, Err: %s\n", templateID, source, err.Error())
+			log.Printf("[Template HTTP][handleGetTemplateByID] Failed to get template by ID. template ID: %s, Source: %s, Err: %s\n", templateID, source, err.Error())
 			httplib.WriteErrorResponse(w, statusCode, []string{err.Error()})
 			return
 		}
 		// success
-		httplib.WriteResponse(w, resBody, statusCode, httplib.JSONContentTypeDecorator)
+		httplib.WriteResponse(w, resBody, statusCode, httplib.JSONContentTypeDecorator, httplib.NewVersionETagDecorator(resVersion))
 	}()
 
 	// prepare channels for main go routine
@@ -104,6 +105,7 @@ func (h *templateHandler) handleGetTemplateByID(w http.ResponseWriter, r *http.R
 		err = errRequestTimeout
 	case err = <-errChan:
 	case res := <-resChan:
+		resVersion = res.Version
 		resBody, err = json.Marshal(httplib.ResponseEnvelope{
 			Data: formatTemplate(res),
 		})
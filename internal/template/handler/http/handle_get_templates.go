@@ -36,7 +36,7 @@ func (h *templatesHandler) handleGetTemplates(w http.ResponseWriter, r *http.Req
 	}()
 
 	// prepare channels for main go routine
-	resChan := make(chan []template.Template, 1)
+	resChan := make(chan templatesPage, 1)
 	errChan := make(chan error, 1)
 
 	go func() {
@@ -75,15 +75,18 @@ func (h *templatesHandler) handleGetTemplates(w http.ResponseWriter, r *http.Req
 		}
 
 		var filter template.GetTemplatesFilter
-		filter, err = h.parseHandleGetTemplatesQuery(r)
+		filter, err = parseGetTemplatesQuery(r)
 		if err != nil {
 			statusCode = http.StatusBadRequest
-			errChan <- errBadRequest
+			errChan <- err
 			return
 		}
 
-		var contents []template.Template
-		contents, err = h.template.GetTemplates(ctx, filter)
+		var (
+			contents []template.Template
+			total    int
+		)
+		contents, total, err = h.template.GetTemplates(ctx, filter)
 		if err != nil {
 			// determine error and status code, by default its internal error
 			parsedErr := errInternalServer
@@ -102,7 +105,13 @@ func (h *templatesHandler) handleGetTemplates(w http.ResponseWriter, r *http.Req
 			return
 		}
 
-		resChan <- contents
+		page, limit := filter.PageLimit()
+		resChan <- templatesPage{
+			templates: contents,
+			page:      page,
+			limit:     limit,
+			total:     total,
+		}
 	}()
 
 	// wait and handle main go routine
@@ -114,12 +123,17 @@ func (h *templatesHandler) handleGetTemplates(w http.ResponseWriter, r *http.Req
 	case res := <-resChan:
 		// format each content
 		contents := make([]templateHTTP, 0)
-		for _, r := range res {
+		for _, r := range res.templates {
 			contents = append(contents, formatTemplate(r))
 		}
 
 		resBody, err = json.Marshal(httplib.ResponseEnvelope{
 			Data: contents,
+			Pagination: &httplib.Pagination{
+				Page:  res.page,
+				Limit: res.limit,
+				Total: res.total,
+			},
 		})
 	}
 }
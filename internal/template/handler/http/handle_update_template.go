@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"hbdtoyou/internal/template"
 	contextlib "hbdtoyou/pkg/context"
 	httplib "hbdtoyou/pkg/http"
 	"io/ioutil"
@@ -21,22 +22,23 @@ func (h *templateHandler) handleUpdateTemplate(w http.ResponseWriter, r *http.Re
 		source     string          // stores request source
 		resBody    []byte          // stores response body to write
 		statusCode = http.StatusOK // stores response status code
+		resVersion int64           // stores response data version
 	)
 
 	// write response
 	defer func() {
 		// error
 		if err != nil {
-			log.Printf("[Template HTTP][handleUpdateTemplate] Failed to update template by ID. template ID: %d, Source: %s, Err: %s\n", templateID, source, err.Error())
+			log.Printf("[Template HTTP][handleUpdateTemplate] Failed to update template by ID. template ID: %s, Source: %s, Err: %s\n", templateID, source, err.Error())
 			httplib.WriteErrorResponse(w, statusCode, []string{err.Error()})
 			return
 		}
 		// success
-		httplib.WriteResponse(w, resBody, statusCode, httplib.JSONContentTypeDecorator)
+		httplib.WriteResponse(w, resBody, statusCode, httplib.JSONContentTypeDecorator, httplib.NewVersionETagDecorator(resVersion))
 	}()
 
 	// prepare channels for main go routine
-	resChan := make(chan string, 1)
+	resChan := make(chan template.Template, 1)
 	errChan := make(chan error, 1)
 
 	go func() {
@@ -66,6 +68,20 @@ func (h *templateHandler) handleUpdateTemplate(w http.ResponseWriter, r *http.Re
 			return
 		}
 
+		// get expected version, updating without it might
+		// overwrite changes made by others
+		version, err := httplib.GetIfMatchVersionFromHeader(r)
+		if err == httplib.ErrIfMatchNotFound {
+			statusCode = http.StatusPreconditionRequired
+			errChan <- errPreconditionRequired
+			return
+		}
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errChan <- errInvalidIfMatch
+			return
+		}
+
 		// read body
 		body, err := ioutil.ReadAll(r.Body)
 		if err != nil {
@@ -91,8 +107,6 @@ func (h *templateHandler) handleUpdateTemplate(w http.ResponseWriter, r *http.Re
 			return
 		}
 
-		// TODO: add authorization flow with roles
-
 		// get current bill data
 		current, err := h.template.GetTemplateByID(ctx, templateID)
 		if err != nil {
@@ -106,14 +120,21 @@ func (h *templateHandler) handleUpdateTemplate(w http.ResponseWriter, r *http.Re
 
 			// log the actual error if its internal error
 			if statusCode == http.StatusInternalServerError {
-				log.Printf("[Template HTTP][handleUpdateTemplate] Internal error from GetTemplateByID, template ID: %d. Err: %s\n", templateID, err.Error())
+				log.Printf("[Template HTTP][handleUpdateTemplate] Internal error from GetTemplateByID, template ID: %s. Err: %s\n", templateID, err.Error())
 			}
 
 			errChan <- parsedErr
 			return
 		}
 
-		// parse content from request body
+		// the data has been changed since the client read it
+		if current.Version != version {
+			statusCode = http.StatusPreconditionFailed
+			errChan <- errVersionConflict
+			return
+		}
+
+		// parse template from request body
 		err = request.parseTemplate(&current)
 		if err != nil {
 			statusCode = http.StatusBadRequest
@@ -130,6 +151,12 @@ func (h *templateHandler) handleUpdateTemplate(w http.ResponseWriter, r *http.Re
 				parsedErr = v
 				statusCode = http.StatusBadRequest
 			}
+			if parsedErr == errForbidden {
+				statusCode = http.StatusForbidden
+			}
+			if err == template.ErrVersionConflict {
+				statusCode = http.StatusPreconditionFailed
+			}
 
 			// log the actual error if its internal error
 			if statusCode == http.StatusInternalServerError {
@@ -140,7 +167,9 @@ func (h *templateHandler) handleUpdateTemplate(w http.ResponseWriter, r *http.Re
 			return
 		}
 
-		resChan <- templateID
+		// the stored version is incremented on every update
+		current.Version++
+		resChan <- current
 	}()
 
 	// wait and handle main go routine
@@ -149,9 +178,10 @@ func (h *templateHandler) handleUpdateTemplate(w http.ResponseWriter, r *http.Re
 		statusCode = http.StatusGatewayTimeout
 		err = errRequestTimeout
 	case err = <-errChan:
-	case templateID := <-resChan:
+	case res := <-resChan:
+		resVersion = res.Version
 		resBody, err = json.Marshal(httplib.ResponseEnvelope{
-			Data: templateID,
+			Data: res.ID,
 		})
 	}
 }
@@ -49,3 +49,37 @@ func (h *templateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
 	}
 }
+
+type templateRestoreHandler struct {
+	template      template.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *templateRestoreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	templateID := vars["id"]
+
+	switch r.Method {
+	case http.MethodPost:
+		h.handleRestoreTemplateByID(w, r, templateID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type trashTemplatesHandler struct {
+	template      template.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *trashTemplatesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetTrashTemplates(w, r)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"hbdtoyou/internal/auth"
 	"hbdtoyou/internal/template"
+	httplib "hbdtoyou/pkg/http"
+	"hbdtoyou/pkg/ratelimit"
 	"net/http"
 	"time"
 
@@ -11,8 +13,9 @@ import (
 )
 
 var (
-	errUnknownScope  = errors.New("unknown scope name")
-	errUnknownConfig = errors.New("unknown config name")
+	errUnknownScope     = errors.New("unknown scope name")
+	errUnknownConfig    = errors.New("unknown config name")
+	errInvalidRateLimit = errors.New("invalid rate limit")
 )
 
 // Handler contains finance HTTP handlers.
@@ -21,6 +24,8 @@ type Handler struct {
 	template      template.Service
 	auth          auth.Service
 	scopeSettings map[Scope]ScopeSetting
+	rateLimiter   *httplib.RateLimiter
+	idempotency   *httplib.Idempotency
 }
 
 // handler is the HTTP handler wrapper.
@@ -45,6 +50,14 @@ var (
 		Name: "templates",
 		URL:  "/v1/templates",
 	}
+	HandlerTemplateRestore = HandlerIdentity{
+		Name: "template_restore",
+		URL:  "/v1/templates/{id}/restore",
+	}
+	HandlerTrashTemplates = HandlerIdentity{
+		Name: "trash_templates",
+		URL:  "/v1/trash/templates",
+	}
 )
 
 // Scope is a shared settings identifier.
@@ -62,6 +75,8 @@ const (
 	ScopeGetTemplateByID
 	ScopeUpdateTemplate
 	ScopeDeleteTemplate
+	ScopeRestoreTemplate
+	ScopeGetTrashTemplates
 )
 
 var (
@@ -73,6 +88,9 @@ var (
 		ScopeGetTemplateByID: "GetTemplateByID",
 		ScopeUpdateTemplate:  "UpdateTemplate",
 		ScopeDeleteTemplate:  "DeleteTemplate",
+		ScopeRestoreTemplate: "RestoreTemplate",
+
+		ScopeGetTrashTemplates: "GetTrashTemplates",
 	}
 
 	// ScopeValue is the reverse-mapping of ScopeName.
@@ -82,12 +100,20 @@ var (
 		ScopeName[ScopeGetTemplateByID]: ScopeGetTemplateByID,
 		ScopeName[ScopeUpdateTemplate]:  ScopeUpdateTemplate,
 		ScopeName[ScopeDeleteTemplate]:  ScopeDeleteTemplate,
+		ScopeName[ScopeRestoreTemplate]: ScopeRestoreTemplate,
+
+		ScopeName[ScopeGetTrashTemplates]: ScopeGetTrashTemplates,
 	}
 )
 
 // ScopeSetting is the available configurations of a Scope.
 type ScopeSetting struct {
 	Timeout time.Duration
+
+	// RateLimit limits the requests of the scope per user,
+	// or per client IP if the request has no user. The zero
+	// value does not limit.
+	RateLimit ratelimit.Limit
 }
 
 // Followings are default values for ScopeSetting fields.
@@ -144,12 +170,35 @@ func WithScopeSetting(scopeName string, scopeSetting ScopeSetting) Option {
 		if scopeSetting.Timeout <= 0 {
 			scopeSetting.Timeout = defaultTimeout
 		}
+		if err := scopeSetting.RateLimit.Validate(); err != nil {
+			return errInvalidRateLimit
+		}
 
 		h.scopeSettings[scope] = scopeSetting
 		return nil
 	})
 }
 
+// WithRateLimiter returns Option to limit the rate of requests
+// using the given rate limiter, with the RateLimit of each
+// scope setting.
+func WithRateLimiter(rateLimiter *httplib.RateLimiter) Option {
+	return Option(func(h *Handler) error {
+		h.rateLimiter = rateLimiter
+		return nil
+	})
+}
+
+// WithIdempotency returns Option to replay the saved response
+// of the create requests sent again with the same
+// Idempotency-Key header.
+func WithIdempotency(idempotency *httplib.Idempotency) Option {
+	return Option(func(h *Handler) error {
+		h.idempotency = idempotency
+		return nil
+	})
+}
+
 // New creates a new Handler.
 //
 // For the given Option, WithScopeSetting() should come first
@@ -190,6 +239,18 @@ func (h *Handler) createHTTPHandler(configName string) (http.Handler, error) {
 			auth:          h.auth,
 			scopeSettings: h.scopeSettings,
 		}
+	case HandlerTemplateRestore.Name:
+		httpHandler = &templateRestoreHandler{
+			template:      h.template,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
+	case HandlerTrashTemplates.Name:
+		httpHandler = &trashTemplatesHandler{
+			template:      h.template,
+			auth:          h.auth,
+			scopeSettings: h.scopeSettings,
+		}
 	default:
 		return httpHandler, errUnknownConfig
 	}
@@ -200,7 +261,7 @@ func (h *Handler) createHTTPHandler(configName string) (http.Handler, error) {
 // Start starts all HTTP handlers.
 func (h *Handler) Start(multiplexer *mux.Router) error {
 	for _, handler := range h.handlers {
-		multiplexer.Handle(handler.identity.URL, handler.h)
+		multiplexer.Handle(handler.identity.URL, h.limitRate(handler.identity, h.ensureIdempotency(handler.identity, handler.h)))
 	}
 	return nil
 }
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"hbdtoyou/pkg/openapi"
+	"net/http"
+)
+
+// getTemplatesQuery is the query of listing templates, it is
+// parsed by parseGetTemplatesQuery().
+var getTemplatesQuery = []*openapi.Parameter{
+	openapi.Query("q", "string", "Search query of the templates."),
+	openapi.Query("tag", "string", "Tag of the templates."),
+	openapi.Query("category", "string", "Category of the templates."),
+	openapi.Query("label", "string", "Label of the templates."),
+	openapi.Query("sort", "string", "Order of the templates, by relevance if q is given."),
+	openapi.Query("page", "integer", "Page number, starting from 1."),
+	openapi.Query("limit", "integer", "Number of templates per page."),
+}
+
+// routes defines the OpenAPI routes of each HTTP handler, it
+// must be updated along with the methods served by handler.go.
+var routes = map[string][]openapi.Route{
+	HandlerTemplates.Name: {
+		{Method: http.MethodPost, Summary: "Create template", Request: templateHTTP{}, Response: "", Idempotent: true},
+		{Method: http.MethodGet, Summary: "Get templates", Response: []templateHTTP{}, Paginated: true, Query: getTemplatesQuery},
+	},
+	HandlerTemplate.Name: {
+		{Method: http.MethodGet, Summary: "Get template by ID", Response: templateHTTP{}, Versioned: true},
+		{Method: http.MethodPatch, Summary: "Update template", Request: templateHTTP{}, Response: "", IfMatch: true},
+		{Method: http.MethodDelete, Summary: "Delete template", Response: ""},
+	},
+	HandlerTemplateRestore.Name: {
+		{Method: http.MethodPost, Summary: "Restore template", Response: ""},
+	},
+	HandlerTrashTemplates.Name: {
+		{Method: http.MethodGet, Summary: "Get trash templates", Response: []templateHTTP{}, Paginated: true, Query: getTemplatesQuery},
+	},
+}
+
+// Routes returns the OpenAPI routes of the registered HTTP
+// handlers.
+func (h *Handler) Routes() []openapi.Route {
+	var res []openapi.Route
+	for _, handler := range h.handlers {
+		for _, route := range routes[handler.identity.Name] {
+			route.Path = handler.identity.URL
+			route.Tag = "template"
+			res = append(res, route)
+		}
+	}
+	return res
+}
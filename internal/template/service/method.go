@@ -2,14 +2,34 @@ package service
 
 import (
 	"context"
+	"hbdtoyou/internal/media"
 	"hbdtoyou/internal/template"
+	contextlib "hbdtoyou/pkg/context"
+	"hbdtoyou/pkg/money"
+	"strings"
+	"time"
 )
 
 // CreateTemplate creates a new template and returns
 // the created template ID.
 func (s *service) CreateTemplate(ctx context.Context, reqTemplate template.Template) (string, error) {
+	// only administrators manage templates
+	err := s.authorize(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	reqTemplate.Tags = normalizeTags(reqTemplate.Tags)
+	reqTemplate.Currency = money.NormalizeCurrency(reqTemplate.Currency)
+
 	// validate fields
-	err := validateTemplate(reqTemplate)
+	err = validateTemplate(reqTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	// validate referenced media
+	err = s.validateThumbnailMediaID(ctx, reqTemplate)
 	if err != nil {
 		return "", err
 	}
@@ -18,7 +38,7 @@ func (s *service) CreateTemplate(ctx context.Context, reqTemplate template.Templ
 	reqTemplate.CreateTime = s.timeNow()
 
 	// get pg store client using transaction
-	pgStoreClient, err := s.pgStore.NewClient(false)
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
 	if err != nil {
 		return "", err
 	}
@@ -41,7 +61,7 @@ func (s *service) GetTemplateByID(ctx context.Context, templateID string) (templ
 	}
 
 	// get pg store client without transaction
-	pgStoreClient, err := s.pgStore.NewClient(false)
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
 	if err != nil {
 		return template.Template{}, err
 	}
@@ -52,24 +72,57 @@ func (s *service) GetTemplateByID(ctx context.Context, templateID string) (templ
 		return template.Template{}, err
 	}
 
+	// resolve thumbnail from media
+	err = s.resolveThumbnailURL(ctx, &result)
+	if err != nil {
+		return template.Template{}, err
+	}
+
 	return result, nil
 }
 
-// GetTemplates returns all templates.
-func (s *service) GetTemplates(ctx context.Context, filter template.GetTemplatesFilter) ([]template.Template, error) {
+// GetTemplates returns a page of templates matching the
+// given filter, along with the total number of matching
+// templates.
+func (s *service) GetTemplates(ctx context.Context, filter template.GetTemplatesFilter) ([]template.Template, int, error) {
+	// validate filter
+	if filter.Page < 0 || filter.Limit < 0 {
+		return nil, 0, template.ErrInvalidPagination
+	}
+
+	filter.Tag = strings.ToLower(strings.TrimSpace(filter.Tag))
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	if filter.Sort == template.SortRelevance && filter.Query == "" {
+		return nil, 0, template.ErrInvalidSort
+	}
+
 	// get pg store client without transaction
-	pgStoreClient, err := s.pgStore.NewClient(false)
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	// get templates from pgstore
 	result, err := pgStoreClient.GetTemplates(ctx, filter)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return result, nil
+	total, err := pgStoreClient.CountTemplates(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// resolve thumbnails from media
+	for i := range result {
+		err = s.resolveThumbnailURL(ctx, &result[i])
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return result, total, nil
 }
 
 // UpdateTemplate updates existing template
@@ -85,8 +138,23 @@ func (s *service) UpdateTemplate(ctx context.Context, reqTemplate template.Templ
 		return template.ErrInvalidTemplateID
 	}
 
+	// only administrators manage templates
+	err := s.authorize(ctx)
+	if err != nil {
+		return err
+	}
+
+	reqTemplate.Tags = normalizeTags(reqTemplate.Tags)
+	reqTemplate.Currency = money.NormalizeCurrency(reqTemplate.Currency)
+
 	// validate fields
-	err := validateTemplate(reqTemplate)
+	err = validateTemplate(reqTemplate)
+	if err != nil {
+		return err
+	}
+
+	// validate referenced media
+	err = s.validateThumbnailMediaID(ctx, reqTemplate)
 	if err != nil {
 		return err
 	}
@@ -95,7 +163,7 @@ func (s *service) UpdateTemplate(ctx context.Context, reqTemplate template.Templ
 	reqTemplate.UpdateTime = s.timeNow()
 
 	// get pg store client using transaction
-	pgStoreClient, err := s.pgStore.NewClient(false)
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
 	if err != nil {
 		return err
 	}
@@ -117,18 +185,104 @@ func (s *service) DeleteTemplateByID(ctx context.Context, templateID string) err
 		return template.ErrInvalidTemplateID
 	}
 
+	// only administrators manage templates
+	err := s.authorize(ctx)
+	if err != nil {
+		return err
+	}
+
 	// get pg store client using transaction
-	pgStoreClient, err := s.pgStore.NewClient(false)
+	pgStoreClient, err := s.pgStore.NewClient(ctx, true)
 	if err != nil {
 		return err
 	}
 
+	// refuse to delete a template still used by a content,
+	// so the content is not left without its template
+	inUse, err := pgStoreClient.IsTemplateInUse(ctx, templateID)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return err
+	}
+	if inUse {
+		pgStoreClient.Rollback()
+		return template.ErrTemplateInUse
+	}
+
 	// delete template in pgstore
-	err = pgStoreClient.DeleteTemplateByID(ctx, templateID)
+	err = pgStoreClient.DeleteTemplateByID(ctx, templateID, s.timeNow())
+	if err != nil {
+		pgStoreClient.Rollback()
+		return err
+	}
+
+	return pgStoreClient.Commit()
+}
+
+// RestoreTemplateByID restores a deleted template with
+// the given template id from trash.
+func (s *service) RestoreTemplateByID(ctx context.Context, templateID string) error {
+	// validate id
+	if templateID == "" {
+		return template.ErrInvalidTemplateID
+	}
+
+	// only administrators manage templates
+	err := s.authorize(ctx)
+	if err != nil {
+		return err
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
 	if err != nil {
 		return err
 	}
 
+	// restore template in pgstore
+	return pgStoreClient.RestoreTemplateByID(ctx, templateID)
+}
+
+// PurgeDeletedTemplates permanently deletes templates
+// that were deleted before the given time and returns the
+// number of purged templates.
+func (s *service) PurgeDeletedTemplates(ctx context.Context, before time.Time) (int64, error) {
+	// the purge job runs without a user, a user purging
+	// templates must be an administrator
+	if _, ok := contextlib.GetUserID(ctx); ok {
+		err := s.authorize(ctx)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return 0, err
+	}
+
+	// purge templates in pgstore
+	return pgStoreClient.PurgeDeletedTemplates(ctx, before)
+}
+
+// authorize returns nil if the user of the request is an
+// administrator.
+func (s *service) authorize(ctx context.Context) error {
+	userID, ok := contextlib.GetUserID(ctx)
+	if !ok {
+		return template.ErrInvalidUserID
+	}
+
+	caller, err := s.user.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !caller.IsAdmin() {
+		return template.ErrForbidden
+	}
+
 	return nil
 }
 
@@ -142,5 +296,104 @@ func validateTemplate(reqTemplate template.Template) error {
 	if _, valid := template.LabelList[reqTemplate.Label]; !valid {
 		return template.ErrInvalidTemplateLabel
 	}
+
+	if _, valid := template.CategoryList[reqTemplate.Category]; !valid {
+		return template.ErrInvalidTemplateCategory
+	}
+
+	if len(reqTemplate.Tags) > maxTags {
+		return template.ErrInvalidTemplateTag
+	}
+
+	for _, tag := range reqTemplate.Tags {
+		if tag == "" || len(tag) > maxTagLength {
+			return template.ErrInvalidTemplateTag
+		}
+	}
+
+	if reqTemplate.FeaturedOrder < 0 {
+		return template.ErrInvalidTemplateFeaturedOrder
+	}
+
+	if reqTemplate.Price < 0 {
+		return template.ErrInvalidTemplatePrice
+	}
+
+	// currency is only required to sell the template
+	if reqTemplate.Currency != "" || reqTemplate.Price > 0 {
+		if !money.IsCurrency(reqTemplate.Currency) {
+			return template.ErrInvalidTemplateCurrency
+		}
+	}
+
+	return nil
+}
+
+// Followings are the limits of template tags.
+const (
+	maxTags      = 10
+	maxTagLength = 32
+)
+
+// normalizeTags returns the given tags in lower case without
+// surrounding spaces and duplicates, so they can be matched
+// exactly.
+func normalizeTags(tags []string) []string {
+	result := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		result = append(result, tag)
+	}
+	return result
+}
+
+// validateThumbnailMediaID validates that the thumbnail media
+// referenced by the given template, if any, exists and is an
+// image.
+func (s *service) validateThumbnailMediaID(ctx context.Context, reqTemplate template.Template) error {
+	if reqTemplate.ThumbnailMediaID == "" {
+		return nil
+	}
+
+	m, err := s.media.GetMediaByID(ctx, reqTemplate.ThumbnailMediaID)
+	if err != nil {
+		if err == media.ErrDataNotFound || err == media.ErrInvalidMediaID {
+			return template.ErrInvalidTemplateThumbnailMediaID
+		}
+		return err
+	}
+
+	if !m.IsImage() || m.Status == media.StatusFailed {
+		return template.ErrInvalidTemplateThumbnailMediaID
+	}
+
+	return nil
+}
+
+// resolveThumbnailURL fills thumbnail URL of the given
+// template with the URL of its thumbnail media variant, if
+// any.
+func (s *service) resolveThumbnailURL(ctx context.Context, t *template.Template) error {
+	if t.ThumbnailMediaID == "" {
+		return nil
+	}
+
+	m, err := s.media.GetMediaByID(ctx, t.ThumbnailMediaID)
+	if err != nil {
+		if err == media.ErrDataNotFound {
+			return nil
+		}
+		return err
+	}
+
+	if variant, ok := m.GetVariant(media.VariantThumbnail); ok && variant.URL != "" {
+		t.ThumbnailURL = variant.URL
+	}
+
 	return nil
 }
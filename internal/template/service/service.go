@@ -1,19 +1,25 @@
 package service
 
 import (
+	"hbdtoyou/internal/auth"
+	"hbdtoyou/internal/media"
 	"time"
 )
 
 // service implements subject.Service.
 type service struct {
 	pgStore PGStore
+	media   media.Service
+	user    auth.Service
 	timeNow func() time.Time
 }
 
 // New creates a new service.
-func New(pgStore PGStore) (*service, error) {
+func New(pgStore PGStore, media media.Service, user auth.Service) (*service, error) {
 	s := &service{
 		pgStore: pgStore,
+		media:   media,
+		user:    user,
 		timeNow: time.Now,
 	}
 
@@ -3,10 +3,11 @@ package service
 import (
 	"context"
 	"hbdtoyou/internal/template"
+	"time"
 )
 
 type PGStore interface {
-	NewClient(useTx bool) (PGStoreClient, error)
+	NewClient(ctx context.Context, useTx bool) (PGStoreClient, error)
 }
 
 type PGStoreClient interface {
@@ -23,9 +24,14 @@ type PGStoreClient interface {
 	// template ID.
 	GetTemplateByID(ctx context.Context, templateID string) (template.Template, error)
 
-	// GetTemplates returns all templates.
+	// GetTemplates returns a page of templates matching the
+	// given filter.
 	GetTemplates(ctx context.Context, filter template.GetTemplatesFilter) ([]template.Template, error)
 
+	// CountTemplates returns the number of templates matching
+	// the given filter.
+	CountTemplates(ctx context.Context, filter template.GetTemplatesFilter) (int, error)
+
 	// UpdateTemplate updates existing template
 	// with the given template data.
 	//
@@ -36,6 +42,21 @@ type PGStoreClient interface {
 	UpdateTemplate(ctx context.Context, reqTemplate template.Template) error
 
 	// DeleteTemplate delete a template
-	// with the given template id.
-	DeleteTemplateByID(ctx context.Context, templateID string) error
+	// with the given template id by setting its delete time.
+	DeleteTemplateByID(ctx context.Context, templateID string, deleteTime time.Time) error
+
+	// RestoreTemplateByID restores a deleted template with
+	// the given template id.
+	RestoreTemplateByID(ctx context.Context, templateID string) error
+
+	// PurgeDeletedTemplates permanently deletes templates
+	// that were deleted before the given time and not
+	// referenced by any content, then returns the number of
+	// purged templates.
+	PurgeDeletedTemplates(ctx context.Context, before time.Time) (int64, error)
+
+	// IsTemplateInUse checks whether a template with the
+	// given template id is used by a content that is not
+	// deleted.
+	IsTemplateInUse(ctx context.Context, templateID string) (bool, error)
 }
@@ -6,17 +6,26 @@ import (
 	"fmt"
 	"hbdtoyou/internal/template"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 func (sc *storeClient) CreateTemplate(ctx context.Context, reqTemplate template.Template) (string, error) {
 	// construct arguments filled with fields for the query
 	argKV := map[string]interface{}{
-		"name":          reqTemplate.Name,
-		"label":         reqTemplate.Label,
-		"thumbnail_uri": reqTemplate.ThumbnailURI,
-		"create_time":   reqTemplate.CreateTime,
+		"name":               reqTemplate.Name,
+		"description":        reqTemplate.Description,
+		"label":              reqTemplate.Label,
+		"category":           reqTemplate.Category,
+		"tags":               pq.Array(reqTemplate.Tags),
+		"thumbnail_uri":      reqTemplate.ThumbnailURI,
+		"thumbnail_media_id": nullString(reqTemplate.ThumbnailMediaID),
+		"featured_order":     nullInt(reqTemplate.FeaturedOrder),
+		"price":              reqTemplate.Price,
+		"currency":           reqTemplate.Currency,
+		"create_time":        reqTemplate.CreateTime,
 	}
 
 	// prepare query
@@ -41,7 +50,7 @@ func (sc *storeClient) CreateTemplate(ctx context.Context, reqTemplate template.
 }
 
 func (sc *storeClient) GetTemplateByID(ctx context.Context, templateID string) (template.Template, error) {
-	query := fmt.Sprintf(queryGetTemplate, "WHERE t.id = $1")
+	query := fmt.Sprintf(queryGetTemplate, "WHERE t.id = $1 AND t.delete_time IS NULL")
 
 	// query single row
 	var model templateModel
@@ -58,25 +67,24 @@ func (sc *storeClient) GetTemplateByID(ctx context.Context, templateID string) (
 
 func (sc *storeClient) GetTemplates(ctx context.Context, filter template.GetTemplatesFilter) ([]template.Template, error) {
 	// define variables to custom query
-	argKV := make(map[string]interface{})
-	conditions := make([]string, 0)
+	condition, argKV := getTemplatesCondition(filter)
 
-	if filter.Label > 0 {
-		conditions = append(conditions, "t.label = :label")
-		argKV["label"] = filter.Label
+	// featured templates come first by default, ties are
+	// broken by ID so the pages are stable
+	order := "t.featured_order ASC NULLS LAST, t.create_time DESC, t.id"
+	switch filter.Sort {
+	case template.SortNewest:
+		order = "t.create_time DESC, t.id"
+	case template.SortRelevance:
+		order = "ts_rank(t.search_vector, websearch_to_tsquery('simple', :query)) DESC, t.create_time DESC, t.id"
 	}
 
-	// construct strings to custom query
-	condition := strings.Join(conditions, " AND ")
-
-	// since the query does not contains "WHERE" yet, need
-	// to add it if needed
-	if len(conditions) > 0 {
-		condition = fmt.Sprintf("WHERE %s", condition)
-	}
+	page, limit := filter.PageLimit()
+	argKV["limit"] = limit
+	argKV["offset"] = (page - 1) * limit
 
 	// construct query
-	query := fmt.Sprintf(queryGetTemplate, condition)
+	query := fmt.Sprintf(queryGetTemplate, fmt.Sprintf("%s ORDER BY %s LIMIT :limit OFFSET :offset", condition, order))
 
 	// prepare query
 	query, args, err := sqlx.Named(query, argKV)
@@ -116,14 +124,96 @@ func (sc *storeClient) GetTemplates(ctx context.Context, filter template.GetTemp
 	return result, nil
 }
 
+func (sc *storeClient) CountTemplates(ctx context.Context, filter template.GetTemplatesFilter) (int, error) {
+	// define variables to custom query
+	condition, argKV := getTemplatesCondition(filter)
+
+	// construct query
+	query := fmt.Sprintf(queryCountTemplate, condition)
+
+	// prepare query
+	query, args, err := sqlx.Named(query, argKV)
+	if err != nil {
+		return 0, err
+	}
+
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	query = sc.q.Rebind(query)
+
+	// query single row
+	var total int
+	err = sc.q.QueryRowx(query, args...).Scan(&total)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// getTemplatesCondition returns the WHERE clause and its
+// arguments matching templates with the given filter.
+func getTemplatesCondition(filter template.GetTemplatesFilter) (string, map[string]interface{}) {
+	argKV := make(map[string]interface{})
+	conditions := make([]string, 0)
+
+	// deleted templates are only listed in trash
+	if filter.Deleted {
+		conditions = append(conditions, "t.delete_time IS NOT NULL")
+	} else {
+		conditions = append(conditions, "t.delete_time IS NULL")
+	}
+
+	if filter.Label > 0 {
+		conditions = append(conditions, "t.label = :label")
+		argKV["label"] = filter.Label
+	}
+
+	if filter.Category > 0 {
+		conditions = append(conditions, "t.category = :category")
+		argKV["category"] = filter.Category
+	}
+
+	if filter.Tag != "" {
+		conditions = append(conditions, ":tag = ANY(t.tags)")
+		argKV["tag"] = filter.Tag
+	}
+
+	if filter.Query != "" {
+		conditions = append(conditions, "t.search_vector @@ websearch_to_tsquery('simple', :query)")
+		argKV["query"] = filter.Query
+	}
+
+	// construct strings to custom query
+	condition := strings.Join(conditions, " AND ")
+
+	// since the query does not contains "WHERE" yet, need
+	// to add it if needed
+	if len(conditions) > 0 {
+		condition = fmt.Sprintf("WHERE %s", condition)
+	}
+
+	return condition, argKV
+}
+
 func (sc *storeClient) UpdateTemplate(ctx context.Context, reqTemplate template.Template) error {
 	// construct arguments filled with fields for the query
 	argsKV := map[string]interface{}{
-		"id":            reqTemplate.ID,
-		"name":          reqTemplate.Name,
-		"label":         reqTemplate.Label,
-		"thumbnail_uri": reqTemplate.ThumbnailURI,
-		"update_time":   reqTemplate.UpdateTime,
+		"id":                 reqTemplate.ID,
+		"name":               reqTemplate.Name,
+		"description":        reqTemplate.Description,
+		"label":              reqTemplate.Label,
+		"category":           reqTemplate.Category,
+		"tags":               pq.Array(reqTemplate.Tags),
+		"thumbnail_uri":      reqTemplate.ThumbnailURI,
+		"thumbnail_media_id": nullString(reqTemplate.ThumbnailMediaID),
+		"featured_order":     nullInt(reqTemplate.FeaturedOrder),
+		"price":              reqTemplate.Price,
+		"currency":           reqTemplate.Currency,
+		"version":            reqTemplate.Version,
+		"update_time":        reqTemplate.UpdateTime,
 	}
 
 	// prepare query
@@ -138,14 +228,28 @@ func (sc *storeClient) UpdateTemplate(ctx context.Context, reqTemplate template.
 	query = sc.q.Rebind(query)
 
 	// execute query
-	_, err = sc.q.Exec(query, args...)
-	return err
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	// nothing is updated when the version is outdated
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return template.ErrVersionConflict
+	}
+
+	return nil
 }
 
-func (sc *storeClient) DeleteTemplateByID(ctx context.Context, templateID string) error {
+func (sc *storeClient) DeleteTemplateByID(ctx context.Context, templateID string, deleteTime time.Time) error {
 	// construct arguments filled with fields for the query
 	argsKV := map[string]interface{}{
-		"id": templateID,
+		"id":          templateID,
+		"delete_time": deleteTime,
 	}
 
 	// prepare query
@@ -160,10 +264,109 @@ func (sc *storeClient) DeleteTemplateByID(ctx context.Context, templateID string
 	query = sc.q.Rebind(query)
 
 	// execute query
-	_, err = sc.q.Exec(query, args...)
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	// nothing is deleted when the template does not exist or
+	// is already deleted
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return template.ErrTemplateNotFound
+	}
+
+	return nil
+}
+
+func (sc *storeClient) RestoreTemplateByID(ctx context.Context, templateID string) error {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"id": templateID,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryRestoreTemplate, argsKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	// nothing is restored when the template is not in trash
+	affected, err := res.RowsAffected()
 	if err != nil {
 		return err
 	}
+	if affected == 0 {
+		return template.ErrTemplateNotFound
+	}
 
 	return nil
 }
+
+func (sc *storeClient) PurgeDeletedTemplates(ctx context.Context, before time.Time) (int64, error) {
+	// construct arguments filled with fields for the query
+	argsKV := map[string]interface{}{
+		"before": before,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryPurgeTemplates, argsKV)
+	if err != nil {
+		return 0, err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	query = sc.q.Rebind(query)
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (sc *storeClient) IsTemplateInUse(ctx context.Context, templateID string) (bool, error) {
+	var inUse bool
+	err := sc.q.QueryRowx(queryIsTemplateInUse, templateID).Scan(&inUse)
+	if err != nil {
+		return false, err
+	}
+
+	return inUse, nil
+}
+
+// nullString returns nil for an empty string, so it is stored
+// as NULL in the database.
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// nullInt returns nil for a zero int, so it is stored as
+// NULL in the database.
+func nullInt(i int) interface{} {
+	if i == 0 {
+		return nil
+	}
+	return i
+}
@@ -5,15 +5,25 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type templateModel struct {
-	ID           uuid.UUID      `db:"id"`
-	Name         string         `db:"name"`
-	Label        template.Label `db:"label"`
-	ThumbnailURI string         `db:"thumbnail_uri"`
-	CreateTime   time.Time      `db:"create_time"`
-	UpdateTime   *time.Time     `db:"update_time"`
+	ID               uuid.UUID         `db:"id"`
+	Name             string            `db:"name"`
+	Description      string            `db:"description"`
+	Label            template.Label    `db:"label"`
+	Category         template.Category `db:"category"`
+	Tags             pq.StringArray    `db:"tags"`
+	ThumbnailURI     string            `db:"thumbnail_uri"`
+	ThumbnailMediaID *string           `db:"thumbnail_media_id"`
+	FeaturedOrder    *int              `db:"featured_order"`
+	Price            int64             `db:"price"`
+	Currency         string            `db:"currency"`
+	Version          int64             `db:"version"`
+	CreateTime       time.Time         `db:"create_time"`
+	UpdateTime       *time.Time        `db:"update_time"`
+	DeleteTime       *time.Time        `db:"delete_time"`
 }
 
 // format formats database struct into domain struct.
@@ -21,14 +31,32 @@ func (dbData *templateModel) format() template.Template {
 	t := template.Template{
 		ID:           dbData.ID.String(),
 		Name:         dbData.Name,
+		Description:  dbData.Description,
 		Label:        dbData.Label,
+		Category:     dbData.Category,
+		Tags:         dbData.Tags,
 		ThumbnailURI: dbData.ThumbnailURI,
+		Price:        dbData.Price,
+		Currency:     dbData.Currency,
+		Version:      dbData.Version,
 		CreateTime:   dbData.CreateTime,
 	}
 
+	if dbData.FeaturedOrder != nil {
+		t.FeaturedOrder = *dbData.FeaturedOrder
+	}
+
+	if dbData.ThumbnailMediaID != nil {
+		t.ThumbnailMediaID = *dbData.ThumbnailMediaID
+	}
+
 	if dbData.UpdateTime != nil {
 		t.UpdateTime = *dbData.UpdateTime
 	}
 
+	if dbData.DeleteTime != nil {
+		t.DeleteTime = *dbData.DeleteTime
+	}
+
 	return t
 }
@@ -6,15 +6,29 @@ const (
 			template
 			(
 				name,
+				description,
 				label,
+				category,
+				tags,
 				thumbnail_uri,
+				thumbnail_media_id,
+				featured_order,
+				price,
+				currency,
 				create_time
 			)
 		VALUES
 			(
 				:name,
+				:description,
 				:label,
+				:category,
+				:tags,
 				:thumbnail_uri,
+				:thumbnail_media_id,
+				:featured_order,
+				:price,
+				:currency,
 				:create_time
 			)
 		RETURNING
@@ -25,14 +39,30 @@ const (
 		SELECT
 			t.id,
 			t.name,
+			t.description,
 			t.label,
+			t.category,
+			t.tags,
 			t.thumbnail_uri,
+			t.thumbnail_media_id,
+			t.featured_order,
+			t.price,
+			t.currency,
+			t.version,
 			t.create_time,
-			t.update_time
+			t.update_time,
+			t.delete_time
 		FROM
 			template t
-		WHERE
-			id = :id
+		%s
+	`
+
+	queryCountTemplate = `
+		SELECT
+			COUNT(*)
+		FROM
+			template t
+		%s
 	`
 
 	queryUpdateTemplate = `
@@ -40,17 +70,72 @@ const (
 			template
 		SET
 			name = :name,
+			description = :description,
 			label = :label,
+			category = :category,
+			tags = :tags,
 			thumbnail_uri = :thumbnail_uri,
+			thumbnail_media_id = :thumbnail_media_id,
+			featured_order = :featured_order,
+			price = :price,
+			currency = :currency,
+			version = version + 1,
 			update_time = :update_time
 		WHERE
-			id = :id 
+			id = :id
+		AND
+			version = :version
+		AND
+			delete_time IS NULL
 	`
 
 	queryDeleteTemplate = `
-		DELETE FROM
+		UPDATE
 			template
+		SET
+			delete_time = :delete_time
 		WHERE
 			id = :id
+		AND
+			delete_time IS NULL
+	`
+
+	queryRestoreTemplate = `
+		UPDATE
+			template
+		SET
+			delete_time = NULL
+		WHERE
+			id = :id
+		AND
+			delete_time IS NOT NULL
+	`
+
+	queryPurgeTemplates = `
+		DELETE FROM
+			template t
+		WHERE
+			t.delete_time < :before
+		AND NOT EXISTS (
+			SELECT
+				1
+			FROM
+				content c
+			WHERE
+				c.template_id = t.id
+		)
+	`
+
+	queryIsTemplateInUse = `
+		SELECT EXISTS (
+			SELECT
+				1
+			FROM
+				content c
+			WHERE
+				c.template_id = $1
+			AND
+				c.delete_time IS NULL
+		)
 	`
 )
@@ -13,10 +13,15 @@ type Service interface {
 
 	// GetTemplateByID returns a template with the given
 	// template ID.
+	//
+	// If the template has a thumbnail media, ThumbnailURL is
+	// the URL of the media thumbnail variant.
 	GetTemplateByID(ctx context.Context, templateID string) (Template, error)
 
-	// GetTemplates returns all templates.
-	GetTemplates(ctx context.Context, filter GetTemplatesFilter) ([]Template, error)
+	// GetTemplates returns a page of templates matching the
+	// given filter, along with the total number of matching
+	// templates.
+	GetTemplates(ctx context.Context, filter GetTemplatesFilter) ([]Template, int, error)
 
 	// UpdateTemplate updates existing template
 	// with the given template data.
@@ -25,24 +30,198 @@ type Service interface {
 	// except ID, and CreateTime. So, make sure to
 	// use current values in the given data if do not want to
 	// update some specific attributes.
+	//
+	// The given version must be the current version of the
+	// template, otherwise ErrVersionConflict is returned.
 	UpdateTemplate(ctx context.Context, reqTemplate Template) error
 
 	// DeleteTemplate delete a template
 	// with the given template id.
+	//
+	// The template is moved to trash and can be restored
+	// until it is purged. A template that is still used by
+	// a content can not be deleted.
 	DeleteTemplateByID(ctx context.Context, templateID string) error
+
+	// RestoreTemplateByID restores a deleted template with
+	// the given template id from trash.
+	RestoreTemplateByID(ctx context.Context, templateID string) error
+
+	// PurgeDeletedTemplates permanently deletes templates
+	// that were deleted before the given time and returns the
+	// number of purged templates.
+	//
+	// Templates still referenced by a content in trash are
+	// kept until the content is purged.
+	PurgeDeletedTemplates(ctx context.Context, before time.Time) (int64, error)
 }
 
 type GetTemplatesFilter struct {
-	Label Label
+	Label    Label
+	Category Category
+	Tag      string
+
+	// Query searches templates by name and description.
+	Query string
+
+	Sort Sort
+
+	// Page starts from 1. Zero values of Page and Limit use
+	// the defaults.
+	Page  int
+	Limit int
+
+	// Deleted returns templates in trash instead.
+	Deleted bool
+}
+
+// Followings are the page size limits of GetTemplates.
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// PageLimit returns the requested page and limit of the
+// filter, with defaults applied and the limit capped to
+// MaxLimit.
+func (f GetTemplatesFilter) PageLimit() (int, int) {
+	page, limit := f.Page, f.Limit
+	if page <= 0 {
+		page = 1
+	}
+
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	return page, limit
 }
 
 type Template struct {
-	ID           string
-	Name         string
-	Label        Label
-	ThumbnailURI string
-	CreateTime   time.Time
-	UpdateTime   time.Time
+	ID               string
+	Name             string
+	Description      string
+	Label            Label
+	Category         Category
+	Tags             []string
+	ThumbnailURI     string
+	ThumbnailMediaID string
+
+	// ThumbnailURL is the signed URL of the thumbnail variant
+	// of the thumbnail media, resolved on read. It expires, so
+	// it is never stored.
+	ThumbnailURL string
+
+	// FeaturedOrder positions the template in the featured
+	// sort order, lower comes first. Zero means the template
+	// is not featured.
+	FeaturedOrder int
+
+	// Price is the price to purchase the template in the
+	// minor unit of Currency. Zero means the template is free
+	// unless it is labeled premium.
+	Price    int64
+	Currency string
+
+	Version    int64
+	CreateTime time.Time
+	UpdateTime time.Time
+	DeleteTime time.Time
+}
+
+// GetThumbnailURI returns the URI to present the thumbnail of
+// the template, the signed URL of the thumbnail media if any.
+//
+// The stored thumbnail URI is used when the media is gone or
+// not processed yet.
+func (t Template) GetThumbnailURI() string {
+	if t.ThumbnailURL != "" {
+		return t.ThumbnailURL
+	}
+	return t.ThumbnailURI
+}
+
+// IsPaid returns whether the template requires an
+// entitlement to be used.
+func (t Template) IsPaid() bool {
+	return t.Label == LabelPremium || t.Price > 0
+}
+
+// Category denotes the occasion a template is made for.
+type Category int
+
+// Following constans are the known categories.
+const (
+	CategoryUnknown     Category = 0
+	CategoryBirthday    Category = 1
+	CategoryAnniversary Category = 2
+	CategoryGraduation  Category = 3
+	CategoryWedding     Category = 4
+	CategoryOther       Category = 5
+)
+
+var (
+	// CategoryList is a list of valid template category.
+	CategoryList = map[Category]struct{}{
+		CategoryBirthday:    {},
+		CategoryAnniversary: {},
+		CategoryGraduation:  {},
+		CategoryWedding:     {},
+		CategoryOther:       {},
+	}
+
+	// CategoryName maps template category to it's string
+	// representation.
+	CategoryName = map[Category]string{
+		CategoryBirthday:    "birthday",
+		CategoryAnniversary: "anniversary",
+		CategoryGraduation:  "graduation",
+		CategoryWedding:     "wedding",
+		CategoryOther:       "other",
+	}
+)
+
+// String implements the Stringer interface.
+func (c Category) String() string {
+	return CategoryName[c]
+}
+
+// Value implements the Valuer interface.
+func (c Category) Value() int {
+	return int(c)
+}
+
+// Sort denotes the order of listed templates.
+type Sort int
+
+// Following constans are the known sort orders.
+const (
+	// SortFeatured lists featured templates first, then the
+	// newest ones.
+	SortFeatured Sort = 0
+	SortNewest   Sort = 1
+
+	// SortRelevance lists templates best matching the search
+	// query first. It is only applicable with a search query.
+	SortRelevance Sort = 2
+)
+
+var (
+	// SortName maps sort order to it's string representation.
+	SortName = map[Sort]string{
+		SortFeatured:  "featured",
+		SortNewest:    "newest",
+		SortRelevance: "relevance",
+	}
+)
+
+// String implements the Stringer interface.
+func (s Sort) String() string {
+	return SortName[s]
 }
 
 // Label denotes the label of content.
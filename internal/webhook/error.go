@@ -0,0 +1,41 @@
+package webhook
+
+import "errors"
+
+var (
+	// ErrDataNotFound is returned when the desired data is
+	// not found.
+	ErrDataNotFound = errors.New("data not found")
+
+	// ErrForbidden is returned when the user is not allowed
+	// to access the requested data.
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrInvalidUserID is returned when the given user ID is
+	// invalid.
+	ErrInvalidUserID = errors.New("invalid user id")
+
+	// ErrInvalidWebhookID is returned when the given webhook
+	// ID is invalid.
+	ErrInvalidWebhookID = errors.New("invalid webhook id")
+
+	// ErrInvalidWebhookURL is returned when the given webhook
+	// URL is not an absolute HTTPS URL.
+	ErrInvalidWebhookURL = errors.New("invalid webhook url")
+
+	// ErrInvalidWebhookSecret is returned when the given
+	// webhook secret is too short.
+	ErrInvalidWebhookSecret = errors.New("invalid webhook secret")
+
+	// ErrInvalidEventType is returned when the given event
+	// types are empty or contain an unknown one.
+	ErrInvalidEventType = errors.New("invalid event type")
+
+	// ErrInvalidWebhookStatus is returned when the given
+	// webhook status is invalid.
+	ErrInvalidWebhookStatus = errors.New("invalid webhook status")
+
+	// ErrInvalidDeliveryID is returned when the given
+	// delivery ID is invalid.
+	ErrInvalidDeliveryID = errors.New("invalid delivery id")
+)
@@ -0,0 +1,37 @@
+package event
+
+import (
+	"context"
+	"hbdtoyou/internal/webhook"
+	eventlib "hbdtoyou/pkg/event"
+)
+
+// Handler contains webhook event handlers.
+type Handler struct {
+	webhook webhook.Service
+}
+
+// New creates a new Handler and subscribes its handlers to
+// the given subscriber.
+func New(webhookSvc webhook.Service, subscriber eventlib.Subscriber) (*Handler, error) {
+	h := &Handler{
+		webhook: webhookSvc,
+	}
+
+	// every event type webhooks can subscribe to is enqueued,
+	// the subscriptions are matched by the service
+	for eventType := range webhook.EventTypeList {
+		err := subscriber.Subscribe(eventType, "webhook.enqueue", h.enqueue)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return h, nil
+}
+
+// enqueue creates deliveries of the given event to the
+// subscribed webhooks.
+func (h *Handler) enqueue(ctx context.Context, e eventlib.Event) error {
+	return h.webhook.EnqueueEvent(ctx, e)
+}
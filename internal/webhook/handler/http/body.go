@@ -0,0 +1,145 @@
+package http
+
+import (
+	"encoding/json"
+	"hbdtoyou/internal/webhook"
+	"time"
+)
+
+// timeFormat denotes the standard time format used in webhook
+// HTTP handlers.
+var timeFormat = "02/01/2006 3:04 PM -07:00"
+
+// webhookHTTP denotes a webhook. Secret is only read from
+// requests, it is never returned.
+type webhookHTTP struct {
+	ID           *string   `json:"id"`
+	URL          *string   `json:"url"`
+	Secret       *string   `json:"secret,omitempty"`
+	EventTypes   *[]string `json:"event_types"`
+	Status       *string   `json:"status"`
+	FailureCount *int      `json:"failure_count"`
+	DisableTime  *string   `json:"disable_time"`
+	CreatedBy    *string   `json:"created_by"`
+	CreateTime   *string   `json:"create_time"`
+	UpdateTime   *string   `json:"update_time"`
+}
+
+func formatWebhook(w webhook.Webhook) webhookHTTP {
+	status := w.Status.String()
+
+	eventTypes := w.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	return webhookHTTP{
+		ID:           &w.ID,
+		URL:          &w.URL,
+		EventTypes:   &eventTypes,
+		Status:       &status,
+		FailureCount: &w.FailureCount,
+		DisableTime:  formatTime(w.DisableTime),
+		CreatedBy:    &w.CreatedBy,
+		CreateTime:   formatTime(w.CreateTime),
+		UpdateTime:   formatTime(w.UpdateTime),
+	}
+}
+
+func (w webhookHTTP) parseWebhook(out *webhook.Webhook) error {
+	if w.URL != nil {
+		out.URL = *w.URL
+	}
+
+	if w.Secret != nil {
+		out.Secret = *w.Secret
+	}
+
+	if w.EventTypes != nil {
+		out.EventTypes = *w.EventTypes
+	}
+
+	if w.Status != nil {
+		status, err := parseWebhookStatus(*w.Status)
+		if err != nil {
+			return err
+		}
+		out.Status = status
+	}
+
+	return nil
+}
+
+func parseWebhookStatus(req string) (webhook.Status, error) {
+	switch req {
+	case webhook.StatusActive.String():
+		return webhook.StatusActive, nil
+	case webhook.StatusDisabled.String():
+		return webhook.StatusDisabled, nil
+	}
+
+	return webhook.StatusUnknown, errInvalidWebhookStatus
+}
+
+// deliveryHTTP denotes a delivery of an event to a webhook,
+// with the last response of the endpoint.
+type deliveryHTTP struct {
+	ID              *string         `json:"id"`
+	WebhookID       *string         `json:"webhook_id"`
+	EventID         *string         `json:"event_id"`
+	EventType       *string         `json:"event_type"`
+	Payload         json.RawMessage `json:"payload"`
+	Status          *string         `json:"status"`
+	Attempts        *int            `json:"attempts"`
+	NextAttemptTime *string         `json:"next_attempt_time"`
+	ResponseCode    *int            `json:"response_code"`
+	ResponseBody    *string         `json:"response_body"`
+	LastError       *string         `json:"last_error"`
+	RedeliveryOf    *string         `json:"redelivery_of"`
+	CreateTime      *string         `json:"create_time"`
+	DeliverTime     *string         `json:"deliver_time"`
+}
+
+func formatDelivery(d webhook.Delivery) deliveryHTTP {
+	status := d.Status.String()
+
+	res := deliveryHTTP{
+		ID:           &d.ID,
+		WebhookID:    &d.WebhookID,
+		EventID:      &d.EventID,
+		EventType:    &d.EventType,
+		Payload:      json.RawMessage(d.Payload),
+		Status:       &status,
+		Attempts:     &d.Attempts,
+		ResponseBody: &d.ResponseBody,
+		LastError:    &d.LastError,
+		CreateTime:   formatTime(d.CreateTime),
+		DeliverTime:  formatTime(d.DeliverTime),
+	}
+
+	// the next attempt is only meaningful while pending
+	if d.Status == webhook.DeliveryStatusPending {
+		res.NextAttemptTime = formatTime(d.NextAttemptTime)
+	}
+
+	if d.ResponseCode != 0 {
+		res.ResponseCode = &d.ResponseCode
+	}
+
+	if d.RedeliveryOf != "" {
+		res.RedeliveryOf = &d.RedeliveryOf
+	}
+
+	return res
+}
+
+// formatTime returns the given time formatted in timeFormat,
+// or nil for a zero time.
+func formatTime(t time.Time) *string {
+	if t.IsZero() {
+		return nil
+	}
+
+	formatted := t.Format(timeFormat)
+	return &formatted
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"errors"
+	"hbdtoyou/internal/webhook"
+)
+
+// Followings are the known errors from Webhook HTTP handlers.
+var (
+	// errBadRequest is returned when the given request is
+	// bad/invalid.
+	errBadRequest = errors.New("BAD_REQUEST")
+
+	// errInternalServer is returned when there is an
+	// unexpected error encountered when processing a request.
+	errInternalServer = errors.New("INTERNAL_SERVER_ERROR")
+
+	// errDataNotFound is returned when the desired data is
+	// not found.
+	errDataNotFound = errors.New("DATA_NOT_FOUND")
+
+	// errForbidden is returned when the user is not allowed
+	// to access the requested data.
+	errForbidden = errors.New("FORBIDDEN")
+
+	// errInvalidToken is returned when the given token is
+	// invalid.
+	errInvalidToken = errors.New("INVALID_TOKEN")
+
+	// errInvalidUserID is returned when the given user ID is
+	// invalid.
+	errInvalidUserID = errors.New("INVALID_USER_ID")
+
+	// errInvalidWebhookID is returned when the given webhook
+	// ID is invalid.
+	errInvalidWebhookID = errors.New("INVALID_WEBHOOK_ID")
+
+	// errInvalidWebhookURL is returned when the given webhook
+	// URL is invalid.
+	errInvalidWebhookURL = errors.New("INVALID_WEBHOOK_URL")
+
+	// errInvalidWebhookSecret is returned when the given
+	// webhook secret is invalid.
+	errInvalidWebhookSecret = errors.New("INVALID_WEBHOOK_SECRET")
+
+	// errInvalidEventType is returned when the given event
+	// types are invalid.
+	errInvalidEventType = errors.New("INVALID_EVENT_TYPE")
+
+	// errInvalidWebhookStatus is returned when the given
+	// webhook status is invalid.
+	errInvalidWebhookStatus = errors.New("INVALID_WEBHOOK_STATUS")
+
+	// errInvalidDeliveryID is returned when the given
+	// delivery ID is invalid.
+	errInvalidDeliveryID = errors.New("INVALID_DELIVERY_ID")
+
+	// errMethodNotAllowed is returned when accessing not
+	// allowed HTTP method.
+	errMethodNotAllowed = errors.New("METHOD_NOT_ALLOWED")
+
+	// errRequestTimeout is returned when processing time has
+	// reached the timeout limit.
+	errRequestTimeout = errors.New("REQUEST_TIMEOUT")
+
+	// errSourceNotProvided is returned when there is no
+	// source provided in the request.
+	errSourceNotProvided = errors.New("SOURCE_NOT_PROVIDED")
+
+	// errUnauthorizedAccess is returned when the request
+	// is unaothorized.
+	errUnauthorizedAccess = errors.New("UNAUTHORIZED_ACCESS")
+)
+
+var (
+	// mapHTTPError maps service error into HTTP error that
+	// categorize as bad request error.
+	//
+	// Internal server error-related should not be mapped here,
+	// and the handler should just return `errInternal` as the
+	// error instead
+	mapHTTPError = map[error]error{
+		webhook.ErrDataNotFound:         errDataNotFound,
+		webhook.ErrForbidden:            errForbidden,
+		webhook.ErrInvalidUserID:        errInvalidUserID,
+		webhook.ErrInvalidWebhookID:     errInvalidWebhookID,
+		webhook.ErrInvalidWebhookURL:    errInvalidWebhookURL,
+		webhook.ErrInvalidWebhookSecret: errInvalidWebhookSecret,
+		webhook.ErrInvalidEventType:     errInvalidEventType,
+		webhook.ErrInvalidWebhookStatus: errInvalidWebhookStatus,
+		webhook.ErrInvalidDeliveryID:    errInvalidDeliveryID,
+	}
+)
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"hbdtoyou/internal/auth"
+	"hbdtoyou/internal/webhook"
+	"net/http"
+
+	httplib "hbdtoyou/pkg/http"
+
+	"github.com/gorilla/mux"
+)
+
+type webhooksHandler struct {
+	webhook       webhook.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *webhooksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetWebhooks(w, r)
+	case http.MethodPost:
+		h.handleCreateWebhook(w, r)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type webhookHandler struct {
+	webhook       webhook.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *webhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	webhookID := vars["id"]
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetWebhookByID(w, r, webhookID)
+	case http.MethodPatch:
+		h.handleUpdateWebhook(w, r, webhookID)
+	case http.MethodDelete:
+		h.handleDeleteWebhook(w, r, webhookID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type webhookDeliveriesHandler struct {
+	webhook       webhook.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *webhookDeliveriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	webhookID := vars["id"]
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleGetWebhookDeliveries(w, r, webhookID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
+
+type webhookRedeliverHandler struct {
+	webhook       webhook.Service
+	auth          auth.Service
+	scopeSettings map[Scope]ScopeSetting
+}
+
+func (h *webhookRedeliverHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	webhookID := vars["id"]
+	deliveryID := vars["delivery_id"]
+
+	switch r.Method {
+	case http.MethodPost:
+		h.handleRedeliverWebhookDelivery(w, r, webhookID, deliveryID)
+	default:
+		httplib.WriteErrorResponse(w, http.StatusMethodNotAllowed, []string{errMethodNotAllowed.Error()})
+	}
+}
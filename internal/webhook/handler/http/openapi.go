@@ -0,0 +1,40 @@
+package http
+
+import (
+	"hbdtoyou/pkg/openapi"
+	"net/http"
+)
+
+// routes defines the OpenAPI routes of each HTTP handler, it
+// must be updated along with the methods served by handler.go.
+var routes = map[string][]openapi.Route{
+	HandlerWebhooks.Name: {
+		{Method: http.MethodGet, Summary: "Get webhooks", Response: []webhookHTTP{}},
+		{Method: http.MethodPost, Summary: "Create webhook", Request: webhookHTTP{}, Response: ""},
+	},
+	HandlerWebhook.Name: {
+		{Method: http.MethodGet, Summary: "Get webhook by ID", Response: webhookHTTP{}},
+		{Method: http.MethodPatch, Summary: "Update webhook", Request: webhookHTTP{}, Response: ""},
+		{Method: http.MethodDelete, Summary: "Delete webhook", Response: ""},
+	},
+	HandlerWebhookDeliveries.Name: {
+		{Method: http.MethodGet, Summary: "Get webhook deliveries", Response: []deliveryHTTP{}},
+	},
+	HandlerWebhookRedeliver.Name: {
+		{Method: http.MethodPost, Summary: "Redeliver webhook delivery", Response: ""},
+	},
+}
+
+// Routes returns the OpenAPI routes of the registered HTTP
+// handlers.
+func (h *Handler) Routes() []openapi.Route {
+	var res []openapi.Route
+	for _, handler := range h.handlers {
+		for _, route := range routes[handler.identity.Name] {
+			route.Path = handler.identity.URL
+			route.Tag = "webhook"
+			res = append(res, route)
+		}
+	}
+	return res
+}
@@ -0,0 +1,111 @@
+package job
+
+import (
+	"context"
+	"hbdtoyou/internal/webhook"
+	joblib "hbdtoyou/pkg/job"
+	"log"
+	"time"
+)
+
+// Followings are default values for DeliverSetting fields.
+const (
+	defaultDeliverInterval = 10 * time.Second
+)
+
+// Handler contains webhook background jobs.
+type Handler struct {
+	webhook        webhook.Service
+	deliverSetting DeliverSetting
+	tenants        []string
+	runners        []*joblib.Runner
+}
+
+// DeliverSetting is the available configurations of the job
+// delivering pending webhook deliveries.
+type DeliverSetting struct {
+	// Interval is how often the job runs.
+	Interval time.Duration
+}
+
+// Option controls the behavior of Handler.
+type Option func(*Handler) error
+
+// WithDeliverSetting returns Option to set the deliver job
+// setting.
+func WithDeliverSetting(setting DeliverSetting) Option {
+	return Option(func(h *Handler) error {
+		if setting.Interval > 0 {
+			h.deliverSetting.Interval = setting.Interval
+		}
+		return nil
+	})
+}
+
+// WithTenants returns Option to run every job once for each
+// of the given tenants.
+func WithTenants(tenants []string) Option {
+	return Option(func(h *Handler) error {
+		h.tenants = tenants
+		return nil
+	})
+}
+
+// New creates a new Handler.
+func New(webhookSvc webhook.Service, options ...Option) (*Handler, error) {
+	h := &Handler{
+		webhook: webhookSvc,
+		deliverSetting: DeliverSetting{
+			Interval: defaultDeliverInterval,
+		},
+	}
+
+	// apply options
+	for _, opt := range options {
+		err := opt(h)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	deliverRunner, err := joblib.NewRunner("deliver_webhooks", h.deliverSetting.Interval, 0, joblib.ForEachTenant(h.tenants, h.deliverWebhooks))
+	if err != nil {
+		return nil, err
+	}
+	h.runners = append(h.runners, deliverRunner)
+
+	return h, nil
+}
+
+// Start starts all jobs.
+func (h *Handler) Start() error {
+	for _, runner := range h.runners {
+		if err := runner.Start(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop stops all jobs and waits until the running ones are
+// finished.
+func (h *Handler) Stop() {
+	for _, runner := range h.runners {
+		runner.Stop()
+	}
+}
+
+// deliverWebhooks sends pending webhook deliveries due at the
+// moment.
+func (h *Handler) deliverWebhooks(ctx context.Context) error {
+	succeeded, err := h.webhook.DeliverWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	if succeeded > 0 {
+		log.Printf("[Webhook Job][deliverWebhooks] Delivered %d webhooks\n", succeeded)
+	}
+
+	return nil
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hbdtoyou/internal/webhook"
+	"log"
+	"time"
+
+	"hbdtoyou/pkg/event"
+	webhooklib "hbdtoyou/pkg/webhook"
+)
+
+// payloadHTTP denotes the JSON body sent to webhooks.
+type payloadHTTP struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	CreateTime string          `json:"create_time"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// EnqueueEvent creates deliveries of the given event to the
+// active webhooks subscribed to its type.
+func (s *service) EnqueueEvent(ctx context.Context, e event.Event) error {
+	if _, valid := webhook.EventTypeList[e.Type]; !valid {
+		return nil
+	}
+
+	data := json.RawMessage(e.Payload)
+	if len(data) == 0 {
+		data = json.RawMessage("null")
+	}
+
+	// the payload is built once, so every webhook and every
+	// retry receives the same body
+	payload, err := json.Marshal(payloadHTTP{
+		ID:         e.ID,
+		Type:       e.Type,
+		CreateTime: e.CreateTime.UTC().Format(time.RFC3339),
+		Data:       data,
+	})
+	if err != nil {
+		return err
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	now := s.timeNow()
+	_, err = pgStoreClient.CreateEventDeliveries(ctx, webhook.Delivery{
+		EventID:         e.ID,
+		EventType:       e.Type,
+		Payload:         payload,
+		Status:          webhook.DeliveryStatusPending,
+		NextAttemptTime: now,
+		CreateTime:      now,
+	})
+
+	return err
+}
+
+// DeliverWebhooks sends pending deliveries due at the moment,
+// and returns the number of succeeded deliveries.
+//
+// The due deliveries are claimed in a short transaction and
+// sent outside of it, so no row is kept locked while waiting
+// for the receivers.
+func (s *service) DeliverWebhooks(ctx context.Context) (int, error) {
+	due, err := s.claimDueDeliveries(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var succeeded int
+	webhooks := make(map[string]webhook.Webhook)
+	for i := range due {
+		w, ok := webhooks[due[i].WebhookID]
+		if !ok {
+			w, err = s.getDeliveryWebhook(ctx, due[i].WebhookID)
+			if err != nil {
+				return succeeded, err
+			}
+			webhooks[w.ID] = w
+		}
+
+		// the webhook may be disabled by an earlier delivery
+		// of the batch, its deliveries are released and kept
+		// pending
+		if w.Status != webhook.StatusActive {
+			err = s.recordDelivery(ctx, due[i], nil)
+			if err != nil {
+				return succeeded, err
+			}
+			continue
+		}
+
+		now := s.timeNow()
+		success := s.deliver(ctx, w, &due[i], now)
+
+		result := &deliveryResult{success: success, now: now}
+		err = s.recordDelivery(ctx, due[i], result)
+		if err != nil {
+			return succeeded, err
+		}
+
+		if result.disabled {
+			w.Status = webhook.StatusDisabled
+			webhooks[w.ID] = w
+			log.Printf("[Webhook Service][DeliverWebhooks] Disabled webhook %s after %d consecutive failures\n", w.ID, s.config.DisableThreshold)
+		}
+
+		if success {
+			succeeded++
+		}
+	}
+
+	return succeeded, nil
+}
+
+// deliveryResult denotes the result of a sent delivery.
+type deliveryResult struct {
+	success bool
+	now     time.Time
+
+	// disabled is set once the result is recorded, whether
+	// the webhook is disabled by it.
+	disabled bool
+}
+
+// claimDueDeliveries returns pending deliveries due at the
+// moment, after postponing them by the claim timeout so
+// concurrent workers do not send them twice.
+func (s *service) claimDueDeliveries(ctx context.Context) ([]webhook.Delivery, error) {
+	now := s.timeNow()
+
+	// get pg store client using transaction, the due
+	// deliveries are locked until they are claimed
+	pgStoreClient, err := s.pgStore.NewClient(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	due, err := pgStoreClient.GetDueDeliveriesForUpdate(ctx, now, s.config.BatchSize)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return nil, err
+	}
+
+	if len(due) == 0 {
+		pgStoreClient.Rollback()
+		return nil, nil
+	}
+
+	ids := make([]string, len(due))
+	for i := range due {
+		ids[i] = due[i].ID
+	}
+
+	err = pgStoreClient.ClaimDeliveries(ctx, ids, now.Add(s.config.ClaimTimeout))
+	if err != nil {
+		pgStoreClient.Rollback()
+		return nil, err
+	}
+
+	err = pgStoreClient.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return due, nil
+}
+
+// getDeliveryWebhook returns the current webhook of a delivery
+// with the given webhook ID.
+func (s *service) getDeliveryWebhook(ctx context.Context, webhookID string) (webhook.Webhook, error) {
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return webhook.Webhook{}, err
+	}
+
+	return pgStoreClient.GetWebhookByID(ctx, webhookID)
+}
+
+// recordDelivery updates the given delivery along with the
+// failures of its webhook by the given result, in a
+// transaction. A delivery without result is released as it
+// was claimed.
+func (s *service) recordDelivery(ctx context.Context, d webhook.Delivery, result *deliveryResult) error {
+	// get pg store client using transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	err = pgStoreClient.UpdateDeliveryAttempt(ctx, d)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return err
+	}
+
+	if result != nil {
+		result.disabled, err = pgStoreClient.RecordWebhookResult(ctx, d.WebhookID, result.success, s.config.DisableThreshold, result.now)
+		if err != nil {
+			pgStoreClient.Rollback()
+			return err
+		}
+	}
+
+	return pgStoreClient.Commit()
+}
+
+// deliver sends the given delivery to the given webhook, updates
+// its status by the result and returns whether it succeeded.
+func (s *service) deliver(ctx context.Context, w webhook.Webhook, d *webhook.Delivery, now time.Time) bool {
+	res, err := s.sender.Send(ctx, webhooklib.Request{
+		URL:    w.URL,
+		Secret: w.Secret,
+		ID:     d.EventID,
+		Event:  d.EventType,
+		Body:   d.Payload,
+	})
+
+	d.Attempts++
+	d.ResponseCode = res.StatusCode
+	d.ResponseBody = res.Body
+	d.LastError = ""
+
+	switch {
+	case err != nil:
+		d.LastError = err.Error()
+	case !res.IsSuccess():
+		d.LastError = fmt.Sprintf("unexpected status code %d", res.StatusCode)
+	default:
+		d.Status = webhook.DeliveryStatusSucceeded
+		d.DeliverTime = now
+		return true
+	}
+
+	if d.Attempts >= s.config.MaxAttempts {
+		d.Status = webhook.DeliveryStatusFailed
+		log.Printf("[Webhook Service][deliver] Delivery %s failed after %d attempts. Err: %s\n", d.ID, d.Attempts, d.LastError)
+		return false
+	}
+
+	d.NextAttemptTime = now.Add(s.getBackoff(d.Attempts))
+	return false
+}
+
+// getBackoff returns the delay before retrying a delivery
+// failed the given number of attempts.
+func (s *service) getBackoff(attempts int) time.Duration {
+	backoff := s.config.BackoffBase
+	for i := 1; i < attempts && backoff < s.config.BackoffMax; i++ {
+		backoff *= 2
+	}
+
+	if backoff > s.config.BackoffMax {
+		return s.config.BackoffMax
+	}
+
+	return backoff
+}
@@ -0,0 +1,505 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"hbdtoyou/internal/auth"
+	"hbdtoyou/internal/webhook"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	contextlib "hbdtoyou/pkg/context"
+	webhooklib "hbdtoyou/pkg/webhook"
+)
+
+const testSecret = "0123456789abcdef"
+
+// memoryStore is an in-memory PGStore. Transactions are not
+// isolated, it only counts the open ones.
+type memoryStore struct {
+	mu         sync.Mutex
+	webhooks   map[string]webhook.Webhook
+	deliveries map[string]webhook.Delivery
+	nextID     int
+	openTx     int
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		webhooks:   make(map[string]webhook.Webhook),
+		deliveries: make(map[string]webhook.Delivery),
+	}
+}
+
+func (s *memoryStore) NewClient(ctx context.Context, useTx bool) (PGStoreClient, error) {
+	if useTx {
+		s.mu.Lock()
+		s.openTx++
+		s.mu.Unlock()
+	}
+	return &memoryStoreClient{s: s, useTx: useTx}, nil
+}
+
+func (s *memoryStore) getOpenTx() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.openTx
+}
+
+func (s *memoryStore) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s-%d", prefix, s.nextID)
+}
+
+type memoryStoreClient struct {
+	s     *memoryStore
+	useTx bool
+}
+
+func (c *memoryStoreClient) endTx() error {
+	if c.useTx {
+		c.s.mu.Lock()
+		c.s.openTx--
+		c.s.mu.Unlock()
+		c.useTx = false
+	}
+	return nil
+}
+
+func (c *memoryStoreClient) Commit() error   { return c.endTx() }
+func (c *memoryStoreClient) Rollback() error { return c.endTx() }
+
+func (c *memoryStoreClient) CreateWebhook(ctx context.Context, reqWebhook webhook.Webhook) (string, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	reqWebhook.ID = c.s.newID("webhook")
+	c.s.webhooks[reqWebhook.ID] = reqWebhook
+	return reqWebhook.ID, nil
+}
+
+func (c *memoryStoreClient) GetWebhooks(ctx context.Context) ([]webhook.Webhook, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	var res []webhook.Webhook
+	for _, w := range c.s.webhooks {
+		res = append(res, w)
+	}
+	return res, nil
+}
+
+func (c *memoryStoreClient) GetWebhookByID(ctx context.Context, webhookID string) (webhook.Webhook, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	w, ok := c.s.webhooks[webhookID]
+	if !ok {
+		return webhook.Webhook{}, webhook.ErrDataNotFound
+	}
+	return w, nil
+}
+
+func (c *memoryStoreClient) UpdateWebhook(ctx context.Context, reqWebhook webhook.Webhook) error {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	c.s.webhooks[reqWebhook.ID] = reqWebhook
+	return nil
+}
+
+func (c *memoryStoreClient) DeleteWebhook(ctx context.Context, webhookID string) error {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	delete(c.s.webhooks, webhookID)
+	return nil
+}
+
+func (c *memoryStoreClient) RecordWebhookResult(ctx context.Context, webhookID string, success bool, threshold int, now time.Time) (bool, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	w := c.s.webhooks[webhookID]
+	if success {
+		w.FailureCount = 0
+		c.s.webhooks[webhookID] = w
+		return false, nil
+	}
+
+	w.FailureCount++
+	disabled := w.Status == webhook.StatusActive && w.FailureCount >= threshold
+	if disabled {
+		w.Status = webhook.StatusDisabled
+		w.DisableTime = now
+	}
+	c.s.webhooks[webhookID] = w
+	return disabled, nil
+}
+
+func (c *memoryStoreClient) CreateEventDeliveries(ctx context.Context, reqDelivery webhook.Delivery) (int64, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	var n int64
+	for _, w := range c.s.webhooks {
+		if w.Status != webhook.StatusActive {
+			continue
+		}
+		d := reqDelivery
+		d.ID = c.s.newID("delivery")
+		d.WebhookID = w.ID
+		c.s.deliveries[d.ID] = d
+		n++
+	}
+	return n, nil
+}
+
+func (c *memoryStoreClient) CreateDelivery(ctx context.Context, reqDelivery webhook.Delivery) (string, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	reqDelivery.ID = c.s.newID("delivery")
+	c.s.deliveries[reqDelivery.ID] = reqDelivery
+	return reqDelivery.ID, nil
+}
+
+func (c *memoryStoreClient) GetDeliveryByID(ctx context.Context, deliveryID string) (webhook.Delivery, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	d, ok := c.s.deliveries[deliveryID]
+	if !ok {
+		return webhook.Delivery{}, webhook.ErrDataNotFound
+	}
+	return d, nil
+}
+
+func (c *memoryStoreClient) GetDeliveries(ctx context.Context, webhookID string, limit int) ([]webhook.Delivery, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	var res []webhook.Delivery
+	for _, d := range c.s.deliveries {
+		if d.WebhookID == webhookID {
+			res = append(res, d)
+		}
+	}
+	return res, nil
+}
+
+func (c *memoryStoreClient) GetDueDeliveriesForUpdate(ctx context.Context, now time.Time, limit int) ([]webhook.Delivery, error) {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	var res []webhook.Delivery
+	for _, d := range c.s.deliveries {
+		if d.Status == webhook.DeliveryStatusPending && !d.NextAttemptTime.After(now) &&
+			c.s.webhooks[d.WebhookID].Status == webhook.StatusActive {
+			res = append(res, d)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+func (c *memoryStoreClient) ClaimDeliveries(ctx context.Context, deliveryIDs []string, until time.Time) error {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	for _, id := range deliveryIDs {
+		d := c.s.deliveries[id]
+		d.NextAttemptTime = until
+		c.s.deliveries[id] = d
+	}
+	return nil
+}
+
+func (c *memoryStoreClient) UpdateDeliveryAttempt(ctx context.Context, reqDelivery webhook.Delivery) error {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	c.s.deliveries[reqDelivery.ID] = reqDelivery
+	return nil
+}
+
+// adminUser is an auth.Service returning every user as an
+// administrator.
+type adminUser struct {
+	auth.Service
+}
+
+func (adminUser) GetUserByID(ctx context.Context, userID string) (auth.User, error) {
+	return auth.User{ID: userID, Role: auth.RoleAdmin}, nil
+}
+
+// receiver is an httptest server recording the received
+// webhook requests, responding with the status returned by
+// respond.
+type receiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+func newReceiver(t *testing.T, respond func(r *http.Request) int) *receiver {
+	rcv := &receiver{}
+	rcv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rcv.mu.Lock()
+		rcv.requests = append(rcv.requests, r)
+		rcv.mu.Unlock()
+		w.WriteHeader(respond(r))
+	}))
+	t.Cleanup(rcv.Close)
+	return rcv
+}
+
+func (rcv *receiver) count() int {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+	return len(rcv.requests)
+}
+
+// newTestService returns a service sending to httptest
+// receivers at a controlled time.
+func newTestService(t *testing.T, store *memoryStore, now *time.Time, config Config) *service {
+	config.AllowInsecureURL = true
+	s, err := New(store, adminUser{}, webhooklib.NewClient(time.Second), WithConfig(config))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	s.timeNow = func() time.Time { return *now }
+	return s
+}
+
+func createTestWebhook(t *testing.T, store *memoryStore, url string) string {
+	client, _ := store.NewClient(context.Background(), false)
+	id, _ := client.CreateWebhook(context.Background(), webhook.Webhook{
+		URL:        url,
+		Secret:     testSecret,
+		EventTypes: []string{"payment.approved"},
+		Status:     webhook.StatusActive,
+	})
+	return id
+}
+
+func createTestDelivery(t *testing.T, store *memoryStore, webhookID, eventID string, now time.Time) string {
+	client, _ := store.NewClient(context.Background(), false)
+	id, _ := client.CreateDelivery(context.Background(), webhook.Delivery{
+		WebhookID:       webhookID,
+		EventID:         eventID,
+		EventType:       "payment.approved",
+		Payload:         []byte(`{"id":"` + eventID + `"}`),
+		Status:          webhook.DeliveryStatusPending,
+		NextAttemptTime: now,
+		CreateTime:      now,
+	})
+	return id
+}
+
+func TestDeliverWebhooksSignsRequests(t *testing.T) {
+	store := newMemoryStore()
+	now := time.Now()
+
+	var openTx int
+	rcv := newReceiver(t, func(r *http.Request) int {
+		openTx = store.getOpenTx()
+
+		body := []byte(`{"id":"event-1"}`)
+		err := webhooklib.Verify(testSecret, r.Header.Get(webhooklib.HeaderSignature), r.Header.Get(webhooklib.HeaderTimestamp), body, time.Minute, time.Now())
+		if err != nil {
+			t.Errorf("Verify() error = %v", err)
+		}
+		return http.StatusOK
+	})
+
+	s := newTestService(t, store, &now, Config{})
+	webhookID := createTestWebhook(t, store, rcv.URL)
+	deliveryID := createTestDelivery(t, store, webhookID, "event-1", now)
+
+	succeeded, err := s.DeliverWebhooks(context.Background())
+	if err != nil {
+		t.Fatalf("DeliverWebhooks() error = %v", err)
+	}
+	if succeeded != 1 || rcv.count() != 1 {
+		t.Fatalf("DeliverWebhooks() = %d, received %d, want 1 and 1", succeeded, rcv.count())
+	}
+
+	// the request is sent outside of any transaction
+	if openTx != 0 {
+		t.Errorf("open transactions while sending = %d, want 0", openTx)
+	}
+	if store.getOpenTx() != 0 {
+		t.Errorf("open transactions after delivering = %d, want 0", store.getOpenTx())
+	}
+
+	d := store.deliveries[deliveryID]
+	if d.Status != webhook.DeliveryStatusSucceeded || d.Attempts != 1 || d.ResponseCode != http.StatusOK {
+		t.Errorf("delivery = %+v, want succeeded after 1 attempt", d)
+	}
+}
+
+func TestDeliverWebhooksRetriesWithBackoff(t *testing.T) {
+	store := newMemoryStore()
+	now := time.Now()
+
+	failing := true
+	rcv := newReceiver(t, func(r *http.Request) int {
+		if failing {
+			return http.StatusServiceUnavailable
+		}
+		return http.StatusOK
+	})
+
+	s := newTestService(t, store, &now, Config{
+		MaxAttempts: 3,
+		BackoffBase: time.Minute,
+		BackoffMax:  90 * time.Second,
+	})
+	webhookID := createTestWebhook(t, store, rcv.URL)
+	deliveryID := createTestDelivery(t, store, webhookID, "event-1", now)
+
+	// the backoff doubles up to the maximum
+	for i, backoff := range []time.Duration{time.Minute, 90 * time.Second} {
+		_, err := s.DeliverWebhooks(context.Background())
+		if err != nil {
+			t.Fatalf("DeliverWebhooks() error = %v", err)
+		}
+
+		d := store.deliveries[deliveryID]
+		if d.Status != webhook.DeliveryStatusPending || d.Attempts != i+1 {
+			t.Fatalf("attempt %d: delivery = %+v, want pending", i+1, d)
+		}
+		if !d.NextAttemptTime.Equal(now.Add(backoff)) {
+			t.Fatalf("attempt %d: next attempt = %v, want %v", i+1, d.NextAttemptTime, now.Add(backoff))
+		}
+		if d.ResponseCode != http.StatusServiceUnavailable || d.LastError == "" {
+			t.Fatalf("attempt %d: delivery = %+v, want failed response recorded", i+1, d)
+		}
+
+		// the delivery is not due before its backoff
+		_, err = s.DeliverWebhooks(context.Background())
+		if err != nil {
+			t.Fatalf("DeliverWebhooks() error = %v", err)
+		}
+		if rcv.count() != i+1 {
+			t.Fatalf("attempt %d: received %d, want %d", i+1, rcv.count(), i+1)
+		}
+
+		now = now.Add(backoff)
+	}
+
+	// the last attempt fails the delivery
+	_, err := s.DeliverWebhooks(context.Background())
+	if err != nil {
+		t.Fatalf("DeliverWebhooks() error = %v", err)
+	}
+
+	d := store.deliveries[deliveryID]
+	if d.Status != webhook.DeliveryStatusFailed || d.Attempts != 3 {
+		t.Fatalf("delivery = %+v, want failed after 3 attempts", d)
+	}
+
+	// a failed delivery is no longer retried
+	failing = false
+	now = now.Add(time.Hour)
+	succeeded, err := s.DeliverWebhooks(context.Background())
+	if err != nil {
+		t.Fatalf("DeliverWebhooks() error = %v", err)
+	}
+	if succeeded != 0 || rcv.count() != 3 {
+		t.Errorf("DeliverWebhooks() = %d, received %d, want 0 and 3", succeeded, rcv.count())
+	}
+}
+
+func TestDeliverWebhooksDisablesFailingWebhook(t *testing.T) {
+	store := newMemoryStore()
+	now := time.Now()
+
+	rcv := newReceiver(t, func(r *http.Request) int {
+		return http.StatusInternalServerError
+	})
+
+	s := newTestService(t, store, &now, Config{
+		DisableThreshold: 2,
+	})
+	webhookID := createTestWebhook(t, store, rcv.URL)
+	var deliveryIDs []string
+	for i := 1; i <= 3; i++ {
+		deliveryIDs = append(deliveryIDs, createTestDelivery(t, store, webhookID, fmt.Sprintf("event-%d", i), now))
+	}
+
+	_, err := s.DeliverWebhooks(context.Background())
+	if err != nil {
+		t.Fatalf("DeliverWebhooks() error = %v", err)
+	}
+
+	// the webhook is disabled by the second failure, the rest
+	// of the batch is not sent
+	if rcv.count() != 2 {
+		t.Errorf("received %d, want 2", rcv.count())
+	}
+
+	w := store.webhooks[webhookID]
+	if w.Status != webhook.StatusDisabled || w.FailureCount != 2 || w.DisableTime.IsZero() {
+		t.Errorf("webhook = %+v, want disabled after 2 failures", w)
+	}
+
+	// the unsent delivery is released pending, due as before
+	d := store.deliveries[deliveryIDs[2]]
+	if d.Status != webhook.DeliveryStatusPending || d.Attempts != 0 || !d.NextAttemptTime.Equal(now) {
+		t.Errorf("delivery = %+v, want pending and due", d)
+	}
+
+	// deliveries of a disabled webhook are not sent
+	now = now.Add(24 * time.Hour)
+	_, err = s.DeliverWebhooks(context.Background())
+	if err != nil {
+		t.Fatalf("DeliverWebhooks() error = %v", err)
+	}
+	if rcv.count() != 2 {
+		t.Errorf("received %d after disabling, want 2", rcv.count())
+	}
+}
+
+func TestRedeliverDelivery(t *testing.T) {
+	store := newMemoryStore()
+	now := time.Now()
+
+	var eventIDs []string
+	rcv := newReceiver(t, func(r *http.Request) int {
+		eventIDs = append(eventIDs, r.Header.Get(webhooklib.HeaderID))
+		return http.StatusOK
+	})
+
+	s := newTestService(t, store, &now, Config{})
+	webhookID := createTestWebhook(t, store, rcv.URL)
+	deliveryID := createTestDelivery(t, store, webhookID, "event-1", now)
+
+	_, err := s.DeliverWebhooks(context.Background())
+	if err != nil {
+		t.Fatalf("DeliverWebhooks() error = %v", err)
+	}
+
+	ctx := contextlib.SetUserID(context.Background(), "admin")
+	if _, err := s.RedeliverDelivery(ctx, "webhook-other", deliveryID); err != webhook.ErrDataNotFound {
+		t.Errorf("RedeliverDelivery() of another webhook error = %v, want %v", err, webhook.ErrDataNotFound)
+	}
+
+	redeliveryID, err := s.RedeliverDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		t.Fatalf("RedeliverDelivery() error = %v", err)
+	}
+
+	succeeded, err := s.DeliverWebhooks(context.Background())
+	if err != nil {
+		t.Fatalf("DeliverWebhooks() error = %v", err)
+	}
+	if succeeded != 1 {
+		t.Errorf("DeliverWebhooks() = %d, want 1", succeeded)
+	}
+
+	// the redelivery is a new delivery of the same event
+	d := store.deliveries[redeliveryID]
+	if d.RedeliveryOf != deliveryID || d.Status != webhook.DeliveryStatusSucceeded {
+		t.Errorf("redelivery = %+v, want succeeded redelivery of %s", d, deliveryID)
+	}
+	if len(eventIDs) != 2 || eventIDs[0] != "event-1" || eventIDs[1] != "event-1" {
+		t.Errorf("received event IDs = %v, want the same event twice", eventIDs)
+	}
+}
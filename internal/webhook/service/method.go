@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"hbdtoyou/internal/auth"
+	"hbdtoyou/internal/webhook"
+	"net/url"
+	"time"
+
+	contextlib "hbdtoyou/pkg/context"
+)
+
+// minSecretLength is the minimum length of a webhook secret.
+const minSecretLength = 16
+
+// CreateWebhook creates a new webhook and returns the created
+// webhook ID.
+func (s *service) CreateWebhook(ctx context.Context, reqWebhook webhook.Webhook) (string, error) {
+	caller, err := s.authorize(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	reqWebhook.EventTypes = normalizeEventTypes(reqWebhook.EventTypes)
+	if reqWebhook.Status == webhook.StatusUnknown {
+		reqWebhook.Status = webhook.StatusActive
+	}
+
+	// validate fields
+	err = s.validateWebhook(reqWebhook)
+	if err != nil {
+		return "", err
+	}
+
+	// update fields
+	now := s.timeNow()
+	reqWebhook.FailureCount = 0
+	reqWebhook.DisableTime = time.Time{}
+	if reqWebhook.Status == webhook.StatusDisabled {
+		reqWebhook.DisableTime = now
+	}
+	reqWebhook.CreatedBy = caller.ID
+	reqWebhook.CreateTime = now
+	reqWebhook.UpdateTime = time.Time{}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return "", err
+	}
+
+	return pgStoreClient.CreateWebhook(ctx, reqWebhook)
+}
+
+// GetWebhooks returns all webhooks.
+func (s *service) GetWebhooks(ctx context.Context) ([]webhook.Webhook, error) {
+	_, err := s.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgStoreClient.GetWebhooks(ctx)
+}
+
+// GetWebhookByID returns a webhook with the given webhook ID.
+func (s *service) GetWebhookByID(ctx context.Context, webhookID string) (webhook.Webhook, error) {
+	// validate id
+	if webhookID == "" {
+		return webhook.Webhook{}, webhook.ErrInvalidWebhookID
+	}
+
+	_, err := s.authorize(ctx)
+	if err != nil {
+		return webhook.Webhook{}, err
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return webhook.Webhook{}, err
+	}
+
+	return pgStoreClient.GetWebhookByID(ctx, webhookID)
+}
+
+// UpdateWebhook updates existing webhook with the given
+// webhook data. Enabling a disabled webhook resets its
+// failures.
+func (s *service) UpdateWebhook(ctx context.Context, reqWebhook webhook.Webhook) error {
+	// validate id
+	if reqWebhook.ID == "" {
+		return webhook.ErrInvalidWebhookID
+	}
+
+	_, err := s.authorize(ctx)
+	if err != nil {
+		return err
+	}
+
+	reqWebhook.EventTypes = normalizeEventTypes(reqWebhook.EventTypes)
+
+	// validate fields
+	err = s.validateWebhook(reqWebhook)
+	if err != nil {
+		return err
+	}
+
+	// get pg store client using transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	current, err := pgStoreClient.GetWebhookByID(ctx, reqWebhook.ID)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return err
+	}
+
+	// update fields, the failures are only maintained by
+	// deliveries
+	now := s.timeNow()
+	reqWebhook.FailureCount = current.FailureCount
+	reqWebhook.DisableTime = current.DisableTime
+	switch {
+	case current.Status == webhook.StatusDisabled && reqWebhook.Status == webhook.StatusActive:
+		reqWebhook.FailureCount = 0
+		reqWebhook.DisableTime = time.Time{}
+	case current.Status == webhook.StatusActive && reqWebhook.Status == webhook.StatusDisabled:
+		reqWebhook.DisableTime = now
+	}
+	reqWebhook.UpdateTime = now
+
+	err = pgStoreClient.UpdateWebhook(ctx, reqWebhook)
+	if err != nil {
+		pgStoreClient.Rollback()
+		return err
+	}
+
+	return pgStoreClient.Commit()
+}
+
+// DeleteWebhook deletes a webhook with the given webhook ID
+// along with its deliveries.
+func (s *service) DeleteWebhook(ctx context.Context, webhookID string) error {
+	// validate id
+	if webhookID == "" {
+		return webhook.ErrInvalidWebhookID
+	}
+
+	_, err := s.authorize(ctx)
+	if err != nil {
+		return err
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	return pgStoreClient.DeleteWebhook(ctx, webhookID)
+}
+
+// GetDeliveries returns the latest deliveries of a webhook
+// with the given webhook ID, newest first.
+func (s *service) GetDeliveries(ctx context.Context, webhookID string) ([]webhook.Delivery, error) {
+	// validate id
+	if webhookID == "" {
+		return nil, webhook.ErrInvalidWebhookID
+	}
+
+	_, err := s.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// make sure the webhook exists, so an unknown webhook is
+	// not mistaken for one without deliveries
+	_, err = pgStoreClient.GetWebhookByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgStoreClient.GetDeliveries(ctx, webhookID, s.config.DeliveriesLimit)
+}
+
+// RedeliverDelivery delivers the event of a delivery with the
+// given delivery ID again, as a new delivery, and returns the
+// created delivery ID.
+func (s *service) RedeliverDelivery(ctx context.Context, webhookID, deliveryID string) (string, error) {
+	// validate id
+	if webhookID == "" {
+		return "", webhook.ErrInvalidWebhookID
+	}
+	if deliveryID == "" {
+		return "", webhook.ErrInvalidDeliveryID
+	}
+
+	_, err := s.authorize(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// get pg store client without transaction
+	pgStoreClient, err := s.pgStore.NewClient(ctx, false)
+	if err != nil {
+		return "", err
+	}
+
+	current, err := pgStoreClient.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return "", err
+	}
+
+	if current.WebhookID != webhookID {
+		return "", webhook.ErrDataNotFound
+	}
+
+	// the event is sent as it was, receivers recognize it by
+	// the same event ID
+	now := s.timeNow()
+	return pgStoreClient.CreateDelivery(ctx, webhook.Delivery{
+		WebhookID:       current.WebhookID,
+		EventID:         current.EventID,
+		EventType:       current.EventType,
+		Payload:         current.Payload,
+		Status:          webhook.DeliveryStatusPending,
+		NextAttemptTime: now,
+		RedeliveryOf:    current.ID,
+		CreateTime:      now,
+	})
+}
+
+// authorize returns the caller if they are an administrator.
+func (s *service) authorize(ctx context.Context) (auth.User, error) {
+	userID, ok := contextlib.GetUserID(ctx)
+	if !ok {
+		return auth.User{}, webhook.ErrInvalidUserID
+	}
+
+	caller, err := s.user.GetUserByID(ctx, userID)
+	if err != nil {
+		return auth.User{}, err
+	}
+
+	if !caller.IsAdmin() {
+		return auth.User{}, webhook.ErrForbidden
+	}
+
+	return caller, nil
+}
+
+// validateWebhook validates fields of the given webhook.
+func (s *service) validateWebhook(reqWebhook webhook.Webhook) error {
+	u, err := url.Parse(reqWebhook.URL)
+	if err != nil || u.Host == "" || u.User != nil {
+		return webhook.ErrInvalidWebhookURL
+	}
+
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if !s.config.AllowInsecureURL {
+			return webhook.ErrInvalidWebhookURL
+		}
+	default:
+		return webhook.ErrInvalidWebhookURL
+	}
+
+	if len(reqWebhook.Secret) < minSecretLength {
+		return webhook.ErrInvalidWebhookSecret
+	}
+
+	if len(reqWebhook.EventTypes) == 0 {
+		return webhook.ErrInvalidEventType
+	}
+	for _, eventType := range reqWebhook.EventTypes {
+		if _, valid := webhook.EventTypeList[eventType]; !valid {
+			return webhook.ErrInvalidEventType
+		}
+	}
+
+	if _, valid := webhook.StatusList[reqWebhook.Status]; !valid {
+		return webhook.ErrInvalidWebhookStatus
+	}
+
+	return nil
+}
+
+// normalizeEventTypes returns the given event types without
+// duplicates, keeping their order.
+func normalizeEventTypes(eventTypes []string) []string {
+	result := make([]string, 0, len(eventTypes))
+	seen := make(map[string]struct{}, len(eventTypes))
+	for _, eventType := range eventTypes {
+		if _, ok := seen[eventType]; ok {
+			continue
+		}
+		seen[eventType] = struct{}{}
+		result = append(result, eventType)
+	}
+
+	return result
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"hbdtoyou/internal/auth"
+	webhooklib "hbdtoyou/pkg/webhook"
+	"time"
+)
+
+// Following constans are config default values.
+const (
+	defaultMaxAttempts      = 8
+	defaultBackoffBase      = 1 * time.Minute
+	defaultBackoffMax       = 6 * time.Hour
+	defaultBatchSize        = 50
+	defaultDisableThreshold = 20
+	defaultDeliveriesLimit  = 100
+	defaultClaimTimeout     = 15 * time.Minute
+)
+
+// service implements webhook.Service.
+type service struct {
+	pgStore PGStore
+	user    auth.Service
+	sender  Sender
+	config  Config
+	timeNow func() time.Time
+}
+
+// Sender sends signed webhook requests.
+type Sender interface {
+	// Send sends the given request. An error is only
+	// returned if no response is received.
+	Send(ctx context.Context, req webhooklib.Request) (webhooklib.Response, error)
+}
+
+// Config denotes service configuration
+//
+// Adding a new field should also add the corresponding default
+// value in getDefaultConfig().
+type Config struct {
+	// MaxAttempts is the number of requests after which a
+	// delivery is no longer retried.
+	MaxAttempts int
+
+	// BackoffBase is the delay before the first retry, it is
+	// doubled on every next retry up to BackoffMax.
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+
+	// BatchSize is the maximum number of deliveries sent at
+	// once.
+	BatchSize int
+
+	// ClaimTimeout is how long the deliveries of a batch are
+	// not due for other workers while being sent. It should
+	// be longer than sending a whole batch, a delivery is
+	// sent again if its result is not recorded in time.
+	ClaimTimeout time.Duration
+
+	// DisableThreshold is the number of consecutive failed
+	// requests after which a webhook is disabled.
+	DisableThreshold int
+
+	// DeliveriesLimit is the maximum number of deliveries
+	// returned by GetDeliveries.
+	DeliveriesLimit int
+
+	// AllowInsecureURL allows webhooks with plain HTTP URLs,
+	// e.g. for local receivers in development.
+	AllowInsecureURL bool
+}
+
+// getDefaultConfig returns service configuration with the
+// predefined default values.
+func getDefaultConfig() Config {
+	return Config{
+		MaxAttempts:      defaultMaxAttempts,
+		BackoffBase:      defaultBackoffBase,
+		BackoffMax:       defaultBackoffMax,
+		BatchSize:        defaultBatchSize,
+		ClaimTimeout:     defaultClaimTimeout,
+		DisableThreshold: defaultDisableThreshold,
+		DeliveriesLimit:  defaultDeliveriesLimit,
+	}
+}
+
+// New creates a new service.
+func New(pgStore PGStore, user auth.Service, sender Sender, options ...Option) (*service, error) {
+	s := &service{
+		pgStore: pgStore,
+		user:    user,
+		sender:  sender,
+		config:  getDefaultConfig(),
+		timeNow: time.Now,
+	}
+
+	// apply options
+	for _, opt := range options {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Option controls the behavior of service.
+type Option func(*service) error
+
+// WithConfig returns Option to set service configuration.
+func WithConfig(config Config) Option {
+	return func(s *service) error {
+		if config.MaxAttempts > 0 {
+			s.config.MaxAttempts = config.MaxAttempts
+		}
+		if config.BackoffBase > 0 {
+			s.config.BackoffBase = config.BackoffBase
+		}
+		if config.BackoffMax > 0 {
+			s.config.BackoffMax = config.BackoffMax
+		}
+		if config.BatchSize > 0 {
+			s.config.BatchSize = config.BatchSize
+		}
+		if config.ClaimTimeout > 0 {
+			s.config.ClaimTimeout = config.ClaimTimeout
+		}
+		if config.DisableThreshold > 0 {
+			s.config.DisableThreshold = config.DisableThreshold
+		}
+		if config.DeliveriesLimit > 0 {
+			s.config.DeliveriesLimit = config.DeliveriesLimit
+		}
+		s.config.AllowInsecureURL = config.AllowInsecureURL
+		return nil
+	}
+}
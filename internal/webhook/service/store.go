@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"hbdtoyou/internal/webhook"
+	"time"
+)
+
+type PGStore interface {
+	NewClient(ctx context.Context, useTx bool) (PGStoreClient, error)
+}
+
+type PGStoreClient interface {
+	// Commit commits the transaction.
+	Commit() error
+	// Rollback aborts the transaction.
+	Rollback() error
+
+	// CreateWebhook creates a new webhook and returns the
+	// created webhook ID.
+	CreateWebhook(ctx context.Context, reqWebhook webhook.Webhook) (string, error)
+
+	// GetWebhooks returns all webhooks, newest first.
+	GetWebhooks(ctx context.Context) ([]webhook.Webhook, error)
+
+	// GetWebhookByID returns a webhook with the given webhook
+	// ID.
+	GetWebhookByID(ctx context.Context, webhookID string) (webhook.Webhook, error)
+
+	// UpdateWebhook updates existing webhook with the given
+	// webhook data.
+	UpdateWebhook(ctx context.Context, reqWebhook webhook.Webhook) error
+
+	// DeleteWebhook deletes a webhook with the given webhook
+	// ID along with its deliveries.
+	DeleteWebhook(ctx context.Context, webhookID string) error
+
+	// RecordWebhookResult resets the consecutive failures of
+	// a webhook with the given webhook ID if the request has
+	// succeeded, otherwise increments them and disables the
+	// webhook once they reach the given threshold. It returns
+	// whether the webhook is disabled.
+	RecordWebhookResult(ctx context.Context, webhookID string, success bool, threshold int, now time.Time) (bool, error)
+
+	// CreateEventDeliveries creates the given delivery for
+	// each active webhook subscribed to its event type, and
+	// returns the number of created deliveries. Webhooks
+	// already having a delivery of the event are skipped.
+	CreateEventDeliveries(ctx context.Context, reqDelivery webhook.Delivery) (int64, error)
+
+	// CreateDelivery creates a new delivery and returns the
+	// created delivery ID.
+	CreateDelivery(ctx context.Context, reqDelivery webhook.Delivery) (string, error)
+
+	// GetDeliveryByID returns a delivery with the given
+	// delivery ID.
+	GetDeliveryByID(ctx context.Context, deliveryID string) (webhook.Delivery, error)
+
+	// GetDeliveries returns at most the given limit of
+	// deliveries of a webhook with the given webhook ID,
+	// newest first.
+	GetDeliveries(ctx context.Context, webhookID string, limit int) ([]webhook.Delivery, error)
+
+	// GetDueDeliveriesForUpdate returns at most the given
+	// limit of pending deliveries of active webhooks due at
+	// the given time, oldest first, and locks them until the
+	// transaction ends. Deliveries locked by another
+	// transaction are skipped.
+	GetDueDeliveriesForUpdate(ctx context.Context, now time.Time, limit int) ([]webhook.Delivery, error)
+
+	// ClaimDeliveries postpones the next attempt of the
+	// deliveries with the given delivery IDs to the given
+	// time, so they are not due for other workers while
+	// being sent.
+	ClaimDeliveries(ctx context.Context, deliveryIDs []string, until time.Time) error
+
+	// UpdateDeliveryAttempt updates the status, attempts and
+	// last response of the given delivery.
+	UpdateDeliveryAttempt(ctx context.Context, reqDelivery webhook.Delivery) error
+}
@@ -0,0 +1,359 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"hbdtoyou/internal/webhook"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+func (sc *storeClient) CreateWebhook(ctx context.Context, reqWebhook webhook.Webhook) (string, error) {
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"url":           reqWebhook.URL,
+		"secret":        reqWebhook.Secret,
+		"event_types":   pq.Array(reqWebhook.EventTypes),
+		"status":        reqWebhook.Status,
+		"failure_count": reqWebhook.FailureCount,
+		"disable_time":  nullTime(reqWebhook.DisableTime),
+		"created_by":    reqWebhook.CreatedBy,
+		"create_time":   reqWebhook.CreateTime,
+	}
+
+	return sc.insert(queryCreateWebhook, argKV)
+}
+
+func (sc *storeClient) GetWebhooks(ctx context.Context) ([]webhook.Webhook, error) {
+	query := fmt.Sprintf(queryGetWebhook, "ORDER BY create_time DESC, id")
+
+	return sc.queryWebhooks(query)
+}
+
+func (sc *storeClient) GetWebhookByID(ctx context.Context, webhookID string) (webhook.Webhook, error) {
+	// invalid IDs cannot match any webhook
+	if _, err := uuid.Parse(webhookID); err != nil {
+		return webhook.Webhook{}, webhook.ErrDataNotFound
+	}
+
+	query := fmt.Sprintf(queryGetWebhook, "WHERE id = $1")
+
+	result, err := sc.queryWebhooks(query, webhookID)
+	if err != nil {
+		return webhook.Webhook{}, err
+	}
+
+	if len(result) == 0 {
+		return webhook.Webhook{}, webhook.ErrDataNotFound
+	}
+
+	return result[0], nil
+}
+
+func (sc *storeClient) UpdateWebhook(ctx context.Context, reqWebhook webhook.Webhook) error {
+	// invalid IDs cannot match any webhook
+	if _, err := uuid.Parse(reqWebhook.ID); err != nil {
+		return webhook.ErrDataNotFound
+	}
+
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"id":            reqWebhook.ID,
+		"url":           reqWebhook.URL,
+		"secret":        reqWebhook.Secret,
+		"event_types":   pq.Array(reqWebhook.EventTypes),
+		"status":        reqWebhook.Status,
+		"failure_count": reqWebhook.FailureCount,
+		"disable_time":  nullTime(reqWebhook.DisableTime),
+		"update_time":   reqWebhook.UpdateTime,
+	}
+
+	return sc.exec(queryUpdateWebhook, argKV)
+}
+
+func (sc *storeClient) DeleteWebhook(ctx context.Context, webhookID string) error {
+	// invalid IDs cannot match any webhook
+	if _, err := uuid.Parse(webhookID); err != nil {
+		return webhook.ErrDataNotFound
+	}
+
+	// the deliveries are deleted by cascade
+	argKV := map[string]interface{}{
+		"id": webhookID,
+	}
+
+	return sc.exec(queryDeleteWebhook, argKV)
+}
+
+func (sc *storeClient) RecordWebhookResult(ctx context.Context, webhookID string, success bool, threshold int, now time.Time) (bool, error) {
+	if success {
+		argKV := map[string]interface{}{
+			"id": webhookID,
+		}
+
+		// nothing is updated when there are no failures to
+		// reset
+		query, args, err := sc.prepare(queryResetWebhookFailures, argKV)
+		if err != nil {
+			return false, err
+		}
+
+		_, err = sc.q.Exec(query, args...)
+		return false, err
+	}
+
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"id":              webhookID,
+		"threshold":       threshold,
+		"now":             now,
+		"active_status":   webhook.StatusActive,
+		"disabled_status": webhook.StatusDisabled,
+	}
+
+	query, args, err := sc.prepare(queryIncrementWebhookFailures, argKV)
+	if err != nil {
+		return false, err
+	}
+
+	var status webhook.Status
+	err = sc.q.QueryRowx(query, args...).Scan(&status)
+	if err != nil {
+		return false, err
+	}
+
+	return status == webhook.StatusDisabled, nil
+}
+
+func (sc *storeClient) CreateEventDeliveries(ctx context.Context, reqDelivery webhook.Delivery) (int64, error) {
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"event_id":          reqDelivery.EventID,
+		"event_type":        reqDelivery.EventType,
+		"payload":           string(reqDelivery.Payload),
+		"status":            reqDelivery.Status,
+		"next_attempt_time": reqDelivery.NextAttemptTime,
+		"create_time":       reqDelivery.CreateTime,
+		"webhook_status":    webhook.StatusActive,
+	}
+
+	query, args, err := sc.prepare(queryCreateEventDeliveries, argKV)
+	if err != nil {
+		return 0, err
+	}
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (sc *storeClient) CreateDelivery(ctx context.Context, reqDelivery webhook.Delivery) (string, error) {
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"webhook_id":        reqDelivery.WebhookID,
+		"event_id":          reqDelivery.EventID,
+		"event_type":        reqDelivery.EventType,
+		"payload":           string(reqDelivery.Payload),
+		"status":            reqDelivery.Status,
+		"next_attempt_time": reqDelivery.NextAttemptTime,
+		"redelivery_of":     nullString(reqDelivery.RedeliveryOf),
+		"create_time":       reqDelivery.CreateTime,
+	}
+
+	return sc.insert(queryCreateDelivery, argKV)
+}
+
+func (sc *storeClient) GetDeliveryByID(ctx context.Context, deliveryID string) (webhook.Delivery, error) {
+	// invalid IDs cannot match any delivery
+	if _, err := uuid.Parse(deliveryID); err != nil {
+		return webhook.Delivery{}, webhook.ErrDataNotFound
+	}
+
+	query := fmt.Sprintf(queryGetDelivery, "WHERE d.id = $1")
+
+	result, err := sc.queryDeliveries(query, deliveryID)
+	if err != nil {
+		return webhook.Delivery{}, err
+	}
+
+	if len(result) == 0 {
+		return webhook.Delivery{}, webhook.ErrDataNotFound
+	}
+
+	return result[0], nil
+}
+
+func (sc *storeClient) GetDeliveries(ctx context.Context, webhookID string, limit int) ([]webhook.Delivery, error) {
+	// invalid IDs cannot match any webhook
+	if _, err := uuid.Parse(webhookID); err != nil {
+		return nil, webhook.ErrDataNotFound
+	}
+
+	query := fmt.Sprintf(queryGetDelivery, "WHERE d.webhook_id = $1 ORDER BY d.create_time DESC, d.id LIMIT $2")
+
+	return sc.queryDeliveries(query, webhookID, limit)
+}
+
+func (sc *storeClient) GetDueDeliveriesForUpdate(ctx context.Context, now time.Time, limit int) ([]webhook.Delivery, error) {
+	// deliveries of disabled webhooks are kept pending until
+	// the webhook is enabled again
+	query := fmt.Sprintf(queryGetDelivery, `
+		JOIN webhook w ON w.id = d.webhook_id
+		WHERE d.status = $1 AND d.next_attempt_time <= $2 AND w.status = $3
+		ORDER BY d.create_time LIMIT $4
+		FOR UPDATE OF d SKIP LOCKED`)
+
+	return sc.queryDeliveries(query, webhook.DeliveryStatusPending, now, webhook.StatusActive, limit)
+}
+
+func (sc *storeClient) ClaimDeliveries(ctx context.Context, deliveryIDs []string, until time.Time) error {
+	if len(deliveryIDs) == 0 {
+		return nil
+	}
+
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"ids":               deliveryIDs,
+		"next_attempt_time": until,
+	}
+
+	return sc.exec(queryClaimDeliveries, argKV)
+}
+
+func (sc *storeClient) UpdateDeliveryAttempt(ctx context.Context, reqDelivery webhook.Delivery) error {
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"id":                reqDelivery.ID,
+		"status":            reqDelivery.Status,
+		"attempts":          reqDelivery.Attempts,
+		"next_attempt_time": reqDelivery.NextAttemptTime,
+		"response_code":     nullInt(reqDelivery.ResponseCode),
+		"response_body":     nullString(reqDelivery.ResponseBody),
+		"last_error":        nullString(reqDelivery.LastError),
+		"deliver_time":      nullTime(reqDelivery.DeliverTime),
+	}
+
+	return sc.exec(queryUpdateDeliveryAttempt, argKV)
+}
+
+// prepare binds the given named arguments to the given query.
+func (sc *storeClient) prepare(query string, argKV map[string]interface{}) (string, []interface{}, error) {
+	query, args, err := sqlx.Named(query, argKV)
+	if err != nil {
+		return "", nil, err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return sc.q.Rebind(query), args, nil
+}
+
+// insert runs the given insert query and returns the ID of the
+// inserted row.
+func (sc *storeClient) insert(query string, argKV map[string]interface{}) (string, error) {
+	query, args, err := sc.prepare(query, argKV)
+	if err != nil {
+		return "", err
+	}
+
+	// execute query
+	var id string
+	err = sc.q.QueryRowx(query, args...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// exec runs the given update query, and returns
+// webhook.ErrDataNotFound if no row is affected.
+func (sc *storeClient) exec(query string, argKV map[string]interface{}) error {
+	query, args, err := sc.prepare(query, argKV)
+	if err != nil {
+		return err
+	}
+
+	// execute query
+	res, err := sc.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return webhook.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// queryWebhooks runs the given webhook query and returns the
+// read rows.
+func (sc *storeClient) queryWebhooks(query string, args ...interface{}) ([]webhook.Webhook, error) {
+	// query to database
+	rows, err := sc.q.Queryx(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// read rows
+	result := make([]webhook.Webhook, 0)
+	for rows.Next() {
+		var row webhookModel
+		err = rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, row.format())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// queryDeliveries runs the given delivery query and returns the
+// read rows.
+func (sc *storeClient) queryDeliveries(query string, args ...interface{}) ([]webhook.Delivery, error) {
+	// query to database
+	rows, err := sc.q.Queryx(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// read rows
+	result := make([]webhook.Delivery, 0)
+	for rows.Next() {
+		var row deliveryModel
+		err = rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, row.format())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,126 @@
+package postgresql
+
+import (
+	"hbdtoyou/internal/webhook"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type webhookModel struct {
+	ID           uuid.UUID      `db:"id"`
+	URL          string         `db:"url"`
+	Secret       string         `db:"secret"`
+	EventTypes   pq.StringArray `db:"event_types"`
+	Status       webhook.Status `db:"status"`
+	FailureCount int            `db:"failure_count"`
+	DisableTime  *time.Time     `db:"disable_time"`
+	CreatedBy    string         `db:"created_by"`
+	CreateTime   time.Time      `db:"create_time"`
+	UpdateTime   *time.Time     `db:"update_time"`
+}
+
+// format formats database struct into domain struct.
+func (dbData *webhookModel) format() webhook.Webhook {
+	w := webhook.Webhook{
+		ID:           dbData.ID.String(),
+		URL:          dbData.URL,
+		Secret:       dbData.Secret,
+		EventTypes:   dbData.EventTypes,
+		Status:       dbData.Status,
+		FailureCount: dbData.FailureCount,
+		CreatedBy:    dbData.CreatedBy,
+		CreateTime:   dbData.CreateTime,
+	}
+
+	if dbData.DisableTime != nil {
+		w.DisableTime = *dbData.DisableTime
+	}
+
+	if dbData.UpdateTime != nil {
+		w.UpdateTime = *dbData.UpdateTime
+	}
+
+	return w
+}
+
+type deliveryModel struct {
+	ID              uuid.UUID              `db:"id"`
+	WebhookID       string                 `db:"webhook_id"`
+	EventID         string                 `db:"event_id"`
+	EventType       string                 `db:"event_type"`
+	Payload         string                 `db:"payload"`
+	Status          webhook.DeliveryStatus `db:"status"`
+	Attempts        int                    `db:"attempts"`
+	NextAttemptTime time.Time              `db:"next_attempt_time"`
+	ResponseCode    *int                   `db:"response_code"`
+	ResponseBody    *string                `db:"response_body"`
+	LastError       *string                `db:"last_error"`
+	RedeliveryOf    *string                `db:"redelivery_of"`
+	CreateTime      time.Time              `db:"create_time"`
+	DeliverTime     *time.Time             `db:"deliver_time"`
+}
+
+// format formats database struct into domain struct.
+func (dbData *deliveryModel) format() webhook.Delivery {
+	d := webhook.Delivery{
+		ID:              dbData.ID.String(),
+		WebhookID:       dbData.WebhookID,
+		EventID:         dbData.EventID,
+		EventType:       dbData.EventType,
+		Payload:         []byte(dbData.Payload),
+		Status:          dbData.Status,
+		Attempts:        dbData.Attempts,
+		NextAttemptTime: dbData.NextAttemptTime,
+		CreateTime:      dbData.CreateTime,
+	}
+
+	if dbData.ResponseCode != nil {
+		d.ResponseCode = *dbData.ResponseCode
+	}
+
+	if dbData.ResponseBody != nil {
+		d.ResponseBody = *dbData.ResponseBody
+	}
+
+	if dbData.LastError != nil {
+		d.LastError = *dbData.LastError
+	}
+
+	if dbData.RedeliveryOf != nil {
+		d.RedeliveryOf = *dbData.RedeliveryOf
+	}
+
+	if dbData.DeliverTime != nil {
+		d.DeliverTime = *dbData.DeliverTime
+	}
+
+	return d
+}
+
+// nullString returns nil for an empty string, so it is stored
+// as NULL.
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// nullInt returns nil for a zero int, so it is stored as NULL.
+func nullInt(i int) interface{} {
+	if i == 0 {
+		return nil
+	}
+	return i
+}
+
+// nullTime returns nil for a zero time, so it is stored as
+// NULL.
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
@@ -0,0 +1,202 @@
+package postgresql
+
+const (
+	queryCreateWebhook = `
+		INSERT INTO
+			webhook
+			(
+				url,
+				secret,
+				event_types,
+				status,
+				failure_count,
+				disable_time,
+				created_by,
+				create_time
+			)
+		VALUES
+			(
+				:url,
+				:secret,
+				:event_types,
+				:status,
+				:failure_count,
+				:disable_time,
+				:created_by,
+				:create_time
+			)
+		RETURNING
+			id
+	`
+
+	queryGetWebhook = `
+		SELECT
+			id,
+			url,
+			secret,
+			event_types,
+			status,
+			failure_count,
+			disable_time,
+			created_by,
+			create_time,
+			update_time
+		FROM
+			webhook
+		%s
+	`
+
+	queryUpdateWebhook = `
+		UPDATE
+			webhook
+		SET
+			url = :url,
+			secret = :secret,
+			event_types = :event_types,
+			status = :status,
+			failure_count = :failure_count,
+			disable_time = :disable_time,
+			update_time = :update_time
+		WHERE
+			id = :id
+	`
+
+	queryDeleteWebhook = `
+		DELETE FROM
+			webhook
+		WHERE
+			id = :id
+	`
+
+	queryResetWebhookFailures = `
+		UPDATE
+			webhook
+		SET
+			failure_count = 0
+		WHERE
+			id = :id
+		AND
+			failure_count > 0
+	`
+
+	// queryIncrementWebhookFailures disables the webhook once
+	// its failures reach the threshold, the SET expressions
+	// see the failures before the increment.
+	queryIncrementWebhookFailures = `
+		UPDATE
+			webhook
+		SET
+			failure_count = failure_count + 1,
+			status = CASE WHEN status = :active_status AND failure_count + 1 >= :threshold THEN :disabled_status ELSE status END,
+			disable_time = CASE WHEN status = :active_status AND failure_count + 1 >= :threshold THEN :now ELSE disable_time END
+		WHERE
+			id = :id
+		RETURNING
+			status
+	`
+
+	// queryCreateEventDeliveries skips webhooks already having
+	// a delivery of the event, other than the manual ones.
+	queryCreateEventDeliveries = `
+		INSERT INTO
+			webhook_delivery
+			(
+				webhook_id,
+				event_id,
+				event_type,
+				payload,
+				status,
+				next_attempt_time,
+				create_time
+			)
+		SELECT
+			id,
+			CAST(:event_id AS TEXT),
+			CAST(:event_type AS TEXT),
+			CAST(:payload AS TEXT),
+			CAST(:status AS SMALLINT),
+			CAST(:next_attempt_time AS TIMESTAMPTZ),
+			CAST(:create_time AS TIMESTAMPTZ)
+		FROM
+			webhook
+		WHERE
+			status = :webhook_status
+		AND
+			CAST(:event_type AS TEXT) = ANY(event_types)
+		ON CONFLICT (webhook_id, event_id) WHERE redelivery_of IS NULL
+		DO NOTHING
+	`
+
+	queryCreateDelivery = `
+		INSERT INTO
+			webhook_delivery
+			(
+				webhook_id,
+				event_id,
+				event_type,
+				payload,
+				status,
+				next_attempt_time,
+				redelivery_of,
+				create_time
+			)
+		VALUES
+			(
+				:webhook_id,
+				:event_id,
+				:event_type,
+				:payload,
+				:status,
+				:next_attempt_time,
+				:redelivery_of,
+				:create_time
+			)
+		RETURNING
+			id
+	`
+
+	queryGetDelivery = `
+		SELECT
+			d.id,
+			d.webhook_id,
+			d.event_id,
+			d.event_type,
+			d.payload,
+			d.status,
+			d.attempts,
+			d.next_attempt_time,
+			d.response_code,
+			d.response_body,
+			d.last_error,
+			d.redelivery_of,
+			d.create_time,
+			d.deliver_time
+		FROM
+			webhook_delivery d
+		%s
+	`
+
+	queryClaimDeliveries = `
+		UPDATE
+			webhook_delivery
+		SET
+			next_attempt_time = :next_attempt_time
+		WHERE
+			id IN (:ids)
+	`
+
+	queryUpdateDeliveryAttempt = `
+		UPDATE
+			webhook_delivery
+		SET
+			status = :status,
+			attempts = :attempts,
+			next_attempt_time = :next_attempt_time,
+			response_code = :response_code,
+			response_body = :response_body,
+			last_error = :last_error,
+			deliver_time = :deliver_time
+		WHERE
+			id = :id
+	`
+)
@@ -0,0 +1,210 @@
+package webhook
+
+import (
+	"context"
+	"hbdtoyou/internal/content"
+	"hbdtoyou/internal/payment"
+	"hbdtoyou/pkg/event"
+	"time"
+)
+
+// Service is the interface for webhook service.
+//
+// A webhook subscribes an endpoint of an integrator to event
+// types. Published events of the subscribed types are
+// delivered to the endpoint as signed requests in the
+// background, and retried with an increasing backoff until
+// the endpoint accepts them.
+//
+// Only administrators manage webhooks.
+type Service interface {
+	// CreateWebhook creates a new webhook and returns the
+	// created webhook ID.
+	CreateWebhook(ctx context.Context, reqWebhook Webhook) (string, error)
+
+	// GetWebhooks returns all webhooks.
+	GetWebhooks(ctx context.Context) ([]Webhook, error)
+
+	// GetWebhookByID returns a webhook with the given webhook
+	// ID.
+	GetWebhookByID(ctx context.Context, webhookID string) (Webhook, error)
+
+	// UpdateWebhook updates existing webhook with the given
+	// webhook data. Enabling a disabled webhook resets its
+	// failures.
+	UpdateWebhook(ctx context.Context, reqWebhook Webhook) error
+
+	// DeleteWebhook deletes a webhook with the given webhook
+	// ID along with its deliveries.
+	DeleteWebhook(ctx context.Context, webhookID string) error
+
+	// GetDeliveries returns the latest deliveries of a
+	// webhook with the given webhook ID, newest first.
+	GetDeliveries(ctx context.Context, webhookID string) ([]Delivery, error)
+
+	// RedeliverDelivery delivers the event of a delivery
+	// with the given delivery ID again, as a new delivery,
+	// and returns the created delivery ID.
+	RedeliverDelivery(ctx context.Context, webhookID, deliveryID string) (string, error)
+
+	// EnqueueEvent creates deliveries of the given event to
+	// the active webhooks subscribed to its type. Enqueueing
+	// an event more than once does not duplicate the
+	// deliveries.
+	EnqueueEvent(ctx context.Context, e event.Event) error
+
+	// DeliverWebhooks sends pending deliveries due at the
+	// moment, and returns the number of succeeded deliveries.
+	//
+	// A webhook failing consecutively more than the allowed
+	// failures is disabled, its pending deliveries are sent
+	// once it is enabled again.
+	DeliverWebhooks(ctx context.Context) (int, error)
+}
+
+// Webhook denotes an endpoint subscribed to event types.
+type Webhook struct {
+	ID  string
+	URL string
+
+	// Secret signs the requests sent to the endpoint. It is
+	// never returned once created.
+	Secret string
+
+	// EventTypes are the subscribed event types, e.g.
+	// "payment.approved".
+	EventTypes []string
+
+	Status Status
+
+	// FailureCount is the number of consecutive failed
+	// requests to the endpoint.
+	FailureCount int
+	DisableTime  time.Time
+
+	CreatedBy  string
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+// Status denotes status of a webhook.
+type Status int
+
+// Following constans are the known webhook status.
+const (
+	StatusUnknown Status = 0
+	StatusActive  Status = 1
+
+	// StatusDisabled is a webhook disabled by an
+	// administrator or for failing consecutively.
+	StatusDisabled Status = 2
+)
+
+var (
+	// StatusList is a list of valid webhook status.
+	StatusList = map[Status]struct{}{
+		StatusActive:   {},
+		StatusDisabled: {},
+	}
+
+	// StatusName maps webhook status to it's string
+	// representation.
+	StatusName = map[Status]string{
+		StatusActive:   "active",
+		StatusDisabled: "disabled",
+	}
+)
+
+// String implements the Stringer interface.
+func (s Status) String() string {
+	return StatusName[s]
+}
+
+// Value implements the Valuer interface.
+func (s Status) Value() int {
+	return int(s)
+}
+
+// EventTypeList is a list of event types webhooks can
+// subscribe to.
+var EventTypeList = map[string]struct{}{
+	content.EventCreated:    {},
+	content.EventPublished:  {},
+	payment.EventApproved:   {},
+	payment.EventUnapproved: {},
+	payment.EventRejected:   {},
+	payment.EventRefunded:   {},
+}
+
+// Delivery denotes a delivery of an event to a webhook.
+type Delivery struct {
+	ID        string
+	WebhookID string
+
+	// EventID is sent as the webhook ID header, receivers
+	// use it to ignore an event delivered more than once.
+	EventID   string
+	EventType string
+
+	// Payload is the JSON body sent to the endpoint.
+	Payload []byte
+
+	Status DeliveryStatus
+
+	// Attempts is the number of sent requests. The response
+	// of the last request is kept, LastError is set if no
+	// response is received.
+	Attempts        int
+	NextAttemptTime time.Time
+	ResponseCode    int
+	ResponseBody    string
+	LastError       string
+
+	// RedeliveryOf is the ID of the redelivered delivery, if
+	// the delivery is made manually.
+	RedeliveryOf string
+
+	CreateTime  time.Time
+	DeliverTime time.Time
+}
+
+// DeliveryStatus denotes status of a delivery.
+type DeliveryStatus int
+
+// Following constans are the known delivery status.
+const (
+	DeliveryStatusUnknown   DeliveryStatus = 0
+	DeliveryStatusPending   DeliveryStatus = 1
+	DeliveryStatusSucceeded DeliveryStatus = 2
+
+	// DeliveryStatusFailed is a delivery that has reached
+	// the maximum attempts.
+	DeliveryStatusFailed DeliveryStatus = 3
+)
+
+var (
+	// DeliveryStatusList is a list of valid delivery status.
+	DeliveryStatusList = map[DeliveryStatus]struct{}{
+		DeliveryStatusPending:   {},
+		DeliveryStatusSucceeded: {},
+		DeliveryStatusFailed:    {},
+	}
+
+	// DeliveryStatusName maps delivery status to it's string
+	// representation.
+	DeliveryStatusName = map[DeliveryStatus]string{
+		DeliveryStatusPending:   "pending",
+		DeliveryStatusSucceeded: "succeeded",
+		DeliveryStatusFailed:    "failed",
+	}
+)
+
+// String implements the Stringer interface.
+func (s DeliveryStatus) String() string {
+	return DeliveryStatusName[s]
+}
+
+// Value implements the Valuer interface.
+func (s DeliveryStatus) Value() int {
+	return int(s)
+}
@@ -0,0 +1,107 @@
+// cache provides an in-memory read-through cache.
+//
+// A value is loaded on the first read of its key and kept
+// until its TTL is passed or it is invalidated. As the cache
+// is kept per process, an invalidation is not seen by other
+// instances, they read the new value once the cached one has
+// expired.
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// LoadFunc loads the value of the given key when it is not
+// cached.
+type LoadFunc[K comparable, V any] func(ctx context.Context, key K) (V, error)
+
+// Cache is an in-memory read-through cache with TTL.
+type Cache[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries map[K]entry[V]
+
+	// generation is increased on every invalidation, a value
+	// loaded before an invalidation is not cached as it might
+	// be outdated.
+	generation uint64
+
+	ttl     time.Duration
+	load    LoadFunc[K, V]
+	timeNow func() time.Time
+}
+
+type entry[V any] struct {
+	value      V
+	expireTime time.Time
+}
+
+// New creates a new cache loading values using the given load
+// function. A non-positive TTL disables caching, every read
+// loads the value.
+func New[K comparable, V any](ttl time.Duration, load LoadFunc[K, V]) *Cache[K, V] {
+	return &Cache[K, V]{
+		entries: make(map[K]entry[V]),
+		ttl:     ttl,
+		load:    load,
+		timeNow: time.Now,
+	}
+}
+
+// Get returns the value of the given key, it is loaded if it
+// is not cached or has expired. Errors are not cached.
+func (c *Cache[K, V]) Get(ctx context.Context, key K) (V, error) {
+	if c.ttl <= 0 {
+		return c.load(ctx, key)
+	}
+
+	c.mu.Lock()
+	now := c.timeNow()
+	e, ok := c.entries[key]
+	if ok && now.Before(e.expireTime) {
+		c.mu.Unlock()
+		return e.value, nil
+	}
+
+	// the expired entry is removed, so keys no longer read
+	// do not stay forever
+	delete(c.entries, key)
+	generation := c.generation
+	c.mu.Unlock()
+
+	value, err := c.load(ctx, key)
+	if err != nil {
+		return value, err
+	}
+
+	c.mu.Lock()
+	if generation == c.generation {
+		c.entries[key] = entry[V]{
+			value:      value,
+			expireTime: now.Add(c.ttl),
+		}
+	}
+	c.mu.Unlock()
+
+	return value, nil
+}
+
+// Invalidate removes the given key from the cache, its next
+// read loads the value again.
+func (c *Cache[K, V]) Invalidate(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+	c.generation++
+}
+
+// Clear removes all keys from the cache.
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[K]entry[V])
+	c.generation++
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Login denotes the result of a login.
+type Login struct {
+	UserID   string `json:"user_id"`
+	Fullname string `json:"fullname"`
+	Email    string `json:"email"`
+	Token    string `json:"token"`
+}
+
+// User denotes a user.
+type User struct {
+	ID       *string `json:"id,omitempty"`
+	Fullname *string `json:"fullname,omitempty"`
+	Email    *string `json:"email,omitempty"`
+	Type     *string `json:"type,omitempty"`
+	Quota    *int    `json:"quota,omitempty"`
+	Role     *string `json:"role,omitempty"`
+	Version  *int64  `json:"version,omitempty"`
+}
+
+// UserUpdate denotes the fields of a user it may update on
+// itself.
+type UserUpdate struct {
+	Fullname *string `json:"fullname,omitempty"`
+}
+
+// LoginSocial logs in using the given social token email, the
+// returned token authenticates the next requests.
+func (c *Client) LoginSocial(ctx context.Context, tokenEmail string) (Login, error) {
+	var res Login
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/v1/auth/social",
+		body:   map[string]string{"token_email": tokenEmail},
+		public: true,
+	}, &res)
+	if err != nil {
+		return Login{}, err
+	}
+
+	c.SetToken(res.UserID, res.Token)
+	return res, nil
+}
+
+// RefreshToken replaces the current access token with a new
+// one. It is called before a request when the token expires
+// soon, calling it directly is not needed.
+func (c *Client) RefreshToken(ctx context.Context) (string, error) {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	_, token := c.Token()
+	if token == "" {
+		return "", ErrTokenNotFound
+	}
+
+	return c.refreshToken(ctx, token)
+}
+
+// refreshToken exchanges the given token with a new one and
+// stores it. Caller must hold refreshMu.
+func (c *Client) refreshToken(ctx context.Context, token string) (string, error) {
+	var res struct {
+		Token string `json:"token"`
+	}
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/v1/auth/refresh",
+		body:   map[string]string{"token": token},
+		public: true,
+	}, &res)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	c.token = res.Token
+	c.mu.Unlock()
+
+	return res.Token, nil
+}
+
+// GetUserByID returns the user of the given ID.
+func (c *Client) GetUserByID(ctx context.Context, userID string) (User, error) {
+	var res User
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "users", userID),
+	}, &res)
+	return res, err
+}
+
+// UpdateUser updates the given fields of the user, expecting
+// it is still of the given version. The new version is
+// returned. A user can only update itself.
+func (c *Client) UpdateUser(ctx context.Context, userID string, version int64, user UserUpdate) (int64, error) {
+	res, err := c.do(ctx, request{
+		method:  http.MethodPatch,
+		path:    path("v1", "users", userID),
+		body:    user,
+		ifMatch: version,
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.version(), nil
+}
@@ -0,0 +1,495 @@
+// client provides a typed Go client of Memorify HTTP API.
+//
+// A Client sends the standard headers of the API, refreshes
+// its access token before it expires, retries requests failed
+// with 5xx status or timeout, and decodes the errors of the
+// response envelope into *Error. Creating requests supporting
+// Idempotency-Key header are retried as well, with the same
+// key.
+//
+// Typed methods are grouped by domain in their own file, e.g.
+// content.go, and mirror the routes of the OpenAPI document
+// served at /openapi.json.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	httplib "hbdtoyou/pkg/http"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Followings are default values for Client fields.
+const (
+	defaultTimeout       = 10 * time.Second
+	defaultMaxRetries    = 2
+	defaultRetryBackoff  = 200 * time.Millisecond
+	defaultRefreshWindow = 5 * time.Minute
+	defaultPathPrefix    = "/tenant"
+)
+
+var (
+	// ErrInvalidBaseURL is returned when the given base URL
+	// is not an absolute URL.
+	ErrInvalidBaseURL = errors.New("invalid base url")
+
+	// ErrInvalidSource is returned when the given source is
+	// empty.
+	ErrInvalidSource = errors.New("invalid source")
+
+	// ErrTokenNotFound is returned when calling an
+	// authenticated endpoint before login or setting a token.
+	ErrTokenNotFound = errors.New("token not found")
+
+	// ErrInvalidOption is returned when the given option value
+	// is invalid.
+	ErrInvalidOption = errors.New("invalid option")
+)
+
+// Client is a client of Memorify HTTP API. It is safe for
+// concurrent use.
+type Client struct {
+	baseURL    string
+	source     string
+	httpClient *http.Client
+	header     http.Header
+
+	maxRetries    int
+	retryBackoff  time.Duration
+	refreshWindow time.Duration
+
+	// mu guards userID and token.
+	mu     sync.Mutex
+	userID string
+	token  string
+
+	// refreshMu makes only one request refreshing the token
+	// at a time.
+	refreshMu sync.Mutex
+
+	timeNow func() time.Time
+}
+
+// Option controls the behavior of Client.
+type Option func(*Client) error
+
+// WithHTTPClient returns Option to send requests using the
+// given HTTP client. Its timeout is the timeout of a single
+// attempt of a request.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return Option(func(c *Client) error {
+		if httpClient == nil {
+			return ErrInvalidOption
+		}
+
+		c.httpClient = httpClient
+		return nil
+	})
+}
+
+// WithToken returns Option to authenticate requests using the
+// given user ID and access token, e.g. a token of a previous
+// login.
+func WithToken(userID, token string) Option {
+	return Option(func(c *Client) error {
+		c.SetToken(userID, token)
+		return nil
+	})
+}
+
+// WithHeader returns Option to send the given header in every
+// request, e.g. the tenant header of the school.
+func WithHeader(key, value string) Option {
+	return Option(func(c *Client) error {
+		c.header.Set(key, value)
+		return nil
+	})
+}
+
+// WithRetry returns Option to set the maximum retries of a
+// failed request and the backoff before the first retry. The
+// backoff is doubled on each retry.
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return Option(func(c *Client) error {
+		if maxRetries < 0 || backoff < 0 {
+			return ErrInvalidOption
+		}
+
+		c.maxRetries = maxRetries
+		c.retryBackoff = backoff
+		return nil
+	})
+}
+
+// WithRefreshWindow returns Option to set how long before its
+// expiration the access token is refreshed.
+func WithRefreshWindow(window time.Duration) Option {
+	return Option(func(c *Client) error {
+		if window < 0 {
+			return ErrInvalidOption
+		}
+
+		c.refreshWindow = window
+		return nil
+	})
+}
+
+// New creates a new Client of the API served at the given base
+// URL, e.g. https://api.hbdtoyou.com/tenant. The given source
+// is sent in X-Source header of every request.
+//
+// The base URL defaults to /tenant path if it has no path.
+func New(baseURL, source string, options ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, ErrInvalidBaseURL
+	}
+	if strings.Trim(u.Path, "/") == "" {
+		u.Path = defaultPathPrefix
+	}
+
+	if source == "" {
+		return nil, ErrInvalidSource
+	}
+
+	c := &Client{
+		baseURL:       strings.TrimRight(u.String(), "/"),
+		source:        source,
+		httpClient:    &http.Client{Timeout: defaultTimeout},
+		header:        make(http.Header),
+		maxRetries:    defaultMaxRetries,
+		retryBackoff:  defaultRetryBackoff,
+		refreshWindow: defaultRefreshWindow,
+		timeNow:       time.Now,
+	}
+
+	// apply options
+	for _, opt := range options {
+		err := opt(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// SetToken sets the user ID and access token authenticating
+// the next requests.
+func (c *Client) SetToken(userID, token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.userID = userID
+	c.token = token
+}
+
+// Token returns the user ID and access token authenticating
+// the requests, the token might have been refreshed.
+func (c *Client) Token() (string, string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.userID, c.token
+}
+
+// request denotes an API request.
+type request struct {
+	method string
+	path   string
+	query  url.Values
+
+	// body is encoded as JSON if it is not nil, unless
+	// contentType is set and body is a []byte.
+	body        interface{}
+	contentType string
+
+	accept  string
+	ifMatch int64
+	public  bool
+
+	// idempotent sends the request with a new Idempotency-Key
+	// header, the same key is sent on retries.
+	idempotent bool
+}
+
+// response denotes an API response.
+type response struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+	pagination *Pagination
+}
+
+// envelope is the standard JSON object of API responses.
+type envelope struct {
+	Data       json.RawMessage `json:"data"`
+	Pagination *Pagination     `json:"pagination"`
+	Errors     []string        `json:"errors"`
+}
+
+// Pagination describes the page of a listed data.
+type Pagination struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+	Total int `json:"total"`
+}
+
+// do sends the given request and decodes the data of the
+// response envelope into data, if it is not nil.
+func (c *Client) do(ctx context.Context, req request, data interface{}) (*response, error) {
+	var body []byte
+	contentType := req.contentType
+	if raw, ok := req.body.([]byte); ok && contentType != "" {
+		body = raw
+	} else if req.body != nil {
+		var err error
+		body, err = json.Marshal(req.body)
+		if err != nil {
+			return nil, err
+		}
+		contentType = "application/json"
+	}
+
+	var userID, token string
+	if !req.public {
+		var err error
+		userID, token, err = c.getToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	u := c.baseURL + req.path
+	if len(req.query) > 0 {
+		u += "?" + req.query.Encode()
+	}
+
+	// creating is not retried, a request timed out might have
+	// been processed, unless the server replays its response
+	retryable := req.method != http.MethodPost || req.idempotent
+
+	var idempotencyKey string
+	if req.idempotent {
+		idempotencyKey = uuid.NewString()
+	}
+
+	var res *response
+	for attempt := 0; ; attempt++ {
+		httpReq, err := http.NewRequestWithContext(ctx, req.method, u, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+
+		for key, values := range c.header {
+			httpReq.Header[key] = values
+		}
+		httpReq.Header.Set("X-Source", c.source)
+		if contentType != "" {
+			httpReq.Header.Set("Content-Type", contentType)
+		}
+		if req.accept != "" {
+			httpReq.Header.Set("Accept", req.accept)
+		}
+		if !req.public {
+			httpReq.Header.Set("X-UserID", userID)
+			httpReq.Header.Set("Authorization", "Bearer "+token)
+		}
+		if req.ifMatch > 0 {
+			httpReq.Header.Set("If-Match", httplib.FormatVersionETag(req.ifMatch))
+		}
+		if idempotencyKey != "" {
+			httpReq.Header.Set("Idempotency-Key", idempotencyKey)
+		}
+
+		res, err = c.send(httpReq)
+		if err == nil && res.statusCode < http.StatusInternalServerError {
+			break
+		}
+
+		// retry failures of the server and timeouts only
+		if !retryable || attempt >= c.maxRetries || ctx.Err() != nil || (err != nil && !isTimeout(err)) {
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+
+		err = sleep(ctx, c.retryBackoff<<attempt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// non-JSON response, e.g. a rendered PDF
+	if !strings.HasPrefix(res.header.Get("Content-Type"), "application/json") {
+		if res.statusCode >= http.StatusBadRequest {
+			return nil, &Error{StatusCode: res.statusCode, RetryAfter: res.retryAfter()}
+		}
+		return res, nil
+	}
+
+	var env envelope
+	err := json.Unmarshal(res.body, &env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	res.pagination = env.Pagination
+
+	if res.statusCode >= http.StatusBadRequest {
+		return nil, &Error{StatusCode: res.statusCode, Codes: env.Errors, RetryAfter: res.retryAfter()}
+	}
+
+	if data != nil && len(env.Data) > 0 {
+		err = json.Unmarshal(env.Data, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode response data: %w", err)
+		}
+	}
+
+	return res, nil
+}
+
+// send sends the given HTTP request and reads its response.
+func (c *Client) send(httpReq *http.Request) (*response, error) {
+	httpRes, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpRes.Body.Close()
+
+	body, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response{
+		statusCode: httpRes.StatusCode,
+		header:     httpRes.Header,
+		body:       body,
+	}, nil
+}
+
+// version returns the data version in ETag header of the
+// response, it is 0 if the response has no version.
+func (r *response) version() int64 {
+	value := strings.TrimPrefix(r.header.Get("ETag"), "W/")
+	value, err := strconv.Unquote(value)
+	if err != nil {
+		return 0
+	}
+
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return version
+}
+
+// retryAfter returns the duration in Retry-After header of
+// the response, in seconds, it is 0 if the response has none.
+func (r *response) retryAfter() time.Duration {
+	seconds, err := strconv.Atoi(r.header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// getToken returns the user ID and access token for the next
+// request, the token is refreshed if it expires soon.
+func (c *Client) getToken(ctx context.Context) (string, string, error) {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	userID, token := c.Token()
+	if token == "" {
+		return "", "", ErrTokenNotFound
+	}
+
+	// an expired token can not be refreshed, the request is
+	// sent as is to get the error from the server
+	expireTime, ok := getTokenExpireTime(token)
+	now := c.timeNow()
+	if !ok || now.Add(c.refreshWindow).Before(expireTime) || !now.Before(expireTime) {
+		return userID, token, nil
+	}
+
+	token, err := c.refreshToken(ctx, token)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	return userID, token, nil
+}
+
+// getTokenExpireTime returns the expiration time in the claims
+// of the given JWT. The signature is not verified, it is only
+// used to know when to refresh the token.
+func getTokenExpireTime(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims struct {
+		ExpiresAt *float64 `json:"exp"`
+	}
+	err = json.Unmarshal(payload, &claims)
+	if err != nil || claims.ExpiresAt == nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(int64(*claims.ExpiresAt), 0), true
+}
+
+// isTimeout returns true if the given error is a timeout of
+// an attempt.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// sleep waits for the given duration or until the context is
+// done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// path joins the given path segments, escaping each of them.
+func path(segments ...string) string {
+	var b strings.Builder
+	for _, segment := range segments {
+		b.WriteString("/")
+		b.WriteString(url.PathEscape(segment))
+	}
+	return b.String()
+}
@@ -0,0 +1,357 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"hbdtoyou/internal/auth"
+	authhttphandler "hbdtoyou/internal/auth/handler/http"
+	authservice "hbdtoyou/internal/auth/service"
+	"hbdtoyou/internal/content"
+	contenthttphandler "hbdtoyou/internal/content/handler/http"
+	"hbdtoyou/pkg/client"
+	httplib "hbdtoyou/pkg/http"
+	"hbdtoyou/pkg/idempotency/store/memory"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/gorilla/mux"
+)
+
+const (
+	testSecretKey = "test-secret-key"
+	testUserID    = "c0ffee00-0000-4000-8000-000000000001"
+)
+
+// contentService is a content.Service keeping the created
+// contents in memory.
+type contentService struct {
+	content.Service
+
+	mu       sync.Mutex
+	created  int
+	restored int
+}
+
+func (s *contentService) CreateContent(ctx context.Context, reqContent content.Content) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.created++
+	return "content-1", nil
+}
+
+func (s *contentService) GetContentByID(ctx context.Context, contentID string) (content.Content, error) {
+	return content.Content{
+		ID:         contentID,
+		UserID:     testUserID,
+		TemplateID: "template-1",
+		Status:     content.StatusActive,
+		Version:    1,
+	}, nil
+}
+
+func (s *contentService) RestoreContentByID(ctx context.Context, contentID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.restored++
+	return nil
+}
+
+// testServer serves the real auth and content HTTP handlers,
+// failing the requests as told by fail.
+type testServer struct {
+	*httptest.Server
+	content *contentService
+
+	mu sync.Mutex
+
+	// fail handles a request instead of the handlers if it
+	// returns true.
+	fail func(w http.ResponseWriter, r *http.Request, attempt int) bool
+
+	// attempts counts the requests per method and path.
+	attempts map[string]int
+
+	// requests records the headers of the requests.
+	requests []http.Header
+}
+
+func newTestServer(t *testing.T) *testServer {
+	authSvc, err := authservice.New(nil, authservice.WithConfig(authservice.Config{
+		PasswordSalt:    "salt",
+		TokenExpiration: time.Hour,
+		TokenSecretKey:  testSecretKey,
+		ClientID:        "client-id",
+	}))
+	if err != nil {
+		t.Fatalf("authservice.New() error = %v", err)
+	}
+
+	authHTTP, err := authhttphandler.New(authSvc,
+		authhttphandler.WithHandler(authhttphandler.HandlerRefreshToken))
+	if err != nil {
+		t.Fatalf("authhttphandler.New() error = %v", err)
+	}
+
+	contentSvc := &contentService{}
+	contentHTTP, err := contenthttphandler.New(contentSvc, authSvc,
+		contenthttphandler.WithIdempotency(httplib.NewIdempotency(memory.New(), time.Hour)),
+		contenthttphandler.WithHandler(contenthttphandler.HandlerContents),
+		contenthttphandler.WithHandler(contenthttphandler.HandlerContent),
+		contenthttphandler.WithHandler(contenthttphandler.HandlerContentRestore))
+	if err != nil {
+		t.Fatalf("contenthttphandler.New() error = %v", err)
+	}
+
+	router := mux.NewRouter()
+	appRouter := router.PathPrefix("/tenant").Subrouter()
+	authHTTP.Start(appRouter)
+	contentHTTP.Start(appRouter)
+	appRouter.Use(httplib.Authenticate(func(ctx context.Context, token string) (string, error) {
+		tokenData, err := authSvc.ValidateToken(ctx, token)
+		return tokenData.UserID, err
+	}))
+
+	ts := &testServer{
+		content:  contentSvc,
+		attempts: make(map[string]int),
+	}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		key := r.Method + " " + r.URL.Path
+		ts.attempts[key]++
+		attempt := ts.attempts[key]
+		ts.requests = append(ts.requests, r.Header.Clone())
+		fail := ts.fail
+		ts.mu.Unlock()
+
+		if fail != nil && fail(w, r, attempt) {
+			return
+		}
+		router.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func (ts *testServer) getAttempts(method, path string) int {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.attempts[method+" "+path]
+}
+
+func (ts *testServer) getRequests() []http.Header {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return append([]http.Header(nil), ts.requests...)
+}
+
+// newToken returns an access token of the test user expiring
+// at the given time.
+func newToken(t *testing.T, expireTime time.Time) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": testUserID,
+		"type":    int(auth.TypeFree),
+		"exp":     expireTime.Unix(),
+	}).SignedString([]byte(testSecretKey))
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	return token
+}
+
+func newTestClient(t *testing.T, ts *testServer, options ...client.Option) *client.Client {
+	options = append([]client.Option{
+		client.WithToken(testUserID, newToken(t, time.Now().Add(time.Hour))),
+		client.WithRetry(2, time.Millisecond),
+	}, options...)
+
+	c, err := client.New(ts.URL, "test", options...)
+	if err != nil {
+		t.Fatalf("client.New() error = %v", err)
+	}
+	return c
+}
+
+func TestClientRefreshesExpiringToken(t *testing.T) {
+	ts := newTestServer(t)
+
+	expiring := newToken(t, time.Now().Add(time.Minute))
+	c := newTestClient(t, ts, client.WithToken(testUserID, expiring), client.WithRefreshWindow(5*time.Minute))
+
+	res, err := c.GetContentByID(context.Background(), "content-1")
+	if err != nil {
+		t.Fatalf("GetContentByID() error = %v", err)
+	}
+	if res.ID == nil || *res.ID != "content-1" {
+		t.Errorf("GetContentByID() = %+v, want content-1", res)
+	}
+
+	if got := ts.getAttempts(http.MethodPost, "/tenant/v1/auth/refresh"); got != 1 {
+		t.Fatalf("refresh requests = %d, want 1", got)
+	}
+
+	// the refreshed token is used and kept
+	userID, token := c.Token()
+	if userID != testUserID || token == expiring || token == "" {
+		t.Fatalf("Token() = %s, %q, want a refreshed token", userID, token)
+	}
+
+	requests := ts.getRequests()
+	if got := requests[len(requests)-1].Get("Authorization"); got != "Bearer "+token {
+		t.Errorf("Authorization = %q, want the refreshed token", got)
+	}
+
+	// a token not expiring soon is not refreshed again
+	_, err = c.GetContentByID(context.Background(), "content-1")
+	if err != nil {
+		t.Fatalf("GetContentByID() error = %v", err)
+	}
+	if got := ts.getAttempts(http.MethodPost, "/tenant/v1/auth/refresh"); got != 1 {
+		t.Errorf("refresh requests = %d, want 1", got)
+	}
+}
+
+func TestClientDoesNotRefreshExpiredToken(t *testing.T) {
+	ts := newTestServer(t)
+
+	expired := newToken(t, time.Now().Add(-time.Minute))
+	c := newTestClient(t, ts, client.WithToken(testUserID, expired))
+
+	_, err := c.GetContentByID(context.Background(), "content-1")
+
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("GetContentByID() error = %v, want 401", err)
+	}
+	if got := ts.getAttempts(http.MethodPost, "/tenant/v1/auth/refresh"); got != 0 {
+		t.Errorf("refresh requests = %d, want 0", got)
+	}
+}
+
+func TestClientRetriesServerErrors(t *testing.T) {
+	ts := newTestServer(t)
+	ts.fail = func(w http.ResponseWriter, r *http.Request, attempt int) bool {
+		if attempt > 2 {
+			return false
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return true
+	}
+
+	c := newTestClient(t, ts)
+	_, err := c.GetContentByID(context.Background(), "content-1")
+	if err != nil {
+		t.Fatalf("GetContentByID() error = %v", err)
+	}
+	if got := ts.getAttempts(http.MethodGet, "/tenant/v1/contents/content-1"); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+
+	// the last failure is returned once the retries run out
+	ts.fail = func(w http.ResponseWriter, r *http.Request, attempt int) bool {
+		httplib.WriteErrorResponse(w, http.StatusBadGateway, []string{"BAD_GATEWAY"})
+		return true
+	}
+
+	_, err = c.GetContentByID(context.Background(), "content-2")
+
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("GetContentByID() error = %v, want 502", err)
+	}
+	if got := ts.getAttempts(http.MethodGet, "/tenant/v1/contents/content-2"); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+}
+
+func TestClientRetriesTimeouts(t *testing.T) {
+	ts := newTestServer(t)
+	ts.fail = func(w http.ResponseWriter, r *http.Request, attempt int) bool {
+		if attempt > 1 {
+			return false
+		}
+		time.Sleep(200 * time.Millisecond)
+		return true
+	}
+
+	c := newTestClient(t, ts, client.WithHTTPClient(&http.Client{Timeout: 50 * time.Millisecond}))
+	_, err := c.GetContentByID(context.Background(), "content-1")
+	if err != nil {
+		t.Fatalf("GetContentByID() error = %v", err)
+	}
+	if got := ts.getAttempts(http.MethodGet, "/tenant/v1/contents/content-1"); got != 2 {
+		t.Errorf("attempts = %d, want 2", got)
+	}
+}
+
+func TestClientDoesNotRetryNonIdempotentPost(t *testing.T) {
+	ts := newTestServer(t)
+	ts.fail = func(w http.ResponseWriter, r *http.Request, attempt int) bool {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return true
+	}
+
+	c := newTestClient(t, ts, client.WithHTTPClient(&http.Client{Timeout: 50 * time.Millisecond}))
+	err := c.RestoreContent(context.Background(), "content-1")
+
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("RestoreContent() error = %v, want 503", err)
+	}
+	if got := ts.getAttempts(http.MethodPost, "/tenant/v1/contents/content-1/restore"); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+
+	// a timed out request might have been processed
+	ts.fail = func(w http.ResponseWriter, r *http.Request, attempt int) bool {
+		time.Sleep(200 * time.Millisecond)
+		return true
+	}
+
+	err = c.RestoreContent(context.Background(), "content-2")
+	if err == nil {
+		t.Fatal("RestoreContent() error = nil, want timeout")
+	}
+	if got := ts.getAttempts(http.MethodPost, "/tenant/v1/contents/content-2/restore"); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestClientRetriesIdempotentPostWithSameKey(t *testing.T) {
+	ts := newTestServer(t)
+	ts.fail = func(w http.ResponseWriter, r *http.Request, attempt int) bool {
+		if attempt > 1 {
+			return false
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return true
+	}
+
+	c := newTestClient(t, ts)
+	templateID := "template-1"
+	id, err := c.CreateContent(context.Background(), client.Content{TemplateID: &templateID})
+	if err != nil {
+		t.Fatalf("CreateContent() error = %v", err)
+	}
+	if id != "content-1" {
+		t.Errorf("CreateContent() = %q, want content-1", id)
+	}
+
+	requests := ts.getRequests()
+	if len(requests) != 2 {
+		t.Fatalf("requests = %d, want 2", len(requests))
+	}
+
+	key := requests[0].Get("Idempotency-Key")
+	if key == "" || requests[1].Get("Idempotency-Key") != key {
+		t.Errorf("Idempotency-Key = %q then %q, want the same key", key, requests[1].Get("Idempotency-Key"))
+	}
+	if ts.content.created != 1 {
+		t.Errorf("created contents = %d, want 1", ts.content.created)
+	}
+}
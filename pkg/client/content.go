@@ -0,0 +1,258 @@
+package client
+
+import (
+	"context"
+	"hbdtoyou/pkg/jsondiff"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Content denotes a content.
+type Content struct {
+	ID                    *string   `json:"id,omitempty"`
+	UserID                *string   `json:"user_id,omitempty"`
+	Username              *string   `json:"user_name,omitempty"`
+	TemplateID            *string   `json:"template_id,omitempty"`
+	TemplateName          *string   `json:"template_name,omitempty"`
+	TemplateLabel         *string   `json:"template_label,omitempty"`
+	DetailContentJSONText *string   `json:"detail_content_json_text,omitempty"`
+	MediaIDs              *[]string `json:"media_ids,omitempty"`
+	Type                  *string   `json:"type,omitempty"`
+	Status                *string   `json:"status,omitempty"`
+	Version               *int64    `json:"version,omitempty"`
+	DeleteTime            *string   `json:"delete_time,omitempty"`
+}
+
+// Revision denotes a revision of a content.
+type Revision struct {
+	Number                *int    `json:"number,omitempty"`
+	UserID                *string `json:"user_id,omitempty"`
+	TemplateID            *string `json:"template_id,omitempty"`
+	DetailContentJSONText *string `json:"detail_content_json_text,omitempty"`
+	Status                *string `json:"status,omitempty"`
+	CreateTime            *string `json:"create_time,omitempty"`
+}
+
+// RevisionDiff denotes the changes between two revisions of a content.
+type RevisionDiff struct {
+	ContentID *string            `json:"content_id,omitempty"`
+	From      *int               `json:"from,omitempty"`
+	To        *int               `json:"to,omitempty"`
+	Changes   *[]jsondiff.Change `json:"changes,omitempty"`
+}
+
+// Member denotes a member invited to a content.
+type Member struct {
+	ID         *string `json:"id,omitempty"`
+	ContentID  *string `json:"content_id,omitempty"`
+	UserID     *string `json:"user_id,omitempty"`
+	Email      *string `json:"email,omitempty"`
+	Role       *string `json:"role,omitempty"`
+	Status     *string `json:"status,omitempty"`
+	InvitedBy  *string `json:"invited_by,omitempty"`
+	CreateTime *string `json:"create_time,omitempty"`
+}
+
+// GetContentsFilter denotes the filter of GetContents.
+type GetContentsFilter struct {
+	UserID        string
+	TemplateID    string
+	TemplateLabel string
+	Status        string
+
+	// SharedWithMe lists the contents shared with the caller
+	// instead.
+	SharedWithMe bool
+}
+
+// CreateContent creates the given content and returns its ID.
+func (c *Client) CreateContent(ctx context.Context, content Content) (string, error) {
+	var res string
+	_, err := c.do(ctx, request{
+		method:     http.MethodPost,
+		path:       "/v1/contents",
+		body:       content,
+		idempotent: true,
+	}, &res)
+	return res, err
+}
+
+// GetContents returns the contents matching the given filter.
+func (c *Client) GetContents(ctx context.Context, filter GetContentsFilter) ([]Content, error) {
+	query := make(url.Values)
+	setQuery(query, "user_id", filter.UserID)
+	setQuery(query, "template_id", filter.TemplateID)
+	setQuery(query, "template_label", filter.TemplateLabel)
+	setQuery(query, "status", filter.Status)
+	if filter.SharedWithMe {
+		query.Set("shared_with_me", "true")
+	}
+
+	var res []Content
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/v1/contents",
+		query:  query,
+	}, &res)
+	return res, err
+}
+
+// GetContentByID returns the content of the given ID.
+func (c *Client) GetContentByID(ctx context.Context, contentID string) (Content, error) {
+	var res Content
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "contents", contentID),
+	}, &res)
+	return res, err
+}
+
+// UpdateContent updates the given fields of the content,
+// expecting it is still of the given version. The new version
+// is returned.
+func (c *Client) UpdateContent(ctx context.Context, contentID string, version int64, content Content) (int64, error) {
+	res, err := c.do(ctx, request{
+		method:  http.MethodPatch,
+		path:    path("v1", "contents", contentID),
+		body:    content,
+		ifMatch: version,
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.version(), nil
+}
+
+// DeleteContent moves the content of the given ID to the
+// trash.
+func (c *Client) DeleteContent(ctx context.Context, contentID string) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   path("v1", "contents", contentID),
+	}, nil)
+	return err
+}
+
+// RestoreContent restores the content of the given ID from the
+// trash.
+func (c *Client) RestoreContent(ctx context.Context, contentID string) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   path("v1", "contents", contentID, "restore"),
+	}, nil)
+	return err
+}
+
+// GetTrashContents returns the contents in the trash.
+func (c *Client) GetTrashContents(ctx context.Context) ([]Content, error) {
+	var res []Content
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/v1/trash/contents",
+	}, &res)
+	return res, err
+}
+
+// GetContentRevisions returns the revisions of the content.
+func (c *Client) GetContentRevisions(ctx context.Context, contentID string) ([]Revision, error) {
+	var res []Revision
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "contents", contentID, "revisions"),
+	}, &res)
+	return res, err
+}
+
+// RestoreContentRevision restores the content to the given
+// revision number. The new version is returned.
+func (c *Client) RestoreContentRevision(ctx context.Context, contentID string, number int) (int64, error) {
+	res, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   path("v1", "contents", contentID, "revisions", strconv.Itoa(number), "restore"),
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.version(), nil
+}
+
+// GetContentRevisionDiff returns the changes of the content
+// between the given revision numbers.
+func (c *Client) GetContentRevisionDiff(ctx context.Context, contentID string, from, to int) (RevisionDiff, error) {
+	query := make(url.Values)
+	query.Set("from", strconv.Itoa(from))
+	query.Set("to", strconv.Itoa(to))
+
+	var res RevisionDiff
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "contents", contentID, "revisions", "diff"),
+		query:  query,
+	}, &res)
+	return res, err
+}
+
+// GetContentMembers returns the members of the content.
+func (c *Client) GetContentMembers(ctx context.Context, contentID string) ([]Member, error) {
+	var res []Member
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "contents", contentID, "members"),
+	}, &res)
+	return res, err
+}
+
+// InviteContentMember invites the given member to the content
+// and returns the member ID.
+func (c *Client) InviteContentMember(ctx context.Context, contentID string, member Member) (string, error) {
+	var res string
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   path("v1", "contents", contentID, "members"),
+		body:   member,
+	}, &res)
+	return res, err
+}
+
+// RemoveContentMember removes the member from the content.
+func (c *Client) RemoveContentMember(ctx context.Context, contentID, memberID string) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   path("v1", "contents", contentID, "members", memberID),
+	}, nil)
+	return err
+}
+
+// GetInvitations returns the pending invitations of the
+// caller.
+func (c *Client) GetInvitations(ctx context.Context) ([]Member, error) {
+	var res []Member
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/v1/invitations",
+	}, &res)
+	return res, err
+}
+
+// AcceptInvitation accepts the invitation of the given member
+// ID.
+func (c *Client) AcceptInvitation(ctx context.Context, memberID string) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   path("v1", "invitations", memberID, "accept"),
+	}, nil)
+	return err
+}
+
+// DeclineInvitation declines the invitation of the given
+// member ID.
+func (c *Client) DeclineInvitation(ctx context.Context, memberID string) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   path("v1", "invitations", memberID, "decline"),
+	}, nil)
+	return err
+}
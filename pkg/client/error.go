@@ -0,0 +1,113 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Followings are the known errors of the API shared by its
+// domains. An *Error having their code matches them using
+// errors.Is(), e.g. errors.Is(err, client.ErrDataNotFound).
+var (
+	ErrBadRequest               = errors.New("BAD_REQUEST")
+	ErrDataNotFound             = errors.New("DATA_NOT_FOUND")
+	ErrExpiredToken             = errors.New("EXPIRED_TOKEN")
+	ErrForbidden                = errors.New("FORBIDDEN")
+	ErrIdempotencyKeyInProgress = errors.New("IDEMPOTENCY_KEY_IN_PROGRESS")
+	ErrIdempotencyKeyMismatch   = errors.New("IDEMPOTENCY_KEY_MISMATCH")
+	ErrInternalServer           = errors.New("INTERNAL_SERVER_ERROR")
+	ErrInvalidIdempotencyKey    = errors.New("INVALID_IDEMPOTENCY_KEY")
+	ErrInvalidIfMatch           = errors.New("INVALID_IF_MATCH")
+	ErrInvalidToken             = errors.New("INVALID_TOKEN")
+	ErrInvalidUserID            = errors.New("INVALID_USER_ID")
+	ErrMethodNotAllowed         = errors.New("METHOD_NOT_ALLOWED")
+	ErrPreconditionRequired     = errors.New("PRECONDITION_REQUIRED")
+	ErrRequestTimeout           = errors.New("REQUEST_TIMEOUT")
+	ErrSchoolInactive           = errors.New("SCHOOL_INACTIVE")
+	ErrSchoolMismatch           = errors.New("SCHOOL_MISMATCH")
+	ErrSchoolNotFound           = errors.New("SCHOOL_NOT_FOUND")
+	ErrSourceNotProvided        = errors.New("SOURCE_NOT_PROVIDED")
+	ErrTooManyRequest           = errors.New("TOO_MANY_REQUEST")
+	ErrUnauthorizedAccess       = errors.New("UNAUTHORIZED_ACCESS")
+	ErrVersionConflict          = errors.New("VERSION_CONFLICT")
+)
+
+// codeErrors maps error codes into the known errors.
+var codeErrors = func() map[string]error {
+	res := make(map[string]error)
+	for _, err := range []error{
+		ErrBadRequest,
+		ErrDataNotFound,
+		ErrExpiredToken,
+		ErrForbidden,
+		ErrIdempotencyKeyInProgress,
+		ErrIdempotencyKeyMismatch,
+		ErrInternalServer,
+		ErrInvalidIdempotencyKey,
+		ErrInvalidIfMatch,
+		ErrInvalidToken,
+		ErrInvalidUserID,
+		ErrMethodNotAllowed,
+		ErrPreconditionRequired,
+		ErrRequestTimeout,
+		ErrSchoolInactive,
+		ErrSchoolMismatch,
+		ErrSchoolNotFound,
+		ErrSourceNotProvided,
+		ErrTooManyRequest,
+		ErrUnauthorizedAccess,
+		ErrVersionConflict,
+	} {
+		res[err.Error()] = err
+	}
+	return res
+}()
+
+// Error is returned when the API responds with an error
+// status.
+type Error struct {
+	StatusCode int
+
+	// Codes are the errors of the response envelope, e.g.
+	// DATA_NOT_FOUND.
+	Codes []string
+
+	// RetryAfter is how long to wait before sending the
+	// request again when it is rate limited, 0 otherwise.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if len(e.Codes) == 0 {
+		return fmt.Sprintf("status %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("status %d: %s", e.StatusCode, strings.Join(e.Codes, ", "))
+}
+
+// Is returns true if the target is the known error of one of
+// the codes.
+func (e *Error) Is(target error) bool {
+	for _, code := range e.Codes {
+		if err, ok := codeErrors[code]; ok && err == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasCode returns true if the error has the given code, it is
+// used for codes without a known error, e.g. VOUCHER_EXHAUSTED.
+func (e *Error) HasCode(code string) bool {
+	for _, c := range e.Codes {
+		if c == code {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// Media denotes an uploaded media.
+type Media struct {
+	ID         *string        `json:"id,omitempty"`
+	UserID     *string        `json:"user_id,omitempty"`
+	FileName   *string        `json:"file_name,omitempty"`
+	MIMEType   *string        `json:"mime_type,omitempty"`
+	Size       *int64         `json:"size,omitempty"`
+	Status     *string        `json:"status,omitempty"`
+	URL        *string        `json:"url,omitempty"`
+	Variants   []MediaVariant `json:"variants,omitempty"`
+	CreateTime *string        `json:"create_time,omitempty"`
+}
+
+// MediaVariant denotes a processed variant of a media, e.g. a thumbnail.
+type MediaVariant struct {
+	Name     *string `json:"name,omitempty"`
+	MIMEType *string `json:"mime_type,omitempty"`
+	Width    *int    `json:"width,omitempty"`
+	Height   *int    `json:"height,omitempty"`
+	Size     *int64  `json:"size,omitempty"`
+	URL      *string `json:"url,omitempty"`
+}
+
+// UploadMedia uploads the given file and returns the media ID.
+func (c *Client) UploadMedia(ctx context.Context, fileName string, file io.Reader) (string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = io.Copy(part, file)
+	if err != nil {
+		return "", err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return "", err
+	}
+
+	var res string
+	_, err = c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/v1/media",
+		body:        body.Bytes(),
+		contentType: writer.FormDataContentType(),
+	}, &res)
+	return res, err
+}
+
+// GetMediaByID returns the media of the given ID.
+func (c *Client) GetMediaByID(ctx context.Context, mediaID string) (Media, error) {
+	var res Media
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "media", mediaID),
+	}, &res)
+	return res, err
+}
+
+// DeleteMedia deletes the media of the given ID.
+func (c *Client) DeleteMedia(ctx context.Context, mediaID string) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   path("v1", "media", mediaID),
+	}, nil)
+	return err
+}
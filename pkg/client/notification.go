@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Notification denotes an in-app notification.
+type Notification struct {
+	ID         *string           `json:"id,omitempty"`
+	Event      *string           `json:"event,omitempty"`
+	Subject    *string           `json:"subject,omitempty"`
+	Body       *string           `json:"body,omitempty"`
+	Data       map[string]string `json:"data,omitempty"`
+	CreateTime *string           `json:"create_time,omitempty"`
+	ReadTime   *string           `json:"read_time,omitempty"`
+}
+
+// GetNotificationsFilter denotes the filter of
+// GetNotifications.
+type GetNotificationsFilter struct {
+	Unread bool
+	Page   int
+	Limit  int
+}
+
+// GetNotifications returns the notifications of the caller
+// matching the given filter.
+func (c *Client) GetNotifications(ctx context.Context, filter GetNotificationsFilter) ([]Notification, error) {
+	query := make(url.Values)
+	if filter.Unread {
+		query.Set("unread", "true")
+	}
+	setPageQuery(query, filter.Page, filter.Limit)
+
+	var res []Notification
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/v1/notifications",
+		query:  query,
+	}, &res)
+	return res, err
+}
+
+// ReadNotification marks the notification of the given ID as
+// read.
+func (c *Client) ReadNotification(ctx context.Context, notificationID string) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   path("v1", "notifications", notificationID, "read"),
+	}, nil)
+	return err
+}
@@ -0,0 +1,465 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Payment denotes a payment.
+type Payment struct {
+	ID                  *string `json:"id,omitempty"`
+	UserID              *string `json:"user_id,omitempty"`
+	UserName            *string `json:"user_name,omitempty"`
+	UserType            *string `json:"user_type,omitempty"`
+	UserQuota           *int    `json:"user_quota,omitempty"`
+	TemplateID          *string `json:"template_id,omitempty"`
+	TemplateName        *string `json:"template_name,omitempty"`
+	TemplateLabel       *string `json:"template_label,omitempty"`
+	ProductType         *string `json:"product_type,omitempty"`
+	ProductID           *string `json:"product_id,omitempty"`
+	ContentID           *string `json:"content_id,omitempty"`
+	Amount              *int64  `json:"amount,omitempty"`
+	Currency            *string `json:"currency,omitempty"`
+	AmountDisplay       *string `json:"amount_display,omitempty"`
+	Quota               *int    `json:"quota,omitempty"`
+	SubscriptionID      *string `json:"subscription_id,omitempty"`
+	VoucherCode         *string `json:"voucher_code,omitempty"`
+	Discount            *int64  `json:"discount,omitempty"`
+	DiscountDisplay     *string `json:"discount_display,omitempty"`
+	RefundedAmount      *int64  `json:"refunded_amount,omitempty"`
+	RefundedDisplay     *string `json:"refunded_amount_display,omitempty"`
+	ReviewerID          *string `json:"reviewer_id,omitempty"`
+	ClaimTime           *string `json:"claim_time,omitempty"`
+	ReviewReason        *string `json:"review_reason,omitempty"`
+	ProofPaymentURL     *string `json:"proof_payment_url,omitempty"`
+	ProofPaymentMediaID *string `json:"proof_payment_media_id,omitempty"`
+	Date                *string `json:"date,omitempty"`
+	Status              *string `json:"status,omitempty"`
+	Version             *int64  `json:"version,omitempty"`
+}
+
+// Bundle denotes a quota bundle on sale.
+type Bundle struct {
+	ID           *string `json:"id,omitempty"`
+	Name         *string `json:"name,omitempty"`
+	Quota        *int    `json:"quota,omitempty"`
+	Price        *int64  `json:"price,omitempty"`
+	Currency     *string `json:"currency,omitempty"`
+	PriceDisplay *string `json:"price_display,omitempty"`
+}
+
+// Plan denotes a subscription plan.
+type Plan struct {
+	ID           *string `json:"id,omitempty"`
+	Name         *string `json:"name,omitempty"`
+	Description  *string `json:"description,omitempty"`
+	Price        *int64  `json:"price,omitempty"`
+	Currency     *string `json:"currency,omitempty"`
+	PriceDisplay *string `json:"price_display,omitempty"`
+	Interval     *string `json:"interval,omitempty"`
+	Quota        *int    `json:"quota,omitempty"`
+	Active       *bool   `json:"active,omitempty"`
+	Version      *int64  `json:"version,omitempty"`
+}
+
+// Subscription denotes a subscription to a plan.
+type Subscription struct {
+	ID           *string `json:"id,omitempty"`
+	UserID       *string `json:"user_id,omitempty"`
+	PlanID       *string `json:"plan_id,omitempty"`
+	PlanName     *string `json:"plan_name,omitempty"`
+	PlanInterval *string `json:"plan_interval,omitempty"`
+	Status       *string `json:"status,omitempty"`
+	AutoRenew    *bool   `json:"auto_renew,omitempty"`
+	StartTime    *string `json:"start_time,omitempty"`
+	EndTime      *string `json:"end_time,omitempty"`
+	RenewTime    *string `json:"renew_time,omitempty"`
+	Version      *int64  `json:"version,omitempty"`
+}
+
+// Voucher denotes a discount voucher.
+type Voucher struct {
+	ID                    *string  `json:"id,omitempty"`
+	Code                  *string  `json:"code,omitempty"`
+	DiscountType          *string  `json:"discount_type,omitempty"`
+	DiscountValue         *int     `json:"discount_value,omitempty"`
+	Currency              *string  `json:"currency,omitempty"`
+	StartTime             *string  `json:"start_time,omitempty"`
+	EndTime               *string  `json:"end_time,omitempty"`
+	MaxRedemptions        *int     `json:"max_redemptions,omitempty"`
+	MaxRedemptionsPerUser *int     `json:"max_redemptions_per_user,omitempty"`
+	TemplateIDs           []string `json:"template_ids,omitempty"`
+	PlanIDs               []string `json:"plan_ids,omitempty"`
+	Active                *bool    `json:"active,omitempty"`
+	Redemptions           *int     `json:"redemptions,omitempty"`
+	Version               *int64   `json:"version,omitempty"`
+}
+
+// Invoice denotes the invoice of a payment.
+type Invoice struct {
+	ID        *string       `json:"id,omitempty"`
+	PaymentID *string       `json:"payment_id,omitempty"`
+	UserID    *string       `json:"user_id,omitempty"`
+	Number    *string       `json:"number,omitempty"`
+	UserName  *string       `json:"user_name,omitempty"`
+	UserEmail *string       `json:"user_email,omitempty"`
+	Items     []InvoiceItem `json:"items,omitempty"`
+	Currency  *string       `json:"currency,omitempty"`
+	Subtotal  *int64        `json:"subtotal,omitempty"`
+	Discount  *int64        `json:"discount,omitempty"`
+	Total     *int64        `json:"total,omitempty"`
+
+	TotalDisplay *string `json:"total_display,omitempty"`
+	IssueTime    *string `json:"issue_time,omitempty"`
+}
+
+// InvoiceItem denotes a line of an invoice.
+type InvoiceItem struct {
+	Description *string `json:"description,omitempty"`
+	Quantity    *int    `json:"quantity,omitempty"`
+	UnitPrice   *int64  `json:"unit_price,omitempty"`
+	Amount      *int64  `json:"amount,omitempty"`
+}
+
+// Refund denotes a refund of a payment.
+type Refund struct {
+	ID               *string `json:"id,omitempty"`
+	PaymentID        *string `json:"payment_id,omitempty"`
+	Type             *string `json:"type,omitempty"`
+	Amount           *int64  `json:"amount,omitempty"`
+	Currency         *string `json:"currency,omitempty"`
+	AmountDisplay    *string `json:"amount_display,omitempty"`
+	Reason           *string `json:"reason,omitempty"`
+	ActorID          *string `json:"actor_id,omitempty"`
+	Status           *string `json:"status,omitempty"`
+	GatewayReference *string `json:"gateway_reference,omitempty"`
+	CreateTime       *string `json:"create_time,omitempty"`
+	UpdateTime       *string `json:"update_time,omitempty"`
+}
+
+// Review denotes a review of pending payments.
+type Review struct {
+	PaymentIDs []string `json:"payment_ids,omitempty"`
+	Status     *string  `json:"status,omitempty"`
+	Reason     *string  `json:"reason,omitempty"`
+}
+
+// ReviewResult denotes the result of reviewing a payment.
+type ReviewResult struct {
+	PaymentID *string `json:"payment_id,omitempty"`
+	Success   *bool   `json:"success,omitempty"`
+	Error     *string `json:"error,omitempty"`
+}
+
+// GetPaymentsFilter denotes the filter of GetPayments.
+type GetPaymentsFilter struct {
+	UserID string
+	Status string
+}
+
+// GetSubscriptionsFilter denotes the filter of
+// GetSubscriptions.
+type GetSubscriptionsFilter struct {
+	UserID string
+	PlanID string
+	Status string
+}
+
+// CreatePayment creates the given payment and returns its ID.
+func (c *Client) CreatePayment(ctx context.Context, payment Payment) (string, error) {
+	var res string
+	_, err := c.do(ctx, request{
+		method:     http.MethodPost,
+		path:       "/v1/payments",
+		body:       payment,
+		idempotent: true,
+	}, &res)
+	return res, err
+}
+
+// GetPayments returns the payments matching the given filter.
+func (c *Client) GetPayments(ctx context.Context, filter GetPaymentsFilter) ([]Payment, error) {
+	query := make(url.Values)
+	setQuery(query, "user_id", filter.UserID)
+	setQuery(query, "status", filter.Status)
+
+	var res []Payment
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/v1/payments",
+		query:  query,
+	}, &res)
+	return res, err
+}
+
+// GetPaymentByID returns the payment of the given ID.
+func (c *Client) GetPaymentByID(ctx context.Context, paymentID string) (Payment, error) {
+	var res Payment
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "payments", paymentID),
+	}, &res)
+	return res, err
+}
+
+// UpdatePayment updates the given fields of the payment,
+// expecting it is still of the given version. The new version
+// is returned.
+func (c *Client) UpdatePayment(ctx context.Context, paymentID string, version int64, payment Payment) (int64, error) {
+	res, err := c.do(ctx, request{
+		method:  http.MethodPatch,
+		path:    path("v1", "payments", paymentID),
+		body:    payment,
+		ifMatch: version,
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.version(), nil
+}
+
+// GetPaymentInvoice returns the invoice of the payment.
+func (c *Client) GetPaymentInvoice(ctx context.Context, paymentID string) (Invoice, error) {
+	var res Invoice
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "payments", paymentID, "invoice"),
+		accept: "application/json",
+	}, &res)
+	return res, err
+}
+
+// GetPaymentInvoicePDF returns the invoice of the payment
+// rendered as PDF.
+func (c *Client) GetPaymentInvoicePDF(ctx context.Context, paymentID string) ([]byte, error) {
+	res, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "payments", paymentID, "invoice"),
+		accept: "application/pdf",
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.body, nil
+}
+
+// RefundPayment refunds the payment and returns the refund ID.
+func (c *Client) RefundPayment(ctx context.Context, paymentID string, refund Refund) (string, error) {
+	var res string
+	_, err := c.do(ctx, request{
+		method:     http.MethodPost,
+		path:       path("v1", "payments", paymentID, "refunds"),
+		body:       refund,
+		idempotent: true,
+	}, &res)
+	return res, err
+}
+
+// GetPaymentRefunds returns the refunds of the payment.
+func (c *Client) GetPaymentRefunds(ctx context.Context, paymentID string) ([]Refund, error) {
+	var res []Refund
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "payments", paymentID, "refunds"),
+	}, &res)
+	return res, err
+}
+
+// ClaimPayment claims the payment for review by the caller
+// and returns the claimed payment.
+func (c *Client) ClaimPayment(ctx context.Context, paymentID string) (Payment, error) {
+	var res Payment
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   path("v1", "payments", paymentID, "claim"),
+	}, &res)
+	return res, err
+}
+
+// ReleasePayment releases the claim of the payment.
+func (c *Client) ReleasePayment(ctx context.Context, paymentID string) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   path("v1", "payments", paymentID, "claim"),
+	}, nil)
+	return err
+}
+
+// GetReviewQueue returns the payments waiting for review. Only
+// the payments claimable by the caller are returned if
+// claimable is true.
+func (c *Client) GetReviewQueue(ctx context.Context, claimable bool) ([]Payment, error) {
+	query := make(url.Values)
+	if claimable {
+		query.Set("claimable", "true")
+	}
+
+	var res []Payment
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/v1/payment-reviews",
+		query:  query,
+	}, &res)
+	return res, err
+}
+
+// ReviewPayments reviews the given payments and returns the
+// result of each payment.
+func (c *Client) ReviewPayments(ctx context.Context, review Review) ([]ReviewResult, error) {
+	var res []ReviewResult
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/v1/payment-reviews",
+		body:   review,
+	}, &res)
+	return res, err
+}
+
+// GetBundles returns the quota bundles on sale.
+func (c *Client) GetBundles(ctx context.Context) ([]Bundle, error) {
+	var res []Bundle
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/v1/bundles",
+		public: true,
+	}, &res)
+	return res, err
+}
+
+// CreatePlan creates the given plan and returns its ID.
+func (c *Client) CreatePlan(ctx context.Context, plan Plan) (string, error) {
+	var res string
+	_, err := c.do(ctx, request{
+		method:     http.MethodPost,
+		path:       "/v1/plans",
+		body:       plan,
+		idempotent: true,
+	}, &res)
+	return res, err
+}
+
+// GetPlans returns all plans.
+func (c *Client) GetPlans(ctx context.Context) ([]Plan, error) {
+	var res []Plan
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/v1/plans",
+	}, &res)
+	return res, err
+}
+
+// GetPlanByID returns the plan of the given ID.
+func (c *Client) GetPlanByID(ctx context.Context, planID string) (Plan, error) {
+	var res Plan
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "plans", planID),
+	}, &res)
+	return res, err
+}
+
+// UpdatePlan updates the given fields of the plan, expecting
+// it is still of the given version. The new version is
+// returned.
+func (c *Client) UpdatePlan(ctx context.Context, planID string, version int64, plan Plan) (int64, error) {
+	res, err := c.do(ctx, request{
+		method:  http.MethodPatch,
+		path:    path("v1", "plans", planID),
+		body:    plan,
+		ifMatch: version,
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.version(), nil
+}
+
+// GetSubscriptions returns the subscriptions matching the
+// given filter.
+func (c *Client) GetSubscriptions(ctx context.Context, filter GetSubscriptionsFilter) ([]Subscription, error) {
+	query := make(url.Values)
+	setQuery(query, "user_id", filter.UserID)
+	setQuery(query, "plan_id", filter.PlanID)
+	setQuery(query, "status", filter.Status)
+
+	var res []Subscription
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/v1/subscriptions",
+		query:  query,
+	}, &res)
+	return res, err
+}
+
+// GetSubscriptionByID returns the subscription of the given
+// ID.
+func (c *Client) GetSubscriptionByID(ctx context.Context, subscriptionID string) (Subscription, error) {
+	var res Subscription
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "subscriptions", subscriptionID),
+	}, &res)
+	return res, err
+}
+
+// CancelSubscription cancels the subscription of the given ID.
+func (c *Client) CancelSubscription(ctx context.Context, subscriptionID string) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   path("v1", "subscriptions", subscriptionID, "cancel"),
+	}, nil)
+	return err
+}
+
+// CreateVoucher creates the given voucher and returns its ID.
+func (c *Client) CreateVoucher(ctx context.Context, voucher Voucher) (string, error) {
+	var res string
+	_, err := c.do(ctx, request{
+		method:     http.MethodPost,
+		path:       "/v1/vouchers",
+		body:       voucher,
+		idempotent: true,
+	}, &res)
+	return res, err
+}
+
+// GetVouchers returns all vouchers.
+func (c *Client) GetVouchers(ctx context.Context) ([]Voucher, error) {
+	var res []Voucher
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/v1/vouchers",
+	}, &res)
+	return res, err
+}
+
+// GetVoucherByID returns the voucher of the given ID.
+func (c *Client) GetVoucherByID(ctx context.Context, voucherID string) (Voucher, error) {
+	var res Voucher
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "vouchers", voucherID),
+	}, &res)
+	return res, err
+}
+
+// UpdateVoucher updates the given fields of the voucher,
+// expecting it is still of the given version. The new version
+// is returned.
+func (c *Client) UpdateVoucher(ctx context.Context, voucherID string, version int64, voucher Voucher) (int64, error) {
+	res, err := c.do(ctx, request{
+		method:  http.MethodPatch,
+		path:    path("v1", "vouchers", voucherID),
+		body:    voucher,
+		ifMatch: version,
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.version(), nil
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// School denotes a school.
+type School struct {
+	ID         *string `json:"id,omitempty"`
+	Code       *string `json:"code,omitempty"`
+	Name       *string `json:"name,omitempty"`
+	Tenant     *string `json:"tenant,omitempty"`
+	Status     *string `json:"status,omitempty"`
+	CreateTime *string `json:"create_time,omitempty"`
+	UpdateTime *string `json:"update_time,omitempty"`
+}
+
+// GetSchools returns all schools.
+func (c *Client) GetSchools(ctx context.Context) ([]School, error) {
+	var res []School
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/v1/schools",
+	}, &res)
+	return res, err
+}
+
+// CreateSchool creates the given school and returns its ID.
+func (c *Client) CreateSchool(ctx context.Context, school School) (string, error) {
+	var res string
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/v1/schools",
+		body:   school,
+	}, &res)
+	return res, err
+}
+
+// GetSchoolByID returns the school of the given ID.
+func (c *Client) GetSchoolByID(ctx context.Context, schoolID string) (School, error) {
+	var res School
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "schools", schoolID),
+	}, &res)
+	return res, err
+}
+
+// UpdateSchool updates the given fields of the school.
+func (c *Client) UpdateSchool(ctx context.Context, schoolID string, school School) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodPatch,
+		path:   path("v1", "schools", schoolID),
+		body:   school,
+	}, nil)
+	return err
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// SchoolConfig denotes a config of a school.
+type SchoolConfig struct {
+	SchoolID   *string         `json:"school_id,omitempty"`
+	Key        *string         `json:"key,omitempty"`
+	Value      json.RawMessage `json:"value,omitempty"`
+	CreateTime *string         `json:"create_time,omitempty"`
+	UpdateTime *string         `json:"update_time,omitempty"`
+}
+
+// GetSchoolConfigs returns the configs of the school.
+func (c *Client) GetSchoolConfigs(ctx context.Context, schoolID string) ([]SchoolConfig, error) {
+	var res []SchoolConfig
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "schools", schoolID, "configs"),
+	}, &res)
+	return res, err
+}
+
+// GetSchoolConfig returns the config of the given key.
+func (c *Client) GetSchoolConfig(ctx context.Context, schoolID, key string) (SchoolConfig, error) {
+	var res SchoolConfig
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "schools", schoolID, "configs", key),
+	}, &res)
+	return res, err
+}
+
+// SetSchoolConfig sets the config of the given key to the
+// given JSON value.
+func (c *Client) SetSchoolConfig(ctx context.Context, schoolID, key string, value json.RawMessage) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodPut,
+		path:   path("v1", "schools", schoolID, "configs", key),
+		body:   SchoolConfig{Value: value},
+	}, nil)
+	return err
+}
+
+// DeleteSchoolConfig deletes the config of the given key, its
+// default value is used afterward.
+func (c *Client) DeleteSchoolConfig(ctx context.Context, schoolID, key string) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   path("v1", "schools", schoolID, "configs", key),
+	}, nil)
+	return err
+}
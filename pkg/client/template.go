@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Template denotes a content template.
+type Template struct {
+	ID               *string   `json:"id,omitempty"`
+	Name             *string   `json:"name,omitempty"`
+	Description      *string   `json:"description,omitempty"`
+	Label            *string   `json:"label,omitempty"`
+	Category         *string   `json:"category,omitempty"`
+	Tags             *[]string `json:"tags,omitempty"`
+	ThumbnailURI     *string   `json:"thumbnail_uri,omitempty"`
+	ThumbnailMediaID *string   `json:"thumbnail_media_id,omitempty"`
+	FeaturedOrder    *int      `json:"featured_order,omitempty"`
+	Price            *int64    `json:"price,omitempty"`
+	Currency         *string   `json:"currency,omitempty"`
+	PriceDisplay     *string   `json:"price_display,omitempty"`
+	Version          *int64    `json:"version,omitempty"`
+	DeleteTime       *string   `json:"delete_time,omitempty"`
+}
+
+// GetTemplatesFilter denotes the filter of GetTemplates and
+// GetTrashTemplates.
+type GetTemplatesFilter struct {
+	// Query searches the templates, they are ordered by
+	// relevance if Sort is empty.
+	Query    string
+	Tag      string
+	Category string
+	Label    string
+	Sort     string
+	Page     int
+	Limit    int
+}
+
+// query returns the query parameters of the filter.
+func (f GetTemplatesFilter) query() url.Values {
+	query := make(url.Values)
+	setQuery(query, "q", f.Query)
+	setQuery(query, "tag", f.Tag)
+	setQuery(query, "category", f.Category)
+	setQuery(query, "label", f.Label)
+	setQuery(query, "sort", f.Sort)
+	setPageQuery(query, f.Page, f.Limit)
+	return query
+}
+
+// CreateTemplate creates the given template and returns its
+// ID.
+func (c *Client) CreateTemplate(ctx context.Context, template Template) (string, error) {
+	var res string
+	_, err := c.do(ctx, request{
+		method:     http.MethodPost,
+		path:       "/v1/templates",
+		body:       template,
+		idempotent: true,
+	}, &res)
+	return res, err
+}
+
+// GetTemplates returns a page of the templates matching the
+// given filter.
+func (c *Client) GetTemplates(ctx context.Context, filter GetTemplatesFilter) ([]Template, Pagination, error) {
+	return c.getTemplates(ctx, "/v1/templates", filter)
+}
+
+// GetTrashTemplates returns a page of the templates in the
+// trash matching the given filter.
+func (c *Client) GetTrashTemplates(ctx context.Context, filter GetTemplatesFilter) ([]Template, Pagination, error) {
+	return c.getTemplates(ctx, "/v1/trash/templates", filter)
+}
+
+func (c *Client) getTemplates(ctx context.Context, urlPath string, filter GetTemplatesFilter) ([]Template, Pagination, error) {
+	var templates []Template
+	res, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   urlPath,
+		query:  filter.query(),
+	}, &templates)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	var pagination Pagination
+	if res.pagination != nil {
+		pagination = *res.pagination
+	}
+
+	return templates, pagination, nil
+}
+
+// GetTemplateByID returns the template of the given ID.
+func (c *Client) GetTemplateByID(ctx context.Context, templateID string) (Template, error) {
+	var res Template
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "templates", templateID),
+	}, &res)
+	return res, err
+}
+
+// UpdateTemplate updates the given fields of the template,
+// expecting it is still of the given version. The new version
+// is returned.
+func (c *Client) UpdateTemplate(ctx context.Context, templateID string, version int64, template Template) (int64, error) {
+	res, err := c.do(ctx, request{
+		method:  http.MethodPatch,
+		path:    path("v1", "templates", templateID),
+		body:    template,
+		ifMatch: version,
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.version(), nil
+}
+
+// DeleteTemplate moves the template of the given ID to the
+// trash.
+func (c *Client) DeleteTemplate(ctx context.Context, templateID string) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   path("v1", "templates", templateID),
+	}, nil)
+	return err
+}
+
+// RestoreTemplate restores the template of the given ID from
+// the trash.
+func (c *Client) RestoreTemplate(ctx context.Context, templateID string) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   path("v1", "templates", templateID, "restore"),
+	}, nil)
+	return err
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// String returns a pointer of the given value, to set an
+// optional field.
+func String(v string) *string { return &v }
+
+// Int returns a pointer of the given value, to set an
+// optional field.
+func Int(v int) *int { return &v }
+
+// Int64 returns a pointer of the given value, to set an
+// optional field.
+func Int64(v int64) *int64 { return &v }
+
+// Bool returns a pointer of the given value, to set an
+// optional field.
+func Bool(v bool) *bool { return &v }
+
+// Strings returns a pointer of the given values, to set an
+// optional list field.
+func Strings(v ...string) *[]string {
+	if v == nil {
+		v = []string{}
+	}
+	return &v
+}
+
+// setQuery sets the given query parameter if its value is not
+// empty.
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+// setPageQuery sets the page and limit query parameters if
+// they are given.
+func setPageQuery(query url.Values, page, limit int) {
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+}
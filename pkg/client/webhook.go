@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// Webhook denotes an outgoing webhook.
+type Webhook struct {
+	ID           *string   `json:"id,omitempty"`
+	URL          *string   `json:"url,omitempty"`
+	Secret       *string   `json:"secret,omitempty"`
+	EventTypes   *[]string `json:"event_types,omitempty"`
+	Status       *string   `json:"status,omitempty"`
+	FailureCount *int      `json:"failure_count,omitempty"`
+	DisableTime  *string   `json:"disable_time,omitempty"`
+	CreatedBy    *string   `json:"created_by,omitempty"`
+	CreateTime   *string   `json:"create_time,omitempty"`
+	UpdateTime   *string   `json:"update_time,omitempty"`
+}
+
+// WebhookDelivery denotes a delivery of an event to a webhook.
+type WebhookDelivery struct {
+	ID              *string         `json:"id,omitempty"`
+	WebhookID       *string         `json:"webhook_id,omitempty"`
+	EventID         *string         `json:"event_id,omitempty"`
+	EventType       *string         `json:"event_type,omitempty"`
+	Payload         json.RawMessage `json:"payload,omitempty"`
+	Status          *string         `json:"status,omitempty"`
+	Attempts        *int            `json:"attempts,omitempty"`
+	NextAttemptTime *string         `json:"next_attempt_time,omitempty"`
+	ResponseCode    *int            `json:"response_code,omitempty"`
+	ResponseBody    *string         `json:"response_body,omitempty"`
+	LastError       *string         `json:"last_error,omitempty"`
+	RedeliveryOf    *string         `json:"redelivery_of,omitempty"`
+	CreateTime      *string         `json:"create_time,omitempty"`
+	DeliverTime     *string         `json:"deliver_time,omitempty"`
+}
+
+// GetWebhooks returns all webhooks.
+func (c *Client) GetWebhooks(ctx context.Context) ([]Webhook, error) {
+	var res []Webhook
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/v1/webhooks",
+	}, &res)
+	return res, err
+}
+
+// CreateWebhook creates the given webhook and returns its ID.
+func (c *Client) CreateWebhook(ctx context.Context, webhook Webhook) (string, error) {
+	var res string
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/v1/webhooks",
+		body:   webhook,
+	}, &res)
+	return res, err
+}
+
+// GetWebhookByID returns the webhook of the given ID.
+func (c *Client) GetWebhookByID(ctx context.Context, webhookID string) (Webhook, error) {
+	var res Webhook
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "webhooks", webhookID),
+	}, &res)
+	return res, err
+}
+
+// UpdateWebhook updates the given fields of the webhook.
+func (c *Client) UpdateWebhook(ctx context.Context, webhookID string, webhook Webhook) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodPatch,
+		path:   path("v1", "webhooks", webhookID),
+		body:   webhook,
+	}, nil)
+	return err
+}
+
+// DeleteWebhook deletes the webhook of the given ID.
+func (c *Client) DeleteWebhook(ctx context.Context, webhookID string) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   path("v1", "webhooks", webhookID),
+	}, nil)
+	return err
+}
+
+// GetWebhookDeliveries returns the deliveries of the webhook.
+func (c *Client) GetWebhookDeliveries(ctx context.Context, webhookID string) ([]WebhookDelivery, error) {
+	var res []WebhookDelivery
+	_, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path("v1", "webhooks", webhookID, "deliveries"),
+	}, &res)
+	return res, err
+}
+
+// RedeliverWebhookDelivery sends the event of the delivery
+// again and returns the new delivery ID.
+func (c *Client) RedeliverWebhookDelivery(ctx context.Context, webhookID, deliveryID string) (string, error) {
+	var res string
+	_, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   path("v1", "webhooks", webhookID, "deliveries", deliveryID, "redeliver"),
+	}, &res)
+	return res, err
+}
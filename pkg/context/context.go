@@ -9,6 +9,7 @@ type key string
 const (
 	keySource         key = "source"
 	keySchoolID       key = "school_id"
+	keyTenant         key = "tenant"
 	keyUserID         key = "user_id"
 	keyHTTPStatusCode key = "http_status_code"
 )
@@ -39,6 +40,19 @@ func GetSchoolID(ctx context.Context) (string, bool) {
 	return v, ok
 }
 
+// SetTenant returns a new Context that carries value v as
+// tenant, i.e. the name of the database of the school.
+func SetTenant(ctx context.Context, v string) context.Context {
+	return context.WithValue(ctx, keyTenant, v)
+}
+
+// GetTenant returns the tenant value stored in the given
+// context, if any.
+func GetTenant(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(keyTenant).(string)
+	return v, ok
+}
+
 // SetUserID returns a new Context that carries value v as
 // user ID.
 func SetUserID(ctx context.Context, v string) context.Context {
@@ -0,0 +1,91 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Bus is an in-process Publisher, it calls the handlers
+// subscribed to the type of a published event.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[string][]subscriber
+}
+
+// subscriber is a named handler subscribed to an event type.
+type subscriber struct {
+	name    string
+	handler Handler
+}
+
+// NewBus creates a new Bus.
+func NewBus() *Bus {
+	return &Bus{
+		subscribers: make(map[string][]subscriber),
+	}
+}
+
+// Subscribe subscribes the given handler to events of the
+// given type. The name identifies the handler in errors and
+// logs.
+func (b *Bus) Subscribe(eventType, name string, handler Handler) error {
+	if eventType == "" {
+		return ErrInvalidEvent
+	}
+
+	if name == "" || handler == nil {
+		return ErrInvalidHandler
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, sub := range b.subscribers[eventType] {
+		if sub.name == name {
+			return ErrHandlerDuplicate
+		}
+	}
+
+	b.subscribers[eventType] = append(b.subscribers[eventType], subscriber{
+		name:    name,
+		handler: handler,
+	})
+	return nil
+}
+
+// Publish calls all handlers subscribed to the type of the
+// given event, in the order they are subscribed.
+//
+// All handlers are called even if some of them fail, and the
+// failures are returned together. As the event is published
+// again on failure, the succeeded handlers are called again
+// as well.
+func (b *Bus) Publish(ctx context.Context, e Event) error {
+	b.mu.RLock()
+	subscribers := b.subscribers[e.Type]
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, sub := range subscribers {
+		err := b.call(ctx, sub, e)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sub.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// call calls the handler of the given subscriber and recovers
+// from panic, so a bad handler does not stop the others.
+func (b *Bus) call(ctx context.Context, sub subscriber, e Event) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("panic: %v", rec)
+		}
+	}()
+
+	return sub.handler(ctx, e)
+}
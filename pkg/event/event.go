@@ -0,0 +1,98 @@
+// event provides domain events and an in-process bus to
+// subscribe to them.
+//
+// Services do not publish events directly. They write the
+// events to an outbox in the same transaction as the change,
+// and a relay publishes the committed events to a Publisher.
+// So an event is never published for a rolled back change,
+// and is published at least once for a committed one.
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Followings are the known errors from event.
+var (
+	// ErrInvalidEvent is returned when the given event has no
+	// type.
+	ErrInvalidEvent = errors.New("event: invalid event")
+
+	// ErrInvalidHandler is returned when subscribing without
+	// a name or a handler.
+	ErrInvalidHandler = errors.New("event: invalid handler")
+
+	// ErrHandlerDuplicate is returned when subscribing a
+	// handler name more than once to an event type.
+	ErrHandlerDuplicate = errors.New("event: handler already subscribed")
+)
+
+// Event denotes a change in a domain, e.g. an approved
+// payment.
+type Event struct {
+	ID string
+
+	// Type is the name of the change prefixed by its domain,
+	// e.g. "payment.approved".
+	Type string
+
+	// AggregateID is the ID of the changed entity, e.g. the
+	// payment ID.
+	AggregateID string
+
+	// Payload is the JSON encoded detail of the change.
+	Payload []byte
+
+	CreateTime time.Time
+}
+
+// New returns a new event of the given type with the given
+// payload encoded as JSON.
+func New(eventType, aggregateID string, payload interface{}, createTime time.Time) (Event, error) {
+	if eventType == "" {
+		return Event{}, ErrInvalidEvent
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		Type:        eventType,
+		AggregateID: aggregateID,
+		Payload:     data,
+		CreateTime:  createTime,
+	}, nil
+}
+
+// Decode decodes the JSON payload of the event into v.
+func (e Event) Decode(v interface{}) error {
+	return json.Unmarshal(e.Payload, v)
+}
+
+// Handler handles a published event.
+//
+// An event is published at least once, so a handler must be
+// idempotent. Returning an error makes the event published
+// again later.
+type Handler func(ctx context.Context, e Event) error
+
+// Publisher publishes events to their subscribers.
+//
+// Bus publishes events in-process. Implementing this interface
+// allows the outbox relay to publish events to a message
+// broker instead.
+type Publisher interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// Subscriber subscribes handlers to events.
+type Subscriber interface {
+	// Subscribe subscribes the given handler to events of the
+	// given type. The name identifies the handler.
+	Subscribe(eventType, name string, handler Handler) error
+}
@@ -0,0 +1,28 @@
+package postgresql
+
+import (
+	"hbdtoyou/pkg/event"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type eventModel struct {
+	ID          uuid.UUID `db:"id"`
+	Type        string    `db:"type"`
+	AggregateID string    `db:"aggregate_id"`
+	Payload     []byte    `db:"payload"`
+	Attempts    int       `db:"attempts"`
+	CreateTime  time.Time `db:"create_time"`
+}
+
+// format formats database struct into event struct.
+func (dbData *eventModel) format() event.Event {
+	return event.Event{
+		ID:          dbData.ID.String(),
+		Type:        dbData.Type,
+		AggregateID: dbData.AggregateID,
+		Payload:     dbData.Payload,
+		CreateTime:  dbData.CreateTime,
+	}
+}
@@ -0,0 +1,51 @@
+// postgresql provides the transactional outbox of events in
+// PostgreSQL, and the relay publishing the committed events.
+package postgresql
+
+import (
+	"context"
+	"hbdtoyou/pkg/event"
+	pglib "hbdtoyou/pkg/postgresql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Channel is the PostgreSQL notification channel the relay is
+// woken up on when events are written.
+const Channel = "event_outbox"
+
+// Write writes the given event to the outbox using the given
+// querier, which should be the transaction of the change the
+// event denotes.
+//
+// The relay is notified once the transaction is committed, a
+// rolled back event is neither published nor notified.
+func Write(ctx context.Context, q pglib.Querier, e event.Event) error {
+	if e.Type == "" {
+		return event.ErrInvalidEvent
+	}
+
+	// construct arguments filled with fields for the query
+	argKV := map[string]interface{}{
+		"type":         e.Type,
+		"aggregate_id": e.AggregateID,
+		"payload":      string(e.Payload),
+		"create_time":  e.CreateTime,
+		"channel":      Channel,
+	}
+
+	// prepare query
+	query, args, err := sqlx.Named(queryWriteEvent, argKV)
+	if err != nil {
+		return err
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return err
+	}
+	query = q.Rebind(query)
+
+	// execute query
+	_, err = q.Exec(query, args...)
+	return err
+}
@@ -0,0 +1,78 @@
+package postgresql
+
+const (
+	// queryWriteEvent inserts an event and notifies the relay
+	// in a single statement, PostgreSQL only delivers the
+	// notification once the transaction is committed.
+	queryWriteEvent = `
+		WITH e AS (
+			INSERT INTO
+				event_outbox
+				(
+					type,
+					aggregate_id,
+					payload,
+					next_attempt_time,
+					create_time
+				)
+			VALUES
+				(
+					:type,
+					:aggregate_id,
+					:payload,
+					:create_time,
+					:create_time
+				)
+			RETURNING
+				id
+		)
+		SELECT
+			pg_notify(:channel, e.id::text)
+		FROM
+			e
+	`
+
+	queryLockDueEvents = `
+		SELECT
+			id,
+			type,
+			aggregate_id,
+			payload,
+			attempts,
+			create_time
+		FROM
+			event_outbox
+		WHERE
+			publish_time IS NULL
+		AND
+			attempts < $1
+		AND
+			next_attempt_time <= $2
+		ORDER BY
+			create_time, id
+		LIMIT
+			$3
+		FOR UPDATE SKIP LOCKED
+	`
+
+	queryMarkEventPublished = `
+		UPDATE
+			event_outbox
+		SET
+			publish_time = $2,
+			last_error = NULL
+		WHERE
+			id = $1
+	`
+
+	queryMarkEventFailed = `
+		UPDATE
+			event_outbox
+		SET
+			attempts = attempts + 1,
+			next_attempt_time = $2,
+			last_error = $3
+		WHERE
+			id = $1
+	`
+)
@@ -0,0 +1,294 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"hbdtoyou/pkg/event"
+	"log"
+	"sync"
+	"time"
+
+	contextlib "hbdtoyou/pkg/context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Followings are the known errors from the relay.
+var (
+	errInvalidDB        = errors.New("outbox: invalid database")
+	errInvalidPublisher = errors.New("outbox: invalid publisher")
+	errAlreadyStarted   = errors.New("outbox: relay already started")
+)
+
+// Followings are default values for Config fields.
+const (
+	defaultPollInterval = 5 * time.Second
+	defaultBatchSize    = 100
+	defaultMaxAttempts  = 10
+	defaultBackoffBase  = 1 * time.Second
+	defaultBackoffMax   = 10 * time.Minute
+)
+
+// Config is the available configurations of Relay.
+type Config struct {
+	// ConnectionString connects the listener waking the relay
+	// up as soon as events are written. The relay only polls
+	// the outbox if it is empty.
+	ConnectionString string
+
+	// PollInterval is how often the outbox is polled when no
+	// notification is received, e.g. for the events to retry.
+	PollInterval time.Duration
+
+	// BatchSize is the maximum number of events published in
+	// a transaction.
+	BatchSize int
+
+	// MaxAttempts is the number of failed publishing after
+	// which an event is no longer retried, it is kept in the
+	// outbox with its last error.
+	MaxAttempts int
+
+	// BackoffBase is the delay before the first retry, it is
+	// doubled on every next retry up to BackoffMax.
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+
+	// Tenant is set in the context of the published events
+	// through contextlib.SetTenant(), so the handlers work on
+	// the database the events are written to.
+	Tenant string
+}
+
+// Relay publishes the events written to the outbox, oldest
+// first. Multiple relays can run on the same outbox, an event
+// is only published by one of them at a time.
+type Relay struct {
+	db        *sqlx.DB
+	publisher event.Publisher
+	config    Config
+	timeNow   func() time.Time
+
+	mu      sync.Mutex
+	started bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// NewRelay creates a new Relay publishing the events in the
+// outbox of the given database to the given publisher.
+func NewRelay(db *sqlx.DB, publisher event.Publisher, cfg Config) (*Relay, error) {
+	if db == nil {
+		return nil, errInvalidDB
+	}
+
+	if publisher == nil {
+		return nil, errInvalidPublisher
+	}
+
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	if cfg.BackoffBase <= 0 {
+		cfg.BackoffBase = defaultBackoffBase
+	}
+	if cfg.BackoffMax <= 0 {
+		cfg.BackoffMax = defaultBackoffMax
+	}
+
+	return &Relay{
+		db:        db,
+		publisher: publisher,
+		config:    cfg,
+		timeNow:   time.Now,
+	}, nil
+}
+
+// Start starts publishing events in the background.
+func (r *Relay) Start() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.started {
+		return errAlreadyStarted
+	}
+	r.started = true
+
+	ctx := context.Background()
+	if r.config.Tenant != "" {
+		ctx = contextlib.SetTenant(ctx, r.config.Tenant)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	r.cancel = cancel
+	r.done = make(chan struct{})
+
+	go r.loop(ctx)
+
+	return nil
+}
+
+// Stop stops the relay and waits until the events being
+// published, if any, are done.
+func (r *Relay) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.started {
+		return
+	}
+	r.started = false
+
+	r.cancel()
+	<-r.done
+}
+
+// loop publishes the due events whenever a notification is
+// received or every poll interval, until the given context is
+// canceled.
+func (r *Relay) loop(ctx context.Context) {
+	defer close(r.done)
+
+	// a nil channel is never received from, so the relay
+	// falls back to polling without a listener
+	var notify <-chan *pq.Notification
+	if r.config.ConnectionString != "" {
+		listener := pq.NewListener(r.config.ConnectionString, time.Second, time.Minute, func(_ pq.ListenerEventType, err error) {
+			if err != nil {
+				log.Printf("[outbox][relay] listener error: %s\n", err.Error())
+			}
+		})
+		defer listener.Close()
+
+		if err := listener.Listen(Channel); err != nil {
+			log.Printf("[outbox][relay] failed to listen, polling only: %s\n", err.Error())
+		} else {
+			notify = listener.Notify
+		}
+	}
+
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		r.relay(ctx)
+
+		// the listener also sends nil after reconnecting, the
+		// notifications missed meanwhile are relayed as well
+		select {
+		case <-ctx.Done():
+			return
+		case <-notify:
+		case <-ticker.C:
+		}
+	}
+}
+
+// relay publishes batches of due events until none is left.
+func (r *Relay) relay(ctx context.Context) {
+	for ctx.Err() == nil {
+		n, err := r.relayBatch(ctx)
+		if err != nil {
+			log.Printf("[outbox][relay] failed to relay events: %s\n", err.Error())
+			return
+		}
+
+		if n < r.config.BatchSize {
+			return
+		}
+	}
+}
+
+// relayBatch publishes a batch of due events and returns the
+// number of events in the batch. The events are locked until
+// they are published or scheduled to retry.
+func (r *Relay) relayBatch(ctx context.Context) (int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	now := r.timeNow()
+	events, err := lockDueEvents(tx, r.config.MaxAttempts, now, r.config.BatchSize)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	for _, e := range events {
+		err = r.publisher.Publish(ctx, e.format())
+		if err != nil {
+			attempts := e.Attempts + 1
+			if attempts >= r.config.MaxAttempts {
+				log.Printf("[outbox][relay] event %s of type %s failed after %d attempts: %s\n", e.ID, e.Type, attempts, err.Error())
+			}
+
+			_, err = tx.Exec(queryMarkEventFailed, e.ID, now.Add(r.getBackoff(attempts)), err.Error())
+		} else {
+			_, err = tx.Exec(queryMarkEventPublished, e.ID, r.timeNow())
+		}
+
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(events), nil
+}
+
+// getBackoff returns the delay before publishing again an
+// event failed the given number of attempts.
+func (r *Relay) getBackoff(attempts int) time.Duration {
+	backoff := r.config.BackoffBase
+	for i := 1; i < attempts && backoff < r.config.BackoffMax; i++ {
+		backoff *= 2
+	}
+
+	if backoff > r.config.BackoffMax {
+		return r.config.BackoffMax
+	}
+
+	return backoff
+}
+
+// lockDueEvents returns at most the given limit of events due
+// at the given time, and locks them until the transaction
+// ends. Events locked by another relay are skipped.
+func lockDueEvents(tx *sqlx.Tx, maxAttempts int, now time.Time, limit int) ([]eventModel, error) {
+	rows, err := tx.Queryx(queryLockDueEvents, maxAttempts, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// read rows
+	result := make([]eventModel, 0)
+	for rows.Next() {
+		var row eventModel
+		err = rows.StructScan(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,127 @@
+// webhook defines gateway client that posts refunds as JSON
+// to an HTTP endpoint of the gateway.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"hbdtoyou/pkg/gateway"
+)
+
+var (
+	errInvalidURL = errors.New("webhook: invalid url")
+)
+
+// Followings are default values for Config fields.
+const (
+	defaultTimeout = 10 * time.Second
+)
+
+// client implements gateway.Client.
+type client struct {
+	url        string
+	headers    map[string]string
+	httpClient *http.Client
+}
+
+// Config contains the available configuration for a client.
+type Config struct {
+	// URL is the endpoint making the refunds.
+	URL string
+
+	// Headers are added to every request, e.g. to
+	// authenticate to the endpoint.
+	Headers map[string]string
+
+	// Timeout limits the duration of a request.
+	Timeout time.Duration
+}
+
+// New returns a new client.
+func New(cfg Config) (*client, error) {
+	if u, err := url.Parse(cfg.URL); err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, errInvalidURL
+	}
+
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	return &client{
+		url:     cfg.URL,
+		headers: cfg.Headers,
+		httpClient: &http.Client{
+			Timeout: cfg.Timeout,
+		},
+	}, nil
+}
+
+// payload is the JSON body posted to the endpoint.
+type payload struct {
+	PaymentReference string `json:"payment_reference"`
+	Amount           int64  `json:"amount"`
+	Currency         string `json:"currency"`
+	Reason           string `json:"reason"`
+}
+
+// response is the JSON body of a made refund.
+type response struct {
+	Reference string `json:"reference"`
+}
+
+// Refund posts the given refund to the endpoint, with the
+// refund ID as Idempotency-Key header. The refund is declined
+// if the endpoint responds with a 4xx status other than 408
+// and 429.
+func (c *client) Refund(ctx context.Context, refund gateway.Refund) (string, error) {
+	body, err := json.Marshal(payload{
+		PaymentReference: refund.PaymentReference,
+		Amount:           refund.Amount,
+		Currency:         refund.Currency,
+		Reason:           refund.Reason,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", refund.ID)
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+	case res.StatusCode == http.StatusRequestTimeout || res.StatusCode == http.StatusTooManyRequests:
+		return "", fmt.Errorf("webhook: unexpected status %d", res.StatusCode)
+	case res.StatusCode >= 400 && res.StatusCode < 500:
+		return "", fmt.Errorf("%w: status %d", gateway.ErrDeclined, res.StatusCode)
+	default:
+		return "", fmt.Errorf("webhook: unexpected status %d", res.StatusCode)
+	}
+
+	var resBody response
+	err = json.NewDecoder(res.Body).Decode(&resBody)
+	if err != nil {
+		return "", err
+	}
+
+	return resBody.Reference, nil
+}
@@ -0,0 +1,44 @@
+// gateway provides clients making refunds through a payment
+// gateway.
+package gateway
+
+import (
+	"context"
+	"errors"
+)
+
+// Followings are the known errors returned from gateway
+// clients.
+var (
+	// ErrDeclined is returned when the gateway declined the
+	// refund, retrying the refund would not make it.
+	ErrDeclined = errors.New("gateway: refund declined")
+)
+
+// Refund denotes a refund of a payment made by a gateway.
+type Refund struct {
+	// ID identifies the refund, the gateway makes a refund
+	// once per ID.
+	ID string
+
+	// PaymentReference is the reference of the refunded
+	// payment in the gateway.
+	PaymentReference string
+
+	// Amount is in the minor unit of Currency.
+	Amount   int64
+	Currency string
+
+	Reason string
+}
+
+// Client is a client that makes refunds through a gateway.
+//
+// All clients that implement this interface should handle
+// authentication and authorization on their own. For example
+// in the initialization function.
+type Client interface {
+	// Refund makes the given refund, and returns the
+	// reference of the refund in the gateway.
+	Refund(ctx context.Context, refund Refund) (string, error)
+}
@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Followings are the known error returned from gRPC request
+// utility functions.
+var (
+	// ErrBearerTokenNotFound is returned when there is no
+	// bearer token in the gRPC request metadata.
+	ErrBearerTokenNotFound = errors.New("bearer token not found")
+
+	// ErrSourceNotFound is returned when there is no source
+	// in the gRPC request metadata.
+	ErrSourceNotFound = errors.New("source not found")
+
+	// ErrInvalidUpdateMask is returned when the update mask in
+	// the gRPC request is empty or has a path not allowed to
+	// be updated.
+	ErrInvalidUpdateMask = errors.New("invalid update mask")
+)
+
+// GetBearerTokenFromMetadata returns token value stored in
+// gRPC request metadata.
+//
+// Value is stored in standard key: authorization.
+func GetBearerTokenFromMetadata(ctx context.Context) (string, error) {
+	value := GetValueFromMetadata(ctx, "authorization")
+	if value == "" {
+		return "", ErrBearerTokenNotFound
+	}
+
+	parts := strings.Split(value, " ")
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		return "", ErrBearerTokenNotFound
+	}
+
+	return parts[1], nil
+}
+
+// GetSourceFromMetadata returns source value stored in gRPC
+// request metadata.
+//
+// Value is stored in custom key: x-source.
+func GetSourceFromMetadata(ctx context.Context) (string, error) {
+	source := GetValueFromMetadata(ctx, "x-source")
+	if source == "" {
+		return "", ErrSourceNotFound
+	}
+	return source, nil
+}
+
+// GetValueFromMetadata returns the first value of the given
+// key stored in gRPC request metadata, or an empty string if
+// there is none. The key is case insensitive.
+func GetValueFromMetadata(ctx context.Context, key string) string {
+	values := metadata.ValueFromIncomingContext(ctx, strings.ToLower(key))
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(values[0])
+}
+
+// GetUpdateMaskPaths returns the paths of the given update
+// mask, each of them must be one of the given allowed paths.
+// Duplicate paths are returned once.
+func GetUpdateMaskPaths(mask *fieldmaskpb.FieldMask, allowed ...string) ([]string, error) {
+	if len(mask.GetPaths()) == 0 {
+		return nil, ErrInvalidUpdateMask
+	}
+
+	allowedPaths := make(map[string]struct{}, len(allowed))
+	for _, path := range allowed {
+		allowedPaths[path] = struct{}{}
+	}
+
+	seen := make(map[string]struct{}, len(mask.GetPaths()))
+	paths := make([]string, 0, len(mask.GetPaths()))
+	for _, path := range mask.GetPaths() {
+		if _, ok := allowedPaths[path]; !ok {
+			return nil, ErrInvalidUpdateMask
+		}
+
+		if _, ok := seen[path]; ok {
+			continue
+		}
+		seen[path] = struct{}{}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"context"
+	"net/http"
+)
+
+// authKey is the key used to store the authenticated user ID
+// in the request context.
+type authKey struct{}
+
+// ValidateTokenFunc validates the given access token and
+// returns the ID of its user.
+type ValidateTokenFunc func(ctx context.Context, token string) (userID string, err error)
+
+// Authenticate returns a middleware storing the ID of the user
+// of a request in its context, once its bearer token is
+// validated by validateToken.
+//
+// A request without a valid token is passed as is, it is left
+// to be refused by the handler. The user ID is read back by
+// GetAuthenticatedUserID(), unlike X-UserID header it can not
+// be set by the client.
+func Authenticate(validateToken ValidateTokenFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, err := GetBearerTokenFromHeader(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			userID, err := validateToken(r.Context(), token)
+			if err != nil || userID == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), authKey{}, userID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetAuthenticatedUserID returns the ID of the user of the
+// given request stored by Authenticate(), if its access token
+// is valid.
+func GetAuthenticatedUserID(r *http.Request) (string, bool) {
+	userID, ok := r.Context().Value(authKey{}).(string)
+	return userID, ok
+}
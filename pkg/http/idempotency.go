@@ -0,0 +1,274 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	contextlib "hbdtoyou/pkg/context"
+	"hbdtoyou/pkg/idempotency"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Followings are the errors written by Idempotency, in the
+// same format of the errors the handlers return.
+var (
+	errInvalidIdempotencyKey    = errors.New("INVALID_IDEMPOTENCY_KEY")
+	errIdempotencyKeyInProgress = errors.New("IDEMPOTENCY_KEY_IN_PROGRESS")
+	errIdempotencyKeyMismatch   = errors.New("IDEMPOTENCY_KEY_MISMATCH")
+	errBadRequest               = errors.New("BAD_REQUEST")
+	errInternalServer           = errors.New("INTERNAL_SERVER_ERROR")
+)
+
+// Followings are the limits of Idempotency.
+const (
+	// maxIdempotencyKeyLength is the maximum length of the
+	// Idempotency-Key header.
+	maxIdempotencyKeyLength = 255
+
+	// idempotencyLockTimeout is how long a record stays
+	// locked if the response is never saved, e.g. the
+	// instance handling the request is stopped. It is longer
+	// than the timeout of any handler.
+	idempotencyLockTimeout = time.Minute
+
+	// defaultIdempotencyTTL is how long a saved response is
+	// replayed if no TTL is given.
+	defaultIdempotencyTTL = 24 * time.Hour
+)
+
+// idempotencyHeaders are the response headers saved and
+// replayed along with the response body.
+var idempotencyHeaders = []string{
+	"Content-Type",
+	"Content-Disposition",
+	"ETag",
+	"Location",
+}
+
+// IdempotencyFunc returns the scope of the given request and
+// the ID of the user sending it, after its access token is
+// checked. The request is not made idempotent if ok is false.
+type IdempotencyFunc func(r *http.Request) (scope, userID string, ok bool)
+
+// LateResponseFunc waits for the final result of a request
+// whose handler timed out while it is still being handled, and
+// returns its response. ok is false if the request failed, so
+// it can be retried.
+type LateResponseFunc func() (statusCode int, body []byte, ok bool)
+
+// lateResponseKey is the context key of the lateResponse of an
+// idempotent request.
+type lateResponseKey struct{}
+
+// lateResponse holds the LateResponseFunc set by the handler of
+// an idempotent request.
+type lateResponse struct {
+	fn LateResponseFunc
+}
+
+// SetLateResponse sets the given function to get the final
+// response of the given request, once its handler timed out.
+// The saved response of an idempotent request is then replayed
+// instead of handling its retries again, it does nothing
+// otherwise.
+//
+// It is called by the handler before it returns.
+func SetLateResponse(r *http.Request, fn LateResponseFunc) {
+	if late, ok := r.Context().Value(lateResponseKey{}).(*lateResponse); ok {
+		late.fn = fn
+	}
+}
+
+// Idempotency replays the saved response of a request sent
+// with Idempotency-Key header, instead of handling it again.
+//
+// The response is saved per tenant, school, scope, user, path
+// and key. A retry of a request still being handled gets 409
+// status and a retry with a different body gets 422 status.
+// Responses with 5xx status are not saved, the request can be
+// retried, unless its handler set the final response through
+// SetLateResponse().
+type Idempotency struct {
+	store idempotency.Store
+	ttl   time.Duration
+}
+
+// NewIdempotency creates a new Idempotency keeping the saved
+// responses in the given store for the given TTL, it defaults
+// to 24 hours.
+func NewIdempotency(store idempotency.Store, ttl time.Duration) *Idempotency {
+	if ttl <= 0 {
+		ttl = defaultIdempotencyTTL
+	}
+
+	return &Idempotency{
+		store: store,
+		ttl:   ttl,
+	}
+}
+
+// Handler returns an http.Handler making the requests to the
+// given handler idempotent, if idempotencyFunc allows it and
+// the request has Idempotency-Key header.
+func (i *Idempotency) Handler(next http.Handler, idempotencyFunc IdempotencyFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := r.Header.Get("Idempotency-Key")
+		if value == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if len(value) > maxIdempotencyKeyLength {
+			WriteErrorResponse(w, http.StatusBadRequest, []string{errInvalidIdempotencyKey.Error()})
+			return
+		}
+
+		scope, userID, ok := idempotencyFunc(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// the body is read to be compared with the retries, and
+		// restored for the handler
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			WriteErrorResponse(w, http.StatusBadRequest, []string{errBadRequest.Error()})
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		// the same user and key might be used with other schools
+		tenant, _ := contextlib.GetTenant(r.Context())
+		schoolID, _ := contextlib.GetSchoolID(r.Context())
+
+		key := tenant + ":" + schoolID + ":" + scope + ":" + userID + ":" + r.URL.Path + ":" + value
+		fingerprint := idempotency.Fingerprint(body)
+		record, locked, err := i.store.Lock(r.Context(), key, fingerprint, idempotencyLockTimeout)
+		if err != nil {
+			log.Printf("[HTTP][Idempotency] Failed to lock record. Key: %s, Err: %s\n", key, err.Error())
+			WriteErrorResponse(w, http.StatusInternalServerError, []string{errInternalServer.Error()})
+			return
+		}
+
+		if !locked {
+			switch {
+			case record.Fingerprint != fingerprint:
+				WriteErrorResponse(w, http.StatusUnprocessableEntity, []string{errIdempotencyKeyMismatch.Error()})
+			case record.Response == nil:
+				WriteErrorResponse(w, http.StatusConflict, []string{errIdempotencyKeyInProgress.Error()})
+			default:
+				replayResponse(w, *record.Response)
+			}
+			return
+		}
+
+		late := &lateResponse{}
+		r = r.WithContext(context.WithValue(r.Context(), lateResponseKey{}, late))
+
+		recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		if recorder.statusCode >= http.StatusInternalServerError {
+			// the record is kept locked until the request is
+			// handled, then its final response is saved
+			if late.fn != nil {
+				go i.saveLateResponse(key, late.fn)
+				return
+			}
+
+			i.unlock(key)
+			return
+		}
+
+		header := make(map[string]string)
+		for _, name := range idempotencyHeaders {
+			if v := w.Header().Get(name); v != "" {
+				header[name] = v
+			}
+		}
+
+		i.save(key, idempotency.Response{
+			StatusCode: recorder.statusCode,
+			Header:     header,
+			Body:       recorder.body.Bytes(),
+		})
+	})
+}
+
+// saveLateResponse waits for the final response returned by the
+// given function and saves it with the given key, or unlocks
+// the record if the request failed.
+func (i *Idempotency) saveLateResponse(key string, fn LateResponseFunc) {
+	statusCode, body, ok := fn()
+	if !ok || statusCode >= http.StatusInternalServerError {
+		i.unlock(key)
+		return
+	}
+
+	i.save(key, idempotency.Response{
+		StatusCode: statusCode,
+		Header: map[string]string{
+			"Content-Type": "application/json",
+		},
+		Body: body,
+	})
+}
+
+// save saves the given response with the given key. It is saved
+// even if the request is canceled, the response might have been
+// handled.
+func (i *Idempotency) save(key string, res idempotency.Response) {
+	err := i.store.Save(context.Background(), key, res, i.ttl)
+	if err != nil {
+		log.Printf("[HTTP][Idempotency] Failed to save response. Key: %s, Err: %s\n", key, err.Error())
+	}
+}
+
+// unlock unlocks the record with the given key, so the request
+// can be retried.
+func (i *Idempotency) unlock(key string) {
+	err := i.store.Unlock(context.Background(), key)
+	if err != nil {
+		log.Printf("[HTTP][Idempotency] Failed to unlock record. Key: %s, Err: %s\n", key, err.Error())
+	}
+}
+
+// replayResponse writes the given saved response.
+func replayResponse(w http.ResponseWriter, res idempotency.Response) {
+	for name, value := range res.Header {
+		w.Header().Set(name, value)
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+
+	w.WriteHeader(res.StatusCode)
+	w.Write(res.Body)
+}
+
+// responseRecorder writes a response while recording its
+// status code and body.
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+// WriteHeader implements http.ResponseWriter.
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if !r.wroteHeader {
+		r.statusCode = statusCode
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write implements http.ResponseWriter.
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
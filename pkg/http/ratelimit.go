@@ -0,0 +1,139 @@
+package http
+
+import (
+	"errors"
+	"hbdtoyou/pkg/ratelimit"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errTooManyRequest is written when a request is denied by
+// RateLimiter, it is the same error the handlers return for
+// too many requests.
+var errTooManyRequest = errors.New("TOO_MANY_REQUEST")
+
+// LimitFunc returns the scope of the given request and its
+// rate limit. The request is not limited if ok is false.
+type LimitFunc func(r *http.Request) (scope string, limit ratelimit.Limit, ok bool)
+
+// RateLimiter limits the rate of HTTP requests per scope,
+// keyed by the user ID of the request or the client IP if it
+// is not authenticated.
+//
+// The user ID is the one stored by Authenticate(), after the
+// access token is validated. X-UserID header is never used,
+// it can be changed by the client to get a new bucket or to
+// drain the bucket of another user.
+type RateLimiter struct {
+	store          ratelimit.Store
+	clientIPHeader string
+}
+
+// NewRateLimiter creates a new RateLimiter keeping the buckets
+// in the given store.
+//
+// The client IP is read from the given header, e.g.
+// X-Forwarded-For, when the service is behind a proxy. Its
+// last value is used, which is the one set by the proxy. The
+// remote address of the connection is used if the header is
+// empty.
+func NewRateLimiter(store ratelimit.Store, clientIPHeader string) *RateLimiter {
+	return &RateLimiter{
+		store:          store,
+		clientIPHeader: clientIPHeader,
+	}
+}
+
+// Handler returns an http.Handler limiting the requests to the
+// given handler using the limit returned by limitFunc.
+//
+// The rate limit headers are written in the response of a
+// limited request: X-RateLimit-Limit, X-RateLimit-Remaining
+// and X-RateLimit-Reset, in seconds. A denied request gets 429
+// status with Retry-After header. The request is allowed if
+// the store fails.
+func (l *RateLimiter) Handler(next http.Handler, limitFunc LimitFunc) http.Handler {
+	return l.handler(next, limitFunc, l.getClientKey)
+}
+
+// PublicHandler returns an http.Handler limiting the requests
+// to the given handler like Handler(), keyed by the client IP
+// only. It is used for the requests made without a user, e.g.
+// logging in, where a user is not a limit of the client.
+func (l *RateLimiter) PublicHandler(next http.Handler, limitFunc LimitFunc) http.Handler {
+	return l.handler(next, limitFunc, func(r *http.Request) string {
+		return "ip:" + l.getClientIP(r)
+	})
+}
+
+// handler returns an http.Handler limiting the requests to the
+// given handler, keyed by the given keyFunc.
+func (l *RateLimiter) handler(next http.Handler, limitFunc LimitFunc, keyFunc func(r *http.Request) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope, limit, ok := limitFunc(r)
+		if !ok || !limit.Enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := scope + ":" + keyFunc(r)
+		res, err := l.store.Take(r.Context(), key, limit)
+		if err != nil {
+			log.Printf("[HTTP][RateLimiter] Failed to take token, request is allowed. Key: %s, Err: %s\n", key, err.Error())
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		w.Header().Set("X-RateLimit-Reset", formatSeconds(res.ResetAfter))
+
+		if !res.Allowed {
+			w.Header().Set("Retry-After", formatSeconds(res.RetryAfter))
+			WriteErrorResponse(w, http.StatusTooManyRequests, []string{errTooManyRequest.Error()})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// getClientKey returns the key of the client sending the given
+// request, its authenticated user ID or its IP.
+func (l *RateLimiter) getClientKey(r *http.Request) string {
+	if userID, ok := GetAuthenticatedUserID(r); ok {
+		return "user:" + userID
+	}
+
+	return "ip:" + l.getClientIP(r)
+}
+
+// getClientIP returns the IP of the client sending the given
+// request.
+func (l *RateLimiter) getClientIP(r *http.Request) string {
+	if l.clientIPHeader != "" {
+		values := strings.Split(r.Header.Get(l.clientIPHeader), ",")
+		ip := strings.TrimSpace(values[len(values)-1])
+		if ip != "" {
+			return ip
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// formatSeconds formats the given duration as whole seconds,
+// rounded up.
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
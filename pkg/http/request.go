@@ -3,6 +3,7 @@ package http
 import (
 	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -20,6 +21,18 @@ var (
 	// ErrUserIDNotFound is returned when there is no user ID
 	// in the HTTP request header.
 	ErrUserIDNotFound = errors.New("user id not found")
+
+	// ErrIfMatchNotFound is returned when there is no
+	// If-Match in the HTTP request header.
+	ErrIfMatchNotFound = errors.New("if-match not found")
+
+	// ErrInvalidIfMatch is returned when the If-Match in the
+	// HTTP request header is not a valid version ETag.
+	ErrInvalidIfMatch = errors.New("invalid if-match")
+
+	// ErrInvalidPagination is returned when the page or limit
+	// in the HTTP request query is not a positive number.
+	ErrInvalidPagination = errors.New("invalid pagination")
 )
 
 // GetBearerTokenFromHeader returns token value stored in HTTP
@@ -63,3 +76,55 @@ func GetUserIDFromHeader(r *http.Request) (string, error) {
 	}
 	return userID, nil
 }
+
+// GetIfMatchVersionFromHeader returns the version stored as an
+// ETag in HTTP request header.
+//
+// Value is stored in standard header: If-Match, formatted by
+// FormatVersionETag(). Weak ETag is accepted as well.
+func GetIfMatchVersionFromHeader(r *http.Request) (int64, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" {
+		return 0, ErrIfMatchNotFound
+	}
+
+	value = strings.TrimPrefix(value, "W/")
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return 0, ErrInvalidIfMatch
+	}
+
+	version, err := strconv.ParseInt(value[1:len(value)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, ErrInvalidIfMatch
+	}
+
+	return version, nil
+}
+
+// GetPaginationFromQuery returns the requested page and limit
+// stored in HTTP request query.
+//
+// Values are stored in query parameters: page and limit. Zero
+// is returned for an omitted value.
+func GetPaginationFromQuery(r *http.Request) (int, int, error) {
+	query := r.URL.Query()
+
+	var page, limit int
+	if value := query.Get("page"); value != "" {
+		v, err := strconv.Atoi(value)
+		if err != nil || v <= 0 {
+			return 0, 0, ErrInvalidPagination
+		}
+		page = v
+	}
+
+	if value := query.Get("limit"); value != "" {
+		v, err := strconv.Atoi(value)
+		if err != nil || v <= 0 {
+			return 0, 0, ErrInvalidPagination
+		}
+		limit = v
+	}
+
+	return page, limit, nil
+}
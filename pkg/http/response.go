@@ -3,15 +3,25 @@ package http
 import (
 	"encoding/json"
 	"fmt"
+	"mime"
 	"net/http"
 )
 
 // ResponseEnvelope is Memorify standard JSON object for HTTP
 // response.
 type ResponseEnvelope struct {
-	Data   interface{} `json:"data,omitempty"`
-	Errors []string    `json:"errors,omitempty"`
-	Status string      `json:"status,omitempty"`
+	Data       interface{} `json:"data,omitempty"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+	Errors     []string    `json:"errors,omitempty"`
+	Status     string      `json:"status,omitempty"`
+}
+
+// Pagination describes the page of a listed data in
+// ResponseEnvelope.
+type Pagination struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+	Total int `json:"total"`
 }
 
 // ResponseDecorator is a HTTP respose decorator.
@@ -41,6 +51,45 @@ func (d contentTypeDecorator) Decorate(w http.ResponseWriter) {
 	w.Header().Set("Content-Type", string(d))
 }
 
+// contentDispositionDecorator implements ResponseDecorator to
+// set Content-Disposition in the HTTP response.
+type contentDispositionDecorator string
+
+// NewContentDispositionDecorator returns a ResponseDecorator
+// to set Content-Disposition in the HTTP response, with the
+// given disposition type (inline or attachment) and file name.
+func NewContentDispositionDecorator(disposition, filename string) ResponseDecorator {
+	return contentDispositionDecorator(mime.FormatMediaType(disposition, map[string]string{
+		"filename": filename,
+	}))
+}
+
+// Decorate updates Content-Disposition in the HTTP response.
+func (d contentDispositionDecorator) Decorate(w http.ResponseWriter) {
+	w.Header().Set("Content-Disposition", string(d))
+}
+
+// etagDecorator implements ResponseDecorator to set ETag in
+// the HTTP response.
+type etagDecorator string
+
+// NewVersionETagDecorator returns a ResponseDecorator to
+// set ETag of the given data version in the HTTP response.
+func NewVersionETagDecorator(version int64) ResponseDecorator {
+	return etagDecorator(FormatVersionETag(version))
+}
+
+// Decorate updates ETag in the HTTP response.
+func (d etagDecorator) Decorate(w http.ResponseWriter) {
+	w.Header().Set("ETag", string(d))
+}
+
+// FormatVersionETag formats the given data version as an
+// ETag.
+func FormatVersionETag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
 // WriteResponse writes HTTP response based on the given
 // arguments:
 //  - w: Response writer object.
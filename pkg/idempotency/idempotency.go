@@ -0,0 +1,59 @@
+// idempotency provides the records of idempotent requests.
+//
+// The first request sent with an idempotency key locks a
+// record of the key, the response is saved in the record once
+// it is handled. The next requests with the same key get the
+// saved response instead of being handled again, as long as
+// the record is not expired.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Record denotes the record of an idempotency key.
+type Record struct {
+	// Fingerprint identifies the request body sent with the
+	// key, a request with a different body is not the same
+	// request.
+	Fingerprint string
+
+	// Response is nil while the first request is still being
+	// handled.
+	Response *Response
+}
+
+// Response denotes a saved response.
+type Response struct {
+	StatusCode int
+	Header     map[string]string
+	Body       []byte
+}
+
+// Store keeps the records of idempotency keys.
+type Store interface {
+	// Lock creates a record of the given key, expiring after
+	// the given TTL if the response is not saved. It returns
+	// false with the existing record if the key has one that
+	// is not expired.
+	Lock(ctx context.Context, key, fingerprint string, ttl time.Duration) (Record, bool, error)
+
+	// Save saves the response in the locked record of the
+	// given key, the record expires after the given TTL.
+	Save(ctx context.Context, key string, res Response, ttl time.Duration) error
+
+	// Unlock deletes the locked record of the given key, so
+	// the request can be handled again. A record with a saved
+	// response is not deleted.
+	Unlock(ctx context.Context, key string) error
+}
+
+// Fingerprint returns the fingerprint of the given request
+// body.
+func Fingerprint(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
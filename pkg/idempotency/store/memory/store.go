@@ -0,0 +1,142 @@
+// memory provides an idempotency.Store keeping the records in
+// memory. The records are kept per process, a retry handled
+// by another instance is not replayed.
+package memory
+
+import (
+	"context"
+	"hbdtoyou/pkg/idempotency"
+	"sync"
+	"time"
+)
+
+// defaultPurgeInterval is the default interval of removing
+// the expired records.
+const defaultPurgeInterval = time.Minute
+
+// Store implements idempotency.Store.
+type Store struct {
+	mu      sync.Mutex
+	records map[string]entry
+
+	purgeInterval time.Duration
+	done          chan struct{}
+	closeOnce     sync.Once
+	timeNow       func() time.Time
+}
+
+type entry struct {
+	record     idempotency.Record
+	expireTime time.Time
+}
+
+// Option controls the behavior of Store.
+type Option func(*Store)
+
+// WithPurgeInterval returns Option to set the interval of
+// removing the expired records.
+func WithPurgeInterval(interval time.Duration) Option {
+	return Option(func(s *Store) {
+		if interval > 0 {
+			s.purgeInterval = interval
+		}
+	})
+}
+
+// New creates a new Store. It removes the expired records in
+// background until it is closed.
+func New(options ...Option) *Store {
+	s := &Store{
+		records:       make(map[string]entry),
+		purgeInterval: defaultPurgeInterval,
+		done:          make(chan struct{}),
+		timeNow:       time.Now,
+	}
+
+	// apply options
+	for _, opt := range options {
+		opt(s)
+	}
+
+	go s.purge()
+	return s
+}
+
+// Lock implements idempotency.Store.
+func (s *Store) Lock(ctx context.Context, key, fingerprint string, ttl time.Duration) (idempotency.Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.timeNow()
+	if e, ok := s.records[key]; ok && now.Before(e.expireTime) {
+		return e.record, false, nil
+	}
+
+	record := idempotency.Record{Fingerprint: fingerprint}
+	s.records[key] = entry{
+		record:     record,
+		expireTime: now.Add(ttl),
+	}
+
+	return record, true, nil
+}
+
+// Save implements idempotency.Store.
+func (s *Store) Save(ctx context.Context, key string, res idempotency.Response, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.records[key]
+	if !ok {
+		return nil
+	}
+
+	e.record.Response = &res
+	e.expireTime = s.timeNow().Add(ttl)
+	s.records[key] = e
+
+	return nil
+}
+
+// Unlock implements idempotency.Store.
+func (s *Store) Unlock(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.records[key]; ok && e.record.Response == nil {
+		delete(s.records, key)
+	}
+
+	return nil
+}
+
+// Close stops removing the expired records.
+func (s *Store) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+	return nil
+}
+
+// purge removes the expired records periodically.
+func (s *Store) purge() {
+	ticker := time.NewTicker(s.purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+
+		s.mu.Lock()
+		now := s.timeNow()
+		for key, e := range s.records {
+			if !now.Before(e.expireTime) {
+				delete(s.records, key)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,39 @@
+package postgresql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"hbdtoyou/pkg/idempotency"
+)
+
+type recordModel struct {
+	Fingerprint string         `db:"fingerprint"`
+	StatusCode  sql.NullInt64  `db:"status_code"`
+	Header      sql.NullString `db:"header"`
+	Body        []byte         `db:"body"`
+}
+
+// format formats database struct into idempotency.Record.
+func (dbData *recordModel) format() (idempotency.Record, error) {
+	record := idempotency.Record{
+		Fingerprint: dbData.Fingerprint,
+	}
+	if !dbData.StatusCode.Valid {
+		return record, nil
+	}
+
+	var header map[string]string
+	if dbData.Header.Valid {
+		err := json.Unmarshal([]byte(dbData.Header.String), &header)
+		if err != nil {
+			return idempotency.Record{}, err
+		}
+	}
+
+	record.Response = &idempotency.Response{
+		StatusCode: int(dbData.StatusCode.Int64),
+		Header:     header,
+		Body:       dbData.Body,
+	}
+	return record, nil
+}
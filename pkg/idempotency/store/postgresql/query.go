@@ -0,0 +1,77 @@
+package postgresql
+
+const (
+	// queryLockRecord creates the record of a key, or takes
+	// over its expired record. No row is returned if the key
+	// has a record that is not expired.
+	queryLockRecord = `
+		INSERT INTO
+			idempotency_record AS r
+			(
+				key,
+				fingerprint,
+				create_time,
+				expire_time
+			)
+		VALUES
+			(
+				$1,
+				$2,
+				now(),
+				now() + CAST($3 AS DOUBLE PRECISION) * INTERVAL '1 second'
+			)
+		ON CONFLICT (key) DO UPDATE SET
+			fingerprint = EXCLUDED.fingerprint,
+			status_code = NULL,
+			header = NULL,
+			body = NULL,
+			create_time = EXCLUDED.create_time,
+			expire_time = EXCLUDED.expire_time
+		WHERE
+			r.expire_time <= now()
+		RETURNING
+			key
+	`
+
+	queryGetRecord = `
+		SELECT
+			fingerprint,
+			status_code,
+			header,
+			body
+		FROM
+			idempotency_record
+		WHERE
+			key = $1
+		AND
+			expire_time > now()
+	`
+
+	querySaveResponse = `
+		UPDATE
+			idempotency_record
+		SET
+			status_code = $2,
+			header = $3,
+			body = $4,
+			expire_time = now() + CAST($5 AS DOUBLE PRECISION) * INTERVAL '1 second'
+		WHERE
+			key = $1
+	`
+
+	queryDeleteLockedRecord = `
+		DELETE FROM
+			idempotency_record
+		WHERE
+			key = $1
+		AND
+			status_code IS NULL
+	`
+
+	queryDeleteExpiredRecords = `
+		DELETE FROM
+			idempotency_record
+		WHERE
+			expire_time <= now()
+	`
+)
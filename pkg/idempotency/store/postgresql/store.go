@@ -0,0 +1,151 @@
+// postgresql provides an idempotency.Store keeping the records
+// in PostgreSQL, so a retry is replayed by any instance of the
+// service.
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"hbdtoyou/pkg/idempotency"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Followings are default values of Store.
+const (
+	defaultPurgeInterval = 5 * time.Minute
+	maxLockAttempts      = 3
+)
+
+// Followings are the known errors from the store.
+var (
+	errInvalidDB  = errors.New("idempotency: invalid database")
+	errLockFailed = errors.New("idempotency: failed to lock record")
+)
+
+// Store implements idempotency.Store.
+type Store struct {
+	db *sqlx.DB
+
+	purgeInterval time.Duration
+	done          chan struct{}
+	closeOnce     sync.Once
+}
+
+// Option controls the behavior of Store.
+type Option func(*Store)
+
+// WithPurgeInterval returns Option to set the interval of
+// deleting the expired records.
+func WithPurgeInterval(interval time.Duration) Option {
+	return Option(func(s *Store) {
+		if interval > 0 {
+			s.purgeInterval = interval
+		}
+	})
+}
+
+// New creates a new Store of the records in the given
+// database. It deletes the expired records in background
+// until it is closed.
+func New(db *sqlx.DB, options ...Option) (*Store, error) {
+	if db == nil {
+		return nil, errInvalidDB
+	}
+
+	s := &Store{
+		db:            db,
+		purgeInterval: defaultPurgeInterval,
+		done:          make(chan struct{}),
+	}
+
+	// apply options
+	for _, opt := range options {
+		opt(s)
+	}
+
+	go s.purge()
+	return s, nil
+}
+
+// Lock implements idempotency.Store.
+func (s *Store) Lock(ctx context.Context, key, fingerprint string, ttl time.Duration) (idempotency.Record, bool, error) {
+	// the existing record might expire between the queries,
+	// locking is attempted again then
+	for attempt := 0; attempt < maxLockAttempts; attempt++ {
+		var lockedKey string
+		err := s.db.QueryRowxContext(ctx, queryLockRecord, key, fingerprint, ttl.Seconds()).Scan(&lockedKey)
+		if err == nil {
+			return idempotency.Record{Fingerprint: fingerprint}, true, nil
+		}
+		if err != sql.ErrNoRows {
+			return idempotency.Record{}, false, err
+		}
+
+		var dbData recordModel
+		err = s.db.QueryRowxContext(ctx, queryGetRecord, key).StructScan(&dbData)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return idempotency.Record{}, false, err
+		}
+
+		record, err := dbData.format()
+		if err != nil {
+			return idempotency.Record{}, false, err
+		}
+		return record, false, nil
+	}
+
+	return idempotency.Record{}, false, errLockFailed
+}
+
+// Save implements idempotency.Store.
+func (s *Store) Save(ctx context.Context, key string, res idempotency.Response, ttl time.Duration) error {
+	header, err := json.Marshal(res.Header)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, querySaveResponse, key, res.StatusCode, string(header), res.Body, ttl.Seconds())
+	return err
+}
+
+// Unlock implements idempotency.Store.
+func (s *Store) Unlock(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, queryDeleteLockedRecord, key)
+	return err
+}
+
+// Close stops deleting the expired records.
+func (s *Store) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+	return nil
+}
+
+// purge deletes the expired records periodically.
+func (s *Store) purge() {
+	ticker := time.NewTicker(s.purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+
+		_, err := s.db.Exec(queryDeleteExpiredRecords)
+		if err != nil {
+			log.Printf("[idempotency] failed to delete expired records: %s\n", err.Error())
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// imageproc provides image normalization functionalities,
+// e.g. auto-orientation, resizing and re-encoding.
+//
+// Decoding an image into pixels and re-encoding it drops all
+// of its metadata, including EXIF and GPS information, so any
+// image passed through Decode() and Encode() is stripped.
+package imageproc
+
+import (
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	// registers gif and webp decoder
+	_ "image/gif"
+
+	_ "golang.org/x/image/webp"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/disintegration/imaging"
+)
+
+// Followings are the known errors from imageproc.
+var (
+	// ErrUnsupportedFormat is returned when encoding into an
+	// unknown format.
+	ErrUnsupportedFormat = errors.New("imageproc: unsupported format")
+
+	// ErrInvalidDimension is returned when the given
+	// dimension is invalid.
+	ErrInvalidDimension = errors.New("imageproc: invalid dimension")
+)
+
+// Format denotes an image encoding format.
+type Format string
+
+// Followings are the known image formats.
+const (
+	FormatJPEG Format = "jpeg"
+	FormatPNG  Format = "png"
+	FormatWebP Format = "webp"
+)
+
+var (
+	// FormatList is a list of valid image formats.
+	FormatList = map[Format]struct{}{
+		FormatJPEG: {},
+		FormatPNG:  {},
+		FormatWebP: {},
+	}
+
+	// FormatMIMEType maps image format to its MIME type.
+	FormatMIMEType = map[Format]string{
+		FormatJPEG: "image/jpeg",
+		FormatPNG:  "image/png",
+		FormatWebP: "image/webp",
+	}
+
+	// DecodableMIMEType is a list of image MIME types that
+	// can be decoded by Decode().
+	DecodableMIMEType = map[string]struct{}{
+		"image/jpeg": {},
+		"image/png":  {},
+		"image/gif":  {},
+		"image/webp": {},
+	}
+
+	// FormatExtension maps image format to its file
+	// extension.
+	FormatExtension = map[Format]string{
+		FormatJPEG: ".jpg",
+		FormatPNG:  ".png",
+		FormatWebP: ".webp",
+	}
+)
+
+// defaultJPEGQuality is the JPEG quality used when the given
+// quality is out of range.
+const defaultJPEGQuality = 85
+
+// Decode decodes an image from r and rotates/flips it
+// according to its EXIF orientation tag, if any.
+func Decode(r io.Reader) (image.Image, error) {
+	return imaging.Decode(r, imaging.AutoOrientation(true))
+}
+
+// DecodeConfig decodes the dimension of an image from r
+// without decoding the entire image.
+func DecodeConfig(r io.Reader) (width, height int, err error) {
+	cfg, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return 0, 0, err
+	}
+	return cfg.Width, cfg.Height, nil
+}
+
+// Fit scales down the given image to fit within the given
+// maximum width and height while preserving its aspect
+// ratio. An image that already fits is returned as it is, so
+// images are never upscaled.
+func Fit(img image.Image, maxWidth, maxHeight int) (image.Image, error) {
+	if maxWidth <= 0 || maxHeight <= 0 {
+		return nil, ErrInvalidDimension
+	}
+
+	bounds := img.Bounds()
+	if bounds.Dx() <= maxWidth && bounds.Dy() <= maxHeight {
+		return img, nil
+	}
+
+	return imaging.Fit(img, maxWidth, maxHeight, imaging.Lanczos), nil
+}
+
+// Encode writes the given image into w in the given format.
+//
+// quality is only used by lossy formats and ranges from 1 to
+// 100. WebP images are encoded losslessly.
+func Encode(w io.Writer, img image.Image, format Format, quality int) error {
+	switch format {
+	case FormatJPEG:
+		if quality < 1 || quality > 100 {
+			quality = defaultJPEGQuality
+		}
+		// JPEG does not support transparency, flatten the
+		// image on a white background
+		if !isOpaque(img) {
+			bg := imaging.New(img.Bounds().Dx(), img.Bounds().Dy(), image.White)
+			img = imaging.Overlay(bg, img, image.Pt(0, 0), 1)
+		}
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+	case FormatPNG:
+		encoder := png.Encoder{CompressionLevel: png.BestCompression}
+		return encoder.Encode(w, img)
+	case FormatWebP:
+		return nativewebp.Encode(w, img, nil)
+	}
+
+	return ErrUnsupportedFormat
+}
+
+// isOpaque checks whether the given image has no transparent
+// pixel.
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+// job provides a runner to run a function periodically in
+// the background.
+package job
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// Followings are the known errors from job.
+var (
+	// ErrInvalidInterval is returned when the given interval
+	// is invalid.
+	ErrInvalidInterval = errors.New("job: invalid interval")
+
+	// ErrMissingFunc is returned when there is no function to
+	// run.
+	ErrMissingFunc = errors.New("job: missing function")
+
+	// ErrAlreadyStarted is returned when starting a runner
+	// more than once.
+	ErrAlreadyStarted = errors.New("job: already started")
+)
+
+// Func is the function run by a Runner.
+//
+// The given context is canceled when the runner is stopped or
+// the run has reached its timeout.
+type Func func(ctx context.Context) error
+
+// Runner runs a function periodically.
+//
+// The function is run once right after the runner is started,
+// then every interval. A run is never overlapped by the next
+// one.
+type Runner struct {
+	name     string
+	interval time.Duration
+	timeout  time.Duration
+	fn       Func
+
+	mu      sync.Mutex
+	started bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// NewRunner creates a new Runner.
+//
+// timeout limits the duration of a single run. If timeout is
+// zero, the interval is used as the timeout.
+func NewRunner(name string, interval, timeout time.Duration, fn Func) (*Runner, error) {
+	if interval <= 0 || timeout < 0 {
+		return nil, ErrInvalidInterval
+	}
+
+	if fn == nil {
+		return nil, ErrMissingFunc
+	}
+
+	if timeout == 0 {
+		timeout = interval
+	}
+
+	return &Runner{
+		name:     name,
+		interval: interval,
+		timeout:  timeout,
+		fn:       fn,
+	}, nil
+}
+
+// Start starts running the function in the background.
+func (r *Runner) Start() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.started {
+		return ErrAlreadyStarted
+	}
+	r.started = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+
+	go r.loop(ctx)
+
+	return nil
+}
+
+// Stop stops the runner and waits until the current run, if
+// any, is finished.
+func (r *Runner) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.started {
+		return
+	}
+	r.started = false
+
+	r.cancel()
+	<-r.done
+}
+
+// loop runs the function every interval until the given
+// context is canceled.
+func (r *Runner) loop(ctx context.Context) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run runs the function once and recovers from panic, so a
+// bad run does not stop the runner.
+func (r *Runner) run(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			log.Printf("[job][%s] run panic recovered: %v\n", r.name, rec)
+		}
+	}()
+
+	err := r.fn(ctx)
+	if err != nil {
+		log.Printf("[job][%s] run failed: %s\n", r.name, err.Error())
+	}
+}
@@ -0,0 +1,39 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	contextlib "hbdtoyou/pkg/context"
+)
+
+// ForEachTenant returns a Func running fn once for each of the
+// given tenants, with the tenant set in its context through
+// contextlib.SetTenant(). A failing tenant does not stop the
+// others, the errors are joined.
+//
+// fn is returned as is if there is no tenant.
+func ForEachTenant(tenants []string, fn Func) Func {
+	if len(tenants) == 0 {
+		return fn
+	}
+
+	return func(ctx context.Context) error {
+		var errs []error
+		for _, tenant := range tenants {
+			// the run has been stopped or timed out
+			if ctx.Err() != nil {
+				errs = append(errs, ctx.Err())
+				break
+			}
+
+			err := fn(contextlib.SetTenant(ctx, tenant))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("tenant %s: %w", tenant, err))
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+}
@@ -0,0 +1,188 @@
+// jsondiff provides functionalities to compare JSON documents.
+//
+// The differences are described as a list of changes similar
+// to JSON Patch (RFC 6902) operations, where each change
+// points to the changed value using JSON Pointer (RFC 6901).
+package jsondiff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Operation denotes the kind of a change.
+type Operation string
+
+// Followings are the known operations.
+const (
+	OperationAdd     Operation = "add"
+	OperationRemove  Operation = "remove"
+	OperationReplace Operation = "replace"
+)
+
+// Change denotes a single difference between two documents.
+type Change struct {
+	Operation Operation `json:"op"`
+	Path      string    `json:"path"`
+
+	// OldValue is the value in the first document. It is
+	// empty for add operation.
+	OldValue interface{} `json:"old_value,omitempty"`
+
+	// NewValue is the value in the second document. It is
+	// empty for remove operation.
+	NewValue interface{} `json:"new_value,omitempty"`
+}
+
+// Diff returns changes needed to turn JSON document a into
+// JSON document b.
+func Diff(a, b []byte) ([]Change, error) {
+	va, err := decode(a)
+	if err != nil {
+		return nil, err
+	}
+
+	vb, err := decode(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return DiffValues(va, vb), nil
+}
+
+// DiffValues returns changes needed to turn decoded JSON
+// value a into decoded JSON value b.
+//
+// The values are expected to be the result of decoding JSON
+// into interface{}.
+func DiffValues(a, b interface{}) []Change {
+	changes := make([]Change, 0)
+	return diff(changes, "", a, b)
+}
+
+// decode decodes the given JSON document while keeping
+// numbers as they are written.
+func decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, fmt.Errorf("jsondiff: %w", err)
+	}
+	return v, nil
+}
+
+// diff appends changes between a and b located at the given
+// path into changes.
+func diff(changes []Change, path string, a, b interface{}) []Change {
+	switch va := a.(type) {
+	case map[string]interface{}:
+		vb, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+		return diffObject(changes, path, va, vb)
+	case []interface{}:
+		vb, ok := b.([]interface{})
+		if !ok {
+			break
+		}
+		return diffArray(changes, path, va, vb)
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		changes = append(changes, Change{
+			Operation: OperationReplace,
+			Path:      path,
+			OldValue:  a,
+			NewValue:  b,
+		})
+	}
+	return changes
+}
+
+// diffObject appends changes between objects a and b. Keys
+// are compared in sorted order, so the result is stable.
+func diffObject(changes []Change, path string, a, b map[string]interface{}) []Change {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		childPath := path + "/" + escape(k)
+
+		va, inA := a[k]
+		vb, inB := b[k]
+		switch {
+		case inA && !inB:
+			changes = append(changes, Change{
+				Operation: OperationRemove,
+				Path:      childPath,
+				OldValue:  va,
+			})
+		case !inA && inB:
+			changes = append(changes, Change{
+				Operation: OperationAdd,
+				Path:      childPath,
+				NewValue:  vb,
+			})
+		default:
+			changes = diff(changes, childPath, va, vb)
+		}
+	}
+
+	return changes
+}
+
+// diffArray appends changes between arrays a and b by
+// comparing their elements by index.
+func diffArray(changes []Change, path string, a, b []interface{}) []Change {
+	common := len(a)
+	if len(b) < common {
+		common = len(b)
+	}
+
+	for i := 0; i < common; i++ {
+		changes = diff(changes, fmt.Sprintf("%s/%d", path, i), a[i], b[i])
+	}
+
+	for i := common; i < len(b); i++ {
+		changes = append(changes, Change{
+			Operation: OperationAdd,
+			Path:      fmt.Sprintf("%s/%d", path, i),
+			NewValue:  b[i],
+		})
+	}
+
+	// remove from the last element, so the indexes of the
+	// remaining elements are still valid when applied in
+	// order
+	for i := len(a) - 1; i >= common; i-- {
+		changes = append(changes, Change{
+			Operation: OperationRemove,
+			Path:      fmt.Sprintf("%s/%d", path, i),
+			OldValue:  a[i],
+		})
+	}
+
+	return changes
+}
+
+// escape escapes the given object key as a JSON Pointer
+// reference token.
+func escape(key string) string {
+	key = strings.ReplaceAll(key, "~", "~0")
+	return strings.ReplaceAll(key, "/", "~1")
+}
@@ -0,0 +1,376 @@
+// jsonschema provides validation of JSON documents against a
+// JSON Schema.
+//
+// Only a subset of the specification is supported, which is
+// enough to describe settings and configurations:
+//   - type: null, boolean, integer, number, string, array and
+//     object
+//   - enum
+//   - minLength, maxLength and pattern of strings
+//   - minimum and maximum of numbers
+//   - items, minItems, maxItems and uniqueItems of arrays
+//   - properties, required and additionalProperties of objects
+//
+// Other keywords are ignored.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrInvalidDocument is returned when the validated
+	// document is not a valid JSON.
+	ErrInvalidDocument = errors.New("invalid json document")
+)
+
+// Schema denotes a JSON Schema.
+type Schema struct {
+	Type string        `json:"type"`
+	Enum []interface{} `json:"enum"`
+
+	MinLength *int   `json:"minLength"`
+	MaxLength *int   `json:"maxLength"`
+	Pattern   string `json:"pattern"`
+
+	Minimum *float64 `json:"minimum"`
+	Maximum *float64 `json:"maximum"`
+
+	Items       *Schema `json:"items"`
+	MinItems    *int    `json:"minItems"`
+	MaxItems    *int    `json:"maxItems"`
+	UniqueItems bool    `json:"uniqueItems"`
+
+	Properties map[string]*Schema `json:"properties"`
+	Required   []string           `json:"required"`
+
+	// AdditionalProperties allows properties not listed in
+	// Properties, they are allowed if it is nil.
+	AdditionalProperties *bool `json:"additionalProperties"`
+
+	pattern *regexp.Regexp
+}
+
+// ValidationError is returned when a document does not match
+// the schema.
+type ValidationError struct {
+	// Path points to the invalid value using JSON Pointer
+	// (RFC 6901).
+	Path    string
+	Message string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Parse parses the given JSON Schema.
+func Parse(data []byte) (*Schema, error) {
+	var s Schema
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// MustParse is like Parse but panics if the schema can not be
+// parsed. It simplifies initialization of global variables
+// holding schemas.
+func MustParse(data string) *Schema {
+	s, err := Parse([]byte(data))
+	if err != nil {
+		panic(fmt.Sprintf("jsonschema: Parse(%q): %s", data, err.Error()))
+	}
+
+	return s
+}
+
+// compile prepares the schema and its subschemas to be used in
+// validation.
+func (s *Schema) compile() error {
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return err
+		}
+		s.pattern = pattern
+	}
+
+	if s.Items != nil {
+		err := s.Items.compile()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, property := range s.Properties {
+		if property == nil {
+			continue
+		}
+
+		err := property.compile()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the given JSON document against the
+// schema. A *ValidationError is returned if the document does
+// not match the schema.
+func (s *Schema) Validate(document []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+
+	var value interface{}
+	err := decoder.Decode(&value)
+	if err != nil {
+		return ErrInvalidDocument
+	}
+
+	// only one document is allowed
+	if _, err := decoder.Token(); err != io.EOF {
+		return ErrInvalidDocument
+	}
+
+	return s.validate("", value)
+}
+
+func (s *Schema) validate(path string, value interface{}) error {
+	err := s.validateType(path, value)
+	if err != nil {
+		return err
+	}
+
+	if len(s.Enum) > 0 {
+		var found bool
+		for _, v := range s.Enum {
+			if equal(v, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return &ValidationError{Path: path, Message: "value is not one of the allowed values"}
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return s.validateString(path, v)
+	case json.Number:
+		return s.validateNumber(path, v)
+	case []interface{}:
+		return s.validateArray(path, v)
+	case map[string]interface{}:
+		return s.validateObject(path, v)
+	}
+
+	return nil
+}
+
+func (s *Schema) validateType(path string, value interface{}) error {
+	if s.Type == "" {
+		return nil
+	}
+
+	var valid bool
+	switch v := value.(type) {
+	case nil:
+		valid = s.Type == "null"
+	case bool:
+		valid = s.Type == "boolean"
+	case string:
+		valid = s.Type == "string"
+	case json.Number:
+		valid = s.Type == "number" || (s.Type == "integer" && isInteger(v))
+	case []interface{}:
+		valid = s.Type == "array"
+	case map[string]interface{}:
+		valid = s.Type == "object"
+	}
+
+	if !valid {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("value must be of type %s", s.Type)}
+	}
+
+	return nil
+}
+
+func (s *Schema) validateString(path string, value string) error {
+	length := utf8.RuneCountInString(value)
+	if s.MinLength != nil && length < *s.MinLength {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("length must be at least %d", *s.MinLength)}
+	}
+
+	if s.MaxLength != nil && length > *s.MaxLength {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("length must be at most %d", *s.MaxLength)}
+	}
+
+	if s.pattern != nil && !s.pattern.MatchString(value) {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("value must match pattern %s", s.Pattern)}
+	}
+
+	return nil
+}
+
+func (s *Schema) validateNumber(path string, value json.Number) error {
+	number, err := value.Float64()
+	if err != nil {
+		return &ValidationError{Path: path, Message: "value is not a valid number"}
+	}
+
+	if s.Minimum != nil && number < *s.Minimum {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("value must be at least %v", *s.Minimum)}
+	}
+
+	if s.Maximum != nil && number > *s.Maximum {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("value must be at most %v", *s.Maximum)}
+	}
+
+	return nil
+}
+
+func (s *Schema) validateArray(path string, value []interface{}) error {
+	if s.MinItems != nil && len(value) < *s.MinItems {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("must have at least %d items", *s.MinItems)}
+	}
+
+	if s.MaxItems != nil && len(value) > *s.MaxItems {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("must have at most %d items", *s.MaxItems)}
+	}
+
+	for i, item := range value {
+		if s.UniqueItems {
+			for _, other := range value[:i] {
+				if equal(item, other) {
+					return &ValidationError{Path: fmt.Sprintf("%s/%d", path, i), Message: "item is not unique"}
+				}
+			}
+		}
+
+		if s.Items != nil {
+			err := s.Items.validate(fmt.Sprintf("%s/%d", path, i), item)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) validateObject(path string, value map[string]interface{}) error {
+	for _, name := range s.Required {
+		if _, ok := value[name]; !ok {
+			return &ValidationError{Path: path + "/" + escape(name), Message: "property is required"}
+		}
+	}
+
+	// properties are validated in order, so the same error is
+	// returned for the same document
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		v := value[name]
+		property, ok := s.Properties[name]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				return &ValidationError{Path: path + "/" + escape(name), Message: "property is not allowed"}
+			}
+			continue
+		}
+
+		if property == nil {
+			continue
+		}
+
+		err := property.validate(path+"/"+escape(name), v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isInteger returns true if the given number has no fractional
+// part, e.g. 1 and 1.0 are integers.
+func isInteger(value json.Number) bool {
+	number, err := value.Float64()
+	if err != nil {
+		return false
+	}
+
+	return number == math.Trunc(number)
+}
+
+// equal returns true if the given decoded JSON values are
+// equal. Numbers are compared by their values.
+func equal(a, b interface{}) bool {
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+// normalize returns the given decoded JSON value with its
+// numbers converted to float64.
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		number, err := v.Float64()
+		if err != nil {
+			return v.String()
+		}
+		return number
+	case int:
+		return float64(v)
+	case []interface{}:
+		result := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			result = append(result, normalize(item))
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[key] = normalize(item)
+		}
+		return result
+	}
+
+	return value
+}
+
+// escape escapes the given property name to be used in a JSON
+// Pointer.
+func escape(name string) string {
+	name = strings.ReplaceAll(name, "~", "~0")
+	return strings.ReplaceAll(name, "/", "~1")
+}
@@ -0,0 +1,184 @@
+// money provides amounts of money in the minor unit of an ISO
+// 4217 currency, so prices are calculated without floating
+// point errors.
+package money
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Followings are the known errors returned from money.
+var (
+	// ErrInvalidCurrency is returned when the given currency
+	// is not a supported ISO 4217 currency code.
+	ErrInvalidCurrency = errors.New("invalid currency")
+
+	// ErrCurrencyMismatch is returned when calculating amounts
+	// of different currencies.
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+)
+
+// Currency denotes a supported currency and how its amounts
+// are displayed.
+type Currency struct {
+	// Code is the ISO 4217 currency code.
+	Code string
+
+	// MinorUnit is the number of decimal places between the
+	// minor and the major unit.
+	MinorUnit int
+
+	Symbol             string
+	ThousandsSeparator string
+	DecimalSeparator   string
+}
+
+// currencies are the supported currencies.
+//
+// IDR is zero-decimal as it is handled by payment gateways,
+// even though ISO 4217 defines 2 decimal places for it.
+var currencies = map[string]Currency{
+	"IDR": {Code: "IDR", MinorUnit: 0, Symbol: "Rp", ThousandsSeparator: ".", DecimalSeparator: ","},
+	"USD": {Code: "USD", MinorUnit: 2, Symbol: "$", ThousandsSeparator: ",", DecimalSeparator: "."},
+	"EUR": {Code: "EUR", MinorUnit: 2, Symbol: "€", ThousandsSeparator: ".", DecimalSeparator: ","},
+	"GBP": {Code: "GBP", MinorUnit: 2, Symbol: "£", ThousandsSeparator: ",", DecimalSeparator: "."},
+	"SGD": {Code: "SGD", MinorUnit: 2, Symbol: "S$", ThousandsSeparator: ",", DecimalSeparator: "."},
+	"MYR": {Code: "MYR", MinorUnit: 2, Symbol: "RM", ThousandsSeparator: ",", DecimalSeparator: "."},
+	"AUD": {Code: "AUD", MinorUnit: 2, Symbol: "A$", ThousandsSeparator: ",", DecimalSeparator: "."},
+	"JPY": {Code: "JPY", MinorUnit: 0, Symbol: "¥", ThousandsSeparator: ",", DecimalSeparator: "."},
+}
+
+// GetCurrency returns the supported currency with the given
+// code. The code is case insensitive.
+func GetCurrency(code string) (Currency, error) {
+	c, ok := currencies[NormalizeCurrency(code)]
+	if !ok {
+		return Currency{}, ErrInvalidCurrency
+	}
+	return c, nil
+}
+
+// IsCurrency returns whether the given code is a supported
+// currency.
+func IsCurrency(code string) bool {
+	_, err := GetCurrency(code)
+	return err == nil
+}
+
+// NormalizeCurrency returns the given currency code in its
+// standard upper case form.
+func NormalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Money denotes an amount of money.
+type Money struct {
+	// Amount is in the minor unit of Currency.
+	Amount   int64
+	Currency string
+}
+
+// New returns an amount of money in the given currency. The
+// currency must be supported.
+func New(amount int64, currency string) (Money, error) {
+	c, err := GetCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{
+		Amount:   amount,
+		Currency: c.Code,
+	}, nil
+}
+
+// IsZero returns whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Equal returns whether both amount and currency are equal.
+func (m Money) Equal(o Money) bool {
+	return m.Amount == o.Amount && m.Currency == o.Currency
+}
+
+// Add returns the sum of both amounts, which must be in the
+// same currency.
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+
+	return Money{
+		Amount:   m.Amount + o.Amount,
+		Currency: m.Currency,
+	}, nil
+}
+
+// Sub returns the difference of both amounts, which must be
+// in the same currency.
+func (m Money) Sub(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+
+	return Money{
+		Amount:   m.Amount - o.Amount,
+		Currency: m.Currency,
+	}, nil
+}
+
+// Percent returns the given percentage of the amount, rounded
+// down to the minor unit.
+func (m Money) Percent(percent int) Money {
+	return Money{
+		Amount:   m.Amount * int64(percent) / 100,
+		Currency: m.Currency,
+	}
+}
+
+// String returns the amount formatted for display using the
+// symbol and separators of its currency, e.g. Rp25.000 or
+// $12.50.
+//
+// An amount in an unsupported currency is formatted with its
+// code instead, e.g. XYZ 1250.
+func (m Money) String() string {
+	c, err := GetCurrency(m.Currency)
+	if err != nil {
+		return strings.TrimSpace(m.Currency + " " + strconv.FormatInt(m.Amount, 10))
+	}
+
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	// split the major and minor units
+	digits := strconv.FormatInt(amount, 10)
+	if len(digits) <= c.MinorUnit {
+		digits = strings.Repeat("0", c.MinorUnit-len(digits)+1) + digits
+	}
+	major := digits[:len(digits)-c.MinorUnit]
+	minor := digits[len(digits)-c.MinorUnit:]
+
+	// group the major unit by thousands
+	var b strings.Builder
+	for i, d := range major {
+		if i > 0 && (len(major)-i)%3 == 0 {
+			b.WriteString(c.ThousandsSeparator)
+		}
+		b.WriteRune(d)
+	}
+
+	if c.MinorUnit > 0 {
+		b.WriteString(c.DecimalSeparator)
+		b.WriteString(minor)
+	}
+
+	return sign + c.Symbol + b.String()
+}
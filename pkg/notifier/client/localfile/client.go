@@ -0,0 +1,73 @@
+// localfile defines notifier client that appends messages to
+// a local file as JSON lines, used in development instead of
+// delivering them.
+package localfile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"hbdtoyou/pkg/notifier"
+)
+
+var (
+	errInvalidPath = errors.New("localfile: invalid path")
+)
+
+// client implements notifier.Client.
+type client struct {
+	path string
+	mu   sync.Mutex
+}
+
+// Config contains the available configuration for a client.
+type Config struct {
+	// Path is the file the messages are appended to. Its
+	// directory is created if it does not exist.
+	Path string
+}
+
+// New returns a new client.
+func New(cfg Config) (*client, error) {
+	if cfg.Path == "" {
+		return nil, errInvalidPath
+	}
+
+	err := os.MkdirAll(filepath.Dir(cfg.Path), 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &client{
+		path: cfg.Path,
+	}, nil
+}
+
+// Send appends the given message to the file.
+func (c *client) Send(ctx context.Context, msg notifier.Message) error {
+	line, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	// concurrent appends must not interleave
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f, err := os.OpenFile(c.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(line)
+	if errClose := f.Close(); err == nil {
+		err = errClose
+	}
+
+	return err
+}
@@ -0,0 +1,25 @@
+// logger defines notifier client that writes messages to the
+// standard logger, used in development instead of delivering
+// them.
+package logger
+
+import (
+	"context"
+	"log"
+
+	"hbdtoyou/pkg/notifier"
+)
+
+// client implements notifier.Client.
+type client struct{}
+
+// New returns a new client.
+func New() *client {
+	return &client{}
+}
+
+// Send writes the given message to the standard logger.
+func (c *client) Send(ctx context.Context, msg notifier.Message) error {
+	log.Printf("[Notifier] To: %s <%s>, Event: %s, Subject: %s, Body: %s\n", msg.Recipient.Name, msg.Recipient.Email, msg.Event, msg.Subject, msg.Body)
+	return nil
+}
@@ -0,0 +1,117 @@
+// smtp defines notifier client that sends messages as emails
+// through an SMTP server.
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"hbdtoyou/pkg/notifier"
+)
+
+var (
+	errInvalidHost = errors.New("smtp: invalid host")
+	errInvalidFrom = errors.New("smtp: invalid from address")
+)
+
+// client implements notifier.Client.
+type client struct {
+	addr    string
+	auth    smtp.Auth
+	from    mail.Address
+	timeNow func() time.Time
+}
+
+// Config contains the available configuration for a client.
+type Config struct {
+	Host string
+	Port int
+
+	// Username and Password authenticate to the server, the
+	// client does not authenticate if Username is empty.
+	Username string
+	Password string
+
+	// From is the sender address of the emails, e.g.
+	// "Memorify <no-reply@example.com>".
+	From string
+}
+
+// New returns a new client.
+func New(cfg Config) (*client, error) {
+	if cfg.Host == "" || cfg.Port <= 0 {
+		return nil, errInvalidHost
+	}
+
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, errInvalidFrom
+	}
+
+	c := &client{
+		addr:    net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		from:    *from,
+		timeNow: time.Now,
+	}
+
+	if cfg.Username != "" {
+		c.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	return c, nil
+}
+
+// Send sends the given message as an email to the recipient
+// email address.
+func (c *client) Send(ctx context.Context, msg notifier.Message) error {
+	if msg.Recipient.Email == "" {
+		return notifier.ErrInvalidRecipient
+	}
+
+	to := mail.Address{
+		Name:    msg.Recipient.Name,
+		Address: msg.Recipient.Email,
+	}
+
+	// the SMTP client does not support context, at least do
+	// not start sending once it is done
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return smtp.SendMail(c.addr, c.auth, c.from.Address, []string{to.Address}, c.buildEmail(to, msg))
+}
+
+// buildEmail returns the given message as a plain text email
+// to the given address.
+func (c *client) buildEmail(to mail.Address, msg notifier.Message) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", c.from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", c.timeNow().Format(time.RFC1123Z))
+	if msg.ID != "" {
+		fmt.Fprintf(&buf, "Message-ID: <%s@%s>\r\n", msg.ID, domainOf(c.from.Address))
+	}
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(msg.Body)
+	buf.WriteString("\r\n")
+
+	return buf.Bytes()
+}
+
+// domainOf returns the domain of the given email address.
+func domainOf(address string) string {
+	return address[strings.LastIndex(address, "@")+1:]
+}
@@ -0,0 +1,113 @@
+// webhook defines notifier client that posts messages as JSON
+// to an HTTP endpoint.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"hbdtoyou/pkg/notifier"
+)
+
+var (
+	errInvalidURL = errors.New("webhook: invalid url")
+)
+
+// Followings are default values for Config fields.
+const (
+	defaultTimeout = 10 * time.Second
+)
+
+// client implements notifier.Client.
+type client struct {
+	url        string
+	headers    map[string]string
+	httpClient *http.Client
+}
+
+// Config contains the available configuration for a client.
+type Config struct {
+	// URL is the endpoint receiving the messages.
+	URL string
+
+	// Headers are added to every request, e.g. to
+	// authenticate to the endpoint.
+	Headers map[string]string
+
+	// Timeout limits the duration of a request.
+	Timeout time.Duration
+}
+
+// New returns a new client.
+func New(cfg Config) (*client, error) {
+	if u, err := url.Parse(cfg.URL); err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, errInvalidURL
+	}
+
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	return &client{
+		url:     cfg.URL,
+		headers: cfg.Headers,
+		httpClient: &http.Client{
+			Timeout: cfg.Timeout,
+		},
+	}, nil
+}
+
+// payload is the JSON body posted to the endpoint.
+type payload struct {
+	ID         string            `json:"id"`
+	Event      string            `json:"event"`
+	UserID     string            `json:"user_id"`
+	Subject    string            `json:"subject"`
+	Body       string            `json:"body"`
+	Data       map[string]string `json:"data"`
+	CreateTime time.Time         `json:"create_time"`
+}
+
+// Send posts the given message to the endpoint. The message is
+// sent once the endpoint responds with a 2xx status.
+func (c *client) Send(ctx context.Context, msg notifier.Message) error {
+	body, err := json.Marshal(payload{
+		ID:         msg.ID,
+		Event:      msg.Event,
+		UserID:     msg.Recipient.UserID,
+		Subject:    msg.Subject,
+		Body:       msg.Body,
+		Data:       msg.Data,
+		CreateTime: msg.CreateTime,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook: unexpected status %d", res.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+// notifier provides clients sending notification messages to
+// users through a channel, e.g. email.
+package notifier
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Followings are the known errors returned from notifier
+// clients.
+var (
+	// ErrInvalidRecipient is returned when the recipient can
+	// not be reached through the channel, e.g. sending an
+	// email to a recipient without email.
+	ErrInvalidRecipient = errors.New("notifier: invalid recipient")
+)
+
+// Message denotes a notification message.
+type Message struct {
+	ID        string
+	Event     string
+	Recipient Recipient
+	Subject   string
+	Body      string
+
+	// Data holds references of the notified change, e.g. the
+	// ID of an approved payment.
+	Data map[string]string
+
+	CreateTime time.Time
+}
+
+// Recipient denotes the user receiving a message.
+type Recipient struct {
+	UserID string
+	Name   string
+	Email  string
+}
+
+// Client is a client that sends messages through a channel.
+//
+// All clients that implement this interface should handle
+// authentication and authorization on their own. For example
+// in the initialization function.
+type Client interface {
+	// Send sends the given message to its recipient.
+	Send(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,103 @@
+package openapi
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	swaggerfiles "github.com/swaggo/files/v2"
+)
+
+//go:embed swagger.html
+var swaggerHTML string
+
+var swaggerTemplate = template.Must(template.New("swagger").Parse(swaggerHTML))
+
+// staticHandler serves a prebuilt response body.
+type staticHandler struct {
+	contentType string
+	body        []byte
+}
+
+// ServeHTTP implements http.Handler.
+func (h *staticHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", h.contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(h.body)
+}
+
+// NewHandler returns an HTTP handler serving the given
+// document as JSON. The document is encoded once, it must not
+// be changed afterward.
+func NewHandler(d *Document) (http.Handler, error) {
+	body, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return &staticHandler{
+		contentType: "application/json",
+		body:        body,
+	}, nil
+}
+
+// swaggerUIAssets are the Swagger UI assets served along with
+// the page.
+var swaggerUIAssets = map[string]string{
+	"swagger-ui.css":       "text/css; charset=utf-8",
+	"swagger-ui-bundle.js": "text/javascript; charset=utf-8",
+}
+
+// NewSwaggerUIHandler returns an HTTP handler serving a
+// Swagger UI page of the document served at the given URL.
+//
+// The handler is mounted at the given path, it serves the page
+// at the path and the Swagger UI assets under it. The assets
+// are embedded in the binary, the page does not load anything
+// from outside.
+func NewSwaggerUIHandler(title, specURL, path string) (http.Handler, error) {
+	path = strings.TrimRight(path, "/")
+
+	var buf bytes.Buffer
+	err := swaggerTemplate.Execute(&buf, struct {
+		Title      string
+		SpecURL    string
+		AssetsPath string
+	}{
+		Title:      title,
+		SpecURL:    specURL,
+		AssetsPath: path,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(path, &staticHandler{
+		contentType: "text/html; charset=utf-8",
+		body:        buf.Bytes(),
+	})
+
+	for name, contentType := range swaggerUIAssets {
+		body, err := fs.ReadFile(swaggerfiles.FS, name)
+		if err != nil {
+			return nil, err
+		}
+
+		mux.Handle(path+"/"+name, &staticHandler{
+			contentType: contentType,
+			body:        body,
+		})
+	}
+
+	return mux, nil
+}
@@ -0,0 +1,458 @@
+// openapi builds an OpenAPI 3 document from the routes of
+// HTTP handlers.
+//
+// Handlers describe their routes with Route, giving a value
+// of their request and response body types. The schemas of
+// the bodies are derived from the json tags of those types,
+// and the response is wrapped in the standard
+// ResponseEnvelope.
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Version denotes the OpenAPI specification version of the
+// built document.
+const Version = "3.0.3"
+
+var (
+	// ErrDuplicateRoute is returned when two routes have the
+	// same method and path.
+	ErrDuplicateRoute = errors.New("duplicate route")
+
+	// ErrInvalidRoute is returned when a route has no method or
+	// path.
+	ErrInvalidRoute = errors.New("invalid route")
+)
+
+// Followings are the names of the shared components.
+const (
+	componentEnvelope   = "ErrorEnvelope"
+	componentPagination = "Pagination"
+	parameterSource     = "Source"
+	parameterUserID     = "UserID"
+	parameterIfMatch    = "IfMatch"
+	parameterIdempotent = "IdempotencyKey"
+	securityBearer      = "BearerAuth"
+)
+
+// Document denotes an OpenAPI document.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+	Tags       []Tag                `json:"tags,omitempty"`
+}
+
+// Info denotes the metadata of the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server denotes a server serving the API.
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// Tag groups operations, one tag is used per domain.
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// PathItem denotes the operations of a path.
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty"`
+	Put    *Operation `json:"put,omitempty"`
+	Post   *Operation `json:"post,omitempty"`
+	Delete *Operation `json:"delete,omitempty"`
+	Patch  *Operation `json:"patch,omitempty"`
+}
+
+// Operation denotes an API operation on a path.
+type Operation struct {
+	Tags        []string               `json:"tags,omitempty"`
+	Summary     string                 `json:"summary,omitempty"`
+	OperationID string                 `json:"operationId,omitempty"`
+	Parameters  []*Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody           `json:"requestBody,omitempty"`
+	Responses   map[string]*Response   `json:"responses"`
+	Security    *[]map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter denotes a parameter of an operation. Only Ref is
+// set when it refers to a shared parameter.
+type Parameter struct {
+	Ref         string  `json:"$ref,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	In          string  `json:"in,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// RequestBody denotes the request body of an operation.
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response denotes a response of an operation.
+type Response struct {
+	Description string                `json:"description"`
+	Headers     map[string]*Header    `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// Header denotes a header of a response.
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// MediaType denotes the body of a content type.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the shared objects of the document.
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	Parameters      map[string]*Parameter      `json:"parameters,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme denotes an authentication mechanism.
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// Route describes an HTTP route of a handler.
+type Route struct {
+	Method  string
+	Path    string
+	Summary string
+	Tag     string
+
+	// Query lists the accepted query parameters, path
+	// parameters are taken from Path.
+	Query []*Parameter
+
+	// Request is a value of the JSON request body type, nil
+	// if the route has no body.
+	Request interface{}
+
+	// Upload is the form field name of the uploaded file when
+	// the request is a multipart form.
+	Upload string
+
+	// Response is a value of the data type in the response
+	// envelope, nil if the route returns no data.
+	Response interface{}
+
+	// Paginated tells the response envelope has pagination.
+	Paginated bool
+
+	// Files lists the other content types of the response,
+	// negotiated by the Accept header, e.g. a rendered PDF.
+	Files []string
+
+	// Public tells the route requires no access token.
+	Public bool
+
+	// Versioned tells the response has the data version in
+	// ETag header.
+	Versioned bool
+
+	// IfMatch tells the route requires the expected version
+	// in If-Match header.
+	IfMatch bool
+
+	// Idempotent tells the route replays the saved response of
+	// a request sent again with the same Idempotency-Key
+	// header.
+	Idempotent bool
+}
+
+// Query returns a query parameter of the given type, e.g.
+// string, integer or boolean.
+func Query(name, typ, description string) *Parameter {
+	return &Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema:      &Schema{Type: typ},
+	}
+}
+
+// pathParameterPattern matches path parameters of a mux path
+// template, e.g. {id}.
+var pathParameterPattern = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+// New creates a new Document of the given routes.
+func New(info Info, serverURL string, routes []Route) (*Document, error) {
+	d := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]*PathItem),
+		Components: Components{
+			Schemas: make(map[string]*Schema),
+			Parameters: map[string]*Parameter{
+				parameterSource: {
+					Name:        "X-Source",
+					In:          "header",
+					Description: "Client sending the request.",
+					Required:    true,
+					Schema:      &Schema{Type: "string"},
+				},
+				parameterUserID: {
+					Name:        "X-UserID",
+					In:          "header",
+					Description: "ID of the user owning the access token.",
+					Required:    true,
+					Schema:      &Schema{Type: "string"},
+				},
+				parameterIfMatch: {
+					Name:        "If-Match",
+					In:          "header",
+					Description: "Version ETag of the data being updated.",
+					Required:    true,
+					Schema:      &Schema{Type: "string"},
+				},
+				parameterIdempotent: {
+					Name:        "Idempotency-Key",
+					In:          "header",
+					Description: "Unique key of the request, up to 255 characters. A retry with the same key gets the saved response.",
+					Schema:      &Schema{Type: "string"},
+				},
+			},
+			SecuritySchemes: map[string]*SecurityScheme{
+				securityBearer: {
+					Type:   "http",
+					Scheme: "bearer",
+				},
+			},
+		},
+	}
+	if serverURL != "" {
+		d.Servers = []Server{{URL: serverURL}}
+	}
+
+	// the shared pagination is registered by its type name
+	g := newGenerator(d.Components.Schemas)
+	g.schemaOf(paginationType)
+	d.Components.Schemas[componentEnvelope] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"errors": {Type: "array", Items: &Schema{Type: "string"}},
+			"status": {Type: "string"},
+		},
+	}
+
+	tags := make(map[string]bool)
+	for _, route := range routes {
+		err := d.addRoute(g, route)
+		if err != nil {
+			return nil, err
+		}
+
+		if route.Tag != "" && !tags[route.Tag] {
+			tags[route.Tag] = true
+			d.Tags = append(d.Tags, Tag{Name: route.Tag})
+		}
+	}
+	sort.Slice(d.Tags, func(i, j int) bool { return d.Tags[i].Name < d.Tags[j].Name })
+
+	return d, nil
+}
+
+// HasOperation returns true if the document has an operation
+// of the given method on the given mux path template.
+func (d *Document) HasOperation(method, path string) bool {
+	item, ok := d.Paths[formatPath(path)]
+	if !ok {
+		return false
+	}
+
+	target := item.operation(method)
+	return target != nil && *target != nil
+}
+
+// operation returns the operation of the given method, nil if
+// the method is not supported.
+func (p *PathItem) operation(method string) **Operation {
+	switch method {
+	case http.MethodGet:
+		return &p.Get
+	case http.MethodPut:
+		return &p.Put
+	case http.MethodPost:
+		return &p.Post
+	case http.MethodDelete:
+		return &p.Delete
+	case http.MethodPatch:
+		return &p.Patch
+	default:
+		return nil
+	}
+}
+
+func (d *Document) addRoute(g *generator, route Route) error {
+	if route.Method == "" || route.Path == "" {
+		return ErrInvalidRoute
+	}
+
+	path := formatPath(route.Path)
+	item, ok := d.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		d.Paths[path] = item
+	}
+
+	target := item.operation(route.Method)
+	if target == nil {
+		return ErrInvalidRoute
+	}
+	if *target != nil {
+		return fmt.Errorf("%w: %s %s", ErrDuplicateRoute, route.Method, route.Path)
+	}
+
+	op := &Operation{
+		Summary:     route.Summary,
+		OperationID: operationID(route.Summary),
+		Responses:   make(map[string]*Response),
+	}
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+
+	// parameters
+	for _, match := range pathParameterPattern.FindAllStringSubmatch(route.Path, -1) {
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+	op.Parameters = append(op.Parameters, route.Query...)
+	op.Parameters = append(op.Parameters, refParameter(parameterSource))
+	if route.Public {
+		op.Security = &[]map[string][]string{}
+	} else {
+		op.Parameters = append(op.Parameters, refParameter(parameterUserID))
+		op.Security = &[]map[string][]string{{securityBearer: {}}}
+	}
+	if route.IfMatch {
+		op.Parameters = append(op.Parameters, refParameter(parameterIfMatch))
+	}
+	if route.Idempotent {
+		op.Parameters = append(op.Parameters, refParameter(parameterIdempotent))
+	}
+
+	// request
+	if route.Request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]*MediaType{
+				"application/json": {Schema: g.schemaOfValue(route.Request)},
+			},
+		}
+	}
+	if route.Upload != "" {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]*MediaType{
+				"multipart/form-data": {Schema: &Schema{
+					Type:     "object",
+					Required: []string{route.Upload},
+					Properties: map[string]*Schema{
+						route.Upload: {Type: "string", Format: "binary"},
+					},
+				}},
+			},
+		}
+	}
+
+	// responses
+	envelope := &Schema{
+		Type:       "object",
+		Properties: map[string]*Schema{},
+	}
+	if route.Response != nil {
+		envelope.Properties["data"] = g.schemaOfValue(route.Response)
+	}
+	if route.Paginated {
+		envelope.Properties["pagination"] = refSchema(componentPagination)
+	}
+
+	success := &Response{
+		Description: http.StatusText(http.StatusOK),
+		Content: map[string]*MediaType{
+			"application/json": {Schema: envelope},
+		},
+	}
+	if route.Versioned || route.IfMatch {
+		success.Headers = map[string]*Header{
+			"ETag": {
+				Description: "Version of the returned data.",
+				Schema:      &Schema{Type: "string"},
+			},
+		}
+	}
+	for _, contentType := range route.Files {
+		success.Content[contentType] = &MediaType{
+			Schema: &Schema{Type: "string", Format: "binary"},
+		}
+	}
+	op.Responses["200"] = success
+	op.Responses["default"] = &Response{
+		Description: "Error",
+		Content: map[string]*MediaType{
+			"application/json": {Schema: refSchema(componentEnvelope)},
+		},
+	}
+
+	*target = op
+	return nil
+}
+
+// formatPath converts the given mux path template into an
+// OpenAPI path, the regular expressions of path parameters
+// are removed.
+func formatPath(path string) string {
+	return pathParameterPattern.ReplaceAllString(path, "{$1}")
+}
+
+// operationID converts the given summary into a camel cased
+// operation ID, e.g. "Get content by ID" into getContentByID.
+func operationID(summary string) string {
+	var b strings.Builder
+	for i, word := range strings.Fields(summary) {
+		if i == 0 {
+			b.WriteString(strings.ToLower(word[:1]) + word[1:])
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+func refParameter(name string) *Parameter {
+	return &Parameter{Ref: "#/components/parameters/" + name}
+}
+
+func refSchema(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
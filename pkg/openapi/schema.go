@@ -0,0 +1,203 @@
+package openapi
+
+import (
+	"encoding/json"
+	httplib "hbdtoyou/pkg/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Schema denotes a schema of a request or response body.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+var (
+	paginationType = reflect.TypeOf(httplib.Pagination{})
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// generator derives schemas from Go types. Named structs are
+// registered as components and referred to by their name.
+type generator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newGenerator(schemas map[string]*Schema) *generator {
+	return &generator{
+		schemas: schemas,
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// schemaOfValue returns the schema of the type of the given
+// value.
+func (g *generator) schemaOfValue(v interface{}) *Schema {
+	return g.schemaOf(reflect.TypeOf(v))
+}
+
+// schemaOf returns the schema of the given type following
+// encoding/json rules.
+func (g *generator) schemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		// any JSON value
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		// []byte is encoded as a base64 string
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaOf(t.Elem())}
+	case reflect.Struct:
+		return g.structSchema(t)
+	}
+
+	// interface or other types, any JSON value
+	return &Schema{}
+}
+
+// structSchema returns a reference to the component of the
+// given struct, the component is registered on the first
+// call. Anonymous structs are returned inline.
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return g.objectSchema(t)
+	}
+
+	if name, ok := g.names[t]; ok {
+		return refSchema(name)
+	}
+
+	name := componentName(t)
+	if _, ok := g.schemas[name]; ok {
+		// same name in other package, e.g. memberHTTP of
+		// content and school
+		name = capitalize(domainName(t)) + name
+	}
+	g.names[t] = name
+
+	// registered before its fields, so recursive types refer
+	// to it
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *g.objectSchema(t)
+
+	return refSchema(name)
+}
+
+// objectSchema returns the object schema of the exported
+// fields of the given struct.
+func (g *generator) objectSchema(t reflect.Type) *Schema {
+	s := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		// fields of embedded structs are promoted
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded := g.objectSchema(ft)
+				for k, v := range embedded.Properties {
+					if _, ok := s.Properties[k]; !ok {
+						s.Properties[k] = v
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		s.Properties[name] = g.schemaOf(field.Type)
+	}
+
+	return s
+}
+
+// componentName returns the component name of the given
+// named type, e.g. contentHTTP is named Content.
+func componentName(t reflect.Type) string {
+	name := strings.TrimSuffix(t.Name(), "HTTP")
+	if name == "" {
+		name = t.Name()
+	}
+	return capitalize(name)
+}
+
+// capitalize returns the given name with its first letter in
+// upper case.
+func capitalize(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// domainName returns the name of the domain owning the given
+// type. Handler packages are all named http, the domain is
+// their parent in internal, e.g. hbdtoyou/internal/content/
+// handler/http is content.
+func domainName(t reflect.Type) string {
+	parts := strings.Split(t.PkgPath(), "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		switch parts[i] {
+		case "http", "handler":
+			continue
+		}
+		return parts[i]
+	}
+	return ""
+}
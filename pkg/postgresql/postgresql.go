@@ -3,6 +3,7 @@ package postgresql
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -88,6 +89,17 @@ func (cm *ClientManager) GetDatabase(clientName string) (*sqlx.DB, error) {
 	return cli.db, nil
 }
 
+// GetClientNames returns the names of all clients, sorted.
+func (cm *ClientManager) GetClientNames() []string {
+	names := make([]string, 0, len(cm.clients))
+	for name := range cm.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
 // newClient creates client with the given client config.
 func newClient(cfg ClientConfig) (client, error) {
 	var cli client
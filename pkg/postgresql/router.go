@@ -0,0 +1,63 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+
+	contextlib "hbdtoyou/pkg/context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// errUnknownTenant is returned when the tenant in a context
+// has no client.
+var errUnknownTenant = errors.New("postgresql: unknown tenant")
+
+// Router routes a context to the database of its tenant.
+//
+// The tenant is the client name stored in the context through
+// contextlib.SetTenant(). A context without a tenant is routed
+// to the default client.
+type Router struct {
+	cm            *ClientManager
+	defaultClient string
+}
+
+// NewRouter creates a new Router routing contexts to the
+// clients of the given ClientManager.
+func NewRouter(cm *ClientManager, defaultClient string) (*Router, error) {
+	if _, ok := cm.clients[defaultClient]; !ok {
+		return nil, errClientNotInitialized
+	}
+
+	return &Router{
+		cm:            cm,
+		defaultClient: defaultClient,
+	}, nil
+}
+
+// GetDatabase returns the database of the tenant in the given
+// context.
+func (r *Router) GetDatabase(ctx context.Context) (*sqlx.DB, error) {
+	tenant, ok := contextlib.GetTenant(ctx)
+	if !ok || tenant == "" {
+		tenant = r.defaultClient
+	}
+
+	db, err := r.cm.GetDatabase(tenant)
+	if err == errClientNotInitialized {
+		return nil, errUnknownTenant
+	}
+
+	return db, err
+}
+
+// GetTenants returns the names of all clients, sorted.
+func (r *Router) GetTenants() []string {
+	return r.cm.GetClientNames()
+}
+
+// GetDefaultTenant returns the name of the default client.
+func (r *Router) GetDefaultTenant() string {
+	return r.defaultClient
+}
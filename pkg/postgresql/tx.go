@@ -0,0 +1,27 @@
+package postgresql
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// txKey is the context key of a shared transaction.
+type txKey struct{}
+
+// SetTx returns a new Context that carries the given
+// transaction, so stores of other services given the context
+// query within the transaction instead of starting their own.
+//
+// The transaction is still committed or rolled back by its
+// owner only.
+func SetTx(ctx context.Context, tx *sqlx.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// GetTx returns the transaction stored in the given context,
+// if any.
+func GetTx(ctx context.Context) (*sqlx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sqlx.Tx)
+	return tx, ok && tx != nil
+}
@@ -0,0 +1,142 @@
+// ratelimit provides token bucket rate limiting.
+//
+// A bucket holds up to Burst tokens and is refilled at the rate
+// of Limit tokens per Period. Every request takes a token, it
+// is denied if the bucket is empty. The buckets are kept in a
+// Store, shared by all instances if the store is.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrInvalidLimit is returned when the given limit is invalid.
+var ErrInvalidLimit = errors.New("invalid rate limit")
+
+// Limit denotes the rate limit of a bucket. The zero value
+// does not limit.
+type Limit struct {
+	// Limit is the number of requests allowed per Period.
+	Limit  int
+	Period time.Duration
+
+	// Burst is the maximum number of requests allowed at once,
+	// it defaults to Limit.
+	Burst int
+}
+
+// Validate returns ErrInvalidLimit if the limit is invalid.
+func (l Limit) Validate() error {
+	if l.Limit < 0 || l.Period < 0 || l.Burst < 0 {
+		return ErrInvalidLimit
+	}
+
+	if l.Limit > 0 && l.Period == 0 {
+		return ErrInvalidLimit
+	}
+
+	return nil
+}
+
+// Enabled returns true if the limit limits requests.
+func (l Limit) Enabled() bool {
+	return l.Limit > 0 && l.Period > 0
+}
+
+// Capacity returns the maximum number of tokens of a bucket.
+func (l Limit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Limit
+}
+
+// Rate returns the number of tokens refilled per second.
+func (l Limit) Rate() float64 {
+	return float64(l.Limit) / l.Period.Seconds()
+}
+
+// Result denotes the result of taking a token.
+type Result struct {
+	Allowed bool
+
+	// Limit is the capacity of the bucket and Remaining is the
+	// number of tokens left in it.
+	Limit     int
+	Remaining int
+
+	// RetryAfter is the time until a token is available, it is
+	// zero if the request is allowed.
+	RetryAfter time.Duration
+
+	// ResetAfter is the time until the bucket is full.
+	ResetAfter time.Duration
+}
+
+// NewResult returns the result of a take of the given limit,
+// leaving the given tokens in the bucket.
+func NewResult(limit Limit, allowed bool, tokens float64) Result {
+	rate := limit.Rate()
+	res := Result{
+		Allowed:    allowed,
+		Limit:      limit.Capacity(),
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: secondsToDuration((float64(limit.Capacity()) - tokens) / rate),
+	}
+
+	if !allowed {
+		res.RetryAfter = secondsToDuration((1 - tokens) / rate)
+	}
+
+	return res
+}
+
+// Bucket denotes the state of a token bucket.
+type Bucket struct {
+	Tokens     float64
+	UpdateTime time.Time
+}
+
+// NewBucket returns a full bucket of the given limit.
+func NewBucket(limit Limit, now time.Time) Bucket {
+	return Bucket{
+		Tokens:     float64(limit.Capacity()),
+		UpdateTime: now,
+	}
+}
+
+// Take refills the bucket up to the given time and takes a
+// token from it if there is one. The updated bucket is
+// returned along with the result.
+func (b Bucket) Take(limit Limit, now time.Time) (Bucket, Result) {
+	elapsed := now.Sub(b.UpdateTime).Seconds()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	tokens := math.Min(float64(limit.Capacity()), b.Tokens+elapsed*limit.Rate())
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+
+	return Bucket{Tokens: tokens, UpdateTime: now}, NewResult(limit, allowed, tokens)
+}
+
+// Store keeps the token buckets.
+type Store interface {
+	// Take takes a token from the bucket of the given key,
+	// refilled at the rate of the given limit. A bucket not
+	// yet known is full.
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
@@ -0,0 +1,117 @@
+// memory provides a ratelimit.Store keeping the buckets in
+// memory. The buckets are kept per process, each instance
+// limits requests on its own.
+package memory
+
+import (
+	"context"
+	"hbdtoyou/pkg/ratelimit"
+	"sync"
+	"time"
+)
+
+// defaultPurgeInterval is the default interval of removing
+// the full buckets.
+const defaultPurgeInterval = time.Minute
+
+// Store implements ratelimit.Store.
+type Store struct {
+	mu      sync.Mutex
+	buckets map[string]entry
+
+	purgeInterval time.Duration
+	done          chan struct{}
+	closeOnce     sync.Once
+	timeNow       func() time.Time
+}
+
+type entry struct {
+	bucket ratelimit.Bucket
+
+	// fullTime is the time the bucket is full again, it is
+	// removed afterward as a new bucket is full as well.
+	fullTime time.Time
+}
+
+// Option controls the behavior of Store.
+type Option func(*Store)
+
+// WithPurgeInterval returns Option to set the interval of
+// removing the full buckets.
+func WithPurgeInterval(interval time.Duration) Option {
+	return Option(func(s *Store) {
+		if interval > 0 {
+			s.purgeInterval = interval
+		}
+	})
+}
+
+// New creates a new Store. It removes the full buckets in
+// background until it is closed.
+func New(options ...Option) *Store {
+	s := &Store{
+		buckets:       make(map[string]entry),
+		purgeInterval: defaultPurgeInterval,
+		done:          make(chan struct{}),
+		timeNow:       time.Now,
+	}
+
+	// apply options
+	for _, opt := range options {
+		opt(s)
+	}
+
+	go s.purge()
+	return s
+}
+
+// Take implements ratelimit.Store.
+func (s *Store) Take(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.timeNow()
+	e, ok := s.buckets[key]
+	if !ok {
+		e.bucket = ratelimit.NewBucket(limit, now)
+	}
+
+	bucket, res := e.bucket.Take(limit, now)
+	s.buckets[key] = entry{
+		bucket:   bucket,
+		fullTime: now.Add(res.ResetAfter),
+	}
+
+	return res, nil
+}
+
+// Close stops removing the full buckets.
+func (s *Store) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+	return nil
+}
+
+// purge removes the full buckets periodically.
+func (s *Store) purge() {
+	ticker := time.NewTicker(s.purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+
+		s.mu.Lock()
+		now := s.timeNow()
+		for key, e := range s.buckets {
+			if !now.Before(e.fullTime) {
+				delete(s.buckets, key)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
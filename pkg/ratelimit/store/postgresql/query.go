@@ -0,0 +1,46 @@
+package postgresql
+
+const (
+	// queryTakeToken refills the bucket and takes a token from
+	// it in a single statement, so the concurrent requests of
+	// all instances are counted. The clock of the database is
+	// used, the clocks of the instances might differ.
+	queryTakeToken = `
+		INSERT INTO
+			rate_limit_bucket AS b
+			(
+				key,
+				tokens,
+				allowed,
+				update_time,
+				expire_time
+			)
+		VALUES
+			(
+				$1,
+				CAST($2 AS DOUBLE PRECISION) - 1,
+				TRUE,
+				now(),
+				now() + CAST($4 AS DOUBLE PRECISION) * INTERVAL '1 second'
+			)
+		ON CONFLICT (key) DO UPDATE SET
+			tokens = CASE
+				WHEN LEAST(CAST($2 AS DOUBLE PRECISION), b.tokens + GREATEST(0, EXTRACT(EPOCH FROM now() - b.update_time)) * CAST($3 AS DOUBLE PRECISION)) >= 1
+				THEN LEAST(CAST($2 AS DOUBLE PRECISION), b.tokens + GREATEST(0, EXTRACT(EPOCH FROM now() - b.update_time)) * CAST($3 AS DOUBLE PRECISION)) - 1
+				ELSE LEAST(CAST($2 AS DOUBLE PRECISION), b.tokens + GREATEST(0, EXTRACT(EPOCH FROM now() - b.update_time)) * CAST($3 AS DOUBLE PRECISION))
+			END,
+			allowed = LEAST(CAST($2 AS DOUBLE PRECISION), b.tokens + GREATEST(0, EXTRACT(EPOCH FROM now() - b.update_time)) * CAST($3 AS DOUBLE PRECISION)) >= 1,
+			update_time = now(),
+			expire_time = now() + CAST($4 AS DOUBLE PRECISION) * INTERVAL '1 second'
+		RETURNING
+			tokens,
+			allowed
+	`
+
+	queryDeleteExpiredBuckets = `
+		DELETE FROM
+			rate_limit_bucket
+		WHERE
+			expire_time < now()
+	`
+)
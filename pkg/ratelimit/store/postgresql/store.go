@@ -0,0 +1,114 @@
+// postgresql provides a ratelimit.Store keeping the buckets in
+// PostgreSQL, so the limits are shared by all instances of the
+// service.
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"hbdtoyou/pkg/ratelimit"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// defaultPurgeInterval is the default interval of deleting
+// the expired buckets.
+const defaultPurgeInterval = 5 * time.Minute
+
+// errInvalidDB is returned when the given database is nil.
+var errInvalidDB = errors.New("ratelimit: invalid database")
+
+// Store implements ratelimit.Store.
+type Store struct {
+	db *sqlx.DB
+
+	purgeInterval time.Duration
+	done          chan struct{}
+	closeOnce     sync.Once
+}
+
+// Option controls the behavior of Store.
+type Option func(*Store)
+
+// WithPurgeInterval returns Option to set the interval of
+// deleting the expired buckets.
+func WithPurgeInterval(interval time.Duration) Option {
+	return Option(func(s *Store) {
+		if interval > 0 {
+			s.purgeInterval = interval
+		}
+	})
+}
+
+// New creates a new Store of the buckets in the given
+// database. It deletes the expired buckets in background
+// until it is closed.
+func New(db *sqlx.DB, options ...Option) (*Store, error) {
+	if db == nil {
+		return nil, errInvalidDB
+	}
+
+	s := &Store{
+		db:            db,
+		purgeInterval: defaultPurgeInterval,
+		done:          make(chan struct{}),
+	}
+
+	// apply options
+	for _, opt := range options {
+		opt(s)
+	}
+
+	go s.purge()
+	return s, nil
+}
+
+// Take implements ratelimit.Store.
+func (s *Store) Take(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	capacity := float64(limit.Capacity())
+	rate := limit.Rate()
+
+	// a bucket is full again, hence not needed, after this long
+	ttl := capacity / rate
+
+	var row struct {
+		Tokens  float64 `db:"tokens"`
+		Allowed bool    `db:"allowed"`
+	}
+	err := s.db.QueryRowxContext(ctx, queryTakeToken, key, capacity, rate, ttl).StructScan(&row)
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	return ratelimit.NewResult(limit, row.Allowed, row.Tokens), nil
+}
+
+// Close stops deleting the expired buckets.
+func (s *Store) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+	return nil
+}
+
+// purge deletes the expired buckets periodically.
+func (s *Store) purge() {
+	ticker := time.NewTicker(s.purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+
+		_, err := s.db.Exec(queryDeleteExpiredBuckets)
+		if err != nil {
+			log.Printf("[ratelimit] failed to delete expired buckets: %s\n", err.Error())
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// localfile defines storage client that stores objects in
+// local file system.
+//
+// Since local files are not reachable from outside the host,
+// the client also implements http.Handler to serve the
+// objects through signed URLs generated by SignedURL().
+package localfile
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"hbdtoyou/pkg/storage"
+)
+
+var (
+	errInvalidRootDir    = errors.New("localfile: invalid root directory")
+	errInvalidBaseURL    = errors.New("localfile: invalid base url")
+	errInvalidSigningKey = errors.New("localfile: invalid signing key")
+)
+
+// Followings are the query parameters of a signed URL.
+const (
+	queryExpires   = "expires"
+	querySignature = "signature"
+)
+
+// client implements storage.Client.
+type client struct {
+	rootDir    string
+	baseURL    string
+	signingKey []byte
+	timeNow    func() time.Time
+}
+
+// Config contains the available configuration for a client.
+type Config struct {
+	// RootDir is the directory in which objects are stored.
+	RootDir string
+
+	// BaseURL is the URL in which the client is served as
+	// HTTP handler. It is used as signed URL prefix.
+	BaseURL string
+
+	// SigningKey is the secret key used to sign URLs.
+	SigningKey string
+}
+
+// New returns a new client.
+func New(cfg Config) (*client, error) {
+	if cfg.RootDir == "" {
+		return nil, errInvalidRootDir
+	}
+
+	if _, err := url.Parse(cfg.BaseURL); err != nil || cfg.BaseURL == "" {
+		return nil, errInvalidBaseURL
+	}
+
+	if cfg.SigningKey == "" {
+		return nil, errInvalidSigningKey
+	}
+
+	err := os.MkdirAll(cfg.RootDir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &client{
+		rootDir:    cfg.RootDir,
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		signingKey: []byte(cfg.SigningKey),
+		timeNow:    time.Now,
+	}, nil
+}
+
+// Put stores the object read from r under the given key.
+func (c *client) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	path, err := c.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	// write into a temporary file first, so readers never see
+	// a partially written object
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, r)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get returns the object stored under the given key.
+func (c *client) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := c.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, storage.ErrObjectNotFound
+		}
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Delete deletes the object stored under the given key.
+func (c *client) Delete(ctx context.Context, key string) error {
+	path, err := c.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// SignedURL returns a URL that grants temporary read access
+// to the object stored under the given key.
+func (c *client) SignedURL(ctx context.Context, key string, expiration time.Duration) (string, error) {
+	if _, err := c.path(key); err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(c.timeNow().Add(expiration).Unix(), 10)
+
+	query := url.Values{}
+	query.Set(queryExpires, expires)
+	query.Set(querySignature, c.sign(key, expires))
+
+	return c.baseURL + "/" + key + "?" + query.Encode(), nil
+}
+
+// ServeHTTP serves the object requested through a signed URL.
+//
+// The handler expects the object key as the remaining path
+// after the mount point, so it should be mounted using
+// http.StripPrefix() or equivalent.
+func (c *client) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	expires := r.URL.Query().Get(queryExpires)
+	signature := r.URL.Query().Get(querySignature)
+
+	// verify signature and expiration
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || c.timeNow().Unix() > unix || !hmac.Equal([]byte(signature), []byte(c.sign(key, expires))) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	path, err := c.path(key)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	http.ServeFile(w, r, path)
+}
+
+// sign returns the signature of the given key and expiration.
+func (c *client) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, c.signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// path returns the file path of the given key. It makes sure
+// the resulting path does not escape the root directory.
+func (c *client) path(key string) (string, error) {
+	if key == "" || strings.Contains(key, "..") || strings.HasPrefix(key, "/") {
+		return "", storage.ErrInvalidKey
+	}
+
+	return filepath.Join(c.rootDir, filepath.FromSlash(key)), nil
+}
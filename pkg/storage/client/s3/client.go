@@ -0,0 +1,128 @@
+// s3 defines storage client that stores objects in an
+// S3-compatible object storage, e.g. AWS S3, Google Cloud
+// Storage interoperability API, or MinIO.
+package s3
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"hbdtoyou/pkg/storage"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+var (
+	errInvalidEndpoint = errors.New("s3: invalid endpoint")
+	errInvalidBucket   = errors.New("s3: invalid bucket")
+)
+
+// client implements storage.Client.
+type client struct {
+	mc     *minio.Client
+	bucket string
+}
+
+// Config contains the available configuration for a client.
+type Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	UseSSL          bool
+}
+
+// New returns a new client.
+func New(cfg Config) (*client, error) {
+	if cfg.Endpoint == "" {
+		return nil, errInvalidEndpoint
+	}
+
+	if cfg.Bucket == "" {
+		return nil, errInvalidBucket
+	}
+
+	mc, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &client{
+		mc:     mc,
+		bucket: cfg.Bucket,
+	}, nil
+}
+
+// Put stores the object read from r under the given key.
+func (c *client) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if key == "" {
+		return storage.ErrInvalidKey
+	}
+
+	_, err := c.mc.PutObject(ctx, c.bucket, key, r, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	return err
+}
+
+// Get returns the object stored under the given key.
+func (c *client) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if key == "" {
+		return nil, storage.ErrInvalidKey
+	}
+
+	obj, err := c.mc.GetObject(ctx, c.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, parseError(err)
+	}
+
+	// GetObject is lazy, stat the object to find out whether
+	// it exists
+	_, err = obj.Stat()
+	if err != nil {
+		obj.Close()
+		return nil, parseError(err)
+	}
+
+	return obj, nil
+}
+
+// Delete deletes the object stored under the given key.
+func (c *client) Delete(ctx context.Context, key string) error {
+	if key == "" {
+		return storage.ErrInvalidKey
+	}
+
+	return c.mc.RemoveObject(ctx, c.bucket, key, minio.RemoveObjectOptions{})
+}
+
+// SignedURL returns a presigned URL that grants temporary
+// read access to the object stored under the given key.
+func (c *client) SignedURL(ctx context.Context, key string, expiration time.Duration) (string, error) {
+	if key == "" {
+		return "", storage.ErrInvalidKey
+	}
+
+	u, err := c.mc.PresignedGetObject(ctx, c.bucket, key, expiration, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return u.String(), nil
+}
+
+// parseError maps S3 errors into storage errors.
+func parseError(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return storage.ErrObjectNotFound
+	}
+	return err
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// Followings are the known errors returned from storage
+// clients.
+var (
+	// ErrObjectNotFound is returned when there is no object
+	// stored with the given key.
+	ErrObjectNotFound = errors.New("storage: object not found")
+
+	// ErrInvalidKey is returned when the given object key is
+	// invalid.
+	ErrInvalidKey = errors.New("storage: invalid key")
+)
+
+// Client is a client that stores objects identified by a key
+// in an object storage.
+//
+// All clients that implement this interface should handle
+// authentication and authorization on their own. For example
+// in the initialization function.
+type Client interface {
+	// Put stores the object read from r under the given key.
+	// size is the object size in bytes, or -1 if unknown.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+
+	// Get returns the object stored under the given key. The
+	// caller must close the returned reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete deletes the object stored under the given key.
+	Delete(ctx context.Context, key string) error
+
+	// SignedURL returns a URL that grants temporary read
+	// access to the object stored under the given key.
+	SignedURL(ctx context.Context, key string, expiration time.Duration) (string, error)
+}
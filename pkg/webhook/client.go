@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Followings are default values for client configuration.
+const (
+	defaultTimeout = 10 * time.Second
+
+	// maxResponseBody is the maximum size of the response
+	// body kept in Response.
+	maxResponseBody = 1024
+)
+
+var errInvalidURL = errors.New("webhook: invalid url")
+
+// Request denotes a webhook request to send.
+type Request struct {
+	URL    string
+	Secret string
+
+	// ID and Event are sent in HeaderID and HeaderEvent.
+	ID    string
+	Event string
+
+	// Body is the JSON body sent as is.
+	Body []byte
+}
+
+// Response denotes the response of a sent webhook request.
+type Response struct {
+	StatusCode int
+
+	// Body is the beginning of the response body, for
+	// troubleshooting failed deliveries.
+	Body string
+}
+
+// IsSuccess returns whether the receiver accepted the request,
+// i.e. responded with a 2xx status code.
+func (r Response) IsSuccess() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Client sends webhook requests.
+type Client struct {
+	httpClient *http.Client
+	timeNow    func() time.Time
+}
+
+// NewClient returns a new client. timeout limits the duration
+// of a request, the default is used if it is zero.
+func NewClient(timeout time.Duration) *Client {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &Client{
+		httpClient: &http.Client{
+			Timeout: timeout,
+			// a redirect could send the payload to an
+			// unintended receiver
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		timeNow: time.Now,
+	}
+}
+
+// Send signs and sends the given request. An error is only
+// returned if no response is received, a response with any
+// status code is returned as is.
+func (c *Client) Send(ctx context.Context, req Request) (Response, error) {
+	if req.URL == "" {
+		return Response{}, errInvalidURL
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return Response{}, err
+	}
+
+	now := c.timeNow()
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(HeaderID, req.ID)
+	httpReq.Header.Set(HeaderEvent, req.Event)
+	httpReq.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, now, req.Body))
+
+	httpRes, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return Response{}, err
+	}
+	defer httpRes.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(httpRes.Body, maxResponseBody))
+
+	// drain the rest so the connection can be reused
+	io.Copy(io.Discard, httpRes.Body)
+
+	return Response{
+		StatusCode: httpRes.StatusCode,
+		Body:       string(body),
+	}, nil
+}
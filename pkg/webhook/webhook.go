@@ -0,0 +1,88 @@
+// webhook provides signing and sending of outgoing webhooks.
+//
+// A webhook request is a JSON POST request signed with the
+// secret shared with the receiver. The signature is the hex
+// encoded HMAC-SHA256 of the timestamp and the body joined by a
+// dot, sent as "v1=<signature>" in HeaderSignature. Receivers
+// should reject requests with an old timestamp to prevent
+// replays.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Followings are the headers of webhook requests.
+const (
+	// HeaderID is the ID of the delivered event, it is kept
+	// on retries so receivers can ignore duplicates.
+	HeaderID = "Webhook-ID"
+
+	// HeaderEvent is the type of the delivered event.
+	HeaderEvent = "Webhook-Event"
+
+	// HeaderTimestamp is the Unix time the request is sent.
+	HeaderTimestamp = "Webhook-Timestamp"
+
+	// HeaderSignature is the signature of the request.
+	HeaderSignature = "Webhook-Signature"
+)
+
+// signatureVersion prefixes the signature, so the scheme can
+// be changed without breaking receivers.
+const signatureVersion = "v1"
+
+// Followings are the known errors from webhook.
+var (
+	// ErrInvalidSignature is returned when the signature does
+	// not match the request.
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+
+	// ErrExpiredTimestamp is returned when the request is
+	// older than the tolerance.
+	ErrExpiredTimestamp = errors.New("webhook: expired timestamp")
+)
+
+// Sign returns the signature of the given body sent at the
+// given time, to be sent in HeaderSignature.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify verifies the given signature and timestamp header
+// values of a received body. Requests sent earlier than the
+// given tolerance before now are rejected.
+func Verify(secret, signature, timestamp string, body []byte, tolerance time.Duration, now time.Time) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	sentTime := time.Unix(unix, 0)
+	if now.Sub(sentTime) > tolerance {
+		return ErrExpiredTimestamp
+	}
+
+	expected := Sign(secret, sentTime, body)
+
+	// the header may hold multiple signatures while the
+	// secret is being rotated
+	for _, sig := range strings.Split(signature, ",") {
+		if hmac.Equal([]byte(strings.TrimSpace(sig)), []byte(expected)) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientSendSignature(t *testing.T) {
+	const secret = "0123456789abcdef"
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	body := []byte(`{"id":"event-1"}`)
+
+	var got *http.Request
+	var gotBody []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	client := NewClient(time.Second)
+	client.timeNow = func() time.Time { return now }
+
+	res, err := client.Send(context.Background(), Request{
+		URL:    receiver.URL,
+		Secret: secret,
+		ID:     "event-1",
+		Event:  "payment.approved",
+		Body:   body,
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if !res.IsSuccess() {
+		t.Fatalf("Send() status = %d, want 2xx", res.StatusCode)
+	}
+
+	if got.Header.Get(HeaderID) != "event-1" {
+		t.Errorf("%s = %q, want %q", HeaderID, got.Header.Get(HeaderID), "event-1")
+	}
+	if got.Header.Get(HeaderEvent) != "payment.approved" {
+		t.Errorf("%s = %q, want %q", HeaderEvent, got.Header.Get(HeaderEvent), "payment.approved")
+	}
+
+	signature := got.Header.Get(HeaderSignature)
+	timestamp := got.Header.Get(HeaderTimestamp)
+	if signature != Sign(secret, now, body) {
+		t.Errorf("%s = %q, want %q", HeaderSignature, signature, Sign(secret, now, body))
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		body      []byte
+		now       time.Time
+		want      error
+	}{
+		{"valid", secret, signature, gotBody, now.Add(time.Minute), nil},
+		{"rotated secret", secret, "v1=00, " + signature, gotBody, now, nil},
+		{"wrong secret", "fedcba9876543210", signature, gotBody, now, ErrInvalidSignature},
+		{"tampered body", secret, signature, []byte(`{"id":"event-2"}`), now, ErrInvalidSignature},
+		{"expired timestamp", secret, signature, gotBody, now.Add(10 * time.Minute), ErrExpiredTimestamp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.signature, timestamp, tt.body, 5*time.Minute, tt.now)
+			if err != tt.want {
+				t.Errorf("Verify() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientSendFailure(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("unavailable"))
+	}))
+	defer receiver.Close()
+
+	res, err := NewClient(time.Second).Send(context.Background(), Request{
+		URL:    receiver.URL,
+		Secret: "0123456789abcdef",
+		Body:   []byte(`{}`),
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if res.IsSuccess() || res.StatusCode != http.StatusInternalServerError || res.Body != "unavailable" {
+		t.Errorf("Send() = %+v, want failed response with body", res)
+	}
+
+	// a redirect is not followed
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, receiver.URL, http.StatusFound)
+	}))
+	defer redirect.Close()
+
+	res, err = NewClient(time.Second).Send(context.Background(), Request{URL: redirect.URL})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if res.StatusCode != http.StatusFound {
+		t.Errorf("Send() status = %d, want %d", res.StatusCode, http.StatusFound)
+	}
+}
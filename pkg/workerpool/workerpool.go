@@ -0,0 +1,108 @@
+// workerpool provides a bounded pool of goroutines to run
+// jobs in the background.
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+)
+
+// Followings are the known errors from workerpool.
+var (
+	// ErrPoolClosed is returned when submitting a job into a
+	// closed pool.
+	ErrPoolClosed = errors.New("workerpool: pool closed")
+
+	// ErrInvalidSize is returned when the given pool size or
+	// queue size is invalid.
+	ErrInvalidSize = errors.New("workerpool: invalid size")
+)
+
+// Job is a unit of work run by the pool.
+type Job func()
+
+// Pool is a bounded pool of workers.
+//
+// Pool runs at most Size jobs concurrently and queues at most
+// QueueSize pending jobs. Submitting a job into a full queue
+// blocks until there is a free slot or the given context is
+// done.
+type Pool struct {
+	jobs   chan Job
+	wg     sync.WaitGroup
+	mu     sync.RWMutex
+	closed bool
+}
+
+// New creates and starts a new Pool.
+func New(size, queueSize int) (*Pool, error) {
+	if size <= 0 || queueSize < 0 {
+		return nil, ErrInvalidSize
+	}
+
+	p := &Pool{
+		jobs: make(chan Job, queueSize),
+	}
+
+	p.wg.Add(size)
+	for i := 0; i < size; i++ {
+		go p.work()
+	}
+
+	return p, nil
+}
+
+// Submit queues the given job to be run by a worker.
+func (p *Pool) Submit(ctx context.Context, job Job) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	select {
+	case p.jobs <- job:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting new jobs and waits until all queued
+// jobs are finished.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.jobs)
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
+
+// work runs queued jobs until the pool is closed.
+func (p *Pool) work() {
+	defer p.wg.Done()
+
+	for job := range p.jobs {
+		run(job)
+	}
+}
+
+// run runs the given job and recovers from panic, so a bad
+// job does not kill the worker.
+func run(job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("workerpool: job panic recovered: %v\n", r)
+		}
+	}()
+
+	job()
+}